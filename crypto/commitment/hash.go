@@ -36,20 +36,31 @@ type HashCommitmenter struct {
 }
 
 func NewProtoHashCommitmenter(msg proto.Message) (*HashCommitmenter, error) {
+	return NewProtoHashCommitmenterWithSession(nil, msg)
+}
+
+// NewProtoHashCommitmenterWithSession returns a hash commitmenter of the message which is bound to the session id.
+func NewProtoHashCommitmenterWithSession(sessionID []byte, msg proto.Message) (*HashCommitmenter, error) {
 	agMsgBs, err := proto.Marshal(msg)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewHashCommitmenter(agMsgBs)
+	return NewHashCommitmenterWithSession(sessionID, agMsgBs)
 }
 
 func NewHashCommitmenter(data []byte) (*HashCommitmenter, error) {
+	return NewHashCommitmenterWithSession(nil, data)
+}
+
+// NewHashCommitmenterWithSession returns a hash commitmenter of the data which is bound to the session id.
+// The commitment can only be decommitted with the same session id.
+func NewHashCommitmenterWithSession(sessionID []byte, data []byte) (*HashCommitmenter, error) {
 	salt, err := utils.GenRandomBytes(utils.SaltSize)
 	if err != nil {
 		return nil, err
 	}
-	digest, err := getDigest(salt, data)
+	digest, err := getDigest(salt, data, sessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +71,19 @@ func NewHashCommitmenter(data []byte) (*HashCommitmenter, error) {
 	}, nil
 }
 
+// NewHashCommitmenterByDecommitment rebuilds the hash commitmenter of the session from its decommitment message.
+func NewHashCommitmenterByDecommitment(sessionID []byte, msg *HashDecommitmentMessage) (*HashCommitmenter, error) {
+	digest, err := getDigest(msg.GetSalt(), msg.GetData(), sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &HashCommitmenter{
+		digest: digest,
+		data:   msg.GetData(),
+		salt:   msg.GetSalt(),
+	}, nil
+}
+
 func (c *HashCommitmenter) GetCommitmentMessage() *HashCommitmentMessage {
 	return &HashCommitmentMessage{
 		Digest: c.digest,
@@ -74,7 +98,12 @@ func (c *HashCommitmenter) GetDecommitmentMessage() *HashDecommitmentMessage {
 }
 
 func (c *HashCommitmentMessage) Decommit(msg *HashDecommitmentMessage) error {
-	digest, err := getDigest(msg.Salt, msg.Data)
+	return c.DecommitWithSession(nil, msg)
+}
+
+// DecommitWithSession verifies the decommitment against the commitment made in the session.
+func (c *HashCommitmentMessage) DecommitWithSession(sessionID []byte, msg *HashDecommitmentMessage) error {
+	digest, err := getDigest(msg.Salt, msg.Data, sessionID)
 	if err != nil {
 		return err
 	}
@@ -85,7 +114,13 @@ func (c *HashCommitmentMessage) Decommit(msg *HashDecommitmentMessage) error {
 }
 
 func (c *HashCommitmentMessage) DecommitToProto(msg *HashDecommitmentMessage, proroMsg proto.Message) error {
-	err := c.Decommit(msg)
+	return c.DecommitToProtoWithSession(nil, msg, proroMsg)
+}
+
+// DecommitToProtoWithSession verifies the decommitment against the commitment made in the session and
+// unmarshals the committed data to the proto message.
+func (c *HashCommitmentMessage) DecommitToProtoWithSession(sessionID []byte, msg *HashDecommitmentMessage, proroMsg proto.Message) error {
+	err := c.DecommitWithSession(sessionID, msg)
 	if err != nil {
 		return err
 	}
@@ -95,8 +130,11 @@ func (c *HashCommitmentMessage) DecommitToProto(msg *HashDecommitmentMessage, pr
 	}
 	return nil
 }
-func getDigest(salt []byte, originData []byte) ([]byte, error) {
+
+func getDigest(salt []byte, originData []byte, sessionID []byte) ([]byte, error) {
 	return utils.HashProtos(salt, &any.Any{
 		Value: originData,
+	}, &any.Any{
+		Value: sessionID,
 	})
 }
@@ -48,6 +48,30 @@ var _ = Describe("hash", func() {
 			Expect(expected).To(BeNil())
 		})
 
+		It("different session", func() {
+			data, err := utils.GenRandomBytes(256)
+			Expect(err).To(BeNil())
+			c, err := NewHashCommitmenterWithSession([]byte("session-1"), data)
+			Expect(err).To(BeNil())
+
+			commitmentMsg := c.GetCommitmentMessage()
+			decommitmentMsg := c.GetDecommitmentMessage()
+			Expect(commitmentMsg.DecommitWithSession([]byte("session-1"), decommitmentMsg)).Should(BeNil())
+			Expect(commitmentMsg.DecommitWithSession([]byte("session-2"), decommitmentMsg)).Should(Equal(ErrDifferentDigest))
+			Expect(commitmentMsg.Decommit(decommitmentMsg)).Should(Equal(ErrDifferentDigest))
+		})
+
+		It("rebuild by decommitment", func() {
+			data, err := utils.GenRandomBytes(256)
+			Expect(err).To(BeNil())
+			c, err := NewHashCommitmenterWithSession([]byte("session-1"), data)
+			Expect(err).To(BeNil())
+
+			got, err := NewHashCommitmenterByDecommitment([]byte("session-1"), c.GetDecommitmentMessage())
+			Expect(err).To(BeNil())
+			Expect(got).Should(Equal(c))
+		})
+
 		It("empty input data", func() {
 			data, err := utils.GenRandomBytes(0)
 			Expect(err).To(Equal(utils.ErrEmptySlice))
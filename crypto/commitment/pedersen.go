@@ -15,13 +15,29 @@
 package commitment
 
 import (
+	"crypto/elliptic"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
 	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	bkhoff "github.com/getamis/alice/crypto/birkhoffinterpolation"
 	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/polynomial"
 )
 
+const (
+	maxHiddingPointRetry = 256
+	// ed25519Cofactor clears the small order component of Ed25519 points
+	ed25519Cofactor = 8
+)
+
+var (
+	// ErrNoHiddingPoint is returned if it's failed to derive a hidding point.
+	ErrNoHiddingPoint = errors.New("no hidding point")
+)
+
 type PedersenCommitmenter struct {
 	secrets *polynomial.Polynomial
 	salts   *polynomial.Polynomial
@@ -43,6 +59,59 @@ func NewPedersenCommitmenter(threshold uint32, hiddingPoint *pt.ECPoint, secrets
 	}, nil
 }
 
+// NewHiddingPoint derives a nothing-up-my-sleeve hidding point from the seed by try-and-increment.
+// The x-coordinate is SHA512(seed || curve name || counter) mod p and the y-coordinate is the even square root.
+// For Ed25519, the first 32 bytes of the hash are decoded as a point and multiplied by the cofactor instead.
+// Anyone could derive the same point again, and nobody knows its discrete logarithm.
+func NewHiddingPoint(curve elliptic.Curve, seed []byte) (*pt.ECPoint, error) {
+	if _, err := pt.ToCurve(curve); err != nil {
+		return nil, err
+	}
+	params := curve.Params()
+	counter := make([]byte, 4)
+	for i := uint32(0); i < maxHiddingPointRetry; i++ {
+		binary.BigEndian.PutUint32(counter, i)
+		h := sha512.New()
+		_, _ = h.Write(seed)
+		_, _ = h.Write([]byte(params.Name))
+		_, _ = h.Write(counter)
+		if curve == pt.Edwards25519() {
+			p, err := pt.NewECPointFromEd25519(h.Sum(nil)[:32])
+			if err != nil {
+				continue
+			}
+			p = p.ScalarMult(big.NewInt(ed25519Cofactor))
+			if p.IsIdentity() {
+				continue
+			}
+			return p, nil
+		}
+		x := new(big.Int).SetBytes(h.Sum(nil))
+		x.Mod(x, params.P)
+
+		// y^2 = x^3 + ax + b, where a = 0 for secp256k1 and a = -3 for NIST curves.
+		y2 := new(big.Int).Exp(x, big.NewInt(3), params.P)
+		if curve != btcec.S256() {
+			y2.Sub(y2, new(big.Int).Mul(big.NewInt(3), x))
+		}
+		y2.Add(y2, params.B)
+		y2.Mod(y2, params.P)
+		y := new(big.Int).ModSqrt(y2, params.P)
+		if y == nil {
+			continue
+		}
+		if y.Bit(0) == 1 {
+			y.Sub(params.P, y)
+		}
+		p, err := pt.NewECPoint(curve, x, y)
+		if err != nil {
+			continue
+		}
+		return p, nil
+	}
+	return nil, ErrNoHiddingPoint
+}
+
 /*
    Given two values secret and salt, Pedersen commitment is defined by secret*G + salt*H,
    where H is the hidding point which is determined by Distributed Pedersen Hidding Point Generation and G is the base point of the hidding point.
@@ -150,4 +150,47 @@ var _ = Describe("Pedersen commitment test", func() {
 		Entry("case #2",
 			big.NewInt(2291), big.NewInt(114), uint32(2), uint32(5), elliptic.P256()),
 	)
+
+	DescribeTable("NewHiddingPoint()", func(curve elliptic.Curve) {
+		seed := []byte("seed")
+		got, err := NewHiddingPoint(curve, seed)
+		Expect(err).Should(BeNil())
+		Expect(got.IsIdentity()).Should(BeFalse())
+		Expect(got.GetY().Bit(0)).Should(BeZero())
+
+		// The same seed gives the same point
+		again, err := NewHiddingPoint(curve, seed)
+		Expect(err).Should(BeNil())
+		Expect(again.Equal(got)).Should(BeTrue())
+
+		other, err := NewHiddingPoint(curve, []byte("other seed"))
+		Expect(err).Should(BeNil())
+		Expect(other.Equal(got)).Should(BeFalse())
+	},
+		Entry("P224", elliptic.P224()),
+		Entry("P256", elliptic.P256()),
+		Entry("P384", elliptic.P384()),
+		Entry("S256", btcec.S256()),
+	)
+
+	It("NewHiddingPoint(): Ed25519", func() {
+		curve := pt.Edwards25519()
+		got, err := NewHiddingPoint(curve, []byte("seed"))
+		Expect(err).Should(BeNil())
+		Expect(got.IsIdentity()).Should(BeFalse())
+		// The point is in the prime order subgroup
+		x, y := curve.ScalarMult(got.GetX(), got.GetY(), curve.Params().N.Bytes())
+		Expect(x.Sign()).Should(BeZero())
+		Expect(y.Int64()).Should(Equal(int64(1)))
+
+		again, err := NewHiddingPoint(curve, []byte("seed"))
+		Expect(err).Should(BeNil())
+		Expect(again.Equal(got)).Should(BeTrue())
+	})
+
+	It("NewHiddingPoint(): invalid curve", func() {
+		got, err := NewHiddingPoint(elliptic.P521(), []byte("seed"))
+		Expect(err).Should(Equal(pt.ErrInvalidCurve))
+		Expect(got).Should(BeNil())
+	})
 })
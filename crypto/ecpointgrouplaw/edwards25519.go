@@ -0,0 +1,238 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecpointgrouplaw
+
+import (
+	"crypto/elliptic"
+	"math/big"
+)
+
+var (
+	// ed25519Curve is the twisted Edwards curve -x^2 + y^2 = 1 + d*x^2*y^2 over GF(2^255-19) in RFC 8032.
+	ed25519Curve = newEdwards25519()
+
+	big1 = big.NewInt(1)
+)
+
+// edwards25519 implements elliptic.Curve in affine twisted Edwards coordinates. The identity element is (0, 1),
+// and B of the params is d. Note that the operations are not constant time.
+type edwards25519 struct {
+	params *elliptic.CurveParams
+	// d2 is 2*d
+	d2 *big.Int
+}
+
+// extendedPoint is a point in extended twisted Edwards coordinates, where x = X/Z, y = Y/Z and x*y = T/Z.
+type extendedPoint struct {
+	x, y, z, t *big.Int
+}
+
+// Edwards25519 returns the curve of Ed25519. Its addition law is complete, so ECPoint never treats it as a
+// Weierstrass curve.
+func Edwards25519() elliptic.Curve {
+	return ed25519Curve
+}
+
+func newEdwards25519() *edwards25519 {
+	p := new(big.Int).Sub(new(big.Int).Lsh(big1, 255), big.NewInt(19))
+	d, _ := new(big.Int).SetString("37095705934669439343138083508754565189542113879843219016388785533085940283555", 10)
+	n, _ := new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+	gx, _ := new(big.Int).SetString("15112221349535400772501151409588531511454012693041857206046113283949847762202", 10)
+	gy, _ := new(big.Int).SetString("46316835694926478169428394003475163141307993866256225615783033603165251855960", 10)
+	return &edwards25519{
+		params: &elliptic.CurveParams{
+			P:       p,
+			N:       n,
+			B:       d,
+			Gx:      gx,
+			Gy:      gy,
+			BitSize: 255,
+			Name:    "Ed25519",
+		},
+		d2: new(big.Int).Mod(new(big.Int).Lsh(d, 1), p),
+	}
+}
+
+func (c *edwards25519) Params() *elliptic.CurveParams {
+	return c.params
+}
+
+// IsOnCurve checks -x^2 + y^2 = 1 + d*x^2*y^2.
+func (c *edwards25519) IsOnCurve(x, y *big.Int) bool {
+	p := c.params.P
+	if x.Sign() < 0 || x.Cmp(p) >= 0 || y.Sign() < 0 || y.Cmp(p) >= 0 {
+		return false
+	}
+	x2 := new(big.Int).Mul(x, x)
+	y2 := new(big.Int).Mul(y, y)
+	lhs := new(big.Int).Sub(y2, x2)
+	lhs.Mod(lhs, p)
+	rhs := new(big.Int).Mul(x2, y2)
+	rhs.Mod(rhs, p)
+	rhs.Mul(rhs, c.params.B)
+	rhs.Add(rhs, big1)
+	rhs.Mod(rhs, p)
+	return lhs.Cmp(rhs) == 0
+}
+
+func (c *edwards25519) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	return c.toAffine(c.add(c.toExtended(x1, y1), c.toExtended(x2, y2)))
+}
+
+func (c *edwards25519) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
+	p := c.toExtended(x1, y1)
+	return c.toAffine(c.add(p, p))
+}
+
+func (c *edwards25519) ScalarMult(x1, y1 *big.Int, k []byte) (*big.Int, *big.Int) {
+	p := c.toExtended(x1, y1)
+	r := c.toExtended(big0, big1)
+	for _, b := range k {
+		for i := 7; i >= 0; i-- {
+			r = c.add(r, r)
+			if (b>>uint(i))&1 == 1 {
+				r = c.add(r, p)
+			}
+		}
+	}
+	return c.toAffine(r)
+}
+
+func (c *edwards25519) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	return c.ScalarMult(c.params.Gx, c.params.Gy, k)
+}
+
+func (c *edwards25519) toExtended(x, y *big.Int) *extendedPoint {
+	t := new(big.Int).Mul(x, y)
+	t.Mod(t, c.params.P)
+	return &extendedPoint{
+		x: new(big.Int).Set(x),
+		y: new(big.Int).Set(y),
+		z: big.NewInt(1),
+		t: t,
+	}
+}
+
+func (c *edwards25519) toAffine(p *extendedPoint) (*big.Int, *big.Int) {
+	zInv := new(big.Int).ModInverse(p.z, c.params.P)
+	x := new(big.Int).Mul(p.x, zInv)
+	x.Mod(x, c.params.P)
+	y := new(big.Int).Mul(p.y, zInv)
+	y.Mod(y, c.params.P)
+	return x, y
+}
+
+// add is the unified addition in "Twisted Edwards Curves Revisited" (add-2008-hwcd-3), which also works for
+// doubling and the identity element since a = -1 is a square and d is not.
+func (c *edwards25519) add(p1, p2 *extendedPoint) *extendedPoint {
+	p := c.params.P
+	a := new(big.Int).Mul(new(big.Int).Sub(p1.y, p1.x), new(big.Int).Sub(p2.y, p2.x))
+	a.Mod(a, p)
+	b := new(big.Int).Mul(new(big.Int).Add(p1.y, p1.x), new(big.Int).Add(p2.y, p2.x))
+	b.Mod(b, p)
+	cc := new(big.Int).Mul(p1.t, p2.t)
+	cc.Mul(cc.Mod(cc, p), c.d2)
+	cc.Mod(cc, p)
+	d := new(big.Int).Mul(p1.z, p2.z)
+	d.Lsh(d, 1)
+	d.Mod(d, p)
+	e := new(big.Int).Sub(b, a)
+	f := new(big.Int).Sub(d, cc)
+	g := new(big.Int).Add(d, cc)
+	h := new(big.Int).Add(b, a)
+	return &extendedPoint{
+		x: new(big.Int).Mod(new(big.Int).Mul(e, f), p),
+		y: new(big.Int).Mod(new(big.Int).Mul(g, h), p),
+		z: new(big.Int).Mod(new(big.Int).Mul(f, g), p),
+		t: new(big.Int).Mod(new(big.Int).Mul(e, h), p),
+	}
+}
+
+func isEdwards(curve elliptic.Curve) bool {
+	return curve == ed25519Curve
+}
+
+// isEdwardsIdentity checks if (x, y) is the identity element (0, 1) of twisted Edwards curves.
+func isEdwardsIdentity(curve elliptic.Curve, x, y *big.Int) bool {
+	return isEdwards(curve) && x != nil && y != nil && x.Sign() == 0 && y.Cmp(big1) == 0
+}
+
+// IsInPrimeOrderSubgroup checks if N*P is the identity element, i.e. the point has no small-order component.
+// Note that ScalarMult reduces the scalar modulo N, so it could not be used to check it. The points of the other
+// curves are always in the subgroup since their cofactors are 1.
+func (p *ECPoint) IsInPrimeOrderSubgroup() bool {
+	if p.IsIdentity() || !isEdwards(p.curve) {
+		return true
+	}
+	x, y := p.curve.ScalarMult(p.x, p.y, p.curve.Params().N.Bytes())
+	return isEdwardsIdentity(p.curve, x, y)
+}
+
+// ToEd25519 encodes the point of Ed25519 in 32 bytes as RFC 8032: the little-endian y-coordinate with the
+// least significant bit of the x-coordinate in the most significant bit.
+func (p *ECPoint) ToEd25519() ([]byte, error) {
+	if !isEdwards(p.curve) {
+		return nil, ErrInvalidCurve
+	}
+	x, y := big0, big1
+	if !p.IsIdentity() {
+		x, y = p.x, p.y
+	}
+	bs := make([]byte, 32)
+	yBytes := y.Bytes()
+	for i := range yBytes {
+		bs[i] = yBytes[len(yBytes)-1-i]
+	}
+	bs[31] |= byte(x.Bit(0) << 7)
+	return bs, nil
+}
+
+// NewECPointFromEd25519 decodes the point of Ed25519 encoded as RFC 8032.
+func NewECPointFromEd25519(bs []byte) (*ECPoint, error) {
+	if len(bs) != 32 {
+		return nil, ErrInvalidPoint
+	}
+	params := ed25519Curve.params
+	yBytes := make([]byte, 32)
+	for i := range bs {
+		yBytes[31-i] = bs[i]
+	}
+	xBit := uint(yBytes[0] >> 7)
+	yBytes[0] &= 0x7f
+	y := new(big.Int).SetBytes(yBytes)
+	if y.Cmp(params.P) >= 0 {
+		return nil, ErrInvalidPoint
+	}
+
+	// x^2 = (y^2 - 1) / (d*y^2 + 1)
+	y2 := new(big.Int).Mul(y, y)
+	u := new(big.Int).Sub(y2, big1)
+	v := new(big.Int).Mul(y2, params.B)
+	v.Add(v, big1)
+	v.ModInverse(v.Mod(v, params.P), params.P)
+	x2 := u.Mul(u, v)
+	x2.Mod(x2, params.P)
+	x := new(big.Int).ModSqrt(x2, params.P)
+	if x == nil {
+		return nil, ErrInvalidPoint
+	}
+	if x.Sign() == 0 && xBit == 1 {
+		return nil, ErrInvalidPoint
+	}
+	if x.Bit(0) != xBit {
+		x.Sub(params.P, x)
+	}
+	return NewECPoint(ed25519Curve, x, y)
+}
@@ -0,0 +1,115 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecpointgrouplaw
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Edwards25519", func() {
+	curve := Edwards25519()
+
+	It("is consistent with crypto/ed25519", func() {
+		for i := 0; i < 10; i++ {
+			seed := make([]byte, ed25519.SeedSize)
+			seed[0] = byte(i)
+			privateKey := ed25519.NewKeyFromSeed(seed)
+
+			// The secret scalar is the clamped lower half of SHA512(seed)
+			h := sha512.Sum512(seed)
+			h[0] &= 248
+			h[31] &= 127
+			h[31] |= 64
+			s := make([]byte, 32)
+			for j := range s {
+				s[j] = h[31-j]
+			}
+			p := ScalarBaseMult(curve, new(big.Int).SetBytes(s))
+			got, err := p.ToEd25519()
+			Expect(err).Should(BeNil())
+			Expect(got).Should(Equal([]byte(privateKey.Public().(ed25519.PublicKey))))
+
+			decoded, err := NewECPointFromEd25519(got)
+			Expect(err).Should(BeNil())
+			Expect(decoded.Equal(p)).Should(BeTrue())
+		}
+	})
+
+	It("encodes the identity element", func() {
+		identity := NewIdentity(curve)
+		got, err := identity.ToEd25519()
+		Expect(err).Should(BeNil())
+		exp := make([]byte, 32)
+		exp[0] = 1
+		Expect(got).Should(Equal(exp))
+
+		decoded, err := NewECPointFromEd25519(got)
+		Expect(err).Should(BeNil())
+		Expect(decoded.IsIdentity()).Should(BeTrue())
+	})
+
+	It("sums up to the identity element", func() {
+		p := ScalarBaseMult(curve, big.NewInt(5566))
+		negP := ScalarBaseMult(curve, new(big.Int).Sub(curve.Params().N, big.NewInt(5566)))
+		got, err := p.Add(negP)
+		Expect(err).Should(BeNil())
+		Expect(got.IsIdentity()).Should(BeTrue())
+		Expect(NewBase(curve).ScalarMult(curve.Params().N).IsIdentity()).Should(BeTrue())
+	})
+
+	It("checks the prime-order subgroup", func() {
+		Expect(NewBase(curve).IsInPrimeOrderSubgroup()).Should(BeTrue())
+		Expect(NewIdentity(curve).IsInPrimeOrderSubgroup()).Should(BeTrue())
+		Expect(NewBase(btcec.S256()).IsInPrimeOrderSubgroup()).Should(BeTrue())
+
+		// (0, -1) is the point of order 2
+		torsion, err := NewECPoint(curve, big.NewInt(0), new(big.Int).Sub(curve.Params().P, big.NewInt(1)))
+		Expect(err).Should(BeNil())
+		Expect(torsion.IsInPrimeOrderSubgroup()).Should(BeFalse())
+		mixed, err := NewBase(curve).Add(torsion)
+		Expect(err).Should(BeNil())
+		Expect(mixed.IsInPrimeOrderSubgroup()).Should(BeFalse())
+	})
+
+	It("invalid curve", func() {
+		got, err := NewBase(btcec.S256()).ToEd25519()
+		Expect(err).Should(Equal(ErrInvalidCurve))
+		Expect(got).Should(BeNil())
+	})
+
+	DescribeTable("invalid encoding", func(bs []byte) {
+		got, err := NewECPointFromEd25519(bs)
+		Expect(err).Should(Equal(ErrInvalidPoint))
+		Expect(got).Should(BeNil())
+	},
+		Entry("short", make([]byte, 31)),
+		// y = p is not canonical
+		Entry("non-canonical y", []byte{
+			0xed, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f,
+		}),
+		// y = 2 has no x-coordinate
+		Entry("not on curve", append([]byte{2}, make([]byte, 31)...)),
+		// x = 0 with the negative sign bit
+		Entry("negative zero", append(append([]byte{1}, make([]byte, 30)...), 0x80)),
+	)
+})
@@ -46,7 +46,7 @@ func NewECPoint(curve elliptic.Curve, x *big.Int, y *big.Int) (*ECPoint, error)
 	if !isOnCurve(curve, x, y) {
 		return nil, ErrInvalidPoint
 	}
-	if isIdentity(x, y) {
+	if isIdentity(x, y) || isEdwardsIdentity(curve, x, y) {
 		return NewIdentity(curve), nil
 	}
 	return &ECPoint{
@@ -102,6 +102,11 @@ func (p *ECPoint) Add(p1 *ECPoint) (*ECPoint, error) {
 	if p1.IsIdentity() {
 		return p.Copy(), nil
 	}
+	// The addition law of twisted Edwards curves is complete
+	if isEdwards(p.curve) {
+		x, y := p.curve.Add(p.x, p.y, p1.x, p1.y)
+		return NewECPoint(p.curve, x, y)
+	}
 
 	// The case : aG+(-a)G. Assume that the coordinate of aG = (x,y). Then (-a)G = (x,-y). Then aG + (-a)G = identity = (nil, nil).
 	if p1.x.Cmp(p.x) == 0 {
@@ -127,6 +132,9 @@ func (p *ECPoint) ScalarMult(k *big.Int) *ECPoint {
 		return NewIdentity(p.curve)
 	}
 	newX, newY := p.curve.ScalarMult(p.x, p.y, kModN.Bytes())
+	if isEdwardsIdentity(p.curve, newX, newY) {
+		return NewIdentity(p.curve)
+	}
 	return &ECPoint{
 		curve: p.curve,
 		x:     newX,
@@ -244,6 +252,8 @@ func (c EcPointMessage_Curve) GetEllipticCurve() (elliptic.Curve, error) {
 		return elliptic.P384(), nil
 	case EcPointMessage_S256:
 		return btcec.S256(), nil
+	case EcPointMessage_ED25519:
+		return ed25519Curve, nil
 	}
 	return nil, ErrInvalidCurve
 }
@@ -258,6 +268,8 @@ func ToCurve(c elliptic.Curve) (EcPointMessage_Curve, error) {
 		return EcPointMessage_P384, nil
 	case btcec.S256():
 		return EcPointMessage_S256, nil
+	case ed25519Curve:
+		return EcPointMessage_ED25519, nil
 	}
 	return 0, ErrInvalidCurve
 }
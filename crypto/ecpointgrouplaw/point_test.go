@@ -409,6 +409,7 @@ var _ = Describe("Point", func() {
 			Entry("P256", EcPointMessage_P256, elliptic.P256()),
 			Entry("P384", EcPointMessage_P384, elliptic.P384()),
 			Entry("S256", EcPointMessage_S256, btcec.S256()),
+			Entry("ED25519", EcPointMessage_ED25519, Edwards25519()),
 		)
 
 		DescribeTable("Point is the identity element", func(curveType EcPointMessage_Curve, curve elliptic.Curve) {
@@ -426,6 +427,7 @@ var _ = Describe("Point", func() {
 			Entry("P256", EcPointMessage_P256, elliptic.P256()),
 			Entry("P384", EcPointMessage_P384, elliptic.P384()),
 			Entry("S256", EcPointMessage_S256, btcec.S256()),
+			Entry("ED25519", EcPointMessage_ED25519, Edwards25519()),
 		)
 
 		Context("Invalid curve", func() {
@@ -438,7 +440,7 @@ var _ = Describe("Point", func() {
 			})
 
 			It("ToPoint()", func() {
-				const UnSupportedEcPointMessage EcPointMessage_Curve = 4
+				const UnSupportedEcPointMessage EcPointMessage_Curve = 5
 				msg := &EcPointMessage{
 					Curve: UnSupportedEcPointMessage,
 				}
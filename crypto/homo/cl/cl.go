@@ -448,6 +448,16 @@ func (c *CL) NewPubKeyFromBytes(bs []byte) (homo.Pubkey, error) {
 	return msg.ToPubkey()
 }
 
+// ToPubKeyBytesWithSession returns our public key. The proof of the CL public key is not bound to the session.
+func (c *CL) ToPubKeyBytesWithSession(sessionID []byte, id string) ([]byte, error) {
+	return c.ToPubKeyBytes(), nil
+}
+
+// NewPubKeyFromBytesWithSession verifies the public key. The proof of the CL public key is not bound to the session.
+func (c *CL) NewPubKeyFromBytesWithSession(sessionID []byte, id string, bs []byte) (homo.Pubkey, error) {
+	return c.NewPubKeyFromBytes(bs)
+}
+
 // Find a prime r such that (ΔK/r) = 1
 func generateR(discriminantK *big.Int) (*big.Int, error) {
 	for i := 0; i < len(smallPrimeList); i++ {
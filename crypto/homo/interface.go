@@ -39,4 +39,8 @@ type Crypto interface {
 	VerifyMtaProof(msg []byte, curve elliptic.Curve, alpha *big.Int, k *big.Int) (*pt.ECPoint, error)
 	GetPubKey() Pubkey
 	NewPubKeyFromBytes([]byte) (Pubkey, error)
+	// ToPubKeyBytesWithSession returns our public key with the proofs bound to the session and our id
+	ToPubKeyBytesWithSession(sessionID []byte, id string) ([]byte, error)
+	// NewPubKeyFromBytesWithSession verifies the proofs of the public key made by the owner id in the session
+	NewPubKeyFromBytesWithSession(sessionID []byte, id string, bs []byte) (Pubkey, error)
 }
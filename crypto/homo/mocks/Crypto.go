@@ -188,6 +188,29 @@ func (_m *Crypto) NewPubKeyFromBytes(_a0 []byte) (homo.Pubkey, error) {
 	return r0, r1
 }
 
+// NewPubKeyFromBytesWithSession provides a mock function with given fields: sessionID, id, bs
+func (_m *Crypto) NewPubKeyFromBytesWithSession(sessionID []byte, id string, bs []byte) (homo.Pubkey, error) {
+	ret := _m.Called(sessionID, id, bs)
+
+	var r0 homo.Pubkey
+	if rf, ok := ret.Get(0).(func([]byte, string, []byte) homo.Pubkey); ok {
+		r0 = rf(sessionID, id, bs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(homo.Pubkey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, string, []byte) error); ok {
+		r1 = rf(sessionID, id, bs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ToPubKeyBytes provides a mock function with given fields:
 func (_m *Crypto) ToPubKeyBytes() []byte {
 	ret := _m.Called()
@@ -204,6 +227,29 @@ func (_m *Crypto) ToPubKeyBytes() []byte {
 	return r0
 }
 
+// ToPubKeyBytesWithSession provides a mock function with given fields: sessionID, id
+func (_m *Crypto) ToPubKeyBytesWithSession(sessionID []byte, id string) ([]byte, error) {
+	ret := _m.Called(sessionID, id)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func([]byte, string) []byte); ok {
+		r0 = rf(sessionID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, string) error); ok {
+		r1 = rf(sessionID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifyEnc provides a mock function with given fields: _a0
 func (_m *Crypto) VerifyEnc(_a0 []byte) error {
 	ret := _m.Called(_a0)
@@ -15,24 +15,51 @@
 package paillier
 
 import (
+	"bytes"
 	"math/big"
 
 	"github.com/getamis/alice/crypto/utils"
 )
 
-func (msg *PubKeyMessage) ToPubkey() (*publicKey, error) {
+// ToPubkey verifies the proofs of n made by the owner id in the session and returns the public key. It refuses the
+// keys smaller than safePubKeySize.
+func (msg *PubKeyMessage) ToPubkey(sessionID []byte, id string) (*publicKey, error) {
+	if !bytes.Equal(msg.GetSessionId(), sessionID) || msg.GetId() != id {
+		return nil, ErrInvalidMessage
+	}
+	n := new(big.Int).SetBytes(msg.GetProof().GetPublicKey())
+	if n.BitLen() < safePubKeySize {
+		return nil, ErrSmallPublicKeySize
+	}
 	err := msg.Proof.Verify()
 	if err != nil {
 		return nil, err
 	}
+	// ensure n is a Paillier-Blum modulus
+	if msg.BlumProof == nil {
+		return nil, ErrInvalidMessage
+	}
+	err = msg.BlumProof.Verify(sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+	if n.Cmp(new(big.Int).SetBytes(msg.BlumProof.N)) != 0 {
+		return nil, ErrInvalidMessage
+	}
+	// ensure the ring-Pedersen parameter is over n
+	if msg.PedersenParameter == nil {
+		return nil, ErrInvalidMessage
+	}
+	pedersen, err := NewPedersenOpenParameter(sessionID, id, msg.PedersenParameter)
+	if err != nil {
+		return nil, err
+	}
+	if n.Cmp(pedersen.n) != 0 {
+		return nil, ErrInvalidMessage
+	}
 
-	n := new(big.Int).SetBytes(msg.Proof.PublicKey)
 	g := new(big.Int).SetBytes(msg.G)
 	nSquare := new(big.Int).Mul(n, n)
-	// ensure n is positive
-	if n.Cmp(big0) <= 0 {
-		return nil, ErrInvalidMessage
-	}
 	// ensure g is [2, nsquare) and g and nSquare are coprime
 	err = utils.InRange(g, big2, nSquare)
 	if err != nil {
@@ -43,9 +70,10 @@ func (msg *PubKeyMessage) ToPubkey() (*publicKey, error) {
 	}
 
 	return &publicKey{
-		n:       n,
-		g:       g,
-		msg:     msg,
-		nSquare: nSquare,
+		n:        n,
+		g:        g,
+		pedersen: pedersen,
+		msg:      msg,
+		nSquare:  nSquare,
 	}, nil
 }
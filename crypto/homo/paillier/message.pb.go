@@ -5,6 +5,7 @@ package paillier
 
 import (
 	fmt "fmt"
+	ecpointgrouplaw "github.com/getamis/alice/crypto/ecpointgrouplaw"
 	zkproof "github.com/getamis/alice/crypto/zkproof"
 	proto "github.com/golang/protobuf/proto"
 	math "math"
@@ -22,11 +23,18 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type PubKeyMessage struct {
-	Proof                *zkproof.IntegerFactorizationProofMessage `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
-	G                    []byte                                    `protobuf:"bytes,2,opt,name=g,proto3" json:"g,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
-	XXX_unrecognized     []byte                                    `json:"-"`
-	XXX_sizecache        int32                                     `json:"-"`
+	Proof *zkproof.IntegerFactorizationProofMessage `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	G     []byte                                    `protobuf:"bytes,2,opt,name=g,proto3" json:"g,omitempty"`
+	// blumProof proves that n is a Paillier-Blum modulus
+	BlumProof *zkproof.PaillierBlumMessage `protobuf:"bytes,3,opt,name=blumProof,proto3" json:"blumProof,omitempty"`
+	// pedersenParameter is the ring-Pedersen parameter over n, under which the peers prove the ranges to the owner
+	PedersenParameter *zkproof.RingPedersenParameterMessage `protobuf:"bytes,4,opt,name=pedersenParameter,proto3" json:"pedersenParameter,omitempty"`
+	// sessionId and id are the session and the owner of the key which blumProof and pedersenParameter are bound to
+	SessionId            []byte   `protobuf:"bytes,5,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	Id                   string   `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *PubKeyMessage) Reset()         { *m = PubKeyMessage{} }
@@ -68,8 +76,264 @@ func (m *PubKeyMessage) GetG() []byte {
 	return nil
 }
 
+func (m *PubKeyMessage) GetBlumProof() *zkproof.PaillierBlumMessage {
+	if m != nil {
+		return m.BlumProof
+	}
+	return nil
+}
+
+func (m *PubKeyMessage) GetPedersenParameter() *zkproof.RingPedersenParameterMessage {
+	if m != nil {
+		return m.PedersenParameter
+	}
+	return nil
+}
+
+func (m *PubKeyMessage) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *PubKeyMessage) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// RangeProofMessage is the proof that the plaintext of a ciphertext is in range
+type RangeProofMessage struct {
+	Salt []byte `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	Z    []byte `protobuf:"bytes,2,opt,name=z,proto3" json:"z,omitempty"`
+	U    []byte `protobuf:"bytes,3,opt,name=u,proto3" json:"u,omitempty"`
+	W    []byte `protobuf:"bytes,4,opt,name=w,proto3" json:"w,omitempty"`
+	S    []byte `protobuf:"bytes,5,opt,name=s,proto3" json:"s,omitempty"`
+	S1   []byte `protobuf:"bytes,6,opt,name=s1,proto3" json:"s1,omitempty"`
+	S2   []byte `protobuf:"bytes,7,opt,name=s2,proto3" json:"s2,omitempty"`
+	// y is alpha*base in the proof with check
+	Y                    *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,8,opt,name=y,proto3" json:"y,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *RangeProofMessage) Reset()         { *m = RangeProofMessage{} }
+func (m *RangeProofMessage) String() string { return proto.CompactTextString(m) }
+func (*RangeProofMessage) ProtoMessage()    {}
+func (*RangeProofMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3150a6ceeb3e2e19, []int{1}
+}
+
+func (m *RangeProofMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RangeProofMessage.Unmarshal(m, b)
+}
+func (m *RangeProofMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RangeProofMessage.Marshal(b, m, deterministic)
+}
+func (m *RangeProofMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RangeProofMessage.Merge(m, src)
+}
+func (m *RangeProofMessage) XXX_Size() int {
+	return xxx_messageInfo_RangeProofMessage.Size(m)
+}
+func (m *RangeProofMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_RangeProofMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RangeProofMessage proto.InternalMessageInfo
+
+func (m *RangeProofMessage) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+func (m *RangeProofMessage) GetZ() []byte {
+	if m != nil {
+		return m.Z
+	}
+	return nil
+}
+
+func (m *RangeProofMessage) GetU() []byte {
+	if m != nil {
+		return m.U
+	}
+	return nil
+}
+
+func (m *RangeProofMessage) GetW() []byte {
+	if m != nil {
+		return m.W
+	}
+	return nil
+}
+
+func (m *RangeProofMessage) GetS() []byte {
+	if m != nil {
+		return m.S
+	}
+	return nil
+}
+
+func (m *RangeProofMessage) GetS1() []byte {
+	if m != nil {
+		return m.S1
+	}
+	return nil
+}
+
+func (m *RangeProofMessage) GetS2() []byte {
+	if m != nil {
+		return m.S2
+	}
+	return nil
+}
+
+func (m *RangeProofMessage) GetY() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.Y
+	}
+	return nil
+}
+
+// RespondentProofMessage is the proof that c2 = c1^x * Enc(y) with x and y in range
+type RespondentProofMessage struct {
+	Salt   []byte `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	Z      []byte `protobuf:"bytes,2,opt,name=z,proto3" json:"z,omitempty"`
+	ZPrime []byte `protobuf:"bytes,3,opt,name=zPrime,proto3" json:"zPrime,omitempty"`
+	T      []byte `protobuf:"bytes,4,opt,name=t,proto3" json:"t,omitempty"`
+	V      []byte `protobuf:"bytes,5,opt,name=v,proto3" json:"v,omitempty"`
+	W      []byte `protobuf:"bytes,6,opt,name=w,proto3" json:"w,omitempty"`
+	S      []byte `protobuf:"bytes,7,opt,name=s,proto3" json:"s,omitempty"`
+	S1     []byte `protobuf:"bytes,8,opt,name=s1,proto3" json:"s1,omitempty"`
+	S2     []byte `protobuf:"bytes,9,opt,name=s2,proto3" json:"s2,omitempty"`
+	T1     []byte `protobuf:"bytes,10,opt,name=t1,proto3" json:"t1,omitempty"`
+	T2     []byte `protobuf:"bytes,11,opt,name=t2,proto3" json:"t2,omitempty"`
+	// u is only set in the proof with check
+	U                    *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,12,opt,name=u,proto3" json:"u,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *RespondentProofMessage) Reset()         { *m = RespondentProofMessage{} }
+func (m *RespondentProofMessage) String() string { return proto.CompactTextString(m) }
+func (*RespondentProofMessage) ProtoMessage()    {}
+func (*RespondentProofMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3150a6ceeb3e2e19, []int{2}
+}
+
+func (m *RespondentProofMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespondentProofMessage.Unmarshal(m, b)
+}
+func (m *RespondentProofMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespondentProofMessage.Marshal(b, m, deterministic)
+}
+func (m *RespondentProofMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespondentProofMessage.Merge(m, src)
+}
+func (m *RespondentProofMessage) XXX_Size() int {
+	return xxx_messageInfo_RespondentProofMessage.Size(m)
+}
+func (m *RespondentProofMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespondentProofMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespondentProofMessage proto.InternalMessageInfo
+
+func (m *RespondentProofMessage) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+func (m *RespondentProofMessage) GetZ() []byte {
+	if m != nil {
+		return m.Z
+	}
+	return nil
+}
+
+func (m *RespondentProofMessage) GetZPrime() []byte {
+	if m != nil {
+		return m.ZPrime
+	}
+	return nil
+}
+
+func (m *RespondentProofMessage) GetT() []byte {
+	if m != nil {
+		return m.T
+	}
+	return nil
+}
+
+func (m *RespondentProofMessage) GetV() []byte {
+	if m != nil {
+		return m.V
+	}
+	return nil
+}
+
+func (m *RespondentProofMessage) GetW() []byte {
+	if m != nil {
+		return m.W
+	}
+	return nil
+}
+
+func (m *RespondentProofMessage) GetS() []byte {
+	if m != nil {
+		return m.S
+	}
+	return nil
+}
+
+func (m *RespondentProofMessage) GetS1() []byte {
+	if m != nil {
+		return m.S1
+	}
+	return nil
+}
+
+func (m *RespondentProofMessage) GetS2() []byte {
+	if m != nil {
+		return m.S2
+	}
+	return nil
+}
+
+func (m *RespondentProofMessage) GetT1() []byte {
+	if m != nil {
+		return m.T1
+	}
+	return nil
+}
+
+func (m *RespondentProofMessage) GetT2() []byte {
+	if m != nil {
+		return m.T2
+	}
+	return nil
+}
+
+func (m *RespondentProofMessage) GetU() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.U
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PubKeyMessage)(nil), "paillier.PubKeyMessage")
+	proto.RegisterType((*RangeProofMessage)(nil), "paillier.RangeProofMessage")
+	proto.RegisterType((*RespondentProofMessage)(nil), "paillier.RespondentProofMessage")
 }
 
 func init() {
@@ -77,17 +341,33 @@ func init() {
 }
 
 var fileDescriptor_3150a6ceeb3e2e19 = []byte{
-	// 180 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xb2, 0x4e, 0xcf, 0x2c, 0xc9,
-	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0x4f, 0x4f, 0x2d, 0x49, 0xcc, 0xcd, 0x2c, 0xd6, 0x4f,
-	0xcc, 0xc9, 0x4c, 0x4e, 0xd5, 0x4f, 0x2e, 0xaa, 0x2c, 0x28, 0xc9, 0xd7, 0xcf, 0xc8, 0xcf, 0xcd,
-	0xd7, 0x2f, 0x48, 0xcc, 0xcc, 0xc9, 0xc9, 0x4c, 0x2d, 0xd2, 0xcf, 0x4d, 0x2d, 0x2e, 0x4e, 0x4c,
-	0x4f, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x80, 0x89, 0x4b, 0x99, 0x12, 0x32, 0xa6,
-	0x2a, 0xbb, 0xa0, 0x28, 0x3f, 0x3f, 0x0d, 0xd5, 0x00, 0xa5, 0x38, 0x2e, 0xde, 0x80, 0xd2, 0x24,
-	0xef, 0xd4, 0x4a, 0x5f, 0x88, 0xb0, 0x90, 0x3d, 0x17, 0x2b, 0x58, 0x9d, 0x04, 0xa3, 0x02, 0xa3,
-	0x06, 0xb7, 0x91, 0xa6, 0x1e, 0x54, 0x9f, 0x9e, 0x67, 0x5e, 0x49, 0x6a, 0x7a, 0x6a, 0x91, 0x5b,
-	0x62, 0x72, 0x49, 0x7e, 0x51, 0x66, 0x55, 0x62, 0x49, 0x66, 0x7e, 0x5e, 0x00, 0x48, 0x06, 0xaa,
-	0x33, 0x08, 0xa2, 0x4f, 0x88, 0x87, 0x8b, 0x31, 0x5d, 0x82, 0x49, 0x81, 0x51, 0x83, 0x27, 0x88,
-	0x31, 0x3d, 0x89, 0x0d, 0x6c, 0x8d, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0xea, 0x78, 0xaa, 0xed,
-	0xe6, 0x00, 0x00, 0x00,
+	// 447 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xdf, 0x6a, 0xdb, 0x30,
+	0x14, 0xc6, 0x91, 0xd7, 0xa6, 0x89, 0xea, 0x15, 0xaa, 0x8b, 0x22, 0x4a, 0x61, 0x21, 0x30, 0xc8,
+	0x2e, 0x66, 0x93, 0x8c, 0xdd, 0x6c, 0x17, 0x83, 0xc1, 0x06, 0x65, 0x0c, 0x8c, 0xf6, 0x04, 0x8a,
+	0x7d, 0xe6, 0x8a, 0xd9, 0x92, 0xd1, 0x9f, 0x86, 0xf8, 0x5d, 0xf6, 0x0a, 0x7b, 0xbf, 0xdd, 0x0d,
+	0x1f, 0xdb, 0xe9, 0x92, 0x5d, 0x74, 0xbd, 0xf3, 0x27, 0x9d, 0xef, 0x3b, 0xdf, 0xcf, 0x88, 0xbe,
+	0x2f, 0x95, 0xbf, 0x0b, 0x9b, 0x24, 0x37, 0x75, 0x5a, 0x82, 0x97, 0xb5, 0x72, 0xa9, 0xac, 0x54,
+	0x0e, 0x69, 0x6e, 0x77, 0x8d, 0x37, 0xe9, 0x9d, 0xa9, 0x4d, 0xda, 0x48, 0x55, 0x55, 0x0a, 0x6c,
+	0x5a, 0x83, 0x73, 0xb2, 0x84, 0xa4, 0xb1, 0xc6, 0x1b, 0x36, 0x1d, 0xcf, 0xaf, 0x1f, 0x8d, 0x81,
+	0xbc, 0x31, 0x4a, 0xfb, 0xd2, 0x9a, 0xd0, 0x54, 0x72, 0x9b, 0xa2, 0xea, 0x63, 0xae, 0xdf, 0x3e,
+	0x66, 0x6e, 0x7f, 0x34, 0xd6, 0x98, 0xef, 0x87, 0xdb, 0x17, 0x3f, 0x23, 0xfa, 0x3c, 0x0b, 0x9b,
+	0x2f, 0xb0, 0xfb, 0xda, 0x9f, 0xb3, 0x0f, 0xf4, 0x14, 0x07, 0x39, 0x99, 0x93, 0xe5, 0xf9, 0xfa,
+	0x55, 0x32, 0x18, 0x93, 0x5b, 0xed, 0xa1, 0x04, 0xfb, 0x59, 0xe6, 0xde, 0x58, 0xd5, 0x4a, 0xaf,
+	0x8c, 0xce, 0xba, 0x9b, 0xc1, 0x29, 0x7a, 0x1f, 0x8b, 0x29, 0x29, 0x79, 0x34, 0x27, 0xcb, 0x58,
+	0x90, 0x92, 0xbd, 0xa3, 0xb3, 0x4d, 0x15, 0x6a, 0x1c, 0xe4, 0xcf, 0x30, 0xf2, 0x66, 0x1f, 0x99,
+	0x0d, 0xe8, 0x1f, 0xab, 0x50, 0x8f, 0x29, 0x0f, 0xe3, 0xec, 0x1b, 0xbd, 0x6c, 0xa0, 0x00, 0xeb,
+	0x40, 0x67, 0xd2, 0xca, 0x1a, 0x3c, 0x58, 0x7e, 0x82, 0x19, 0x2f, 0xf7, 0x19, 0x42, 0xe9, 0x32,
+	0x3b, 0x9e, 0x1a, 0xc3, 0xfe, 0xf5, 0xb3, 0x1b, 0x3a, 0x73, 0xe0, 0x9c, 0x32, 0xfa, 0xb6, 0xe0,
+	0xa7, 0x58, 0xf3, 0xe1, 0x80, 0x5d, 0xd0, 0x48, 0x15, 0x7c, 0x32, 0x27, 0xcb, 0x99, 0x88, 0x54,
+	0xb1, 0xf8, 0x45, 0xe8, 0xa5, 0x90, 0xba, 0x84, 0xbf, 0x49, 0x19, 0xa3, 0x27, 0x4e, 0x56, 0x1e,
+	0x7f, 0x51, 0x2c, 0xf0, 0xbb, 0xc3, 0x6e, 0x47, 0xec, 0xb6, 0x53, 0x01, 0x71, 0x63, 0x41, 0x42,
+	0xa7, 0xb6, 0x58, 0x3c, 0x16, 0x64, 0xdb, 0x29, 0x37, 0x6c, 0x26, 0xae, 0xdb, 0xe8, 0x56, 0xb8,
+	0x31, 0x16, 0x91, 0x5b, 0xa1, 0x5e, 0xf3, 0xb3, 0x41, 0xaf, 0xd9, 0x6b, 0x4a, 0x76, 0x7c, 0x8a,
+	0xd0, 0x2f, 0x92, 0xa3, 0x17, 0x90, 0x7c, 0xca, 0xb3, 0x4e, 0x8f, 0xb8, 0x64, 0xb7, 0xf8, 0x4d,
+	0xe8, 0x95, 0x00, 0xd7, 0x18, 0x5d, 0x80, 0xf6, 0x4f, 0x6c, 0x7d, 0x45, 0x27, 0x6d, 0x66, 0x55,
+	0x0d, 0x43, 0xf5, 0x41, 0x75, 0x53, 0x7e, 0xec, 0x8f, 0x9e, 0xfb, 0xb1, 0xff, 0x7d, 0xcf, 0x36,
+	0x39, 0x60, 0x3b, 0x3b, 0x64, 0x9b, 0x1e, 0xb1, 0xcd, 0xf6, 0x6c, 0x17, 0x34, 0xf2, 0x2b, 0x4e,
+	0x7b, 0xed, 0xf1, 0xde, 0xaf, 0xf9, 0xf9, 0xa0, 0x91, 0x3d, 0xf0, 0xf8, 0x3f, 0xd9, 0xc3, 0x66,
+	0x82, 0x6f, 0xfa, 0xcd, 0x9f, 0x01, 0x00, 0xe3, 0x57, 0xe6, 0x2b, 0x90, 0x03, 0x00, 0x00,
 }
@@ -18,11 +18,17 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/alice/crypto/zkproof"
 )
 
 var _ = Describe("Message test", func() {
 	Context("ToPubkey()", func() {
-		var p *Paillier
+		var (
+			sessionID = []byte("session id")
+			id        = "id"
+
+			p *Paillier
+		)
 		BeforeEach(func() {
 			var err error
 			p, err = NewPaillier(2048)
@@ -30,30 +36,105 @@ var _ = Describe("Message test", func() {
 		})
 
 		It("should be ok", func() {
-			pub, err := p.publicKey.msg.ToPubkey()
+			pub, err := p.publicKey.msg.ToPubkey(nil, "")
 			Expect(err).Should(BeNil())
 			Expect(pub).Should(Equal(p.publicKey))
 		})
 
 		It("g and nSqaure are not relative prime", func() {
 			p.publicKey.msg.G = p.n.Bytes()
-			pub, err := p.publicKey.msg.ToPubkey()
+			pub, err := p.publicKey.msg.ToPubkey(nil, "")
 			Expect(err).Should(Equal(ErrInvalidMessage))
 			Expect(pub).Should(BeNil())
 		})
 
 		It("g is not in range", func() {
 			p.publicKey.msg.G = p.nSquare.Bytes()
-			pub, err := p.publicKey.msg.ToPubkey()
+			pub, err := p.publicKey.msg.ToPubkey(nil, "")
 			Expect(err).Should(Equal(utils.ErrNotInRange))
 			Expect(pub).Should(BeNil())
 		})
 
 		It("zero n", func() {
 			p.publicKey.msg.Proof.PublicKey = big0.Bytes()
-			pub, err := p.publicKey.msg.ToPubkey()
-			Expect(err).Should(Equal(utils.ErrLargerFloor))
+			pub, err := p.publicKey.msg.ToPubkey(nil, "")
+			Expect(err).Should(Equal(ErrSmallPublicKeySize))
+			Expect(pub).Should(BeNil())
+		})
+
+		It("small n", func() {
+			p.publicKey.msg.Proof.PublicKey = p.n.Bytes()[1:]
+			pub, err := p.publicKey.msg.ToPubkey(nil, "")
+			Expect(err).Should(Equal(ErrSmallPublicKeySize))
+			Expect(pub).Should(BeNil())
+		})
+
+		It("missing Paillier-Blum proof", func() {
+			p.publicKey.msg.BlumProof = nil
+			pub, err := p.publicKey.msg.ToPubkey(nil, "")
+			Expect(err).Should(Equal(ErrInvalidMessage))
+			Expect(pub).Should(BeNil())
+		})
+
+		It("invalid Paillier-Blum proof", func() {
+			p.publicKey.msg.BlumProof.Z[0] = p.publicKey.msg.BlumProof.X[0]
+			pub, err := p.publicKey.msg.ToPubkey(nil, "")
+			Expect(err).Should(Equal(zkproof.ErrVerifyFailure))
 			Expect(pub).Should(BeNil())
 		})
+
+		It("Paillier-Blum proof of another n", func() {
+			another, err := NewPaillier(2048)
+			Expect(err).Should(BeNil())
+			p.publicKey.msg.BlumProof = another.publicKey.msg.BlumProof
+			pub, err := p.publicKey.msg.ToPubkey(nil, "")
+			Expect(err).Should(Equal(ErrInvalidMessage))
+			Expect(pub).Should(BeNil())
+		})
+
+		Context("bound to the session", func() {
+			var msg *PubKeyMessage
+			BeforeEach(func() {
+				var err error
+				msg, err = p.NewPubKeyMessage(sessionID, id)
+				Expect(err).Should(BeNil())
+			})
+
+			It("should be ok", func() {
+				pub, err := msg.ToPubkey(sessionID, id)
+				Expect(err).Should(BeNil())
+				Expect(pub.n).Should(Equal(p.n))
+			})
+
+			It("another session", func() {
+				pub, err := msg.ToPubkey([]byte("another session id"), id)
+				Expect(err).Should(Equal(ErrInvalidMessage))
+				Expect(pub).Should(BeNil())
+			})
+
+			It("another owner", func() {
+				pub, err := msg.ToPubkey(sessionID, "another id")
+				Expect(err).Should(Equal(ErrInvalidMessage))
+				Expect(pub).Should(BeNil())
+			})
+
+			It("Paillier-Blum proof of another session", func() {
+				another, err := p.NewPubKeyMessage([]byte("another session id"), id)
+				Expect(err).Should(BeNil())
+				msg.BlumProof = another.BlumProof
+				pub, err := msg.ToPubkey(sessionID, id)
+				Expect(err).Should(Equal(zkproof.ErrVerifyFailure))
+				Expect(pub).Should(BeNil())
+			})
+
+			It("ring-Pedersen proof of another owner", func() {
+				another, err := p.NewPubKeyMessage(sessionID, "another id")
+				Expect(err).Should(BeNil())
+				msg.PedersenParameter = another.PedersenParameter
+				pub, err := msg.ToPubkey(sessionID, id)
+				Expect(err).Should(Equal(zkproof.ErrVerifyFailure))
+				Expect(pub).Should(BeNil())
+			})
+		})
 	})
 })
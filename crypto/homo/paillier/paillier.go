@@ -48,6 +48,8 @@ var (
 	ErrInvalidMessage = errors.New("invalid message")
 	//ErrSmallPublicKeySize is returned if the size of public key is small
 	ErrSmallPublicKeySize = errors.New("small public key")
+	//ErrInvalidOpening is returned if the plaintext and the randomness don't open the ciphertext
+	ErrInvalidOpening = errors.New("invalid opening")
 
 	big0 = big.NewInt(0)
 	big1 = big.NewInt(1)
@@ -56,9 +58,10 @@ var (
 
 // publicKey is (n, g)
 type publicKey struct {
-	n   *big.Int
-	g   *big.Int
-	msg *PubKeyMessage
+	n        *big.Int
+	g        *big.Int
+	pedersen *PedersenOpenParameter
+	msg      *PubKeyMessage
 
 	// cache value
 	nSquare *big.Int
@@ -98,7 +101,8 @@ func (pub *publicKey) Encrypt(mBytes []byte) ([]byte, error) {
 	return c.Bytes(), nil
 }
 
-// In paillier, we cannot verify enc message. Therefore, we always return nil.
+// In paillier, we cannot verify enc message. Therefore, we always return nil. The range of the plaintext could be
+// proven by RangeProofMessage instead.
 func (pub *publicKey) VerifyEnc([]byte) error {
 	return nil
 }
@@ -107,9 +111,17 @@ func (p *Paillier) GetPubKey() homo.Pubkey {
 	return p.publicKey
 }
 
+// GetPedersenParameter returns the ring-Pedersen parameter over our modulus. The peers prove the ranges of their
+// ciphertexts to us under it.
+func (p *Paillier) GetPedersenParameter() *PedersenParameter {
+	return p.pedersen
+}
+
 // Refer: https://en.wikipedia.org/wiki/Paillier_cryptosystem
 // privateKey is (λ, μ)
 type privateKey struct {
+	p      *big.Int
+	q      *big.Int
 	lambda *big.Int // λ=lcm(p−1, q−1)
 	mu     *big.Int // μ=(L(g^λ mod n^2))^-1 mod n
 }
@@ -117,6 +129,7 @@ type privateKey struct {
 type Paillier struct {
 	*publicKey
 	privateKey *privateKey
+	pedersen   *PedersenParameter
 }
 
 func NewPaillier(keySize int) (*Paillier, error) {
@@ -141,20 +154,33 @@ func NewPaillierUnSafe(keySize int) (*Paillier, error) {
 	if err != nil {
 		return nil, err
 	}
+	blumMsg, err := zkproof.NewPaillierBlumMessage(nil, "", p, q, n)
+	if err != nil {
+		return nil, err
+	}
+	pedersen, err := newPedersenParameter(n, lambda)
+	if err != nil {
+		return nil, err
+	}
 	pubKeyMessage := &PubKeyMessage{
-		Proof: msg,
-		G:     g.Bytes(),
+		Proof:             msg,
+		G:                 g.Bytes(),
+		BlumProof:         blumMsg,
+		PedersenParameter: pedersen.ToMessage(),
 	}
-	pub, err := pubKeyMessage.ToPubkey()
+	pub, err := pubKeyMessage.ToPubkey(nil, "")
 	if err != nil {
 		return nil, err
 	}
 	return &Paillier{
 		publicKey: pub,
 		privateKey: &privateKey{
+			p:      p,
+			q:      q,
 			lambda: lambda,
 			mu:     mu,
 		},
+		pedersen: pedersen,
 	}, nil
 }
 
@@ -179,13 +205,93 @@ func (p *Paillier) Decrypt(cBytes []byte) ([]byte, error) {
 	return l.Bytes(), nil
 }
 
+// Open decrypts our ciphertext c = g^m * r^n mod n^2, and returns m with the randomness r. Anyone could verify m
+// by VerifyOpening without our private key, so it should be used only if m could be revealed.
+func (p *Paillier) Open(cBytes []byte) (*big.Int, *big.Int, error) {
+	mBytes, err := p.Decrypt(cBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := new(big.Int).SetBytes(mBytes)
+	r, err := p.getRandomness(new(big.Int).SetBytes(cBytes), m)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, r, nil
+}
+
+// VerifyOpening verifies that c = g^m * r^n mod n^2 under the public key, i.e. m is the plaintext of c.
+func VerifyOpening(pubkey homo.Pubkey, cBytes []byte, m *big.Int, r *big.Int) error {
+	pub, err := toPublicKey(pubkey)
+	if err != nil {
+		return err
+	}
+	c := new(big.Int).SetBytes(cBytes)
+	err = isCorrectCiphertext(c, pub)
+	if err != nil {
+		return err
+	}
+	err = utils.InRange(m, big0, pub.n)
+	if err != nil {
+		return err
+	}
+	err = ensureInMultiplicativeGroup(r, pub.n)
+	if err != nil {
+		return err
+	}
+	if pub.encrypt(m, r).Cmp(c) != 0 {
+		return ErrInvalidOpening
+	}
+	return nil
+}
+
+// NewPubKeyMessage returns our public key with the Paillier-Blum and the ring-Pedersen proofs bound to the session
+// and our id.
+func (p *Paillier) NewPubKeyMessage(sessionID []byte, id string) (*PubKeyMessage, error) {
+	blumMsg, err := zkproof.NewPaillierBlumMessage(sessionID, id, p.privateKey.p, p.privateKey.q, p.n)
+	if err != nil {
+		return nil, err
+	}
+	pedersenMsg, err := p.pedersen.NewMessage(sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+	return &PubKeyMessage{
+		Proof:             p.msg.Proof,
+		G:                 p.msg.G,
+		BlumProof:         blumMsg,
+		PedersenParameter: pedersenMsg,
+		SessionId:         sessionID,
+		Id:                id,
+	}, nil
+}
+
+func (p *Paillier) ToPubKeyBytesWithSession(sessionID []byte, id string) ([]byte, error) {
+	msg, err := p.NewPubKeyMessage(sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+// NewPubKeyFromBytes verifies the proofs with the session and the owner in the message. It is only used to restore
+// the public keys verified before.
 func (p *Paillier) NewPubKeyFromBytes(bs []byte) (homo.Pubkey, error) {
 	msg := &PubKeyMessage{}
 	err := proto.Unmarshal(bs, msg)
 	if err != nil {
 		return nil, err
 	}
-	return msg.ToPubkey()
+	return msg.ToPubkey(msg.GetSessionId(), msg.GetId())
+}
+
+func (p *Paillier) NewPubKeyFromBytesWithSession(sessionID []byte, id string, bs []byte) (homo.Pubkey, error) {
+	msg := &PubKeyMessage{}
+	err := proto.Unmarshal(bs, msg)
+	if err != nil {
+		return nil, err
+	}
+	return msg.ToPubkey(sessionID, id)
 }
 
 func (p *Paillier) GetMtaProof(curve elliptic.Curve, _ *big.Int, a *big.Int) ([]byte, error) {
@@ -212,16 +318,16 @@ func (p *Paillier) VerifyMtaProof(bs []byte, curve elliptic.Curve, _ *big.Int, _
 }
 
 // getNAndLambda returns N and lambda.
-// n = pq and lambda = lcm(p-1, q-1)
+// n = pq and lambda = lcm(p-1, q-1), where p = q = 3 mod 4 so that n is a Paillier-Blum modulus
 func getNAndLambda(keySize int) (*big.Int, *big.Int, *big.Int, *big.Int, error) {
 	pqSize := keySize / 2
 	for i := 0; i < maxGenN; i++ {
 		// random two primes p and q
-		p, err := rand.Prime(rand.Reader, pqSize)
+		p, err := randomBlumPrime(pqSize)
 		if err != nil {
 			return nil, nil, nil, nil, err
 		}
-		q, err := rand.Prime(rand.Reader, pqSize)
+		q, err := randomBlumPrime(pqSize)
 		if err != nil {
 			return nil, nil, nil, nil, err
 		}
@@ -252,6 +358,20 @@ func getNAndLambda(keySize int) (*big.Int, *big.Int, *big.Int, *big.Int, error)
 	return nil, nil, nil, nil, ErrExceedMaxRetry
 }
 
+// randomBlumPrime returns a random prime p = 3 mod 4.
+func randomBlumPrime(bits int) (*big.Int, error) {
+	for i := 0; i < maxGenN; i++ {
+		p, err := rand.Prime(rand.Reader, bits)
+		if err != nil {
+			return nil, err
+		}
+		if p.Bit(1) == 1 {
+			return p, nil
+		}
+	}
+	return nil, ErrExceedMaxRetry
+}
+
 func isCorrectCiphertext(c *big.Int, pubKey *publicKey) error {
 	// Ensure 0 < c < n^2
 	err := utils.InRange(c, big1, pubKey.nSquare)
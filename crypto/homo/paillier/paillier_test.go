@@ -98,6 +98,24 @@ var _ = Describe("Paillier test", func() {
 		Expect(p.publicKey.nSquare).Should(Equal(gotPub.nSquare))
 	})
 
+	It("ToPubKeyBytesWithSession()", func() {
+		sessionID := []byte("session id")
+		bs, err := p.ToPubKeyBytesWithSession(sessionID, "id")
+		Expect(err).Should(BeNil())
+		pubkey, err := p.NewPubKeyFromBytesWithSession(sessionID, "id", bs)
+		Expect(err).Should(BeNil())
+		Expect(pubkey.(*publicKey).n).Should(Equal(p.n))
+
+		By("Restore public key by the message bound to the session")
+		pubkey, err = p.NewPubKeyFromBytes(bs)
+		Expect(err).Should(BeNil())
+		Expect(pubkey.(*publicKey).n).Should(Equal(p.n))
+
+		pubkey, err = p.NewPubKeyFromBytesWithSession([]byte("another session id"), "id", bs)
+		Expect(err).Should(Equal(ErrInvalidMessage))
+		Expect(pubkey).Should(BeNil())
+	})
+
 	It("should be ok with zero messages", func() {
 		m := big0.Bytes()
 		c, err := p.Encrypt(m)
@@ -186,6 +204,52 @@ var _ = Describe("Paillier test", func() {
 		})
 	})
 
+	Context("Open()", func() {
+		var (
+			m *big.Int
+			c []byte
+		)
+		BeforeEach(func() {
+			var err error
+			m = big.NewInt(5566)
+			c, err = p.Encrypt(m.Bytes())
+			Expect(err).Should(BeNil())
+		})
+
+		It("should be ok", func() {
+			gotM, gotR, err := p.Open(c)
+			Expect(err).Should(BeNil())
+			Expect(gotM).Should(Equal(m))
+			Expect(VerifyOpening(p.GetPubKey(), c, gotM, gotR)).Should(BeNil())
+		})
+
+		It("another plaintext", func() {
+			_, gotR, err := p.Open(c)
+			Expect(err).Should(BeNil())
+			Expect(VerifyOpening(p.GetPubKey(), c, big.NewInt(5567), gotR)).Should(Equal(ErrInvalidOpening))
+		})
+
+		It("another randomness", func() {
+			gotM, gotR, err := p.Open(c)
+			Expect(err).Should(BeNil())
+			r := new(big.Int).Add(gotR, big1)
+			Expect(VerifyOpening(p.GetPubKey(), c, gotM, r)).Should(Equal(ErrInvalidOpening))
+		})
+
+		It("over range plaintext", func() {
+			_, gotR, err := p.Open(c)
+			Expect(err).Should(BeNil())
+			Expect(VerifyOpening(p.GetPubKey(), c, p.publicKey.n, gotR)).Should(Equal(utils.ErrNotInRange))
+		})
+
+		It("invalid ciphertext", func() {
+			gotM, gotR, err := p.Open(big0.Bytes())
+			Expect(err).Should(Equal(utils.ErrNotInRange))
+			Expect(gotM).Should(BeNil())
+			Expect(gotR).Should(BeNil())
+		})
+	})
+
 	DescribeTable("lFunction", func(x *big.Int, n *big.Int, exp *big.Int, expErr error) {
 		got, gotErr := lFunction(x, n)
 		if expErr != nil {
@@ -0,0 +1,136 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paillier
+
+import (
+	"math/big"
+
+	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/alice/crypto/zkproof"
+)
+
+// PedersenOpenParameter is the ring-Pedersen parameter (n, s, t) of the verifier in the range proofs. The prover
+// does not know the factorization of n or the discrete log of s to the base t.
+type PedersenOpenParameter struct {
+	n *big.Int
+	s *big.Int
+	t *big.Int
+}
+
+// NewPedersenOpenParameter verifies the proof of the ring-Pedersen parameter made by the owner id in the session and
+// returns the parameter.
+func NewPedersenOpenParameter(sessionID []byte, id string, msg *zkproof.RingPedersenParameterMessage) (*PedersenOpenParameter, error) {
+	err := msg.Verify(sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+	return &PedersenOpenParameter{
+		n: new(big.Int).SetBytes(msg.N),
+		s: new(big.Int).SetBytes(msg.S),
+		t: new(big.Int).SetBytes(msg.T),
+	}, nil
+}
+
+// GetPedersenOpenParameter returns the ring-Pedersen parameter of the Paillier public key, which is verified with the key.
+func GetPedersenOpenParameter(pubkey homo.Pubkey) (*PedersenOpenParameter, error) {
+	pub, err := toPublicKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	return pub.pedersen, nil
+}
+
+func (ped *PedersenOpenParameter) GetN() *big.Int {
+	return new(big.Int).Set(ped.n)
+}
+
+func (ped *PedersenOpenParameter) GetS() *big.Int {
+	return new(big.Int).Set(ped.s)
+}
+
+func (ped *PedersenOpenParameter) GetT() *big.Int {
+	return new(big.Int).Set(ped.t)
+}
+
+// VerifyNoSmallFactor verifies the proof that the modulus of the public key has no small factor under the parameter.
+func (ped *PedersenOpenParameter) VerifyNoSmallFactor(sessionID []byte, pubkey homo.Pubkey, msg *zkproof.NoSmallFactorMessage) error {
+	pub, err := toPublicKey(pubkey)
+	if err != nil {
+		return err
+	}
+	return msg.Verify(sessionID, pub.n, ped.n, ped.s, ped.t)
+}
+
+// commit computes s^x * t^y mod n
+func (ped *PedersenOpenParameter) commit(x *big.Int, y *big.Int) *big.Int {
+	result := new(big.Int).Exp(ped.s, x, ped.n)
+	result = result.Mul(result, new(big.Int).Exp(ped.t, y, ped.n))
+	return result.Mod(result, ped.n)
+}
+
+// NewNoSmallFactorMessage proves that our modulus has no small factor under the ring-Pedersen parameter of the verifier.
+func (p *Paillier) NewNoSmallFactorMessage(sessionID []byte, ped *PedersenOpenParameter) (*zkproof.NoSmallFactorMessage, error) {
+	return zkproof.NewNoSmallFactorMessage(sessionID, p.privateKey.p, p.privateKey.q, p.n, ped.n, ped.s, ped.t)
+}
+
+// PedersenParameter is our ring-Pedersen parameter over the Paillier modulus n, where s = t^lambda mod n.
+type PedersenParameter struct {
+	*PedersenOpenParameter
+
+	lambda *big.Int
+	order  *big.Int
+	msg    *zkproof.RingPedersenParameterMessage
+}
+
+// newPedersenParameter generates a ring-Pedersen parameter over n with the proof bound to no session. order is the
+// Carmichael function of n.
+func newPedersenParameter(n *big.Int, order *big.Int) (*PedersenParameter, error) {
+	tau, err := utils.RandomCoprimeInt(n)
+	if err != nil {
+		return nil, err
+	}
+	lambda, err := utils.RandomPositiveInt(order)
+	if err != nil {
+		return nil, err
+	}
+	// t = tau^2 mod n, so t is a quadratic residue
+	t := new(big.Int).Exp(tau, big2, n)
+	s := new(big.Int).Exp(t, lambda, n)
+	ped := &PedersenParameter{
+		PedersenOpenParameter: &PedersenOpenParameter{
+			n: n,
+			s: s,
+			t: t,
+		},
+		lambda: lambda,
+		order:  order,
+	}
+	ped.msg, err = ped.NewMessage(nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return ped, nil
+}
+
+// ToMessage returns the parameter with the proof of s = t^lambda mod n bound to no session
+func (ped *PedersenParameter) ToMessage() *zkproof.RingPedersenParameterMessage {
+	return ped.msg
+}
+
+// NewMessage returns the parameter with the proof of s = t^lambda mod n bound to the session and our id
+func (ped *PedersenParameter) NewMessage(sessionID []byte, id string) (*zkproof.RingPedersenParameterMessage, error) {
+	return zkproof.NewRingPedersenParameterMessage(sessionID, id, ped.order, ped.n, ped.s, ped.t, ped.lambda)
+}
@@ -0,0 +1,533 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paillier
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/alice/crypto/zkproof"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+var (
+	big3 = big.NewInt(3)
+	big7 = big.NewInt(7)
+)
+
+/*
+	The range proofs come from the appendix A of the paper "Fast Multiparty Threshold ECDSA with Fast Trustless
+	Setup" (i.e. GG18). We use the ring-Pedersen parameter (ñ, s, t) of the verifier as (Ñ, h1, h2) in the paper,
+	and q is the order of the curve.
+
+	Alice's range proof: Alice proves the plaintext m of her ciphertext c = g^m * r^n mod n^2 is in [0, q^3).
+	Step 1: Alice randomly chooses alpha in [0, q^3), beta in Z_n^*, gamma in [0, q^3*ñ), rho in [0, q*ñ) and computes
+	- z = s^m * t^rho mod ñ
+	- u = g^alpha * beta^n mod n^2
+	- w = s^alpha * t^gamma mod ñ
+	Step 2: Alice computes e := H(n, g, c, ñ, s, t, z, u, w, sid) in [0, q) and the proof (z, u, w, s', s1, s2), where
+	- s' = r^e * beta mod n
+	- s1 = e*m + alpha
+	- s2 = e*rho + gamma
+	Step 3: The verifier checks s1 < q^3, u = g^s1 * s'^n * c^-e mod n^2 and w = s^s1 * t^s2 * z^-e mod ñ.
+	In the proof with check (cf. Π^log* in CGGMP20), Alice also proves m is the discrete log of X = m*B for a base
+	point B. She sends y = alpha*B in the proof, B, X and y are hashed into e, and the verifier checks s1*B = e*X + y.
+
+	Bob's proof: Bob computes c2 = c1^x * g^y * r^n mod n^2 under Alice's public key, and proves x is in [0, q^3) and
+	y is in [0, q^7). In the proof with check, Bob also proves x is the discrete log of X = x*G.
+	Step 1: Bob randomly chooses alpha in [0, q^3), rho in [0, q*ñ), rho' in [0, q^3*ñ), sigma in [0, q*ñ),
+	beta in Z_n^*, gamma in [0, q^7), tau in [0, q^3*ñ) and computes
+	- z = s^x * t^rho mod ñ
+	- z' = s^alpha * t^rho' mod ñ
+	- t' = s^y * t^sigma mod ñ
+	- v = c1^alpha * g^gamma * beta^n mod n^2
+	- w = s^gamma * t^tau mod ñ
+	- u = alpha*G (only in the proof with check)
+	Step 2: Bob computes e := H(n, g, c1, c2, ñ, s, t, z, z', t', v, w, sid[, X, u]) in [0, q) and the proof, where
+	- s' = r^e * beta mod n
+	- s1 = e*x + alpha
+	- s2 = e*rho + rho'
+	- t1 = e*y + gamma
+	- t2 = e*sigma + tau
+	Step 3: The verifier checks s1 < q^3, t1 < q^7, s^s1 * t^s2 = z^e * z' mod ñ, s^t1 * t^t2 = t'^e * w mod ñ,
+	c1^s1 * g^t1 * s'^n = c2^e * v mod n^2 and s1*G = e*X + u in the proof with check.
+*/
+
+// NewRangeProofMessage returns Alice's range proof of our ciphertext c under the ring-Pedersen parameter of the verifier.
+func (p *Paillier) NewRangeProofMessage(sessionID []byte, q *big.Int, cBytes []byte, ped *PedersenOpenParameter) (*RangeProofMessage, error) {
+	return p.newRangeProofMessage(sessionID, q, cBytes, ped, nil)
+}
+
+// NewRangeProofMessageWithCheck returns Alice's range proof with check, which also shows the plaintext m of our
+// ciphertext c is the discrete log of m*base. The range is bounded by the order of the curve of the base.
+func (p *Paillier) NewRangeProofMessageWithCheck(sessionID []byte, cBytes []byte, ped *PedersenOpenParameter, base *pt.ECPoint) (*RangeProofMessage, error) {
+	return p.newRangeProofMessage(sessionID, base.GetCurve().Params().N, cBytes, ped, base)
+}
+
+func (p *Paillier) newRangeProofMessage(sessionID []byte, q *big.Int, cBytes []byte, ped *PedersenOpenParameter, base *pt.ECPoint) (*RangeProofMessage, error) {
+	mBytes, err := p.Decrypt(cBytes)
+	if err != nil {
+		return nil, err
+	}
+	c := new(big.Int).SetBytes(cBytes)
+	m := new(big.Int).SetBytes(mBytes)
+	r, err := p.getRandomness(c, m)
+	if err != nil {
+		return nil, err
+	}
+
+	q3 := new(big.Int).Exp(q, big3, nil)
+	alpha, err := utils.RandomInt(q3)
+	if err != nil {
+		return nil, err
+	}
+	beta, err := utils.RandomCoprimeInt(p.n)
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := utils.RandomInt(new(big.Int).Mul(q3, ped.n))
+	if err != nil {
+		return nil, err
+	}
+	rho, err := utils.RandomInt(new(big.Int).Mul(q, ped.n))
+	if err != nil {
+		return nil, err
+	}
+	z := ped.commit(m, rho)
+	u := p.publicKey.encrypt(alpha, beta)
+	w := ped.commit(alpha, gamma)
+
+	msgs := hashProofInputs(sessionID, p.n, p.g, c, ped.n, ped.s, ped.t, z, u, w)
+	var msgY *pt.EcPointMessage
+	if base != nil {
+		msgs, err = appendCheckInputs(msgs, base, base.ScalarMult(m))
+		if err != nil {
+			return nil, err
+		}
+		msgY, err = base.ScalarMult(alpha).ToEcPointMessage()
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msgY)
+	}
+	e, salt, err := utils.HashProtosRejectSampling(q, msgs...)
+	if err != nil {
+		return nil, err
+	}
+	s := new(big.Int).Exp(r, e, p.n)
+	s = s.Mul(s, beta)
+	s = s.Mod(s, p.n)
+	s1 := new(big.Int).Mul(e, m)
+	s1 = s1.Add(s1, alpha)
+	s2 := new(big.Int).Mul(e, rho)
+	s2 = s2.Add(s2, gamma)
+	return &RangeProofMessage{
+		Salt: salt,
+		Z:    z.Bytes(),
+		U:    u.Bytes(),
+		W:    w.Bytes(),
+		S:    s.Bytes(),
+		S1:   s1.Bytes(),
+		S2:   s2.Bytes(),
+		Y:    msgY,
+	}, nil
+}
+
+// Verify verifies Alice's range proof of the ciphertext c under our ring-Pedersen parameter.
+func (msg *RangeProofMessage) Verify(sessionID []byte, q *big.Int, pubkey homo.Pubkey, cBytes []byte, ped *PedersenOpenParameter) error {
+	return msg.verify(sessionID, q, pubkey, cBytes, ped, nil, nil)
+}
+
+// VerifyWithCheck verifies Alice's range proof with check that the plaintext of the ciphertext c is the discrete log
+// of X to the base.
+func (msg *RangeProofMessage) VerifyWithCheck(sessionID []byte, pubkey homo.Pubkey, cBytes []byte, ped *PedersenOpenParameter, base *pt.ECPoint, X *pt.ECPoint) error {
+	if !base.IsSameCurve(X) {
+		return pt.ErrDifferentCurve
+	}
+	return msg.verify(sessionID, base.GetCurve().Params().N, pubkey, cBytes, ped, base, X)
+}
+
+func (msg *RangeProofMessage) verify(sessionID []byte, q *big.Int, pubkey homo.Pubkey, cBytes []byte, ped *PedersenOpenParameter, base *pt.ECPoint, X *pt.ECPoint) error {
+	pub, err := toPublicKey(pubkey)
+	if err != nil {
+		return err
+	}
+	c := new(big.Int).SetBytes(cBytes)
+	err = isCorrectCiphertext(c, pub)
+	if err != nil {
+		return err
+	}
+	u := new(big.Int).SetBytes(msg.GetU())
+	err = isCorrectCiphertext(u, pub)
+	if err != nil {
+		return err
+	}
+	s := new(big.Int).SetBytes(msg.GetS())
+	err = ensureInMultiplicativeGroup(s, pub.n)
+	if err != nil {
+		return err
+	}
+	z := new(big.Int).SetBytes(msg.GetZ())
+	w := new(big.Int).SetBytes(msg.GetW())
+	for _, v := range []*big.Int{z, w} {
+		err = ensureInMultiplicativeGroup(v, ped.n)
+		if err != nil {
+			return err
+		}
+	}
+	// Check s1 < q^3
+	s1 := new(big.Int).SetBytes(msg.GetS1())
+	err = utils.InRange(s1, big0, new(big.Int).Exp(q, big3, nil))
+	if err != nil {
+		return err
+	}
+	s2 := new(big.Int).SetBytes(msg.GetS2())
+
+	msgs := hashProofInputs(sessionID, pub.n, pub.g, c, ped.n, ped.s, ped.t, z, u, w)
+	var y *pt.ECPoint
+	if base != nil {
+		msgs, err = appendCheckInputs(msgs, base, X)
+		if err != nil {
+			return err
+		}
+		y, err = msg.GetY().ToPoint()
+		if err != nil {
+			return err
+		}
+		if !y.IsSameCurve(base) {
+			return pt.ErrDifferentCurve
+		}
+		msgs = append(msgs, msg.GetY())
+	}
+	e, err := utils.HashProtosToInt(msg.GetSalt(), msgs...)
+	if err != nil {
+		return err
+	}
+	err = utils.InRange(e, big0, q)
+	if err != nil {
+		return err
+	}
+
+	// Check u * c^e = g^s1 * s^n mod n^2
+	left := new(big.Int).Exp(c, e, pub.nSquare)
+	left = left.Mul(left, u)
+	left = left.Mod(left, pub.nSquare)
+	if left.Cmp(pub.encrypt(s1, s)) != 0 {
+		return zkproof.ErrVerifyFailure
+	}
+	// Check w * z^e = s^s1 * t^s2 mod ñ
+	left = new(big.Int).Exp(z, e, ped.n)
+	left = left.Mul(left, w)
+	left = left.Mod(left, ped.n)
+	if left.Cmp(ped.commit(s1, s2)) != 0 {
+		return zkproof.ErrVerifyFailure
+	}
+	if base == nil {
+		return nil
+	}
+	// Check s1*B = e*X + y
+	expected, err := X.ScalarMult(e).Add(y)
+	if err != nil {
+		return err
+	}
+	if !base.ScalarMult(new(big.Int).Mod(s1, q)).Equal(expected) {
+		return zkproof.ErrVerifyFailure
+	}
+	return nil
+}
+
+// NewRespondentProofMessage computes c2 = c1^x * Enc(y) under the public key of the peer, and returns c2 with Bob's
+// proof under the ring-Pedersen parameter of the peer. If withCheck is true, the proof also shows x is the discrete
+// log of x*G, where G is the base point of the curve.
+func NewRespondentProofMessage(sessionID []byte, curve elliptic.Curve, pubkey homo.Pubkey, ped *PedersenOpenParameter, c1Bytes []byte, x *big.Int, y *big.Int, withCheck bool) ([]byte, *RespondentProofMessage, error) {
+	pub, err := toPublicKey(pubkey)
+	if err != nil {
+		return nil, nil, err
+	}
+	c1 := new(big.Int).SetBytes(c1Bytes)
+	err = isCorrectCiphertext(c1, pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := utils.RandomCoprimeInt(pub.n)
+	if err != nil {
+		return nil, nil, err
+	}
+	c2 := new(big.Int).Exp(c1, x, pub.nSquare)
+	c2 = c2.Mul(c2, pub.encrypt(y, r))
+	c2 = c2.Mod(c2, pub.nSquare)
+
+	q := curve.Params().N
+	q3 := new(big.Int).Exp(q, big3, nil)
+	qN := new(big.Int).Mul(q, ped.n)
+	q3N := new(big.Int).Mul(q3, ped.n)
+	alpha, err := utils.RandomInt(q3)
+	if err != nil {
+		return nil, nil, err
+	}
+	rho, err := utils.RandomInt(qN)
+	if err != nil {
+		return nil, nil, err
+	}
+	rhoPrime, err := utils.RandomInt(q3N)
+	if err != nil {
+		return nil, nil, err
+	}
+	sigma, err := utils.RandomInt(qN)
+	if err != nil {
+		return nil, nil, err
+	}
+	beta, err := utils.RandomCoprimeInt(pub.n)
+	if err != nil {
+		return nil, nil, err
+	}
+	gamma, err := utils.RandomInt(new(big.Int).Exp(q, big7, nil))
+	if err != nil {
+		return nil, nil, err
+	}
+	tau, err := utils.RandomInt(q3N)
+	if err != nil {
+		return nil, nil, err
+	}
+	z := ped.commit(x, rho)
+	zPrime := ped.commit(alpha, rhoPrime)
+	t := ped.commit(y, sigma)
+	v := new(big.Int).Exp(c1, alpha, pub.nSquare)
+	v = v.Mul(v, pub.encrypt(gamma, beta))
+	v = v.Mod(v, pub.nSquare)
+	w := ped.commit(gamma, tau)
+
+	msgs := hashProofInputs(sessionID, pub.n, pub.g, c1, c2, ped.n, ped.s, ped.t, z, zPrime, t, v, w)
+	var msgU *pt.EcPointMessage
+	if withCheck {
+		msgX, err := pt.ScalarBaseMult(curve, x).ToEcPointMessage()
+		if err != nil {
+			return nil, nil, err
+		}
+		msgU, err = pt.ScalarBaseMult(curve, alpha).ToEcPointMessage()
+		if err != nil {
+			return nil, nil, err
+		}
+		msgs = append(msgs, msgX, msgU)
+	}
+	e, salt, err := utils.HashProtosRejectSampling(q, msgs...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s := new(big.Int).Exp(r, e, pub.n)
+	s = s.Mul(s, beta)
+	s = s.Mod(s, pub.n)
+	s1 := new(big.Int).Mul(e, x)
+	s1 = s1.Add(s1, alpha)
+	s2 := new(big.Int).Mul(e, rho)
+	s2 = s2.Add(s2, rhoPrime)
+	t1 := new(big.Int).Mul(e, y)
+	t1 = t1.Add(t1, gamma)
+	t2 := new(big.Int).Mul(e, sigma)
+	t2 = t2.Add(t2, tau)
+	return c2.Bytes(), &RespondentProofMessage{
+		Salt:   salt,
+		Z:      z.Bytes(),
+		ZPrime: zPrime.Bytes(),
+		T:      t.Bytes(),
+		V:      v.Bytes(),
+		W:      w.Bytes(),
+		S:      s.Bytes(),
+		S1:     s1.Bytes(),
+		S2:     s2.Bytes(),
+		T1:     t1.Bytes(),
+		T2:     t2.Bytes(),
+		U:      msgU,
+	}, nil
+}
+
+// Verify verifies Bob's proof of c2 = c1^x * Enc(y) under our public key and ring-Pedersen parameter. If X is not nil,
+// it verifies the proof with check that x is the discrete log of X.
+func (msg *RespondentProofMessage) Verify(sessionID []byte, curve elliptic.Curve, pubkey homo.Pubkey, ped *PedersenOpenParameter, c1Bytes []byte, c2Bytes []byte, X *pt.ECPoint) error {
+	pub, err := toPublicKey(pubkey)
+	if err != nil {
+		return err
+	}
+	c1 := new(big.Int).SetBytes(c1Bytes)
+	c2 := new(big.Int).SetBytes(c2Bytes)
+	v := new(big.Int).SetBytes(msg.GetV())
+	for _, c := range []*big.Int{c1, c2, v} {
+		err = isCorrectCiphertext(c, pub)
+		if err != nil {
+			return err
+		}
+	}
+	s := new(big.Int).SetBytes(msg.GetS())
+	err = ensureInMultiplicativeGroup(s, pub.n)
+	if err != nil {
+		return err
+	}
+	z := new(big.Int).SetBytes(msg.GetZ())
+	zPrime := new(big.Int).SetBytes(msg.GetZPrime())
+	t := new(big.Int).SetBytes(msg.GetT())
+	w := new(big.Int).SetBytes(msg.GetW())
+	for _, v := range []*big.Int{z, zPrime, t, w} {
+		err = ensureInMultiplicativeGroup(v, ped.n)
+		if err != nil {
+			return err
+		}
+	}
+	// Check s1 < q^3 and t1 < q^7
+	q := curve.Params().N
+	s1 := new(big.Int).SetBytes(msg.GetS1())
+	err = utils.InRange(s1, big0, new(big.Int).Exp(q, big3, nil))
+	if err != nil {
+		return err
+	}
+	t1 := new(big.Int).SetBytes(msg.GetT1())
+	err = utils.InRange(t1, big0, new(big.Int).Exp(q, big7, nil))
+	if err != nil {
+		return err
+	}
+	s2 := new(big.Int).SetBytes(msg.GetS2())
+	t2 := new(big.Int).SetBytes(msg.GetT2())
+
+	msgs := hashProofInputs(sessionID, pub.n, pub.g, c1, c2, ped.n, ped.s, ped.t, z, zPrime, t, v, w)
+	var u *pt.ECPoint
+	if X != nil {
+		msgX, err := X.ToEcPointMessage()
+		if err != nil {
+			return err
+		}
+		u, err = msg.GetU().ToPoint()
+		if err != nil {
+			return err
+		}
+		if !u.IsSameCurve(X) || !X.IsSameCurve(pt.NewBase(curve)) {
+			return pt.ErrDifferentCurve
+		}
+		msgs = append(msgs, msgX, msg.GetU())
+	}
+	e, err := utils.HashProtosToInt(msg.GetSalt(), msgs...)
+	if err != nil {
+		return err
+	}
+	err = utils.InRange(e, big0, q)
+	if err != nil {
+		return err
+	}
+
+	// Check z^e * z' = s^s1 * t^s2 mod ñ
+	left := new(big.Int).Exp(z, e, ped.n)
+	left = left.Mul(left, zPrime)
+	left = left.Mod(left, ped.n)
+	if left.Cmp(ped.commit(s1, s2)) != 0 {
+		return zkproof.ErrVerifyFailure
+	}
+	// Check t'^e * w = s^t1 * t^t2 mod ñ
+	left = new(big.Int).Exp(t, e, ped.n)
+	left = left.Mul(left, w)
+	left = left.Mod(left, ped.n)
+	if left.Cmp(ped.commit(t1, t2)) != 0 {
+		return zkproof.ErrVerifyFailure
+	}
+	// Check c2^e * v = c1^s1 * g^t1 * s'^n mod n^2
+	left = new(big.Int).Exp(c2, e, pub.nSquare)
+	left = left.Mul(left, v)
+	left = left.Mod(left, pub.nSquare)
+	right := new(big.Int).Exp(c1, s1, pub.nSquare)
+	right = right.Mul(right, pub.encrypt(t1, s))
+	right = right.Mod(right, pub.nSquare)
+	if left.Cmp(right) != 0 {
+		return zkproof.ErrVerifyFailure
+	}
+	if X == nil {
+		return nil
+	}
+	// Check s1*G = e*X + u
+	expected, err := X.ScalarMult(e).Add(u)
+	if err != nil {
+		return err
+	}
+	if !pt.ScalarBaseMult(curve, new(big.Int).Mod(s1, q)).Equal(expected) {
+		return zkproof.ErrVerifyFailure
+	}
+	return nil
+}
+
+// getRandomness returns r of our ciphertext c = g^m * r^n mod n^2
+func (p *Paillier) getRandomness(c *big.Int, m *big.Int) (*big.Int, error) {
+	// r^n = c * g^-m mod n
+	rn := new(big.Int).ModInverse(new(big.Int).Exp(p.g, m, p.nSquare), p.nSquare)
+	if rn == nil {
+		return nil, ErrInvalidMessage
+	}
+	rn = rn.Mul(rn, c)
+	rn = rn.Mod(rn, p.n)
+	// r = (r^n)^(n^-1 mod lambda) mod n
+	nInverse := new(big.Int).ModInverse(p.n, p.privateKey.lambda)
+	if nInverse == nil {
+		return nil, ErrInvalidInput
+	}
+	return rn.Exp(rn, nInverse, p.n), nil
+}
+
+// encrypt computes g^m * r^n mod n^2
+func (pub *publicKey) encrypt(m *big.Int, r *big.Int) *big.Int {
+	result := new(big.Int).Exp(pub.g, m, pub.nSquare)
+	result = result.Mul(result, new(big.Int).Exp(r, pub.n, pub.nSquare))
+	return result.Mod(result, pub.nSquare)
+}
+
+func toPublicKey(pubkey homo.Pubkey) (*publicKey, error) {
+	pub, ok := pubkey.(*publicKey)
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+	return pub, nil
+}
+
+func ensureInMultiplicativeGroup(v *big.Int, n *big.Int) error {
+	err := utils.InRange(v, big1, n)
+	if err != nil {
+		return err
+	}
+	if !utils.IsRelativePrime(v, n) {
+		return ErrInvalidMessage
+	}
+	return nil
+}
+
+func hashProofInputs(sessionID []byte, values ...*big.Int) []proto.Message {
+	msgs := make([]proto.Message, len(values)+1)
+	for i, v := range values {
+		msgs[i] = &any.Any{Value: v.Bytes()}
+	}
+	msgs[len(values)] = &any.Any{Value: sessionID}
+	return msgs
+}
+
+// appendCheckInputs appends the base and X of the proof with check to the hash inputs
+func appendCheckInputs(msgs []proto.Message, base *pt.ECPoint, X *pt.ECPoint) ([]proto.Message, error) {
+	msgBase, err := base.ToEcPointMessage()
+	if err != nil {
+		return nil, err
+	}
+	msgX, err := X.ToEcPointMessage()
+	if err != nil {
+		return nil, err
+	}
+	return append(msgs, msgBase, msgX), nil
+}
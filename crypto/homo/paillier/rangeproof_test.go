@@ -0,0 +1,207 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paillier
+
+import (
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/alice/crypto/zkproof"
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Range proof test", func() {
+	var (
+		curve     = btcec.S256()
+		q         = curve.Params().N
+		sessionID = []byte("session id")
+
+		alice, bob   *Paillier
+		alicePed     *PedersenParameter
+		bobPed       *PedersenParameter
+		aliceOpenPed *PedersenOpenParameter
+		bobOpenPed   *PedersenOpenParameter
+	)
+
+	BeforeEach(func() {
+		if alice != nil {
+			return
+		}
+		var err error
+		alice, err = NewPaillier(2048)
+		Expect(err).Should(BeNil())
+		bob, err = NewPaillier(2048)
+		Expect(err).Should(BeNil())
+		alicePed = alice.GetPedersenParameter()
+		bobPed = bob.GetPedersenParameter()
+
+		aliceOpenPed, err = NewPedersenOpenParameter(nil, "", alicePed.ToMessage())
+		Expect(err).Should(BeNil())
+		bobOpenPed, err = NewPedersenOpenParameter(nil, "", bobPed.ToMessage())
+		Expect(err).Should(BeNil())
+	})
+
+	Context("Alice's range proof", func() {
+		It("should be ok", func() {
+			k, err := utils.RandomInt(q)
+			Expect(err).Should(BeNil())
+			c, err := alice.Encrypt(k.Bytes())
+			Expect(err).Should(BeNil())
+			msg, err := alice.NewRangeProofMessage(sessionID, q, c, bobOpenPed)
+			Expect(err).Should(BeNil())
+			Expect(msg.Verify(sessionID, q, alice.GetPubKey(), c, bobPed.PedersenOpenParameter)).Should(BeNil())
+			Expect(msg.Verify([]byte("another session id"), q, alice.GetPubKey(), c, bobPed.PedersenOpenParameter)).Should(Equal(zkproof.ErrVerifyFailure))
+		})
+
+		It("out-of-range plaintext", func() {
+			c, err := alice.Encrypt(new(big.Int).Exp(q, big.NewInt(4), nil).Bytes())
+			Expect(err).Should(BeNil())
+			msg, err := alice.NewRangeProofMessage(sessionID, q, c, bobOpenPed)
+			Expect(err).Should(BeNil())
+			Expect(msg.Verify(sessionID, q, alice.GetPubKey(), c, bobPed.PedersenOpenParameter)).Should(Equal(utils.ErrNotInRange))
+		})
+
+		It("should be ok with check", func() {
+			k, err := utils.RandomInt(q)
+			Expect(err).Should(BeNil())
+			c, err := alice.Encrypt(k.Bytes())
+			Expect(err).Should(BeNil())
+			r, err := utils.RandomPositiveInt(q)
+			Expect(err).Should(BeNil())
+			base := pt.ScalarBaseMult(curve, r)
+			msg, err := alice.NewRangeProofMessageWithCheck(sessionID, c, bobOpenPed, base)
+			Expect(err).Should(BeNil())
+			X := base.ScalarMult(k)
+			Expect(msg.VerifyWithCheck(sessionID, alice.GetPubKey(), c, bobPed.PedersenOpenParameter, base, X)).Should(BeNil())
+
+			// Unexpected X
+			X = base.ScalarMult(new(big.Int).Add(k, big1))
+			Expect(msg.VerifyWithCheck(sessionID, alice.GetPubKey(), c, bobPed.PedersenOpenParameter, base, X)).Should(Equal(zkproof.ErrVerifyFailure))
+			// Unexpected base
+			X = pt.ScalarBaseMult(curve, k)
+			Expect(msg.VerifyWithCheck(sessionID, alice.GetPubKey(), c, bobPed.PedersenOpenParameter, pt.NewBase(curve), X)).Should(Equal(zkproof.ErrVerifyFailure))
+		})
+
+		It("without the point in the proof with check", func() {
+			k, err := utils.RandomInt(q)
+			Expect(err).Should(BeNil())
+			c, err := alice.Encrypt(k.Bytes())
+			Expect(err).Should(BeNil())
+			msg, err := alice.NewRangeProofMessage(sessionID, q, c, bobOpenPed)
+			Expect(err).Should(BeNil())
+			Expect(msg.Y).Should(BeNil())
+			base := pt.NewBase(curve)
+			Expect(msg.VerifyWithCheck(sessionID, alice.GetPubKey(), c, bobPed.PedersenOpenParameter, base, base.ScalarMult(k))).Should(Equal(pt.ErrInvalidPoint))
+		})
+	})
+
+	Context("Bob's proof", func() {
+		var (
+			k, x, y *big.Int
+			c1      []byte
+		)
+
+		BeforeEach(func() {
+			var err error
+			k, err = utils.RandomInt(q)
+			Expect(err).Should(BeNil())
+			c1, err = alice.Encrypt(k.Bytes())
+			Expect(err).Should(BeNil())
+			x, err = utils.RandomInt(q)
+			Expect(err).Should(BeNil())
+			y, err = utils.RandomInt(new(big.Int).Exp(q, big.NewInt(5), nil))
+			Expect(err).Should(BeNil())
+		})
+
+		It("should be ok", func() {
+			c2, msg, err := NewRespondentProofMessage(sessionID, curve, alice.GetPubKey(), aliceOpenPed, c1, x, y, false)
+			Expect(err).Should(BeNil())
+			Expect(msg.U).Should(BeNil())
+			Expect(msg.Verify(sessionID, curve, alice.GetPubKey(), alicePed.PedersenOpenParameter, c1, c2, nil)).Should(BeNil())
+
+			// c2 is the encryption of k*x + y
+			got, err := alice.Decrypt(c2)
+			Expect(err).Should(BeNil())
+			expected := new(big.Int).Mul(k, x)
+			Expect(new(big.Int).SetBytes(got)).Should(Equal(expected.Add(expected, y)))
+		})
+
+		It("should be ok with check", func() {
+			c2, msg, err := NewRespondentProofMessage(sessionID, curve, alice.GetPubKey(), aliceOpenPed, c1, x, y, true)
+			Expect(err).Should(BeNil())
+			X := pt.ScalarBaseMult(curve, x)
+			Expect(msg.Verify(sessionID, curve, alice.GetPubKey(), alicePed.PedersenOpenParameter, c1, c2, X)).Should(BeNil())
+
+			// Unexpected X
+			X = pt.ScalarBaseMult(curve, new(big.Int).Add(x, big1))
+			Expect(msg.Verify(sessionID, curve, alice.GetPubKey(), alicePed.PedersenOpenParameter, c1, c2, X)).Should(Equal(zkproof.ErrVerifyFailure))
+		})
+
+		It("without the point in the proof with check", func() {
+			c2, msg, err := NewRespondentProofMessage(sessionID, curve, alice.GetPubKey(), aliceOpenPed, c1, x, y, false)
+			Expect(err).Should(BeNil())
+			X := pt.ScalarBaseMult(curve, x)
+			Expect(msg.Verify(sessionID, curve, alice.GetPubKey(), alicePed.PedersenOpenParameter, c1, c2, X)).Should(Equal(pt.ErrInvalidPoint))
+		})
+
+		It("out-of-range x", func() {
+			x = new(big.Int).Exp(q, big.NewInt(4), nil)
+			c2, msg, err := NewRespondentProofMessage(sessionID, curve, alice.GetPubKey(), aliceOpenPed, c1, x, y, false)
+			Expect(err).Should(BeNil())
+			Expect(msg.Verify(sessionID, curve, alice.GetPubKey(), alicePed.PedersenOpenParameter, c1, c2, nil)).Should(Equal(utils.ErrNotInRange))
+		})
+
+		It("out-of-range y", func() {
+			y = new(big.Int).Exp(q, big.NewInt(8), nil)
+			c2, msg, err := NewRespondentProofMessage(sessionID, curve, alice.GetPubKey(), aliceOpenPed, c1, x, y, false)
+			Expect(err).Should(BeNil())
+			Expect(msg.Verify(sessionID, curve, alice.GetPubKey(), alicePed.PedersenOpenParameter, c1, c2, nil)).Should(Equal(utils.ErrNotInRange))
+		})
+
+		It("another ciphertext", func() {
+			c2, msg, err := NewRespondentProofMessage(sessionID, curve, alice.GetPubKey(), aliceOpenPed, c1, x, y, false)
+			Expect(err).Should(BeNil())
+			c2, err = alice.Add(c2, c1)
+			Expect(err).Should(BeNil())
+			Expect(msg.Verify(sessionID, curve, alice.GetPubKey(), alicePed.PedersenOpenParameter, c1, c2, nil)).Should(Equal(zkproof.ErrVerifyFailure))
+		})
+	})
+
+	Context("Pedersen parameter", func() {
+		It("should be ok", func() {
+			Expect(aliceOpenPed).Should(Equal(alicePed.PedersenOpenParameter))
+			Expect(new(big.Int).Exp(alicePed.GetT(), alicePed.lambda, alicePed.GetN())).Should(Equal(alicePed.GetS()))
+		})
+
+		It("no small factor", func() {
+			msg, err := alice.NewNoSmallFactorMessage(sessionID, bobOpenPed)
+			Expect(err).Should(BeNil())
+			Expect(bobPed.VerifyNoSmallFactor(sessionID, alice.GetPubKey(), msg)).Should(BeNil())
+			Expect(bobPed.VerifyNoSmallFactor(sessionID, bob.GetPubKey(), msg)).Should(Equal(zkproof.ErrVerifyFailure))
+		})
+
+		It("altered s", func() {
+			msg := proto.Clone(alicePed.ToMessage()).(*zkproof.RingPedersenParameterMessage)
+			msg.S = bobPed.GetS().Bytes()
+			got, err := NewPedersenOpenParameter(nil, "", msg)
+			Expect(err).ShouldNot(BeNil())
+			Expect(got).Should(BeNil())
+		})
+	})
+})
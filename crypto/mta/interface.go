@@ -20,6 +20,7 @@ import (
 
 	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/homo/paillier"
 	"github.com/getamis/alice/crypto/zkproof"
 )
 
@@ -28,12 +29,14 @@ type Mta interface {
 	OverrideA(newA *big.Int) (Mta, error)
 	GetEncK() []byte
 	GetAG(curve elliptic.Curve) *pt.ECPoint
-	GetAProof(curve elliptic.Curve) (*zkproof.SchnorrProofMessage, error)
+	GetAProof(sessionID []byte, curve elliptic.Curve) (*zkproof.SchnorrProofMessage, error)
 	GetAK() *big.Int
 	GetProductWithK(v *big.Int) *big.Int
 	Decrypt(c *big.Int) (*big.Int, error)
 	Compute(publicKey homo.Pubkey, encMessage []byte) (*big.Int, *big.Int, error)
+	ComputeWithRangeProof(sessionID []byte, curve elliptic.Curve, publicKey homo.Pubkey, ped *paillier.PedersenOpenParameter, encMessage []byte, withCheck bool) (*big.Int, *big.Int, *paillier.RespondentProofMessage, error)
 	GetProofWithCheck(curve elliptic.Curve, beta *big.Int) ([]byte, error)
 	VerifyProofWithCheck(proof []byte, curve elliptic.Curve, alpha *big.Int) (*pt.ECPoint, error)
 	GetResult(alphas []*big.Int, betas []*big.Int) (*big.Int, error)
+	GetState() *State
 }
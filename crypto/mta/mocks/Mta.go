@@ -10,6 +10,8 @@ import (
 
 	homo "github.com/getamis/alice/crypto/homo"
 
+	paillier "github.com/getamis/alice/crypto/homo/paillier"
+
 	mock "github.com/stretchr/testify/mock"
 
 	mta "github.com/getamis/alice/crypto/mta"
@@ -54,6 +56,47 @@ func (_m *Mta) Compute(publicKey homo.Pubkey, encMessage []byte) (*big.Int, *big
 	return r0, r1, r2
 }
 
+// ComputeWithRangeProof provides a mock function with given fields: sessionID, curve, publicKey, ped, encMessage, withCheck
+func (_m *Mta) ComputeWithRangeProof(sessionID []byte, curve elliptic.Curve, publicKey homo.Pubkey, ped *paillier.PedersenOpenParameter, encMessage []byte, withCheck bool) (*big.Int, *big.Int, *paillier.RespondentProofMessage, error) {
+	ret := _m.Called(sessionID, curve, publicKey, ped, encMessage, withCheck)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func([]byte, elliptic.Curve, homo.Pubkey, *paillier.PedersenOpenParameter, []byte, bool) *big.Int); ok {
+		r0 = rf(sessionID, curve, publicKey, ped, encMessage, withCheck)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 *big.Int
+	if rf, ok := ret.Get(1).(func([]byte, elliptic.Curve, homo.Pubkey, *paillier.PedersenOpenParameter, []byte, bool) *big.Int); ok {
+		r1 = rf(sessionID, curve, publicKey, ped, encMessage, withCheck)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*big.Int)
+		}
+	}
+
+	var r2 *paillier.RespondentProofMessage
+	if rf, ok := ret.Get(2).(func([]byte, elliptic.Curve, homo.Pubkey, *paillier.PedersenOpenParameter, []byte, bool) *paillier.RespondentProofMessage); ok {
+		r2 = rf(sessionID, curve, publicKey, ped, encMessage, withCheck)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*paillier.RespondentProofMessage)
+		}
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func([]byte, elliptic.Curve, homo.Pubkey, *paillier.PedersenOpenParameter, []byte, bool) error); ok {
+		r3 = rf(sessionID, curve, publicKey, ped, encMessage, withCheck)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
 // Decrypt provides a mock function with given fields: c
 func (_m *Mta) Decrypt(c *big.Int) (*big.Int, error) {
 	ret := _m.Called(c)
@@ -109,13 +152,13 @@ func (_m *Mta) GetAK() *big.Int {
 	return r0
 }
 
-// GetAProof provides a mock function with given fields: curve
-func (_m *Mta) GetAProof(curve elliptic.Curve) (*zkproof.SchnorrProofMessage, error) {
-	ret := _m.Called(curve)
+// GetAProof provides a mock function with given fields: sessionID, curve
+func (_m *Mta) GetAProof(sessionID []byte, curve elliptic.Curve) (*zkproof.SchnorrProofMessage, error) {
+	ret := _m.Called(sessionID, curve)
 
 	var r0 *zkproof.SchnorrProofMessage
-	if rf, ok := ret.Get(0).(func(elliptic.Curve) *zkproof.SchnorrProofMessage); ok {
-		r0 = rf(curve)
+	if rf, ok := ret.Get(0).(func([]byte, elliptic.Curve) *zkproof.SchnorrProofMessage); ok {
+		r0 = rf(sessionID, curve)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*zkproof.SchnorrProofMessage)
@@ -123,8 +166,8 @@ func (_m *Mta) GetAProof(curve elliptic.Curve) (*zkproof.SchnorrProofMessage, er
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(elliptic.Curve) error); ok {
-		r1 = rf(curve)
+	if rf, ok := ret.Get(1).(func([]byte, elliptic.Curve) error); ok {
+		r1 = rf(sessionID, curve)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -210,6 +253,22 @@ func (_m *Mta) GetResult(alphas []*big.Int, betas []*big.Int) (*big.Int, error)
 	return r0, r1
 }
 
+// GetState provides a mock function with given fields:
+func (_m *Mta) GetState() *mta.State {
+	ret := _m.Called()
+
+	var r0 *mta.State
+	if rf, ok := ret.Get(0).(func() *mta.State); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*mta.State)
+		}
+	}
+
+	return r0
+}
+
 // OverrideA provides a mock function with given fields: newA
 func (_m *Mta) OverrideA(newA *big.Int) (mta.Mta, error) {
 	ret := _m.Called(newA)
@@ -21,6 +21,7 @@ import (
 
 	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/homo/paillier"
 	"github.com/getamis/alice/crypto/utils"
 	"github.com/getamis/alice/crypto/zkproof"
 )
@@ -30,6 +31,7 @@ var (
 	ErrInconsistentAlphaAndBeta = errors.New("inconsistent alpha and beta")
 
 	big0 = big.NewInt(0)
+	big5 = big.NewInt(5)
 )
 
 type mta struct {
@@ -64,6 +66,42 @@ func NewMta(fieldOrder *big.Int, homoCrypto homo.Crypto) (*mta, error) {
 	}, nil
 }
 
+// State is the secret state of a mta. It's used to checkpoint the process which owns the mta, so it must be kept private.
+type State struct {
+	K    *big.Int
+	A    *big.Int
+	EncK []byte
+}
+
+// NewMtaWithState rebuilds the mta from its state
+func NewMtaWithState(fieldOrder *big.Int, homoCrypto homo.Crypto, state *State) (*mta, error) {
+	err := utils.InRange(state.K, big0, fieldOrder)
+	if err != nil {
+		return nil, err
+	}
+	err = utils.InRange(state.A, big0, fieldOrder)
+	if err != nil {
+		return nil, err
+	}
+	return &mta{
+		fieldOrder: fieldOrder,
+		homoCrypto: homoCrypto,
+
+		k:    state.K,
+		encK: state.EncK,
+		a:    state.A,
+	}, nil
+}
+
+// GetState returns the state of the mta
+func (m *mta) GetState() *State {
+	return &State{
+		K:    new(big.Int).Set(m.k),
+		A:    new(big.Int).Set(m.a),
+		EncK: m.encK,
+	}
+}
+
 // OverrideA returns a new mta with new a
 func (m *mta) OverrideA(newA *big.Int) (Mta, error) {
 	err := utils.InRange(newA, big0, m.fieldOrder)
@@ -90,9 +128,9 @@ func (m *mta) GetAG(curve elliptic.Curve) *pt.ECPoint {
 	return pt.ScalarBaseMult(curve, m.a)
 }
 
-// GetAProof returns Schnorr proof message of a
-func (m *mta) GetAProof(curve elliptic.Curve) (*zkproof.SchnorrProofMessage, error) {
-	return zkproof.NewBaseSchorrMessage(curve, m.a)
+// GetAProof returns Schnorr proof message of a bound to the session id
+func (m *mta) GetAProof(sessionID []byte, curve elliptic.Curve) (*zkproof.SchnorrProofMessage, error) {
+	return zkproof.NewBaseSchorrMessageWithSession(sessionID, curve, m.a)
 }
 
 // GetAK returns ak
@@ -147,6 +185,21 @@ func (m *mta) Compute(publicKey homo.Pubkey, encMessage []byte) (*big.Int, *big.
 	return new(big.Int).SetBytes(r), new(big.Int).Neg(beta), nil
 }
 
+// ComputeWithRangeProof is Compute with Bob's range proof under the ring-Pedersen parameter of the peer, and the
+// public key must be Paillier. Beta is in [0, q^5) as the proof requires. If withCheck is true, the proof also shows
+// a is the discrete log of aG.
+func (m *mta) ComputeWithRangeProof(sessionID []byte, curve elliptic.Curve, publicKey homo.Pubkey, ped *paillier.PedersenOpenParameter, encMessage []byte, withCheck bool) (*big.Int, *big.Int, *paillier.RespondentProofMessage, error) {
+	beta, err := utils.RandomInt(new(big.Int).Exp(m.fieldOrder, big5, nil))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	r, proof, err := paillier.NewRespondentProofMessage(sessionID, curve, publicKey, ped, encMessage, m.a, beta, withCheck)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return new(big.Int).SetBytes(r), new(big.Int).Neg(beta), proof, nil
+}
+
 func (m *mta) GetProofWithCheck(curve elliptic.Curve, beta *big.Int) ([]byte, error) {
 	return m.homoCrypto.GetMtaProof(curve, beta, m.a)
 }
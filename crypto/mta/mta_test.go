@@ -188,10 +188,26 @@ var _ = Describe("Mta", func() {
 		})
 	})
 
+	Context("NewMtaWithState", func() {
+		It("should be ok", func() {
+			got, err := NewMtaWithState(fieldOrder, mockHomo, m.GetState())
+			Expect(err).Should(BeNil())
+			Expect(got).Should(Equal(m))
+		})
+
+		It("over field order", func() {
+			state := m.GetState()
+			state.K = fieldOrder
+			got, err := NewMtaWithState(fieldOrder, mockHomo, state)
+			Expect(err).Should(Equal(utils.ErrNotInRange))
+			Expect(got).Should(BeNil())
+		})
+	})
+
 	It("GetAProof", func() {
-		proof, err := m.GetAProof(curve)
+		proof, err := m.GetAProof([]byte("session"), curve)
 		Expect(err).Should(BeNil())
-		err = proof.Verify(pt.NewBase(curve))
+		err = proof.VerifyWithSession([]byte("session"), pt.NewBase(curve))
 		Expect(err).Should(BeNil())
 	})
 
@@ -242,4 +258,35 @@ var _ = Describe("Mta", func() {
 		Entry("CL", c1, c2),
 		Entry("paillier", p1, p2),
 	)
+
+	DescribeTable("ComputeWithRangeProof() should be ok", func(withCheck bool) {
+		sessionID := []byte("session id")
+		m1, err := NewMta(fieldOrder, p1)
+		Expect(err).Should(BeNil())
+		m2, err := NewMta(fieldOrder, p2)
+		Expect(err).Should(BeNil())
+		ped := p1.GetPedersenParameter()
+
+		m1EncryptedK := m1.GetEncK()
+		encMessage, beta, proof, err := m2.ComputeWithRangeProof(sessionID, curve, p1.GetPubKey(), ped.PedersenOpenParameter, m1EncryptedK, withCheck)
+		Expect(err).Should(BeNil())
+		var aG *pt.ECPoint
+		if withCheck {
+			aG = m2.GetAG(curve)
+		}
+		err = proof.Verify(sessionID, curve, p1.GetPubKey(), ped.PedersenOpenParameter, m1EncryptedK, encMessage.Bytes(), aG)
+		Expect(err).Should(BeNil())
+		alpha, err := m1.Decrypt(encMessage)
+		Expect(err).Should(BeNil())
+
+		r, err := m1.GetResult([]*big.Int{alpha}, []*big.Int{beta})
+		Expect(err).Should(BeNil())
+		k1a2 := new(big.Int).Mul(m1.k, m2.a)
+		exp := new(big.Int).Add(k1a2, m1.GetAK())
+		exp = new(big.Int).Mod(exp, m1.fieldOrder)
+		Expect(r).Should(Equal(exp))
+	},
+		Entry("without check", false),
+		Entry("with check", true),
+	)
 })
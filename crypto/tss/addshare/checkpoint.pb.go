@@ -0,0 +1,323 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/getamis/alice/crypto/tss/addshare/checkpoint.proto
+
+package addshare
+
+import (
+	fmt "fmt"
+	birkhoffinterpolation "github.com/getamis/alice/crypto/birkhoffinterpolation"
+	ecpointgrouplaw "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	message "github.com/getamis/alice/crypto/tss/message"
+	zkproof "github.com/getamis/alice/crypto/zkproof"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// OldPeerState is the checkpoint of an add share process of an old peer
+type OldPeerState struct {
+	Round       Type                                                 `protobuf:"varint,1,opt,name=round,proto3,enum=addshare.Type" json:"round,omitempty"`
+	SessionId   []byte                                               `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Pubkey      *ecpointgrouplaw.EcPointMessage                      `protobuf:"bytes,3,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	Threshold   uint32                                               `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Share       []byte                                               `protobuf:"bytes,5,opt,name=share,proto3" json:"share,omitempty"`
+	Bks         map[string]*birkhoffinterpolation.BkParameterMessage `protobuf:"bytes,6,rep,name=bks,proto3" json:"bks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	NewPeerId   string                                               `protobuf:"bytes,7,opt,name=new_peer_id,json=newPeerId,proto3" json:"new_peer_id,omitempty"`
+	SiGProofMsg *zkproof.SchnorrProofMessage                         `protobuf:"bytes,8,opt,name=siGProofMsg,proto3" json:"siGProofMsg,omitempty"`
+	// The fields below are set after the new bk round
+	Co     []byte `protobuf:"bytes,9,opt,name=co,proto3" json:"co,omitempty"`
+	DeltaI []byte `protobuf:"bytes,10,opt,name=delta_i,json=deltaI,proto3" json:"delta_i,omitempty"`
+	// messages are all the accepted messages
+	Messages []*Message `protobuf:"bytes,11,rep,name=messages,proto3" json:"messages,omitempty"`
+	// echoes are all the accepted echo messages of the rounds sent by echo broadcast
+	Echoes []*message.EchoMessage `protobuf:"bytes,12,rep,name=echoes,proto3" json:"echoes,omitempty"`
+	// echoTypes are the message types of the rounds sent by echo broadcast, which are enabled again after restoring
+	EchoTypes            []int32  `protobuf:"varint,13,rep,packed,name=echoTypes,proto3" json:"echoTypes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OldPeerState) Reset()         { *m = OldPeerState{} }
+func (m *OldPeerState) String() string { return proto.CompactTextString(m) }
+func (*OldPeerState) ProtoMessage()    {}
+func (*OldPeerState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_938befd3bd767430, []int{0}
+}
+
+func (m *OldPeerState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OldPeerState.Unmarshal(m, b)
+}
+func (m *OldPeerState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OldPeerState.Marshal(b, m, deterministic)
+}
+func (m *OldPeerState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OldPeerState.Merge(m, src)
+}
+func (m *OldPeerState) XXX_Size() int {
+	return xxx_messageInfo_OldPeerState.Size(m)
+}
+func (m *OldPeerState) XXX_DiscardUnknown() {
+	xxx_messageInfo_OldPeerState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OldPeerState proto.InternalMessageInfo
+
+func (m *OldPeerState) GetRound() Type {
+	if m != nil {
+		return m.Round
+	}
+	return Type_OldPeer
+}
+
+func (m *OldPeerState) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *OldPeerState) GetPubkey() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.Pubkey
+	}
+	return nil
+}
+
+func (m *OldPeerState) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *OldPeerState) GetShare() []byte {
+	if m != nil {
+		return m.Share
+	}
+	return nil
+}
+
+func (m *OldPeerState) GetBks() map[string]*birkhoffinterpolation.BkParameterMessage {
+	if m != nil {
+		return m.Bks
+	}
+	return nil
+}
+
+func (m *OldPeerState) GetNewPeerId() string {
+	if m != nil {
+		return m.NewPeerId
+	}
+	return ""
+}
+
+func (m *OldPeerState) GetSiGProofMsg() *zkproof.SchnorrProofMessage {
+	if m != nil {
+		return m.SiGProofMsg
+	}
+	return nil
+}
+
+func (m *OldPeerState) GetCo() []byte {
+	if m != nil {
+		return m.Co
+	}
+	return nil
+}
+
+func (m *OldPeerState) GetDeltaI() []byte {
+	if m != nil {
+		return m.DeltaI
+	}
+	return nil
+}
+
+func (m *OldPeerState) GetMessages() []*Message {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func (m *OldPeerState) GetEchoes() []*message.EchoMessage {
+	if m != nil {
+		return m.Echoes
+	}
+	return nil
+}
+
+func (m *OldPeerState) GetEchoTypes() []int32 {
+	if m != nil {
+		return m.EchoTypes
+	}
+	return nil
+}
+
+// NewPeerState is the checkpoint of an add share process of the new peer
+type NewPeerState struct {
+	Round       Type                            `protobuf:"varint,1,opt,name=round,proto3,enum=addshare.Type" json:"round,omitempty"`
+	SessionId   []byte                          `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Pubkey      *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,3,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	Threshold   uint32                          `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	NewPeerRank uint32                          `protobuf:"varint,5,opt,name=new_peer_rank,json=newPeerRank,proto3" json:"new_peer_rank,omitempty"`
+	// bk is set after the old peer round
+	Bk *birkhoffinterpolation.BkParameterMessage `protobuf:"bytes,6,opt,name=bk,proto3" json:"bk,omitempty"`
+	// messages are all the accepted messages
+	Messages []*Message `protobuf:"bytes,7,rep,name=messages,proto3" json:"messages,omitempty"`
+	// echoes are all the accepted echo messages of the rounds sent by echo broadcast
+	Echoes []*message.EchoMessage `protobuf:"bytes,8,rep,name=echoes,proto3" json:"echoes,omitempty"`
+	// echoTypes are the message types of the rounds sent by echo broadcast, which are enabled again after restoring
+	EchoTypes            []int32  `protobuf:"varint,9,rep,packed,name=echoTypes,proto3" json:"echoTypes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewPeerState) Reset()         { *m = NewPeerState{} }
+func (m *NewPeerState) String() string { return proto.CompactTextString(m) }
+func (*NewPeerState) ProtoMessage()    {}
+func (*NewPeerState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_938befd3bd767430, []int{1}
+}
+
+func (m *NewPeerState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewPeerState.Unmarshal(m, b)
+}
+func (m *NewPeerState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewPeerState.Marshal(b, m, deterministic)
+}
+func (m *NewPeerState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewPeerState.Merge(m, src)
+}
+func (m *NewPeerState) XXX_Size() int {
+	return xxx_messageInfo_NewPeerState.Size(m)
+}
+func (m *NewPeerState) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewPeerState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewPeerState proto.InternalMessageInfo
+
+func (m *NewPeerState) GetRound() Type {
+	if m != nil {
+		return m.Round
+	}
+	return Type_OldPeer
+}
+
+func (m *NewPeerState) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *NewPeerState) GetPubkey() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.Pubkey
+	}
+	return nil
+}
+
+func (m *NewPeerState) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *NewPeerState) GetNewPeerRank() uint32 {
+	if m != nil {
+		return m.NewPeerRank
+	}
+	return 0
+}
+
+func (m *NewPeerState) GetBk() *birkhoffinterpolation.BkParameterMessage {
+	if m != nil {
+		return m.Bk
+	}
+	return nil
+}
+
+func (m *NewPeerState) GetMessages() []*Message {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func (m *NewPeerState) GetEchoes() []*message.EchoMessage {
+	if m != nil {
+		return m.Echoes
+	}
+	return nil
+}
+
+func (m *NewPeerState) GetEchoTypes() []int32 {
+	if m != nil {
+		return m.EchoTypes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*OldPeerState)(nil), "addshare.OldPeerState")
+	proto.RegisterMapType((map[string]*birkhoffinterpolation.BkParameterMessage)(nil), "addshare.OldPeerState.BksEntry")
+	proto.RegisterType((*NewPeerState)(nil), "addshare.NewPeerState")
+}
+
+func init() {
+	proto.RegisterFile("github.com/getamis/alice/crypto/tss/addshare/checkpoint.proto", fileDescriptor_938befd3bd767430)
+}
+
+var fileDescriptor_938befd3bd767430 = []byte{
+	// 566 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x93, 0x5b, 0x6b, 0xdb, 0x30,
+	0x14, 0xc7, 0x71, 0xdc, 0xb8, 0xb1, 0x9c, 0x94, 0x4d, 0x14, 0x26, 0x42, 0xb7, 0x99, 0xb2, 0x07,
+	0x0f, 0x36, 0x99, 0x75, 0xec, 0xd6, 0xb1, 0x0e, 0x0a, 0x65, 0xf4, 0xa1, 0x5b, 0x70, 0xf7, 0x5e,
+	0x64, 0xf9, 0x34, 0x36, 0x72, 0x2c, 0x23, 0x29, 0x0b, 0xd9, 0xf3, 0x3e, 0xe5, 0x3e, 0xcd, 0xb0,
+	0xec, 0xa4, 0x17, 0x06, 0x59, 0xde, 0xf6, 0x26, 0x9d, 0x73, 0xfe, 0x3a, 0x97, 0xdf, 0x11, 0xfa,
+	0x34, 0x2d, 0x4c, 0x3e, 0x4f, 0x29, 0x97, 0xb3, 0x78, 0x0a, 0x86, 0xcd, 0x0a, 0x1d, 0xb3, 0xb2,
+	0xe0, 0x10, 0x73, 0xb5, 0xac, 0x8d, 0x8c, 0x8d, 0xd6, 0x31, 0xcb, 0x32, 0x9d, 0x33, 0x05, 0x31,
+	0xcf, 0x81, 0x8b, 0x5a, 0x16, 0x95, 0xa1, 0xb5, 0x92, 0x46, 0xe2, 0xc1, 0xca, 0x35, 0x3e, 0xd9,
+	0xf4, 0x50, 0x5a, 0x28, 0x91, 0xcb, 0xeb, 0xeb, 0xa2, 0x32, 0xa0, 0x6a, 0x59, 0x32, 0x53, 0xc8,
+	0x2a, 0x4e, 0x45, 0xfb, 0xd2, 0xf8, 0xe3, 0x26, 0x3d, 0x70, 0x9b, 0x78, 0xaa, 0xe4, 0xbc, 0x2e,
+	0xd9, 0x22, 0xbe, 0x55, 0xc6, 0xf8, 0xcd, 0x26, 0xf1, 0x4f, 0x51, 0x2b, 0x29, 0xaf, 0xe3, 0x19,
+	0x68, 0xcd, 0xa6, 0xd0, 0xc9, 0x8e, 0xb7, 0x6a, 0xfe, 0xae, 0xf6, 0xed, 0xbf, 0x68, 0x3b, 0x49,
+	0x0c, 0x3c, 0x97, 0xad, 0xee, 0xf0, 0xf7, 0x0e, 0x1a, 0x7e, 0x2b, 0xb3, 0x09, 0x80, 0xba, 0x34,
+	0xcc, 0x00, 0x7e, 0x86, 0xfa, 0x4a, 0xce, 0xab, 0x8c, 0x38, 0xa1, 0x13, 0xed, 0x1d, 0xed, 0xd1,
+	0x55, 0x42, 0xfa, 0x7d, 0x59, 0x43, 0xd2, 0x3a, 0xf1, 0x63, 0x84, 0x34, 0x68, 0x5d, 0xc8, 0xea,
+	0xaa, 0xc8, 0x48, 0x2f, 0x74, 0xa2, 0x61, 0xe2, 0x77, 0x96, 0xf3, 0x0c, 0xbf, 0x43, 0x5e, 0x3d,
+	0x4f, 0x05, 0x2c, 0x89, 0x1b, 0x3a, 0x51, 0x70, 0xf4, 0x94, 0xde, 0x1b, 0x17, 0x3d, 0xe3, 0x93,
+	0xe6, 0x7e, 0xd1, 0x56, 0x94, 0x74, 0xe1, 0xf8, 0x00, 0xf9, 0x26, 0x57, 0xa0, 0x73, 0x59, 0x66,
+	0x64, 0x27, 0x74, 0xa2, 0x51, 0x72, 0x63, 0xc0, 0xfb, 0xa8, 0x6f, 0x4b, 0x21, 0x7d, 0x9b, 0xb0,
+	0xbd, 0xe0, 0x57, 0xc8, 0x4d, 0x85, 0x26, 0x5e, 0xe8, 0xda, 0x4c, 0xeb, 0x7a, 0x6f, 0xb7, 0x45,
+	0x4f, 0x85, 0x3e, 0xab, 0x8c, 0x5a, 0x26, 0x4d, 0x2c, 0x7e, 0x82, 0x82, 0x0a, 0x16, 0x57, 0x35,
+	0x80, 0x6a, 0xea, 0xdf, 0x0d, 0x9d, 0xc8, 0x4f, 0xfc, 0x0a, 0x16, 0x8d, 0xe0, 0x3c, 0xc3, 0x27,
+	0x28, 0xd0, 0xc5, 0x97, 0x49, 0xc3, 0xe8, 0x42, 0x4f, 0xc9, 0xc0, 0x36, 0x71, 0x40, 0x3b, 0x6c,
+	0xf4, 0x92, 0xe7, 0x95, 0x54, 0xaa, 0xf5, 0x77, 0x1d, 0xdc, 0x16, 0xe0, 0x3d, 0xd4, 0xe3, 0x92,
+	0xf8, 0xb6, 0xca, 0x1e, 0x97, 0xf8, 0x11, 0xda, 0xcd, 0xa0, 0x34, 0xec, 0xaa, 0x20, 0xc8, 0x1a,
+	0x3d, 0x7b, 0x3d, 0xc7, 0x2f, 0xd1, 0xa0, 0x83, 0xa2, 0x49, 0x60, 0x1b, 0x78, 0x78, 0xd3, 0xc0,
+	0xea, 0xe9, 0x75, 0x08, 0x7e, 0x81, 0xbc, 0x86, 0x1d, 0x68, 0x32, 0xb4, 0xc1, 0xfb, 0xb4, 0x73,
+	0xd1, 0x33, 0x9e, 0xcb, 0xf5, 0x30, 0xdb, 0x98, 0x66, 0x98, 0xcd, 0xa9, 0xe1, 0xa6, 0xc9, 0x28,
+	0x74, 0xa3, 0x7e, 0x72, 0x63, 0x18, 0x33, 0x34, 0x58, 0x0d, 0x05, 0x3f, 0x40, 0x6e, 0x03, 0xcb,
+	0xb1, 0x73, 0x68, 0x8e, 0xf8, 0x33, 0xea, 0xff, 0x60, 0xe5, 0x1c, 0x2c, 0xdb, 0xe0, 0xe8, 0x39,
+	0xfd, 0xeb, 0x7f, 0xa1, 0xa7, 0x62, 0xc2, 0x14, 0x9b, 0x81, 0x01, 0xb5, 0xca, 0xde, 0xea, 0x8e,
+	0x7b, 0xef, 0x9d, 0xc3, 0x5f, 0x2e, 0x1a, 0x7e, 0x85, 0xc5, 0x9a, 0xc2, 0x7f, 0xbd, 0x5c, 0x87,
+	0x68, 0xb4, 0xde, 0x09, 0xc5, 0x2a, 0x61, 0x97, 0x6c, 0x94, 0x04, 0xdd, 0x56, 0x24, 0xac, 0x12,
+	0xf8, 0x03, 0xea, 0xa5, 0x82, 0x78, 0xdb, 0x8e, 0xa4, 0x97, 0x8a, 0x3b, 0xa4, 0x77, 0xb7, 0x21,
+	0x3d, 0xd8, 0x96, 0xb4, 0x7f, 0x8f, 0x74, 0xea, 0xd9, 0xaf, 0xfe, 0xfa, 0xcf, 0x00, 0xc5, 0x70,
+	0x6b, 0xe2, 0x5d, 0x05, 0x00, 0x00,
+}
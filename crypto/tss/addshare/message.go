@@ -19,6 +19,9 @@ import (
 )
 
 func (m *Message) IsValid() bool {
+	if len(m.GetSessionId()) == 0 {
+		return false
+	}
 	switch m.Type {
 	case Type_OldPeer:
 		return m.GetOldPeer() != nil
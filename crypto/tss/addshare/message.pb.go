@@ -58,8 +58,9 @@ func (Type) EnumDescriptor() ([]byte, []int) {
 }
 
 type Message struct {
-	Type Type   `protobuf:"varint,1,opt,name=type,proto3,enum=addshare.Type" json:"type,omitempty"`
-	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Type      Type   `protobuf:"varint,1,opt,name=type,proto3,enum=addshare.Type" json:"type,omitempty"`
+	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId []byte `protobuf:"bytes,8,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	// Types that are valid to be assigned to Body:
 	//	*Message_OldPeer
 	//	*Message_NewBk
@@ -111,6 +112,13 @@ func (m *Message) GetId() string {
 	return ""
 }
 
+func (m *Message) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
 type isMessage_Body interface {
 	isMessage_Body()
 }
@@ -440,38 +448,39 @@ func init() {
 }
 
 var fileDescriptor_3fbc6fdd3de40a9f = []byte{
-	// 526 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0x5f, 0x8b, 0xd3, 0x4e,
-	0x14, 0x6d, 0xb2, 0x6d, 0xba, 0xbd, 0xf9, 0xfd, 0x96, 0x32, 0xae, 0x10, 0x96, 0x05, 0x4b, 0x9e,
-	0xaa, 0x48, 0x82, 0x15, 0x11, 0x15, 0xf6, 0xa1, 0x8b, 0xda, 0x07, 0x57, 0x4b, 0x14, 0x5f, 0x97,
-	0xfc, 0xb9, 0x6d, 0x86, 0xa4, 0x99, 0x61, 0x32, 0xb5, 0xc4, 0x8f, 0xe6, 0x97, 0xf1, 0xab, 0x48,
-	0x66, 0xa6, 0x76, 0xbb, 0x14, 0x8a, 0xec, 0xdb, 0xcc, 0xbd, 0xe7, 0xdc, 0x3f, 0xe7, 0xcc, 0xc0,
-	0xdb, 0x25, 0x95, 0xf9, 0x3a, 0x09, 0x52, 0xb6, 0x0a, 0x97, 0x28, 0xe3, 0x15, 0xad, 0xc3, 0xb8,
-	0xa4, 0x29, 0x86, 0xa9, 0x68, 0xb8, 0x64, 0xa1, 0xac, 0xeb, 0x30, 0xce, 0xb2, 0x3a, 0x8f, 0x05,
-	0x86, 0x2b, 0xac, 0xeb, 0x78, 0x89, 0x01, 0x17, 0x4c, 0x32, 0x72, 0xba, 0x8d, 0x5f, 0x5c, 0x1d,
-	0xab, 0x92, 0x50, 0x51, 0xe4, 0x6c, 0xb1, 0xa0, 0x95, 0x44, 0xc1, 0x59, 0x19, 0x4b, 0xca, 0xaa,
-	0x30, 0x29, 0x74, 0xa5, 0x8b, 0x77, 0xc7, 0xf8, 0x98, 0x72, 0x46, 0x2b, 0xb9, 0x14, 0x6c, 0xcd,
-	0xcb, 0x78, 0x13, 0xaa, 0x9b, 0x21, 0xbf, 0x3a, 0x46, 0xfe, 0x59, 0x70, 0xc1, 0xd8, 0x62, 0x7f,
-	0x7a, 0xff, 0x97, 0x0d, 0xfd, 0x1b, 0x1d, 0x21, 0x3e, 0x74, 0x65, 0xc3, 0xd1, 0xb3, 0x46, 0xd6,
-	0xf8, 0x6c, 0x72, 0x16, 0x6c, 0x17, 0x0b, 0xbe, 0x35, 0x1c, 0x23, 0x95, 0x23, 0x67, 0x60, 0xd3,
-	0xcc, 0xb3, 0x47, 0xd6, 0x78, 0x10, 0xd9, 0x34, 0x23, 0x13, 0x38, 0x65, 0x65, 0x76, 0xcb, 0x11,
-	0x85, 0x77, 0x32, 0xb2, 0xc6, 0xee, 0xe4, 0xf1, 0x8e, 0x37, 0x65, 0x59, 0xf3, 0xa5, 0xcc, 0xe6,
-	0x88, 0x62, 0xd6, 0x89, 0xfa, 0x4c, 0x1f, 0xc9, 0x73, 0x70, 0x2a, 0xdc, 0xdc, 0x26, 0x85, 0xd7,
-	0x55, 0x8c, 0x47, 0xfb, 0x8c, 0xcf, 0xb8, 0x99, 0x16, 0xb3, 0x4e, 0xd4, 0xab, 0xda, 0x03, 0x79,
-	0x01, 0xfd, 0x94, 0xad, 0xf8, 0x5a, 0xa2, 0xd7, 0x3b, 0xd4, 0xe0, 0x5a, 0x27, 0xdb, 0x06, 0x06,
-	0x47, 0x02, 0x70, 0x04, 0xd6, 0xeb, 0x52, 0x7a, 0x8e, 0x62, 0x9c, 0xef, 0x33, 0x22, 0x95, 0x9b,
-	0x75, 0x22, 0x83, 0x6a, 0xf1, 0x3f, 0x50, 0xd0, 0x45, 0xe3, 0xf5, 0x0f, 0xe1, 0xbf, 0xab, 0x5c,
-	0x8b, 0xd7, 0xa8, 0xa9, 0x03, 0xdd, 0x84, 0x65, 0x8d, 0xff, 0xdb, 0x02, 0xf7, 0xce, 0x8e, 0xe4,
-	0x0d, 0xd8, 0x49, 0xa1, 0xe4, 0x73, 0x27, 0x4f, 0x83, 0x83, 0x6e, 0x07, 0xd3, 0x62, 0x1e, 0x8b,
-	0x78, 0x85, 0x12, 0x85, 0xd1, 0x3d, 0xb2, 0x93, 0x82, 0x5c, 0x81, 0x5b, 0xd3, 0x8f, 0xf3, 0xd6,
-	0xa1, 0x9b, 0x7a, 0xa9, 0x04, 0x76, 0x27, 0x97, 0x81, 0x31, 0x2d, 0xf8, 0x9a, 0xe6, 0x15, 0x13,
-	0x42, 0xe7, 0x0d, 0xed, 0x2e, 0x81, 0xbc, 0x06, 0x87, 0xaf, 0x93, 0x02, 0x1b, 0xe3, 0xc2, 0x93,
-	0xe0, 0xde, 0x63, 0x09, 0xde, 0xa7, 0xf3, 0xf6, 0xbe, 0x65, 0x1b, 0x38, 0xb9, 0x84, 0x81, 0xcc,
-	0x05, 0xd6, 0x39, 0x2b, 0x33, 0xe5, 0xc7, 0xff, 0xd1, 0x2e, 0xe0, 0x7f, 0x80, 0xc1, 0x5f, 0x4b,
-	0x1e, 0xb0, 0x9e, 0x9f, 0x6a, 0xa1, 0x8c, 0x57, 0xe4, 0x1c, 0x7a, 0x19, 0x96, 0x32, 0x56, 0xc5,
-	0xfe, 0x8b, 0xf4, 0xe5, 0xa1, 0x1a, 0xf8, 0x3e, 0xc0, 0xce, 0xde, 0xc3, 0x3d, 0xfc, 0x4f, 0x1a,
-	0xa3, 0x2d, 0xbd, 0xdf, 0xd1, 0xfa, 0xc7, 0x8e, 0xcf, 0xae, 0xa1, 0xdb, 0xfe, 0x0d, 0xe2, 0x42,
-	0xdf, 0xbc, 0x81, 0x61, 0x87, 0x0c, 0xa0, 0xa7, 0xf4, 0x1a, 0x5a, 0x6d, 0xdc, 0xac, 0x3c, 0xb4,
-	0x09, 0x80, 0xa3, 0x47, 0x1b, 0x9e, 0xb4, 0x67, 0x3d, 0xc2, 0xb0, 0x9b, 0x38, 0xea, 0x27, 0xbe,
-	0xfc, 0x13, 0x00, 0x00, 0xff, 0xff, 0x81, 0x4a, 0x19, 0x4a, 0x85, 0x04, 0x00, 0x00,
+	// 540 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xdf, 0x8b, 0xd3, 0x40,
+	0x10, 0x6e, 0x72, 0x6d, 0x7a, 0x9d, 0x9c, 0x47, 0x59, 0x4f, 0x08, 0xc7, 0x89, 0x25, 0x4f, 0x55,
+	0x24, 0xc1, 0x8a, 0x88, 0x0a, 0xf7, 0xd0, 0x43, 0xad, 0xe0, 0x69, 0x89, 0xe2, 0x6b, 0x49, 0xb2,
+	0xd3, 0x66, 0x49, 0x9a, 0x0d, 0xbb, 0x5b, 0x4b, 0xfc, 0x4f, 0x7d, 0xf2, 0x5f, 0x91, 0x6c, 0x52,
+	0xdb, 0x1e, 0x85, 0x22, 0xf7, 0xb6, 0x33, 0xf3, 0x7d, 0xf3, 0xeb, 0x9b, 0x85, 0xb7, 0x0b, 0xa6,
+	0x92, 0x55, 0xe4, 0xc5, 0x7c, 0xe9, 0x2f, 0x50, 0x85, 0x4b, 0x26, 0xfd, 0x30, 0x63, 0x31, 0xfa,
+	0xb1, 0x28, 0x0b, 0xc5, 0x7d, 0x25, 0xa5, 0x1f, 0x52, 0x2a, 0x93, 0x50, 0xa0, 0xbf, 0x44, 0x29,
+	0xc3, 0x05, 0x7a, 0x85, 0xe0, 0x8a, 0x93, 0xd3, 0x8d, 0xff, 0xf2, 0xfa, 0x58, 0x96, 0x88, 0x89,
+	0x34, 0xe1, 0xf3, 0x39, 0xcb, 0x15, 0x8a, 0x82, 0x67, 0xa1, 0x62, 0x3c, 0xf7, 0xa3, 0xb4, 0xce,
+	0x74, 0xf9, 0xee, 0x18, 0x1f, 0xe3, 0x82, 0xb3, 0x5c, 0x2d, 0x04, 0x5f, 0x15, 0x59, 0xb8, 0xf6,
+	0xb5, 0xd5, 0x90, 0x5f, 0x1d, 0x23, 0xff, 0x4a, 0x0b, 0xc1, 0xf9, 0x7c, 0xbf, 0x7b, 0xf7, 0xb7,
+	0x09, 0xdd, 0xdb, 0xda, 0x43, 0x5c, 0x68, 0xab, 0xb2, 0x40, 0xc7, 0x18, 0x18, 0xc3, 0xf3, 0xd1,
+	0xb9, 0xb7, 0x19, 0xcc, 0xfb, 0x5e, 0x16, 0x18, 0xe8, 0x18, 0x39, 0x07, 0x93, 0x51, 0xc7, 0x1c,
+	0x18, 0xc3, 0x5e, 0x60, 0x32, 0x4a, 0x1e, 0x03, 0x48, 0x94, 0x92, 0xf1, 0x7c, 0xc6, 0xa8, 0x73,
+	0x3a, 0x30, 0x86, 0x67, 0x41, 0xaf, 0xf1, 0x7c, 0xa2, 0x64, 0x04, 0xa7, 0x3c, 0xa3, 0xb3, 0x02,
+	0x51, 0x38, 0x27, 0x03, 0x63, 0x68, 0x8f, 0x1e, 0x6d, 0xd3, 0x8e, 0x39, 0x2d, 0xbf, 0x66, 0x74,
+	0x8a, 0x28, 0x26, 0xad, 0xa0, 0xcb, 0xeb, 0x27, 0x79, 0x0e, 0x56, 0x8e, 0xeb, 0x59, 0x94, 0x3a,
+	0x6d, 0xcd, 0x78, 0xb8, 0xcf, 0xf8, 0x82, 0xeb, 0x71, 0x3a, 0x69, 0x05, 0x9d, 0xbc, 0x7a, 0x90,
+	0x17, 0xd0, 0x8d, 0xf9, 0xb2, 0x58, 0x29, 0x74, 0x3a, 0x87, 0x0a, 0xdc, 0xd4, 0xc1, 0xaa, 0x40,
+	0x83, 0x23, 0x1e, 0x58, 0x02, 0xe5, 0x2a, 0x53, 0x8e, 0xa5, 0x19, 0x17, 0xfb, 0x8c, 0x40, 0xc7,
+	0x26, 0xad, 0xa0, 0x41, 0x55, 0xf8, 0x9f, 0x28, 0xd8, 0xbc, 0x74, 0xba, 0x87, 0xf0, 0x3f, 0x74,
+	0xac, 0xc2, 0xd7, 0xa8, 0xb1, 0x05, 0xed, 0x88, 0xd3, 0xd2, 0xfd, 0x63, 0x80, 0xbd, 0x33, 0x23,
+	0x79, 0x03, 0x66, 0x94, 0xea, 0xed, 0xda, 0xa3, 0xa7, 0xde, 0xc1, 0x63, 0xf0, 0xc6, 0xe9, 0x34,
+	0x14, 0xe1, 0x12, 0x15, 0x8a, 0x46, 0x96, 0xc0, 0x8c, 0x52, 0x72, 0x0d, 0xb6, 0x64, 0x1f, 0xa7,
+	0x95, 0x80, 0xb7, 0x72, 0xa1, 0xf7, 0x6f, 0x8f, 0xae, 0xbc, 0x46, 0x53, 0xef, 0x5b, 0x9c, 0xe4,
+	0x5c, 0x88, 0x3a, 0xde, 0xd0, 0x76, 0x09, 0xe4, 0x35, 0x58, 0xc5, 0x2a, 0x4a, 0xb1, 0x6c, 0x54,
+	0x78, 0xe2, 0xdd, 0xb9, 0x25, 0xef, 0x7d, 0x3c, 0xad, 0xec, 0x0d, 0xbb, 0x81, 0x93, 0x2b, 0xe8,
+	0xa9, 0x44, 0xa0, 0x4c, 0x78, 0x46, 0xb5, 0x1e, 0x0f, 0x82, 0xad, 0xc3, 0xfd, 0x00, 0xbd, 0x7f,
+	0x92, 0xdc, 0x63, 0x3c, 0x37, 0x06, 0x7b, 0x47, 0x2b, 0x72, 0x01, 0x1d, 0x8a, 0x99, 0x0a, 0x75,
+	0xb2, 0xb3, 0xa0, 0x36, 0xee, 0xbb, 0x03, 0xd7, 0x05, 0xd8, 0xca, 0x7b, 0xb8, 0x86, 0xfb, 0x19,
+	0x60, 0x2b, 0xe9, 0xdd, 0x8a, 0xc6, 0x7f, 0x56, 0x7c, 0x76, 0x03, 0xed, 0xea, 0xeb, 0x10, 0x1b,
+	0xba, 0xcd, 0x0d, 0xf4, 0x5b, 0xa4, 0x07, 0x1d, 0xbd, 0xaf, 0xbe, 0x51, 0xf9, 0x9b, 0x91, 0xfb,
+	0x26, 0x01, 0xb0, 0xea, 0xd6, 0xfa, 0x27, 0xd5, 0xbb, 0x6e, 0xa1, 0xdf, 0x8e, 0x2c, 0xfd, 0x51,
+	0x5f, 0xfe, 0x1d, 0x00, 0x22, 0x83, 0x94, 0x92, 0xa4, 0x04, 0x00, 0x00,
 }
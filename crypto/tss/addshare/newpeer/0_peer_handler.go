@@ -43,17 +43,19 @@ type peerHandler struct {
 	pubkey      *ecpointgrouplaw.ECPoint
 	threshold   uint32
 	newPeerRank uint32
+	sessionID   []byte
 
 	peerManager types.PeerManager
 	peerNum     uint32
 	peers       map[string]*peer
 }
 
-func newPeerHandler(peerManager types.PeerManager, pubkey *ecpointgrouplaw.ECPoint, threshold, newPeerRank uint32) *peerHandler {
+func newPeerHandler(peerManager types.PeerManager, sessionID []byte, pubkey *ecpointgrouplaw.ECPoint, threshold, newPeerRank uint32) *peerHandler {
 	return &peerHandler{
 		pubkey:      pubkey,
 		threshold:   threshold,
 		newPeerRank: newPeerRank,
+		sessionID:   sessionID,
 
 		peerManager: peerManager,
 		peerNum:     peerManager.NumPeers(),
@@ -98,7 +100,7 @@ func (p *peerHandler) HandleMessage(logger log.Logger, message types.Message) er
 		logger.Warn("Failed to get point", "err", err)
 		return err
 	}
-	err = siGProofMsg.Verify(ecpointgrouplaw.NewBase(pubkey.GetCurve()))
+	err = siGProofMsg.VerifyWithSession(p.sessionID, ecpointgrouplaw.NewBase(pubkey.GetCurve()))
 	if err != nil {
 		logger.Warn("Failed to verify Schorr proof", "err", err)
 		return err
@@ -114,14 +116,7 @@ func (p *peerHandler) HandleMessage(logger log.Logger, message types.Message) er
 }
 
 func (p *peerHandler) Finalize(logger log.Logger) (types.Handler, error) {
-	i := 0
-	bks := make(birkhoffinterpolation.BkParameters, p.peerNum)
-	sgs := make([]*ecpointgrouplaw.ECPoint, p.peerNum)
-	for _, peer := range p.peers {
-		bks[i] = peer.peer.bk
-		sgs[i] = peer.peer.siG
-		i++
-	}
+	bks, sgs := p.getBksAndSgs()
 
 	// The sum of siG must be equal to the given public key.
 	err := tss.ValidatePublicKey(logger, bks, sgs, p.threshold, p.pubkey)
@@ -135,8 +130,9 @@ func (p *peerHandler) Finalize(logger log.Logger) (types.Handler, error) {
 	}
 
 	msg := &addshare.Message{
-		Type: addshare.Type_NewBk,
-		Id:   p.peerManager.SelfID(),
+		Type:      addshare.Type_NewBk,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &addshare.Message_NewBk{
 			NewBk: &addshare.BodyNewBk{
 				Bk: selfBK.ToMessage(),
@@ -147,6 +143,19 @@ func (p *peerHandler) Finalize(logger log.Logger) (types.Handler, error) {
 	return newResultHandler(p, selfBK, bks, sgs), nil
 }
 
+// getBksAndSgs returns the bks and siGs of the old peers
+func (p *peerHandler) getBksAndSgs() (birkhoffinterpolation.BkParameters, []*ecpointgrouplaw.ECPoint) {
+	i := 0
+	bks := make(birkhoffinterpolation.BkParameters, p.peerNum)
+	sgs := make([]*ecpointgrouplaw.ECPoint, p.peerNum)
+	for _, peer := range p.peers {
+		bks[i] = peer.peer.bk
+		sgs[i] = peer.peer.siG
+		i++
+	}
+	return bks, sgs
+}
+
 func (p *peerHandler) broadcast(msg proto.Message) {
 	for id := range p.peers {
 		p.peerManager.MustSend(id, msg)
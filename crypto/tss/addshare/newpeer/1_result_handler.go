@@ -35,6 +35,7 @@ type resultHandler struct {
 	*peerHandler
 
 	share *big.Int
+	siG   *ecpointgrouplaw.ECPoint
 	bk    *birkhoffinterpolation.BkParameter
 	bks   birkhoffinterpolation.BkParameters
 	sgs   []*ecpointgrouplaw.ECPoint
@@ -109,15 +110,17 @@ func (r *resultHandler) Finalize(logger log.Logger) (types.Handler, error) {
 		return nil, err
 	}
 	r.share = share
+	r.siG = siG
 
-	siGProofMsg, err := zkproof.NewBaseSchorrMessage(curve, share)
+	siGProofMsg, err := zkproof.NewBaseSchorrMessageWithSession(r.sessionID, curve, share)
 	if err != nil {
 		log.Warn("Failed to new si schorr proof", "err", err)
 		return nil, err
 	}
 	msg := &addshare.Message{
-		Type: addshare.Type_Verify,
-		Id:   r.peerManager.SelfID(),
+		Type:      addshare.Type_Verify,
+		Id:        r.peerManager.SelfID(),
+		SessionId: r.sessionID,
 		Body: &addshare.Message_Verify{
 			Verify: &addshare.BodyVerify{
 				SiGProofMsg: siGProofMsg,
@@ -127,3 +130,13 @@ func (r *resultHandler) Finalize(logger log.Logger) (types.Handler, error) {
 	r.broadcast(msg)
 	return nil, nil
 }
+
+// getPublicShares returns the public shares of self and the old peers
+func (r *resultHandler) getPublicShares() map[string]*ecpointgrouplaw.ECPoint {
+	publicShares := make(map[string]*ecpointgrouplaw.ECPoint, len(r.peers)+1)
+	publicShares[r.peerManager.SelfID()] = r.siG
+	for id, peer := range r.peers {
+		publicShares[id] = peer.peer.siG
+	}
+	return publicShares
+}
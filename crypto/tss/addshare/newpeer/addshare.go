@@ -35,17 +35,26 @@ type Result struct {
 	PublicKey *ecpointgrouplaw.ECPoint
 	Share     *big.Int
 	Bks       map[string]*birkhoffinterpolation.BkParameter
+	// PublicShares are the verified public shares (i.e. s_i*G) of the participants, including self
+	PublicShares map[string]*ecpointgrouplaw.ECPoint
 }
 
-func NewAddShare(peerManager types.PeerManager, pubkey *ecpointgrouplaw.ECPoint, threshold, newPeerRank uint32, listener types.StateChangedListener) *AddShare {
-	ph := newPeerHandler(peerManager, pubkey, threshold, newPeerRank)
+func NewAddShare(peerManager types.PeerManager, sessionID []byte, pubkey *ecpointgrouplaw.ECPoint, threshold, newPeerRank uint32, listener types.StateChangedListener) (*AddShare, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	ph := newPeerHandler(peerManager, sessionID, pubkey, threshold, newPeerRank)
+	return newAddShareWithCurrentHandler(peerManager, sessionID, listener, ph, ph), nil
+}
+
+func newAddShareWithCurrentHandler(peerManager types.PeerManager, sessionID []byte, listener types.StateChangedListener, ph *peerHandler, handler types.Handler) *AddShare {
 	return &AddShare{
 		ph:      ph,
-		MsgMain: message.NewMsgMain(peerManager.SelfID(), peerManager.NumPeers(), listener, ph, types.MessageType(addshare.Type_OldPeer), types.MessageType(addshare.Type_Result)),
+		MsgMain: message.NewMsgMain(peerManager, sessionID, listener, handler, types.MessageType(addshare.Type_OldPeer), types.MessageType(addshare.Type_Result)),
 	}
 }
 
-// GetResult returns the final result: public key, share, bks (including self bk)
+// GetResult returns the final result: public key, share, bks and public shares (including self ones)
 func (a *AddShare) GetResult() (*Result, error) {
 	if a.GetState() != types.StateDone {
 		return nil, tss.ErrNotReady
@@ -65,8 +74,9 @@ func (a *AddShare) GetResult() (*Result, error) {
 		bks[id] = peer.peer.bk
 	}
 	return &Result{
-		PublicKey: rh.pubkey,
-		Share:     rh.share,
-		Bks:       bks,
+		PublicKey:    rh.pubkey,
+		Share:        rh.share,
+		Bks:          bks,
+		PublicShares: rh.getPublicShares(),
 	}, nil
 }
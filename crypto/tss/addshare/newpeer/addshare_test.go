@@ -44,6 +44,7 @@ var _ = Describe("AddShare", func() {
 		curve := btcec.S256()
 		fieldOrder := curve.Params().N
 		threshold := uint32(1)
+		sessionID := []byte("session")
 
 		// new peer static information
 		newPeerID := "id-new"
@@ -65,12 +66,13 @@ var _ = Describe("AddShare", func() {
 		Expect(err).Should(BeNil())
 		newPoly := poly.Differentiate(oldPeerRank)
 		oldPeerShare := newPoly.Evaluate(oldPeerX)
-		siGProofMsg, err := zkproof.NewBaseSchorrMessage(curve, oldPeerShare)
+		siGProofMsg, err := zkproof.NewBaseSchorrMessageWithSession(sessionID, curve, oldPeerShare)
 		Expect(err).Should(BeNil())
 		pm := newAddshareNewPeerManager(newPeerID, 1)
 
 		// Create and start a new addShare process.
-		addShare := NewAddShare(pm, pubkey, threshold, newPeerRank, listener)
+		addShare, err := NewAddShare(pm, sessionID, pubkey, threshold, newPeerRank, listener)
+		Expect(err).Should(BeNil())
 		r, err := addShare.GetResult()
 		Expect(r).Should(BeNil())
 		Expect(err).Should(Equal(tss.ErrNotReady))
@@ -78,8 +80,9 @@ var _ = Describe("AddShare", func() {
 
 		// Send the old peer information to the new peer.
 		oldPeerMsg := &addshare.Message{
-			Type: addshare.Type_OldPeer,
-			Id:   oldPeerID,
+			Type:      addshare.Type_OldPeer,
+			Id:        oldPeerID,
+			SessionId: sessionID,
 			Body: &addshare.Message_OldPeer{
 				OldPeer: &addshare.BodyOldPeer{
 					Bk:          oldPeerBk.ToMessage(),
@@ -109,8 +112,9 @@ var _ = Describe("AddShare", func() {
 
 		// Send delta to the new peer.
 		resultMsg := &addshare.Message{
-			Type: addshare.Type_Result,
-			Id:   oldPeerID,
+			Type:      addshare.Type_Result,
+			Id:        oldPeerID,
+			SessionId: sessionID,
 			Body: &addshare.Message_Result{
 				Result: &addshare.BodyResult{
 					Delta: delta.Bytes(),
@@ -130,6 +134,17 @@ var _ = Describe("AddShare", func() {
 		Expect(err).Should(BeNil())
 		Expect(r.Share).ShouldNot(BeNil())
 		Expect(r.PublicKey).Should(Equal(pubkey))
+		Expect(r.PublicShares).Should(HaveLen(2))
+		Expect(r.PublicShares[newPeerID].Equal(ecpointgrouplaw.ScalarBaseMult(curve, r.Share))).Should(BeTrue())
+		Expect(r.PublicShares[oldPeerID].Equal(ecpointgrouplaw.ScalarBaseMult(curve, oldPeerShare))).Should(BeTrue())
+		Expect(tss.ValidatePublicShares(log.Discard(), r.Bks, r.PublicShares, threshold, pubkey)).Should(BeNil())
+	})
+
+	It("empty session id", func() {
+		pubkey := ecpointgrouplaw.ScalarBaseMult(btcec.S256(), big.NewInt(1))
+		addShare, err := NewAddShare(newAddshareNewPeerManager("id-new", 1), nil, pubkey, 1, 0, nil)
+		Expect(err).Should(Equal(tss.ErrEmptySessionID))
+		Expect(addShare).Should(BeNil())
 	})
 })
 
@@ -153,6 +168,10 @@ func (p *addShareNewPeerManager) SelfID() string {
 	return p.id
 }
 
+func (p *addShareNewPeerManager) PeerIDs() []string {
+	return nil
+}
+
 func (p *addShareNewPeerManager) MustSend(id string, message proto.Message) {
 	// Do nothing.
 }
@@ -0,0 +1,106 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package newpeer
+
+import (
+	"bytes"
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/addshare"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/alice/crypto/zkproof"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoint", func() {
+	It("restores a crashed peer in the result round and finishes the process", func() {
+		curve := btcec.S256()
+		fieldOrder := curve.Params().N
+		key := bytes.Repeat([]byte{1}, tss.CheckpointKeySize)
+		sessionID := []byte("session")
+		oldPeerID := "id-old"
+		oldPeerBk := birkhoffinterpolation.NewBkParameter(big.NewInt(5), uint32(0))
+		oldPeerShare := big.NewInt(100)
+		pubkey := ecpointgrouplaw.ScalarBaseMult(curve, oldPeerShare)
+		pubkeyMsg, err := pubkey.ToEcPointMessage()
+		Expect(err).Should(BeNil())
+		siGProofMsg, err := zkproof.NewBaseSchorrMessageWithSession(sessionID, curve, oldPeerShare)
+		Expect(err).Should(BeNil())
+
+		listener := new(mocks.StateChangedListener)
+		listener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+		crashed, err := NewAddShare(newAddshareNewPeerManager("id-new", 1), sessionID, pubkey, 1, 0, listener)
+		Expect(err).Should(BeNil())
+		crashed.Start()
+		Expect(crashed.AddMessage(&addshare.Message{
+			Type:      addshare.Type_OldPeer,
+			Id:        oldPeerID,
+			SessionId: sessionID,
+			Body: &addshare.Message_OldPeer{
+				OldPeer: &addshare.BodyOldPeer{
+					Bk:          oldPeerBk.ToMessage(),
+					SiGProofMsg: siGProofMsg,
+					Pubkey:      pubkeyMsg,
+					Threshold:   1,
+				},
+			},
+		})).Should(BeNil())
+		time.Sleep(500 * time.Millisecond)
+		crashed.Stop()
+		checkpoint, err := crashed.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		restoredListener := new(mocks.StateChangedListener)
+		restoredListener.On("OnStateChanged", types.StateInit, types.StateDone).Once()
+		restored, err := RestoreAddShare(crashed.ph.peerManager, key, checkpoint, restoredListener)
+		Expect(err).Should(BeNil())
+		rh, ok := restored.GetHandler().(*resultHandler)
+		Expect(ok).Should(BeTrue())
+		Expect(rh.bk).Should(Equal(crashed.GetHandler().(*resultHandler).bk))
+		restored.Start()
+
+		// Send delta to the restored new peer.
+		bks := birkhoffinterpolation.BkParameters{oldPeerBk}
+		co, err := bks.GetAddShareCoefficient(oldPeerBk, rh.bk, fieldOrder, 1)
+		Expect(err).Should(BeNil())
+		delta := new(big.Int).Mul(co, oldPeerShare)
+		delta.Mod(delta, fieldOrder)
+		Expect(restored.AddMessage(&addshare.Message{
+			Type:      addshare.Type_Result,
+			Id:        oldPeerID,
+			SessionId: sessionID,
+			Body: &addshare.Message_Result{
+				Result: &addshare.BodyResult{
+					Delta: delta.Bytes(),
+				},
+			},
+		})).Should(BeNil())
+		time.Sleep(500 * time.Millisecond)
+
+		restored.Stop()
+		r, err := restored.GetResult()
+		Expect(err).Should(BeNil())
+		Expect(r.PublicKey).Should(Equal(pubkey))
+		listener.AssertExpectations(GinkgoT())
+		restoredListener.AssertExpectations(GinkgoT())
+	})
+})
@@ -40,13 +40,14 @@ type peerHandler struct {
 	bk          *birkhoffinterpolation.BkParameter
 	threshold   uint32
 	newPeer     *peer
+	sessionID   []byte
 
 	peerManager types.PeerManager
 	peerNum     uint32
 	peers       map[string]*peer
 }
 
-func newPeerHandler(peerManager types.PeerManager, pubkey *ecpointgrouplaw.ECPoint, threshold uint32, share *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, newPeerID string) (*peerHandler, error) {
+func newPeerHandler(peerManager types.PeerManager, sessionID []byte, pubkey *ecpointgrouplaw.ECPoint, threshold uint32, share *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, newPeerID string) (*peerHandler, error) {
 	numPeers := peerManager.NumPeers()
 	lenBks := len(bks)
 	if lenBks != int(numPeers+1) {
@@ -59,7 +60,7 @@ func newPeerHandler(peerManager types.PeerManager, pubkey *ecpointgrouplaw.ECPoi
 
 	curve := pubkey.GetCurve()
 	fieldOrder := curve.Params().N
-	siGProofMsg, err := zkproof.NewBaseSchorrMessage(curve, share)
+	siGProofMsg, err := zkproof.NewBaseSchorrMessageWithSession(sessionID, curve, share)
 	if err != nil {
 		log.Warn("Failed to new si schorr proof", "err", err)
 		return nil, err
@@ -78,6 +79,7 @@ func newPeerHandler(peerManager types.PeerManager, pubkey *ecpointgrouplaw.ECPoi
 		bk:          selfBK,
 		threshold:   threshold,
 		newPeer:     newPeer(newPeerID),
+		sessionID:   sessionID,
 
 		peerManager: peerManager,
 		peerNum:     numPeers,
@@ -94,6 +96,10 @@ func (p *peerHandler) GetRequiredMessageCount() uint32 {
 	return uint32(1)
 }
 
+func (p *peerHandler) GetRoundPeerIDs() []string {
+	return []string{p.newPeer.Id}
+}
+
 func (p *peerHandler) IsHandled(logger log.Logger, id string) bool {
 	if id != p.newPeer.Id {
 		logger.Warn("Get message from invalid peer")
@@ -155,8 +161,9 @@ func (p *peerHandler) Finalize(logger log.Logger) (types.Handler, error) {
 	for id := range p.peers {
 		// Send delta_i_j and siG to peer j.
 		computeMsg := &addshare.Message{
-			Type: addshare.Type_Compute,
-			Id:   p.peerManager.SelfID(),
+			Type:      addshare.Type_Compute,
+			Id:        p.peerManager.SelfID(),
+			SessionId: p.sessionID,
 			Body: &addshare.Message_Compute{
 				Compute: &addshare.BodyCompute{
 					Delta:       deltaIJ[i].Bytes(),
@@ -177,8 +184,9 @@ func (p *peerHandler) GetOldPeerMessage() *addshare.Message {
 		return nil
 	}
 	return &addshare.Message{
-		Type: addshare.Type_OldPeer,
-		Id:   p.peerManager.SelfID(),
+		Type:      addshare.Type_OldPeer,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &addshare.Message_OldPeer{
 			OldPeer: &addshare.BodyOldPeer{
 				Bk:          p.bk.ToMessage(),
@@ -56,6 +56,10 @@ var _ = Describe("peer handler, negative cases", func() {
 		})
 	})
 
+	It("GetRoundPeerIDs", func() {
+		Expect(ph.GetRoundPeerIDs()).Should(Equal([]string{peerID}))
+	})
+
 	Context("HandleMessage/Finalize", func() {
 		var (
 			curve       elliptic.Curve
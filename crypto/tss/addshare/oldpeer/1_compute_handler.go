@@ -56,6 +56,14 @@ func (p *computeHandler) GetRequiredMessageCount() uint32 {
 	return p.peerNum
 }
 
+func (p *computeHandler) GetRoundPeerIDs() []string {
+	ids := make([]string, 0, len(p.peers))
+	for id := range p.peers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 func (p *computeHandler) IsHandled(logger log.Logger, id string) bool {
 	peer, ok := p.peers[id]
 	if !ok {
@@ -85,7 +93,7 @@ func (p *computeHandler) HandleMessage(logger log.Logger, message types.Message)
 		logger.Warn("Failed to get point", "err", err)
 		return err
 	}
-	err = siGProofMsg.Verify(ecpointgrouplaw.NewBase(p.pubkey.GetCurve()))
+	err = siGProofMsg.VerifyWithSession(p.sessionID, ecpointgrouplaw.NewBase(p.pubkey.GetCurve()))
 	if err != nil {
 		logger.Warn("Failed to verify Schorr proof", "err", err)
 		return err
@@ -108,8 +116,9 @@ func (p *computeHandler) Finalize(logger log.Logger) (types.Handler, error) {
 
 	// Send the new delta_i to the new peer.
 	msg := &addshare.Message{
-		Type: addshare.Type_Result,
-		Id:   p.peerManager.SelfID(),
+		Type:      addshare.Type_Result,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &addshare.Message_Result{
 			Result: &addshare.BodyResult{
 				Delta: p.deltaI.Bytes(),
@@ -60,6 +60,11 @@ var _ = Describe("compute handler, negative cases", func() {
 		})
 	})
 
+	It("GetRoundPeerIDs", func() {
+		ch.peers[peerID] = &peer{}
+		Expect(ch.GetRoundPeerIDs()).Should(Equal([]string{peerID}))
+	})
+
 	Context("HandleMessage", func() {
 		var (
 			err         error
@@ -48,6 +48,10 @@ func (p *verifyHandler) GetRequiredMessageCount() uint32 {
 	return uint32(1)
 }
 
+func (p *verifyHandler) GetRoundPeerIDs() []string {
+	return []string{p.newPeer.Id}
+}
+
 func (p *verifyHandler) IsHandled(logger log.Logger, id string) bool {
 	if id != p.newPeer.Id {
 		logger.Warn("Get message from invalid peer")
@@ -70,7 +74,7 @@ func (p *verifyHandler) HandleMessage(logger log.Logger, message types.Message)
 		logger.Warn("Failed to get point", "err", err)
 		return err
 	}
-	err = siGProofMsg.Verify(ecpointgrouplaw.NewBase(p.pubkey.GetCurve()))
+	err = siGProofMsg.VerifyWithSession(p.sessionID, ecpointgrouplaw.NewBase(p.pubkey.GetCurve()))
 	if err != nil {
 		logger.Warn("Failed to verify Schorr proof", "err", err)
 		return err
@@ -107,3 +111,18 @@ func (p *verifyHandler) Finalize(logger log.Logger) (types.Handler, error) {
 
 	return nil, tss.ValidatePublicKey(logger, bks, sgs, p.threshold, p.pubkey)
 }
+
+// getPublicShares returns the public shares of self, the old peers and the new peer
+func (p *verifyHandler) getPublicShares() (map[string]*ecpointgrouplaw.ECPoint, error) {
+	siG, err := p.siGProofMsg.V.ToPoint()
+	if err != nil {
+		return nil, err
+	}
+	publicShares := make(map[string]*ecpointgrouplaw.ECPoint, p.peerNum+2)
+	publicShares[p.peerManager.SelfID()] = siG
+	publicShares[p.newPeer.Id] = p.newPeer.verify.siG
+	for id, peer := range p.peers {
+		publicShares[id] = peer.compute.siG
+	}
+	return publicShares, nil
+}
@@ -35,21 +35,29 @@ type Result struct {
 	PublicKey *ecpointgrouplaw.ECPoint
 	Share     *big.Int
 	Bks       map[string]*birkhoffinterpolation.BkParameter
+	// PublicShares are the verified public shares (i.e. s_i*G) of the participants, including self
+	PublicShares map[string]*ecpointgrouplaw.ECPoint
 }
 
-func NewAddShare(peerManager types.PeerManager, pubkey *ecpointgrouplaw.ECPoint, threshold uint32, share *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, newPeerID string, listener types.StateChangedListener) (*AddShare, error) {
-	peerNum := peerManager.NumPeers()
-	ph, err := newPeerHandler(peerManager, pubkey, threshold, share, bks, newPeerID)
+func NewAddShare(peerManager types.PeerManager, sessionID []byte, pubkey *ecpointgrouplaw.ECPoint, threshold uint32, share *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, newPeerID string, listener types.StateChangedListener) (*AddShare, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	ph, err := newPeerHandler(peerManager, sessionID, pubkey, threshold, share, bks, newPeerID)
 	if err != nil {
 		return nil, err
 	}
+	return newAddShareWithCurrentHandler(peerManager, sessionID, listener, ph, ph), nil
+}
+
+func newAddShareWithCurrentHandler(peerManager types.PeerManager, sessionID []byte, listener types.StateChangedListener, ph *peerHandler, handler types.Handler) *AddShare {
 	return &AddShare{
 		ph:      ph,
-		MsgMain: message.NewMsgMain(peerManager.SelfID(), peerNum, listener, ph, types.MessageType(addshare.Type_NewBk), types.MessageType(addshare.Type_Compute), types.MessageType(addshare.Type_Verify)),
-	}, nil
+		MsgMain: message.NewMsgMain(peerManager, sessionID, listener, handler, types.MessageType(addshare.Type_NewBk), types.MessageType(addshare.Type_Compute), types.MessageType(addshare.Type_Verify)),
+	}
 }
 
-// GetResult returns the final result: public key, share, bks (including self bk)
+// GetResult returns the final result: public key, share, bks and public shares (including self ones)
 func (a *AddShare) GetResult() (*Result, error) {
 	if a.GetState() != types.StateDone {
 		return nil, tss.ErrNotReady
@@ -62,6 +70,12 @@ func (a *AddShare) GetResult() (*Result, error) {
 		return nil, tss.ErrNotReady
 	}
 
+	publicShares, err := ch.getPublicShares()
+	if err != nil {
+		log.Error("Failed to get public shares", "err", err)
+		return nil, err
+	}
+
 	// Total bks = peer bks + self bk + new bk
 	bks := make(map[string]*birkhoffinterpolation.BkParameter, a.ph.peerManager.NumPeers()+2)
 	bks[a.ph.peerManager.SelfID()] = a.ph.bk
@@ -70,9 +84,10 @@ func (a *AddShare) GetResult() (*Result, error) {
 		bks[id] = peer.peer.bk
 	}
 	return &Result{
-		PublicKey: ch.pubkey,
-		Share:     ch.share,
-		Bks:       bks,
+		PublicKey:    ch.pubkey,
+		Share:        ch.share,
+		Bks:          bks,
+		PublicShares: publicShares,
 	}, nil
 }
 
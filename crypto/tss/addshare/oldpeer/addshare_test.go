@@ -60,8 +60,9 @@ var _ = Describe("AddShare", func() {
 
 		// Send the new peer bk to the old peer.
 		newBkMsg := &addshare.Message{
-			Type: addshare.Type_NewBk,
-			Id:   newPeerID,
+			Type:      addshare.Type_NewBk,
+			Id:        newPeerID,
+			SessionId: sessionID,
 			Body: &addshare.Message_NewBk{
 				NewBk: &addshare.BodyNewBk{
 					Bk: newBk.ToMessage(),
@@ -86,13 +87,14 @@ var _ = Describe("AddShare", func() {
 		newShare.Mod(newShare, curve.Params().N)
 
 		// Build the new peer's siG proof
-		siGProofMsg, err := zkproof.NewBaseSchorrMessage(curve, newShare)
+		siGProofMsg, err := zkproof.NewBaseSchorrMessageWithSession(sessionID, curve, newShare)
 		Expect(err).Should(BeNil())
 
 		// Send the new peer siG proof to the old peer.
 		verifyMsg := &addshare.Message{
-			Type: addshare.Type_Verify,
-			Id:   newPeerID,
+			Type:      addshare.Type_Verify,
+			Id:        newPeerID,
+			SessionId: sessionID,
 			Body: &addshare.Message_Verify{
 				Verify: &addshare.BodyVerify{
 					SiGProofMsg: siGProofMsg,
@@ -115,6 +117,9 @@ var _ = Describe("AddShare", func() {
 			Expect(err).Should(BeNil())
 			Expect(r.Share).ShouldNot(BeNil())
 			Expect(r.Bks[newPeerID]).ShouldNot(BeNil())
+			Expect(r.PublicShares).Should(HaveLen(len(bks) + 1))
+			Expect(r.PublicShares[newPeerID].Equal(ecpointgrouplaw.ScalarBaseMult(curve, newShare))).Should(BeTrue())
+			Expect(tss.ValidatePublicShares(log.Discard(), r.Bks, r.PublicShares, threshold, r.PublicKey)).Should(BeNil())
 		}
 	},
 		Entry("Case #0", uint32(3),
@@ -180,7 +185,7 @@ var _ = Describe("AddShare", func() {
 			listener[i].On("OnStateChanged", types.StateInit, types.StateFailed).Once()
 			tempPoly := poly.Differentiate(ranks[i])
 			oldShare := tempPoly.Evaluate(xs[i])
-			addShares[id], err = NewAddShare(peerManagers[i], pubkey, threshold, oldShare, bks, newPeerID, listener[i])
+			addShares[id], err = NewAddShare(peerManagers[i], sessionID, pubkey, threshold, oldShare, bks, newPeerID, listener[i])
 			Expect(err).Should(Equal(tss.ErrInconsistentPeerNumAndBks))
 		}
 	})
@@ -220,7 +225,7 @@ var _ = Describe("AddShare", func() {
 			listener[i].On("OnStateChanged", types.StateInit, types.StateFailed).Once()
 			tempPoly := poly.Differentiate(ranks[i])
 			oldShare := tempPoly.Evaluate(xs[i])
-			addShares[id], err = NewAddShare(peerManagers[i], pubkey, threshold, oldShare, bks, newPeerID, listener[i])
+			addShares[id], err = NewAddShare(peerManagers[i], sessionID, pubkey, threshold, oldShare, bks, newPeerID, listener[i])
 			Expect(err).Should(Equal(utils.ErrLargeThreshold))
 		}
 	})
@@ -252,9 +257,16 @@ var _ = Describe("AddShare", func() {
 		pm.setAddshares(addShares)
 		listener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
 		var err error
-		_, err = NewAddShare(pm, pubkey, threshold, oldShare, bks, newPeerID, listener)
+		_, err = NewAddShare(pm, sessionID, pubkey, threshold, oldShare, bks, newPeerID, listener)
 		Expect(err).Should(Equal(tss.ErrSelfBKNotFound))
 	})
+
+	It("empty session id", func() {
+		pubkey := ecpointgrouplaw.ScalarBaseMult(curve, big.NewInt(100))
+		addShare, err := NewAddShare(newAddshareOldPeerManager(getID(0), newPeerID, 2), nil, pubkey, 2, big.NewInt(50), nil, newPeerID, nil)
+		Expect(err).Should(Equal(tss.ErrEmptySessionID))
+		Expect(addShare).Should(BeNil())
+	})
 })
 
 func newAddShares(c elliptic.Curve, threshold uint32, bks []*birkhoffinterpolation.BkParameter, newPeerID string) (map[string]*AddShare, map[string]*mocks.StateChangedListener) {
@@ -284,7 +296,7 @@ func newAddShares(c elliptic.Curve, threshold uint32, bks []*birkhoffinterpolati
 		listeners[id] = new(mocks.StateChangedListener)
 		tempPoly := poly.Differentiate(bks[i].GetRank())
 		oldShare := tempPoly.Evaluate(bks[i].GetX())
-		addShares[id], err = NewAddShare(peerManagers[i], pubkey, threshold, oldShare, bksMap, newPeerID, listeners[id])
+		addShares[id], err = NewAddShare(peerManagers[i], sessionID, pubkey, threshold, oldShare, bksMap, newPeerID, listeners[id])
 		Expect(err).Should(BeNil())
 		r, err := addShares[id].GetResult()
 		Expect(r).Should(BeNil())
@@ -294,6 +306,8 @@ func newAddShares(c elliptic.Curve, threshold uint32, bks []*birkhoffinterpolati
 	return addShares, listeners
 }
 
+var sessionID = []byte("session")
+
 func getID(id int) string {
 	return fmt.Sprintf("id-%d", id)
 }
@@ -325,6 +339,16 @@ func (p *addShareOldPeerManager) SelfID() string {
 	return p.id
 }
 
+func (p *addShareOldPeerManager) PeerIDs() []string {
+	ids := make([]string, 0, len(p.addSharesForOld))
+	for id := range p.addSharesForOld {
+		if id != p.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func (p *addShareOldPeerManager) MustSend(id string, message proto.Message) {
 	msg := message.(types.Message)
 	if id == p.newPeerID {
@@ -0,0 +1,163 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oldpeer
+
+import (
+	"math/big"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/addshare"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+const checkpointLabel = "addshare-oldpeer"
+
+// Checkpoint returns the encrypted state of the process, including the share and the collected messages.
+// It could be restored by RestoreAddShare after the process crashes.
+func (a *AddShare) Checkpoint(key []byte) ([]byte, error) {
+	var state *addshare.OldPeerState
+	err := a.Snapshot(func(handler types.Handler, msgs []types.Message, echoes []*message.EchoMessage) error {
+		var err error
+		state, err = a.ph.getState(handler, msgs, echoes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, msgType := range a.EchoBroadcastTypes() {
+		state.EchoTypes = append(state.EchoTypes, int32(msgType))
+	}
+	return tss.EncryptCheckpoint(key, checkpointLabel, state)
+}
+
+// RestoreAddShare restores the process from the checkpoint. The process continues from the round in which
+// the checkpoint was made. The peer manager must manage the same peers as before.
+func RestoreAddShare(peerManager types.PeerManager, key []byte, checkpoint []byte, listener types.StateChangedListener) (*AddShare, error) {
+	state := &addshare.OldPeerState{}
+	err := tss.DecryptCheckpoint(key, checkpointLabel, checkpoint, state)
+	if err != nil {
+		return nil, err
+	}
+	ph, handler, err := restoreHandler(peerManager, state)
+	if err != nil {
+		return nil, err
+	}
+	a := newAddShareWithCurrentHandler(peerManager, state.SessionId, listener, ph, handler)
+	msgs := make([]types.Message, len(state.Messages))
+	for i, msg := range state.Messages {
+		msgs[i] = msg
+	}
+	// The echo broadcast is enabled by the caller, so it's enabled again before the echoes are restored
+	if len(state.EchoTypes) > 0 {
+		echoTypes := make([]types.MessageType, len(state.EchoTypes))
+		for i, msgType := range state.EchoTypes {
+			echoTypes[i] = types.MessageType(msgType)
+		}
+		a.EnableEchoBroadcast(echoTypes...)
+	}
+	err = a.Restore(msgs, state.Echoes)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (p *peerHandler) getState(handler types.Handler, msgs []types.Message, echoes []*message.EchoMessage) (*addshare.OldPeerState, error) {
+	pubkey, err := p.pubkey.ToEcPointMessage()
+	if err != nil {
+		return nil, err
+	}
+	bks := make(map[string]*birkhoffinterpolation.BkParameterMessage, len(p.peers)+1)
+	bks[p.peerManager.SelfID()] = p.bk.ToMessage()
+	for id, peer := range p.peers {
+		bks[id] = peer.peer.bk.ToMessage()
+	}
+	state := &addshare.OldPeerState{
+		Round:       addshare.Type(handler.MessageType()),
+		SessionId:   p.sessionID,
+		Pubkey:      pubkey,
+		Threshold:   p.threshold,
+		Share:       p.share.Bytes(),
+		Bks:         bks,
+		NewPeerId:   p.newPeer.Id,
+		SiGProofMsg: p.siGProofMsg,
+		Messages:    make([]*addshare.Message, len(msgs)),
+		Echoes:      echoes,
+	}
+	for i, msg := range msgs {
+		state.Messages[i] = getMessage(msg)
+	}
+	var ch *computeHandler
+	switch h := handler.(type) {
+	case *computeHandler:
+		ch = h
+	case *verifyHandler:
+		ch = h.computeHandler
+	}
+	if ch != nil {
+		state.Co = ch.co.Bytes()
+		state.DeltaI = ch.deltaI.Bytes()
+	}
+	return state, nil
+}
+
+// restoreHandler rebuilds the handler of the round in the state. The messages of the previous rounds are
+// handled again. No message is sent while handling messages in this protocol.
+func restoreHandler(peerManager types.PeerManager, state *addshare.OldPeerState) (*peerHandler, types.Handler, error) {
+	if err := tss.EnsureSessionID(state.SessionId); err != nil {
+		return nil, nil, err
+	}
+	pubkey, err := state.GetPubkey().ToPoint()
+	if err != nil {
+		return nil, nil, err
+	}
+	bks := make(map[string]*birkhoffinterpolation.BkParameter, len(state.Bks))
+	for id, bk := range state.Bks {
+		bks[id] = bk.ToBk()
+	}
+	ph, err := newPeerHandler(peerManager, state.SessionId, pubkey, state.Threshold, new(big.Int).SetBytes(state.Share), bks, state.NewPeerId)
+	if err != nil {
+		return nil, nil, err
+	}
+	ph.siGProofMsg = state.SiGProofMsg
+
+	var handler types.Handler = ph
+	for handler.MessageType() < types.MessageType(state.Round) {
+		for _, msg := range state.Messages {
+			if msg.GetMessageType() != handler.MessageType() {
+				continue
+			}
+			err = handler.HandleMessage(log.Discard(), msg)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		switch h := handler.(type) {
+		case *peerHandler:
+			handler = newComputeHandler(h, new(big.Int).SetBytes(state.Co), new(big.Int).SetBytes(state.DeltaI))
+		case *computeHandler:
+			handler = newVerifyHandler(h)
+		default:
+			return nil, nil, tss.ErrInvalidCheckpoint
+		}
+	}
+	if handler.MessageType() != types.MessageType(state.Round) {
+		return nil, nil, tss.ErrInvalidCheckpoint
+	}
+	return ph, handler, nil
+}
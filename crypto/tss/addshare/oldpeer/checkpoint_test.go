@@ -0,0 +1,114 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oldpeer
+
+import (
+	"bytes"
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/addshare"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/zkproof"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoint", func() {
+	var (
+		curve     = btcec.S256()
+		key       = bytes.Repeat([]byte{1}, tss.CheckpointKeySize)
+		newPeerID = "new-peer"
+		bks       = []*birkhoffinterpolation.BkParameter{
+			birkhoffinterpolation.NewBkParameter(big.NewInt(1), uint32(0)),
+			birkhoffinterpolation.NewBkParameter(big.NewInt(2), uint32(0)),
+			birkhoffinterpolation.NewBkParameter(big.NewInt(3), uint32(0)),
+		}
+	)
+
+	It("restores a crashed peer in the verify round and finishes the process", func() {
+		addShares, listeners := newAddShares(curve, 2, bks, newPeerID)
+		for _, l := range listeners {
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Once()
+		}
+
+		newBkMsg := &addshare.Message{
+			Type:      addshare.Type_NewBk,
+			Id:        newPeerID,
+			SessionId: sessionID,
+			Body: &addshare.Message_NewBk{
+				NewBk: &addshare.BodyNewBk{
+					Bk: birkhoffinterpolation.NewBkParameter(big.NewInt(4), uint32(0)).ToMessage(),
+				},
+			},
+		}
+		for _, addShare := range addShares {
+			Expect(addShare.AddMessage(newBkMsg)).Should(BeNil())
+		}
+		time.Sleep(1 * time.Second)
+
+		crashedID := getID(0)
+		crashed := addShares[crashedID]
+		listeners[crashedID].On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+		crashed.Stop()
+		checkpoint, err := crashed.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		restored, err := RestoreAddShare(crashed.ph.peerManager, key, checkpoint, listeners[crashedID])
+		Expect(err).Should(BeNil())
+		exp := crashed.GetHandler().(*verifyHandler)
+		got, ok := restored.GetHandler().(*verifyHandler)
+		Expect(ok).Should(BeTrue())
+		Expect(got.deltaI).Should(Equal(exp.deltaI))
+		Expect(got.co).Should(Equal(exp.co))
+		addShares[crashedID] = restored
+		restored.Start()
+
+		newShare := big.NewInt(0)
+		for _, addShare := range addShares {
+			newShare.Add(newShare, addShare.GetHandler().(*verifyHandler).deltaI)
+		}
+		newShare.Mod(newShare, curve.Params().N)
+		siGProofMsg, err := zkproof.NewBaseSchorrMessageWithSession(sessionID, curve, newShare)
+		Expect(err).Should(BeNil())
+		verifyMsg := &addshare.Message{
+			Type:      addshare.Type_Verify,
+			Id:        newPeerID,
+			SessionId: sessionID,
+			Body: &addshare.Message_Verify{
+				Verify: &addshare.BodyVerify{
+					SiGProofMsg: siGProofMsg,
+				},
+			},
+		}
+		for _, addShare := range addShares {
+			Expect(addShare.AddMessage(verifyMsg)).Should(BeNil())
+		}
+		time.Sleep(1 * time.Second)
+
+		for _, addShare := range addShares {
+			addShare.Stop()
+			r, err := addShare.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(r.Bks[newPeerID]).ShouldNot(BeNil())
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+})
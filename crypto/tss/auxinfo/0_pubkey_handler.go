@@ -0,0 +1,128 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auxinfo
+
+import (
+	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+type pubkeyData struct {
+	publicKey homo.Pubkey
+}
+
+type pubkeyHandler struct {
+	homo      homo.Crypto
+	sessionID []byte
+	// pubkey is our homomorphic public key with the proofs bound to the session
+	pubkey []byte
+
+	peerManager types.PeerManager
+	peerNum     uint32
+	peers       map[string]*peer
+}
+
+func newPubkeyHandler(peerManager types.PeerManager, sessionID []byte, homo homo.Crypto) (*pubkeyHandler, error) {
+	peerIDs, err := message.GetPeerIDs(peerManager)
+	if err != nil {
+		log.Warn("Failed to get peer ids", "err", err)
+		return nil, err
+	}
+	peers := make(map[string]*peer, len(peerIDs))
+	for _, id := range peerIDs {
+		peers[id] = newPeer(id)
+	}
+	pubkey, err := homo.ToPubKeyBytesWithSession(sessionID, peerManager.SelfID())
+	if err != nil {
+		log.Warn("Failed to get public key", "err", err)
+		return nil, err
+	}
+	return &pubkeyHandler{
+		homo:      homo,
+		sessionID: sessionID,
+		pubkey:    pubkey,
+
+		peerManager: peerManager,
+		peerNum:     peerManager.NumPeers(),
+		peers:       peers,
+	}, nil
+}
+
+func (p *pubkeyHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_Pubkey)
+}
+
+func (p *pubkeyHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *pubkeyHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.pubkey != nil
+}
+
+func (p *pubkeyHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	// Verify the public key with its proof
+	publicKey, err := p.homo.NewPubKeyFromBytesWithSession(p.sessionID, id, msg.GetPubkey().GetPubkey())
+	if err != nil {
+		logger.Warn("Failed to get public key", "err", err)
+		return blame(err, msg)
+	}
+	peer.pubkey = &pubkeyData{
+		publicKey: publicKey,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *pubkeyHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	return nil, nil
+}
+
+func (p *pubkeyHandler) GetPubkeyMessage() *Message {
+	return &Message{
+		Type:      Type_Pubkey,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_Pubkey{
+			Pubkey: &BodyPubkey{
+				Pubkey: p.pubkey,
+			},
+		},
+	}
+}
+
+func getMessage(messsage types.Message) *Message {
+	return messsage.(*Message)
+}
+
+// blame attaches the invalid message of the sender to the error as the evidence.
+func blame(err error, msg *Message) error {
+	return message.NewBlameError(err, msg)
+}
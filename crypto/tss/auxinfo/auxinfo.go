@@ -0,0 +1,78 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auxinfo
+
+import (
+	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+// AuxInfo broadcasts the long-lived homomorphic public key of each peer with its proof once, so that the
+// signers could skip generating and verifying the keys for every signature.
+type AuxInfo struct {
+	ph *pubkeyHandler
+	*message.MsgMain
+}
+
+type Result struct {
+	// Homo is the homomorphic encryption of self
+	Homo homo.Crypto
+	// Pubkeys are the verified homomorphic public keys of the peers
+	Pubkeys map[string]homo.Pubkey
+}
+
+func NewAuxInfo(peerManager types.PeerManager, sessionID []byte, homo homo.Crypto, listener types.StateChangedListener) (*AuxInfo, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	ph, err := newPubkeyHandler(peerManager, sessionID, homo)
+	if err != nil {
+		return nil, err
+	}
+	return &AuxInfo{
+		ph:      ph,
+		MsgMain: message.NewMsgMain(peerManager, sessionID, listener, ph, types.MessageType(Type_Pubkey)),
+	}, nil
+}
+
+func (a *AuxInfo) GetPubkeyMessage() *Message {
+	return a.ph.GetPubkeyMessage()
+}
+
+// GetResult returns the final result: self homomorphic encryption and the verified public keys of the peers
+func (a *AuxInfo) GetResult() (*Result, error) {
+	if a.GetState() != types.StateDone {
+		return nil, tss.ErrNotReady
+	}
+
+	h := a.GetHandler()
+	ph, ok := h.(*pubkeyHandler)
+	if !ok {
+		log.Error("We cannot convert to pubkey handler in done state")
+		return nil, tss.ErrNotReady
+	}
+
+	pubkeys := make(map[string]homo.Pubkey, len(ph.peers))
+	for id, peer := range ph.peers {
+		pubkeys[id] = peer.pubkey.publicKey
+	}
+	return &Result{
+		Homo:    ph.homo,
+		Pubkeys: pubkeys,
+	}, nil
+}
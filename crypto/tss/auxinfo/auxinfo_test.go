@@ -0,0 +1,237 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package auxinfo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/getamis/alice/crypto/homo/paillier"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/sirius/log"
+	proto "github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAuxInfo(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AuxInfo Suite")
+}
+
+var _ = Describe("AuxInfo", func() {
+	It("verifies the public keys of the peers", func() {
+		auxInfos, listeners := newAuxInfos(3)
+		for _, l := range listeners {
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Once()
+		}
+
+		// Send out pubkey message
+		for fromID, fromA := range auxInfos {
+			msg := fromA.GetPubkeyMessage()
+			for toID, toA := range auxInfos {
+				if fromID == toID {
+					continue
+				}
+				Expect(toA.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		// Verifying the proofs of the Paillier keys takes a while
+		for _, a := range auxInfos {
+			Eventually(a.GetState, 10*time.Second).Should(Equal(types.StateDone))
+		}
+
+		for id, a := range auxInfos {
+			a.Stop()
+			r, err := a.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(r.Homo).Should(Equal(a.ph.homo))
+			Expect(r.Pubkeys).Should(HaveLen(len(auxInfos) - 1))
+			for peerID, pubkey := range r.Pubkeys {
+				Expect(peerID).ShouldNot(Equal(id))
+				Expect(pubkey.ToPubKeyBytes()).Should(Equal(auxInfos[peerID].ph.pubkey))
+			}
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	Context("HandleMessage", func() {
+		var (
+			auxInfos  map[string]*AuxInfo
+			listeners map[string]*mocks.StateChangedListener
+		)
+		BeforeEach(func() {
+			auxInfos, listeners = newAuxInfos(2)
+		})
+
+		AfterEach(func() {
+			for _, l := range listeners {
+				l.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+			}
+			for _, a := range auxInfos {
+				a.Stop()
+			}
+			time.Sleep(500 * time.Millisecond)
+			for _, l := range listeners {
+				l.AssertExpectations(GinkgoT())
+			}
+		})
+
+		It("peer not found", func() {
+			msg := &Message{
+				Id: "invalid peer",
+			}
+			for _, a := range auxInfos {
+				Expect(a.ph.HandleMessage(log.Discard(), msg)).Should(Equal(tss.ErrPeerNotFound))
+			}
+		})
+
+		It("invalid public key", func() {
+			for id, a := range auxInfos {
+				for peerID := range a.ph.peers {
+					msg := auxInfos[peerID].GetPubkeyMessage()
+					msg.GetPubkey().Pubkey = []byte("invalid public key")
+					Expect(a.ph.HandleMessage(log.Discard(), msg)).ShouldNot(BeNil())
+					Expect(a.ph.IsHandled(log.Discard(), peerID)).Should(BeFalse(), id)
+				}
+			}
+		})
+
+		It("blames the sender of an invalid public key", func() {
+			for _, a := range auxInfos {
+				for peerID := range a.ph.peers {
+					msg := auxInfos[peerID].GetPubkeyMessage()
+					msg.GetPubkey().Pubkey = []byte("invalid public key")
+					err := a.ph.HandleMessage(log.Discard(), msg)
+					var blameErr *message.BlameError
+					Expect(errors.As(err, &blameErr)).Should(BeTrue())
+					Expect(blameErr.Culprits).Should(Equal([]string{peerID}))
+					Expect(blameErr.Evidence).Should(Equal([]types.Message{msg}))
+				}
+			}
+		})
+
+		It("public key of another session", func() {
+			for id, a := range auxInfos {
+				for peerID := range a.ph.peers {
+					pubkey, err := auxInfos[peerID].ph.homo.ToPubKeyBytesWithSession([]byte("another session"), peerID)
+					Expect(err).Should(BeNil())
+					msg := auxInfos[peerID].GetPubkeyMessage()
+					msg.GetPubkey().Pubkey = pubkey
+					Expect(a.ph.HandleMessage(log.Discard(), msg)).Should(MatchError(paillier.ErrInvalidMessage))
+					Expect(a.ph.IsHandled(log.Discard(), peerID)).Should(BeFalse(), id)
+				}
+			}
+		})
+
+		It("public key of another peer", func() {
+			for id, a := range auxInfos {
+				for peerID := range a.ph.peers {
+					pubkey, err := auxInfos[peerID].ph.homo.ToPubKeyBytesWithSession(sessionID, "another peer")
+					Expect(err).Should(BeNil())
+					msg := auxInfos[peerID].GetPubkeyMessage()
+					msg.GetPubkey().Pubkey = pubkey
+					Expect(a.ph.HandleMessage(log.Discard(), msg)).Should(MatchError(paillier.ErrInvalidMessage))
+					Expect(a.ph.IsHandled(log.Discard(), peerID)).Should(BeFalse(), id)
+				}
+			}
+		})
+	})
+
+	It("empty session id", func() {
+		a, err := NewAuxInfo(newPeerManager(getID(0), 2), nil, nil, nil)
+		Expect(err).Should(Equal(tss.ErrEmptySessionID))
+		Expect(a).Should(BeNil())
+	})
+})
+
+var sessionID = []byte("session")
+
+func getID(id int) string {
+	return fmt.Sprintf("id-%d", id)
+}
+
+type peerManager struct {
+	id       string
+	numPeers uint32
+	auxInfos map[string]*AuxInfo
+}
+
+func newPeerManager(id string, numPeers int) *peerManager {
+	return &peerManager{
+		id:       id,
+		numPeers: uint32(numPeers),
+	}
+}
+
+func (p *peerManager) setAuxInfos(auxInfos map[string]*AuxInfo) {
+	p.auxInfos = auxInfos
+}
+
+func (p *peerManager) NumPeers() uint32 {
+	return p.numPeers
+}
+
+func (p *peerManager) SelfID() string {
+	return p.id
+}
+
+func (p *peerManager) PeerIDs() []string {
+	ids := make([]string, 0, len(p.auxInfos))
+	for id := range p.auxInfos {
+		if id != p.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (p *peerManager) MustSend(id string, message proto.Message) {
+	a := p.auxInfos[id]
+	msg := message.(types.Message)
+	Expect(a.AddMessage(msg)).Should(BeNil())
+}
+
+func newAuxInfos(n int) (map[string]*AuxInfo, map[string]*mocks.StateChangedListener) {
+	auxInfos := make(map[string]*AuxInfo, n)
+	peerManagers := make([]*peerManager, n)
+	listeners := make(map[string]*mocks.StateChangedListener, n)
+	for i := 0; i < n; i++ {
+		id := getID(i)
+		peerManagers[i] = newPeerManager(id, n-1)
+		peerManagers[i].setAuxInfos(auxInfos)
+		// Add the ids first since the handlers get the peer ids in the constructor
+		auxInfos[id] = nil
+	}
+	for i := 0; i < n; i++ {
+		id := getID(i)
+		listeners[id] = new(mocks.StateChangedListener)
+		homo, err := paillier.NewPaillier(2048)
+		Expect(err).Should(BeNil())
+		auxInfos[id], err = NewAuxInfo(peerManagers[i], sessionID, homo, listeners[id])
+		Expect(err).Should(BeNil())
+		r, err := auxInfos[id].GetResult()
+		Expect(r).Should(BeNil())
+		Expect(err).Should(Equal(tss.ErrNotReady))
+		auxInfos[id].Start()
+	}
+	return auxInfos, listeners
+}
@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/getamis/alice/crypto/tss/auxinfo/message.proto
+
+package auxinfo
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Type int32
+
+const (
+	Type_Pubkey Type = 0
+)
+
+var Type_name = map[int32]string{
+	0: "Pubkey",
+}
+
+var Type_value = map[string]int32{
+	"Pubkey": 0,
+}
+
+func (x Type) String() string {
+	return proto.EnumName(Type_name, int32(x))
+}
+
+func (Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_271a491973502f59, []int{0}
+}
+
+type Message struct {
+	Type      Type   `protobuf:"varint,1,opt,name=type,proto3,enum=auxinfo.Type" json:"type,omitempty"`
+	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId []byte `protobuf:"bytes,3,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	// Types that are valid to be assigned to Body:
+	//	*Message_Pubkey
+	Body                 isMessage_Body `protobuf_oneof:"body"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Message) Reset()         { *m = Message{} }
+func (m *Message) String() string { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()    {}
+func (*Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_271a491973502f59, []int{0}
+}
+
+func (m *Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Message.Unmarshal(m, b)
+}
+func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Message.Marshal(b, m, deterministic)
+}
+func (m *Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Message.Merge(m, src)
+}
+func (m *Message) XXX_Size() int {
+	return xxx_messageInfo_Message.Size(m)
+}
+func (m *Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Message proto.InternalMessageInfo
+
+func (m *Message) GetType() Type {
+	if m != nil {
+		return m.Type
+	}
+	return Type_Pubkey
+}
+
+func (m *Message) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Message) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+type isMessage_Body interface {
+	isMessage_Body()
+}
+
+type Message_Pubkey struct {
+	Pubkey *BodyPubkey `protobuf:"bytes,4,opt,name=pubkey,proto3,oneof"`
+}
+
+func (*Message_Pubkey) isMessage_Body() {}
+
+func (m *Message) GetBody() isMessage_Body {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *Message) GetPubkey() *BodyPubkey {
+	if x, ok := m.GetBody().(*Message_Pubkey); ok {
+		return x.Pubkey
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Message) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Message_Pubkey)(nil),
+	}
+}
+
+type BodyPubkey struct {
+	// pubkey is the homomorphic public key with its proof
+	Pubkey               []byte   `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BodyPubkey) Reset()         { *m = BodyPubkey{} }
+func (m *BodyPubkey) String() string { return proto.CompactTextString(m) }
+func (*BodyPubkey) ProtoMessage()    {}
+func (*BodyPubkey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_271a491973502f59, []int{1}
+}
+
+func (m *BodyPubkey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyPubkey.Unmarshal(m, b)
+}
+func (m *BodyPubkey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyPubkey.Marshal(b, m, deterministic)
+}
+func (m *BodyPubkey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyPubkey.Merge(m, src)
+}
+func (m *BodyPubkey) XXX_Size() int {
+	return xxx_messageInfo_BodyPubkey.Size(m)
+}
+func (m *BodyPubkey) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyPubkey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyPubkey proto.InternalMessageInfo
+
+func (m *BodyPubkey) GetPubkey() []byte {
+	if m != nil {
+		return m.Pubkey
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("auxinfo.Type", Type_name, Type_value)
+	proto.RegisterType((*Message)(nil), "auxinfo.Message")
+	proto.RegisterType((*BodyPubkey)(nil), "auxinfo.BodyPubkey")
+}
+
+func init() {
+	proto.RegisterFile("github.com/getamis/alice/crypto/tss/auxinfo/message.proto", fileDescriptor_271a491973502f59)
+}
+
+var fileDescriptor_271a491973502f59 = []byte{
+	// 226 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0x8f, 0xc1, 0x4a, 0xc3, 0x40,
+	0x10, 0x86, 0xbb, 0x31, 0xa4, 0x74, 0xd4, 0x22, 0x23, 0x48, 0x0e, 0x1e, 0x62, 0xf1, 0x10, 0x04,
+	0xb3, 0x50, 0x4f, 0x5e, 0x7b, 0xd2, 0x83, 0x20, 0xc1, 0x17, 0x48, 0xb2, 0x63, 0x5c, 0x34, 0x9d,
+	0xa5, 0xb3, 0x01, 0xf7, 0x31, 0x7c, 0x63, 0x69, 0xba, 0xb4, 0xc7, 0xfd, 0xbf, 0x8f, 0x8f, 0x1d,
+	0x78, 0xee, 0xad, 0xff, 0x1a, 0xdb, 0xaa, 0xe3, 0x41, 0xf7, 0xe4, 0x9b, 0xc1, 0x8a, 0x6e, 0x7e,
+	0x6c, 0x47, 0xba, 0xdb, 0x05, 0xe7, 0x59, 0x7b, 0x11, 0xdd, 0x8c, 0xbf, 0x76, 0xfb, 0xc9, 0x7a,
+	0x20, 0x91, 0xa6, 0xa7, 0xca, 0xed, 0xd8, 0x33, 0xce, 0xe3, 0xbc, 0xfa, 0x53, 0x30, 0x7f, 0x3b,
+	0x20, 0xbc, 0x83, 0xd4, 0x07, 0x47, 0xb9, 0x2a, 0x54, 0xb9, 0x5c, 0x5f, 0x56, 0xd1, 0xa9, 0x3e,
+	0x82, 0xa3, 0x7a, 0x42, 0xb8, 0x84, 0xc4, 0x9a, 0x3c, 0x29, 0x54, 0xb9, 0xa8, 0x13, 0x6b, 0xf0,
+	0x16, 0x16, 0x42, 0x22, 0x96, 0xb7, 0xaf, 0x26, 0x3f, 0x2b, 0x54, 0x79, 0x51, 0x9f, 0x06, 0x7c,
+	0x84, 0xcc, 0x8d, 0xed, 0x37, 0x85, 0x3c, 0x2d, 0x54, 0x79, 0xbe, 0xbe, 0x3e, 0x26, 0x37, 0x6c,
+	0xc2, 0xfb, 0x84, 0x5e, 0x66, 0x75, 0x94, 0x36, 0x19, 0xa4, 0x2d, 0x9b, 0xb0, 0xba, 0x07, 0x38,
+	0x71, 0xbc, 0x39, 0x46, 0xd4, 0xd4, 0x8f, 0xaf, 0x07, 0x84, 0x74, 0xff, 0x31, 0x04, 0xc8, 0x0e,
+	0xe6, 0xd5, 0xac, 0xcd, 0xa6, 0xeb, 0x9e, 0xfe, 0x07, 0x00, 0x8b, 0x3a, 0x3a, 0x5f, 0x1a, 0x01,
+	0x00, 0x00,
+}
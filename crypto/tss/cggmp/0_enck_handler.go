@@ -0,0 +1,330 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo/paillier"
+	"github.com/getamis/alice/crypto/mta"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/auxinfo"
+	"github.com/getamis/alice/crypto/tss/dkg"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrPeerNotFound is returned if the peer is not one of the signers
+	ErrPeerNotFound = errors.New("peer message not found")
+	// ErrNotPaillier is returned if the homomorphic encryption of the aux info is not Paillier
+	ErrNotPaillier = errors.New("not Paillier")
+	// ErrBkNotFound is returned if the Birkhoff parameter of a signer is not in the DKG result
+	ErrBkNotFound = errors.New("Birkhoff parameter not found")
+	// ErrPublicShareNotFound is returned if the public share of a peer is not in the DKG result
+	ErrPublicShareNotFound = errors.New("public share not found")
+	// ErrPubkeyNotFound is returned if the verified Paillier public key of a peer is not in the aux info
+	ErrPubkeyNotFound = errors.New("homomorphic public key not found")
+	// ErrProofNotFound is returned if the proof to us is not in the message
+	ErrProofNotFound = errors.New("proof not found")
+)
+
+type encKData struct {
+	encK     []byte
+	encGamma []byte
+	// beta and betaHat are our shares of gamma_i*k_j and w_i*k_j in the MtAs with the peer
+	beta     *big.Int
+	betaHat  *big.Int
+	mtaEntry *BodyMtaEntry
+}
+
+type encKHandler struct {
+	publicKey *pt.ECPoint
+	sessionID []byte
+	homo      *paillier.Paillier
+	// pedersen is our ring-Pedersen parameter, under which the peers prove the ranges of their ciphertexts
+	pedersen *paillier.PedersenParameter
+
+	// gammaMta is the MtA of k_i and gamma_i, and wiMta is the one of k_i and w_i
+	gammaMta mta.Mta
+	wiMta    mta.Mta
+	encGamma []byte
+	// encKMsg is our EncK message with the proofs under the ring-Pedersen parameters of the peers, and mtaMsg is our
+	// Mta message with the MtAs with the peers
+	encKMsg *Message
+	mtaMsg  *Message
+
+	peerManager types.PeerManager
+	peerNum     uint32
+	peers       map[string]*peer
+}
+
+func newEncKHandler(peerManager types.PeerManager, sessionID []byte, dkgResult *dkg.Result, auxInfo *auxinfo.Result) (*encKHandler, error) {
+	paillierHomo, ok := auxInfo.Homo.(*paillier.Paillier)
+	if !ok {
+		log.Warn("Only Paillier is supported")
+		return nil, ErrNotPaillier
+	}
+	curve := dkgResult.PublicKey.GetCurve()
+	fieldOrder := curve.Params().N
+	selfID := peerManager.SelfID()
+	peerIDs, err := message.GetPeerIDs(peerManager)
+	if err != nil {
+		log.Warn("Failed to get peer ids", "err", err)
+		return nil, err
+	}
+	coefficients, err := computeCoefficients(fieldOrder, dkgResult.Bks, append([]string{selfID}, peerIDs...))
+	if err != nil {
+		log.Warn("Failed to compute Birkhoff coefficients", "err", err)
+		return nil, err
+	}
+	wi := new(big.Int).Mul(dkgResult.Share, coefficients[0])
+	wi = wi.Mod(wi, fieldOrder)
+
+	// Build the peers with W_j, and ensure the sum of W_j is the public key
+	sumW := pt.ScalarBaseMult(curve, wi)
+	peers := make(map[string]*peer, len(peerIDs))
+	for i, id := range peerIDs {
+		publicShare, ok := dkgResult.PublicShares[id]
+		if !ok {
+			log.Warn("Public share not found", "id", id)
+			return nil, ErrPublicShareNotFound
+		}
+		pubkey, ok := auxInfo.Pubkeys[id]
+		if !ok || pubkey == nil {
+			log.Warn("Homomorphic public key not found", "id", id)
+			return nil, ErrPubkeyNotFound
+		}
+		pedersen, err := paillier.GetPedersenOpenParameter(pubkey)
+		if err != nil {
+			log.Warn("Failed to get pedersen parameter", "id", id, "err", err)
+			return nil, err
+		}
+		bigW := publicShare.ScalarMult(coefficients[i+1])
+		sumW, err = sumW.Add(bigW)
+		if err != nil {
+			return nil, err
+		}
+		peers[id] = newPeer(id, pubkey, pedersen, bigW)
+	}
+	if !sumW.Equal(dkgResult.PublicKey) {
+		log.Warn("Inconsistent public key", "got", sumW, "expected", dkgResult.PublicKey)
+		return nil, tss.ErrInconsistentPubKey
+	}
+
+	gammaMta, err := mta.NewMta(fieldOrder, paillierHomo)
+	if err != nil {
+		log.Warn("Failed to new gamma mta", "err", err)
+		return nil, err
+	}
+	wiMta, err := gammaMta.OverrideA(wi)
+	if err != nil {
+		log.Warn("Failed to create wi mta", "err", err)
+		return nil, err
+	}
+	encGamma, err := paillierHomo.Encrypt(gammaMta.GetState().A.Bytes())
+	if err != nil {
+		log.Warn("Failed to encrypt gamma", "err", err)
+		return nil, err
+	}
+	h := &encKHandler{
+		publicKey: dkgResult.PublicKey,
+		sessionID: sessionID,
+		homo:      paillierHomo,
+		pedersen:  paillierHomo.GetPedersenParameter(),
+
+		gammaMta: gammaMta,
+		wiMta:    wiMta,
+		encGamma: encGamma,
+
+		peerManager: peerManager,
+		peerNum:     peerManager.NumPeers(),
+		peers:       peers,
+	}
+	h.encKMsg, err = h.getEncKMessage()
+	if err != nil {
+		log.Warn("Failed to get enck message", "err", err)
+		return nil, err
+	}
+	return h, nil
+}
+
+func (p *encKHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_EncK)
+}
+
+func (p *encKHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *encKHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.enck != nil
+}
+
+func (p *encKHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return ErrPeerNotFound
+	}
+
+	body := msg.GetEncK()
+	proof, ok := body.GetProofs()[p.peerManager.SelfID()]
+	if !ok {
+		logger.Warn("Proof not found")
+		return ErrProofNotFound
+	}
+	err := p.pedersen.VerifyNoSmallFactor(p.sessionID, peer.pubkey, proof.GetNoSmallFactorProof())
+	if err != nil {
+		logger.Warn("Failed to verify no small factor proof", "err", err)
+		return err
+	}
+	err = proof.GetEncProof().Verify(p.sessionID, p.getN(), peer.pubkey, body.GetEncK(), p.pedersen.PedersenOpenParameter)
+	if err != nil {
+		logger.Warn("Failed to verify range proof of enck", "err", err)
+		return err
+	}
+
+	// Compute D_{j,i} and D^_{j,i} with Bob's proofs with check under the ring-Pedersen parameter of the peer
+	d, beta, dProof, err := p.gammaMta.ComputeWithRangeProof(p.sessionID, p.getCurve(), peer.pubkey, peer.pedersen, body.GetEncK(), true)
+	if err != nil {
+		logger.Warn("Failed to compute for gamma mta", "err", err)
+		return err
+	}
+	dHat, betaHat, dHatProof, err := p.wiMta.ComputeWithRangeProof(p.sessionID, p.getCurve(), peer.pubkey, peer.pedersen, body.GetEncK(), true)
+	if err != nil {
+		logger.Warn("Failed to compute for wi mta", "err", err)
+		return err
+	}
+	// Prove the plaintext of our G_i is the discrete log of Gamma_i
+	gammaProof, err := p.homo.NewRangeProofMessageWithCheck(p.sessionID, p.encGamma, peer.pedersen, pt.NewBase(p.getCurve()))
+	if err != nil {
+		logger.Warn("Failed to compute gamma proof", "err", err)
+		return err
+	}
+
+	peer.enck = &encKData{
+		encK:     body.GetEncK(),
+		encGamma: body.GetEncGamma(),
+		beta:     beta,
+		betaHat:  betaHat,
+		mtaEntry: &BodyMtaEntry{
+			GammaProof: gammaProof,
+			D:          d.Bytes(),
+			DProof:     dProof,
+			DHat:       dHat.Bytes(),
+			DHatProof:  dHatProof,
+		},
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *encKHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	msgBigGamma, err := p.gammaMta.GetAG(p.getCurve()).ToEcPointMessage()
+	if err != nil {
+		logger.Warn("Failed to convert gamma", "err", err)
+		return nil, err
+	}
+	mtas := make(map[string]*BodyMtaEntry, len(p.peers))
+	for id, peer := range p.peers {
+		mtas[id] = peer.enck.mtaEntry
+	}
+	p.mtaMsg = &Message{
+		Type:      Type_Mta,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_Mta{
+			Mta: &BodyMta{
+				Gamma: msgBigGamma,
+				Mtas:  mtas,
+			},
+		},
+	}
+	for id := range p.peers {
+		p.peerManager.MustSend(id, p.mtaMsg)
+	}
+	return newMtaHandler(p), nil
+}
+
+// getEncKMessage returns our EncK message, which contains the range proofs of k_i and the proofs that our modulus
+// has no small factor under the ring-Pedersen parameters of the peers.
+func (p *encKHandler) getEncKMessage() (*Message, error) {
+	encK := p.gammaMta.GetEncK()
+	proofs := make(map[string]*EncKProof, len(p.peers))
+	for id, peer := range p.peers {
+		encProof, err := p.homo.NewRangeProofMessage(p.sessionID, p.getN(), encK, peer.pedersen)
+		if err != nil {
+			return nil, err
+		}
+		noSmallFactorProof, err := p.homo.NewNoSmallFactorMessage(p.sessionID, peer.pedersen)
+		if err != nil {
+			return nil, err
+		}
+		proofs[id] = &EncKProof{
+			EncProof:           encProof,
+			NoSmallFactorProof: noSmallFactorProof,
+		}
+	}
+	return &Message{
+		Type:      Type_EncK,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_EncK{
+			EncK: &BodyEncK{
+				EncK:     encK,
+				EncGamma: p.encGamma,
+				Proofs:   proofs,
+			},
+		},
+	}, nil
+}
+
+func (p *encKHandler) getCurve() elliptic.Curve {
+	return p.publicKey.GetCurve()
+}
+
+func (p *encKHandler) getN() *big.Int {
+	return p.getCurve().Params().N
+}
+
+// computeCoefficients returns the Birkhoff coefficients of the signers in the order of the ids
+func computeCoefficients(fieldOrder *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, ids []string) ([]*big.Int, error) {
+	signerBks := make(birkhoffinterpolation.BkParameters, len(ids))
+	for i, id := range ids {
+		bk, ok := bks[id]
+		if !ok {
+			log.Warn("Birkhoff parameter not found", "id", id)
+			return nil, ErrBkNotFound
+		}
+		signerBks[i] = bk
+	}
+	return signerBks.ComputeBkCoefficient(uint32(len(ids)), fieldOrder)
+}
+
+func getMessage(messsage types.Message) *Message {
+	return messsage.(*Message)
+}
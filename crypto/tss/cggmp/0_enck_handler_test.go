@@ -0,0 +1,90 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/alice/crypto/zkproof"
+	"github.com/getamis/sirius/log"
+	proto "github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("enck handler, negative cases", func() {
+	var (
+		presigners map[string]*Presigner
+		listeners  map[string]*mocks.StateChangedListener
+
+		fromID, toID string
+		fromH, toH   *encKHandler
+		msg          *Message
+	)
+
+	BeforeEach(func() {
+		presigners, listeners, _ = newStoppedPresigners(Type_Mta)
+		fromID, toID = getID(1), getID(0)
+		fromH = presigners[fromID].eh
+		toH = presigners[toID].eh
+		msg = proto.Clone(fromH.encKMsg).(*Message)
+	})
+
+	AfterEach(func() {
+		stopPresigners(presigners, listeners)
+	})
+
+	It("peer not found", func() {
+		Expect(toH.IsHandled(log.Discard(), "invalid peer")).Should(BeFalse())
+		Expect(toH.HandleMessage(log.Discard(), &Message{
+			Id: "invalid peer",
+		})).Should(Equal(ErrPeerNotFound))
+	})
+
+	It("proof not found", func() {
+		delete(msg.GetEncK().GetProofs(), toID)
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(ErrProofNotFound))
+		Expect(toH.IsHandled(log.Discard(), fromID)).Should(BeFalse())
+	})
+
+	It("missing no small factor proof", func() {
+		msg.GetEncK().GetProofs()[toID].NoSmallFactorProof = nil
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(utils.ErrNotInRange))
+		Expect(toH.IsHandled(log.Discard(), fromID)).Should(BeFalse())
+	})
+
+	It("range proof of another session", func() {
+		proof, err := fromH.homo.NewRangeProofMessage([]byte("another session id"), fromH.getN(), msg.GetEncK().GetEncK(), fromH.peers[toID].pedersen)
+		Expect(err).Should(BeNil())
+		msg.GetEncK().GetProofs()[toID].EncProof = proof
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(zkproof.ErrVerifyFailure))
+	})
+
+	It("range proof of another ciphertext", func() {
+		msg.GetEncK().EncK = fromH.encGamma
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(zkproof.ErrVerifyFailure))
+	})
+
+	It("blames the sender", func() {
+		msg.GetEncK().EncK = fromH.encGamma
+		listeners[toID].On("OnStateChanged", types.StateInit, types.StateFailed).Return().Once()
+		Expect(presigners[toID].AddMessage(msg)).Should(BeNil())
+		Eventually(presigners[toID].GetFailure, "10s").ShouldNot(BeNil())
+		Expect(presigners[toID].GetFailure().Culprits).Should(Equal([]string{fromID}))
+		Expect(presigners[toID].GetFailure().Err).Should(Equal(zkproof.ErrVerifyFailure))
+		delete(listeners, toID)
+	})
+})
@@ -0,0 +1,202 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	"errors"
+	"math/big"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo/paillier"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+// ErrMtaNotFound is returned if the MtA with a signer is not in the message
+var ErrMtaNotFound = errors.New("mta not found")
+
+type mtaData struct {
+	bigGamma *pt.ECPoint
+	// alpha and alphaHat are our shares of k_i*gamma_j and k_i*w_j in the MtAs with the peer
+	alpha    *big.Int
+	alphaHat *big.Int
+	// mtas are the MtAs of the peer with all the signers except itself
+	mtas map[string]*BodyMtaEntry
+}
+
+type mtaHandler struct {
+	*encKHandler
+}
+
+func newMtaHandler(p *encKHandler) *mtaHandler {
+	return &mtaHandler{
+		encKHandler: p,
+	}
+}
+
+func (p *mtaHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_Mta)
+}
+
+func (p *mtaHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *mtaHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.mta != nil
+}
+
+func (p *mtaHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return ErrPeerNotFound
+	}
+
+	body := msg.GetMta()
+	mtas := body.GetMtas()
+	if len(mtas) != len(p.peers) {
+		logger.Warn("Inconsistent mta number", "got", len(mtas), "expected", len(p.peers))
+		return ErrMtaNotFound
+	}
+	// The sender runs the MtAs with us and the other peers
+	entry, ok := mtas[p.peerManager.SelfID()]
+	if !ok {
+		logger.Warn("Mta not found")
+		return ErrMtaNotFound
+	}
+	for peerID := range p.peers {
+		if _, ok := mtas[peerID]; !ok && peerID != id {
+			logger.Warn("Mta not found", "peerID", peerID)
+			return ErrMtaNotFound
+		}
+	}
+	curve := p.getCurve()
+	bigGamma, err := body.GetGamma().ToPoint()
+	if err != nil {
+		logger.Warn("Failed to get gamma", "err", err)
+		return err
+	}
+	err = entry.GetGammaProof().VerifyWithCheck(p.sessionID, peer.pubkey, peer.enck.encGamma, p.pedersen.PedersenOpenParameter, pt.NewBase(curve), bigGamma)
+	if err != nil {
+		logger.Warn("Failed to verify gamma proof", "err", err)
+		return err
+	}
+	encK := p.gammaMta.GetEncK()
+	err = entry.GetDProof().Verify(p.sessionID, curve, p.homo.GetPubKey(), p.pedersen.PedersenOpenParameter, encK, entry.GetD(), bigGamma)
+	if err != nil {
+		logger.Warn("Failed to verify proof of d", "err", err)
+		return err
+	}
+	err = entry.GetDHatProof().Verify(p.sessionID, curve, p.homo.GetPubKey(), p.pedersen.PedersenOpenParameter, encK, entry.GetDHat(), peer.bigW)
+	if err != nil {
+		logger.Warn("Failed to verify proof of d hat", "err", err)
+		return err
+	}
+	alpha, err := p.gammaMta.Decrypt(new(big.Int).SetBytes(entry.GetD()))
+	if err != nil {
+		logger.Warn("Failed to decrypt d", "err", err)
+		return err
+	}
+	alphaHat, err := p.wiMta.Decrypt(new(big.Int).SetBytes(entry.GetDHat()))
+	if err != nil {
+		logger.Warn("Failed to decrypt d hat", "err", err)
+		return err
+	}
+	peer.mta = &mtaData{
+		bigGamma: bigGamma,
+		alpha:    alpha,
+		alphaHat: alphaHat,
+		mtas:     mtas,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *mtaHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	curve := p.getCurve()
+	bigGamma := p.gammaMta.GetAG(curve)
+	alphas := make([]*big.Int, 0, len(p.peers))
+	betas := make([]*big.Int, 0, len(p.peers))
+	alphaHats := make([]*big.Int, 0, len(p.peers))
+	betaHats := make([]*big.Int, 0, len(p.peers))
+	var err error
+	for _, peer := range p.peers {
+		bigGamma, err = bigGamma.Add(peer.mta.bigGamma)
+		if err != nil {
+			logger.Warn("Failed to add gamma", "err", err)
+			return nil, err
+		}
+		alphas = append(alphas, peer.mta.alpha)
+		betas = append(betas, peer.enck.beta)
+		alphaHats = append(alphaHats, peer.mta.alphaHat)
+		betaHats = append(betaHats, peer.enck.betaHat)
+	}
+	// delta_i = k_i*gamma_i + sum(alpha + beta) and chi_i = k_i*w_i + sum(alpha^ + beta^)
+	delta, err := p.gammaMta.GetResult(alphas, betas)
+	if err != nil {
+		logger.Warn("Failed to get delta", "err", err)
+		return nil, err
+	}
+	chi, err := p.wiMta.GetResult(alphaHats, betaHats)
+	if err != nil {
+		logger.Warn("Failed to get chi", "err", err)
+		return nil, err
+	}
+	bigDelta := bigGamma.ScalarMult(p.gammaMta.GetState().K)
+	msgBigDelta, err := bigDelta.ToEcPointMessage()
+	if err != nil {
+		logger.Warn("Failed to convert delta", "err", err)
+		return nil, err
+	}
+	chiGamma := bigGamma.ScalarMult(chi)
+	msgChiGamma, err := chiGamma.ToEcPointMessage()
+	if err != nil {
+		logger.Warn("Failed to convert chi gamma", "err", err)
+		return nil, err
+	}
+	// Prove the plaintext of our K_i is the discrete log of Delta_i to Gamma
+	proofs := make(map[string]*paillier.RangeProofMessage, len(p.peers))
+	for id, peer := range p.peers {
+		proofs[id], err = p.homo.NewRangeProofMessageWithCheck(p.sessionID, p.gammaMta.GetEncK(), peer.pedersen, bigGamma)
+		if err != nil {
+			logger.Warn("Failed to compute delta proof", "id", id, "err", err)
+			return nil, err
+		}
+	}
+	deltaMsg := &Message{
+		Type:      Type_Delta,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_Delta{
+			Delta: &BodyDelta{
+				Delta:          delta.Bytes(),
+				BigDelta:       msgBigDelta,
+				BigDeltaProofs: proofs,
+				ChiGamma:       msgChiGamma,
+			},
+		},
+	}
+	for id := range p.peers {
+		p.peerManager.MustSend(id, deltaMsg)
+	}
+	return newDeltaHandler(p, bigGamma, delta, chi, bigDelta, chiGamma), nil
+}
@@ -0,0 +1,102 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	"math/big"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/alice/crypto/zkproof"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("mta handler, negative cases", func() {
+	var (
+		presigners map[string]*Presigner
+		listeners  map[string]*mocks.StateChangedListener
+
+		fromID, toID string
+		fromH, toH   *mtaHandler
+		msg          *Message
+	)
+
+	BeforeEach(func() {
+		var pms map[string]*stopPeerManager
+		presigners, listeners, pms = newStoppedPresigners(Type_Mta)
+		sendEncKMessages(presigners)
+		waitHandler(presigners, func(h types.Handler) bool {
+			_, ok := h.(*mtaHandler)
+			return ok
+		})
+		fromID, toID = getID(1), getID(0)
+		fromH = presigners[fromID].GetHandler().(*mtaHandler)
+		toH = presigners[toID].GetHandler().(*mtaHandler)
+		msg = pms[fromID].getDropped(toID)
+		Expect(msg).ShouldNot(BeNil())
+	})
+
+	AfterEach(func() {
+		stopPresigners(presigners, listeners)
+	})
+
+	It("should be ok", func() {
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(BeNil())
+		Expect(toH.IsHandled(log.Discard(), fromID)).Should(BeTrue())
+	})
+
+	It("peer not found", func() {
+		Expect(toH.IsHandled(log.Discard(), "invalid peer")).Should(BeFalse())
+		Expect(toH.HandleMessage(log.Discard(), &Message{
+			Id: "invalid peer",
+		})).Should(Equal(ErrPeerNotFound))
+	})
+
+	It("unexpected gamma", func() {
+		gamma, err := pt.ScalarBaseMult(fromH.getCurve(), big.NewInt(2)).ToEcPointMessage()
+		Expect(err).Should(BeNil())
+		msg.GetMta().Gamma = gamma
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(zkproof.ErrVerifyFailure))
+	})
+
+	It("mta not found", func() {
+		delete(msg.GetMta().GetMtas(), toID)
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(ErrMtaNotFound))
+		Expect(toH.IsHandled(log.Discard(), fromID)).Should(BeFalse())
+	})
+
+	It("gamma proof of another session", func() {
+		proof, err := fromH.homo.NewRangeProofMessageWithCheck([]byte("another session id"), fromH.encGamma, fromH.peers[toID].pedersen, pt.NewBase(fromH.getCurve()))
+		Expect(err).Should(BeNil())
+		msg.GetMta().GetMtas()[toID].GammaProof = proof
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(zkproof.ErrVerifyFailure))
+	})
+
+	It("swapped d and d hat", func() {
+		body := msg.GetMta().GetMtas()[toID]
+		body.D, body.DHat = body.DHat, body.D
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(zkproof.ErrVerifyFailure))
+		Expect(toH.IsHandled(log.Discard(), fromID)).Should(BeFalse())
+	})
+
+	It("d hat with the proof of d", func() {
+		body := msg.GetMta().GetMtas()[toID]
+		body.DHat, body.DHatProof = body.D, body.DProof
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(zkproof.ErrVerifyFailure))
+	})
+})
@@ -0,0 +1,233 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	"errors"
+	"math/big"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrInconsistentDelta is returned if delta*G is not the sum of Delta_j
+	ErrInconsistentDelta = errors.New("inconsistent delta")
+	// ErrInconsistentChi is returned if delta*Q is not the sum of chi_j*Gamma
+	ErrInconsistentChi = errors.New("inconsistent chi")
+	// ErrIdentityR is returned if R is the identity
+	ErrIdentityR = errors.New("identity r")
+)
+
+type deltaData struct {
+	delta    *big.Int
+	bigDelta *pt.ECPoint
+	chiGamma *pt.ECPoint
+}
+
+// presignData is k_j*R and chi_j*R of a peer, with which the online signer checks sigma_j
+type presignData struct {
+	kR   *pt.ECPoint
+	chiR *pt.ECPoint
+}
+
+type deltaHandler struct {
+	*mtaHandler
+
+	// bigGamma is Gamma = sum(Gamma_j), bigDelta is Delta_i = k_i*Gamma, and chiGamma is chi_i*Gamma
+	bigGamma *pt.ECPoint
+	delta    *big.Int
+	chi      *big.Int
+	bigDelta *pt.ECPoint
+	chiGamma *pt.ECPoint
+	r        *pt.ECPoint
+}
+
+func newDeltaHandler(p *mtaHandler, bigGamma *pt.ECPoint, delta *big.Int, chi *big.Int, bigDelta *pt.ECPoint, chiGamma *pt.ECPoint) *deltaHandler {
+	return &deltaHandler{
+		mtaHandler: p,
+
+		bigGamma: bigGamma,
+		delta:    delta,
+		chi:      chi,
+		bigDelta: bigDelta,
+		chiGamma: chiGamma,
+	}
+}
+
+func (p *deltaHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_Delta)
+}
+
+func (p *deltaHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *deltaHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.delta != nil
+}
+
+func (p *deltaHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return ErrPeerNotFound
+	}
+
+	body := msg.GetDelta()
+	bigDelta, err := body.GetBigDelta().ToPoint()
+	if err != nil {
+		logger.Warn("Failed to get delta point", "err", err)
+		return err
+	}
+	proof, ok := body.GetBigDeltaProofs()[p.peerManager.SelfID()]
+	if !ok {
+		logger.Warn("Proof not found")
+		return ErrProofNotFound
+	}
+	err = proof.VerifyWithCheck(p.sessionID, peer.pubkey, peer.enck.encK, p.pedersen.PedersenOpenParameter, p.bigGamma, bigDelta)
+	if err != nil {
+		logger.Warn("Failed to verify delta proof", "err", err)
+		return err
+	}
+	chiGamma, err := body.GetChiGamma().ToPoint()
+	if err != nil {
+		logger.Warn("Failed to get chi gamma point", "err", err)
+		return err
+	}
+	if !chiGamma.IsSameCurve(p.publicKey) {
+		logger.Warn("Different curve of chi gamma")
+		return pt.ErrDifferentCurve
+	}
+	peer.delta = &deltaData{
+		delta:    new(big.Int).SetBytes(body.GetDelta()),
+		bigDelta: bigDelta,
+		chiGamma: chiGamma,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *deltaHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	curve := p.getCurve()
+	delta := new(big.Int).Set(p.delta)
+	sumBigDelta := p.bigDelta
+	sumChiGamma := p.chiGamma
+	var err error
+	for _, peer := range p.peers {
+		delta = delta.Add(delta, peer.delta.delta)
+		sumBigDelta, err = sumBigDelta.Add(peer.delta.bigDelta)
+		if err != nil {
+			logger.Warn("Failed to add delta", "err", err)
+			return nil, err
+		}
+		sumChiGamma, err = sumChiGamma.Add(peer.delta.chiGamma)
+		if err != nil {
+			logger.Warn("Failed to add chi gamma", "err", err)
+			return nil, err
+		}
+	}
+	delta = delta.Mod(delta, p.getN())
+	// Ensure delta*G = sum(Delta_j) and delta*Q = sum(chi_j*Gamma), i.e. delta = k*gamma and chi = k*x. Otherwise,
+	// the presignature is dropped, and we reveal our ephemeral secrets to find the culprits.
+	if !pt.ScalarBaseMult(curve, delta).Equal(sumBigDelta) {
+		logger.Warn("Inconsistent delta")
+		return p.reveal(logger, ErrInconsistentDelta)
+	}
+	if !p.publicKey.ScalarMult(delta).Equal(sumChiGamma) {
+		logger.Warn("Inconsistent chi")
+		return p.reveal(logger, ErrInconsistentChi)
+	}
+	deltaInverse := new(big.Int).ModInverse(delta, p.getN())
+	if deltaInverse == nil {
+		logger.Warn("Zero delta")
+		return nil, ErrIdentityR
+	}
+	// R = delta^-1 * Gamma
+	r := p.bigGamma.ScalarMult(deltaInverse)
+	if r.IsIdentity() {
+		logger.Warn("Identity R")
+		return nil, ErrIdentityR
+	}
+	// k_j*R = delta^-1 * Delta_j and chi_j*R = delta^-1 * chi_j*Gamma. Their sums are G and Q respectively.
+	for _, peer := range p.peers {
+		peer.presign = &presignData{
+			kR:   peer.delta.bigDelta.ScalarMult(deltaInverse),
+			chiR: peer.delta.chiGamma.ScalarMult(deltaInverse),
+		}
+	}
+	p.r = r
+	return nil, nil
+}
+
+// reveal sends our ephemeral secrets with the openings of the MtA ciphertexts from the peers
+func (p *deltaHandler) reveal(logger log.Logger, err error) (types.Handler, error) {
+	msg, openErr := p.getRevealMessage()
+	if openErr != nil {
+		logger.Warn("Failed to get reveal message", "err", openErr)
+		return nil, openErr
+	}
+	for id := range p.peers {
+		p.peerManager.MustSend(id, msg)
+	}
+	return newRevealHandler(p, err), nil
+}
+
+func (p *deltaHandler) getRevealMessage() (*Message, error) {
+	state := p.gammaMta.GetState()
+	_, kNonce, err := p.homo.Open(state.EncK)
+	if err != nil {
+		return nil, err
+	}
+	selfID := p.peerManager.SelfID()
+	openings := make(map[string]*MtaOpening, len(p.peers))
+	for id, peer := range p.peers {
+		entry := peer.mta.mtas[selfID]
+		alpha, alphaNonce, err := p.homo.Open(entry.GetD())
+		if err != nil {
+			return nil, err
+		}
+		alphaHat, alphaHatNonce, err := p.homo.Open(entry.GetDHat())
+		if err != nil {
+			return nil, err
+		}
+		openings[id] = &MtaOpening{
+			Alpha:         alpha.Bytes(),
+			AlphaNonce:    alphaNonce.Bytes(),
+			AlphaHat:      alphaHat.Bytes(),
+			AlphaHatNonce: alphaHatNonce.Bytes(),
+		}
+	}
+	return &Message{
+		Type:      Type_Reveal,
+		Id:        selfID,
+		SessionId: p.sessionID,
+		Body: &Message_Reveal{
+			Reveal: &BodyReveal{
+				K:        state.K.Bytes(),
+				KNonce:   kNonce.Bytes(),
+				Gamma:    state.A.Bytes(),
+				Openings: openings,
+			},
+		},
+	}, nil
+}
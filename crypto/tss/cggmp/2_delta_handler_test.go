@@ -0,0 +1,120 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	"math/big"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/alice/crypto/zkproof"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("delta handler, negative cases", func() {
+	var (
+		presigners map[string]*Presigner
+		listeners  map[string]*mocks.StateChangedListener
+
+		fromID, toID string
+		fromH, toH   *deltaHandler
+		msg          *Message
+		pms          map[string]*stopPeerManager
+	)
+
+	BeforeEach(func() {
+		presigners, listeners, pms = newStoppedPresigners(Type_Delta)
+		sendEncKMessages(presigners)
+		waitHandler(presigners, func(h types.Handler) bool {
+			_, ok := h.(*deltaHandler)
+			return ok
+		})
+		fromID, toID = getID(1), getID(0)
+		fromH = presigners[fromID].GetHandler().(*deltaHandler)
+		toH = presigners[toID].GetHandler().(*deltaHandler)
+		msg = pms[fromID].getDropped(toID)
+		Expect(msg).ShouldNot(BeNil())
+	})
+
+	AfterEach(func() {
+		stopPresigners(presigners, listeners)
+	})
+
+	It("should be ok", func() {
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(BeNil())
+		Expect(toH.IsHandled(log.Discard(), fromID)).Should(BeTrue())
+		got, err := toH.Finalize(log.Discard())
+		Expect(got).Should(BeNil())
+		Expect(err).Should(BeNil())
+
+		// The peer gets the same R
+		Expect(fromH.HandleMessage(log.Discard(), pms[toID].getDropped(fromID))).Should(BeNil())
+		got, err = fromH.Finalize(log.Discard())
+		Expect(got).Should(BeNil())
+		Expect(err).Should(BeNil())
+		Expect(fromH.r).Should(Equal(toH.r))
+	})
+
+	It("peer not found", func() {
+		Expect(toH.IsHandled(log.Discard(), "invalid peer")).Should(BeFalse())
+		Expect(toH.HandleMessage(log.Discard(), &Message{
+			Id: "invalid peer",
+		})).Should(Equal(ErrPeerNotFound))
+	})
+
+	It("unexpected big delta", func() {
+		bigDelta, err := fromH.bigDelta.Add(pt.NewBase(fromH.getCurve()))
+		Expect(err).Should(BeNil())
+		msg.GetDelta().BigDelta, err = bigDelta.ToEcPointMessage()
+		Expect(err).Should(BeNil())
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(zkproof.ErrVerifyFailure))
+		Expect(toH.IsHandled(log.Discard(), fromID)).Should(BeFalse())
+	})
+
+	It("big delta proof under another base", func() {
+		proof, err := fromH.homo.NewRangeProofMessageWithCheck(sessionID, fromH.gammaMta.GetEncK(), fromH.peers[toID].pedersen, pt.NewBase(fromH.getCurve()))
+		Expect(err).Should(BeNil())
+		msg.GetDelta().GetBigDeltaProofs()[toID] = proof
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(zkproof.ErrVerifyFailure))
+	})
+
+	It("proof not found", func() {
+		delete(msg.GetDelta().GetBigDeltaProofs(), toID)
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(ErrProofNotFound))
+		Expect(toH.IsHandled(log.Discard(), fromID)).Should(BeFalse())
+	})
+
+	It("inconsistent delta", func() {
+		delta := new(big.Int).SetBytes(msg.GetDelta().GetDelta())
+		msg.GetDelta().Delta = delta.Add(delta, big.NewInt(1)).Bytes()
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(BeNil())
+		got, err := toH.Finalize(log.Discard())
+		Expect(err).Should(BeNil())
+		Expect(got.(*revealHandler).err).Should(Equal(ErrInconsistentDelta))
+	})
+
+	It("inconsistent chi", func() {
+		var err error
+		msg.GetDelta().ChiGamma, err = toH.chiGamma.ToEcPointMessage()
+		Expect(err).Should(BeNil())
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(BeNil())
+		got, err := toH.Finalize(log.Discard())
+		Expect(err).Should(BeNil())
+		Expect(got.(*revealHandler).err).Should(Equal(ErrInconsistentChi))
+	})
+})
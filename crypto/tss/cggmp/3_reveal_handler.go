@@ -0,0 +1,207 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo/paillier"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+// ErrInvalidReveal is returned if the revealed secrets are inconsistent with the public values
+var ErrInvalidReveal = errors.New("invalid reveal")
+
+type revealData struct {
+	k     *big.Int
+	gamma *big.Int
+	// alphas and alphaHats map the ids of the signers to the plaintexts of the MtA ciphertexts from them
+	alphas    map[string]*big.Int
+	alphaHats map[string]*big.Int
+}
+
+// revealHandler finds the culprits if delta = k*gamma or chi = k*x fails to hold. Every signer reveals k_j and
+// gamma_j with the openings of the MtA ciphertexts it received. The ciphertexts are in the Mta messages, which are
+// the same for all the peers, so everyone could recompute delta_j and chi_j*G of the peers, and blame the ones
+// whose Delta messages are inconsistent with them.
+type revealHandler struct {
+	*deltaHandler
+
+	err error
+}
+
+func newRevealHandler(p *deltaHandler, err error) *revealHandler {
+	return &revealHandler{
+		deltaHandler: p,
+		err:          err,
+	}
+}
+
+func (p *revealHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_Reveal)
+}
+
+func (p *revealHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *revealHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.reveal != nil
+}
+
+func (p *revealHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return ErrPeerNotFound
+	}
+
+	body := msg.GetReveal()
+	k := new(big.Int).SetBytes(body.GetK())
+	err := paillier.VerifyOpening(peer.pubkey, peer.enck.encK, k, new(big.Int).SetBytes(body.GetKNonce()))
+	if err != nil {
+		logger.Warn("Failed to verify k", "err", err)
+		return err
+	}
+	gamma := new(big.Int).SetBytes(body.GetGamma())
+	if !pt.ScalarBaseMult(p.getCurve(), gamma).Equal(peer.mta.bigGamma) {
+		logger.Warn("Inconsistent gamma")
+		return ErrInvalidReveal
+	}
+	openings := body.GetOpenings()
+	if len(openings) != len(p.peers) {
+		logger.Warn("Inconsistent opening number", "got", len(openings), "expected", len(p.peers))
+		return ErrInvalidReveal
+	}
+	alphas := make(map[string]*big.Int, len(openings))
+	alphaHats := make(map[string]*big.Int, len(openings))
+	for fromID, opening := range openings {
+		entry := p.getMtaEntry(fromID, id)
+		if entry == nil {
+			logger.Warn("Mta not found", "fromID", fromID)
+			return ErrInvalidReveal
+		}
+		alpha := new(big.Int).SetBytes(opening.GetAlpha())
+		err = paillier.VerifyOpening(peer.pubkey, entry.GetD(), alpha, new(big.Int).SetBytes(opening.GetAlphaNonce()))
+		if err != nil {
+			logger.Warn("Failed to verify alpha", "fromID", fromID, "err", err)
+			return err
+		}
+		alphaHat := new(big.Int).SetBytes(opening.GetAlphaHat())
+		err = paillier.VerifyOpening(peer.pubkey, entry.GetDHat(), alphaHat, new(big.Int).SetBytes(opening.GetAlphaHatNonce()))
+		if err != nil {
+			logger.Warn("Failed to verify alpha hat", "fromID", fromID, "err", err)
+			return err
+		}
+		alphas[fromID] = alpha
+		alphaHats[fromID] = alphaHat
+	}
+	peer.reveal = &revealData{
+		k:         k,
+		gamma:     gamma,
+		alphas:    alphas,
+		alphaHats: alphaHats,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *revealHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	curve := p.getCurve()
+	n := p.getN()
+	selfID := p.peerManager.SelfID()
+	state := p.gammaMta.GetState()
+	self := &revealData{
+		k:         state.K,
+		gamma:     state.A,
+		alphas:    make(map[string]*big.Int, len(p.peers)),
+		alphaHats: make(map[string]*big.Int, len(p.peers)),
+	}
+	reveals := map[string]*revealData{
+		selfID: self,
+	}
+	ids := make([]string, 0, len(p.peers))
+	for id, peer := range p.peers {
+		self.alphas[id] = peer.mta.alpha
+		self.alphaHats[id] = peer.mta.alphaHat
+		reveals[id] = peer.reveal
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	k := big.NewInt(0)
+	gamma := big.NewInt(0)
+	for _, r := range reveals {
+		k = k.Add(k, r.k)
+		gamma = gamma.Add(gamma, r.gamma)
+	}
+
+	var evidence []types.Message
+	for _, id := range ids {
+		peer := p.peers[id]
+		r := reveals[id]
+		// delta_j = k_j*gamma_j + sum(alpha_{j,l} + beta_{l,j}), where beta_{l,j} = k_l*gamma_j - alpha_{l,j}.
+		// chi_j = k_j*w_j + sum(alphaHat_{j,l} + betaHat_{l,j}), where betaHat_{l,j} = k_l*w_j - alphaHat_{l,j}, so
+		// chi_j*G = k*W_j + sum(alphaHat_{j,l} - alphaHat_{l,j})*G.
+		delta := new(big.Int).Mul(r.k, r.gamma)
+		alphaHat := big.NewInt(0)
+		for l, lr := range reveals {
+			if l == id {
+				continue
+			}
+			delta = delta.Add(delta, r.alphas[l])
+			delta = delta.Add(delta, new(big.Int).Mul(lr.k, r.gamma))
+			delta = delta.Sub(delta, lr.alphas[id])
+			alphaHat = alphaHat.Add(alphaHat, r.alphaHats[l])
+			alphaHat = alphaHat.Sub(alphaHat, lr.alphaHats[id])
+		}
+		chiG, err := peer.bigW.ScalarMult(k).Add(pt.ScalarBaseMult(curve, new(big.Int).Mod(alphaHat, n)))
+		if err != nil {
+			logger.Warn("Failed to compute chi", "id", id, "err", err)
+			return nil, err
+		}
+		if delta.Mod(delta, n).Cmp(new(big.Int).Mod(peer.delta.delta, n)) != 0 || !chiG.ScalarMult(gamma).Equal(peer.delta.chiGamma) {
+			logger.Warn("Inconsistent delta or chi", "id", id)
+			evidence = append(evidence, peer.GetMessage(types.MessageType(Type_Delta)))
+		}
+	}
+	if len(evidence) == 0 {
+		logger.Warn("No culprit found")
+		return nil, p.err
+	}
+	return nil, message.NewBlameError(p.err, evidence...)
+}
+
+// getMtaEntry returns the MtA from the signer to the peer
+func (p *revealHandler) getMtaEntry(fromID string, toID string) *BodyMtaEntry {
+	if fromID == p.peerManager.SelfID() {
+		return p.mtaMsg.GetMta().GetMtas()[toID]
+	}
+	from, ok := p.peers[fromID]
+	if !ok || fromID == toID {
+		return nil
+	}
+	return from.mta.mtas[toID]
+}
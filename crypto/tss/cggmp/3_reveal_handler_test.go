@@ -0,0 +1,125 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	"math/big"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo/paillier"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("reveal handler, negative cases", func() {
+	var (
+		presigners map[string]*Presigner
+		listeners  map[string]*mocks.StateChangedListener
+
+		fromID, toID string
+		toH          *revealHandler
+		msg          *Message
+	)
+
+	BeforeEach(func() {
+		var pms map[string]*stopPeerManager
+		presigners, listeners, pms = newStoppedPresigners(Type_Delta)
+		sendEncKMessages(presigners)
+		waitHandler(presigners, func(h types.Handler) bool {
+			_, ok := h.(*deltaHandler)
+			return ok
+		})
+		fromID, toID = getID(1), getID(0)
+		fromDH := presigners[fromID].GetHandler().(*deltaHandler)
+		toDH := presigners[toID].GetHandler().(*deltaHandler)
+		Expect(toDH.HandleMessage(log.Discard(), pms[fromID].getDropped(toID))).Should(BeNil())
+		toH = newRevealHandler(toDH, ErrInconsistentDelta)
+		var err error
+		msg, err = fromDH.getRevealMessage()
+		Expect(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		stopPresigners(presigners, listeners)
+	})
+
+	It("should be ok", func() {
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(BeNil())
+		Expect(toH.IsHandled(log.Discard(), fromID)).Should(BeTrue())
+		// Nobody is blamed if the values of the peers are consistent
+		got, err := toH.Finalize(log.Discard())
+		Expect(got).Should(BeNil())
+		Expect(err).Should(Equal(ErrInconsistentDelta))
+	})
+
+	It("blames the peer with inconsistent delta", func() {
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(BeNil())
+		peer := toH.peers[fromID]
+		peer.delta.delta = new(big.Int).Add(peer.delta.delta, big.NewInt(1))
+		got, err := toH.Finalize(log.Discard())
+		Expect(got).Should(BeNil())
+		expectBlame(err, ErrInconsistentDelta, getMessage(peer.GetMessage(types.MessageType(Type_Delta))))
+	})
+
+	It("blames the peer with inconsistent chi", func() {
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(BeNil())
+		peer := toH.peers[fromID]
+		peer.delta.chiGamma = pt.NewBase(toH.getCurve())
+		got, err := toH.Finalize(log.Discard())
+		Expect(got).Should(BeNil())
+		expectBlame(err, ErrInconsistentDelta, getMessage(peer.GetMessage(types.MessageType(Type_Delta))))
+	})
+
+	It("invalid k", func() {
+		k := new(big.Int).SetBytes(msg.GetReveal().GetK())
+		msg.GetReveal().K = k.Add(k, big.NewInt(1)).Bytes()
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(paillier.ErrInvalidOpening))
+		Expect(toH.IsHandled(log.Discard(), fromID)).Should(BeFalse())
+	})
+
+	It("invalid gamma", func() {
+		gamma := new(big.Int).SetBytes(msg.GetReveal().GetGamma())
+		msg.GetReveal().Gamma = gamma.Add(gamma, big.NewInt(1)).Bytes()
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(ErrInvalidReveal))
+	})
+
+	It("opening not found", func() {
+		delete(msg.GetReveal().GetOpenings(), toID)
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(ErrInvalidReveal))
+	})
+
+	It("opening of another peer", func() {
+		openings := msg.GetReveal().GetOpenings()
+		openings[fromID] = openings[toID]
+		delete(openings, toID)
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(ErrInvalidReveal))
+	})
+
+	It("invalid alpha", func() {
+		opening := msg.GetReveal().GetOpenings()[toID]
+		alpha := new(big.Int).SetBytes(opening.GetAlpha())
+		opening.Alpha = alpha.Add(alpha, big.NewInt(1)).Bytes()
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(paillier.ErrInvalidOpening))
+	})
+
+	It("invalid alpha hat", func() {
+		opening := msg.GetReveal().GetOpenings()[toID]
+		opening.AlphaHat, opening.AlphaHatNonce = opening.GetAlpha(), opening.GetAlphaNonce()
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(paillier.ErrInvalidOpening))
+	})
+})
@@ -0,0 +1,196 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/signer"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrZeroS is returned if the s is zero
+	ErrZeroS = errors.New("zero s")
+	// ErrInvalidSignature is returned if the signature is invalid
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrInvalidSigma is returned if sigma_j*R is not m*k_j*R + r*chi_j*R
+	ErrInvalidSigma = errors.New("invalid sigma")
+)
+
+type sigmaData struct {
+	sigma *big.Int
+}
+
+type sigmaHandler struct {
+	publicKey *pt.ECPoint
+	r         *pt.ECPoint
+	msg       []byte
+	sigma     *big.Int
+	sessionID []byte
+	s         *big.Int
+
+	peerManager types.PeerManager
+	peerNum     uint32
+	peers       map[string]*peer
+}
+
+func newSigmaHandler(peerManager types.PeerManager, sessionID []byte, presignature *Presignature, msg []byte) (*sigmaHandler, error) {
+	// Ensure the signers are the same as the ones generating the presignature
+	peerIDs, err := message.GetPeerIDs(peerManager)
+	if err != nil {
+		log.Warn("Failed to get peer ids", "err", err)
+		return nil, err
+	}
+	sort.Strings(peerIDs)
+	if peerManager.SelfID() != presignature.selfID || int(peerManager.NumPeers()) != len(presignature.peerIDs) || !isSameIDs(peerIDs, presignature.peerIDs) {
+		log.Warn("Inconsistent signers", "self", peerManager.SelfID(), "peers", peerIDs, "expected", presignature.peerIDs)
+		return nil, ErrInconsistentSigners
+	}
+	k, chi, err := presignature.consume()
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make(map[string]*peer, len(peerIDs))
+	for _, id := range peerIDs {
+		peers[id] = newPeer(id, nil, nil, nil)
+		peers[id].presign = presignature.peers[id]
+	}
+	// sigma_i = k_i*m + r*chi_i
+	n := presignature.publicKey.GetCurve().Params().N
+	sigma := new(big.Int).Mul(k, new(big.Int).SetBytes(msg))
+	sigma = sigma.Add(sigma, new(big.Int).Mul(presignature.r.GetX(), chi))
+	return &sigmaHandler{
+		publicKey: presignature.publicKey,
+		r:         presignature.r,
+		msg:       msg,
+		sigma:     sigma.Mod(sigma, n),
+		sessionID: sessionID,
+
+		peerManager: peerManager,
+		peerNum:     peerManager.NumPeers(),
+		peers:       peers,
+	}, nil
+}
+
+func (p *sigmaHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_Sigma)
+}
+
+func (p *sigmaHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *sigmaHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.sigma != nil
+}
+
+func (p *sigmaHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return ErrPeerNotFound
+	}
+
+	peer.sigma = &sigmaData{
+		sigma: new(big.Int).SetBytes(msg.GetSigma().GetSigma()),
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *sigmaHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	n := p.publicKey.GetCurve().Params().N
+	s := new(big.Int).Set(p.sigma)
+	for _, peer := range p.peers {
+		s = s.Add(s, peer.sigma.sigma)
+	}
+	s = s.Mod(s, n)
+	if s.Sign() == 0 {
+		logger.Warn("Zero s")
+		return nil, p.blameInvalidSigma(logger, ErrZeroS)
+	}
+	if !signer.VerifySignature(p.publicKey, new(big.Int).Mod(p.r.GetX(), n), s, new(big.Int).SetBytes(p.msg)) {
+		logger.Warn("Failed to verify the signature")
+		return nil, p.blameInvalidSigma(logger, ErrInvalidSignature)
+	}
+	p.s = s
+	return nil, nil
+}
+
+// blameInvalidSigma blames the peers whose sigma_j*R are not m*k_j*R + r*chi_j*R. Since the sum of k_j*R is G and
+// the sum of chi_j*R is Q, the signature is valid if all the sigma_j are valid. It returns err if nobody is blamed.
+func (p *sigmaHandler) blameInvalidSigma(logger log.Logger, err error) error {
+	ids := make([]string, 0, len(p.peers))
+	for id := range p.peers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	m := new(big.Int).SetBytes(p.msg)
+	var evidence []types.Message
+	for _, id := range ids {
+		peer := p.peers[id]
+		expected, addErr := peer.presign.kR.ScalarMult(m).Add(peer.presign.chiR.ScalarMult(p.r.GetX()))
+		if addErr != nil {
+			logger.Warn("Failed to compute expected sigma", "id", id, "err", addErr)
+			return addErr
+		}
+		if !p.r.ScalarMult(peer.sigma.sigma).Equal(expected) {
+			logger.Warn("Invalid sigma", "id", id)
+			evidence = append(evidence, peer.GetMessage(types.MessageType(Type_Sigma)))
+		}
+	}
+	if len(evidence) == 0 {
+		return err
+	}
+	return message.NewBlameError(ErrInvalidSigma, evidence...)
+}
+
+func (p *sigmaHandler) getSigmaMessage() *Message {
+	return &Message{
+		Type:      Type_Sigma,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_Sigma{
+			Sigma: &BodySigma{
+				Sigma: p.sigma.Bytes(),
+			},
+		},
+	}
+}
+
+func isSameIDs(ids1 []string, ids2 []string) bool {
+	if len(ids1) != len(ids2) {
+		return false
+	}
+	for i := range ids1 {
+		if ids1[i] != ids2[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sigma handler, negative cases", func() {
+	var (
+		curve = btcec.S256()
+		ids   = []string{getID(0), getID(1)}
+
+		h0, h1 *sigmaHandler
+	)
+
+	BeforeEach(func() {
+		// The presignatures are not generated jointly, so the signature is invalid even if the sigmas are valid
+		newHandler := func(id string, peerID string) *sigmaHandler {
+			r := pt.ScalarBaseMult(curve, big.NewInt(7))
+			h, err := newSigmaHandler(newPeerManager(id, ids), sessionID, &Presignature{
+				publicKey: pt.ScalarBaseMult(curve, big.NewInt(5)),
+				r:         r,
+				k:         big.NewInt(11),
+				chi:       big.NewInt(13),
+				selfID:    id,
+				peerIDs:   []string{peerID},
+				peers: map[string]*presignData{
+					peerID: {
+						kR:   r.ScalarMult(big.NewInt(11)),
+						chiR: r.ScalarMult(big.NewInt(13)),
+					},
+				},
+			}, msg)
+			Expect(err).Should(BeNil())
+			return h
+		}
+		h0 = newHandler(ids[0], ids[1])
+		h1 = newHandler(ids[1], ids[0])
+	})
+
+	It("peer not found", func() {
+		Expect(h0.IsHandled(log.Discard(), "invalid peer")).Should(BeFalse())
+		Expect(h0.HandleMessage(log.Discard(), &Message{
+			Id: "invalid peer",
+		})).Should(Equal(ErrPeerNotFound))
+	})
+
+	It("invalid signature", func() {
+		Expect(h0.HandleMessage(log.Discard(), h1.getSigmaMessage())).Should(BeNil())
+		Expect(h0.IsHandled(log.Discard(), ids[1])).Should(BeTrue())
+		got, err := h0.Finalize(log.Discard())
+		Expect(got).Should(BeNil())
+		Expect(err).Should(Equal(ErrInvalidSignature))
+	})
+
+	It("invalid sigma", func() {
+		msg := h1.getSigmaMessage()
+		msg.GetSigma().Sigma = new(big.Int).Add(h1.sigma, big.NewInt(1)).Bytes()
+		Expect(h0.HandleMessage(log.Discard(), msg)).Should(BeNil())
+		got, err := h0.Finalize(log.Discard())
+		Expect(got).Should(BeNil())
+		expectBlame(err, ErrInvalidSigma, msg)
+	})
+
+	It("zero s", func() {
+		msg := h1.getSigmaMessage()
+		msg.GetSigma().Sigma = new(big.Int).Sub(curve.Params().N, h0.sigma).Bytes()
+		Expect(h0.HandleMessage(log.Discard(), msg)).Should(BeNil())
+		got, err := h0.Finalize(log.Discard())
+		Expect(got).Should(BeNil())
+		expectBlame(err, ErrInvalidSigma, msg)
+	})
+})
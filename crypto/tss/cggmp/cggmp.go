@@ -0,0 +1,225 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cggmp implements the three-round presigning and the one-round online signing of CGGMP20 with the keys of
+// DKG. The aborts are identifiable. The peers sending invalid proofs are blamed. If delta or chi is inconsistent in
+// presigning, the signers reveal their ephemeral secrets in one more round to find the culprits, instead of the
+// proofs of CGGMP20 (i.e. Π^aff-p and Π^dec). If the signature is invalid in online signing, the peers whose
+// sigma_j are inconsistent with k_j*R and chi_j*R of the presignature are blamed.
+package cggmp
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/auxinfo"
+	"github.com/getamis/alice/crypto/tss/dkg"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/signer"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrPresignatureUsed is returned if the presignature has been used to sign
+	ErrPresignatureUsed = errors.New("presignature has been used")
+	// ErrInconsistentSigners is returned if the signers are not the ones generating the presignature
+	ErrInconsistentSigners = errors.New("inconsistent signers")
+)
+
+// Presignature is the output of the presigning of CGGMP (i.e. R, k_i and chi_i). It contains the secret shares, so
+// it must be kept private and used to sign only one message. NewOnlineSigner consumes it, and it cannot be used
+// again.
+type Presignature struct {
+	mu   sync.Mutex
+	used bool
+
+	publicKey *pt.ECPoint
+	r         *pt.ECPoint
+	k         *big.Int
+	chi       *big.Int
+	selfID    string
+	// peerIDs are the sorted ids of the peers generating the presignature together, and peers are their k_j*R and
+	// chi_j*R
+	peerIDs []string
+	peers   map[string]*presignData
+}
+
+// GetPublicKey returns the public key which the presignature signs under
+func (p *Presignature) GetPublicKey() *pt.ECPoint {
+	return p.publicKey.Copy()
+}
+
+// GetR returns the R of the presignature, whose x-coordinate is the r of the signature
+func (p *Presignature) GetR() *pt.ECPoint {
+	return p.r.Copy()
+}
+
+// IsUsed returns true if the presignature has been used to sign
+func (p *Presignature) IsUsed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.used
+}
+
+// consume marks the presignature as used and returns k_i and chi_i. The secrets are dropped from the presignature,
+// so they could be returned only once.
+func (p *Presignature) consume() (*big.Int, *big.Int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.used {
+		return nil, nil, ErrPresignatureUsed
+	}
+	k, chi := p.k, p.chi
+	p.used = true
+	p.k = nil
+	p.chi = nil
+	return k, chi, nil
+}
+
+// Presigner runs the three rounds of the presigning of CGGMP (i.e. EncK, Mta and Delta) with the key of DKG and the
+// Paillier keys of auxinfo. All the messages carry the zero-knowledge proofs, so a peer sending an invalid proof
+// is reported as a culprit in GetFailure(). If delta*G is not the sum of Delta_j or delta*Q is not the sum of
+// chi_j*Gamma, the presigners run the Reveal round, and the peers sending inconsistent Delta messages are the
+// culprits. The EncK, Mta and Delta rounds are echo broadcasts, so the peers must route the received *message.EchoMessage
+// to AddMessage.
+type Presigner struct {
+	eh *encKHandler
+	*message.MsgMain
+
+	mu           sync.Mutex
+	presignature *Presignature
+}
+
+// NewPresigner creates a presigner with self and the peers in the peer manager as the signers. The Birkhoff
+// parameters and the public shares of the signers are taken from the DKG result, so the result could be used
+// without resharing. The homomorphic encryption of the aux info must be Paillier.
+func NewPresigner(peerManager types.PeerManager, sessionID []byte, dkgResult *dkg.Result, auxInfo *auxinfo.Result, listener types.StateChangedListener) (*Presigner, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	eh, err := newEncKHandler(peerManager, sessionID, dkgResult, auxInfo)
+	if err != nil {
+		log.Warn("Failed to new an enck handler", "err", err)
+		return nil, err
+	}
+	p := &Presigner{
+		eh: eh,
+		MsgMain: message.NewMsgMain(peerManager,
+			sessionID,
+			listener,
+			eh,
+			types.MessageType(Type_EncK),
+			types.MessageType(Type_Mta),
+			types.MessageType(Type_Delta),
+			types.MessageType(Type_Reveal),
+		),
+	}
+	// The peers must agree on the ciphertexts, with which the secrets are checked in the Reveal round, and on whether
+	// to run the Reveal round
+	p.EnableEchoBroadcast(types.MessageType(Type_EncK), types.MessageType(Type_Mta), types.MessageType(Type_Delta))
+	return p, nil
+}
+
+// GetEncKMessage returns the EncK message to broadcast, which contains the proofs under the ring-Pedersen parameters
+// of all the peers.
+func (p *Presigner) GetEncKMessage() *Message {
+	return p.eh.encKMsg
+}
+
+// GetResult returns the presignature. The same presignature is returned in every call, so it could be used
+// only once.
+func (p *Presigner) GetResult() (*Presignature, error) {
+	if p.GetState() != types.StateDone {
+		return nil, tss.ErrNotReady
+	}
+
+	h := p.GetHandler()
+	rh, ok := h.(*deltaHandler)
+	if !ok {
+		log.Error("We cannot convert to delta handler in done state")
+		return nil, tss.ErrNotReady
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.presignature == nil {
+		peerIDs := make([]string, 0, len(rh.peers))
+		peers := make(map[string]*presignData, len(rh.peers))
+		for id, peer := range rh.peers {
+			peerIDs = append(peerIDs, id)
+			peers[id] = peer.presign
+		}
+		sort.Strings(peerIDs)
+		p.presignature = &Presignature{
+			publicKey: rh.publicKey,
+			r:         rh.r,
+			k:         rh.gammaMta.GetState().K,
+			chi:       rh.chi,
+			selfID:    rh.peerManager.SelfID(),
+			peerIDs:   peerIDs,
+			peers:     peers,
+		}
+	}
+	return p.presignature, nil
+}
+
+// OnlineSigner signs the message with a presignature in one round, where each signer broadcasts
+// sigma_i = k_i*m + r*chi_i. If the signature is invalid, the peers whose sigma_j*R are not m*k_j*R + r*chi_j*R are
+// reported as culprits in GetFailure().
+type OnlineSigner struct {
+	sh *sigmaHandler
+	*message.MsgMain
+
+	lowS bool
+}
+
+// NewOnlineSigner consumes the presignature to sign the message. The peer manager must manage the same peers as
+// the one generating the presignature. The presignature could not be used again even if the signing fails.
+func NewOnlineSigner(peerManager types.PeerManager, sessionID []byte, presignature *Presignature, msg []byte, listener types.StateChangedListener) (*OnlineSigner, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	sh, err := newSigmaHandler(peerManager, sessionID, presignature, msg)
+	if err != nil {
+		log.Warn("Failed to new a sigma handler", "err", err)
+		return nil, err
+	}
+	return &OnlineSigner{
+		sh:      sh,
+		lowS:    true,
+		MsgMain: message.NewMsgMain(peerManager, sessionID, listener, sh, types.MessageType(Type_Sigma)),
+	}, nil
+}
+
+func (s *OnlineSigner) GetSigmaMessage() *Message {
+	return s.sh.getSigmaMessage()
+}
+
+// SetLowS sets whether s of the result is normalized to the lower one. It's enabled by default.
+func (s *OnlineSigner) SetLowS(lowS bool) {
+	s.lowS = lowS
+}
+
+// GetResult returns the signature with the recovery id
+func (s *OnlineSigner) GetResult() (*signer.Result, error) {
+	if s.GetState() != types.StateDone {
+		return nil, tss.ErrNotReady
+	}
+	return signer.NewResult(s.sh.r, s.sh.s, s.lowS), nil
+}
@@ -0,0 +1,591 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo"
+	homoMocks "github.com/getamis/alice/crypto/homo/mocks"
+	"github.com/getamis/alice/crypto/homo/paillier"
+	"github.com/getamis/alice/crypto/polynomial"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/auxinfo"
+	"github.com/getamis/alice/crypto/tss/dkg"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	proto "github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	sessionID = []byte("session id")
+	msg       = []byte{1, 2, 3}
+
+	testHomos = make(map[string]*paillier.Paillier)
+)
+
+func TestCGGMP(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CGGMP Suite")
+}
+
+var _ = Describe("CGGMP", func() {
+	DescribeTable("presigns and signs", func(threshold int, num int, ids []string) {
+		dkgResults := newTestDKGResults(threshold, num)
+		presigners, listeners := newTestPresigners(dkgResults, ids)
+		doneChs := expectDone(listeners)
+		for _, p := range presigners {
+			_, err := p.GetResult()
+			Expect(err).Should(Equal(tss.ErrNotReady))
+		}
+		sendEncKMessages(presigners)
+		for _, ch := range doneChs {
+			<-ch
+		}
+
+		presignatures := make(map[string]*Presignature, len(ids))
+		for id, p := range presigners {
+			p.Stop()
+			var err error
+			presignatures[id], err = p.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(presignatures[id].IsUsed()).Should(BeFalse())
+		}
+		// All R should be the same
+		for _, p := range presignatures {
+			Expect(p.GetR()).Should(Equal(presignatures[ids[0]].GetR()))
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+
+		signers, listeners := newTestOnlineSigners(presignatures, msg)
+		doneChs = expectDone(listeners)
+		for _, s := range signers {
+			_, err := s.GetResult()
+			Expect(err).Should(Equal(tss.ErrNotReady))
+		}
+		for fromID, fromS := range signers {
+			m := fromS.GetSigmaMessage()
+			for toID, toS := range signers {
+				if fromID != toID {
+					Expect(toS.AddMessage(m)).Should(BeNil())
+				}
+			}
+		}
+		for _, ch := range doneChs {
+			<-ch
+		}
+
+		publicKey := dkgResults[ids[0]].PublicKey
+		ecdsaPublicKey := &ecdsa.PublicKey{
+			Curve: publicKey.GetCurve(),
+			X:     publicKey.GetX(),
+			Y:     publicKey.GetY(),
+		}
+		for id, s := range signers {
+			s.Stop()
+			Expect(presignatures[id].IsUsed()).Should(BeTrue())
+			result, err := s.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(ecdsa.Verify(ecdsaPublicKey, msg, result.R, result.S)).Should(BeTrue())
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+
+		// The presignatures could not be used again
+		for id, p := range presignatures {
+			pm := newPeerManager(id, ids)
+			got, err := NewOnlineSigner(pm, sessionID, p, msg, new(mocks.StateChangedListener))
+			Expect(got).Should(BeNil())
+			Expect(err).Should(Equal(ErrPresignatureUsed))
+		}
+	},
+		Entry("(t, n) = (2, 3)", 2, 3, []string{getID(0), getID(2)}),
+		Entry("(t, n) = (3, 3)", 3, 3, []string{getID(0), getID(1), getID(2)}),
+	)
+
+	Context("NewPresigner", func() {
+		var (
+			ids        = []string{getID(0), getID(1)}
+			dkgResults map[string]*dkg.Result
+			auxInfos   map[string]*auxinfo.Result
+			pm         *peerManager
+		)
+
+		BeforeEach(func() {
+			dkgResults = newTestDKGResults(2, 3)
+			auxInfos = newTestAuxInfos(ids)
+			pm = newPeerManager(ids[0], ids)
+		})
+
+		It("empty session id", func() {
+			got, err := NewPresigner(pm, nil, dkgResults[ids[0]], auxInfos[ids[0]], new(mocks.StateChangedListener))
+			Expect(got).Should(BeNil())
+			Expect(err).Should(Equal(tss.ErrEmptySessionID))
+		})
+
+		It("not Paillier", func() {
+			auxInfos[ids[0]].Homo = new(homoMocks.Crypto)
+			got, err := NewPresigner(pm, sessionID, dkgResults[ids[0]], auxInfos[ids[0]], new(mocks.StateChangedListener))
+			Expect(got).Should(BeNil())
+			Expect(err).Should(Equal(ErrNotPaillier))
+		})
+
+		It("bk not found", func() {
+			delete(dkgResults[ids[0]].Bks, ids[1])
+			got, err := NewPresigner(pm, sessionID, dkgResults[ids[0]], auxInfos[ids[0]], new(mocks.StateChangedListener))
+			Expect(got).Should(BeNil())
+			Expect(err).Should(Equal(ErrBkNotFound))
+		})
+
+		It("not enough bks", func() {
+			pm = newPeerManager(ids[0], ids[:1])
+			got, err := NewPresigner(pm, sessionID, dkgResults[ids[0]], auxInfos[ids[0]], new(mocks.StateChangedListener))
+			Expect(got).Should(BeNil())
+			Expect(err).Should(Equal(tss.ErrInconsistentPubKey))
+		})
+
+		It("public share not found", func() {
+			delete(dkgResults[ids[0]].PublicShares, ids[1])
+			got, err := NewPresigner(pm, sessionID, dkgResults[ids[0]], auxInfos[ids[0]], new(mocks.StateChangedListener))
+			Expect(got).Should(BeNil())
+			Expect(err).Should(Equal(ErrPublicShareNotFound))
+		})
+
+		It("public key not found", func() {
+			delete(auxInfos[ids[0]].Pubkeys, ids[1])
+			got, err := NewPresigner(pm, sessionID, dkgResults[ids[0]], auxInfos[ids[0]], new(mocks.StateChangedListener))
+			Expect(got).Should(BeNil())
+			Expect(err).Should(Equal(ErrPubkeyNotFound))
+		})
+
+		It("inconsistent public share", func() {
+			dkgResults[ids[0]].PublicShares[ids[1]] = pt.ScalarBaseMult(btcec.S256(), big.NewInt(1))
+			got, err := NewPresigner(pm, sessionID, dkgResults[ids[0]], auxInfos[ids[0]], new(mocks.StateChangedListener))
+			Expect(got).Should(BeNil())
+			Expect(err).Should(Equal(tss.ErrInconsistentPubKey))
+		})
+	})
+
+	DescribeTable("blames the peer with inconsistent delta or chi", func(tamper func(*encKData), expErr error) {
+		ids := []string{getID(0), getID(1), getID(2)}
+		presigners, listeners := newTestPresigners(newTestDKGResults(2, 3), ids)
+		pms := make(map[string]*stopPeerManager, len(presigners))
+		for id, p := range presigners {
+			pms[id] = newStopPeerManager(Type_Mta, p.eh.peerManager)
+			p.eh.peerManager = pms[id]
+		}
+		sendEncKMessages(presigners)
+		waitHandler(presigners, func(h types.Handler) bool {
+			_, ok := h.(*mtaHandler)
+			return ok
+		})
+
+		// The culprit computes its delta and chi with a wrong share of the MtA with one of the peers
+		badID := ids[2]
+		for _, peer := range presigners[badID].eh.peers {
+			tamper(peer.enck)
+			break
+		}
+		failedChs := expectFailed(listeners)
+		for _, pm := range pms {
+			pm.release()
+		}
+		for _, ch := range failedChs {
+			<-ch
+		}
+
+		for id, p := range presigners {
+			failure := p.GetFailure()
+			Expect(failure).ShouldNot(BeNil())
+			Expect(failure.Err).Should(MatchError(expErr))
+			if id == badID {
+				Expect(failure.Culprits).Should(BeEmpty())
+				continue
+			}
+			Expect(failure.Culprits).Should(Equal([]string{badID}))
+			result, err := p.GetResult()
+			Expect(err).Should(Equal(tss.ErrNotReady))
+			Expect(result).Should(BeNil())
+		}
+		for _, p := range presigners {
+			p.Stop()
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	},
+		Entry("inconsistent delta", func(d *encKData) {
+			d.beta.Add(d.beta, big.NewInt(1))
+		}, ErrInconsistentDelta),
+		Entry("inconsistent chi", func(d *encKData) {
+			d.betaHat.Add(d.betaHat, big.NewInt(1))
+		}, ErrInconsistentChi),
+	)
+
+	It("blames the peer with an invalid sigma", func() {
+		ids := []string{getID(0), getID(1)}
+		presigners, listeners := newTestPresigners(newTestDKGResults(2, 3), ids)
+		doneChs := expectDone(listeners)
+		sendEncKMessages(presigners)
+		for _, ch := range doneChs {
+			<-ch
+		}
+		presignatures := make(map[string]*Presignature, len(ids))
+		for id, p := range presigners {
+			p.Stop()
+			var err error
+			presignatures[id], err = p.GetResult()
+			Expect(err).Should(BeNil())
+		}
+
+		signers, listeners := newTestOnlineSigners(presignatures, msg)
+		failedCh := make(chan struct{})
+		listeners[ids[0]].On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+			close(failedCh)
+		}).Once()
+		// The sigma of id-1 is increased by one
+		m := signers[ids[1]].GetSigmaMessage()
+		sigma := new(big.Int).SetBytes(m.GetSigma().GetSigma())
+		m.GetSigma().Sigma = sigma.Add(sigma, big.NewInt(1)).Bytes()
+		Expect(signers[ids[0]].AddMessage(m)).Should(BeNil())
+		<-failedCh
+
+		failure := signers[ids[0]].GetFailure()
+		Expect(failure).ShouldNot(BeNil())
+		Expect(failure.Culprits).Should(Equal([]string{ids[1]}))
+		Expect(failure.Err).Should(MatchError(ErrInvalidSigma))
+		result, err := signers[ids[0]].GetResult()
+		Expect(err).Should(Equal(tss.ErrNotReady))
+		Expect(result).Should(BeNil())
+
+		listeners[ids[1]].On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+		for _, s := range signers {
+			s.Stop()
+		}
+		time.Sleep(500 * time.Millisecond)
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("NewOnlineSigner with inconsistent signers", func() {
+		presignature := &Presignature{
+			selfID:  getID(0),
+			peerIDs: []string{getID(1)},
+		}
+		got, err := NewOnlineSigner(newPeerManager(getID(0), []string{getID(0), getID(2)}), sessionID, presignature, msg, new(mocks.StateChangedListener))
+		Expect(got).Should(BeNil())
+		Expect(err).Should(Equal(ErrInconsistentSigners))
+		Expect(presignature.IsUsed()).Should(BeFalse())
+	})
+})
+
+func getID(id int) string {
+	return fmt.Sprintf("id-%d", id)
+}
+
+func getTestHomo(id string) *paillier.Paillier {
+	homo, ok := testHomos[id]
+	if !ok {
+		var err error
+		homo, err = paillier.NewPaillier(2048)
+		Expect(err).Should(BeNil())
+		testHomos[id] = homo
+	}
+	return homo
+}
+
+// newTestDKGResults returns the DKG results of a (threshold, num) key, whose shares are of rank 0
+func newTestDKGResults(threshold int, num int) map[string]*dkg.Result {
+	curve := btcec.S256()
+	poly, err := polynomial.RandomPolynomial(curve.Params().N, uint32(threshold-1))
+	Expect(err).Should(BeNil())
+	shares := make(map[string]*big.Int, num)
+	bks := make(map[string]*birkhoffinterpolation.BkParameter, num)
+	publicShares := make(map[string]*pt.ECPoint, num)
+	for i := 0; i < num; i++ {
+		id := getID(i)
+		x := big.NewInt(int64(i + 1))
+		shares[id] = poly.Evaluate(x)
+		bks[id] = birkhoffinterpolation.NewBkParameter(x, 0)
+		publicShares[id] = pt.ScalarBaseMult(curve, shares[id])
+	}
+	results := make(map[string]*dkg.Result, num)
+	for id, share := range shares {
+		// Copy the maps, so they could be modified in the tests
+		resultBks := make(map[string]*birkhoffinterpolation.BkParameter, num)
+		resultPublicShares := make(map[string]*pt.ECPoint, num)
+		for peerID := range shares {
+			resultBks[peerID] = bks[peerID]
+			resultPublicShares[peerID] = publicShares[peerID]
+		}
+		results[id] = &dkg.Result{
+			PublicKey:    pt.ScalarBaseMult(curve, poly.Get(0)),
+			Share:        share,
+			Bks:          resultBks,
+			PublicShares: resultPublicShares,
+		}
+	}
+	return results
+}
+
+// newTestAuxInfos returns the aux info of the peers, whose Paillier public keys are verified before
+func newTestAuxInfos(ids []string) map[string]*auxinfo.Result {
+	auxInfos := make(map[string]*auxinfo.Result, len(ids))
+	for _, id := range ids {
+		pubkeys := make(map[string]homo.Pubkey, len(ids)-1)
+		for _, peerID := range ids {
+			if peerID != id {
+				pubkeys[peerID] = getTestHomo(peerID).GetPubKey()
+			}
+		}
+		auxInfos[id] = &auxinfo.Result{
+			Homo:    getTestHomo(id),
+			Pubkeys: pubkeys,
+		}
+	}
+	return auxInfos
+}
+
+func newTestPresigners(dkgResults map[string]*dkg.Result, ids []string) (map[string]*Presigner, map[string]*mocks.StateChangedListener) {
+	auxInfos := newTestAuxInfos(ids)
+	presigners := make(map[string]*Presigner, len(ids))
+	listeners := make(map[string]*mocks.StateChangedListener, len(ids))
+	mains := make(map[string]*message.MsgMain, len(ids))
+	for _, id := range ids {
+		pm := newPeerManager(id, ids)
+		pm.mains = mains
+		listeners[id] = new(mocks.StateChangedListener)
+		p, err := NewPresigner(pm, sessionID, dkgResults[id], auxInfos[id], listeners[id])
+		Expect(err).Should(BeNil())
+		presigners[id] = p
+		mains[id] = p.MsgMain
+	}
+	for _, p := range presigners {
+		p.Start()
+	}
+	return presigners, listeners
+}
+
+func newTestOnlineSigners(presignatures map[string]*Presignature, msg []byte) (map[string]*OnlineSigner, map[string]*mocks.StateChangedListener) {
+	ids := make([]string, 0, len(presignatures))
+	for id := range presignatures {
+		ids = append(ids, id)
+	}
+	signers := make(map[string]*OnlineSigner, len(ids))
+	listeners := make(map[string]*mocks.StateChangedListener, len(ids))
+	for _, id := range ids {
+		listeners[id] = new(mocks.StateChangedListener)
+		s, err := NewOnlineSigner(newPeerManager(id, ids), sessionID, presignatures[id], msg, listeners[id])
+		Expect(err).Should(BeNil())
+		signers[id] = s
+		s.Start()
+	}
+	return signers, listeners
+}
+
+func sendEncKMessages(presigners map[string]*Presigner) {
+	for _, p := range presigners {
+		m := p.GetEncKMessage()
+		for _, peer := range presigners {
+			if peer != p {
+				Expect(peer.AddMessage(proto.Clone(m).(*Message))).Should(BeNil())
+			}
+		}
+	}
+}
+
+func expectDone(listeners map[string]*mocks.StateChangedListener) []chan struct{} {
+	doneChs := make([]chan struct{}, 0, len(listeners))
+	for _, l := range listeners {
+		doneCh := make(chan struct{})
+		l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+			close(doneCh)
+		}).Once()
+		doneChs = append(doneChs, doneCh)
+	}
+	return doneChs
+}
+
+func expectFailed(listeners map[string]*mocks.StateChangedListener) []chan struct{} {
+	failedChs := make([]chan struct{}, 0, len(listeners))
+	for _, l := range listeners {
+		failedCh := make(chan struct{})
+		l.On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+			close(failedCh)
+		}).Once()
+		failedChs = append(failedChs, failedCh)
+	}
+	return failedChs
+}
+
+// expectBlame expects that the error blames the senders of the messages with the messages as the evidence.
+func expectBlame(err error, expErr error, msgs ...*Message) {
+	var blameErr *message.BlameError
+	ExpectWithOffset(1, errors.As(err, &blameErr)).Should(BeTrue())
+	ExpectWithOffset(1, blameErr.Err).Should(Equal(expErr))
+	culprits := make([]string, len(msgs))
+	evidence := make([]types.Message, len(msgs))
+	for i, m := range msgs {
+		culprits[i] = m.GetId()
+		evidence[i] = m
+	}
+	ExpectWithOffset(1, blameErr.Culprits).Should(Equal(culprits))
+	ExpectWithOffset(1, blameErr.Evidence).Should(Equal(evidence))
+}
+
+// waitHandler waits until the handlers of the presigners are the ones of the round
+func waitHandler(presigners map[string]*Presigner, isRound func(types.Handler) bool) {
+	for _, p := range presigners {
+		Eventually(func() bool {
+			return isRound(p.GetHandler())
+		}, 30*time.Second).Should(BeTrue())
+	}
+}
+
+type peerManager struct {
+	id      string
+	peerIDs []string
+	mains   map[string]*message.MsgMain
+}
+
+// newPeerManager news a peer manager of the peer, and the others in ids are the peers
+func newPeerManager(id string, ids []string) *peerManager {
+	peerIDs := make([]string, 0, len(ids))
+	for _, peerID := range ids {
+		if peerID != id {
+			peerIDs = append(peerIDs, peerID)
+		}
+	}
+	return &peerManager{
+		id:      id,
+		peerIDs: peerIDs,
+	}
+}
+
+func (p *peerManager) NumPeers() uint32 {
+	return uint32(len(p.peerIDs))
+}
+
+func (p *peerManager) SelfID() string {
+	return p.id
+}
+
+func (p *peerManager) PeerIDs() []string {
+	return p.peerIDs
+}
+
+func (p *peerManager) MustSend(id string, message proto.Message) {
+	m, ok := p.mains[id]
+	if !ok {
+		return
+	}
+	Expect(m.AddMessage(message.(types.Message))).Should(BeNil())
+}
+
+// stopPeerManager drops the messages of the round and the following rounds. The dropped messages of the round are
+// kept by the receivers, so they could be handled in the tests or sent after release.
+type stopPeerManager struct {
+	types.PeerManager
+	stopMessageType Type
+
+	mu       sync.Mutex
+	dropped  map[string]*Message
+	released bool
+}
+
+func newStopPeerManager(stopMessageType Type, p types.PeerManager) *stopPeerManager {
+	return &stopPeerManager{
+		PeerManager:     p,
+		stopMessageType: stopMessageType,
+		dropped:         make(map[string]*Message),
+	}
+}
+
+func (p *stopPeerManager) MustSend(id string, message proto.Message) {
+	msg := message.(*Message)
+	p.mu.Lock()
+	released := p.released
+	p.mu.Unlock()
+	if released || msg.Type < p.stopMessageType {
+		p.PeerManager.MustSend(id, message)
+		return
+	}
+	if msg.Type == p.stopMessageType {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.dropped[id] = proto.Clone(msg).(*Message)
+	}
+}
+
+// release sends the dropped messages, and the following messages are not dropped anymore
+func (p *stopPeerManager) release() {
+	p.mu.Lock()
+	p.released = true
+	dropped := p.dropped
+	p.dropped = make(map[string]*Message)
+	p.mu.Unlock()
+	for id, msg := range dropped {
+		p.PeerManager.MustSend(id, msg)
+	}
+}
+
+func (p *stopPeerManager) getDropped(id string) *Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.dropped[id]
+}
+
+// newStoppedPresigners news the presigners of a (2, 3) key, which stop before the messages of the round are sent
+func newStoppedPresigners(stopMessageType Type) (map[string]*Presigner, map[string]*mocks.StateChangedListener, map[string]*stopPeerManager) {
+	presigners, listeners := newTestPresigners(newTestDKGResults(2, 3), []string{getID(0), getID(1)})
+	pms := make(map[string]*stopPeerManager, len(presigners))
+	for id, p := range presigners {
+		pms[id] = newStopPeerManager(stopMessageType, p.eh.peerManager)
+		p.eh.peerManager = pms[id]
+	}
+	return presigners, listeners, pms
+}
+
+// stopPresigners stops the presigners, which are not done
+func stopPresigners(presigners map[string]*Presigner, listeners map[string]*mocks.StateChangedListener) {
+	for _, l := range listeners {
+		l.On("OnStateChanged", types.StateInit, types.StateFailed).Return().Once()
+	}
+	for _, p := range presigners {
+		p.Stop()
+	}
+	time.Sleep(500 * time.Millisecond)
+	for _, l := range listeners {
+		l.AssertExpectations(GinkgoT())
+	}
+}
@@ -0,0 +1,754 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/getamis/alice/crypto/tss/cggmp/message.proto
+
+package cggmp
+
+import (
+	fmt "fmt"
+	ecpointgrouplaw "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	paillier "github.com/getamis/alice/crypto/homo/paillier"
+	zkproof "github.com/getamis/alice/crypto/zkproof"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Type int32
+
+const (
+	Type_EncK   Type = 0
+	Type_Mta    Type = 1
+	Type_Delta  Type = 2
+	Type_Sigma  Type = 3
+	Type_Reveal Type = 4
+)
+
+var Type_name = map[int32]string{
+	0: "EncK",
+	1: "Mta",
+	2: "Delta",
+	3: "Sigma",
+	4: "Reveal",
+}
+
+var Type_value = map[string]int32{
+	"EncK":   0,
+	"Mta":    1,
+	"Delta":  2,
+	"Sigma":  3,
+	"Reveal": 4,
+}
+
+func (x Type) String() string {
+	return proto.EnumName(Type_name, int32(x))
+}
+
+func (Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b68ab99c594abd27, []int{0}
+}
+
+type Message struct {
+	Type      Type   `protobuf:"varint,1,opt,name=type,proto3,enum=cggmp.Type" json:"type,omitempty"`
+	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId []byte `protobuf:"bytes,3,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	// Types that are valid to be assigned to Body:
+	//	*Message_EncK
+	//	*Message_Mta
+	//	*Message_Delta
+	//	*Message_Sigma
+	//	*Message_Reveal
+	Body                 isMessage_Body `protobuf_oneof:"body"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Message) Reset()         { *m = Message{} }
+func (m *Message) String() string { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()    {}
+func (*Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b68ab99c594abd27, []int{0}
+}
+
+func (m *Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Message.Unmarshal(m, b)
+}
+func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Message.Marshal(b, m, deterministic)
+}
+func (m *Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Message.Merge(m, src)
+}
+func (m *Message) XXX_Size() int {
+	return xxx_messageInfo_Message.Size(m)
+}
+func (m *Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Message proto.InternalMessageInfo
+
+func (m *Message) GetType() Type {
+	if m != nil {
+		return m.Type
+	}
+	return Type_EncK
+}
+
+func (m *Message) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Message) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+type isMessage_Body interface {
+	isMessage_Body()
+}
+
+type Message_EncK struct {
+	EncK *BodyEncK `protobuf:"bytes,4,opt,name=encK,proto3,oneof"`
+}
+
+type Message_Mta struct {
+	Mta *BodyMta `protobuf:"bytes,5,opt,name=mta,proto3,oneof"`
+}
+
+type Message_Delta struct {
+	Delta *BodyDelta `protobuf:"bytes,6,opt,name=delta,proto3,oneof"`
+}
+
+type Message_Sigma struct {
+	Sigma *BodySigma `protobuf:"bytes,7,opt,name=sigma,proto3,oneof"`
+}
+
+type Message_Reveal struct {
+	Reveal *BodyReveal `protobuf:"bytes,8,opt,name=reveal,proto3,oneof"`
+}
+
+func (*Message_EncK) isMessage_Body() {}
+
+func (*Message_Mta) isMessage_Body() {}
+
+func (*Message_Delta) isMessage_Body() {}
+
+func (*Message_Sigma) isMessage_Body() {}
+
+func (*Message_Reveal) isMessage_Body() {}
+
+func (m *Message) GetBody() isMessage_Body {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *Message) GetEncK() *BodyEncK {
+	if x, ok := m.GetBody().(*Message_EncK); ok {
+		return x.EncK
+	}
+	return nil
+}
+
+func (m *Message) GetMta() *BodyMta {
+	if x, ok := m.GetBody().(*Message_Mta); ok {
+		return x.Mta
+	}
+	return nil
+}
+
+func (m *Message) GetDelta() *BodyDelta {
+	if x, ok := m.GetBody().(*Message_Delta); ok {
+		return x.Delta
+	}
+	return nil
+}
+
+func (m *Message) GetSigma() *BodySigma {
+	if x, ok := m.GetBody().(*Message_Sigma); ok {
+		return x.Sigma
+	}
+	return nil
+}
+
+func (m *Message) GetReveal() *BodyReveal {
+	if x, ok := m.GetBody().(*Message_Reveal); ok {
+		return x.Reveal
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Message) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Message_EncK)(nil),
+		(*Message_Mta)(nil),
+		(*Message_Delta)(nil),
+		(*Message_Sigma)(nil),
+		(*Message_Reveal)(nil),
+	}
+}
+
+type BodyEncK struct {
+	// encK and encGamma are K_i = Enc(k_i) and G_i = Enc(gamma_i) under the Paillier key of the sender
+	EncK     []byte `protobuf:"bytes,1,opt,name=encK,proto3" json:"encK,omitempty"`
+	EncGamma []byte `protobuf:"bytes,2,opt,name=encGamma,proto3" json:"encGamma,omitempty"`
+	// proofs map the ids of the peers to the proofs under their ring-Pedersen parameters. The message is the same for
+	// all the peers, so they agree on K_i.
+	Proofs               map[string]*EncKProof `protobuf:"bytes,3,rep,name=proofs,proto3" json:"proofs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *BodyEncK) Reset()         { *m = BodyEncK{} }
+func (m *BodyEncK) String() string { return proto.CompactTextString(m) }
+func (*BodyEncK) ProtoMessage()    {}
+func (*BodyEncK) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b68ab99c594abd27, []int{1}
+}
+
+func (m *BodyEncK) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyEncK.Unmarshal(m, b)
+}
+func (m *BodyEncK) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyEncK.Marshal(b, m, deterministic)
+}
+func (m *BodyEncK) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyEncK.Merge(m, src)
+}
+func (m *BodyEncK) XXX_Size() int {
+	return xxx_messageInfo_BodyEncK.Size(m)
+}
+func (m *BodyEncK) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyEncK.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyEncK proto.InternalMessageInfo
+
+func (m *BodyEncK) GetEncK() []byte {
+	if m != nil {
+		return m.EncK
+	}
+	return nil
+}
+
+func (m *BodyEncK) GetEncGamma() []byte {
+	if m != nil {
+		return m.EncGamma
+	}
+	return nil
+}
+
+func (m *BodyEncK) GetProofs() map[string]*EncKProof {
+	if m != nil {
+		return m.Proofs
+	}
+	return nil
+}
+
+type EncKProof struct {
+	// encProof is the range proof of encK under the ring-Pedersen parameter of the receiver
+	EncProof *paillier.RangeProofMessage `protobuf:"bytes,1,opt,name=encProof,proto3" json:"encProof,omitempty"`
+	// noSmallFactorProof is the proof that the Paillier modulus of the sender has no small factor
+	NoSmallFactorProof   *zkproof.NoSmallFactorMessage `protobuf:"bytes,2,opt,name=noSmallFactorProof,proto3" json:"noSmallFactorProof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *EncKProof) Reset()         { *m = EncKProof{} }
+func (m *EncKProof) String() string { return proto.CompactTextString(m) }
+func (*EncKProof) ProtoMessage()    {}
+func (*EncKProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b68ab99c594abd27, []int{2}
+}
+
+func (m *EncKProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncKProof.Unmarshal(m, b)
+}
+func (m *EncKProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncKProof.Marshal(b, m, deterministic)
+}
+func (m *EncKProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncKProof.Merge(m, src)
+}
+func (m *EncKProof) XXX_Size() int {
+	return xxx_messageInfo_EncKProof.Size(m)
+}
+func (m *EncKProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncKProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncKProof proto.InternalMessageInfo
+
+func (m *EncKProof) GetEncProof() *paillier.RangeProofMessage {
+	if m != nil {
+		return m.EncProof
+	}
+	return nil
+}
+
+func (m *EncKProof) GetNoSmallFactorProof() *zkproof.NoSmallFactorMessage {
+	if m != nil {
+		return m.NoSmallFactorProof
+	}
+	return nil
+}
+
+type BodyMta struct {
+	// gamma is Gamma_i = gamma_i*G
+	Gamma *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,1,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	// mtas map the ids of the peers to the MtAs with them. The message is the same for all the peers, so the
+	// ciphertexts of all the MtAs are known to everyone for identifying the culprits.
+	Mtas                 map[string]*BodyMtaEntry `protobuf:"bytes,2,rep,name=mtas,proto3" json:"mtas,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *BodyMta) Reset()         { *m = BodyMta{} }
+func (m *BodyMta) String() string { return proto.CompactTextString(m) }
+func (*BodyMta) ProtoMessage()    {}
+func (*BodyMta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b68ab99c594abd27, []int{3}
+}
+
+func (m *BodyMta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyMta.Unmarshal(m, b)
+}
+func (m *BodyMta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyMta.Marshal(b, m, deterministic)
+}
+func (m *BodyMta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyMta.Merge(m, src)
+}
+func (m *BodyMta) XXX_Size() int {
+	return xxx_messageInfo_BodyMta.Size(m)
+}
+func (m *BodyMta) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyMta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyMta proto.InternalMessageInfo
+
+func (m *BodyMta) GetGamma() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.Gamma
+	}
+	return nil
+}
+
+func (m *BodyMta) GetMtas() map[string]*BodyMtaEntry {
+	if m != nil {
+		return m.Mtas
+	}
+	return nil
+}
+
+type BodyMtaEntry struct {
+	// gammaProof is the range proof with check that the plaintext of encGamma is the discrete log of gamma
+	GammaProof *paillier.RangeProofMessage `protobuf:"bytes,1,opt,name=gammaProof,proto3" json:"gammaProof,omitempty"`
+	// d and dHat are the MtA ciphertexts of gamma_i*k_j and w_i*k_j under the Paillier key of the receiver
+	D                    []byte                           `protobuf:"bytes,2,opt,name=d,proto3" json:"d,omitempty"`
+	DProof               *paillier.RespondentProofMessage `protobuf:"bytes,3,opt,name=dProof,proto3" json:"dProof,omitempty"`
+	DHat                 []byte                           `protobuf:"bytes,4,opt,name=dHat,proto3" json:"dHat,omitempty"`
+	DHatProof            *paillier.RespondentProofMessage `protobuf:"bytes,5,opt,name=dHatProof,proto3" json:"dHatProof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *BodyMtaEntry) Reset()         { *m = BodyMtaEntry{} }
+func (m *BodyMtaEntry) String() string { return proto.CompactTextString(m) }
+func (*BodyMtaEntry) ProtoMessage()    {}
+func (*BodyMtaEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b68ab99c594abd27, []int{4}
+}
+
+func (m *BodyMtaEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyMtaEntry.Unmarshal(m, b)
+}
+func (m *BodyMtaEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyMtaEntry.Marshal(b, m, deterministic)
+}
+func (m *BodyMtaEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyMtaEntry.Merge(m, src)
+}
+func (m *BodyMtaEntry) XXX_Size() int {
+	return xxx_messageInfo_BodyMtaEntry.Size(m)
+}
+func (m *BodyMtaEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyMtaEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyMtaEntry proto.InternalMessageInfo
+
+func (m *BodyMtaEntry) GetGammaProof() *paillier.RangeProofMessage {
+	if m != nil {
+		return m.GammaProof
+	}
+	return nil
+}
+
+func (m *BodyMtaEntry) GetD() []byte {
+	if m != nil {
+		return m.D
+	}
+	return nil
+}
+
+func (m *BodyMtaEntry) GetDProof() *paillier.RespondentProofMessage {
+	if m != nil {
+		return m.DProof
+	}
+	return nil
+}
+
+func (m *BodyMtaEntry) GetDHat() []byte {
+	if m != nil {
+		return m.DHat
+	}
+	return nil
+}
+
+func (m *BodyMtaEntry) GetDHatProof() *paillier.RespondentProofMessage {
+	if m != nil {
+		return m.DHatProof
+	}
+	return nil
+}
+
+type BodyDelta struct {
+	Delta []byte `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	// bigDelta is Delta_i = k_i*Gamma
+	BigDelta *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,2,opt,name=bigDelta,proto3" json:"bigDelta,omitempty"`
+	// bigDeltaProofs map the ids of the peers to the range proofs with check that the plaintext of encK is the
+	// discrete log of bigDelta to Gamma under their ring-Pedersen parameters. The message is the same for all the
+	// peers, so they agree on whether the presigning fails.
+	BigDeltaProofs map[string]*paillier.RangeProofMessage `protobuf:"bytes,3,rep,name=bigDeltaProofs,proto3" json:"bigDeltaProofs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// chiGamma is chi_i*Gamma, so chi_i*R = delta^-1*chiGamma
+	ChiGamma             *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,4,opt,name=chiGamma,proto3" json:"chiGamma,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *BodyDelta) Reset()         { *m = BodyDelta{} }
+func (m *BodyDelta) String() string { return proto.CompactTextString(m) }
+func (*BodyDelta) ProtoMessage()    {}
+func (*BodyDelta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b68ab99c594abd27, []int{5}
+}
+
+func (m *BodyDelta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyDelta.Unmarshal(m, b)
+}
+func (m *BodyDelta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyDelta.Marshal(b, m, deterministic)
+}
+func (m *BodyDelta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyDelta.Merge(m, src)
+}
+func (m *BodyDelta) XXX_Size() int {
+	return xxx_messageInfo_BodyDelta.Size(m)
+}
+func (m *BodyDelta) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyDelta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyDelta proto.InternalMessageInfo
+
+func (m *BodyDelta) GetDelta() []byte {
+	if m != nil {
+		return m.Delta
+	}
+	return nil
+}
+
+func (m *BodyDelta) GetBigDelta() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.BigDelta
+	}
+	return nil
+}
+
+func (m *BodyDelta) GetBigDeltaProofs() map[string]*paillier.RangeProofMessage {
+	if m != nil {
+		return m.BigDeltaProofs
+	}
+	return nil
+}
+
+func (m *BodyDelta) GetChiGamma() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.ChiGamma
+	}
+	return nil
+}
+
+type BodySigma struct {
+	Sigma                []byte   `protobuf:"bytes,1,opt,name=sigma,proto3" json:"sigma,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BodySigma) Reset()         { *m = BodySigma{} }
+func (m *BodySigma) String() string { return proto.CompactTextString(m) }
+func (*BodySigma) ProtoMessage()    {}
+func (*BodySigma) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b68ab99c594abd27, []int{6}
+}
+
+func (m *BodySigma) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodySigma.Unmarshal(m, b)
+}
+func (m *BodySigma) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodySigma.Marshal(b, m, deterministic)
+}
+func (m *BodySigma) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodySigma.Merge(m, src)
+}
+func (m *BodySigma) XXX_Size() int {
+	return xxx_messageInfo_BodySigma.Size(m)
+}
+func (m *BodySigma) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodySigma.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodySigma proto.InternalMessageInfo
+
+func (m *BodySigma) GetSigma() []byte {
+	if m != nil {
+		return m.Sigma
+	}
+	return nil
+}
+
+// BodyReveal is sent only if delta*G is not the sum of Delta_j or the sum of chi_j*Gamma is not delta*Q. The
+// presignature is dropped, so the ephemeral secrets could be revealed to find the peers sending invalid delta_j or
+// chi_j*Gamma.
+type BodyReveal struct {
+	// k and gamma are k_i and gamma_i, and kNonce is the randomness of K_i
+	K      []byte `protobuf:"bytes,1,opt,name=k,proto3" json:"k,omitempty"`
+	KNonce []byte `protobuf:"bytes,2,opt,name=kNonce,proto3" json:"kNonce,omitempty"`
+	Gamma  []byte `protobuf:"bytes,3,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	// openings map the ids of the peers to the plaintexts and the randomness of the MtA ciphertexts from them
+	Openings             map[string]*MtaOpening `protobuf:"bytes,4,rep,name=openings,proto3" json:"openings,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *BodyReveal) Reset()         { *m = BodyReveal{} }
+func (m *BodyReveal) String() string { return proto.CompactTextString(m) }
+func (*BodyReveal) ProtoMessage()    {}
+func (*BodyReveal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b68ab99c594abd27, []int{7}
+}
+
+func (m *BodyReveal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyReveal.Unmarshal(m, b)
+}
+func (m *BodyReveal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyReveal.Marshal(b, m, deterministic)
+}
+func (m *BodyReveal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyReveal.Merge(m, src)
+}
+func (m *BodyReveal) XXX_Size() int {
+	return xxx_messageInfo_BodyReveal.Size(m)
+}
+func (m *BodyReveal) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyReveal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyReveal proto.InternalMessageInfo
+
+func (m *BodyReveal) GetK() []byte {
+	if m != nil {
+		return m.K
+	}
+	return nil
+}
+
+func (m *BodyReveal) GetKNonce() []byte {
+	if m != nil {
+		return m.KNonce
+	}
+	return nil
+}
+
+func (m *BodyReveal) GetGamma() []byte {
+	if m != nil {
+		return m.Gamma
+	}
+	return nil
+}
+
+func (m *BodyReveal) GetOpenings() map[string]*MtaOpening {
+	if m != nil {
+		return m.Openings
+	}
+	return nil
+}
+
+type MtaOpening struct {
+	Alpha                []byte   `protobuf:"bytes,1,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	AlphaNonce           []byte   `protobuf:"bytes,2,opt,name=alphaNonce,proto3" json:"alphaNonce,omitempty"`
+	AlphaHat             []byte   `protobuf:"bytes,3,opt,name=alphaHat,proto3" json:"alphaHat,omitempty"`
+	AlphaHatNonce        []byte   `protobuf:"bytes,4,opt,name=alphaHatNonce,proto3" json:"alphaHatNonce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MtaOpening) Reset()         { *m = MtaOpening{} }
+func (m *MtaOpening) String() string { return proto.CompactTextString(m) }
+func (*MtaOpening) ProtoMessage()    {}
+func (*MtaOpening) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b68ab99c594abd27, []int{8}
+}
+
+func (m *MtaOpening) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MtaOpening.Unmarshal(m, b)
+}
+func (m *MtaOpening) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MtaOpening.Marshal(b, m, deterministic)
+}
+func (m *MtaOpening) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MtaOpening.Merge(m, src)
+}
+func (m *MtaOpening) XXX_Size() int {
+	return xxx_messageInfo_MtaOpening.Size(m)
+}
+func (m *MtaOpening) XXX_DiscardUnknown() {
+	xxx_messageInfo_MtaOpening.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MtaOpening proto.InternalMessageInfo
+
+func (m *MtaOpening) GetAlpha() []byte {
+	if m != nil {
+		return m.Alpha
+	}
+	return nil
+}
+
+func (m *MtaOpening) GetAlphaNonce() []byte {
+	if m != nil {
+		return m.AlphaNonce
+	}
+	return nil
+}
+
+func (m *MtaOpening) GetAlphaHat() []byte {
+	if m != nil {
+		return m.AlphaHat
+	}
+	return nil
+}
+
+func (m *MtaOpening) GetAlphaHatNonce() []byte {
+	if m != nil {
+		return m.AlphaHatNonce
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("cggmp.Type", Type_name, Type_value)
+	proto.RegisterType((*Message)(nil), "cggmp.Message")
+	proto.RegisterType((*BodyEncK)(nil), "cggmp.BodyEncK")
+	proto.RegisterMapType((map[string]*EncKProof)(nil), "cggmp.BodyEncK.ProofsEntry")
+	proto.RegisterType((*EncKProof)(nil), "cggmp.EncKProof")
+	proto.RegisterType((*BodyMta)(nil), "cggmp.BodyMta")
+	proto.RegisterMapType((map[string]*BodyMtaEntry)(nil), "cggmp.BodyMta.MtasEntry")
+	proto.RegisterType((*BodyMtaEntry)(nil), "cggmp.BodyMtaEntry")
+	proto.RegisterType((*BodyDelta)(nil), "cggmp.BodyDelta")
+	proto.RegisterMapType((map[string]*paillier.RangeProofMessage)(nil), "cggmp.BodyDelta.BigDeltaProofsEntry")
+	proto.RegisterType((*BodySigma)(nil), "cggmp.BodySigma")
+	proto.RegisterType((*BodyReveal)(nil), "cggmp.BodyReveal")
+	proto.RegisterMapType((map[string]*MtaOpening)(nil), "cggmp.BodyReveal.OpeningsEntry")
+	proto.RegisterType((*MtaOpening)(nil), "cggmp.MtaOpening")
+}
+
+func init() {
+	proto.RegisterFile("github.com/getamis/alice/crypto/tss/cggmp/message.proto", fileDescriptor_b68ab99c594abd27)
+}
+
+var fileDescriptor_b68ab99c594abd27 = []byte{
+	// 859 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xef, 0x6e, 0xe3, 0x44,
+	0x10, 0x8f, 0x1d, 0x27, 0x8d, 0xa7, 0xb9, 0x12, 0xe6, 0x10, 0xb2, 0xc2, 0x9f, 0x86, 0xe8, 0x80,
+	0xf0, 0x47, 0x8e, 0xe8, 0xe9, 0x74, 0x27, 0x2a, 0xf1, 0xa1, 0xa2, 0x10, 0xd4, 0x4b, 0xa9, 0x7c,
+	0x7c, 0x46, 0xda, 0xda, 0x8b, 0x6b, 0xc5, 0xf6, 0x5a, 0xf6, 0xf6, 0x50, 0xf8, 0xcc, 0x1b, 0xf0,
+	0x1a, 0x3c, 0x02, 0x2f, 0xc0, 0x03, 0xf0, 0x0c, 0xbc, 0x06, 0xda, 0xd9, 0xb5, 0x6b, 0xf7, 0x22,
+	0x9a, 0x4f, 0xd9, 0x99, 0xf9, 0xcd, 0xcf, 0x3b, 0xbf, 0x9d, 0x99, 0xc0, 0xf3, 0x38, 0x91, 0x37,
+	0xb7, 0xd7, 0x7e, 0x28, 0xb2, 0x65, 0xcc, 0x25, 0xcb, 0x92, 0x6a, 0xc9, 0xd2, 0x24, 0xe4, 0xcb,
+	0xb0, 0xdc, 0x16, 0x52, 0x2c, 0x65, 0x55, 0x2d, 0xc3, 0x38, 0xce, 0x8a, 0x65, 0xc6, 0xab, 0x8a,
+	0xc5, 0xdc, 0x2f, 0x4a, 0x21, 0x05, 0x0e, 0xc8, 0x39, 0x3d, 0x7d, 0x28, 0x9f, 0x87, 0x85, 0x48,
+	0x72, 0x19, 0x97, 0xe2, 0xb6, 0x48, 0xd9, 0xaf, 0x4b, 0xb2, 0x34, 0xc7, 0xc3, 0xc9, 0x37, 0x22,
+	0x13, 0xcb, 0x82, 0x25, 0x69, 0x9a, 0xf0, 0xb2, 0x7b, 0x81, 0xe9, 0xb3, 0x87, 0x92, 0x7f, 0xdb,
+	0x14, 0xa5, 0x10, 0xbf, 0x74, 0xd3, 0xe6, 0x7f, 0xda, 0x70, 0xb0, 0xd6, 0x1e, 0x3c, 0x06, 0x47,
+	0x6e, 0x0b, 0xee, 0x59, 0x33, 0x6b, 0x71, 0x74, 0x72, 0xe8, 0x53, 0x49, 0xfe, 0x4f, 0xdb, 0x82,
+	0x07, 0x14, 0xc0, 0x23, 0xb0, 0x93, 0xc8, 0xb3, 0x67, 0xd6, 0xc2, 0x0d, 0xec, 0x24, 0xc2, 0xf7,
+	0xc1, 0xad, 0x78, 0x55, 0x25, 0x22, 0xff, 0x21, 0xf2, 0xfa, 0x33, 0x6b, 0x31, 0x0e, 0xee, 0x1c,
+	0xf8, 0x31, 0x38, 0x3c, 0x0f, 0x2f, 0x3c, 0x67, 0x66, 0x2d, 0x0e, 0x4f, 0xde, 0x32, 0x74, 0x67,
+	0x22, 0xda, 0x9e, 0xe7, 0xe1, 0xc5, 0xaa, 0x17, 0x50, 0x18, 0xe7, 0xd0, 0xcf, 0x24, 0xf3, 0x06,
+	0x84, 0x3a, 0x6a, 0xa1, 0xd6, 0x92, 0xad, 0x7a, 0x81, 0x0a, 0xe2, 0x02, 0x06, 0x11, 0x4f, 0x25,
+	0xf3, 0x86, 0x84, 0x9a, 0xb4, 0x50, 0xdf, 0x2a, 0xff, 0xaa, 0x17, 0x68, 0x80, 0x42, 0x56, 0x49,
+	0x9c, 0x31, 0xef, 0xe0, 0x0d, 0xe4, 0x2b, 0xe5, 0x57, 0x48, 0x02, 0xe0, 0x17, 0x30, 0x2c, 0xf9,
+	0x6b, 0xce, 0x52, 0x6f, 0x44, 0xd0, 0xb7, 0x5b, 0xd0, 0x80, 0x02, 0xab, 0x5e, 0x60, 0x20, 0x67,
+	0x43, 0x70, 0xae, 0x45, 0xb4, 0x9d, 0xff, 0x65, 0xc1, 0xa8, 0xae, 0x00, 0xd1, 0x14, 0x68, 0x51,
+	0xe5, 0xba, 0x9a, 0x29, 0x8c, 0x78, 0x1e, 0x7e, 0xcf, 0xb2, 0x8c, 0x91, 0x50, 0xe3, 0xa0, 0xb1,
+	0xf1, 0x29, 0x0c, 0xe9, 0x09, 0x2a, 0xaf, 0x3f, 0xeb, 0x2f, 0x0e, 0x4f, 0xde, 0xbb, 0x27, 0x89,
+	0x7f, 0x45, 0xd1, 0xf3, 0x5c, 0x96, 0xdb, 0xc0, 0x40, 0xa7, 0x17, 0x70, 0xd8, 0x72, 0xe3, 0x04,
+	0xfa, 0x1b, 0xbe, 0xa5, 0x4f, 0xba, 0x81, 0x3a, 0xe2, 0x27, 0x30, 0x78, 0xcd, 0xd2, 0x5b, 0xee,
+	0xd9, 0x9d, 0x8a, 0x15, 0x21, 0x25, 0x06, 0x3a, 0xfc, 0xb5, 0xfd, 0xc2, 0x9a, 0xff, 0x61, 0x81,
+	0xdb, 0x04, 0xf0, 0x39, 0xdd, 0x95, 0xce, 0x44, 0xa8, 0x6e, 0x54, 0x77, 0x97, 0x1f, 0xb0, 0x3c,
+	0xe6, 0x14, 0x33, 0xed, 0x11, 0x34, 0x60, 0x5c, 0x03, 0xe6, 0xe2, 0x55, 0xc6, 0xd2, 0xf4, 0x3b,
+	0x16, 0x4a, 0x51, 0x6a, 0x0a, 0xfd, 0xfd, 0x0f, 0x7c, 0xd3, 0x68, 0xfe, 0x65, 0x1b, 0x52, 0x93,
+	0xec, 0x48, 0x54, 0xa2, 0x1e, 0x98, 0x07, 0xc7, 0x67, 0x30, 0x88, 0x49, 0x3c, 0x7d, 0xa1, 0x63,
+	0xff, 0xde, 0xc0, 0xf8, 0xe7, 0xe1, 0x95, 0xb2, 0x6b, 0x3e, 0x8d, 0xc6, 0x2f, 0xc1, 0xc9, 0x24,
+	0xab, 0x3c, 0x9b, 0x84, 0xf5, 0xba, 0x5d, 0xe4, 0xaf, 0x25, 0x33, 0xaa, 0x12, 0x6a, 0xfa, 0x12,
+	0xdc, 0xc6, 0xb5, 0x43, 0xd1, 0xcf, 0xba, 0x8a, 0x3e, 0xee, 0xb2, 0x69, 0xa2, 0x96, 0xa8, 0xff,
+	0x5a, 0x30, 0x6e, 0xc7, 0xf0, 0x14, 0x80, 0x6e, 0xb5, 0xb7, 0xb2, 0x2d, 0x38, 0x8e, 0xc1, 0x8a,
+	0x4c, 0xe7, 0x58, 0x11, 0xbe, 0x80, 0x61, 0xa4, 0x69, 0xfa, 0x44, 0x33, 0x6b, 0xd1, 0xf0, 0xaa,
+	0x10, 0x79, 0xc4, 0x73, 0xd9, 0xe1, 0x32, 0x78, 0xd5, 0x9c, 0xd1, 0x8a, 0x49, 0x9a, 0xbe, 0x71,
+	0x40, 0x67, 0xfc, 0x06, 0x5c, 0xf5, 0xab, 0x09, 0x07, 0x7b, 0x12, 0xde, 0xa5, 0xcc, 0xff, 0xb6,
+	0xc1, 0x6d, 0x66, 0x0e, 0xdf, 0xa9, 0x87, 0x52, 0xf7, 0xbf, 0x36, 0xf0, 0x14, 0x46, 0xd7, 0x49,
+	0x4c, 0x08, 0xcf, 0xde, 0xef, 0x0d, 0x9b, 0x04, 0x7c, 0x09, 0x47, 0xf5, 0xf9, 0xaa, 0x3d, 0x29,
+	0x4f, 0xee, 0x0f, 0xbc, 0x7f, 0xd6, 0x81, 0xe9, 0x37, 0xb9, 0x97, 0xab, 0xae, 0x12, 0xde, 0x24,
+	0x7a, 0x16, 0x9d, 0x3d, 0xaf, 0x52, 0x27, 0x4c, 0x7f, 0x86, 0xc7, 0x3b, 0xbe, 0xb1, 0xa3, 0x5b,
+	0xbe, 0xea, 0x76, 0xcb, 0xff, 0x3e, 0x74, 0xab, 0x6b, 0x3e, 0x02, 0xb7, 0x59, 0x4a, 0x4a, 0x4a,
+	0xbd, 0xb5, 0x8c, 0x94, 0x64, 0xcc, 0xff, 0xb1, 0x00, 0xee, 0xb6, 0x91, 0xea, 0x8c, 0x8d, 0x01,
+	0x58, 0x1b, 0x7c, 0x17, 0x86, 0x9b, 0x4b, 0x91, 0x87, 0xdc, 0x34, 0x8b, 0xb1, 0x14, 0x95, 0x1e,
+	0x20, 0xbd, 0x8f, 0xb5, 0xa1, 0xa4, 0x10, 0x05, 0xcf, 0x93, 0x3c, 0xae, 0x3c, 0x87, 0x24, 0x3d,
+	0x7e, 0x63, 0xdd, 0xf9, 0x3f, 0x1a, 0x84, 0x56, 0xb3, 0x49, 0x98, 0x5e, 0xc2, 0xa3, 0x4e, 0x68,
+	0x87, 0x08, 0x9f, 0x76, 0x45, 0xa8, 0x77, 0xe9, 0x5a, 0x32, 0x93, 0xd9, 0x2e, 0xfd, 0x77, 0x0b,
+	0xe0, 0x2e, 0xa2, 0x6e, 0xcc, 0xd2, 0xe2, 0xa6, 0x29, 0x9e, 0x0c, 0xfc, 0x10, 0x80, 0x0e, 0xed,
+	0x1a, 0x5b, 0x1e, 0xb5, 0x68, 0xc9, 0x52, 0x3d, 0xae, 0x4b, 0x6d, 0x6c, 0x7c, 0x02, 0x8f, 0xea,
+	0xb3, 0x4e, 0xd7, 0x43, 0xd0, 0x75, 0x7e, 0x7e, 0x0a, 0x8e, 0xfa, 0x6f, 0xc3, 0x11, 0x38, 0x6a,
+	0x27, 0x4e, 0x7a, 0x78, 0x00, 0xfd, 0xb5, 0x64, 0x13, 0x0b, 0x5d, 0x18, 0xd0, 0xcb, 0x4f, 0x6c,
+	0x75, 0xa4, 0x37, 0x9a, 0xf4, 0x11, 0x60, 0xa8, 0x95, 0x9a, 0x38, 0xd7, 0x43, 0xfa, 0xfb, 0x7c,
+	0xfa, 0xdf, 0x00, 0xeb, 0x6d, 0xcc, 0x57, 0x31, 0x08, 0x00, 0x00,
+}
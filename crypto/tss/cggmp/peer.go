@@ -0,0 +1,48 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cggmp
+
+import (
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/homo/paillier"
+	"github.com/getamis/alice/crypto/tss"
+)
+
+type peer struct {
+	*tss.Peer
+	// pubkey and pedersen are the verified Paillier public key and ring-Pedersen parameter of the peer
+	pubkey   homo.Pubkey
+	pedersen *paillier.PedersenOpenParameter
+	// bigW is W_j = c_j*X_j, where c_j is the Birkhoff coefficient and X_j is the public share of the peer
+	bigW *pt.ECPoint
+
+	enck   *encKData
+	mta    *mtaData
+	delta  *deltaData
+	reveal *revealData
+	// presign is k_j*R and chi_j*R of the peer in the presignature
+	presign *presignData
+	sigma   *sigmaData
+}
+
+func newPeer(id string, pubkey homo.Pubkey, pedersen *paillier.PedersenOpenParameter, bigW *pt.ECPoint) *peer {
+	return &peer{
+		Peer:     tss.NewPeer(id),
+		pubkey:   pubkey,
+		pedersen: pedersen,
+		bigW:     bigW,
+	}
+}
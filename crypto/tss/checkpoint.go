@@ -0,0 +1,125 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tss
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+
+	"github.com/getamis/alice/crypto/commitment"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/sirius/log"
+	"github.com/golang/protobuf/proto"
+)
+
+// CheckpointKeySize is the size of the key to encrypt checkpoints (AES-256-GCM)
+const CheckpointKeySize = 32
+
+var (
+	// ErrInvalidCheckpointKey is returned if the checkpoint key is not CheckpointKeySize bytes
+	ErrInvalidCheckpointKey = errors.New("invalid checkpoint key")
+	// ErrInvalidCheckpoint is returned if the checkpoint cannot be decrypted or restored
+	ErrInvalidCheckpoint = errors.New("invalid checkpoint")
+)
+
+// EncryptCheckpoint marshals the state and encrypts it with the key. The label binds the checkpoint
+// to a protocol, so that a checkpoint of one protocol cannot be restored by another one.
+// A fresh nonce is used for every checkpoint.
+func EncryptCheckpoint(key []byte, label string, state proto.Message) ([]byte, error) {
+	aead, err := newCheckpointAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := proto.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := utils.GenRandomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, bs, []byte(label)), nil
+}
+
+// DecryptCheckpoint decrypts the checkpoint with the key and unmarshals it to the state.
+func DecryptCheckpoint(key []byte, label string, checkpoint []byte, state proto.Message) error {
+	aead, err := newCheckpointAEAD(key)
+	if err != nil {
+		return err
+	}
+	nonceSize := aead.NonceSize()
+	if len(checkpoint) < nonceSize {
+		return ErrInvalidCheckpoint
+	}
+	bs, err := aead.Open(nil, checkpoint[:nonceSize], checkpoint[nonceSize:], []byte(label))
+	if err != nil {
+		return ErrInvalidCheckpoint
+	}
+	return proto.Unmarshal(bs, state)
+}
+
+func newCheckpointAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != CheckpointKeySize {
+		return nil, ErrInvalidCheckpointKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// NewCommitterByDecommitment rebuilds the committer made by NewCommitterByPoint and returns the committed point.
+func NewCommitterByDecommitment(logger log.Logger, sessionID []byte, decommit *commitment.HashDecommitmentMessage) (*commitment.HashCommitmenter, *pt.ECPoint, error) {
+	c, err := commitment.NewHashCommitmenterByDecommitment(sessionID, decommit)
+	if err != nil {
+		logger.Warn("Failed to new committer", "err", err)
+		return nil, nil, err
+	}
+	p, err := GetPointFromHashCommitment(logger, sessionID, c.GetCommitmentMessage(), decommit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, p, nil
+}
+
+// SilentPeerManager drops all the messages. It's used to replay the messages which were handled before
+// the checkpoint was made, so that no message is sent twice.
+type SilentPeerManager struct {
+	types.PeerManager
+}
+
+// NewSilentPeerManager returns a silent peer manager which wraps the peer manager
+func NewSilentPeerManager(peerManager types.PeerManager) *SilentPeerManager {
+	return &SilentPeerManager{
+		PeerManager: peerManager,
+	}
+}
+
+// MustSend drops the message
+func (p *SilentPeerManager) MustSend(id string, msg proto.Message) {}
+
+// PeerIDs returns the ids of the peers given by the wrapped peer manager. It returns nil if the wrapped one
+// doesn't implement types.PeerIDsGetter.
+func (p *SilentPeerManager) PeerIDs() []string {
+	g, ok := p.PeerManager.(types.PeerIDsGetter)
+	if !ok {
+		return nil
+	}
+	return g.PeerIDs()
+}
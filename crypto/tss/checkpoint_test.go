@@ -0,0 +1,106 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tss
+
+import (
+	"bytes"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/commitment"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/sirius/log"
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoint", func() {
+	var (
+		key   = bytes.Repeat([]byte{1}, CheckpointKeySize)
+		state = &commitment.HashDecommitmentMessage{
+			Data: []byte("data"),
+			Salt: []byte("salt"),
+		}
+	)
+
+	It("should be ok", func() {
+		c1, err := EncryptCheckpoint(key, "label", state)
+		Expect(err).Should(BeNil())
+		c2, err := EncryptCheckpoint(key, "label", state)
+		Expect(err).Should(BeNil())
+		// Never reuse nonces
+		Expect(c1).ShouldNot(Equal(c2))
+
+		got := &commitment.HashDecommitmentMessage{}
+		Expect(DecryptCheckpoint(key, "label", c1, got)).Should(BeNil())
+		Expect(proto.Equal(got, state)).Should(BeTrue())
+	})
+
+	It("invalid key", func() {
+		c, err := EncryptCheckpoint(key[1:], "label", state)
+		Expect(err).Should(Equal(ErrInvalidCheckpointKey))
+		Expect(c).Should(BeNil())
+	})
+
+	It("wrong key", func() {
+		c, err := EncryptCheckpoint(key, "label", state)
+		Expect(err).Should(BeNil())
+		otherKey := bytes.Repeat([]byte{2}, CheckpointKeySize)
+		Expect(DecryptCheckpoint(otherKey, "label", c, &commitment.HashDecommitmentMessage{})).Should(Equal(ErrInvalidCheckpoint))
+	})
+
+	It("wrong label", func() {
+		c, err := EncryptCheckpoint(key, "label", state)
+		Expect(err).Should(BeNil())
+		Expect(DecryptCheckpoint(key, "other", c, &commitment.HashDecommitmentMessage{})).Should(Equal(ErrInvalidCheckpoint))
+	})
+
+	It("tampered checkpoint", func() {
+		c, err := EncryptCheckpoint(key, "label", state)
+		Expect(err).Should(BeNil())
+		c[len(c)-1] ^= 1
+		Expect(DecryptCheckpoint(key, "label", c, &commitment.HashDecommitmentMessage{})).Should(Equal(ErrInvalidCheckpoint))
+		Expect(DecryptCheckpoint(key, "label", c[:4], &commitment.HashDecommitmentMessage{})).Should(Equal(ErrInvalidCheckpoint))
+	})
+
+	It("NewCommitterByDecommitment", func() {
+		sessionID := []byte("session")
+		p := pt.NewBase(btcec.S256())
+		c, err := NewCommitterByPoint(sessionID, p)
+		Expect(err).Should(BeNil())
+
+		got, gotPoint, err := NewCommitterByDecommitment(log.Discard(), sessionID, c.GetDecommitmentMessage())
+		Expect(err).Should(BeNil())
+		Expect(got).Should(Equal(c))
+		Expect(gotPoint.Equal(p)).Should(BeTrue())
+	})
+
+	It("SilentPeerManager returns the peer ids of the wrapped peer manager", func() {
+		ids := []string{"id-1", "id-2"}
+		Expect(NewSilentPeerManager(&peerIDsManager{PeerManager: new(mocks.PeerManager), ids: ids}).PeerIDs()).Should(Equal(ids))
+		Expect(NewSilentPeerManager(new(mocks.PeerManager)).PeerIDs()).Should(BeNil())
+	})
+})
+
+type peerIDsManager struct {
+	types.PeerManager
+
+	ids []string
+}
+
+func (p *peerIDsManager) PeerIDs() []string {
+	return p.ids
+}
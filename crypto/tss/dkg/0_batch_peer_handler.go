@@ -0,0 +1,246 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/polynomial"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/sirius/log"
+	proto "github.com/golang/protobuf/proto"
+)
+
+type batchPeerData struct {
+	bk                   *birkhoffinterpolation.BkParameter
+	commitments          []*commitment.HashCommitmentMessage
+	chainCodeCommitments []*commitment.HashCommitmentMessage
+}
+
+// batchKey is the private polynomial and the commitments of one key in the batch
+type batchKey struct {
+	poly                *polynomial.Polynomial
+	u0g                 *ecpointgrouplaw.ECPoint
+	u0gCommiter         *commitment.HashCommitmenter
+	feldmanCommitmenter *commitment.FeldmanCommitmenter
+	chainCodeCommiter   *commitment.HashCommitmenter
+}
+
+type batchPeerHandler struct {
+	// self information
+	bk        *birkhoffinterpolation.BkParameter
+	keys      []*batchKey
+	threshold uint32
+	curve     elliptic.Curve
+	sessionID []byte
+
+	peerManager types.PeerManager
+	peerNum     uint32
+	peers       map[string]*batchPeer
+}
+
+func newBatchPeerHandler(curve elliptic.Curve, peerManager types.PeerManager, sessionID []byte, threshold uint32, rank uint32, batchSize uint32) (*batchPeerHandler, error) {
+	fieldOrder := curve.Params().N
+	keys := make([]*batchKey, batchSize)
+	for i := range keys {
+		poly, err := polynomial.RandomPolynomial(fieldOrder, threshold-1)
+		if err != nil {
+			return nil, err
+		}
+		keys[i], err = newBatchKey(curve, sessionID, poly)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Random x and build bk
+	x, err := utils.RandomPositiveInt(fieldOrder)
+	if err != nil {
+		return nil, err
+	}
+	return &batchPeerHandler{
+		bk:        birkhoffinterpolation.NewBkParameter(x, rank),
+		keys:      keys,
+		threshold: threshold,
+		curve:     curve,
+		sessionID: sessionID,
+
+		peerManager: peerManager,
+		peerNum:     peerManager.NumPeers(),
+		peers:       make(map[string]*batchPeer, peerManager.NumPeers()),
+	}, nil
+}
+
+func newBatchKey(curve elliptic.Curve, sessionID []byte, poly *polynomial.Polynomial) (*batchKey, error) {
+	feldmanCommitmenter, err := commitment.NewFeldmanCommitmenter(curve, poly)
+	if err != nil {
+		return nil, err
+	}
+	u0g := ecpointgrouplaw.ScalarBaseMult(curve, poly.Get(0))
+	u0gCommiter, err := tss.NewCommitterByPoint(sessionID, u0g)
+	if err != nil {
+		return nil, err
+	}
+	chainCode, err := utils.GenRandomBytes(ChainCodeSize)
+	if err != nil {
+		return nil, err
+	}
+	chainCodeCommiter, err := commitment.NewHashCommitmenterWithSession(sessionID, chainCode)
+	if err != nil {
+		return nil, err
+	}
+	return &batchKey{
+		poly:                poly,
+		u0g:                 u0g,
+		u0gCommiter:         u0gCommiter,
+		feldmanCommitmenter: feldmanCommitmenter,
+		chainCodeCommiter:   chainCodeCommiter,
+	}, nil
+}
+
+func (p *batchPeerHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_BatchPeer)
+}
+
+func (p *batchPeerHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *batchPeerHandler) IsHandled(logger log.Logger, id string) bool {
+	_, ok := p.peers[id]
+	return ok
+}
+
+func (p *batchPeerHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	body := msg.GetBatchPeer()
+	if err := p.ensureBatchSize(logger, len(body.GetCommitments()), len(body.GetChainCodeCommitments())); err != nil {
+		return blame(err, msg)
+	}
+	peer := newBatchPeer(id)
+	peer.peer = &batchPeerData{
+		bk:                   body.GetBk().ToBk(),
+		commitments:          body.GetCommitments(),
+		chainCodeCommitments: body.GetChainCodeCommitments(),
+	}
+	p.peers[id] = peer
+	return peer.AddMessage(msg)
+}
+
+func (p *batchPeerHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	// Check if the bks are ok
+	bks := make(birkhoffinterpolation.BkParameters, p.peerNum+1)
+	bks[0] = p.bk
+	i := 1
+	for _, peer := range p.peers {
+		bks[i] = peer.peer.bk
+		i++
+	}
+	err := bks.CheckValid(p.threshold, p.curve.Params().N)
+	if err != nil {
+		logger.Warn("Failed to check bks", "err", err)
+		return nil, err
+	}
+
+	// Send out Feldman commit messages and decommit messages of all the keys to all peers
+	p.broadcast(p.getDecommitMessage())
+	return newBatchDecommitHandler(p), nil
+}
+
+func (p *batchPeerHandler) GetPeerMessage() *Message {
+	commitments := make([]*commitment.HashCommitmentMessage, len(p.keys))
+	chainCodeCommitments := make([]*commitment.HashCommitmentMessage, len(p.keys))
+	for i, k := range p.keys {
+		commitments[i] = k.u0gCommiter.GetCommitmentMessage()
+		chainCodeCommitments[i] = k.chainCodeCommiter.GetCommitmentMessage()
+	}
+	return &Message{
+		Type:      Type_BatchPeer,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_BatchPeer{
+			BatchPeer: &BodyBatchPeer{
+				Bk:                   p.bk.ToMessage(),
+				Commitments:          commitments,
+				ChainCodeCommitments: chainCodeCommitments,
+			},
+		},
+	}
+}
+
+func (p *batchPeerHandler) getDecommitMessage() *Message {
+	hashDecommitments := make([]*commitment.HashDecommitmentMessage, len(p.keys))
+	pointCommitments := make([]*commitment.PointCommitmentMessage, len(p.keys))
+	for i, k := range p.keys {
+		hashDecommitments[i] = k.u0gCommiter.GetDecommitmentMessage()
+		pointCommitments[i] = k.feldmanCommitmenter.GetCommitmentMessage()
+	}
+	return &Message{
+		Type:      Type_BatchDecommit,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_BatchDecommit{
+			BatchDecommit: &BodyBatchDecommit{
+				HashDecommitments: hashDecommitments,
+				PointCommitments:  pointCommitments,
+			},
+		},
+	}
+}
+
+func (p *batchPeerHandler) getVerifyMessage(bk *birkhoffinterpolation.BkParameter) *Message {
+	verifies := make([]*commitment.FeldmanVerifyMessage, len(p.keys))
+	for i, k := range p.keys {
+		verifies[i] = k.feldmanCommitmenter.GetVerifyMessage(bk)
+	}
+	return &Message{
+		Type:      Type_BatchVerify,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_BatchVerify{
+			BatchVerify: &BodyBatchVerify{
+				Verifies: verifies,
+			},
+		},
+	}
+}
+
+// ensureBatchSize checks if the numbers of the elements in a batch message are the batch size
+func (p *batchPeerHandler) ensureBatchSize(logger log.Logger, sizes ...int) error {
+	for _, size := range sizes {
+		if size != len(p.keys) {
+			logger.Warn("Inconsistent batch size", "got", size, "expected", len(p.keys))
+			return ErrInconsistentBatchSize
+		}
+	}
+	return nil
+}
+
+// evaluate returns the evaluation of the i-th polynomial for the bk
+func (p *batchPeerHandler) evaluate(i int, bk *birkhoffinterpolation.BkParameter) *big.Int {
+	return p.keys[i].poly.Differentiate(bk.GetRank()).Evaluate(bk.GetX())
+}
+
+func (p *batchPeerHandler) broadcast(msg proto.Message) {
+	for id := range p.peers {
+		p.peerManager.MustSend(id, msg)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("batch peer handler, negative cases", func() {
+	var (
+		ph     *batchPeerHandler
+		peerId = "peer-id"
+	)
+
+	BeforeEach(func() {
+		ph = &batchPeerHandler{
+			peers: map[string]*batchPeer{},
+		}
+	})
+
+	Context("IsHandled", func() {
+		It("peer not found", func() {
+			Expect(ph.IsHandled(log.Discard(), peerId)).Should(BeFalse())
+		})
+
+		It("message is handled before", func() {
+			ph.peers[peerId] = &batchPeer{}
+			Expect(ph.IsHandled(log.Discard(), peerId)).Should(BeTrue())
+		})
+	})
+
+	It("inconsistent batch size", func() {
+		curve := btcec.S256()
+		ph, err := newBatchPeerHandler(curve, newBatchPeerManager(getID(0), 1), sessionID, 2, 0, 2)
+		Expect(err).Should(BeNil())
+		other, err := newBatchPeerHandler(curve, newBatchPeerManager(getID(1), 1), sessionID, 2, 0, 3)
+		Expect(err).Should(BeNil())
+		Expect(ph.HandleMessage(log.Discard(), other.GetPeerMessage())).Should(MatchError(ErrInconsistentBatchSize))
+	})
+
+	It("blames the sender of an invalid batch size", func() {
+		curve := btcec.S256()
+		ph, err := newBatchPeerHandler(curve, newBatchPeerManager(getID(0), 1), sessionID, 2, 0, 2)
+		Expect(err).Should(BeNil())
+		other, err := newBatchPeerHandler(curve, newBatchPeerManager(getID(1), 1), sessionID, 2, 0, 3)
+		Expect(err).Should(BeNil())
+		msg := other.GetPeerMessage()
+		err = ph.HandleMessage(log.Discard(), msg)
+		expectBlame(err, msg)
+	})
+
+	It("duplicate bks", func() {
+		ph, other := newBatchPeerHandlers(2)
+		ph.peers[other.peerManager.SelfID()].peer.bk = ph.bk
+		got, err := ph.Finalize(log.Discard())
+		Expect(got).Should(BeNil())
+		Expect(err).Should(Equal(birkhoffinterpolation.ErrInvalidBks))
+	})
+})
+
+// newBatchPeerHandlers returns the peer handlers of two peers which have handled the peer messages of each
+// other. All the messages sent by the handlers are dropped.
+func newBatchPeerHandlers(batchSize uint32) (*batchPeerHandler, *batchPeerHandler) {
+	curve := btcec.S256()
+	ph0, err := newBatchPeerHandler(curve, tss.NewSilentPeerManager(newBatchPeerManager(getID(0), 1)), sessionID, 2, 0, batchSize)
+	Expect(err).Should(BeNil())
+	ph1, err := newBatchPeerHandler(curve, tss.NewSilentPeerManager(newBatchPeerManager(getID(1), 1)), sessionID, 2, 0, batchSize)
+	Expect(err).Should(BeNil())
+	Expect(ph0.HandleMessage(log.Discard(), ph1.GetPeerMessage())).Should(BeNil())
+	Expect(ph1.HandleMessage(log.Discard(), ph0.GetPeerMessage())).Should(BeNil())
+	return ph0, ph1
+}
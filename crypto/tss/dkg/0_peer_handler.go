@@ -17,26 +17,33 @@ package dkg
 import (
 	"crypto/elliptic"
 	"errors"
-	fmt "fmt"
 	"math/big"
+	"sort"
 
 	"github.com/getamis/alice/crypto/birkhoffinterpolation"
 	"github.com/getamis/alice/crypto/commitment"
 	"github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/polynomial"
 	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/utils"
 	"github.com/getamis/sirius/log"
 	proto "github.com/golang/protobuf/proto"
 )
 
+const (
+	// hiddingPointSeed is the seed to derive the hidding point of Pedersen commitments in the Pedersen mode
+	hiddingPointSeed = "github.com/getamis/alice/crypto/tss/dkg"
+)
+
 var (
 	ErrNotEnoughRanks = errors.New("not enough ranks")
 )
 
 type peerData struct {
-	bk *birkhoffinterpolation.BkParameter
+	bk                  *birkhoffinterpolation.BkParameter
+	chainCodeCommitment *commitment.HashCommitmentMessage
 }
 
 type peerHandler struct {
@@ -48,32 +55,43 @@ type peerHandler struct {
 	u0g                 *ecpointgrouplaw.ECPoint
 	u0gCommiter         *commitment.HashCommitmenter
 	feldmanCommitmenter *commitment.FeldmanCommitmenter
+	sessionID           []byte
+	// chainCodeCommiter commits the random contribution to the chain code
+	chainCodeCommiter *commitment.HashCommitmenter
+
+	// Only used in the Pedersen mode
+	salts                *polynomial.Polynomial
+	hiddingPoint         *ecpointgrouplaw.ECPoint
+	pedersenCommitmenter *commitment.PedersenCommitmenter
 
 	peerManager types.PeerManager
 	peerNum     uint32
 	peers       map[string]*peer
+	// disqualified peers are removed from peers after the reveal round
+	disqualified map[string]bool
+	// extracted peers sent the Feldman commitments inconsistent with the shares in the Pedersen mode. They are
+	// removed from peers after the Feldman complaint round, but their secrets are reconstructed by the others and
+	// still contribute to the public key.
+	extracted map[string]*peer
 }
 
-func newPeerHandler(curve elliptic.Curve, peerManager types.PeerManager, threshold uint32, rank uint32) (*peerHandler, error) {
+func newPeerHandler(curve elliptic.Curve, peerManager types.PeerManager, sessionID []byte, threshold uint32, rank uint32) (*peerHandler, error) {
 	params := curve.Params()
 	fieldOrder := params.N
-	fmt.Printf("fieldOrder: %d\n", params.N)
 	poly, err := polynomial.RandomPolynomial(fieldOrder, threshold-1)
 	if err != nil {
 		return nil, err
 	}
 
-	// u0 for private key reconstruction test( shoud delete )
-	fmt.Printf("u0: %s \n", poly.Get(0).Text(10))
 	// Random x and build bk
 	x, err := utils.RandomPositiveInt(fieldOrder)
 	if err != nil {
 		return nil, err
 	}
-	return newPeerHandlerWithPolynomial(curve, peerManager, threshold, x, rank, poly)
+	return newPeerHandlerWithPolynomial(curve, peerManager, sessionID, threshold, x, rank, poly)
 }
 
-func newPeerHandlerWithPolynomial(curve elliptic.Curve, peerManager types.PeerManager, threshold uint32, x *big.Int, rank uint32, poly *polynomial.Polynomial) (*peerHandler, error) {
+func newPeerHandlerWithPolynomial(curve elliptic.Curve, peerManager types.PeerManager, sessionID []byte, threshold uint32, x *big.Int, rank uint32, poly *polynomial.Polynomial) (*peerHandler, error) {
 	if err := utils.EnsureThreshold(threshold, peerManager.NumPeers()+1); err != nil {
 		return nil, err
 	}
@@ -90,8 +108,17 @@ func newPeerHandlerWithPolynomial(curve elliptic.Curve, peerManager types.PeerMa
 	// Calculate u0g
 	u0 := poly.Get(0)
 	u0g := ecpointgrouplaw.ScalarBaseMult(curve, u0)
-	fmt.Printf("u0g: %d,  %d\n", u0g.GetX(), u0g.GetY())
-	u0gCommiter, err := tss.NewCommitterByPoint(u0g)
+	u0gCommiter, err := tss.NewCommitterByPoint(sessionID, u0g)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit the contribution to the chain code
+	chainCode, err := utils.GenRandomBytes(ChainCodeSize)
+	if err != nil {
+		return nil, err
+	}
+	chainCodeCommiter, err := commitment.NewHashCommitmenterWithSession(sessionID, chainCode)
 	if err != nil {
 		return nil, err
 	}
@@ -104,13 +131,55 @@ func newPeerHandlerWithPolynomial(curve elliptic.Curve, peerManager types.PeerMa
 		u0g:                 u0g,
 		u0gCommiter:         u0gCommiter,
 		feldmanCommitmenter: feldmanCommitmenter,
+		sessionID:           sessionID,
+		chainCodeCommiter:   chainCodeCommiter,
 
-		peerManager: peerManager,
-		peerNum:     peerManager.NumPeers(),
-		peers:       make(map[string]*peer, peerManager.NumPeers()),
+		peerManager:  peerManager,
+		peerNum:      peerManager.NumPeers(),
+		peers:        make(map[string]*peer, peerManager.NumPeers()),
+		disqualified: make(map[string]bool),
+		extracted:    make(map[string]*peer),
 	}, nil
 }
 
+func newPedersenPeerHandler(curve elliptic.Curve, peerManager types.PeerManager, sessionID []byte, threshold uint32, rank uint32) (*peerHandler, error) {
+	ph, err := newPeerHandler(curve, peerManager, sessionID, threshold, rank)
+	if err != nil {
+		return nil, err
+	}
+	salts, err := polynomial.RandomPolynomial(curve.Params().N, threshold-1)
+	if err != nil {
+		return nil, err
+	}
+	err = ph.setSalts(salts)
+	if err != nil {
+		return nil, err
+	}
+	return ph, nil
+}
+
+// setSalts switches the peer handler to the Pedersen mode. The secret polynomial is committed by Pedersen
+// commitments with the salt polynomial first, and the Feldman commitments are revealed after all the shares
+// are verified. So nobody could choose its contribution after seeing the others' ones.
+func (p *peerHandler) setSalts(salts *polynomial.Polynomial) error {
+	hiddingPoint, err := commitment.NewHiddingPoint(p.curve, []byte(hiddingPointSeed))
+	if err != nil {
+		return err
+	}
+	pedersenCommitmenter, err := commitment.NewPedersenCommitmenter(p.threshold, hiddingPoint, p.poly, salts)
+	if err != nil {
+		return err
+	}
+	p.salts = salts
+	p.hiddingPoint = hiddingPoint
+	p.pedersenCommitmenter = pedersenCommitmenter
+	return nil
+}
+
+func (p *peerHandler) isPedersen() bool {
+	return p.salts != nil
+}
+
 func (p *peerHandler) MessageType() types.MessageType {
 	return types.MessageType(Type_Peer)
 }
@@ -128,9 +197,18 @@ func (p *peerHandler) HandleMessage(logger log.Logger, message types.Message) er
 	msg := getMessage(message)
 	id := msg.GetId()
 	body := msg.GetPeer()
+	if p.isPedersen() && len(body.GetPedersenCommitment().GetPoints()) != int(p.threshold) {
+		logger.Warn("Inconsistent Pedersen commitment", "got", len(body.GetPedersenCommitment().GetPoints()), "expected", p.threshold)
+		return blame(commitment.ErrDifferentLength, msg)
+	}
+	if body.GetChainCodeCommitment() == nil {
+		logger.Warn("Empty chain code commitment")
+		return blame(ErrInvalidChainCode, msg)
+	}
 	peer := newPeer(id)
 	peer.peer = &peerData{
-		bk: body.GetBk().ToBk(),
+		bk:                  body.GetBk().ToBk(),
+		chainCodeCommitment: body.GetChainCodeCommitment(),
 	}
 	p.peers[id] = peer
 	return peer.AddMessage(msg)
@@ -151,6 +229,14 @@ func (p *peerHandler) Finalize(logger log.Logger) (types.Handler, error) {
 		return nil, err
 	}
 
+	// Send out the shares with salts to each peer in the Pedersen mode
+	if p.isPedersen() {
+		for id, peer := range p.peers {
+			p.peerManager.MustSend(id, p.getPedersenVerifyMessage(peer.peer.bk))
+		}
+		return newPedersenVerifyHandler(p), nil
+	}
+
 	// Send out Feldman commit message and decommit message to all peers
 	msg := p.getDecommitMessage()
 	p.broadcast(msg)
@@ -158,13 +244,29 @@ func (p *peerHandler) Finalize(logger log.Logger) (types.Handler, error) {
 }
 
 func (p *peerHandler) GetPeerMessage() *Message {
+	if p.isPedersen() {
+		return &Message{
+			Type:      Type_Peer,
+			Id:        p.peerManager.SelfID(),
+			SessionId: p.sessionID,
+			Body: &Message_Peer{
+				Peer: &BodyPeer{
+					Bk:                  p.bk.ToMessage(),
+					PedersenCommitment:  p.pedersenCommitmenter.GetCommitmentMessage(),
+					ChainCodeCommitment: p.chainCodeCommiter.GetCommitmentMessage(),
+				},
+			},
+		}
+	}
 	return &Message{
-		Type: Type_Peer,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_Peer,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_Peer{
 			Peer: &BodyPeer{
-				Bk:         p.bk.ToMessage(),
-				Commitment: p.u0gCommiter.GetCommitmentMessage(),
+				Bk:                  p.bk.ToMessage(),
+				Commitment:          p.u0gCommiter.GetCommitmentMessage(),
+				ChainCodeCommitment: p.chainCodeCommiter.GetCommitmentMessage(),
 			},
 		},
 	}
@@ -172,8 +274,9 @@ func (p *peerHandler) GetPeerMessage() *Message {
 
 func (p *peerHandler) getDecommitMessage() *Message {
 	return &Message{
-		Type: Type_Decommit,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_Decommit,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_Decommit{
 			Decommit: &BodyDecommit{
 				HashDecommitment: p.u0gCommiter.GetDecommitmentMessage(),
@@ -183,6 +286,145 @@ func (p *peerHandler) getDecommitMessage() *Message {
 	}
 }
 
+func (p *peerHandler) getPedersenVerifyMessage(bk *birkhoffinterpolation.BkParameter) *Message {
+	return &Message{
+		Type:      Type_PedersenVerify,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_PedersenVerify{
+			PedersenVerify: &BodyPedersenVerify{
+				Verify: p.pedersenCommitmenter.GetVerifyMessage(bk),
+			},
+		},
+	}
+}
+
+func (p *peerHandler) getComplaintMessage() *Message {
+	return &Message{
+		Type:      Type_Complaint,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_Complaint{
+			Complaint: &BodyComplaint{
+				AccusedIds: p.getAccusedIDs(),
+			},
+		},
+	}
+}
+
+func (p *peerHandler) getFeldmanMessage() *Message {
+	return &Message{
+		Type:      Type_Feldman,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_Feldman{
+			Feldman: &BodyFeldman{
+				PointCommitment: p.feldmanCommitmenter.GetCommitmentMessage(),
+			},
+		},
+	}
+}
+
+func (p *peerHandler) getFeldmanComplaintMessage() *Message {
+	var shares []*RevealedShare
+	for _, id := range p.getPeerIDs() {
+		peer := p.peers[id]
+		if !peer.feldman.complaint {
+			continue
+		}
+		verify := peer.pedersenVerify.verify
+		shares = append(shares, &RevealedShare{
+			Id:         id,
+			Evaluation: verify.GetEvaluation(),
+			Salt:       verify.GetSalt(),
+		})
+	}
+	return &Message{
+		Type:      Type_FeldmanComplaint,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_FeldmanComplaint{
+			FeldmanComplaint: &BodyFeldmanComplaint{
+				Shares: shares,
+			},
+		},
+	}
+}
+
+func (p *peerHandler) getExtractMessage() *Message {
+	ids := make([]string, 0, len(p.extracted))
+	for id := range p.extracted {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	shares := make([]*RevealedShare, len(ids))
+	for i, id := range ids {
+		verify := p.extracted[id].pedersenVerify.verify
+		shares[i] = &RevealedShare{
+			Id:         id,
+			Evaluation: verify.GetEvaluation(),
+			Salt:       verify.GetSalt(),
+		}
+	}
+	return &Message{
+		Type:      Type_Extract,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_Extract{
+			Extract: &BodyExtract{
+				Shares: shares,
+			},
+		},
+	}
+}
+
+// getAccusedIDs returns the sorted ids of the peers whose shares are failed to verify
+func (p *peerHandler) getAccusedIDs() []string {
+	var ids []string
+	for id, peer := range p.peers {
+		if peer.verify != nil && peer.verify.complaint {
+			ids = append(ids, id)
+		}
+		if peer.pedersenVerify != nil && peer.pedersenVerify.complaint {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// getPeerIDs returns the sorted ids of the peers
+func (p *peerHandler) getPeerIDs() []string {
+	ids := make([]string, 0, len(p.peers))
+	for id := range p.peers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// getPeerBks returns the bks of self and the peers sorted by the ids of the peers
+func (p *peerHandler) getPeerBks() birkhoffinterpolation.BkParameters {
+	bks := make(birkhoffinterpolation.BkParameters, 0, len(p.peers)+1)
+	bks = append(bks, p.bk)
+	for _, id := range p.getPeerIDs() {
+		bks = append(bks, p.peers[id].peer.bk)
+	}
+	return bks
+}
+
+// getBk returns the bk of the participant, including self
+func (p *peerHandler) getBk(id string) (*birkhoffinterpolation.BkParameter, bool) {
+	if id == p.peerManager.SelfID() {
+		return p.bk, true
+	}
+	peer, ok := p.peers[id]
+	if !ok {
+		return nil, false
+	}
+	return peer.peer.bk, true
+}
+
 func (p *peerHandler) broadcast(msg proto.Message) {
 	for id := range p.peers {
 		p.peerManager.MustSend(id, msg)
@@ -193,6 +435,11 @@ func getMessage(messsage types.Message) *Message {
 	return messsage.(*Message)
 }
 
+// blame attaches the invalid message of the sender to the error as the evidence.
+func blame(err error, msg *Message) error {
+	return message.NewBlameError(err, msg)
+}
+
 func getMessageByType(peer *peer, t Type) *Message {
 	return getMessage(peer.GetMessage(types.MessageType(t)))
 }
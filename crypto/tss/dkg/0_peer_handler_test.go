@@ -18,6 +18,7 @@ import (
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/commitment"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/message/types/mocks"
 	"github.com/getamis/sirius/log"
@@ -51,6 +52,38 @@ var _ = Describe("peer handler, negative cases", func() {
 		})
 	})
 
+	It("inconsistent Pedersen commitment", func() {
+		curve := btcec.S256()
+		ph, err := newPedersenPeerHandler(curve, newPeerManager(getID(0), 1), sessionID, 2, 0)
+		Expect(err).Should(BeNil())
+		other, err := newPeerHandler(curve, newPeerManager(getID(1), 1), sessionID, 2, 0)
+		Expect(err).Should(BeNil())
+		Expect(ph.HandleMessage(log.Discard(), other.GetPeerMessage())).Should(MatchError(commitment.ErrDifferentLength))
+	})
+
+	It("empty chain code commitment", func() {
+		curve := btcec.S256()
+		ph, err := newPeerHandler(curve, newPeerManager(getID(0), 1), sessionID, 2, 0)
+		Expect(err).Should(BeNil())
+		other, err := newPeerHandler(curve, newPeerManager(getID(1), 1), sessionID, 2, 0)
+		Expect(err).Should(BeNil())
+		msg := other.GetPeerMessage()
+		msg.GetPeer().ChainCodeCommitment = nil
+		Expect(ph.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidChainCode))
+	})
+
+	It("blames the sender of an invalid peer message", func() {
+		curve := btcec.S256()
+		ph, err := newPeerHandler(curve, newPeerManager(getID(0), 1), sessionID, 2, 0)
+		Expect(err).Should(BeNil())
+		other, err := newPeerHandler(curve, newPeerManager(getID(1), 1), sessionID, 2, 0)
+		Expect(err).Should(BeNil())
+		msg := other.GetPeerMessage()
+		msg.GetPeer().ChainCodeCommitment = nil
+		err = ph.HandleMessage(log.Discard(), msg)
+		expectBlame(err, msg)
+	})
+
 	Context("Finalize", func() {
 		var (
 			curve     = btcec.S256()
@@ -0,0 +1,92 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+type batchDecommitData struct {
+	u0gs             []*ecpointgrouplaw.ECPoint
+	pointCommitments []*commitment.PointCommitmentMessage
+}
+
+type batchDecommitHandler struct {
+	*batchPeerHandler
+}
+
+func newBatchDecommitHandler(p *batchPeerHandler) *batchDecommitHandler {
+	return &batchDecommitHandler{
+		batchPeerHandler: p,
+	}
+}
+
+func (p *batchDecommitHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_BatchDecommit)
+}
+
+func (p *batchDecommitHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *batchDecommitHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.decommit != nil
+}
+
+func (p *batchDecommitHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	body := msg.GetBatchDecommit()
+	if err := p.ensureBatchSize(logger, len(body.GetHashDecommitments()), len(body.GetPointCommitments())); err != nil {
+		return blame(err, msg)
+	}
+	// Ensure decommit successfully for all the keys
+	u0gs := make([]*ecpointgrouplaw.ECPoint, len(p.keys))
+	for i := range p.keys {
+		var err error
+		u0gs[i], err = tss.GetPointFromHashCommitment(logger, p.sessionID, peer.peer.commitments[i], body.GetHashDecommitments()[i])
+		if err != nil {
+			logger.Warn("Failed to get u0g", "index", i, "err", err)
+			return blame(err, msg)
+		}
+	}
+	peer.decommit = &batchDecommitData{
+		u0gs:             u0gs,
+		pointCommitments: body.GetPointCommitments(),
+	}
+
+	// Send the verify message of all the keys
+	p.peerManager.MustSend(id, p.getVerifyMessage(peer.peer.bk))
+	return peer.AddMessage(msg)
+}
+
+func (p *batchDecommitHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	return newBatchVerifyHandler(p), nil
+}
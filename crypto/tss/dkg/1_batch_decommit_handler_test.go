@@ -0,0 +1,75 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("batch decommit handler, negative cases", func() {
+	var (
+		dh    *batchDecommitHandler
+		other *batchPeerHandler
+	)
+
+	BeforeEach(func() {
+		var ph *batchPeerHandler
+		ph, other = newBatchPeerHandlers(2)
+		dh = newBatchDecommitHandler(ph)
+	})
+
+	It("peer not found", func() {
+		msg := other.getDecommitMessage()
+		msg.Id = "invalid peer"
+		Expect(dh.HandleMessage(log.Discard(), msg)).Should(Equal(tss.ErrPeerNotFound))
+	})
+
+	It("inconsistent batch size", func() {
+		msg := other.getDecommitMessage()
+		body := msg.GetBatchDecommit()
+		body.PointCommitments = body.PointCommitments[1:]
+		Expect(dh.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInconsistentBatchSize))
+	})
+
+	It("invalid decommitment", func() {
+		msg := other.getDecommitMessage()
+		decommitment := msg.GetBatchDecommit().GetHashDecommitments()[1]
+		decommitment.Salt = addOne(decommitment.Salt)
+		Expect(dh.HandleMessage(log.Discard(), msg)).Should(MatchError(commitment.ErrDifferentDigest))
+	})
+
+	It("blames the sender of an invalid decommitment", func() {
+		msg := other.getDecommitMessage()
+		decommitment := msg.GetBatchDecommit().GetHashDecommitments()[0]
+		decommitment.Salt = addOne(decommitment.Salt)
+		err := dh.HandleMessage(log.Discard(), msg)
+		expectBlame(err, msg)
+	})
+})
+
+// newBatchVerifyHandlers returns the verify handlers of two peers which have handled the decommit messages of
+// each other
+func newBatchVerifyHandlers(batchSize uint32) (*batchVerifyHandler, *batchVerifyHandler) {
+	ph0, ph1 := newBatchPeerHandlers(batchSize)
+	dh0 := newBatchDecommitHandler(ph0)
+	dh1 := newBatchDecommitHandler(ph1)
+	Expect(dh0.HandleMessage(log.Discard(), ph1.getDecommitMessage())).Should(BeNil())
+	Expect(dh1.HandleMessage(log.Discard(), ph0.getDecommitMessage())).Should(BeNil())
+	return newBatchVerifyHandler(dh0), newBatchVerifyHandler(dh1)
+}
@@ -65,10 +65,10 @@ func (p *decommitHandler) HandleMessage(logger log.Logger, message types.Message
 	// Ensure decommit successfully
 	body := msg.GetDecommit()
 	peerMessage := getMessageByType(peer, Type_Peer)
-	u0g, err := tss.GetPointFromHashCommitment(logger, peerMessage.GetPeer().GetCommitment(), body.GetHashDecommitment())
+	u0g, err := tss.GetPointFromHashCommitment(logger, p.sessionID, peerMessage.GetPeer().GetCommitment(), body.GetHashDecommitment())
 	if err != nil {
 		logger.Warn("Failed to get u0g", "err", err)
-		return err
+		return blame(err, msg)
 	}
 
 	// Build and send the verify message
@@ -76,8 +76,9 @@ func (p *decommitHandler) HandleMessage(logger log.Logger, message types.Message
 	peer.decommit = &decommitData{
 		u0g: u0g,
 		verifyMessage: &Message{
-			Type: Type_Verify,
-			Id:   p.peerManager.SelfID(),
+			Type:      Type_Verify,
+			Id:        p.peerManager.SelfID(),
+			SessionId: p.sessionID,
 			Body: &Message_Verify{
 				Verify: &BodyVerify{
 					Verify: v,
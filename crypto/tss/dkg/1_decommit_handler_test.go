@@ -131,7 +131,23 @@ var _ = Describe("decommit handler, negative cases", func() {
 							PointCommitment: de.PointCommitment,
 						},
 					}
-					Expect(d.GetHandler().HandleMessage(log.Discard(), msg)).Should(Equal(commitment.ErrDifferentDigest))
+					Expect(d.GetHandler().HandleMessage(log.Discard(), msg)).Should(MatchError(commitment.ErrDifferentDigest))
+				}
+				msg = dh.getDecommitMessage()
+			}
+		})
+
+		It("blames the sender of an invalid decommit message", func() {
+			var msg *Message
+			for _, d := range dkgs {
+				dh, ok := d.GetHandler().(*decommitHandler)
+				Expect(ok).Should(BeTrue())
+
+				if msg != nil {
+					msg = proto.Clone(msg).(*Message)
+					msg.GetDecommit().HashDecommitment.Data = []byte("invalid data")
+					err := dh.HandleMessage(log.Discard(), msg)
+					expectBlame(err, msg)
 				}
 				msg = dh.getDecommitMessage()
 			}
@@ -159,9 +175,9 @@ func (p *stopPeerManager) MustSend(id string, message proto.Message) {
 		return
 	}
 
-	// Stop peer manager if we try to send the next
+	// Stop peer manager if we try to send the message of the stop round. The later rounds are stopped as well.
 	msg := message.(*Message)
-	if msg.Type >= p.stopMessageType {
+	if msg.Type == p.stopMessageType {
 		p.isStopped = true
 		return
 	}
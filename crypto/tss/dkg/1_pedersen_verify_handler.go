@@ -0,0 +1,85 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+type pedersenVerifyData struct {
+	verify *commitment.PedersenVerifyMessage
+	// complaint is true if the share is failed to verify
+	complaint bool
+}
+
+type pedersenVerifyHandler struct {
+	*peerHandler
+}
+
+func newPedersenVerifyHandler(p *peerHandler) *pedersenVerifyHandler {
+	return &pedersenVerifyHandler{
+		peerHandler: p,
+	}
+}
+
+func (p *pedersenVerifyHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_PedersenVerify)
+}
+
+func (p *pedersenVerifyHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *pedersenVerifyHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.pedersenVerify != nil
+}
+
+func (p *pedersenVerifyHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	// Pedersen Verify
+	verify := msg.GetPedersenVerify().GetVerify()
+	peerMessage := getMessageByType(peer, Type_Peer)
+	err := verify.Verify(peerMessage.GetPeer().GetPedersenCommitment(), p.hiddingPoint, p.bk, p.threshold-1)
+	if err != nil {
+		// Complain about the peer in the next round instead of aborting
+		logger.Warn("Failed to verify message, complain about the peer", "err", err)
+	}
+	peer.pedersenVerify = &pedersenVerifyData{
+		verify:    verify,
+		complaint: err != nil,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *pedersenVerifyHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	msg := p.getComplaintMessage()
+	p.broadcast(msg)
+	return newComplaintHandler(p.peerHandler), nil
+}
@@ -0,0 +1,82 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pedersen verify handler, negative cases", func() {
+	var (
+		curve     = btcec.S256()
+		threshold = uint32(2)
+		peerId    = getID(1)
+
+		pvh   *pedersenVerifyHandler
+		other *peerHandler
+	)
+
+	BeforeEach(func() {
+		ph, err := newPedersenPeerHandler(curve, newPeerManager(getID(0), 1), sessionID, threshold, 0)
+		Expect(err).Should(BeNil())
+		other, err = newPedersenPeerHandler(curve, newPeerManager(peerId, 1), sessionID, threshold, 0)
+		Expect(err).Should(BeNil())
+		Expect(ph.HandleMessage(log.Discard(), other.GetPeerMessage())).Should(BeNil())
+		pvh = newPedersenVerifyHandler(ph)
+	})
+
+	Context("IsHandled", func() {
+		It("peer not found", func() {
+			Expect(pvh.IsHandled(log.Discard(), "invalid peer")).Should(BeFalse())
+		})
+
+		It("message is handled before", func() {
+			pvh.peers[peerId].pedersenVerify = &pedersenVerifyData{}
+			Expect(pvh.IsHandled(log.Discard(), peerId)).Should(BeTrue())
+		})
+
+		It("message is not handled before", func() {
+			Expect(pvh.IsHandled(log.Discard(), peerId)).Should(BeFalse())
+		})
+	})
+
+	Context("HandleMessage", func() {
+		It("peer not found", func() {
+			msg := &Message{
+				Id: "invalid peer",
+			}
+			Expect(pvh.HandleMessage(log.Discard(), msg)).Should(Equal(tss.ErrPeerNotFound))
+		})
+
+		It("valid share", func() {
+			msg := other.getPedersenVerifyMessage(pvh.bk)
+			Expect(pvh.HandleMessage(log.Discard(), msg)).Should(BeNil())
+			Expect(pvh.IsHandled(log.Discard(), peerId)).Should(BeTrue())
+		})
+
+		It("invalid share", func() {
+			wrongBk := birkhoffinterpolation.NewBkParameter(pvh.bk.GetX(), pvh.bk.GetRank()+1)
+			msg := other.getPedersenVerifyMessage(wrongBk)
+			Expect(pvh.HandleMessage(log.Discard(), msg)).Should(BeNil())
+			Expect(pvh.IsHandled(log.Discard(), peerId)).Should(BeTrue())
+			Expect(pvh.getAccusedIDs()).Should(Equal([]string{peerId}))
+		})
+	})
+})
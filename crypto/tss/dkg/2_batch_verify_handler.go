@@ -0,0 +1,145 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"math/big"
+
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/zkproof"
+	"github.com/getamis/sirius/log"
+)
+
+type batchVerifyData struct {
+	evaluations []*big.Int
+}
+
+type batchVerifyHandler struct {
+	*batchDecommitHandler
+	publicKeys   []*ecpointgrouplaw.ECPoint
+	shares       []*big.Int
+	siGProofMsgs []*zkproof.SchnorrProofMessage
+}
+
+func newBatchVerifyHandler(d *batchDecommitHandler) *batchVerifyHandler {
+	return &batchVerifyHandler{
+		batchDecommitHandler: d,
+	}
+}
+
+func (p *batchVerifyHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_BatchVerify)
+}
+
+func (p *batchVerifyHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *batchVerifyHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.verify != nil
+}
+
+func (p *batchVerifyHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	verifies := msg.GetBatchVerify().GetVerifies()
+	if err := p.ensureBatchSize(logger, len(verifies)); err != nil {
+		return blame(err, msg)
+	}
+	// Feldman Verify for all the keys
+	evaluations := make([]*big.Int, len(p.keys))
+	for i, verify := range verifies {
+		err := verify.Verify(peer.decommit.pointCommitments[i], p.bk, p.threshold-1)
+		if err != nil {
+			// The share is only known by us, so we cannot prove the sender is malicious to the others.
+			logger.Warn("Failed to verify message", "index", i, "err", err)
+			return ErrUnidentifiableShare
+		}
+		evaluations[i] = new(big.Int).SetBytes(verify.GetEvaluation())
+	}
+	peer.verify = &batchVerifyData{
+		evaluations: evaluations,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *batchVerifyHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	fieldOrder := p.curve.Params().N
+	p.publicKeys = make([]*ecpointgrouplaw.ECPoint, len(p.keys))
+	p.shares = make([]*big.Int, len(p.keys))
+	p.siGProofMsgs = make([]*zkproof.SchnorrProofMessage, len(p.keys))
+	for i, k := range p.keys {
+		// Build the public key, the sum of self u0g and the peers' u0gs
+		var err error
+		publicKey := k.u0g.Copy()
+		// Build the share, the sum of f^(n_j)(x_j)
+		share := p.evaluate(i, p.bk)
+		for _, peer := range p.peers {
+			publicKey, err = publicKey.Add(peer.decommit.u0gs[i])
+			if err != nil {
+				logger.Warn("Failed to add ug", "index", i, "err", err)
+				return nil, err
+			}
+			share = new(big.Int).Add(share, peer.verify.evaluations[i])
+		}
+		// The verification of ECDSA does not permit the public key is the identity element.
+		if publicKey.IsIdentity() {
+			return nil, ErrTrivialPublicKey
+		}
+		p.publicKeys[i] = publicKey
+		p.shares[i] = new(big.Int).Mod(share, fieldOrder)
+		p.siGProofMsgs[i], err = zkproof.NewBaseSchorrMessageWithSession(p.sessionID, p.curve, p.shares[i])
+		if err != nil {
+			logger.Warn("Failed to new si schorr proof", "index", i, "err", err)
+			return nil, err
+		}
+	}
+
+	// Send out the result message of all the keys
+	p.broadcast(p.getResultMessage())
+	return newBatchResultHandler(p), nil
+}
+
+func (p *batchVerifyHandler) getResultMessage() *Message {
+	chainCodeDecommitments := make([]*commitment.HashDecommitmentMessage, len(p.keys))
+	for i, k := range p.keys {
+		chainCodeDecommitments[i] = k.chainCodeCommiter.GetDecommitmentMessage()
+	}
+	return &Message{
+		Type:      Type_BatchResult,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_BatchResult{
+			BatchResult: &BodyBatchResult{
+				SiGProofMsgs:           p.siGProofMsgs,
+				ChainCodeDecommitments: chainCodeDecommitments,
+			},
+		},
+	}
+}
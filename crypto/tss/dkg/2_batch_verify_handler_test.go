@@ -0,0 +1,72 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"errors"
+
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("batch verify handler, negative cases", func() {
+	var (
+		vh    *batchVerifyHandler
+		other *batchVerifyHandler
+	)
+
+	BeforeEach(func() {
+		vh, other = newBatchVerifyHandlers(2)
+	})
+
+	It("inconsistent batch size", func() {
+		msg := other.getVerifyMessage(vh.bk)
+		body := msg.GetBatchVerify()
+		body.Verifies = body.Verifies[1:]
+		Expect(vh.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInconsistentBatchSize))
+	})
+
+	It("invalid share", func() {
+		msg := other.getVerifyMessage(vh.bk)
+		verify := msg.GetBatchVerify().GetVerifies()[1]
+		verify.Evaluation = addOne(verify.Evaluation)
+		Expect(vh.HandleMessage(log.Discard(), msg)).Should(Equal(ErrUnidentifiableShare))
+	})
+
+	It("does not blame the sender of an invalid share", func() {
+		msg := other.getVerifyMessage(vh.bk)
+		verify := msg.GetBatchVerify().GetVerifies()[0]
+		verify.Evaluation = addOne(verify.Evaluation)
+		err := vh.HandleMessage(log.Discard(), msg)
+		var blameErr *message.BlameError
+		Expect(errors.As(err, &blameErr)).Should(BeFalse())
+		Expect(vh.IsHandled(log.Discard(), other.peerManager.SelfID())).Should(BeFalse())
+	})
+})
+
+// newBatchResultHandlers returns the result handlers of two peers which have handled the verify messages of
+// each other
+func newBatchResultHandlers(batchSize uint32) (*batchResultHandler, *batchResultHandler) {
+	vh0, vh1 := newBatchVerifyHandlers(batchSize)
+	Expect(vh0.HandleMessage(log.Discard(), vh1.getVerifyMessage(vh0.bk))).Should(BeNil())
+	Expect(vh1.HandleMessage(log.Discard(), vh0.getVerifyMessage(vh1.bk))).Should(BeNil())
+	h0, err := vh0.Finalize(log.Discard())
+	Expect(err).Should(BeNil())
+	h1, err := vh1.Finalize(log.Discard())
+	Expect(err).Should(BeNil())
+	return h0.(*batchResultHandler), h1.(*batchResultHandler)
+}
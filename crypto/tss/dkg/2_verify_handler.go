@@ -33,6 +33,8 @@ var (
 
 type verifyData struct {
 	verify *commitment.FeldmanVerifyMessage
+	// complaint is true if the share is failed to verify
+	complaint bool
 }
 
 type verifyHandler struct {
@@ -48,6 +50,11 @@ func newVerifyHandler(d *decommitHandler) *verifyHandler {
 	}
 }
 
+// newResultVerifyHandler returns a verify handler to build the result after the complaint rounds.
+func newResultVerifyHandler(p *peerHandler) *verifyHandler {
+	return newVerifyHandler(newDecommitHandler(p))
+}
+
 func (p *verifyHandler) MessageType() types.MessageType {
 	return types.MessageType(Type_Verify)
 }
@@ -79,21 +86,55 @@ func (p *verifyHandler) HandleMessage(logger log.Logger, message types.Message)
 	decommitMessage := getMessageByType(peer, Type_Decommit)
 	err := verify.Verify(decommitMessage.GetDecommit().GetPointCommitment(), p.bk, p.threshold-1)
 	if err != nil {
-		logger.Warn("Failed to verify message", "err", err)
-		return err
+		// Complain about the peer in the next round instead of aborting
+		logger.Warn("Failed to verify message, complain about the peer", "err", err)
 	}
 	peer.verify = &verifyData{
-		verify: verify,
+		verify:    verify,
+		complaint: err != nil,
 	}
 	return peer.AddMessage(msg)
 }
 
 func (p *verifyHandler) Finalize(logger log.Logger) (types.Handler, error) {
-	// Build the public key, the sum of uG
+	msg := p.getComplaintMessage()
+	p.broadcast(msg)
+	return newComplaintHandler(p.peerHandler), nil
+}
+
+// finalizeResult builds the public key and the share with the qualified peers, including the extracted ones, and
+// sends out the result message.
+func (p *peerHandler) finalizeResult(logger log.Logger) (types.Handler, error) {
+	u0gs := make([]*ecpointgrouplaw.ECPoint, 0, len(p.peers))
+	evaluations := make([]*big.Int, 0, len(p.peers))
+	for _, peer := range p.peers {
+		if p.isPedersen() {
+			u0gs = append(u0gs, peer.feldman.u0g)
+			evaluations = append(evaluations, new(big.Int).SetBytes(peer.pedersenVerify.verify.GetEvaluation()))
+			continue
+		}
+		u0gs = append(u0gs, peer.decommit.u0g)
+		evaluations = append(evaluations, new(big.Int).SetBytes(peer.verify.verify.GetEvaluation()))
+	}
+	// The extracted peers are still qualified, so their shares and reconstructed u0gs are included
+	for _, peer := range p.extracted {
+		u0gs = append(u0gs, peer.feldman.u0g)
+		evaluations = append(evaluations, new(big.Int).SetBytes(peer.pedersenVerify.verify.GetEvaluation()))
+	}
+	vh := newResultVerifyHandler(p)
+	publicKey, err := vh.buildPublicKey(logger, u0gs)
+	if err != nil {
+		return nil, err
+	}
+	return vh.buildResult(logger, publicKey, evaluations)
+}
+
+// buildPublicKey returns the public key, the sum of self u0g and the peers' u0gs.
+func (p *verifyHandler) buildPublicKey(logger log.Logger, u0gs []*ecpointgrouplaw.ECPoint) (*ecpointgrouplaw.ECPoint, error) {
 	var err error
 	publicKey := p.u0g.Copy()
-	for _, peer := range p.peers {
-		publicKey, err = publicKey.Add(peer.decommit.u0g)
+	for _, u0g := range u0gs {
+		publicKey, err = publicKey.Add(u0g)
 		if err != nil {
 			logger.Warn("Failed to add ug", "err", err)
 			return nil, err
@@ -103,19 +144,24 @@ func (p *verifyHandler) Finalize(logger log.Logger) (types.Handler, error) {
 	if publicKey.IsIdentity() {
 		return nil, ErrTrivialPublicKey
 	}
+	return publicKey, nil
+}
+
+// buildResult builds the share from the peers' evaluations, and sends out the result message.
+func (p *verifyHandler) buildResult(logger log.Logger, publicKey *ecpointgrouplaw.ECPoint, evaluations []*big.Int) (types.Handler, error) {
+	var err error
 	p.publicKey = publicKey
 
 	// Build the share, the sum of f^(n_j)(x_j)
 	poly := p.poly.Differentiate(p.bk.GetRank())
 	p.share = poly.Evaluate(p.bk.GetX())
-	for _, peer := range p.peers {
-		v := peer.verify.verify
-		p.share = new(big.Int).Add(p.share, new(big.Int).SetBytes(v.Evaluation))
+	for _, evaluation := range evaluations {
+		p.share = new(big.Int).Add(p.share, evaluation)
 	}
 	p.share = new(big.Int).Mod(p.share, p.curve.Params().N)
 
 	// Build and send out the result message
-	p.siGProofMsg, err = zkproof.NewBaseSchorrMessage(p.curve, p.share)
+	p.siGProofMsg, err = zkproof.NewBaseSchorrMessageWithSession(p.sessionID, p.curve, p.share)
 	if err != nil {
 		log.Warn("Failed to new si schorr proof", "err", err)
 		return nil, err
@@ -127,11 +173,13 @@ func (p *verifyHandler) Finalize(logger log.Logger) (types.Handler, error) {
 
 func (p *verifyHandler) getResultMessage() *Message {
 	return &Message{
-		Type: Type_Result,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_Result,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_Result{
 			Result: &BodyResult{
-				SiGProofMsg: p.siGProofMsg,
+				SiGProofMsg:           p.siGProofMsg,
+				ChainCodeDecommitment: p.chainCodeCommiter.GetDecommitmentMessage(),
 			},
 		},
 	}
@@ -18,7 +18,6 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcec"
-	"github.com/getamis/alice/crypto/commitment"
 	"github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/tss"
 	"github.com/getamis/alice/crypto/tss/message/types"
@@ -130,8 +129,10 @@ var _ = Describe("verify handler, negative cases", func() {
 				for pId, peer := range vh.peers {
 					vm := peer.decommit.verifyMessage
 					vm.Id = pId
-					Expect(vh.HandleMessage(log.Discard(), vm)).Should(Equal(commitment.ErrFailedVerify))
+					Expect(vh.HandleMessage(log.Discard(), vm)).Should(BeNil())
+					Expect(peer.verify.complaint).Should(BeTrue())
 				}
+				Expect(vh.getAccusedIDs()).Should(Equal(vh.getPeerIDs()))
 			}
 		})
 
@@ -140,10 +141,14 @@ var _ = Describe("verify handler, negative cases", func() {
 				vh, ok := d.GetHandler().(*verifyHandler)
 				Expect(ok).Should(BeTrue())
 
+				var u0gs []*ecpointgrouplaw.ECPoint
+				for _, peer := range vh.peers {
+					u0gs = append(u0gs, peer.decommit.u0g)
+				}
 				vh.u0g = ecpointgrouplaw.NewBase(elliptic.P224())
-				h, err := vh.Finalize(log.Discard())
+				publicKey, err := vh.buildPublicKey(log.Discard(), u0gs)
 				Expect(err).Should(Equal(ecpointgrouplaw.ErrDifferentCurve))
-				Expect(h).Should(BeNil())
+				Expect(publicKey).Should(BeNil())
 			}
 		})
 	})
@@ -0,0 +1,154 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+type batchResultData struct {
+	results    []*ecpointgrouplaw.ECPoint
+	chainCodes [][]byte
+}
+
+type batchResultHandler struct {
+	*batchVerifyHandler
+}
+
+func newBatchResultHandler(v *batchVerifyHandler) *batchResultHandler {
+	return &batchResultHandler{
+		batchVerifyHandler: v,
+	}
+}
+
+func (p *batchResultHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_BatchResult)
+}
+
+func (p *batchResultHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *batchResultHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.result != nil
+}
+
+func (p *batchResultHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	body := msg.GetBatchResult()
+	if err := p.ensureBatchSize(logger, len(body.GetSiGProofMsgs()), len(body.GetChainCodeDecommitments())); err != nil {
+		return blame(err, msg)
+	}
+	results := make([]*ecpointgrouplaw.ECPoint, len(p.keys))
+	chainCodes := make([][]byte, len(p.keys))
+	for i, siGProofMsg := range body.GetSiGProofMsgs() {
+		var err error
+		results[i], err = siGProofMsg.V.ToPoint()
+		if err != nil {
+			logger.Warn("Failed to get point", "index", i, "err", err)
+			return blame(err, msg)
+		}
+		err = siGProofMsg.VerifyWithSession(p.sessionID, ecpointgrouplaw.NewBase(p.curve))
+		if err != nil {
+			logger.Warn("Failed to verify Schorr proof", "index", i, "err", err)
+			return blame(err, msg)
+		}
+
+		chainCodeDecommitment := body.GetChainCodeDecommitments()[i]
+		if len(chainCodeDecommitment.GetData()) != ChainCodeSize {
+			logger.Warn("Invalid chain code length", "index", i, "got", len(chainCodeDecommitment.GetData()), "expected", ChainCodeSize)
+			return blame(ErrInvalidChainCode, msg)
+		}
+		err = peer.peer.chainCodeCommitments[i].DecommitWithSession(p.sessionID, chainCodeDecommitment)
+		if err != nil {
+			logger.Warn("Failed to decommit chain code", "index", i, "err", err)
+			return blame(err, msg)
+		}
+		chainCodes[i] = chainCodeDecommitment.GetData()
+	}
+	peer.result = &batchResultData{
+		results:    results,
+		chainCodes: chainCodes,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *batchResultHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	// Fix the order of the peers, so that the bks and the public shares of all the keys are matched
+	peers := make([]*batchPeer, 0, len(p.peers))
+	bks := make(birkhoffinterpolation.BkParameters, 0, p.peerNum+1)
+	bks = append(bks, p.bk)
+	for _, peer := range p.peers {
+		peers = append(peers, peer)
+		bks = append(bks, peer.peer.bk)
+	}
+	for i := range p.keys {
+		siG, err := p.siGProofMsgs[i].V.ToPoint()
+		if err != nil {
+			logger.Warn("Failed to get point", "index", i, "err", err)
+			return nil, err
+		}
+		sgs := make([]*ecpointgrouplaw.ECPoint, 0, p.peerNum+1)
+		sgs = append(sgs, siG)
+		for _, peer := range peers {
+			sgs = append(sgs, peer.result.results[i])
+		}
+		err = tss.ValidatePublicKey(logger, bks, sgs, p.threshold, p.publicKeys[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// getPublicShares returns the public shares of self and the peers of the i-th key
+func (p *batchResultHandler) getPublicShares(i int) (map[string]*ecpointgrouplaw.ECPoint, error) {
+	siG, err := p.siGProofMsgs[i].V.ToPoint()
+	if err != nil {
+		return nil, err
+	}
+	publicShares := make(map[string]*ecpointgrouplaw.ECPoint, len(p.peers)+1)
+	publicShares[p.peerManager.SelfID()] = siG
+	for id, peer := range p.peers {
+		publicShares[id] = peer.result.results[i]
+	}
+	return publicShares, nil
+}
+
+// getChainCode returns the chain code of the i-th key
+func (p *batchResultHandler) getChainCode(i int) []byte {
+	contributions := make(map[string][]byte, len(p.peers)+1)
+	contributions[p.peerManager.SelfID()] = p.keys[i].chainCodeCommiter.GetDecommitmentMessage().GetData()
+	for id, peer := range p.peers {
+		contributions[id] = peer.result.chainCodes[i]
+	}
+	return buildChainCode(contributions)
+}
@@ -0,0 +1,84 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/zkproof"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("batch result handler, negative cases", func() {
+	var (
+		rh    *batchResultHandler
+		other *batchResultHandler
+	)
+
+	BeforeEach(func() {
+		rh, other = newBatchResultHandlers(2)
+	})
+
+	It("handles the result message", func() {
+		Expect(rh.IsHandled(log.Discard(), other.peerManager.SelfID())).Should(BeFalse())
+		Expect(rh.HandleMessage(log.Discard(), other.getResultMessage())).Should(BeNil())
+		Expect(rh.IsHandled(log.Discard(), other.peerManager.SelfID())).Should(BeTrue())
+		got, err := rh.Finalize(log.Discard())
+		Expect(err).Should(BeNil())
+		Expect(got).Should(BeNil())
+	})
+
+	It("inconsistent batch size", func() {
+		msg := other.getResultMessage()
+		body := msg.GetBatchResult()
+		body.ChainCodeDecommitments = body.ChainCodeDecommitments[1:]
+		Expect(rh.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInconsistentBatchSize))
+	})
+
+	It("invalid verify", func() {
+		msg := other.getResultMessage()
+		msg.GetBatchResult().GetSiGProofMsgs()[1].U = []byte("invalid U")
+		Expect(rh.HandleMessage(log.Discard(), msg)).Should(MatchError(zkproof.ErrVerifyFailure))
+	})
+
+	It("blames the sender of an invalid verify", func() {
+		msg := other.getResultMessage()
+		msg.GetBatchResult().GetSiGProofMsgs()[0].U = []byte("invalid U")
+		err := rh.HandleMessage(log.Discard(), msg)
+		expectBlame(err, msg)
+	})
+
+	It("invalid chain code length", func() {
+		msg := other.getResultMessage()
+		msg.GetBatchResult().GetChainCodeDecommitments()[1].Data = []byte("invalid chain code")
+		Expect(rh.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidChainCode))
+	})
+
+	It("invalid chain code decommitment", func() {
+		msg := other.getResultMessage()
+		msg.GetBatchResult().GetChainCodeDecommitments()[1].Data = make([]byte, ChainCodeSize)
+		Expect(rh.HandleMessage(log.Discard(), msg)).Should(MatchError(commitment.ErrDifferentDigest))
+	})
+
+	It("inconsistent public key", func() {
+		Expect(rh.HandleMessage(log.Discard(), other.getResultMessage())).Should(BeNil())
+		rh.publicKeys[1] = rh.publicKeys[0]
+		got, err := rh.Finalize(log.Discard())
+		Expect(err).Should(Equal(tss.ErrInconsistentPubKey))
+		Expect(got).Should(BeNil())
+	})
+})
@@ -0,0 +1,126 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"errors"
+
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrInvalidComplaint is returned if the complaint accuses unknown or duplicate peers
+	ErrInvalidComplaint = errors.New("invalid complaint")
+)
+
+type complaintData struct {
+	accusedIDs []string
+}
+
+type complaintHandler struct {
+	*peerHandler
+}
+
+func newComplaintHandler(p *peerHandler) *complaintHandler {
+	return &complaintHandler{
+		peerHandler: p,
+	}
+}
+
+func (p *complaintHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_Complaint)
+}
+
+func (p *complaintHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *complaintHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.complaint != nil
+}
+
+func (p *complaintHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	accusedIDs := msg.GetComplaint().GetAccusedIds()
+	accused := make(map[string]bool, len(accusedIDs))
+	for _, accusedID := range accusedIDs {
+		_, ok := p.getBk(accusedID)
+		if !ok || accusedID == id || accused[accusedID] {
+			logger.Warn("Invalid accused peer", "accusedID", accusedID)
+			return blame(ErrInvalidComplaint, msg)
+		}
+		accused[accusedID] = true
+	}
+	peer.complaint = &complaintData{
+		accusedIDs: accusedIDs,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *complaintHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	// Reveal the disputed shares to all the peers
+	msg := p.getRevealMessage()
+	p.broadcast(msg)
+	return newRevealHandler(p), nil
+}
+
+// getRevealMessage returns the reveal message with the shares sent to the peers complaining about us
+func (p *complaintHandler) getRevealMessage() *Message {
+	selfID := p.peerManager.SelfID()
+	var shares []*RevealedShare
+	for _, id := range p.getPeerIDs() {
+		peer := p.peers[id]
+		for _, accusedID := range peer.complaint.accusedIDs {
+			if accusedID != selfID {
+				continue
+			}
+			share := &RevealedShare{
+				Id: id,
+			}
+			if p.isPedersen() {
+				v := p.pedersenCommitmenter.GetVerifyMessage(peer.peer.bk)
+				share.Evaluation = v.GetEvaluation()
+				share.Salt = v.GetSalt()
+			} else {
+				share.Evaluation = p.feldmanCommitmenter.GetVerifyMessage(peer.peer.bk).GetEvaluation()
+			}
+			shares = append(shares, share)
+		}
+	}
+	return &Message{
+		Type:      Type_Reveal,
+		Id:        selfID,
+		SessionId: p.sessionID,
+		Body: &Message_Reveal{
+			Reveal: &BodyReveal{
+				Shares: shares,
+			},
+		},
+	}
+}
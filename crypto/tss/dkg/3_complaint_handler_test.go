@@ -0,0 +1,156 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("complaint handler, negative cases", func() {
+	var (
+		threshold = uint32(2)
+		selfId    = getID(0)
+		peerId    = getID(1)
+
+		chs []*complaintHandler
+		ch  *complaintHandler
+	)
+
+	BeforeEach(func() {
+		chs = newComplaintHandlers(threshold, 3)
+		ch = chs[0]
+	})
+
+	Context("IsHandled", func() {
+		It("peer not found", func() {
+			Expect(ch.IsHandled(log.Discard(), "invalid peer")).Should(BeFalse())
+		})
+
+		It("message is handled before", func() {
+			ch.peers[peerId].complaint = &complaintData{}
+			Expect(ch.IsHandled(log.Discard(), peerId)).Should(BeTrue())
+		})
+
+		It("message is not handled before", func() {
+			Expect(ch.IsHandled(log.Discard(), peerId)).Should(BeFalse())
+		})
+	})
+
+	Context("HandleMessage", func() {
+		It("peer not found", func() {
+			msg := &Message{
+				Id: "invalid peer",
+			}
+			Expect(ch.HandleMessage(log.Discard(), msg)).Should(Equal(tss.ErrPeerNotFound))
+		})
+
+		It("no complaint", func() {
+			Expect(ch.HandleMessage(log.Discard(), chs[1].getComplaintMessage())).Should(BeNil())
+			Expect(ch.IsHandled(log.Discard(), peerId)).Should(BeTrue())
+			Expect(ch.peers[peerId].complaint.accusedIDs).Should(BeEmpty())
+		})
+
+		It("valid complaint", func() {
+			msg := newComplaintMessage(peerId, selfId, getID(2))
+			Expect(ch.HandleMessage(log.Discard(), msg)).Should(BeNil())
+			Expect(ch.peers[peerId].complaint.accusedIDs).Should(Equal([]string{selfId, getID(2)}))
+		})
+
+		It("accuse itself", func() {
+			msg := newComplaintMessage(peerId, peerId)
+			Expect(ch.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidComplaint))
+			Expect(ch.IsHandled(log.Discard(), peerId)).Should(BeFalse())
+		})
+
+		It("accuse unknown peer", func() {
+			msg := newComplaintMessage(peerId, "invalid peer")
+			Expect(ch.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidComplaint))
+			Expect(ch.IsHandled(log.Discard(), peerId)).Should(BeFalse())
+		})
+
+		It("duplicate accused peers", func() {
+			msg := newComplaintMessage(peerId, selfId, selfId)
+			Expect(ch.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidComplaint))
+			Expect(ch.IsHandled(log.Discard(), peerId)).Should(BeFalse())
+		})
+
+		It("blames the sender of an invalid complaint", func() {
+			msg := newComplaintMessage(peerId, peerId)
+			err := ch.HandleMessage(log.Discard(), msg)
+			expectBlame(err, msg)
+		})
+	})
+
+	Context("getRevealMessage", func() {
+		It("reveal the shares to the accusers", func() {
+			Expect(ch.HandleMessage(log.Discard(), newComplaintMessage(peerId, selfId))).Should(BeNil())
+			Expect(ch.HandleMessage(log.Discard(), newComplaintMessage(getID(2)))).Should(BeNil())
+			shares := ch.getRevealMessage().GetReveal().GetShares()
+			Expect(shares).Should(HaveLen(1))
+			Expect(shares[0].GetId()).Should(Equal(peerId))
+
+			// The revealed share is the one sent to the accuser
+			v := chs[1].peers[selfId].pedersenVerify.verify
+			Expect(shares[0].GetEvaluation()).Should(Equal(v.GetEvaluation()))
+			Expect(shares[0].GetSalt()).Should(Equal(v.GetSalt()))
+		})
+	})
+})
+
+// newComplaintHandlers returns the complaint handlers of the Pedersen mode which verified the shares of each other.
+func newComplaintHandlers(threshold uint32, n int) []*complaintHandler {
+	curve := btcec.S256()
+	phs := make([]*peerHandler, n)
+	for i := range phs {
+		var err error
+		phs[i], err = newPedersenPeerHandler(curve, newPeerManager(getID(i), n-1), sessionID, threshold, 0)
+		Expect(err).Should(BeNil())
+	}
+	for i, ph := range phs {
+		for j, other := range phs {
+			if i != j {
+				Expect(ph.HandleMessage(log.Discard(), other.GetPeerMessage())).Should(BeNil())
+			}
+		}
+	}
+	chs := make([]*complaintHandler, n)
+	for i, ph := range phs {
+		pvh := newPedersenVerifyHandler(ph)
+		for j, other := range phs {
+			if i != j {
+				Expect(pvh.HandleMessage(log.Discard(), other.getPedersenVerifyMessage(ph.bk))).Should(BeNil())
+			}
+		}
+		chs[i] = newComplaintHandler(ph)
+	}
+	return chs
+}
+
+func newComplaintMessage(id string, accusedIDs ...string) *Message {
+	return &Message{
+		Type:      Type_Complaint,
+		Id:        id,
+		SessionId: sessionID,
+		Body: &Message_Complaint{
+			Complaint: &BodyComplaint{
+				AccusedIds: accusedIDs,
+			},
+		},
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+type feldmanData struct {
+	u0g *ecpointgrouplaw.ECPoint
+	// complaint is true if the Feldman commitments are inconsistent with the share
+	complaint bool
+}
+
+type feldmanHandler struct {
+	*revealHandler
+}
+
+func newFeldmanHandler(p *revealHandler) *feldmanHandler {
+	return &feldmanHandler{
+		revealHandler: p,
+	}
+}
+
+func (p *feldmanHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_Feldman)
+}
+
+// GetRoundPeerIDs returns the ids of the qualified peers
+func (p *feldmanHandler) GetRoundPeerIDs() []string {
+	return p.getPeerIDs()
+}
+
+func (p *feldmanHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *feldmanHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.feldman != nil
+}
+
+func (p *feldmanHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	// Feldman Verify with the verified (or revealed) share of the Pedersen round
+	u0g, err := verifyFeldmanShare(msg.GetFeldman().GetPointCommitment(), p.bk, peer.pedersenVerify.verify.GetEvaluation(), p.threshold-1)
+	if err != nil {
+		// Complain about the peer in the next round, and its secret is reconstructed instead of aborting
+		logger.Warn("Failed to verify message, complain about the peer", "err", err)
+	}
+	peer.feldman = &feldmanData{
+		u0g:       u0g,
+		complaint: err != nil,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *feldmanHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	msg := p.getFeldmanComplaintMessage()
+	p.broadcast(msg)
+	return newFeldmanComplaintHandler(p), nil
+}
+
+// verifyFeldmanShare checks the share of bk with the Feldman commitments, and returns u0g of the commitments.
+func verifyFeldmanShare(pointCommitment *commitment.PointCommitmentMessage, bk *birkhoffinterpolation.BkParameter, evaluation []byte, degree uint32) (*ecpointgrouplaw.ECPoint, error) {
+	verify := &commitment.FeldmanVerifyMessage{
+		Evaluation: evaluation,
+	}
+	err := verify.Verify(pointCommitment, bk, degree)
+	if err != nil {
+		return nil, err
+	}
+	return pointCommitment.GetPoints()[0].ToPoint()
+}
@@ -0,0 +1,94 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("feldman handler, negative cases", func() {
+	var (
+		curve     = btcec.S256()
+		threshold = uint32(2)
+		peerId    = getID(1)
+
+		fh    *feldmanHandler
+		other *peerHandler
+	)
+
+	BeforeEach(func() {
+		ph, err := newPedersenPeerHandler(curve, newPeerManager(getID(0), 1), sessionID, threshold, 0)
+		Expect(err).Should(BeNil())
+		other, err = newPedersenPeerHandler(curve, newPeerManager(peerId, 1), sessionID, threshold, 0)
+		Expect(err).Should(BeNil())
+		Expect(ph.HandleMessage(log.Discard(), other.GetPeerMessage())).Should(BeNil())
+		pvh := newPedersenVerifyHandler(ph)
+		Expect(pvh.HandleMessage(log.Discard(), other.getPedersenVerifyMessage(ph.bk))).Should(BeNil())
+		fh = newFeldmanHandler(newRevealHandler(newComplaintHandler(pvh.peerHandler)))
+	})
+
+	Context("IsHandled", func() {
+		It("peer not found", func() {
+			Expect(fh.IsHandled(log.Discard(), "invalid peer")).Should(BeFalse())
+		})
+
+		It("message is handled before", func() {
+			fh.peers[peerId].feldman = &feldmanData{}
+			Expect(fh.IsHandled(log.Discard(), peerId)).Should(BeTrue())
+		})
+
+		It("message is not handled before", func() {
+			Expect(fh.IsHandled(log.Discard(), peerId)).Should(BeFalse())
+		})
+	})
+
+	Context("HandleMessage", func() {
+		It("peer not found", func() {
+			msg := &Message{
+				Id: "invalid peer",
+			}
+			Expect(fh.HandleMessage(log.Discard(), msg)).Should(Equal(tss.ErrPeerNotFound))
+		})
+
+		It("valid Feldman commitment", func() {
+			msg := other.getFeldmanMessage()
+			Expect(fh.HandleMessage(log.Discard(), msg)).Should(BeNil())
+			Expect(fh.peers[peerId].feldman.u0g.Equal(other.u0g)).Should(BeTrue())
+			Expect(fh.peers[peerId].feldman.complaint).Should(BeFalse())
+		})
+
+		It("complains about an inconsistent Feldman commitment", func() {
+			// The Feldman commitment of another polynomial
+			another, err := newPedersenPeerHandler(curve, newPeerManager(peerId, 1), sessionID, threshold, 0)
+			Expect(err).Should(BeNil())
+			msg := another.getFeldmanMessage()
+			Expect(fh.HandleMessage(log.Discard(), msg)).Should(BeNil())
+			Expect(fh.IsHandled(log.Discard(), peerId)).Should(BeTrue())
+			Expect(fh.peers[peerId].feldman.complaint).Should(BeTrue())
+			Expect(fh.getFeldmanComplaintMessage().GetFeldmanComplaint().GetShares()).Should(HaveLen(1))
+		})
+
+		It("complains about a malformed Feldman commitment", func() {
+			msg := other.getFeldmanMessage()
+			msg.GetFeldman().GetPointCommitment().Points = msg.GetFeldman().GetPointCommitment().Points[1:]
+			Expect(fh.HandleMessage(log.Discard(), msg)).Should(BeNil())
+			Expect(fh.peers[peerId].feldman.complaint).Should(BeTrue())
+		})
+	})
+})
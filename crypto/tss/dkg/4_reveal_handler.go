@@ -0,0 +1,173 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"errors"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrInvalidReveal is returned if the reveal message contains unknown or duplicate shares
+	ErrInvalidReveal = errors.New("invalid reveal")
+)
+
+type revealData struct {
+	shares map[string]*RevealedShare
+}
+
+type revealHandler struct {
+	*complaintHandler
+}
+
+func newRevealHandler(p *complaintHandler) *revealHandler {
+	return &revealHandler{
+		complaintHandler: p,
+	}
+}
+
+func (p *revealHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_Reveal)
+}
+
+func (p *revealHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *revealHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.reveal != nil
+}
+
+func (p *revealHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	shares := make(map[string]*RevealedShare)
+	for _, share := range msg.GetReveal().GetShares() {
+		_, ok := p.getBk(share.GetId())
+		if !ok || share.GetId() == id || shares[share.GetId()] != nil {
+			logger.Warn("Invalid revealed share", "accuserID", share.GetId())
+			return blame(ErrInvalidReveal, msg)
+		}
+		shares[share.GetId()] = share
+	}
+	peer.reveal = &revealData{
+		shares: shares,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *revealHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	err := p.disqualify(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reveal the Feldman commitments of the qualified peers in the Pedersen mode
+	if p.isPedersen() {
+		msg := p.getFeldmanMessage()
+		p.broadcast(msg)
+		return newFeldmanHandler(p), nil
+	}
+	return p.finalizeResult(logger)
+}
+
+// disqualify removes the peers which are failed to reveal valid shares for the complaints. Every honest peer
+// has the same complaints and reveals, so they all disqualify the same peers.
+func (p *revealHandler) disqualify(logger log.Logger) error {
+	selfID := p.peerManager.SelfID()
+	complaints := map[string][]string{
+		selfID: p.getAccusedIDs(),
+	}
+	for id, peer := range p.peers {
+		complaints[id] = peer.complaint.accusedIDs
+	}
+
+	disqualified := make(map[string]bool)
+	for accuserID, accusedIDs := range complaints {
+		bk, _ := p.getBk(accuserID)
+		for _, accusedID := range accusedIDs {
+			if accusedID == selfID {
+				// Our revealed shares are always valid
+				continue
+			}
+			accused := p.peers[accusedID]
+			share := accused.reveal.shares[accuserID]
+			if share == nil || p.verifyRevealedShare(accused, share, bk) != nil {
+				logger.Warn("Disqualify the peer", "accuserID", accuserID, "accusedID", accusedID)
+				disqualified[accusedID] = true
+				continue
+			}
+			// Replace our share with the revealed one
+			if accuserID == selfID {
+				if p.isPedersen() {
+					accused.pedersenVerify.verify = &commitment.PedersenVerifyMessage{
+						Evaluation: share.GetEvaluation(),
+						Salt:       share.GetSalt(),
+					}
+				} else {
+					accused.verify.verify = &commitment.FeldmanVerifyMessage{
+						Evaluation: share.GetEvaluation(),
+					}
+				}
+			}
+		}
+	}
+
+	// Remove the disqualified peers and check if the remaining ranks are still valid
+	for id := range disqualified {
+		delete(p.peers, id)
+		p.disqualified[id] = true
+	}
+	p.peerNum = uint32(len(p.peers))
+	err := p.getPeerBks().CheckValid(p.threshold, p.curve.Params().N)
+	if err != nil {
+		logger.Warn("Failed to check bks of the qualified peers", "err", err)
+		return err
+	}
+	return nil
+}
+
+// verifyRevealedShare verifies the share revealed by the accused peer against its commitment.
+func (p *revealHandler) verifyRevealedShare(accused *peer, share *RevealedShare, bk *birkhoffinterpolation.BkParameter) error {
+	if p.isPedersen() {
+		verify := &commitment.PedersenVerifyMessage{
+			Evaluation: share.GetEvaluation(),
+			Salt:       share.GetSalt(),
+		}
+		peerMessage := getMessageByType(accused, Type_Peer)
+		return verify.Verify(peerMessage.GetPeer().GetPedersenCommitment(), p.hiddingPoint, bk, p.threshold-1)
+	}
+	verify := &commitment.FeldmanVerifyMessage{
+		Evaluation: share.GetEvaluation(),
+	}
+	decommitMessage := getMessageByType(accused, Type_Decommit)
+	return verify.Verify(decommitMessage.GetDecommit().GetPointCommitment(), bk, p.threshold-1)
+}
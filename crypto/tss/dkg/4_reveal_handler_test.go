@@ -0,0 +1,185 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"math/big"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("reveal handler, negative cases", func() {
+	var (
+		selfId    = getID(0)
+		accusedId = getID(1)
+
+		chs []*complaintHandler
+		rh  *revealHandler
+	)
+
+	BeforeEach(func() {
+		chs = newComplaintHandlers(2, 3)
+		rh = newRevealHandler(chs[0])
+	})
+
+	Context("IsHandled", func() {
+		It("peer not found", func() {
+			Expect(rh.IsHandled(log.Discard(), "invalid peer")).Should(BeFalse())
+		})
+
+		It("message is handled before", func() {
+			rh.peers[accusedId].reveal = &revealData{}
+			Expect(rh.IsHandled(log.Discard(), accusedId)).Should(BeTrue())
+		})
+
+		It("message is not handled before", func() {
+			Expect(rh.IsHandled(log.Discard(), accusedId)).Should(BeFalse())
+		})
+	})
+
+	Context("HandleMessage", func() {
+		It("peer not found", func() {
+			msg := &Message{
+				Id: "invalid peer",
+			}
+			Expect(rh.HandleMessage(log.Discard(), msg)).Should(Equal(tss.ErrPeerNotFound))
+		})
+
+		It("reveal the share of itself", func() {
+			msg := newRevealMessage(accusedId, accusedId, accusedId)
+			Expect(rh.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidReveal))
+			Expect(rh.IsHandled(log.Discard(), accusedId)).Should(BeFalse())
+		})
+
+		It("reveal the share of unknown peer", func() {
+			msg := newRevealMessage(accusedId, "invalid peer")
+			Expect(rh.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidReveal))
+			Expect(rh.IsHandled(log.Discard(), accusedId)).Should(BeFalse())
+		})
+
+		It("duplicate revealed shares", func() {
+			msg := newRevealMessage(accusedId, selfId, selfId)
+			Expect(rh.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidReveal))
+			Expect(rh.IsHandled(log.Discard(), accusedId)).Should(BeFalse())
+		})
+
+		It("blames the sender of an invalid reveal", func() {
+			msg := newRevealMessage(accusedId, accusedId, accusedId)
+			err := rh.HandleMessage(log.Discard(), msg)
+			expectBlame(err, msg)
+		})
+	})
+
+	Context("disqualify", func() {
+		var expected []byte
+
+		BeforeEach(func() {
+			// Self received a wrong share from the accused peer
+			v := chs[0].peers[accusedId].pedersenVerify
+			expected = v.verify.GetEvaluation()
+			v.verify.Evaluation = new(big.Int).Add(new(big.Int).SetBytes(expected), big.NewInt(1)).Bytes()
+			v.complaint = true
+			exchangeComplaints(chs)
+		})
+
+		It("valid revealed share", func() {
+			handleRevealMessages(rh, getRevealMessages(chs))
+			Expect(rh.disqualify(log.Discard())).Should(BeNil())
+			Expect(rh.peers).Should(HaveLen(2))
+			Expect(rh.peerNum).Should(BeNumerically("==", 2))
+			Expect(rh.disqualified).Should(BeEmpty())
+			// The share is replaced by the revealed one
+			Expect(rh.peers[accusedId].pedersenVerify.verify.GetEvaluation()).Should(Equal(expected))
+		})
+
+		It("invalid revealed share", func() {
+			msgs := getRevealMessages(chs)
+			share := msgs[accusedId].GetReveal().GetShares()[0]
+			share.Evaluation = new(big.Int).Add(new(big.Int).SetBytes(share.GetEvaluation()), big.NewInt(1)).Bytes()
+			handleRevealMessages(rh, msgs)
+			Expect(rh.disqualify(log.Discard())).Should(BeNil())
+			Expect(rh.getPeerIDs()).Should(Equal([]string{getID(2)}))
+			Expect(rh.peerNum).Should(BeNumerically("==", 1))
+			Expect(rh.disqualified).Should(Equal(map[string]bool{accusedId: true}))
+		})
+
+		It("missing revealed share", func() {
+			msgs := getRevealMessages(chs)
+			msgs[accusedId] = newRevealMessage(accusedId)
+			handleRevealMessages(rh, msgs)
+			Expect(rh.disqualify(log.Discard())).Should(BeNil())
+			Expect(rh.getPeerIDs()).Should(Equal([]string{getID(2)}))
+			Expect(rh.disqualified).Should(Equal(map[string]bool{accusedId: true}))
+		})
+
+		It("invalid ranks of the qualified peers", func() {
+			rh.threshold = 3
+			msgs := getRevealMessages(chs)
+			msgs[accusedId] = newRevealMessage(accusedId)
+			handleRevealMessages(rh, msgs)
+			Expect(rh.disqualify(log.Discard())).Should(Equal(birkhoffinterpolation.ErrEqualOrLargerThreshold))
+		})
+	})
+})
+
+func exchangeComplaints(chs []*complaintHandler) {
+	for i, ch := range chs {
+		for j, other := range chs {
+			if i != j {
+				Expect(ch.HandleMessage(log.Discard(), other.getComplaintMessage())).Should(BeNil())
+			}
+		}
+	}
+}
+
+func getRevealMessages(chs []*complaintHandler) map[string]*Message {
+	msgs := make(map[string]*Message, len(chs))
+	for _, ch := range chs {
+		msg := ch.getRevealMessage()
+		msgs[msg.GetId()] = msg
+	}
+	return msgs
+}
+
+func handleRevealMessages(rh *revealHandler, msgs map[string]*Message) {
+	for id, msg := range msgs {
+		if id != rh.peerManager.SelfID() {
+			Expect(rh.HandleMessage(log.Discard(), msg)).Should(BeNil())
+		}
+	}
+}
+
+func newRevealMessage(id string, accuserIDs ...string) *Message {
+	shares := make([]*RevealedShare, len(accuserIDs))
+	for i, accuserID := range accuserIDs {
+		shares[i] = &RevealedShare{
+			Id: accuserID,
+		}
+	}
+	return &Message{
+		Type:      Type_Reveal,
+		Id:        id,
+		SessionId: sessionID,
+		Body: &Message_Reveal{
+			Reveal: &BodyReveal{
+				Shares: shares,
+			},
+		},
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrSelfExtracted is returned if our Feldman commitments are inconsistent with the shares we sent
+	ErrSelfExtracted = errors.New("self extracted")
+)
+
+type feldmanComplaintData struct {
+	accusedIDs []string
+}
+
+type feldmanComplaintHandler struct {
+	*feldmanHandler
+}
+
+func newFeldmanComplaintHandler(p *feldmanHandler) *feldmanComplaintHandler {
+	return &feldmanComplaintHandler{
+		feldmanHandler: p,
+	}
+}
+
+func (p *feldmanComplaintHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_FeldmanComplaint)
+}
+
+// GetRoundPeerIDs returns the ids of the qualified peers
+func (p *feldmanComplaintHandler) GetRoundPeerIDs() []string {
+	return p.getPeerIDs()
+}
+
+func (p *feldmanComplaintHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *feldmanComplaintHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.feldmanComplaint != nil
+}
+
+func (p *feldmanComplaintHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	// A valid complaint reveals the share sent by the accused peer, which is inconsistent with its Feldman commitments
+	accusedIDs := make([]string, 0, len(msg.GetFeldmanComplaint().GetShares()))
+	accused := make(map[string]bool)
+	for _, share := range msg.GetFeldmanComplaint().GetShares() {
+		accusedID := share.GetId()
+		pedersenCommitment, feldmanCommitment, ok := p.getCommitments(accusedID)
+		if !ok || accusedID == id || accused[accusedID] {
+			logger.Warn("Invalid accused peer", "accusedID", accusedID)
+			return blame(ErrInvalidComplaint, msg)
+		}
+		verify := &commitment.PedersenVerifyMessage{
+			Evaluation: share.GetEvaluation(),
+			Salt:       share.GetSalt(),
+		}
+		err := verify.Verify(pedersenCommitment, p.hiddingPoint, peer.peer.bk, p.threshold-1)
+		if err != nil {
+			logger.Warn("Failed to verify the share of the accused peer", "accusedID", accusedID, "err", err)
+			return blame(ErrInvalidComplaint, msg)
+		}
+		_, err = verifyFeldmanShare(feldmanCommitment, peer.peer.bk, share.GetEvaluation(), p.threshold-1)
+		if err == nil {
+			logger.Warn("The share is consistent with the Feldman commitments", "accusedID", accusedID)
+			return blame(ErrInvalidComplaint, msg)
+		}
+		accused[accusedID] = true
+		accusedIDs = append(accusedIDs, accusedID)
+	}
+	peer.feldmanComplaint = &feldmanComplaintData{
+		accusedIDs: accusedIDs,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *feldmanComplaintHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	err := p.extract(logger)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.extracted) == 0 {
+		return p.finalizeResult(logger)
+	}
+
+	// Reveal the shares of the extracted peers to reconstruct their secrets
+	msg := p.getExtractMessage()
+	p.broadcast(msg)
+	return newExtractHandler(p), nil
+}
+
+// extract drops the peers accused by the valid complaints. They stay in the qualified set as GJKR does, so the
+// public key is not changed by them, and their secrets are reconstructed in the extract round.
+func (p *feldmanComplaintHandler) extract(logger log.Logger) error {
+	accused := make(map[string]bool)
+	for id, peer := range p.peers {
+		if peer.feldman.complaint {
+			accused[id] = true
+		}
+		for _, accusedID := range peer.feldmanComplaint.accusedIDs {
+			accused[accusedID] = true
+		}
+	}
+	if accused[p.peerManager.SelfID()] {
+		logger.Warn("Our Feldman commitments are inconsistent with the shares")
+		return ErrSelfExtracted
+	}
+	ids := make([]string, 0, len(accused))
+	for id := range accused {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		logger.Warn("Extract the secret of the peer", "id", id)
+		p.extracted[id] = p.peers[id]
+		delete(p.peers, id)
+	}
+	p.peerNum = uint32(len(p.peers))
+
+	// The secrets of the extracted peers are reconstructed by the remaining peers
+	err := p.getPeerBks().CheckValid(p.threshold, p.curve.Params().N)
+	if err != nil {
+		logger.Warn("Failed to check bks of the remaining peers", "err", err)
+		return err
+	}
+	return nil
+}
+
+// getCommitments returns the Pedersen and Feldman commitments of the participant, including self
+func (p *feldmanComplaintHandler) getCommitments(id string) (*commitment.PointCommitmentMessage, *commitment.PointCommitmentMessage, bool) {
+	if id == p.peerManager.SelfID() {
+		return p.pedersenCommitmenter.GetCommitmentMessage(), p.feldmanCommitmenter.GetCommitmentMessage(), true
+	}
+	peer, ok := p.peers[id]
+	if !ok {
+		return nil, nil, false
+	}
+	peerMessage := getMessageByType(peer, Type_Peer)
+	feldmanMessage := getMessageByType(peer, Type_Feldman)
+	return peerMessage.GetPeer().GetPedersenCommitment(), feldmanMessage.GetFeldman().GetPointCommitment(), true
+}
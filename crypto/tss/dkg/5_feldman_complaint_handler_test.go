@@ -0,0 +1,209 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"math/big"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/polynomial"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("feldman complaint handler, negative cases", func() {
+	var (
+		selfId    = getID(0)
+		badId     = getID(1)
+		accuserId = getID(2)
+	)
+
+	Context("IsHandled", func() {
+		var fch *feldmanComplaintHandler
+
+		BeforeEach(func() {
+			fch = newFeldmanComplaintHandlers(2, 3, "")[0]
+		})
+
+		It("peer not found", func() {
+			Expect(fch.IsHandled(log.Discard(), "invalid peer")).Should(BeFalse())
+		})
+
+		It("message is handled before", func() {
+			fch.peers[badId].feldmanComplaint = &feldmanComplaintData{}
+			Expect(fch.IsHandled(log.Discard(), badId)).Should(BeTrue())
+		})
+
+		It("message is not handled before", func() {
+			Expect(fch.IsHandled(log.Discard(), badId)).Should(BeFalse())
+		})
+	})
+
+	Context("HandleMessage", func() {
+		var fchs []*feldmanComplaintHandler
+
+		BeforeEach(func() {
+			fchs = newFeldmanComplaintHandlers(2, 3, badId)
+		})
+
+		It("peer not found", func() {
+			msg := &Message{
+				Id: "invalid peer",
+			}
+			Expect(fchs[0].HandleMessage(log.Discard(), msg)).Should(Equal(tss.ErrPeerNotFound))
+		})
+
+		It("valid complaint", func() {
+			msg := fchs[2].getFeldmanComplaintMessage()
+			Expect(fchs[0].HandleMessage(log.Discard(), msg)).Should(BeNil())
+			Expect(fchs[0].peers[accuserId].feldmanComplaint.accusedIDs).Should(Equal([]string{badId}))
+		})
+
+		It("accuse itself", func() {
+			msg := fchs[2].getFeldmanComplaintMessage()
+			msg.GetFeldmanComplaint().GetShares()[0].Id = accuserId
+			Expect(fchs[0].HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidComplaint))
+			Expect(fchs[0].IsHandled(log.Discard(), accuserId)).Should(BeFalse())
+		})
+
+		It("accuse unknown peer", func() {
+			msg := fchs[2].getFeldmanComplaintMessage()
+			msg.GetFeldmanComplaint().GetShares()[0].Id = "invalid peer"
+			Expect(fchs[0].HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidComplaint))
+		})
+
+		It("duplicate accused peers", func() {
+			msg := fchs[2].getFeldmanComplaintMessage()
+			shares := msg.GetFeldmanComplaint().GetShares()
+			msg.GetFeldmanComplaint().Shares = append(shares, shares[0])
+			Expect(fchs[0].HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidComplaint))
+		})
+
+		It("share inconsistent with the Pedersen commitments", func() {
+			msg := fchs[2].getFeldmanComplaintMessage()
+			share := msg.GetFeldmanComplaint().GetShares()[0]
+			share.Evaluation = new(big.Int).Add(new(big.Int).SetBytes(share.GetEvaluation()), big.NewInt(1)).Bytes()
+			Expect(fchs[0].HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidComplaint))
+		})
+
+		It("share consistent with the Feldman commitments", func() {
+			// Accuse self with the valid share sent by self
+			verify := fchs[0].pedersenCommitmenter.GetVerifyMessage(fchs[2].bk)
+			msg := newFeldmanComplaintMessage(accuserId, &RevealedShare{
+				Id:         selfId,
+				Evaluation: verify.GetEvaluation(),
+				Salt:       verify.GetSalt(),
+			})
+			Expect(fchs[0].HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidComplaint))
+		})
+
+		It("blames the sender of an invalid complaint", func() {
+			msg := fchs[2].getFeldmanComplaintMessage()
+			msg.GetFeldmanComplaint().GetShares()[0].Id = "invalid peer"
+			err := fchs[0].HandleMessage(log.Discard(), msg)
+			expectBlame(err, msg)
+		})
+	})
+
+	Context("extract", func() {
+		It("no complaint", func() {
+			fchs := newFeldmanComplaintHandlers(2, 3, "")
+			exchangeFeldmanComplaints(fchs)
+			Expect(fchs[0].extract(log.Discard())).Should(BeNil())
+			Expect(fchs[0].peers).Should(HaveLen(2))
+			Expect(fchs[0].extracted).Should(BeEmpty())
+		})
+
+		It("extracts the accused peer", func() {
+			fchs := newFeldmanComplaintHandlers(2, 3, badId)
+			exchangeFeldmanComplaints(fchs)
+			Expect(fchs[0].extract(log.Discard())).Should(BeNil())
+			Expect(fchs[0].getPeerIDs()).Should(Equal([]string{accuserId}))
+			Expect(fchs[0].peerNum).Should(BeNumerically("==", 1))
+			Expect(fchs[0].extracted).Should(HaveKey(badId))
+			Expect(fchs[0].getExtractMessage().GetExtract().GetShares()).Should(HaveLen(1))
+		})
+
+		It("self extracted", func() {
+			fchs := newFeldmanComplaintHandlers(2, 3, selfId)
+			exchangeFeldmanComplaints(fchs)
+			Expect(fchs[0].extract(log.Discard())).Should(Equal(ErrSelfExtracted))
+		})
+
+		It("invalid ranks of the remaining peers", func() {
+			fchs := newFeldmanComplaintHandlers(3, 3, badId)
+			exchangeFeldmanComplaints(fchs)
+			Expect(fchs[0].extract(log.Discard())).Should(Equal(birkhoffinterpolation.ErrEqualOrLargerThreshold))
+		})
+	})
+})
+
+// newFeldmanComplaintHandlers news the Feldman complaint handlers after the Feldman round without disqualified
+// peers. The Feldman commitments of the bad peer are the ones of another polynomial.
+func newFeldmanComplaintHandlers(threshold uint32, n int, badID string) []*feldmanComplaintHandler {
+	chs := newComplaintHandlers(threshold, n)
+	exchangeComplaints(chs)
+	msgs := getRevealMessages(chs)
+	fhs := make([]*feldmanHandler, n)
+	for i, ch := range chs {
+		ch.peerManager = tss.NewSilentPeerManager(ch.peerManager)
+		rh := newRevealHandler(ch)
+		handleRevealMessages(rh, msgs)
+		Expect(rh.disqualify(log.Discard())).Should(BeNil())
+		if ch.peerManager.SelfID() == badID {
+			poly, err := polynomial.RandomPolynomial(ch.curve.Params().N, threshold-1)
+			Expect(err).Should(BeNil())
+			ch.feldmanCommitmenter, err = commitment.NewFeldmanCommitmenter(ch.curve, poly)
+			Expect(err).Should(BeNil())
+		}
+		fhs[i] = newFeldmanHandler(rh)
+	}
+	fchs := make([]*feldmanComplaintHandler, n)
+	for i, fh := range fhs {
+		for j, other := range fhs {
+			if i != j {
+				Expect(fh.HandleMessage(log.Discard(), other.getFeldmanMessage())).Should(BeNil())
+			}
+		}
+		fchs[i] = newFeldmanComplaintHandler(fh)
+	}
+	return fchs
+}
+
+func exchangeFeldmanComplaints(fchs []*feldmanComplaintHandler) {
+	for i, fch := range fchs {
+		for j, other := range fchs {
+			if i != j {
+				Expect(fch.HandleMessage(log.Discard(), other.getFeldmanComplaintMessage())).Should(BeNil())
+			}
+		}
+	}
+}
+
+func newFeldmanComplaintMessage(id string, shares ...*RevealedShare) *Message {
+	return &Message{
+		Type:      Type_FeldmanComplaint,
+		Id:        id,
+		SessionId: sessionID,
+		Body: &Message_FeldmanComplaint{
+			FeldmanComplaint: &BodyFeldmanComplaint{
+				Shares: shares,
+			},
+		},
+	}
+}
@@ -23,7 +23,8 @@ import (
 )
 
 type resultData struct {
-	result *ecpointgrouplaw.ECPoint
+	result    *ecpointgrouplaw.ECPoint
+	chainCode []byte
 }
 
 type resultHandler struct {
@@ -40,6 +41,11 @@ func (p *resultHandler) MessageType() types.MessageType {
 	return types.MessageType(Type_Result)
 }
 
+// GetRoundPeerIDs returns the ids of the qualified peers
+func (p *resultHandler) GetRoundPeerIDs() []string {
+	return p.getPeerIDs()
+}
+
 func (p *resultHandler) GetRequiredMessageCount() uint32 {
 	return p.peerNum
 }
@@ -66,15 +72,26 @@ func (p *resultHandler) HandleMessage(logger log.Logger, message types.Message)
 	r, err := siGProofMsg.V.ToPoint()
 	if err != nil {
 		logger.Warn("Failed to get point", "err", err)
-		return err
+		return blame(err, msg)
 	}
-	err = siGProofMsg.Verify(ecpointgrouplaw.NewBase(p.publicKey.GetCurve()))
+	err = siGProofMsg.VerifyWithSession(p.sessionID, ecpointgrouplaw.NewBase(p.publicKey.GetCurve()))
 	if err != nil {
 		logger.Warn("Failed to verify Schorr proof", "err", err)
-		return err
+		return blame(err, msg)
+	}
+	chainCodeDecommitment := msg.GetResult().GetChainCodeDecommitment()
+	if len(chainCodeDecommitment.GetData()) != ChainCodeSize {
+		logger.Warn("Invalid chain code length", "got", len(chainCodeDecommitment.GetData()), "expected", ChainCodeSize)
+		return blame(ErrInvalidChainCode, msg)
+	}
+	err = peer.peer.chainCodeCommitment.DecommitWithSession(p.sessionID, chainCodeDecommitment)
+	if err != nil {
+		logger.Warn("Failed to decommit chain code", "err", err)
+		return blame(err, msg)
 	}
 	peer.result = &resultData{
-		result: r,
+		result:    r,
+		chainCode: chainCodeDecommitment.GetData(),
 	}
 	return peer.AddMessage(msg)
 }
@@ -97,3 +114,27 @@ func (p *resultHandler) Finalize(logger log.Logger) (types.Handler, error) {
 	}
 	return nil, tss.ValidatePublicKey(logger, bks, sgs, p.threshold, p.publicKey)
 }
+
+// getPublicShares returns the public shares of self and the peers
+func (p *resultHandler) getPublicShares() (map[string]*ecpointgrouplaw.ECPoint, error) {
+	siG, err := p.siGProofMsg.V.ToPoint()
+	if err != nil {
+		return nil, err
+	}
+	publicShares := make(map[string]*ecpointgrouplaw.ECPoint, len(p.peers)+1)
+	publicShares[p.peerManager.SelfID()] = siG
+	for id, peer := range p.peers {
+		publicShares[id] = peer.result.result
+	}
+	return publicShares, nil
+}
+
+// getChainCode returns the chain code built by the contributions of self and the qualified peers
+func (p *resultHandler) getChainCode() []byte {
+	contributions := make(map[string][]byte, len(p.peers)+1)
+	contributions[p.peerManager.SelfID()] = p.chainCodeCommiter.GetDecommitmentMessage().GetData()
+	for id, peer := range p.peers {
+		contributions[id] = peer.result.chainCode
+	}
+	return buildChainCode(contributions)
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/commitment"
 	"github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/matrix"
 	"github.com/getamis/alice/crypto/tss"
@@ -130,7 +131,7 @@ var _ = Describe("result handler, negative cases", func() {
 				Expect(ok).Should(BeTrue())
 
 				if msg != nil {
-					Expect(rh.HandleMessage(log.Discard(), msg)).Should(Equal(ecpointgrouplaw.ErrInvalidPoint))
+					Expect(rh.HandleMessage(log.Discard(), msg)).Should(MatchError(ecpointgrouplaw.ErrInvalidPoint))
 				}
 				msg = rh.getResultMessage()
 				r := msg.GetResult()
@@ -148,7 +149,7 @@ var _ = Describe("result handler, negative cases", func() {
 				Expect(ok).Should(BeTrue())
 
 				if msg != nil {
-					Expect(rh.HandleMessage(log.Discard(), msg)).Should(Equal(zkproof.ErrVerifyFailure))
+					Expect(rh.HandleMessage(log.Discard(), msg)).Should(MatchError(zkproof.ErrVerifyFailure))
 				}
 				msg = rh.getResultMessage()
 				r := msg.GetResult()
@@ -159,6 +160,49 @@ var _ = Describe("result handler, negative cases", func() {
 			}
 		})
 
+		It("invalid chain code length", func() {
+			var msg *Message
+			for _, d := range dkgs {
+				rh, ok := d.GetHandler().(*resultHandler)
+				Expect(ok).Should(BeTrue())
+
+				if msg != nil {
+					Expect(rh.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidChainCode))
+				}
+				msg = rh.getResultMessage()
+				msg.GetResult().ChainCodeDecommitment.Data = []byte("invalid chain code")
+			}
+		})
+
+		It("invalid chain code decommitment", func() {
+			var msg *Message
+			for _, d := range dkgs {
+				rh, ok := d.GetHandler().(*resultHandler)
+				Expect(ok).Should(BeTrue())
+
+				if msg != nil {
+					Expect(rh.HandleMessage(log.Discard(), msg)).Should(MatchError(commitment.ErrDifferentDigest))
+				}
+				msg = rh.getResultMessage()
+				msg.GetResult().ChainCodeDecommitment.Data = make([]byte, ChainCodeSize)
+			}
+		})
+
+		It("blames the sender of an invalid verify", func() {
+			var msg *Message
+			for _, d := range dkgs {
+				rh, ok := d.GetHandler().(*resultHandler)
+				Expect(ok).Should(BeTrue())
+
+				if msg != nil {
+					err := rh.HandleMessage(log.Discard(), msg)
+					expectBlame(err, msg)
+				}
+				msg = rh.getResultMessage()
+				msg.GetResult().SiGProofMsg.U = []byte("invalid U")
+			}
+		})
+
 		It("invalid self V", func() {
 			for _, d := range dkgs {
 				rh, ok := d.GetHandler().(*resultHandler)
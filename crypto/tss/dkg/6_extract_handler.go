@@ -0,0 +1,128 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrInvalidExtract is returned if the extract message contains unknown, duplicate or missing shares
+	ErrInvalidExtract = errors.New("invalid extract")
+)
+
+type extractData struct {
+	shares map[string]*RevealedShare
+}
+
+type extractHandler struct {
+	*feldmanComplaintHandler
+}
+
+func newExtractHandler(p *feldmanComplaintHandler) *extractHandler {
+	return &extractHandler{
+		feldmanComplaintHandler: p,
+	}
+}
+
+func (p *extractHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_Extract)
+}
+
+// GetRoundPeerIDs returns the ids of the qualified peers except the extracted ones
+func (p *extractHandler) GetRoundPeerIDs() []string {
+	return p.getPeerIDs()
+}
+
+func (p *extractHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *extractHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.extract != nil
+}
+
+func (p *extractHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	// The shares of all the extracted peers must be revealed and verified by their Pedersen commitments
+	shares := make(map[string]*RevealedShare, len(p.extracted))
+	for _, share := range msg.GetExtract().GetShares() {
+		extracted, ok := p.extracted[share.GetId()]
+		if !ok || shares[share.GetId()] != nil {
+			logger.Warn("Invalid extracted share", "extractedID", share.GetId())
+			return blame(ErrInvalidExtract, msg)
+		}
+		verify := &commitment.PedersenVerifyMessage{
+			Evaluation: share.GetEvaluation(),
+			Salt:       share.GetSalt(),
+		}
+		peerMessage := getMessageByType(extracted, Type_Peer)
+		err := verify.Verify(peerMessage.GetPeer().GetPedersenCommitment(), p.hiddingPoint, peer.peer.bk, p.threshold-1)
+		if err != nil {
+			logger.Warn("Failed to verify the extracted share", "extractedID", share.GetId(), "err", err)
+			return blame(err, msg)
+		}
+		shares[share.GetId()] = share
+	}
+	if len(shares) != len(p.extracted) {
+		logger.Warn("Missing extracted shares", "got", len(shares), "expected", len(p.extracted))
+		return blame(ErrInvalidExtract, msg)
+	}
+	peer.extract = &extractData{
+		shares: shares,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *extractHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	// Reconstruct u0g of the extracted peers by Birkhoff interpolation of the verified shares
+	fieldOrder := p.curve.Params().N
+	coefficients, err := p.getPeerBks().ComputeBkCoefficient(p.threshold, fieldOrder)
+	if err != nil {
+		logger.Warn("Failed to compute bk coefficients", "err", err)
+		return nil, err
+	}
+	ids := p.getPeerIDs()
+	for extractedID, extracted := range p.extracted {
+		evaluation := new(big.Int).SetBytes(extracted.pedersenVerify.verify.GetEvaluation())
+		u0 := new(big.Int).Mul(coefficients[0], evaluation)
+		for i, id := range ids {
+			evaluation := new(big.Int).SetBytes(p.peers[id].extract.shares[extractedID].GetEvaluation())
+			u0.Add(u0, new(big.Int).Mul(coefficients[i+1], evaluation))
+		}
+		u0.Mod(u0, fieldOrder)
+		extracted.feldman.u0g = ecpointgrouplaw.ScalarBaseMult(p.curve, u0)
+	}
+	return p.finalizeResult(logger)
+}
@@ -0,0 +1,134 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"math/big"
+
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("extract handler, negative cases", func() {
+	var (
+		badId   = getID(1)
+		otherId = getID(2)
+
+		fchs []*feldmanComplaintHandler
+		ehs  map[string]*extractHandler
+	)
+
+	BeforeEach(func() {
+		fchs = newFeldmanComplaintHandlers(2, 4, badId)
+		exchangeFeldmanComplaints(fchs)
+		ehs = make(map[string]*extractHandler, len(fchs)-1)
+		for _, fch := range fchs {
+			id := fch.peerManager.SelfID()
+			if id == badId {
+				continue
+			}
+			Expect(fch.extract(log.Discard())).Should(BeNil())
+			ehs[id] = newExtractHandler(fch)
+		}
+	})
+
+	Context("IsHandled", func() {
+		It("peer not found", func() {
+			Expect(ehs[getID(0)].IsHandled(log.Discard(), badId)).Should(BeFalse())
+		})
+
+		It("message is handled before", func() {
+			ehs[getID(0)].peers[otherId].extract = &extractData{}
+			Expect(ehs[getID(0)].IsHandled(log.Discard(), otherId)).Should(BeTrue())
+		})
+
+		It("message is not handled before", func() {
+			Expect(ehs[getID(0)].IsHandled(log.Discard(), otherId)).Should(BeFalse())
+		})
+	})
+
+	Context("HandleMessage/Finalize", func() {
+		It("peer not found", func() {
+			msg := newExtractMessage(badId)
+			Expect(ehs[getID(0)].HandleMessage(log.Discard(), msg)).Should(Equal(tss.ErrPeerNotFound))
+		})
+
+		It("reconstructs u0g of the extracted peer", func() {
+			for id, eh := range ehs {
+				for otherID, other := range ehs {
+					if id != otherID {
+						Expect(eh.HandleMessage(log.Discard(), other.getExtractMessage())).Should(BeNil())
+					}
+				}
+			}
+			eh := ehs[getID(0)]
+			h, err := eh.Finalize(log.Discard())
+			Expect(err).Should(BeNil())
+			Expect(h).ShouldNot(BeNil())
+			Expect(eh.extracted[badId].feldman.u0g.Equal(fchs[1].u0g)).Should(BeTrue())
+			// The extracted peer still contributes to the public key
+			expected := fchs[0].u0g
+			for _, fch := range fchs[1:] {
+				expected, err = expected.Add(fch.u0g)
+				Expect(err).Should(BeNil())
+			}
+			Expect(h.(*resultHandler).publicKey.Equal(expected)).Should(BeTrue())
+		})
+
+		It("share of unknown peer", func() {
+			msg := ehs[otherId].getExtractMessage()
+			msg.GetExtract().GetShares()[0].Id = otherId
+			Expect(ehs[getID(0)].HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidExtract))
+			Expect(ehs[getID(0)].IsHandled(log.Discard(), otherId)).Should(BeFalse())
+		})
+
+		It("duplicate shares", func() {
+			msg := ehs[otherId].getExtractMessage()
+			shares := msg.GetExtract().GetShares()
+			msg.GetExtract().Shares = append(shares, shares[0])
+			Expect(ehs[getID(0)].HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidExtract))
+		})
+
+		It("missing shares", func() {
+			msg := newExtractMessage(otherId)
+			Expect(ehs[getID(0)].HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInvalidExtract))
+		})
+
+		It("invalid share", func() {
+			msg := ehs[otherId].getExtractMessage()
+			share := msg.GetExtract().GetShares()[0]
+			share.Evaluation = new(big.Int).Add(new(big.Int).SetBytes(share.GetEvaluation()), big.NewInt(1)).Bytes()
+			err := ehs[getID(0)].HandleMessage(log.Discard(), msg)
+			Expect(err).Should(MatchError(commitment.ErrFailedVerify))
+			expectBlame(err, msg)
+		})
+	})
+})
+
+func newExtractMessage(id string, shares ...*RevealedShare) *Message {
+	return &Message{
+		Type:      Type_Extract,
+		Id:        id,
+		SessionId: sessionID,
+		Body: &Message_Extract{
+			Extract: &BodyExtract{
+				Shares: shares,
+			},
+		},
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"crypto/elliptic"
+	"errors"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrInvalidBatchSize is returned if the batch size is zero
+	ErrInvalidBatchSize = errors.New("invalid batch size")
+	// ErrInconsistentBatchSize is returned if the number of the elements in a batch message is not the batch size
+	ErrInconsistentBatchSize = errors.New("inconsistent batch size")
+	// ErrUnidentifiableShare is returned if a peer sends an invalid share in batch DKG. The shares are sent privately
+	// and there are no complaint rounds to reveal them, so the sender cannot be blamed with a public evidence.
+	ErrUnidentifiableShare = errors.New("invalid share without complaint rounds")
+	// ErrBatchCheckpointNotSupported is returned if a checkpoint of batch DKG is requested
+	ErrBatchCheckpointNotSupported = errors.New("batch dkg does not support checkpoints")
+)
+
+// BatchDKG generates a batch of independent keys with the same threshold and ranks in one session. The
+// messages of all the keys are sent together in each round, so the number of rounds does not grow with the
+// batch size. Different from NewDKG, batch DKG is not identifiable. There are no complaint and reveal rounds, so a
+// peer sending an invalid share aborts the process with ErrUnidentifiableShare instead of being disqualified, and
+// the process cannot be checkpointed. Use NewDKG for each key if identifiable abort or checkpoints are required.
+type BatchDKG struct {
+	ph *batchPeerHandler
+	*message.MsgMain
+}
+
+func NewBatchDKG(curve elliptic.Curve, peerManager types.PeerManager, sessionID []byte, threshold uint32, rank uint32, batchSize uint32, listener types.StateChangedListener) (*BatchDKG, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	if batchSize == 0 {
+		return nil, ErrInvalidBatchSize
+	}
+	peerNum := peerManager.NumPeers()
+	if err := ensureRandAndThreshold(rank, threshold, peerNum); err != nil {
+		return nil, err
+	}
+	ph, err := newBatchPeerHandler(curve, peerManager, sessionID, threshold, rank, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchDKG{
+		ph:      ph,
+		MsgMain: message.NewMsgMain(peerManager, sessionID, listener, ph, types.MessageType(Type_BatchPeer), types.MessageType(Type_BatchDecommit), types.MessageType(Type_BatchVerify), types.MessageType(Type_BatchResult)),
+	}, nil
+}
+
+// GetResults returns the results of the keys in the batch. The results are in the same order for all the
+// participants.
+func (d *BatchDKG) GetResults() ([]*Result, error) {
+	if d.GetState() != types.StateDone {
+		return nil, tss.ErrNotReady
+	}
+
+	h := d.GetHandler()
+	rh, ok := h.(*batchResultHandler)
+	if !ok {
+		log.Error("We cannot convert to batch result handler in done state")
+		return nil, tss.ErrNotReady
+	}
+
+	results := make([]*Result, len(d.ph.keys))
+	for i := range results {
+		bks := make(map[string]*birkhoffinterpolation.BkParameter, d.ph.peerNum+1)
+		bks[d.ph.peerManager.SelfID()] = d.ph.bk
+		for id, peer := range d.ph.peers {
+			bks[id] = peer.peer.bk
+		}
+		publicShares, err := rh.getPublicShares(i)
+		if err != nil {
+			log.Error("Failed to get public shares", "err", err)
+			return nil, err
+		}
+		results[i] = &Result{
+			PublicKey:    rh.publicKeys[i],
+			Share:        rh.shares[i],
+			Bks:          bks,
+			PublicShares: publicShares,
+			Disqualified: []string{},
+			ChainCode:    rh.getChainCode(i),
+		}
+	}
+	return results, nil
+}
+
+// Checkpoint always returns ErrBatchCheckpointNotSupported. Batch DKG keeps no state for the complaint rounds
+// to restore, so an interrupted batch should be started over with a new session.
+func (d *BatchDKG) Checkpoint(key []byte) ([]byte, error) {
+	return nil, ErrBatchCheckpointNotSupported
+}
+
+func (d *BatchDKG) GetPeerMessage() *Message {
+	return d.ph.GetPeerMessage()
+}
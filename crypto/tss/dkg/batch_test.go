@@ -0,0 +1,255 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/sirius/log"
+	proto "github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("BatchDKG", func() {
+	curve := btcec.S256()
+
+	DescribeTable("NewBatchDKG()", func(c elliptic.Curve, threshold uint32, ranks []uint32, batchSize uint32) {
+		dkgs, listeners := newBatchDKGs(c, threshold, ranks, batchSize)
+		doneChs := make(map[string]chan struct{}, len(listeners))
+		for id, l := range listeners {
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+		// Send out peer message
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			Expect(msg.GetBatchPeer().GetCommitments()).Should(HaveLen(int(batchSize)))
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		results := make(map[string][]*Result, len(dkgs))
+		for id, d := range dkgs {
+			d.Stop()
+			rs, err := d.GetResults()
+			Expect(err).Should(BeNil())
+			Expect(rs).Should(HaveLen(int(batchSize)))
+			results[id] = rs
+		}
+		publicKeys := make(map[string]bool, batchSize)
+		for i := 0; i < int(batchSize); i++ {
+			// Build the public key of the i-th key
+			secret := big.NewInt(0)
+			for _, d := range dkgs {
+				secret = new(big.Int).Add(secret, d.ph.keys[i].poly.Get(0))
+			}
+			pubkey := ecpointgrouplaw.ScalarBaseMult(c, secret)
+			publicKeys[pubkey.String()] = true
+
+			bks := make(birkhoffinterpolation.BkParameters, 0, len(dkgs))
+			sgs := make([]*ecpointgrouplaw.ECPoint, 0, len(dkgs))
+			for id, rs := range results {
+				r := rs[i]
+				Expect(r.PublicKey.Equal(pubkey)).Should(BeTrue())
+				Expect(r.Bks).Should(HaveLen(len(dkgs)))
+				Expect(r.ChainCode).Should(Equal(results[getID(0)][i].ChainCode))
+				Expect(r.PublicShares[id].Equal(ecpointgrouplaw.ScalarBaseMult(c, r.Share))).Should(BeTrue())
+				Expect(tss.ValidatePublicShares(log.Discard(), r.Bks, r.PublicShares, threshold, r.PublicKey)).Should(BeNil())
+				bks = append(bks, r.Bks[id])
+				sgs = append(sgs, ecpointgrouplaw.ScalarBaseMult(c, r.Share))
+			}
+			// The shares could recover the public key
+			Expect(tss.ValidatePublicKey(log.Discard(), bks, sgs, threshold, pubkey)).Should(BeNil())
+		}
+		// The keys are independent
+		Expect(publicKeys).Should(HaveLen(int(batchSize)))
+
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	},
+		Entry("Case #0", curve, uint32(3), []uint32{0, 0, 0, 0, 0}, uint32(1)),
+		Entry("Case #1", curve, uint32(3), []uint32{0, 0, 1, 1, 1}, uint32(5)),
+		Entry("Case #2", elliptic.P256(), uint32(3), []uint32{0, 0, 1, 1}, uint32(3)),
+		Entry("Ed25519", ecpointgrouplaw.Edwards25519(), uint32(3), []uint32{0, 0, 1, 1}, uint32(3)),
+	)
+
+	It("aborts if a share is failed to verify", func() {
+		badID, victimID := getID(0), getID(1)
+		dkgs, listeners := newBatchDKGs(curve, 3, []uint32{0, 0, 0, 0}, 3)
+		// The bad peer sends a wrong share of the last key to the victim
+		dkgs[badID].ph.peerManager = &batchTamperPeerManager{
+			batchPeerManager: dkgs[badID].ph.peerManager.(*batchPeerManager),
+			tamper: func(id string, msg *Message) {
+				if msg.Type == Type_BatchVerify && id == victimID {
+					verify := msg.GetBatchVerify().GetVerifies()[2]
+					verify.Evaluation = addOne(verify.GetEvaluation())
+				}
+			},
+		}
+		failedCh := make(chan struct{})
+		for id, l := range listeners {
+			if id == victimID {
+				l.On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+					close(failedCh)
+				}).Once()
+				continue
+			}
+			l.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+		}
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		<-failedCh
+
+		// The others are waiting for the result message of the victim until they are stopped
+		for _, d := range dkgs {
+			d.Stop()
+		}
+		time.Sleep(500 * time.Millisecond)
+		for _, d := range dkgs {
+			rs, err := d.GetResults()
+			Expect(err).Should(Equal(tss.ErrNotReady))
+			Expect(rs).Should(BeNil())
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("does not support checkpoints", func() {
+		d, err := NewBatchDKG(curve, newBatchPeerManager(getID(0), 2), sessionID, 2, 0, 1, new(mocks.StateChangedListener))
+		Expect(err).Should(BeNil())
+		c, err := d.Checkpoint(make([]byte, tss.CheckpointKeySize))
+		Expect(err).Should(Equal(ErrBatchCheckpointNotSupported))
+		Expect(c).Should(BeNil())
+	})
+
+	Context("NewBatchDKG(), negative cases", func() {
+		It("invalid batch size", func() {
+			d, err := NewBatchDKG(curve, newBatchPeerManager(getID(0), 2), sessionID, 2, 0, 0, new(mocks.StateChangedListener))
+			Expect(err).Should(Equal(ErrInvalidBatchSize))
+			Expect(d).Should(BeNil())
+		})
+
+		It("empty session id", func() {
+			d, err := NewBatchDKG(curve, newBatchPeerManager(getID(0), 2), nil, 2, 0, 1, new(mocks.StateChangedListener))
+			Expect(err).Should(Equal(tss.ErrEmptySessionID))
+			Expect(d).Should(BeNil())
+		})
+
+		It("large threshold", func() {
+			d, err := NewBatchDKG(curve, newBatchPeerManager(getID(0), 1), sessionID, 3, 0, 1, new(mocks.StateChangedListener))
+			Expect(err).ShouldNot(BeNil())
+			Expect(d).Should(BeNil())
+		})
+	})
+})
+
+type batchPeerManager struct {
+	id       string
+	numPeers uint32
+	dkgs     map[string]*BatchDKG
+}
+
+func newBatchPeerManager(id string, numPeers int) *batchPeerManager {
+	return &batchPeerManager{
+		id:       id,
+		numPeers: uint32(numPeers),
+	}
+}
+
+func (p *batchPeerManager) NumPeers() uint32 {
+	return p.numPeers
+}
+
+func (p *batchPeerManager) SelfID() string {
+	return p.id
+}
+
+func (p *batchPeerManager) PeerIDs() []string {
+	ids := make([]string, 0, len(p.dkgs))
+	for id := range p.dkgs {
+		if id != p.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (p *batchPeerManager) MustSend(id string, message proto.Message) {
+	// The messages may be rejected if the receiver has failed
+	_ = p.dkgs[id].AddMessage(message.(types.Message))
+}
+
+// batchTamperPeerManager tampers the messages before sending them out
+type batchTamperPeerManager struct {
+	*batchPeerManager
+
+	tamper func(id string, msg *Message)
+}
+
+func (p *batchTamperPeerManager) MustSend(id string, message proto.Message) {
+	msg := proto.Clone(message).(*Message)
+	p.tamper(id, msg)
+	p.batchPeerManager.MustSend(id, msg)
+}
+
+func newBatchDKGs(curve elliptic.Curve, threshold uint32, ranks []uint32, batchSize uint32) (map[string]*BatchDKG, map[string]*mocks.StateChangedListener) {
+	lens := len(ranks)
+	dkgs := make(map[string]*BatchDKG, lens)
+	listeners := make(map[string]*mocks.StateChangedListener, lens)
+	for i := 0; i < lens; i++ {
+		id := getID(i)
+		pm := newBatchPeerManager(id, lens-1)
+		pm.dkgs = dkgs
+		listeners[id] = new(mocks.StateChangedListener)
+		var err error
+		dkgs[id], err = NewBatchDKG(curve, pm, sessionID, threshold, ranks[i], batchSize, listeners[id])
+		Expect(err).Should(BeNil())
+		rs, err := dkgs[id].GetResults()
+		Expect(rs).Should(BeNil())
+		Expect(err).Should(Equal(tss.ErrNotReady))
+		dkgs[id].Start()
+	}
+	return dkgs, listeners
+}
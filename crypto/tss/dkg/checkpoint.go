@@ -0,0 +1,238 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"math/big"
+
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/polynomial"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+const checkpointLabel = "dkg"
+
+// Checkpoint returns the encrypted state of the process, including the private polynomial and the
+// collected messages. It could be restored by RestoreDKG after the process crashes.
+func (d *DKG) Checkpoint(key []byte) ([]byte, error) {
+	var state *State
+	err := d.Snapshot(func(handler types.Handler, msgs []types.Message, echoes []*message.EchoMessage) error {
+		var err error
+		state, err = d.ph.getState(handler, msgs, echoes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tss.EncryptCheckpoint(key, checkpointLabel, state)
+}
+
+// RestoreDKG restores the process from the checkpoint. The process continues from the round in which
+// the checkpoint was made. The peer manager must manage the same peers as before.
+func RestoreDKG(peerManager types.PeerManager, key []byte, checkpoint []byte, listener types.StateChangedListener) (*DKG, error) {
+	state := &State{}
+	err := tss.DecryptCheckpoint(key, checkpointLabel, checkpoint, state)
+	if err != nil {
+		return nil, err
+	}
+	ph, handler, err := restoreHandler(peerManager, state)
+	if err != nil {
+		return nil, err
+	}
+	d := newDKGWithCurrentHandler(peerManager, state.SessionId, listener, ph, handler)
+	msgs := make([]types.Message, len(state.Messages))
+	for i, msg := range state.Messages {
+		msgs[i] = msg
+	}
+	err = d.Restore(msgs, state.Echoes)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (p *peerHandler) getState(handler types.Handler, msgs []types.Message, echoes []*message.EchoMessage) (*State, error) {
+	curve, err := ecpointgrouplaw.ToCurve(p.curve)
+	if err != nil {
+		return nil, err
+	}
+	coefficients := make([][]byte, p.poly.Len())
+	for i := range coefficients {
+		coefficients[i] = p.poly.Get(i).Bytes()
+	}
+	state := &State{
+		Round:                 Type(handler.MessageType()),
+		SessionId:             p.sessionID,
+		Curve:                 curve,
+		Threshold:             p.threshold,
+		Bk:                    p.bk.ToMessage(),
+		Coefficients:          coefficients,
+		U0GDecommitment:       p.u0gCommiter.GetDecommitmentMessage(),
+		Messages:              make([]*Message, len(msgs)),
+		ChainCodeDecommitment: p.chainCodeCommiter.GetDecommitmentMessage(),
+		Echoes:                echoes,
+	}
+	for i, msg := range msgs {
+		state.Messages[i] = getMessage(msg)
+	}
+	if p.isPedersen() {
+		state.Salts = make([][]byte, p.salts.Len())
+		for i := range state.Salts {
+			state.Salts[i] = p.salts.Get(i).Bytes()
+		}
+	}
+	if rh, ok := handler.(*resultHandler); ok {
+		state.PublicKey, err = rh.publicKey.ToEcPointMessage()
+		if err != nil {
+			return nil, err
+		}
+		state.Share = rh.share.Bytes()
+		state.SiGProofMsg = rh.siGProofMsg
+	}
+	return state, nil
+}
+
+// restoreHandler rebuilds the handler of the round in the state. The messages of the previous rounds are
+// handled again without sending out any messages.
+func restoreHandler(peerManager types.PeerManager, state *State) (*peerHandler, types.Handler, error) {
+	if err := tss.EnsureSessionID(state.SessionId); err != nil {
+		return nil, nil, err
+	}
+	curve, err := state.Curve.GetEllipticCurve()
+	if err != nil {
+		return nil, nil, err
+	}
+	poly, err := toPolynomial(curve.Params().N, state.Coefficients)
+	if err != nil {
+		return nil, nil, err
+	}
+	bk := state.GetBk().ToBk()
+	ph, err := newPeerHandlerWithPolynomial(curve, peerManager, state.SessionId, state.Threshold, bk.GetX(), bk.GetRank(), poly)
+	if err != nil {
+		return nil, nil, err
+	}
+	u0gCommiter, u0g, err := tss.NewCommitterByDecommitment(log.Discard(), state.SessionId, state.U0GDecommitment)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !u0g.Equal(ph.u0g) {
+		return nil, nil, tss.ErrInvalidCheckpoint
+	}
+	ph.u0gCommiter = u0gCommiter
+	if len(state.GetChainCodeDecommitment().GetData()) != ChainCodeSize {
+		return nil, nil, tss.ErrInvalidCheckpoint
+	}
+	ph.chainCodeCommiter, err = commitment.NewHashCommitmenterByDecommitment(state.SessionId, state.ChainCodeDecommitment)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(state.Salts) > 0 {
+		salts, err := toPolynomial(curve.Params().N, state.Salts)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = ph.setSalts(salts)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Replay the messages of the previous rounds silently
+	ph.peerManager = tss.NewSilentPeerManager(peerManager)
+	defer func() {
+		ph.peerManager = peerManager
+	}()
+	var handler types.Handler = ph
+	for handler.MessageType() != types.MessageType(state.Round) {
+		for _, msg := range state.Messages {
+			if msg.GetMessageType() != handler.MessageType() || ph.disqualified[msg.GetId()] || ph.extracted[msg.GetId()] != nil {
+				continue
+			}
+			err = handler.HandleMessage(log.Discard(), msg)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		switch h := handler.(type) {
+		case *peerHandler:
+			if h.isPedersen() {
+				handler = newPedersenVerifyHandler(h)
+			} else {
+				handler = newDecommitHandler(h)
+			}
+		case *decommitHandler:
+			handler = newVerifyHandler(h)
+		case *verifyHandler:
+			handler = newComplaintHandler(h.peerHandler)
+		case *pedersenVerifyHandler:
+			handler = newComplaintHandler(h.peerHandler)
+		case *complaintHandler:
+			handler = newRevealHandler(h)
+		case *revealHandler:
+			err = h.disqualify(log.Discard())
+			if err != nil {
+				break
+			}
+			if h.isPedersen() {
+				handler = newFeldmanHandler(h)
+			} else {
+				handler, err = restoreResultHandler(newResultVerifyHandler(h.peerHandler), state)
+			}
+		case *feldmanHandler:
+			handler = newFeldmanComplaintHandler(h)
+		case *feldmanComplaintHandler:
+			err = h.extract(log.Discard())
+			if err != nil {
+				break
+			}
+			if len(h.extracted) > 0 {
+				handler = newExtractHandler(h)
+			} else {
+				handler, err = restoreResultHandler(newResultVerifyHandler(h.peerHandler), state)
+			}
+		case *extractHandler:
+			handler, err = restoreResultHandler(newResultVerifyHandler(h.peerHandler), state)
+		default:
+			return nil, nil, tss.ErrInvalidCheckpoint
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return ph, handler, nil
+}
+
+func restoreResultHandler(h *verifyHandler, state *State) (*resultHandler, error) {
+	var err error
+	h.publicKey, err = state.GetPublicKey().ToPoint()
+	if err != nil {
+		return nil, err
+	}
+	h.share = new(big.Int).SetBytes(state.Share)
+	h.siGProofMsg = state.SiGProofMsg
+	return newResultHandler(h), nil
+}
+
+func toPolynomial(fieldOrder *big.Int, coefficients [][]byte) (*polynomial.Polynomial, error) {
+	cs := make([]*big.Int, len(coefficients))
+	for i, c := range coefficients {
+		cs[i] = new(big.Int).SetBytes(c)
+	}
+	return polynomial.NewPolynomial(fieldOrder, cs)
+}
@@ -0,0 +1,218 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/getamis/alice/crypto/tss/dkg/checkpoint.proto
+
+package dkg
+
+import (
+	fmt "fmt"
+	birkhoffinterpolation "github.com/getamis/alice/crypto/birkhoffinterpolation"
+	commitment "github.com/getamis/alice/crypto/commitment"
+	ecpointgrouplaw "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	message "github.com/getamis/alice/crypto/tss/message"
+	zkproof "github.com/getamis/alice/crypto/zkproof"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// State is the checkpoint of a DKG process
+type State struct {
+	Round           Type                                      `protobuf:"varint,1,opt,name=round,proto3,enum=dkg.Type" json:"round,omitempty"`
+	SessionId       []byte                                    `protobuf:"bytes,2,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	Curve           ecpointgrouplaw.EcPointMessage_Curve      `protobuf:"varint,3,opt,name=curve,proto3,enum=ecpointgrouplaw.EcPointMessage_Curve" json:"curve,omitempty"`
+	Threshold       uint32                                    `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Bk              *birkhoffinterpolation.BkParameterMessage `protobuf:"bytes,5,opt,name=bk,proto3" json:"bk,omitempty"`
+	Coefficients    [][]byte                                  `protobuf:"bytes,6,rep,name=coefficients,proto3" json:"coefficients,omitempty"`
+	U0GDecommitment *commitment.HashDecommitmentMessage       `protobuf:"bytes,7,opt,name=u0gDecommitment,proto3" json:"u0gDecommitment,omitempty"`
+	// The fields below are set after the verify round
+	PublicKey   *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,8,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Share       []byte                          `protobuf:"bytes,9,opt,name=share,proto3" json:"share,omitempty"`
+	SiGProofMsg *zkproof.SchnorrProofMessage    `protobuf:"bytes,10,opt,name=siGProofMsg,proto3" json:"siGProofMsg,omitempty"`
+	// messages are all the accepted messages
+	Messages []*Message `protobuf:"bytes,11,rep,name=messages,proto3" json:"messages,omitempty"`
+	// salts are the coefficients of the salt polynomial, which are only set in the Pedersen mode
+	Salts                 [][]byte                            `protobuf:"bytes,12,rep,name=salts,proto3" json:"salts,omitempty"`
+	ChainCodeDecommitment *commitment.HashDecommitmentMessage `protobuf:"bytes,13,opt,name=chainCodeDecommitment,proto3" json:"chainCodeDecommitment,omitempty"`
+	// echoes are all the accepted echo messages of the rounds sent by echo broadcast
+	Echoes               []*message.EchoMessage `protobuf:"bytes,14,rep,name=echoes,proto3" json:"echoes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *State) Reset()         { *m = State{} }
+func (m *State) String() string { return proto.CompactTextString(m) }
+func (*State) ProtoMessage()    {}
+func (*State) Descriptor() ([]byte, []int) {
+	return fileDescriptor_816d12d9acb07aa1, []int{0}
+}
+
+func (m *State) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_State.Unmarshal(m, b)
+}
+func (m *State) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_State.Marshal(b, m, deterministic)
+}
+func (m *State) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_State.Merge(m, src)
+}
+func (m *State) XXX_Size() int {
+	return xxx_messageInfo_State.Size(m)
+}
+func (m *State) XXX_DiscardUnknown() {
+	xxx_messageInfo_State.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_State proto.InternalMessageInfo
+
+func (m *State) GetRound() Type {
+	if m != nil {
+		return m.Round
+	}
+	return Type_Peer
+}
+
+func (m *State) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *State) GetCurve() ecpointgrouplaw.EcPointMessage_Curve {
+	if m != nil {
+		return m.Curve
+	}
+	return ecpointgrouplaw.EcPointMessage_P224
+}
+
+func (m *State) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *State) GetBk() *birkhoffinterpolation.BkParameterMessage {
+	if m != nil {
+		return m.Bk
+	}
+	return nil
+}
+
+func (m *State) GetCoefficients() [][]byte {
+	if m != nil {
+		return m.Coefficients
+	}
+	return nil
+}
+
+func (m *State) GetU0GDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.U0GDecommitment
+	}
+	return nil
+}
+
+func (m *State) GetPublicKey() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *State) GetShare() []byte {
+	if m != nil {
+		return m.Share
+	}
+	return nil
+}
+
+func (m *State) GetSiGProofMsg() *zkproof.SchnorrProofMessage {
+	if m != nil {
+		return m.SiGProofMsg
+	}
+	return nil
+}
+
+func (m *State) GetMessages() []*Message {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func (m *State) GetSalts() [][]byte {
+	if m != nil {
+		return m.Salts
+	}
+	return nil
+}
+
+func (m *State) GetChainCodeDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.ChainCodeDecommitment
+	}
+	return nil
+}
+
+func (m *State) GetEchoes() []*message.EchoMessage {
+	if m != nil {
+		return m.Echoes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*State)(nil), "dkg.State")
+}
+
+func init() {
+	proto.RegisterFile("github.com/getamis/alice/crypto/tss/dkg/checkpoint.proto", fileDescriptor_816d12d9acb07aa1)
+}
+
+var fileDescriptor_816d12d9acb07aa1 = []byte{
+	// 497 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x5f, 0x8b, 0x13, 0x31,
+	0x14, 0xc5, 0x69, 0xeb, 0xd4, 0x6d, 0xda, 0x5d, 0x21, 0xac, 0x10, 0xca, 0xc2, 0x0e, 0x2b, 0xc2,
+	0x08, 0x92, 0x91, 0xca, 0x8a, 0xb2, 0xb8, 0x0f, 0xae, 0x8b, 0x8a, 0x14, 0xca, 0xac, 0x2f, 0x3e,
+	0x66, 0x32, 0xb7, 0x93, 0x30, 0x7f, 0x32, 0x24, 0x19, 0xa5, 0x7e, 0x27, 0xbf, 0xa3, 0x34, 0x33,
+	0xb5, 0x7f, 0x58, 0x98, 0x3e, 0xe6, 0xe6, 0xfe, 0xee, 0xb9, 0x9c, 0x7b, 0xd0, 0xfb, 0x54, 0x5a,
+	0x51, 0xc7, 0x94, 0xab, 0x22, 0x4c, 0xc1, 0xb2, 0x42, 0x9a, 0x90, 0xe5, 0x92, 0x43, 0xc8, 0xf5,
+	0xaa, 0xb2, 0x2a, 0xb4, 0xc6, 0x84, 0x49, 0x96, 0x86, 0x5c, 0x00, 0xcf, 0x2a, 0x25, 0x4b, 0x4b,
+	0x2b, 0xad, 0xac, 0xc2, 0x83, 0x24, 0x4b, 0xa7, 0xb7, 0x5d, 0x78, 0x2c, 0x75, 0x26, 0xd4, 0x72,
+	0x29, 0x4b, 0x0b, 0xba, 0x52, 0x39, 0xb3, 0x52, 0x95, 0x61, 0x9c, 0x35, 0x43, 0xa6, 0x9d, 0xf2,
+	0x5c, 0x15, 0x85, 0xb4, 0x05, 0x94, 0x36, 0x2c, 0xc0, 0x18, 0x96, 0x42, 0x4b, 0xde, 0x74, 0x91,
+	0xc0, 0xdd, 0xb6, 0xa9, 0x56, 0x75, 0x95, 0xb3, 0xdf, 0xe1, 0xce, 0xee, 0xd3, 0xeb, 0x2e, 0xf8,
+	0x4f, 0x56, 0x69, 0xa5, 0x96, 0x07, 0x9a, 0xd7, 0xc7, 0x9a, 0xb5, 0x8f, 0xbd, 0x3b, 0x06, 0x6b,
+	0x91, 0x10, 0xb8, 0x50, 0x0d, 0x77, 0xf5, 0xd7, 0x43, 0xde, 0x83, 0x65, 0x16, 0xf0, 0x25, 0xf2,
+	0xb4, 0xaa, 0xcb, 0x84, 0xf4, 0xfc, 0x5e, 0x70, 0x36, 0x1b, 0xd1, 0x24, 0x4b, 0xe9, 0x8f, 0x55,
+	0x05, 0x51, 0x53, 0xc7, 0x17, 0x68, 0x64, 0xc0, 0x18, 0xa9, 0xca, 0x6f, 0x09, 0xe9, 0xfb, 0xbd,
+	0x60, 0x12, 0x6d, 0x0b, 0xf8, 0x06, 0x79, 0xbc, 0xd6, 0xbf, 0x80, 0x0c, 0x1c, 0xfe, 0x92, 0x1e,
+	0x78, 0x43, 0xef, 0xf9, 0x62, 0xfd, 0x9e, 0xb7, 0x6b, 0xdf, 0xad, 0x9b, 0xa3, 0x86, 0x59, 0x8f,
+	0xb6, 0x42, 0x83, 0x11, 0x2a, 0x4f, 0xc8, 0x13, 0xbf, 0x17, 0x9c, 0x46, 0xdb, 0x02, 0xfe, 0x80,
+	0xfa, 0x71, 0x46, 0x3c, 0xbf, 0x17, 0x8c, 0x67, 0xaf, 0xe8, 0xa3, 0xd7, 0xa6, 0x9f, 0xb2, 0x05,
+	0xd3, 0xac, 0x00, 0x0b, 0xba, 0x55, 0x88, 0xfa, 0x71, 0x86, 0xaf, 0xd0, 0x84, 0x2b, 0x58, 0x2e,
+	0x25, 0x97, 0x50, 0x5a, 0x43, 0x86, 0xfe, 0x20, 0x98, 0x44, 0x7b, 0x35, 0x3c, 0x47, 0xcf, 0xea,
+	0x37, 0xe9, 0x67, 0xd8, 0xc6, 0x80, 0x3c, 0x75, 0x5a, 0x2f, 0xe8, 0xb6, 0x44, 0xbf, 0x32, 0x23,
+	0x76, 0x7b, 0x36, 0x2a, 0x87, 0x2c, 0xfe, 0x88, 0x46, 0x55, 0x1d, 0xe7, 0x92, 0x7f, 0x87, 0x15,
+	0x39, 0x71, 0x83, 0x2e, 0x3b, 0xcc, 0x88, 0xb6, 0x04, 0x3e, 0x47, 0x9e, 0x11, 0x4c, 0x03, 0x19,
+	0x39, 0x87, 0x9b, 0x07, 0xbe, 0x45, 0x63, 0x23, 0xbf, 0x2c, 0xd6, 0x79, 0x99, 0x9b, 0x94, 0x20,
+	0x37, 0xf6, 0x82, 0xb6, 0x11, 0xa2, 0x0f, 0x5c, 0x94, 0x4a, 0xeb, 0xe6, 0xbf, 0x9d, 0xb9, 0x0b,
+	0xe0, 0x00, 0x9d, 0xb4, 0xc7, 0x37, 0x64, 0xec, 0x0f, 0x82, 0xf1, 0x6c, 0xe2, 0xee, 0xbb, 0x69,
+	0xfe, 0xff, 0xeb, 0xf4, 0x59, 0x6e, 0x0d, 0x99, 0x38, 0xab, 0x9a, 0x07, 0xfe, 0x89, 0x9e, 0x73,
+	0xc1, 0x64, 0x79, 0xa7, 0x12, 0xd8, 0x73, 0xea, 0xf4, 0x78, 0xa7, 0x1e, 0x9f, 0x80, 0x5f, 0xa3,
+	0xe1, 0x3a, 0x8f, 0x60, 0xc8, 0x99, 0x5b, 0xec, 0x9c, 0x6e, 0x92, 0x7d, 0xcf, 0x85, 0xda, 0xc0,
+	0x6d, 0x4f, 0x3c, 0x74, 0xb1, 0x7d, 0xfb, 0x6f, 0x00, 0x7a, 0x88, 0x94, 0x6c, 0x54, 0x04, 0x00,
+	0x00,
+}
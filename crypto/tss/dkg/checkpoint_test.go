@@ -0,0 +1,657 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"bytes"
+	"crypto/rand"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/commitment"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/polynomial"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Checkpoint", func() {
+	var (
+		curve = btcec.S256()
+		key   = bytes.Repeat([]byte{1}, tss.CheckpointKeySize)
+	)
+
+	It("restores a crashed peer and finishes the process", func() {
+		dkgs, listeners := newDKGs(curve, 3, []uint32{0, 0, 0})
+		doneChs := make(map[string]chan struct{}, len(listeners))
+		for id, l := range listeners {
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+		crashedID := getID(0)
+		crashed := dkgs[crashedID]
+		failedCh := make(chan struct{})
+		listeners[crashedID].On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+			close(failedCh)
+		}).Once()
+		crashed.Stop()
+		<-failedCh
+
+		// The crashed peer receives the peer messages, but never handles them
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		// Wait for the messages of the peer and the next rounds
+		waitForMessages(crashed, 2*(len(dkgs)-1))
+		checkpoint, err := crashed.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		restored, err := RestoreDKG(crashed.ph.peerManager, key, checkpoint, listeners[crashedID])
+		Expect(err).Should(BeNil())
+		Expect(restored.GetU0()).Should(Equal(crashed.GetU0()))
+		dkgs[crashedID] = restored
+		restored.Start()
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		var pubkey = restored.ph.u0g
+		for id, d := range dkgs {
+			if id == crashedID {
+				continue
+			}
+			pubkey, err = pubkey.Add(d.ph.u0g)
+			Expect(err).Should(BeNil())
+		}
+		var chainCode []byte
+		for _, d := range dkgs {
+			d.Stop()
+			r, err := d.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(r.PublicKey.Equal(pubkey)).Should(BeTrue())
+			// The restored peer reveals the same contribution to the chain code
+			if chainCode == nil {
+				chainCode = r.ChainCode
+			}
+			Expect(r.ChainCode).Should(Equal(chainCode))
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("restores the result of a done process", func() {
+		dkgs, listeners := newDKGs(curve, 3, []uint32{0, 0, 0})
+		doneChs := make(map[string]chan struct{}, len(listeners))
+		for id, l := range listeners {
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		d := dkgs[getID(1)]
+		d.Stop()
+		exp, err := d.GetResult()
+		Expect(err).Should(BeNil())
+		checkpoint, err := d.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		// All the previous rounds are replayed and the last round is handled again
+		doneCh := make(chan struct{})
+		listener := new(mocks.StateChangedListener)
+		listener.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+			close(doneCh)
+		}).Once()
+		restored, err := RestoreDKG(d.ph.peerManager, key, checkpoint, listener)
+		Expect(err).Should(BeNil())
+		Expect(restored.GetHandler().MessageType()).Should(Equal(types.MessageType(Type_Result)))
+		restored.Start()
+		<-doneCh
+		got, err := restored.GetResult()
+		Expect(err).Should(BeNil())
+		Expect(got.Share).Should(Equal(exp.Share))
+		Expect(got.PublicKey.Equal(exp.PublicKey)).Should(BeTrue())
+		Expect(got.Bks).Should(Equal(exp.Bks))
+		Expect(got.ChainCode).Should(Equal(exp.ChainCode))
+		listener.AssertExpectations(GinkgoT())
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("restores the result of a process with disqualified peers", func() {
+		dkgs, listeners := newDKGs(curve, 3, []uint32{0, 0, 0, 0})
+		badID, victimID := getID(0), getID(1)
+		// The bad peer sends a wrong share to the victim and fails to reveal it
+		dkgs[badID].ph.peerManager = &tamperPeerManager{
+			peerManager: dkgs[badID].ph.peerManager.(*peerManager),
+			tamper: func(id string, msg *Message) {
+				if msg.Type == Type_Verify && id == victimID {
+					msg.GetVerify().GetVerify().Evaluation = addOne(msg.GetVerify().GetVerify().GetEvaluation())
+				}
+				if msg.Type == Type_Reveal {
+					msg.GetReveal().Shares = nil
+				}
+			},
+		}
+		// The disqualified peer never finishes, and fails after it's stopped
+		failedCh := make(chan struct{})
+		doneChs := make(map[string]chan struct{}, len(listeners))
+		for id, l := range listeners {
+			if id == badID {
+				l.On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+					close(failedCh)
+				}).Once()
+				continue
+			}
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		d := dkgs[victimID]
+		d.Stop()
+		exp, err := d.GetResult()
+		Expect(err).Should(BeNil())
+		Expect(exp.Disqualified).Should(Equal([]string{badID}))
+		checkpoint, err := d.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		// The disqualification is replayed, and the revealed share is not used
+		doneCh := make(chan struct{})
+		listener := new(mocks.StateChangedListener)
+		listener.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+			close(doneCh)
+		}).Once()
+		restored, err := RestoreDKG(d.ph.peerManager, key, checkpoint, listener)
+		Expect(err).Should(BeNil())
+		restored.Start()
+		<-doneCh
+		got, err := restored.GetResult()
+		Expect(err).Should(BeNil())
+		Expect(got).Should(Equal(exp))
+		listener.AssertExpectations(GinkgoT())
+
+		for _, d := range dkgs {
+			d.Stop()
+		}
+		<-failedCh
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("restores the result of a process with extracted peers", func() {
+		dkgs, listeners := newPedersenDKGs(curve, 3, []uint32{0, 0, 0, 0})
+		badID := getID(0)
+		// The bad peer reveals the Feldman commitments of another polynomial
+		poly, err := polynomial.RandomPolynomial(curve.Params().N, 2)
+		Expect(err).Should(BeNil())
+		dkgs[badID].ph.feldmanCommitmenter, err = commitment.NewFeldmanCommitmenter(curve, poly)
+		Expect(err).Should(BeNil())
+		finalChs := make(map[string]chan struct{}, len(listeners))
+		for id, l := range listeners {
+			finalCh := make(chan struct{})
+			finalChs[id] = finalCh
+			newState := types.StateDone
+			if id == badID {
+				newState = types.StateFailed
+			}
+			l.On("OnStateChanged", types.StateInit, newState).Run(func(args mock.Arguments) {
+				close(finalCh)
+			}).Once()
+		}
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, finalCh := range finalChs {
+			<-finalCh
+		}
+
+		d := dkgs[getID(1)]
+		d.Stop()
+		exp, err := d.GetResult()
+		Expect(err).Should(BeNil())
+		Expect(exp.Extracted).Should(Equal([]string{badID}))
+		checkpoint, err := d.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		// The extraction is replayed, and the messages of the extracted peer are not used
+		doneCh := make(chan struct{})
+		listener := new(mocks.StateChangedListener)
+		listener.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+			close(doneCh)
+		}).Once()
+		restored, err := RestoreDKG(d.ph.peerManager, key, checkpoint, listener)
+		Expect(err).Should(BeNil())
+		restored.Start()
+		<-doneCh
+		got, err := restored.GetResult()
+		Expect(err).Should(BeNil())
+		Expect(got).Should(Equal(exp))
+		listener.AssertExpectations(GinkgoT())
+
+		for _, d := range dkgs {
+			d.Stop()
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("restores a crashed peer in the Pedersen mode", func() {
+		dkgs, listeners := newPedersenDKGs(curve, 3, []uint32{0, 0, 1})
+		doneChs := make(map[string]chan struct{}, len(listeners))
+		for id, l := range listeners {
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+		crashedID := getID(0)
+		crashed := dkgs[crashedID]
+		failedCh := make(chan struct{})
+		listeners[crashedID].On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+			close(failedCh)
+		}).Once()
+		crashed.Stop()
+		<-failedCh
+
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		// Wait for the messages of the peer and the next rounds
+		waitForMessages(crashed, 2*(len(dkgs)-1))
+		checkpoint, err := crashed.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		restored, err := RestoreDKG(crashed.ph.peerManager, key, checkpoint, listeners[crashedID])
+		Expect(err).Should(BeNil())
+		Expect(restored.ph.isPedersen()).Should(BeTrue())
+		Expect(restored.GetPeerMessage()).Should(Equal(crashed.GetPeerMessage()))
+		dkgs[crashedID] = restored
+		restored.Start()
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		var pubkey *ecpointgrouplaw.ECPoint
+		for _, d := range dkgs {
+			d.Stop()
+			r, err := d.GetResult()
+			Expect(err).Should(BeNil())
+			if pubkey == nil {
+				pubkey = r.PublicKey
+			}
+			Expect(r.PublicKey.Equal(pubkey)).Should(BeTrue())
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("restores a crashed peer behind the encrypted transport", func() {
+		ids := []string{getID(0), getID(1), getID(2)}
+		privKeys := make(map[string][]byte, len(ids))
+		publicKeys := make(map[string][]byte, len(ids))
+		for _, id := range ids {
+			var err error
+			privKeys[id], publicKeys[id], err = message.GenerateEncryptionKey(rand.Reader)
+			Expect(err).Should(BeNil())
+		}
+		receivers := make(map[string]*message.EncryptedReceiver, len(ids))
+		newPeerManager := func(id string) *message.EncryptedPeerManager {
+			pm, err := message.NewEncryptedPeerManager(&encryptedTransport{id: id, ids: ids, receivers: receivers}, sessionID, privKeys[id], publicKeys)
+			Expect(err).Should(BeNil())
+			return pm
+		}
+
+		crashedID := ids[0]
+		dkgs := make(map[string]*DKG, len(ids))
+		listeners := make(map[string]*mocks.StateChangedListener, len(ids))
+		doneChs := make(map[string]chan struct{}, len(ids))
+		var crashedAdder *holdAdder
+		for _, id := range ids {
+			listeners[id] = new(mocks.StateChangedListener)
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			listeners[id].On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+			var err error
+			dkgs[id], err = NewDKG(curve, newPeerManager(id), sessionID, 3, 0, listeners[id])
+			Expect(err).Should(BeNil())
+			var adder message.MessageAdder = dkgs[id]
+			if id == crashedID {
+				crashedAdder = &holdAdder{adder: dkgs[id]}
+				adder = crashedAdder
+			}
+			receivers[id], err = message.NewEncryptedReceiver(adder, id, sessionID, privKeys[id], publicKeys)
+			Expect(err).Should(BeNil())
+		}
+		for _, d := range dkgs {
+			d.Start()
+		}
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+
+		// The crashed peer has sent the decommit and verify messages before it crashes in the Verify round
+		crashed := dkgs[crashedID]
+		Eventually(func() types.MessageType {
+			return crashed.GetHandler().MessageType()
+		}).Should(Equal(types.MessageType(Type_Verify)))
+		failedCh := make(chan struct{})
+		listeners[crashedID].On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+			close(failedCh)
+		}).Once()
+		crashed.Stop()
+		<-failedCh
+		checkpoint, err := crashed.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		// The sequence numbers of the restored peer start over, but its messages are still accepted
+		restored, err := RestoreDKG(newPeerManager(crashedID), key, checkpoint, listeners[crashedID])
+		Expect(err).Should(BeNil())
+		dkgs[crashedID] = restored
+		restored.Start()
+		crashedAdder.release(restored)
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		var pubkey *ecpointgrouplaw.ECPoint
+		for _, d := range dkgs {
+			d.Stop()
+			r, err := d.GetResult()
+			Expect(err).Should(BeNil())
+			if pubkey == nil {
+				pubkey = r.PublicKey
+			}
+			Expect(r.PublicKey.Equal(pubkey)).Should(BeTrue())
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("restores a crashed peer in an echo broadcast round", func() {
+		crashedID, slowID := getID(0), getID(2)
+		dkgs := make(map[string]*DKG, 3)
+		listeners := make(map[string]*mocks.StateChangedListener, 3)
+		doneChs := make(map[string]chan struct{}, 3)
+		pms := make(map[string]*holdPeerManager, 3)
+		for i := 0; i < 3; i++ {
+			id := getID(i)
+			pm := newPeerManager(id, 2)
+			pm.setDKGs(dkgs)
+			pms[id] = &holdPeerManager{peerManager: pm}
+			listeners[id] = new(mocks.StateChangedListener)
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			listeners[id].On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+			var err error
+			dkgs[id], err = NewDKG(curve, pms[id], sessionID, 3, 0, listeners[id])
+			Expect(err).Should(BeNil())
+		}
+		// The echo of the complaints from the slow peer arrives after the crashed peer is restored
+		pms[slowID].hold = func(id string, msg types.Message) bool {
+			echo, ok := msg.(*message.EchoMessage)
+			return ok && id == crashedID && Type(echo.GetType()) == Type_Complaint
+		}
+		for _, d := range dkgs {
+			d.Start()
+		}
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+
+		// The crashed peer has collected the echo of the other peer, and the reveal messages of the next round
+		crashed := dkgs[crashedID]
+		Eventually(func() bool {
+			var ok bool
+			_ = crashed.Snapshot(func(handler types.Handler, msgs []types.Message, echoes []*message.EchoMessage) error {
+				var reveals int
+				for _, msg := range msgs {
+					if msg.GetMessageType() == types.MessageType(Type_Reveal) {
+						reveals++
+					}
+				}
+				ok = handler.MessageType() == types.MessageType(Type_Complaint) && len(echoes) == 1 && reveals == 2
+				return nil
+			})
+			return ok
+		}).Should(BeTrue())
+		failedCh := make(chan struct{})
+		listeners[crashedID].On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+			close(failedCh)
+		}).Once()
+		crashed.Stop()
+		<-failedCh
+		checkpoint, err := crashed.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		restored, err := RestoreDKG(pms[crashedID], key, checkpoint, listeners[crashedID])
+		Expect(err).Should(BeNil())
+		Expect(restored.GetHandler().MessageType()).Should(Equal(types.MessageType(Type_Complaint)))
+		dkgs[crashedID] = restored
+		restored.Start()
+		pms[slowID].release()
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		var pubkey *ecpointgrouplaw.ECPoint
+		for _, d := range dkgs {
+			d.Stop()
+			r, err := d.GetResult()
+			Expect(err).Should(BeNil())
+			if pubkey == nil {
+				pubkey = r.PublicKey
+			}
+			Expect(r.PublicKey.Equal(pubkey)).Should(BeTrue())
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("wrong key", func() {
+		d, err := NewDKG(curve, newPeerManager("id", 2), sessionID, 3, 0, nil)
+		Expect(err).Should(BeNil())
+		checkpoint, err := d.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		restored, err := RestoreDKG(newPeerManager("id", 2), bytes.Repeat([]byte{2}, tss.CheckpointKeySize), checkpoint, nil)
+		Expect(err).Should(Equal(tss.ErrInvalidCheckpoint))
+		Expect(restored).Should(BeNil())
+	})
+})
+
+func waitForMessages(d *DKG, count int) {
+	Eventually(func() int {
+		var got int
+		_ = d.Snapshot(func(handler types.Handler, msgs []types.Message, _ []*message.EchoMessage) error {
+			got = len(msgs)
+			return nil
+		})
+		return got
+	}).Should(Equal(count))
+}
+
+// encryptedTransport delivers the encrypted messages to the receivers of the peers
+type encryptedTransport struct {
+	id        string
+	ids       []string
+	receivers map[string]*message.EncryptedReceiver
+}
+
+func (t *encryptedTransport) NumPeers() uint32 {
+	return uint32(len(t.ids) - 1)
+}
+
+func (t *encryptedTransport) SelfID() string {
+	return t.id
+}
+
+func (t *encryptedTransport) PeerIDs() []string {
+	ids := make([]string, 0, len(t.ids)-1)
+	for _, id := range t.ids {
+		if id != t.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (t *encryptedTransport) MustSend(id string, msg proto.Message) {
+	Expect(t.receivers[id].AddMessage(msg.(types.Message))).Should(BeNil())
+}
+
+// holdAdder holds the messages after the Decommit round until they are released to another process
+type holdAdder struct {
+	lock     sync.Mutex
+	adder    message.MessageAdder
+	held     []types.Message
+	released bool
+}
+
+func (h *holdAdder) AddMessage(msg types.Message) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if m, ok := msg.(*Message); h.released || ok && (m.Type == Type_Peer || m.Type == Type_Decommit) {
+		return h.adder.AddMessage(msg)
+	}
+	h.held = append(h.held, msg)
+	return nil
+}
+
+func (h *holdAdder) release(adder message.MessageAdder) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.adder = adder
+	h.released = true
+	for _, msg := range h.held {
+		Expect(adder.AddMessage(msg)).Should(BeNil())
+	}
+	h.held = nil
+}
+
+// holdPeerManager holds the messages chosen by hold until they are released. The errors of the delivered messages
+// are ignored, because the restored peers send the messages of the restored round again.
+type holdPeerManager struct {
+	*peerManager
+
+	lock sync.Mutex
+	hold func(id string, msg types.Message) bool
+	held map[string][]types.Message
+}
+
+func (p *holdPeerManager) MustSend(id string, message proto.Message) {
+	msg := message.(types.Message)
+	p.lock.Lock()
+	if p.hold != nil && p.hold(id, msg) {
+		if p.held == nil {
+			p.held = make(map[string][]types.Message)
+		}
+		p.held[id] = append(p.held[id], msg)
+		p.lock.Unlock()
+		return
+	}
+	p.lock.Unlock()
+	_ = p.dkgs[id].AddMessage(msg)
+}
+
+func (p *holdPeerManager) release() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.hold = nil
+	for id, msgs := range p.held {
+		for _, msg := range msgs {
+			Expect(p.dkgs[id].AddMessage(msg)).Should(BeNil())
+		}
+	}
+	p.held = nil
+}
@@ -0,0 +1,205 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+)
+
+const (
+	// ChainCodeSize is the size of the BIP-32 chain code
+	ChainCodeSize = 32
+	// HardenedKeyStart is the first index of the hardened child keys, which cannot be derived from public keys
+	HardenedKeyStart = uint32(0x80000000)
+)
+
+var (
+	// ErrInvalidChainCode is returned if the chain code is invalid
+	ErrInvalidChainCode = errors.New("invalid chain code")
+	// ErrInvalidDerivationPath is returned if the derivation path is invalid
+	ErrInvalidDerivationPath = errors.New("invalid derivation path")
+	// ErrHardenedDerivation is returned if the index is hardened
+	ErrHardenedDerivation = errors.New("hardened derivation is not supported")
+	// ErrInvalidChildKey is returned if the child key is invalid. The next index should be used instead.
+	ErrInvalidChildKey = errors.New("invalid child key")
+)
+
+// ChildKey is a non-hardened BIP-32 child key of a threshold key
+type ChildKey struct {
+	PublicKey *ecpointgrouplaw.ECPoint
+	ChainCode []byte
+	// Tweak is the sum of the tweaks along the path. The child private key is the parent one plus Tweak.
+	Tweak *big.Int
+}
+
+// ParseDerivationPath parses the path like "m/0/1" to the child indices. Hardened indices are not allowed.
+func ParseDerivationPath(path string) ([]uint32, error) {
+	elements := strings.Split(path, "/")
+	if elements[0] != "m" {
+		return nil, ErrInvalidDerivationPath
+	}
+	indices := make([]uint32, len(elements)-1)
+	for i, e := range elements[1:] {
+		if strings.HasSuffix(e, "'") || strings.HasSuffix(e, "h") || strings.HasSuffix(e, "H") {
+			return nil, ErrHardenedDerivation
+		}
+		index, err := strconv.ParseUint(e, 10, 32)
+		if err != nil {
+			return nil, ErrInvalidDerivationPath
+		}
+		if uint32(index) >= HardenedKeyStart {
+			return nil, ErrHardenedDerivation
+		}
+		indices[i] = uint32(index)
+	}
+	return indices, nil
+}
+
+// DeriveChildKey derives the child key along the path by the public parent key to public child key
+// derivation (CKDpub) of BIP-32. Only the curves in short Weierstrass form are supported.
+func DeriveChildKey(publicKey *ecpointgrouplaw.ECPoint, chainCode []byte, path []uint32) (*ChildKey, error) {
+	curve := publicKey.GetCurve()
+	if curve == ecpointgrouplaw.Edwards25519() {
+		return nil, ecpointgrouplaw.ErrInvalidCurve
+	}
+	if len(chainCode) != ChainCodeSize {
+		return nil, ErrInvalidChainCode
+	}
+	if publicKey.IsIdentity() {
+		return nil, ErrInvalidChildKey
+	}
+	n := curve.Params().N
+	child := &ChildKey{
+		PublicKey: publicKey.Copy(),
+		ChainCode: chainCode,
+		Tweak:     big.NewInt(0),
+	}
+	for _, index := range path {
+		if index >= HardenedKeyStart {
+			return nil, ErrHardenedDerivation
+		}
+		// I = HMAC-SHA512(c_par, ser_P(K_par) || ser_32(i))
+		mac := hmac.New(sha512.New, child.ChainCode)
+		mac.Write(compress(child.PublicKey))
+		indexBytes := make([]byte, 4)
+		binary.BigEndian.PutUint32(indexBytes, index)
+		mac.Write(indexBytes)
+		i := mac.Sum(nil)
+
+		il := new(big.Int).SetBytes(i[:ChainCodeSize])
+		if il.Cmp(n) >= 0 {
+			return nil, ErrInvalidChildKey
+		}
+		pk, err := child.PublicKey.Add(ecpointgrouplaw.ScalarBaseMult(curve, il))
+		if err != nil {
+			return nil, err
+		}
+		if pk.IsIdentity() {
+			return nil, ErrInvalidChildKey
+		}
+		child.PublicKey = pk
+		child.ChainCode = i[ChainCodeSize:]
+		child.Tweak = new(big.Int).Add(child.Tweak, il)
+		child.Tweak.Mod(child.Tweak, n)
+	}
+	return child, nil
+}
+
+// DeriveChild derives the child key of the path, and returns the result with the child public key, the child
+// chain code, and the tweaked share and public shares. The result could be used to sign under the child key
+// directly. Only the shares of rank 0 are tweaked, because the tweak is added to the constant term of the
+// polynomial and the derivatives are unchanged.
+func (r *Result) DeriveChild(selfID string, path string) (*Result, error) {
+	indices, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	bk, ok := r.Bks[selfID]
+	if !ok {
+		return nil, tss.ErrPeerNotFound
+	}
+	child, err := DeriveChildKey(r.PublicKey, r.ChainCode, indices)
+	if err != nil {
+		return nil, err
+	}
+	curve := r.PublicKey.GetCurve()
+	share := new(big.Int).Set(r.Share)
+	if bk.GetRank() == 0 {
+		share.Add(share, child.Tweak)
+		share.Mod(share, curve.Params().N)
+	}
+	var publicShares map[string]*ecpointgrouplaw.ECPoint
+	if r.PublicShares != nil {
+		tweakG := ecpointgrouplaw.ScalarBaseMult(curve, child.Tweak)
+		publicShares = make(map[string]*ecpointgrouplaw.ECPoint, len(r.PublicShares))
+		for id, publicShare := range r.PublicShares {
+			peerBk, ok := r.Bks[id]
+			if !ok {
+				return nil, tss.ErrPeerNotFound
+			}
+			if peerBk.GetRank() != 0 {
+				publicShares[id] = publicShare.Copy()
+				continue
+			}
+			publicShares[id], err = publicShare.Add(tweakG)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &Result{
+		PublicKey:    child.PublicKey,
+		Share:        share,
+		Bks:          r.Bks,
+		PublicShares: publicShares,
+		Disqualified: r.Disqualified,
+		ChainCode:    child.ChainCode,
+	}, nil
+}
+
+// buildChainCode returns the chain code, the hash of the contributions sorted by the ids of the participants
+func buildChainCode(contributions map[string][]byte) []byte {
+	ids := make([]string, 0, len(contributions))
+	for id := range contributions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	h := sha256.New()
+	for _, id := range ids {
+		h.Write(contributions[id])
+	}
+	return h.Sum(nil)
+}
+
+// compress serializes the point in the SEC1 compressed form
+func compress(p *ecpointgrouplaw.ECPoint) []byte {
+	byteLen := (p.GetCurve().Params().BitSize + 7) / 8
+	bs := make([]byte, 1+byteLen)
+	bs[0] = 0x02 | byte(p.GetY().Bit(0))
+	x := p.GetX().Bytes()
+	copy(bs[1+byteLen-len(x):], x)
+	return bs
+}
@@ -0,0 +1,184 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Derive", func() {
+	curve := btcec.S256()
+
+	// The test vectors of BIP-32
+	DescribeTable("DeriveChildKey()", func(parent string, path []uint32, expected string) {
+		publicKey, chainCode := decodeXpub(parent)
+		child, err := DeriveChildKey(publicKey, chainCode, path)
+		Expect(err).Should(BeNil())
+		expectedPublicKey, expectedChainCode := decodeXpub(expected)
+		Expect(child.PublicKey.Equal(expectedPublicKey)).Should(BeTrue())
+		Expect(child.ChainCode).Should(Equal(expectedChainCode))
+		// The tweak moves the parent key to the child key
+		tweaked, err := publicKey.Add(ecpointgrouplaw.ScalarBaseMult(curve, child.Tweak))
+		Expect(err).Should(BeNil())
+		Expect(tweaked.Equal(child.PublicKey)).Should(BeTrue())
+	},
+		Entry("Vector 1: m/0H -> m/0H/1",
+			"xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+			[]uint32{1},
+			"xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+		),
+		Entry("Vector 1: m/0H/1/2H -> m/0H/1/2H/2/1000000000",
+			"xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5",
+			[]uint32{2, 1000000000},
+			"xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy",
+		),
+		Entry("Vector 2: m -> m/0",
+			"xpub661MyMwAqRbcFW31YEwpkMuc5THy2PSt5bDMsktWQcFF8syAmRUapSCGu8ED9W6oDMSgv6Zz8idoc4a6mr8BDzTJY47LJhkJ8UB7WEGuduB",
+			[]uint32{0},
+			"xpub69H7F5d8KSRgmmdJg2KhpAK8SR3DjMwAdkxj3ZuxV27CprR9LgpeyGmXUbC6wb7ERfvrnKZjXoUmmDznezpbZb7ap6r1D3tgFxHmwMkQTPH",
+		),
+	)
+
+	DescribeTable("ParseDerivationPath()", func(path string, expected []uint32, expectedErr error) {
+		indices, err := ParseDerivationPath(path)
+		if expectedErr != nil {
+			Expect(err).Should(Equal(expectedErr))
+			return
+		}
+		Expect(err).Should(BeNil())
+		Expect(indices).Should(Equal(expected))
+	},
+		Entry("master", "m", []uint32{}, nil),
+		Entry("non-hardened", "m/0/1/2147483647", []uint32{0, 1, 2147483647}, nil),
+		Entry("hardened by apostrophe", "m/0'/1", nil, ErrHardenedDerivation),
+		Entry("hardened by h", "m/0/1h", nil, ErrHardenedDerivation),
+		Entry("hardened index", "m/2147483648", nil, ErrHardenedDerivation),
+		Entry("no master", "0/1", nil, ErrInvalidDerivationPath),
+		Entry("empty index", "m/0/", nil, ErrInvalidDerivationPath),
+		Entry("invalid index", "m/a", nil, ErrInvalidDerivationPath),
+	)
+
+	Context("DeriveChildKey(), negative cases", func() {
+		var publicKey *ecpointgrouplaw.ECPoint
+		BeforeEach(func() {
+			publicKey = ecpointgrouplaw.ScalarBaseMult(curve, big.NewInt(5))
+		})
+
+		It("invalid chain code", func() {
+			_, err := DeriveChildKey(publicKey, make([]byte, ChainCodeSize-1), []uint32{0})
+			Expect(err).Should(Equal(ErrInvalidChainCode))
+		})
+
+		It("hardened index", func() {
+			_, err := DeriveChildKey(publicKey, make([]byte, ChainCodeSize), []uint32{HardenedKeyStart})
+			Expect(err).Should(Equal(ErrHardenedDerivation))
+		})
+
+		It("unsupported curve", func() {
+			publicKey = ecpointgrouplaw.ScalarBaseMult(ecpointgrouplaw.Edwards25519(), big.NewInt(5))
+			_, err := DeriveChildKey(publicKey, make([]byte, ChainCodeSize), []uint32{0})
+			Expect(err).Should(Equal(ecpointgrouplaw.ErrInvalidCurve))
+		})
+	})
+
+	It("derives child keys with the chain code generated jointly", func() {
+		threshold := uint32(3)
+		dkgs, listeners := newPedersenDKGs(curve, threshold, []uint32{0, 0, 1, 1, 1})
+		doneChs := make(map[string]chan struct{}, len(listeners))
+		for id, l := range listeners {
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		var expected *Result
+		bks := make(birkhoffinterpolation.BkParameters, 0, len(dkgs))
+		sgs := make([]*ecpointgrouplaw.ECPoint, 0, len(dkgs))
+		for id, d := range dkgs {
+			d.Stop()
+			r, err := d.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(r.ChainCode).Should(HaveLen(ChainCodeSize))
+
+			child, err := r.DeriveChild(id, "m/0/7")
+			Expect(err).Should(BeNil())
+			if expected == nil {
+				expected = child
+			}
+			// All the participants get the same child key
+			Expect(r.ChainCode).ShouldNot(Equal(child.ChainCode))
+			Expect(child.ChainCode).Should(Equal(expected.ChainCode))
+			Expect(child.PublicKey.Equal(expected.PublicKey)).Should(BeTrue())
+			Expect(child.PublicShares[id].Equal(ecpointgrouplaw.ScalarBaseMult(curve, child.Share))).Should(BeTrue())
+			Expect(tss.ValidatePublicShares(log.Discard(), child.Bks, child.PublicShares, threshold, child.PublicKey)).Should(BeNil())
+			bks = append(bks, r.Bks[id])
+			sgs = append(sgs, ecpointgrouplaw.ScalarBaseMult(curve, child.Share))
+		}
+		// The tweaked shares could recover the child public key
+		Expect(tss.ValidatePublicKey(log.Discard(), bks, sgs, threshold, expected.PublicKey)).Should(BeNil())
+
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+})
+
+// decodeXpub returns the public key and the chain code of the base58check encoded extended public key
+func decodeXpub(xpub string) (*ecpointgrouplaw.ECPoint, []byte) {
+	const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	n := big.NewInt(0)
+	for _, c := range xpub {
+		n.Mul(n, big.NewInt(58))
+		n.Add(n, big.NewInt(int64(strings.IndexRune(alphabet, c))))
+	}
+	bs := n.Bytes()
+	// version(4) || depth(1) || fingerprint(4) || child number(4) || chain code(32) || key(33) || checksum(4)
+	ExpectWithOffset(1, bs).Should(HaveLen(82))
+	h := sha256.Sum256(bs[:78])
+	h = sha256.Sum256(h[:])
+	ExpectWithOffset(1, bs[78:]).Should(Equal(h[:4]))
+	pubkey, err := btcec.ParsePubKey(bs[45:78], btcec.S256())
+	ExpectWithOffset(1, err).Should(BeNil())
+	publicKey, err := ecpointgrouplaw.NewECPoint(btcec.S256(), pubkey.X, pubkey.Y)
+	ExpectWithOffset(1, err).Should(BeNil())
+	return publicKey, bs[13:45]
+}
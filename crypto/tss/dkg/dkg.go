@@ -17,6 +17,7 @@ package dkg
 import (
 	"crypto/elliptic"
 	"math/big"
+	"sort"
 
 	"github.com/getamis/alice/crypto/birkhoffinterpolation"
 	"github.com/getamis/alice/crypto/ecpointgrouplaw"
@@ -27,6 +28,8 @@ import (
 	"github.com/getamis/sirius/log"
 )
 
+// DKG generates the shares of a key. The complaint and reveal rounds are echo broadcasts, so the peers must route
+// the received *message.EchoMessage to AddMessage as well.
 type DKG struct {
 	ph *peerHandler
 	*message.MsgMain
@@ -36,33 +39,79 @@ type Result struct {
 	PublicKey *ecpointgrouplaw.ECPoint
 	Share     *big.Int
 	Bks       map[string]*birkhoffinterpolation.BkParameter
+	// PublicShares are the verified public shares (i.e. s_i*G) of the participants, including self
+	PublicShares map[string]*ecpointgrouplaw.ECPoint
+	// Disqualified is the sorted ids of the peers which are failed to reveal valid shares
+	Disqualified []string
+	// Extracted is the sorted ids of the peers which sent the Feldman commitments inconsistent with the shares. Their
+	// secrets are reconstructed by the others and still contribute to the public key, but they are not in Bks.
+	Extracted []string
+	// ChainCode is the BIP-32 chain code generated jointly by the qualified participants
+	ChainCode []byte
 }
 
-func NewDKG(curve elliptic.Curve, peerManager types.PeerManager, threshold uint32, rank uint32, listener types.StateChangedListener) (*DKG, error) {
+func NewDKG(curve elliptic.Curve, peerManager types.PeerManager, sessionID []byte, threshold uint32, rank uint32, listener types.StateChangedListener) (*DKG, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
 	peerNum := peerManager.NumPeers()
 	if err := ensureRandAndThreshold(rank, threshold, peerNum); err != nil {
 		return nil, err
 	}
-	ph, err := newPeerHandler(curve, peerManager, threshold, rank)
+	ph, err := newPeerHandler(curve, peerManager, sessionID, threshold, rank)
 	if err != nil {
 		return nil, err
 	}
-	return &DKG{
-		ph:      ph,
-		MsgMain: message.NewMsgMain(peerManager.SelfID(), peerNum, listener, ph, types.MessageType(Type_Peer), types.MessageType(Type_Decommit), types.MessageType(Type_Verify), types.MessageType(Type_Result)),
-	}, nil
+	return newDKGWithCurrentHandler(peerManager, sessionID, listener, ph, ph), nil
+}
+
+// NewPedersenDKG creates a DKG in the Pedersen mode (GJKR). Each peer commits its polynomial by Pedersen
+// commitments with a nothing-up-my-sleeve hidding point first, and reveals the Feldman commitments only after
+// all the shares are verified. The result is the same as the one of NewDKG. A peer revealing invalid Feldman
+// commitments aborts the process instead of having its secret reconstructed by the others (cf. step 4 of the
+// extraction phase of GJKR), so it could still bias the public key by aborting.
+func NewPedersenDKG(curve elliptic.Curve, peerManager types.PeerManager, sessionID []byte, threshold uint32, rank uint32, listener types.StateChangedListener) (*DKG, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	peerNum := peerManager.NumPeers()
+	if err := ensureRandAndThreshold(rank, threshold, peerNum); err != nil {
+		return nil, err
+	}
+	ph, err := newPedersenPeerHandler(curve, peerManager, sessionID, threshold, rank)
+	if err != nil {
+		return nil, err
+	}
+	return newDKGWithCurrentHandler(peerManager, sessionID, listener, ph, ph), nil
 }
 
 // For testing use
-func newDKGWithHandler(peerManager types.PeerManager, threshold uint32, rank uint32, listener types.StateChangedListener, ph *peerHandler) (*DKG, error) {
+func newDKGWithHandler(peerManager types.PeerManager, sessionID []byte, threshold uint32, rank uint32, listener types.StateChangedListener, ph *peerHandler) (*DKG, error) {
 	peerNum := peerManager.NumPeers()
 	if err := ensureRandAndThreshold(rank, threshold, peerNum); err != nil {
 		return nil, err
 	}
-	return &DKG{
+	return newDKGWithCurrentHandler(peerManager, sessionID, listener, ph, ph), nil
+}
+
+func newDKGWithCurrentHandler(peerManager types.PeerManager, sessionID []byte, listener types.StateChangedListener, ph *peerHandler, handler types.Handler) *DKG {
+	msgTypes := []types.MessageType{types.MessageType(Type_Peer), types.MessageType(Type_Decommit), types.MessageType(Type_Verify), types.MessageType(Type_Complaint), types.MessageType(Type_Reveal), types.MessageType(Type_Result)}
+	if ph != nil && ph.isPedersen() {
+		msgTypes = []types.MessageType{types.MessageType(Type_Peer), types.MessageType(Type_PedersenVerify), types.MessageType(Type_Complaint), types.MessageType(Type_Reveal), types.MessageType(Type_Feldman), types.MessageType(Type_FeldmanComplaint), types.MessageType(Type_Extract), types.MessageType(Type_Result)}
+	}
+	d := &DKG{
 		ph:      ph,
-		MsgMain: message.NewMsgMain(peerManager.SelfID(), peerNum, listener, ph, types.MessageType(Type_Peer), types.MessageType(Type_Decommit), types.MessageType(Type_Verify), types.MessageType(Type_Result)),
-	}, nil
+		MsgMain: message.NewMsgMain(peerManager, sessionID, listener, handler, msgTypes...),
+	}
+	// The disqualification depends on the complaints and the revealed shares, so all the peers must receive the
+	// same ones. Otherwise, the qualified peers may be different and the honest peers get inconsistent results.
+	// So do the extraction on the Feldman commitments and the Feldman complaints in the Pedersen mode.
+	echoTypes := []types.MessageType{types.MessageType(Type_Complaint), types.MessageType(Type_Reveal)}
+	if ph != nil && ph.isPedersen() {
+		echoTypes = append(echoTypes, types.MessageType(Type_Feldman), types.MessageType(Type_FeldmanComplaint), types.MessageType(Type_Extract))
+	}
+	d.EnableEchoBroadcast(echoTypes...)
+	return d
 }
 
 func ensureRandAndThreshold(rank uint32, threshold uint32, peerNum uint32) error {
@@ -76,7 +125,7 @@ func ensureRandAndThreshold(rank uint32, threshold uint32, peerNum uint32) error
 	return nil
 }
 
-// GetResult returns the final result: public key, share, bks (including self bk)
+// GetResult returns the final result: public key, share, bks and public shares (including self ones)
 func (d *DKG) GetResult() (*Result, error) {
 	if d.GetState() != types.StateDone {
 		return nil, tss.ErrNotReady
@@ -89,15 +138,34 @@ func (d *DKG) GetResult() (*Result, error) {
 		return nil, tss.ErrNotReady
 	}
 
+	publicShares, err := rh.getPublicShares()
+	if err != nil {
+		log.Error("Failed to get public shares", "err", err)
+		return nil, err
+	}
 	bks := make(map[string]*birkhoffinterpolation.BkParameter, d.ph.peerManager.NumPeers()+1)
 	bks[d.ph.peerManager.SelfID()] = d.ph.bk
 	for id, peer := range d.ph.peers {
 		bks[id] = peer.peer.bk
 	}
+	disqualified := make([]string, 0, len(d.ph.disqualified))
+	for id := range d.ph.disqualified {
+		disqualified = append(disqualified, id)
+	}
+	sort.Strings(disqualified)
+	extracted := make([]string, 0, len(d.ph.extracted))
+	for id := range d.ph.extracted {
+		extracted = append(extracted, id)
+	}
+	sort.Strings(extracted)
 	return &Result{
-		PublicKey: rh.publicKey,
-		Share:     rh.share,
-		Bks:       bks,
+		PublicKey:    rh.publicKey,
+		Share:        rh.share,
+		Bks:          bks,
+		PublicShares: publicShares,
+		Disqualified: disqualified,
+		Extracted:    extracted,
+		ChainCode:    rh.getChainCode(),
 	}, nil
 }
 
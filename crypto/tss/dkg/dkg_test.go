@@ -15,6 +15,7 @@ package dkg
 
 import (
 	"crypto/elliptic"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -22,16 +23,20 @@ import (
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/commitment"
 	"github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/polynomial"
 	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/message/types/mocks"
 	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/sirius/log"
 	proto "github.com/golang/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDKG(t *testing.T) {
@@ -93,10 +98,14 @@ var _ = Describe("DKG", func() {
 		}
 		fmt.Printf("secret: %d\n", secret)
 		pubkey := ecpointgrouplaw.ScalarBaseMult(c, secret)
-		for _, d := range dkgs {
+		for id, d := range dkgs {
 			r, err := d.GetResult()
 			Expect(err).Should(BeNil())
 			Expect(r.PublicKey.Equal(pubkey)).Should(BeTrue())
+			// The public shares are consistent with the shares and the public key
+			Expect(r.PublicShares).Should(HaveLen(len(dkgs)))
+			Expect(r.PublicShares[id].Equal(ecpointgrouplaw.ScalarBaseMult(c, r.Share))).Should(BeTrue())
+			Expect(tss.ValidatePublicShares(log.Discard(), r.Bks, r.PublicShares, threshold, r.PublicKey)).Should(BeNil())
 		}
 
 		for _, l := range listeners {
@@ -108,6 +117,11 @@ var _ = Describe("DKG", func() {
 				0, 0, 0, 0, 0,
 			},
 		),
+		Entry("Ed25519", ecpointgrouplaw.Edwards25519(), uint32(3),
+			[]uint32{
+				0, 0, 1, 1,
+			},
+		),
 		/*
 			Entry("Case #1", curve, uint32(3),
 				[]uint32{
@@ -122,6 +136,310 @@ var _ = Describe("DKG", func() {
 		*/
 	)
 
+	DescribeTable("NewPedersenDKG()", func(c elliptic.Curve, threshold uint32, ranks []uint32) {
+		dkgs, listeners := newPedersenDKGs(c, threshold, ranks)
+		doneChs := make(map[string]chan struct{}, len(listeners))
+		for id, l := range listeners {
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+		// Send out peer message
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			Expect(msg.GetPeer().GetCommitment()).Should(BeNil())
+			Expect(msg.GetPeer().GetPedersenCommitment().GetPoints()).Should(HaveLen(int(threshold)))
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		secret := big.NewInt(0)
+		for _, d := range dkgs {
+			d.Stop()
+			secret = new(big.Int).Add(secret, d.GetU0())
+		}
+		pubkey := ecpointgrouplaw.ScalarBaseMult(c, secret)
+		bks := make(birkhoffinterpolation.BkParameters, 0, len(dkgs))
+		sgs := make([]*ecpointgrouplaw.ECPoint, 0, len(dkgs))
+		for id, d := range dkgs {
+			r, err := d.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(r.PublicKey.Equal(pubkey)).Should(BeTrue())
+			Expect(r.Bks).Should(HaveLen(len(dkgs)))
+			bks = append(bks, r.Bks[id])
+			sgs = append(sgs, ecpointgrouplaw.ScalarBaseMult(c, r.Share))
+		}
+		// The shares could recover the public key
+		Expect(tss.ValidatePublicKey(log.Discard(), bks, sgs, threshold, pubkey)).Should(BeNil())
+
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	},
+		Entry("Case #0", curve, uint32(3), []uint32{0, 0, 0, 0, 0}),
+		Entry("Case #1", curve, uint32(3), []uint32{0, 0, 1, 1, 1}),
+		Entry("Case #2", elliptic.P256(), uint32(3), []uint32{0, 0, 1, 1}),
+		Entry("Ed25519", ecpointgrouplaw.Edwards25519(), uint32(3), []uint32{0, 0, 1, 1}),
+	)
+
+	DescribeTable("complaints", func(newDKG newDKGFunc, threshold uint32, ranks []uint32, badReveal bool, failed bool) {
+		badID, victimID := getID(0), getID(1)
+		dkgs, listeners := newDKGsByFunc(newDKG, curve, threshold, ranks)
+		// The bad peer sends a wrong share to the victim, and may reveal a wrong one to all the peers
+		dkgs[badID].ph.peerManager = &tamperPeerManager{
+			peerManager: dkgs[badID].ph.peerManager.(*peerManager),
+			tamper: func(id string, msg *Message) {
+				switch {
+				case msg.Type == Type_Verify && id == victimID:
+					msg.GetVerify().GetVerify().Evaluation = addOne(msg.GetVerify().GetVerify().GetEvaluation())
+				case msg.Type == Type_PedersenVerify && id == victimID:
+					msg.GetPedersenVerify().GetVerify().Evaluation = addOne(msg.GetPedersenVerify().GetVerify().GetEvaluation())
+				case msg.Type == Type_Reveal && badReveal:
+					// Broadcast the same wrong share to all the peers
+					for _, share := range msg.GetReveal().GetShares() {
+						share.Evaluation = addOne(share.GetEvaluation())
+					}
+				}
+			},
+		}
+		disqualified := badReveal
+		honest := make(map[string]*DKG, len(dkgs))
+		for id, d := range dkgs {
+			if !disqualified || id != badID {
+				honest[id] = d
+			}
+		}
+		finalChs := make(map[string]chan struct{}, len(honest))
+		for id := range honest {
+			finalCh := make(chan struct{})
+			finalChs[id] = finalCh
+			newState := types.StateDone
+			if failed {
+				newState = types.StateFailed
+			}
+			listeners[id].On("OnStateChanged", types.StateInit, newState).Run(func(args mock.Arguments) {
+				close(finalCh)
+			}).Once()
+		}
+		// The disqualified peer never finishes, and fails after it's stopped
+		badFailedCh := make(chan struct{})
+		if disqualified {
+			listeners[badID].On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+				close(badFailedCh)
+			}).Once()
+		}
+
+		// Send out peer message
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, finalCh := range finalChs {
+			<-finalCh
+		}
+		for _, d := range dkgs {
+			d.Stop()
+		}
+		if disqualified {
+			<-badFailedCh
+		}
+		if failed {
+			for _, d := range dkgs {
+				r, err := d.GetResult()
+				Expect(err).Should(Equal(tss.ErrNotReady))
+				Expect(r).Should(BeNil())
+			}
+			return
+		}
+
+		secret := big.NewInt(0)
+		for _, d := range honest {
+			secret = new(big.Int).Add(secret, d.GetU0())
+		}
+		pubkey := ecpointgrouplaw.ScalarBaseMult(curve, secret)
+		bks := make(birkhoffinterpolation.BkParameters, 0, len(honest))
+		sgs := make([]*ecpointgrouplaw.ECPoint, 0, len(honest))
+		for id, d := range honest {
+			r, err := d.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(r.PublicKey.Equal(pubkey)).Should(BeTrue())
+			Expect(r.Bks).Should(HaveLen(len(honest)))
+			Expect(r.PublicShares).Should(HaveLen(len(honest)))
+			Expect(tss.ValidatePublicShares(log.Discard(), r.Bks, r.PublicShares, threshold, r.PublicKey)).Should(BeNil())
+			if disqualified {
+				Expect(r.Disqualified).Should(Equal([]string{badID}))
+			} else {
+				Expect(r.Disqualified).Should(BeEmpty())
+			}
+			bks = append(bks, r.Bks[id])
+			sgs = append(sgs, ecpointgrouplaw.ScalarBaseMult(curve, r.Share))
+		}
+		// The shares of the qualified peers could recover the public key
+		Expect(tss.ValidatePublicKey(log.Discard(), bks, sgs, threshold, pubkey)).Should(BeNil())
+
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	},
+		Entry("valid reveal", NewDKG, uint32(3), []uint32{0, 0, 0, 0}, false, false),
+		Entry("invalid reveal", NewDKG, uint32(3), []uint32{0, 0, 0, 0}, true, false),
+		Entry("invalid reveal with invalid remaining ranks", NewDKG, uint32(3), []uint32{0, 0, 0}, true, true),
+		Entry("valid reveal in the Pedersen mode", NewPedersenDKG, uint32(3), []uint32{0, 0, 0, 0}, false, false),
+		Entry("invalid reveal in the Pedersen mode", NewPedersenDKG, uint32(3), []uint32{0, 0, 1, 1}, true, false),
+		Entry("invalid reveal with invalid remaining ranks in the Pedersen mode", NewPedersenDKG, uint32(3), []uint32{0, 0, 0}, true, true),
+	)
+
+	It("inconsistent complaints", func() {
+		badID, victimID, fooledID := getID(0), getID(1), getID(2)
+		dkgs, listeners := newDKGs(curve, uint32(3), []uint32{0, 0, 0, 0})
+		// The bad peer accuses the victim only to the fooled peer, which would disqualify the victim by itself
+		dkgs[badID].ph.peerManager = &tamperPeerManager{
+			peerManager: dkgs[badID].ph.peerManager.(*peerManager),
+			tamper: func(id string, msg *Message) {
+				if msg.Type == Type_Complaint && id == fooledID {
+					msg.GetComplaint().AccusedIds = []string{victimID}
+				}
+			},
+		}
+		failedChs := make(map[string]chan struct{}, len(dkgs))
+		for id := range dkgs {
+			failedCh := make(chan struct{})
+			failedChs[id] = failedCh
+			listeners[id].On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+				close(failedCh)
+			}).Once()
+		}
+
+		// Send out peer message
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for id, failedCh := range failedChs {
+			if id != badID {
+				<-failedCh
+			}
+		}
+		// The bad peer never finishes, and fails after it's stopped
+		for _, d := range dkgs {
+			d.Stop()
+		}
+		<-failedChs[badID]
+
+		for id, d := range dkgs {
+			if id == badID {
+				continue
+			}
+			f := d.GetFailure()
+			Expect(f).ShouldNot(BeNil())
+			Expect(errors.Is(f.Err, message.ErrInconsistentBroadcast)).Should(BeTrue())
+			Expect(f.MessageType).Should(Equal(types.MessageType(Type_Complaint)))
+			Expect(f.Culprits).Should(ContainElement(badID))
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	DescribeTable("invalid Feldman commitments", func(threshold uint32, ranks []uint32, failed bool) {
+		badID := getID(0)
+		dkgs, listeners := newPedersenDKGs(curve, threshold, ranks)
+		// The bad peer reveals the Feldman commitments of another polynomial to all the peers
+		poly, err := polynomial.RandomPolynomial(curve.Params().N, threshold-1)
+		Expect(err).Should(BeNil())
+		dkgs[badID].ph.feldmanCommitmenter, err = commitment.NewFeldmanCommitmenter(curve, poly)
+		Expect(err).Should(BeNil())
+		finalChs := make(map[string]chan struct{}, len(dkgs))
+		for id := range dkgs {
+			finalCh := make(chan struct{})
+			finalChs[id] = finalCh
+			newState := types.StateDone
+			if failed || id == badID {
+				newState = types.StateFailed
+			}
+			listeners[id].On("OnStateChanged", types.StateInit, newState).Run(func(args mock.Arguments) {
+				close(finalCh)
+			}).Once()
+		}
+
+		// Send out peer message
+		for fromID, fromD := range dkgs {
+			msg := fromD.GetPeerMessage()
+			for toID, toD := range dkgs {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, finalCh := range finalChs {
+			<-finalCh
+		}
+		for _, d := range dkgs {
+			d.Stop()
+		}
+		// The bad peer finds itself extracted
+		Expect(dkgs[badID].GetFailure().Err).Should(Equal(ErrSelfExtracted))
+		if failed {
+			for id, d := range dkgs {
+				if id == badID {
+					continue
+				}
+				r, err := d.GetResult()
+				Expect(err).Should(Equal(tss.ErrNotReady))
+				Expect(r).Should(BeNil())
+				Expect(d.GetFailure().MessageType).Should(Equal(types.MessageType(Type_FeldmanComplaint)))
+			}
+			return
+		}
+
+		// The secret of the bad peer is reconstructed, so the public key is the same as the one without extraction
+		secret := big.NewInt(0)
+		for _, d := range dkgs {
+			secret = new(big.Int).Add(secret, d.GetU0())
+		}
+		pubkey := ecpointgrouplaw.ScalarBaseMult(curve, secret)
+		for id, d := range dkgs {
+			if id == badID {
+				continue
+			}
+			r, err := d.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(r.PublicKey.Equal(pubkey)).Should(BeTrue())
+			Expect(r.Bks).Should(HaveLen(len(dkgs) - 1))
+			Expect(r.Bks).ShouldNot(HaveKey(badID))
+			Expect(r.Disqualified).Should(BeEmpty())
+			Expect(r.Extracted).Should(Equal([]string{badID}))
+			Expect(tss.ValidatePublicShares(log.Discard(), r.Bks, r.PublicShares, threshold, r.PublicKey)).Should(BeNil())
+			Expect(r.PublicShares[id].Equal(ecpointgrouplaw.ScalarBaseMult(curve, r.Share))).Should(BeTrue())
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	},
+		Entry("extracts the bad peer", uint32(3), []uint32{0, 0, 1, 1}, false),
+		Entry("invalid remaining ranks", uint32(3), []uint32{0, 0, 0}, true),
+	)
+
 	DescribeTable("newDKGWithHandler", func(c elliptic.Curve, threshold uint32, coefficients [][]*big.Int, x []*big.Int, ranks []uint32, expectShare []*big.Int, expPubKey *ecpointgrouplaw.ECPoint) {
 		// new peer managers and dkgs
 		lens := len(ranks)
@@ -136,11 +454,11 @@ var _ = Describe("DKG", func() {
 			peerManagers[i] = pm
 			poly, err := polynomial.NewPolynomial(c.Params().N, coefficients[i])
 			Expect(err).Should(BeNil())
-			ph, err := newPeerHandlerWithPolynomial(c, peerManagers[i], threshold, x[i], ranks[i], poly)
+			ph, err := newPeerHandlerWithPolynomial(c, peerManagers[i], sessionID, threshold, x[i], ranks[i], poly)
 			Expect(err).Should(BeNil())
 			listener[i] = new(mocks.StateChangedListener)
 			listener[i].On("OnStateChanged", types.StateInit, types.StateDone).Once()
-			dkgs[id], err = newDKGWithHandler(peerManagers[i], threshold, ranks[i], listener[i], ph)
+			dkgs[id], err = newDKGWithHandler(peerManagers[i], sessionID, threshold, ranks[i], listener[i], ph)
 			Expect(err).Should(BeNil())
 			dkgs[id].Start()
 			bks[id] = birkhoffinterpolation.NewBkParameter(x[i], ranks[i])
@@ -389,11 +707,11 @@ var _ = Describe("DKG", func() {
 			peerManagers[i] = pm
 			poly, err := polynomial.NewPolynomial(c.Params().N, coefficients[i])
 			Expect(err).Should(BeNil())
-			ph, err := newPeerHandlerWithPolynomial(c, peerManagers[i], threshold, x[i], ranks[i], poly)
+			ph, err := newPeerHandlerWithPolynomial(c, peerManagers[i], sessionID, threshold, x[i], ranks[i], poly)
 			Expect(err).Should(BeNil())
 			listener[i] = new(mocks.StateChangedListener)
 			listener[i].On("OnStateChanged", types.StateInit, types.StateFailed).Once()
-			dkgs[id], err = newDKGWithHandler(peerManagers[i], threshold, ranks[i], listener[i], ph)
+			dkgs[id], err = newDKGWithHandler(peerManagers[i], sessionID, threshold, ranks[i], listener[i], ph)
 			Expect(err).Should(BeNil())
 			dkgs[id].Start()
 		}
@@ -494,42 +812,56 @@ var _ = Describe("DKG", func() {
 			peerManagers[i] = pm
 			poly, err := polynomial.NewPolynomial(curve.Params().N, coefficients[i])
 			Expect(err).Should(BeNil())
-			ph, err := newPeerHandlerWithPolynomial(curve, peerManagers[i], threshold, x[i], ranks[i], poly)
+			ph, err := newPeerHandlerWithPolynomial(curve, peerManagers[i], sessionID, threshold, x[i], ranks[i], poly)
 			Expect(err).Should(Equal(utils.ErrLargeThreshold))
 			Expect(ph).Should(BeNil())
 		}
 	})
 
 	Context("negative cases", func() {
+		It("empty session id", func() {
+			d, err := NewDKG(curve, newPeerManager("id", 4), nil, 3, 0, nil)
+			Expect(err).Should(Equal(tss.ErrEmptySessionID))
+			Expect(d).Should(BeNil())
+		})
+
+		It("message of other session", func() {
+			d, err := NewDKG(curve, newPeerManager("id", 4), sessionID, 3, 0, nil)
+			Expect(err).Should(BeNil())
+			msg := d.GetPeerMessage()
+			msg.SessionId = []byte("other session")
+			Expect(d.AddMessage(msg)).Should(Equal(message.ErrInvalidSession))
+		})
+
 		It("larger threshold", func() {
-			d, err := NewDKG(curve, newPeerManager("id", 4), 6, 0, nil)
+			d, err := NewDKG(curve, newPeerManager("id", 4), sessionID, 6, 0, nil)
 			Expect(err).Should(Equal(utils.ErrLargeThreshold))
 			Expect(d).Should(BeNil())
 
-			d, err = newDKGWithHandler(newPeerManager("id", 4), 6, 0, nil, nil)
+			d, err = newDKGWithHandler(newPeerManager("id", 4), sessionID, 6, 0, nil, nil)
 			Expect(err).Should(Equal(utils.ErrLargeThreshold))
 			Expect(d).Should(BeNil())
 		})
 
 		It("large rank", func() {
-			d, err := NewDKG(curve, newPeerManager("id", 4), 3, 3, nil)
+			d, err := NewDKG(curve, newPeerManager("id", 4), sessionID, 3, 3, nil)
 			Expect(err).Should(Equal(utils.ErrLargeRank))
 			Expect(d).Should(BeNil())
 
-			d, err = newDKGWithHandler(newPeerManager("id", 4), 3, 3, nil, nil)
+			d, err = newDKGWithHandler(newPeerManager("id", 4), sessionID, 3, 3, nil, nil)
 			Expect(err).Should(Equal(utils.ErrLargeRank))
 			Expect(d).Should(BeNil())
 		})
 
 		It("larger threshold", func() {
-			d, err := NewDKG(curve, newPeerManager("id", 4), 5, 0, nil)
+			d, err := NewDKG(curve, newPeerManager("id", 4), sessionID, 5, 0, nil)
 			Expect(err).Should(BeNil())
 			Expect(d).ShouldNot(BeNil())
 			r, err := d.GetResult()
 			Expect(err).Should(Equal(tss.ErrNotReady))
 			Expect(r).Should(BeNil())
 
-			d, err = newDKGWithHandler(newPeerManager("id", 4), 5, 0, nil, nil)
+			d, err = newDKGWithHandler(newPeerManager("id", 4), sessionID, 5, 0, nil, nil)
 			Expect(err).Should(BeNil())
 			Expect(d).ShouldNot(BeNil())
 			r, err = d.GetResult()
@@ -539,10 +871,20 @@ var _ = Describe("DKG", func() {
 	})
 })
 
+var sessionID = []byte("session")
+
 func getID(id int) string {
 	return fmt.Sprintf("id-%d", id)
 }
 
+// expectBlame expects that the error blames the sender of the message with the message as the evidence.
+func expectBlame(err error, msg *Message) {
+	var blameErr *message.BlameError
+	ExpectWithOffset(1, errors.As(err, &blameErr)).Should(BeTrue())
+	ExpectWithOffset(1, blameErr.Culprits).Should(Equal([]string{msg.GetId()}))
+	ExpectWithOffset(1, blameErr.Evidence).Should(Equal([]types.Message{msg}))
+}
+
 type peerManager struct {
 	id       string
 	numPeers uint32
@@ -568,13 +910,56 @@ func (p *peerManager) SelfID() string {
 	return p.id
 }
 
+func (p *peerManager) PeerIDs() []string {
+	ids := make([]string, 0, len(p.dkgs))
+	for id := range p.dkgs {
+		if id != p.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func (p *peerManager) MustSend(id string, message proto.Message) {
 	d := p.dkgs[id]
 	msg := message.(types.Message)
 	Expect(d.AddMessage(msg)).Should(BeNil())
 }
 
+// tamperPeerManager tampers the messages before sending them out
+type tamperPeerManager struct {
+	*peerManager
+
+	tamper func(id string, msg *Message)
+}
+
+func (p *tamperPeerManager) MustSend(id string, message proto.Message) {
+	msg, ok := proto.Clone(message).(*Message)
+	if !ok {
+		// Echo messages are not tampered
+		_ = p.dkgs[id].AddMessage(message.(types.Message))
+		return
+	}
+	p.tamper(id, msg)
+	// The messages may be rejected as old ones if we are disqualified and the others finish earlier
+	_ = p.dkgs[id].AddMessage(msg)
+}
+
+func addOne(bs []byte) []byte {
+	return new(big.Int).Add(new(big.Int).SetBytes(bs), big.NewInt(1)).Bytes()
+}
+
 func newDKGs(curve elliptic.Curve, threshold uint32, ranks []uint32) (map[string]*DKG, map[string]*mocks.StateChangedListener) {
+	return newDKGsByFunc(NewDKG, curve, threshold, ranks)
+}
+
+func newPedersenDKGs(curve elliptic.Curve, threshold uint32, ranks []uint32) (map[string]*DKG, map[string]*mocks.StateChangedListener) {
+	return newDKGsByFunc(NewPedersenDKG, curve, threshold, ranks)
+}
+
+type newDKGFunc func(curve elliptic.Curve, peerManager types.PeerManager, sessionID []byte, threshold uint32, rank uint32, listener types.StateChangedListener) (*DKG, error)
+
+func newDKGsByFunc(newDKG newDKGFunc, curve elliptic.Curve, threshold uint32, ranks []uint32) (map[string]*DKG, map[string]*mocks.StateChangedListener) {
 	lens := len(ranks)
 	dkgs := make(map[string]*DKG, lens)
 	peerManagers := make([]types.PeerManager, lens)
@@ -586,7 +971,7 @@ func newDKGs(curve elliptic.Curve, threshold uint32, ranks []uint32) (map[string
 		peerManagers[i] = pm
 		listeners[id] = new(mocks.StateChangedListener)
 		var err error
-		dkgs[id], err = NewDKG(curve, peerManagers[i], threshold, ranks[i], listeners[id])
+		dkgs[id], err = newDKG(curve, peerManagers[i], sessionID, threshold, ranks[i], listeners[id])
 		Expect(err).Should(BeNil())
 		r, err := dkgs[id].GetResult()
 		Expect(r).Should(BeNil())
@@ -19,6 +19,9 @@ import (
 )
 
 func (m *Message) IsValid() bool {
+	if len(m.GetSessionId()) == 0 {
+		return false
+	}
 	switch m.Type {
 	case Type_Peer:
 		return m.GetPeer() != nil
@@ -28,6 +31,26 @@ func (m *Message) IsValid() bool {
 		return m.GetVerify() != nil
 	case Type_Result:
 		return m.GetResult() != nil
+	case Type_PedersenVerify:
+		return m.GetPedersenVerify() != nil
+	case Type_Feldman:
+		return m.GetFeldman() != nil
+	case Type_Complaint:
+		return m.GetComplaint() != nil
+	case Type_Reveal:
+		return m.GetReveal() != nil
+	case Type_FeldmanComplaint:
+		return m.GetFeldmanComplaint() != nil
+	case Type_Extract:
+		return m.GetExtract() != nil
+	case Type_BatchPeer:
+		return m.GetBatchPeer() != nil
+	case Type_BatchDecommit:
+		return m.GetBatchDecommit() != nil
+	case Type_BatchVerify:
+		return m.GetBatchVerify() != nil
+	case Type_BatchResult:
+		return m.GetBatchResult() != nil
 	}
 	return false
 }
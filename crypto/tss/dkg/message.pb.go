@@ -23,27 +23,62 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// The new types are appended to keep the old values, and the rounds are ordered by MsgMain instead of the values.
+// The default mode: Peer, Decommit, Verify, Complaint, Reveal and Result
+// The Pedersen mode: Peer, PedersenVerify, Complaint, Reveal, Feldman, FeldmanComplaint, Extract and Result. The
+// Extract round is skipped if there is no valid Feldman complaint.
+// The batch mode: BatchPeer, BatchDecommit, BatchVerify and BatchResult
 type Type int32
 
 const (
-	Type_Peer     Type = 0
-	Type_Decommit Type = 1
-	Type_Verify   Type = 2
-	Type_Result   Type = 3
+	Type_Peer             Type = 0
+	Type_Decommit         Type = 1
+	Type_Verify           Type = 2
+	Type_Result           Type = 3
+	Type_PedersenVerify   Type = 4
+	Type_Complaint        Type = 5
+	Type_Reveal           Type = 6
+	Type_Feldman          Type = 7
+	Type_BatchPeer        Type = 8
+	Type_BatchDecommit    Type = 9
+	Type_BatchVerify      Type = 10
+	Type_BatchResult      Type = 11
+	Type_FeldmanComplaint Type = 12
+	Type_Extract          Type = 13
 )
 
 var Type_name = map[int32]string{
-	0: "Peer",
-	1: "Decommit",
-	2: "Verify",
-	3: "Result",
+	0:  "Peer",
+	1:  "Decommit",
+	2:  "Verify",
+	3:  "Result",
+	4:  "PedersenVerify",
+	5:  "Complaint",
+	6:  "Reveal",
+	7:  "Feldman",
+	8:  "BatchPeer",
+	9:  "BatchDecommit",
+	10: "BatchVerify",
+	11: "BatchResult",
+	12: "FeldmanComplaint",
+	13: "Extract",
 }
 
 var Type_value = map[string]int32{
-	"Peer":     0,
-	"Decommit": 1,
-	"Verify":   2,
-	"Result":   3,
+	"Peer":             0,
+	"Decommit":         1,
+	"Verify":           2,
+	"Result":           3,
+	"PedersenVerify":   4,
+	"Complaint":        5,
+	"Reveal":           6,
+	"Feldman":          7,
+	"BatchPeer":        8,
+	"BatchDecommit":    9,
+	"BatchVerify":      10,
+	"BatchResult":      11,
+	"FeldmanComplaint": 12,
+	"Extract":          13,
 }
 
 func (x Type) String() string {
@@ -55,13 +90,24 @@ func (Type) EnumDescriptor() ([]byte, []int) {
 }
 
 type Message struct {
-	Type Type   `protobuf:"varint,1,opt,name=type,proto3,enum=dkg.Type" json:"type,omitempty"`
-	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Type      Type   `protobuf:"varint,1,opt,name=type,proto3,enum=dkg.Type" json:"type,omitempty"`
+	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId []byte `protobuf:"bytes,7,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
 	// Types that are valid to be assigned to Body:
 	//	*Message_Peer
 	//	*Message_Decommit
 	//	*Message_Verify
 	//	*Message_Result
+	//	*Message_PedersenVerify
+	//	*Message_Feldman
+	//	*Message_Complaint
+	//	*Message_Reveal
+	//	*Message_BatchPeer
+	//	*Message_BatchDecommit
+	//	*Message_BatchVerify
+	//	*Message_BatchResult
+	//	*Message_FeldmanComplaint
+	//	*Message_Extract
 	Body                 isMessage_Body `protobuf_oneof:"body"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
@@ -107,6 +153,13 @@ func (m *Message) GetId() string {
 	return ""
 }
 
+func (m *Message) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
 type isMessage_Body interface {
 	isMessage_Body()
 }
@@ -127,6 +180,46 @@ type Message_Result struct {
 	Result *BodyResult `protobuf:"bytes,6,opt,name=result,proto3,oneof"`
 }
 
+type Message_PedersenVerify struct {
+	PedersenVerify *BodyPedersenVerify `protobuf:"bytes,8,opt,name=pedersenVerify,proto3,oneof"`
+}
+
+type Message_Feldman struct {
+	Feldman *BodyFeldman `protobuf:"bytes,9,opt,name=feldman,proto3,oneof"`
+}
+
+type Message_Complaint struct {
+	Complaint *BodyComplaint `protobuf:"bytes,10,opt,name=complaint,proto3,oneof"`
+}
+
+type Message_Reveal struct {
+	Reveal *BodyReveal `protobuf:"bytes,11,opt,name=reveal,proto3,oneof"`
+}
+
+type Message_BatchPeer struct {
+	BatchPeer *BodyBatchPeer `protobuf:"bytes,12,opt,name=batchPeer,proto3,oneof"`
+}
+
+type Message_BatchDecommit struct {
+	BatchDecommit *BodyBatchDecommit `protobuf:"bytes,13,opt,name=batchDecommit,proto3,oneof"`
+}
+
+type Message_BatchVerify struct {
+	BatchVerify *BodyBatchVerify `protobuf:"bytes,14,opt,name=batchVerify,proto3,oneof"`
+}
+
+type Message_BatchResult struct {
+	BatchResult *BodyBatchResult `protobuf:"bytes,15,opt,name=batchResult,proto3,oneof"`
+}
+
+type Message_FeldmanComplaint struct {
+	FeldmanComplaint *BodyFeldmanComplaint `protobuf:"bytes,16,opt,name=feldmanComplaint,proto3,oneof"`
+}
+
+type Message_Extract struct {
+	Extract *BodyExtract `protobuf:"bytes,17,opt,name=extract,proto3,oneof"`
+}
+
 func (*Message_Peer) isMessage_Body() {}
 
 func (*Message_Decommit) isMessage_Body() {}
@@ -135,6 +228,26 @@ func (*Message_Verify) isMessage_Body() {}
 
 func (*Message_Result) isMessage_Body() {}
 
+func (*Message_PedersenVerify) isMessage_Body() {}
+
+func (*Message_Feldman) isMessage_Body() {}
+
+func (*Message_Complaint) isMessage_Body() {}
+
+func (*Message_Reveal) isMessage_Body() {}
+
+func (*Message_BatchPeer) isMessage_Body() {}
+
+func (*Message_BatchDecommit) isMessage_Body() {}
+
+func (*Message_BatchVerify) isMessage_Body() {}
+
+func (*Message_BatchResult) isMessage_Body() {}
+
+func (*Message_FeldmanComplaint) isMessage_Body() {}
+
+func (*Message_Extract) isMessage_Body() {}
+
 func (m *Message) GetBody() isMessage_Body {
 	if m != nil {
 		return m.Body
@@ -170,6 +283,76 @@ func (m *Message) GetResult() *BodyResult {
 	return nil
 }
 
+func (m *Message) GetPedersenVerify() *BodyPedersenVerify {
+	if x, ok := m.GetBody().(*Message_PedersenVerify); ok {
+		return x.PedersenVerify
+	}
+	return nil
+}
+
+func (m *Message) GetFeldman() *BodyFeldman {
+	if x, ok := m.GetBody().(*Message_Feldman); ok {
+		return x.Feldman
+	}
+	return nil
+}
+
+func (m *Message) GetComplaint() *BodyComplaint {
+	if x, ok := m.GetBody().(*Message_Complaint); ok {
+		return x.Complaint
+	}
+	return nil
+}
+
+func (m *Message) GetReveal() *BodyReveal {
+	if x, ok := m.GetBody().(*Message_Reveal); ok {
+		return x.Reveal
+	}
+	return nil
+}
+
+func (m *Message) GetBatchPeer() *BodyBatchPeer {
+	if x, ok := m.GetBody().(*Message_BatchPeer); ok {
+		return x.BatchPeer
+	}
+	return nil
+}
+
+func (m *Message) GetBatchDecommit() *BodyBatchDecommit {
+	if x, ok := m.GetBody().(*Message_BatchDecommit); ok {
+		return x.BatchDecommit
+	}
+	return nil
+}
+
+func (m *Message) GetBatchVerify() *BodyBatchVerify {
+	if x, ok := m.GetBody().(*Message_BatchVerify); ok {
+		return x.BatchVerify
+	}
+	return nil
+}
+
+func (m *Message) GetBatchResult() *BodyBatchResult {
+	if x, ok := m.GetBody().(*Message_BatchResult); ok {
+		return x.BatchResult
+	}
+	return nil
+}
+
+func (m *Message) GetFeldmanComplaint() *BodyFeldmanComplaint {
+	if x, ok := m.GetBody().(*Message_FeldmanComplaint); ok {
+		return x.FeldmanComplaint
+	}
+	return nil
+}
+
+func (m *Message) GetExtract() *BodyExtract {
+	if x, ok := m.GetBody().(*Message_Extract); ok {
+		return x.Extract
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -177,15 +360,29 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_Decommit)(nil),
 		(*Message_Verify)(nil),
 		(*Message_Result)(nil),
+		(*Message_PedersenVerify)(nil),
+		(*Message_Feldman)(nil),
+		(*Message_Complaint)(nil),
+		(*Message_Reveal)(nil),
+		(*Message_BatchPeer)(nil),
+		(*Message_BatchDecommit)(nil),
+		(*Message_BatchVerify)(nil),
+		(*Message_BatchResult)(nil),
+		(*Message_FeldmanComplaint)(nil),
+		(*Message_Extract)(nil),
 	}
 }
 
 type BodyPeer struct {
-	Bk                   *birkhoffinterpolation.BkParameterMessage `protobuf:"bytes,1,opt,name=bk,proto3" json:"bk,omitempty"`
-	Commitment           *commitment.HashCommitmentMessage         `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
-	XXX_unrecognized     []byte                                    `json:"-"`
-	XXX_sizecache        int32                                     `json:"-"`
+	Bk         *birkhoffinterpolation.BkParameterMessage `protobuf:"bytes,1,opt,name=bk,proto3" json:"bk,omitempty"`
+	Commitment *commitment.HashCommitmentMessage         `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	// pedersenCommitment is only set in the Pedersen mode
+	PedersenCommitment *commitment.PointCommitmentMessage `protobuf:"bytes,3,opt,name=pedersenCommitment,proto3" json:"pedersenCommitment,omitempty"`
+	// chainCodeCommitment commits the contribution to the BIP-32 chain code
+	ChainCodeCommitment  *commitment.HashCommitmentMessage `protobuf:"bytes,4,opt,name=chainCodeCommitment,proto3" json:"chainCodeCommitment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
 }
 
 func (m *BodyPeer) Reset()         { *m = BodyPeer{} }
@@ -227,6 +424,20 @@ func (m *BodyPeer) GetCommitment() *commitment.HashCommitmentMessage {
 	return nil
 }
 
+func (m *BodyPeer) GetPedersenCommitment() *commitment.PointCommitmentMessage {
+	if m != nil {
+		return m.PedersenCommitment
+	}
+	return nil
+}
+
+func (m *BodyPeer) GetChainCodeCommitment() *commitment.HashCommitmentMessage {
+	if m != nil {
+		return m.ChainCodeCommitment
+	}
+	return nil
+}
+
 type BodyDecommit struct {
 	HashDecommitment     *commitment.HashDecommitmentMessage `protobuf:"bytes,1,opt,name=hashDecommitment,proto3" json:"hashDecommitment,omitempty"`
 	PointCommitment      *commitment.PointCommitmentMessage  `protobuf:"bytes,2,opt,name=pointCommitment,proto3" json:"pointCommitment,omitempty"`
@@ -314,10 +525,11 @@ func (m *BodyVerify) GetVerify() *commitment.FeldmanVerifyMessage {
 }
 
 type BodyResult struct {
-	SiGProofMsg          *zkproof.SchnorrProofMessage `protobuf:"bytes,1,opt,name=siGProofMsg,proto3" json:"siGProofMsg,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
-	XXX_unrecognized     []byte                       `json:"-"`
-	XXX_sizecache        int32                        `json:"-"`
+	SiGProofMsg           *zkproof.SchnorrProofMessage        `protobuf:"bytes,1,opt,name=siGProofMsg,proto3" json:"siGProofMsg,omitempty"`
+	ChainCodeDecommitment *commitment.HashDecommitmentMessage `protobuf:"bytes,2,opt,name=chainCodeDecommitment,proto3" json:"chainCodeDecommitment,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}                            `json:"-"`
+	XXX_unrecognized      []byte                              `json:"-"`
+	XXX_sizecache         int32                               `json:"-"`
 }
 
 func (m *BodyResult) Reset()         { *m = BodyResult{} }
@@ -352,6 +564,497 @@ func (m *BodyResult) GetSiGProofMsg() *zkproof.SchnorrProofMessage {
 	return nil
 }
 
+func (m *BodyResult) GetChainCodeDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.ChainCodeDecommitment
+	}
+	return nil
+}
+
+type BodyPedersenVerify struct {
+	Verify               *commitment.PedersenVerifyMessage `protobuf:"bytes,1,opt,name=verify,proto3" json:"verify,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *BodyPedersenVerify) Reset()         { *m = BodyPedersenVerify{} }
+func (m *BodyPedersenVerify) String() string { return proto.CompactTextString(m) }
+func (*BodyPedersenVerify) ProtoMessage()    {}
+func (*BodyPedersenVerify) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727ac1befdf68301, []int{5}
+}
+
+func (m *BodyPedersenVerify) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyPedersenVerify.Unmarshal(m, b)
+}
+func (m *BodyPedersenVerify) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyPedersenVerify.Marshal(b, m, deterministic)
+}
+func (m *BodyPedersenVerify) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyPedersenVerify.Merge(m, src)
+}
+func (m *BodyPedersenVerify) XXX_Size() int {
+	return xxx_messageInfo_BodyPedersenVerify.Size(m)
+}
+func (m *BodyPedersenVerify) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyPedersenVerify.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyPedersenVerify proto.InternalMessageInfo
+
+func (m *BodyPedersenVerify) GetVerify() *commitment.PedersenVerifyMessage {
+	if m != nil {
+		return m.Verify
+	}
+	return nil
+}
+
+type BodyFeldman struct {
+	PointCommitment      *commitment.PointCommitmentMessage `protobuf:"bytes,1,opt,name=pointCommitment,proto3" json:"pointCommitment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *BodyFeldman) Reset()         { *m = BodyFeldman{} }
+func (m *BodyFeldman) String() string { return proto.CompactTextString(m) }
+func (*BodyFeldman) ProtoMessage()    {}
+func (*BodyFeldman) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727ac1befdf68301, []int{6}
+}
+
+func (m *BodyFeldman) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyFeldman.Unmarshal(m, b)
+}
+func (m *BodyFeldman) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyFeldman.Marshal(b, m, deterministic)
+}
+func (m *BodyFeldman) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyFeldman.Merge(m, src)
+}
+func (m *BodyFeldman) XXX_Size() int {
+	return xxx_messageInfo_BodyFeldman.Size(m)
+}
+func (m *BodyFeldman) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyFeldman.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyFeldman proto.InternalMessageInfo
+
+func (m *BodyFeldman) GetPointCommitment() *commitment.PointCommitmentMessage {
+	if m != nil {
+		return m.PointCommitment
+	}
+	return nil
+}
+
+type BodyComplaint struct {
+	// accusedIds are the ids of the peers whose shares are failed to verify
+	AccusedIds           []string `protobuf:"bytes,1,rep,name=accusedIds,proto3" json:"accusedIds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BodyComplaint) Reset()         { *m = BodyComplaint{} }
+func (m *BodyComplaint) String() string { return proto.CompactTextString(m) }
+func (*BodyComplaint) ProtoMessage()    {}
+func (*BodyComplaint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727ac1befdf68301, []int{7}
+}
+
+func (m *BodyComplaint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyComplaint.Unmarshal(m, b)
+}
+func (m *BodyComplaint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyComplaint.Marshal(b, m, deterministic)
+}
+func (m *BodyComplaint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyComplaint.Merge(m, src)
+}
+func (m *BodyComplaint) XXX_Size() int {
+	return xxx_messageInfo_BodyComplaint.Size(m)
+}
+func (m *BodyComplaint) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyComplaint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyComplaint proto.InternalMessageInfo
+
+func (m *BodyComplaint) GetAccusedIds() []string {
+	if m != nil {
+		return m.AccusedIds
+	}
+	return nil
+}
+
+type BodyReveal struct {
+	Shares               []*RevealedShare `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BodyReveal) Reset()         { *m = BodyReveal{} }
+func (m *BodyReveal) String() string { return proto.CompactTextString(m) }
+func (*BodyReveal) ProtoMessage()    {}
+func (*BodyReveal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727ac1befdf68301, []int{8}
+}
+
+func (m *BodyReveal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyReveal.Unmarshal(m, b)
+}
+func (m *BodyReveal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyReveal.Marshal(b, m, deterministic)
+}
+func (m *BodyReveal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyReveal.Merge(m, src)
+}
+func (m *BodyReveal) XXX_Size() int {
+	return xxx_messageInfo_BodyReveal.Size(m)
+}
+func (m *BodyReveal) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyReveal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyReveal proto.InternalMessageInfo
+
+func (m *BodyReveal) GetShares() []*RevealedShare {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+// RevealedShare is the disputed share sent to the peer complaining about it
+type RevealedShare struct {
+	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Evaluation []byte `protobuf:"bytes,2,opt,name=evaluation,proto3" json:"evaluation,omitempty"`
+	// salt is only set in the Pedersen mode
+	Salt                 []byte   `protobuf:"bytes,3,opt,name=salt,proto3" json:"salt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevealedShare) Reset()         { *m = RevealedShare{} }
+func (m *RevealedShare) String() string { return proto.CompactTextString(m) }
+func (*RevealedShare) ProtoMessage()    {}
+func (*RevealedShare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727ac1befdf68301, []int{9}
+}
+
+func (m *RevealedShare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevealedShare.Unmarshal(m, b)
+}
+func (m *RevealedShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevealedShare.Marshal(b, m, deterministic)
+}
+func (m *RevealedShare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevealedShare.Merge(m, src)
+}
+func (m *RevealedShare) XXX_Size() int {
+	return xxx_messageInfo_RevealedShare.Size(m)
+}
+func (m *RevealedShare) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevealedShare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevealedShare proto.InternalMessageInfo
+
+func (m *RevealedShare) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RevealedShare) GetEvaluation() []byte {
+	if m != nil {
+		return m.Evaluation
+	}
+	return nil
+}
+
+func (m *RevealedShare) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+type BodyFeldmanComplaint struct {
+	// shares are the shares inconsistent with the Feldman commitments of the accused peers. The id of each share is
+	// the accused peer.
+	Shares               []*RevealedShare `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BodyFeldmanComplaint) Reset()         { *m = BodyFeldmanComplaint{} }
+func (m *BodyFeldmanComplaint) String() string { return proto.CompactTextString(m) }
+func (*BodyFeldmanComplaint) ProtoMessage()    {}
+func (*BodyFeldmanComplaint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727ac1befdf68301, []int{10}
+}
+
+func (m *BodyFeldmanComplaint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyFeldmanComplaint.Unmarshal(m, b)
+}
+func (m *BodyFeldmanComplaint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyFeldmanComplaint.Marshal(b, m, deterministic)
+}
+func (m *BodyFeldmanComplaint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyFeldmanComplaint.Merge(m, src)
+}
+func (m *BodyFeldmanComplaint) XXX_Size() int {
+	return xxx_messageInfo_BodyFeldmanComplaint.Size(m)
+}
+func (m *BodyFeldmanComplaint) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyFeldmanComplaint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyFeldmanComplaint proto.InternalMessageInfo
+
+func (m *BodyFeldmanComplaint) GetShares() []*RevealedShare {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+type BodyExtract struct {
+	// shares are the shares sent by the extracted peers. The id of each share is the extracted peer.
+	Shares               []*RevealedShare `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BodyExtract) Reset()         { *m = BodyExtract{} }
+func (m *BodyExtract) String() string { return proto.CompactTextString(m) }
+func (*BodyExtract) ProtoMessage()    {}
+func (*BodyExtract) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727ac1befdf68301, []int{11}
+}
+
+func (m *BodyExtract) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyExtract.Unmarshal(m, b)
+}
+func (m *BodyExtract) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyExtract.Marshal(b, m, deterministic)
+}
+func (m *BodyExtract) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyExtract.Merge(m, src)
+}
+func (m *BodyExtract) XXX_Size() int {
+	return xxx_messageInfo_BodyExtract.Size(m)
+}
+func (m *BodyExtract) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyExtract.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyExtract proto.InternalMessageInfo
+
+func (m *BodyExtract) GetShares() []*RevealedShare {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+// The batch bodies carry the messages of all the keys in the batch. The i-th element belongs to the i-th key.
+type BodyBatchPeer struct {
+	Bk                   *birkhoffinterpolation.BkParameterMessage `protobuf:"bytes,1,opt,name=bk,proto3" json:"bk,omitempty"`
+	Commitments          []*commitment.HashCommitmentMessage       `protobuf:"bytes,2,rep,name=commitments,proto3" json:"commitments,omitempty"`
+	ChainCodeCommitments []*commitment.HashCommitmentMessage       `protobuf:"bytes,3,rep,name=chainCodeCommitments,proto3" json:"chainCodeCommitments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
+	XXX_unrecognized     []byte                                    `json:"-"`
+	XXX_sizecache        int32                                     `json:"-"`
+}
+
+func (m *BodyBatchPeer) Reset()         { *m = BodyBatchPeer{} }
+func (m *BodyBatchPeer) String() string { return proto.CompactTextString(m) }
+func (*BodyBatchPeer) ProtoMessage()    {}
+func (*BodyBatchPeer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727ac1befdf68301, []int{12}
+}
+
+func (m *BodyBatchPeer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyBatchPeer.Unmarshal(m, b)
+}
+func (m *BodyBatchPeer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyBatchPeer.Marshal(b, m, deterministic)
+}
+func (m *BodyBatchPeer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyBatchPeer.Merge(m, src)
+}
+func (m *BodyBatchPeer) XXX_Size() int {
+	return xxx_messageInfo_BodyBatchPeer.Size(m)
+}
+func (m *BodyBatchPeer) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyBatchPeer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyBatchPeer proto.InternalMessageInfo
+
+func (m *BodyBatchPeer) GetBk() *birkhoffinterpolation.BkParameterMessage {
+	if m != nil {
+		return m.Bk
+	}
+	return nil
+}
+
+func (m *BodyBatchPeer) GetCommitments() []*commitment.HashCommitmentMessage {
+	if m != nil {
+		return m.Commitments
+	}
+	return nil
+}
+
+func (m *BodyBatchPeer) GetChainCodeCommitments() []*commitment.HashCommitmentMessage {
+	if m != nil {
+		return m.ChainCodeCommitments
+	}
+	return nil
+}
+
+type BodyBatchDecommit struct {
+	HashDecommitments    []*commitment.HashDecommitmentMessage `protobuf:"bytes,1,rep,name=hashDecommitments,proto3" json:"hashDecommitments,omitempty"`
+	PointCommitments     []*commitment.PointCommitmentMessage  `protobuf:"bytes,2,rep,name=pointCommitments,proto3" json:"pointCommitments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
+	XXX_unrecognized     []byte                                `json:"-"`
+	XXX_sizecache        int32                                 `json:"-"`
+}
+
+func (m *BodyBatchDecommit) Reset()         { *m = BodyBatchDecommit{} }
+func (m *BodyBatchDecommit) String() string { return proto.CompactTextString(m) }
+func (*BodyBatchDecommit) ProtoMessage()    {}
+func (*BodyBatchDecommit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727ac1befdf68301, []int{13}
+}
+
+func (m *BodyBatchDecommit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyBatchDecommit.Unmarshal(m, b)
+}
+func (m *BodyBatchDecommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyBatchDecommit.Marshal(b, m, deterministic)
+}
+func (m *BodyBatchDecommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyBatchDecommit.Merge(m, src)
+}
+func (m *BodyBatchDecommit) XXX_Size() int {
+	return xxx_messageInfo_BodyBatchDecommit.Size(m)
+}
+func (m *BodyBatchDecommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyBatchDecommit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyBatchDecommit proto.InternalMessageInfo
+
+func (m *BodyBatchDecommit) GetHashDecommitments() []*commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.HashDecommitments
+	}
+	return nil
+}
+
+func (m *BodyBatchDecommit) GetPointCommitments() []*commitment.PointCommitmentMessage {
+	if m != nil {
+		return m.PointCommitments
+	}
+	return nil
+}
+
+type BodyBatchVerify struct {
+	Verifies             []*commitment.FeldmanVerifyMessage `protobuf:"bytes,1,rep,name=verifies,proto3" json:"verifies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *BodyBatchVerify) Reset()         { *m = BodyBatchVerify{} }
+func (m *BodyBatchVerify) String() string { return proto.CompactTextString(m) }
+func (*BodyBatchVerify) ProtoMessage()    {}
+func (*BodyBatchVerify) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727ac1befdf68301, []int{14}
+}
+
+func (m *BodyBatchVerify) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyBatchVerify.Unmarshal(m, b)
+}
+func (m *BodyBatchVerify) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyBatchVerify.Marshal(b, m, deterministic)
+}
+func (m *BodyBatchVerify) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyBatchVerify.Merge(m, src)
+}
+func (m *BodyBatchVerify) XXX_Size() int {
+	return xxx_messageInfo_BodyBatchVerify.Size(m)
+}
+func (m *BodyBatchVerify) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyBatchVerify.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyBatchVerify proto.InternalMessageInfo
+
+func (m *BodyBatchVerify) GetVerifies() []*commitment.FeldmanVerifyMessage {
+	if m != nil {
+		return m.Verifies
+	}
+	return nil
+}
+
+type BodyBatchResult struct {
+	SiGProofMsgs           []*zkproof.SchnorrProofMessage        `protobuf:"bytes,1,rep,name=siGProofMsgs,proto3" json:"siGProofMsgs,omitempty"`
+	ChainCodeDecommitments []*commitment.HashDecommitmentMessage `protobuf:"bytes,2,rep,name=chainCodeDecommitments,proto3" json:"chainCodeDecommitments,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                              `json:"-"`
+	XXX_unrecognized       []byte                                `json:"-"`
+	XXX_sizecache          int32                                 `json:"-"`
+}
+
+func (m *BodyBatchResult) Reset()         { *m = BodyBatchResult{} }
+func (m *BodyBatchResult) String() string { return proto.CompactTextString(m) }
+func (*BodyBatchResult) ProtoMessage()    {}
+func (*BodyBatchResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727ac1befdf68301, []int{15}
+}
+
+func (m *BodyBatchResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyBatchResult.Unmarshal(m, b)
+}
+func (m *BodyBatchResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyBatchResult.Marshal(b, m, deterministic)
+}
+func (m *BodyBatchResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyBatchResult.Merge(m, src)
+}
+func (m *BodyBatchResult) XXX_Size() int {
+	return xxx_messageInfo_BodyBatchResult.Size(m)
+}
+func (m *BodyBatchResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyBatchResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyBatchResult proto.InternalMessageInfo
+
+func (m *BodyBatchResult) GetSiGProofMsgs() []*zkproof.SchnorrProofMessage {
+	if m != nil {
+		return m.SiGProofMsgs
+	}
+	return nil
+}
+
+func (m *BodyBatchResult) GetChainCodeDecommitments() []*commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.ChainCodeDecommitments
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("dkg.Type", Type_name, Type_value)
 	proto.RegisterType((*Message)(nil), "dkg.Message")
@@ -359,6 +1062,17 @@ func init() {
 	proto.RegisterType((*BodyDecommit)(nil), "dkg.BodyDecommit")
 	proto.RegisterType((*BodyVerify)(nil), "dkg.BodyVerify")
 	proto.RegisterType((*BodyResult)(nil), "dkg.BodyResult")
+	proto.RegisterType((*BodyPedersenVerify)(nil), "dkg.BodyPedersenVerify")
+	proto.RegisterType((*BodyFeldman)(nil), "dkg.BodyFeldman")
+	proto.RegisterType((*BodyComplaint)(nil), "dkg.BodyComplaint")
+	proto.RegisterType((*BodyReveal)(nil), "dkg.BodyReveal")
+	proto.RegisterType((*RevealedShare)(nil), "dkg.RevealedShare")
+	proto.RegisterType((*BodyFeldmanComplaint)(nil), "dkg.BodyFeldmanComplaint")
+	proto.RegisterType((*BodyExtract)(nil), "dkg.BodyExtract")
+	proto.RegisterType((*BodyBatchPeer)(nil), "dkg.BodyBatchPeer")
+	proto.RegisterType((*BodyBatchDecommit)(nil), "dkg.BodyBatchDecommit")
+	proto.RegisterType((*BodyBatchVerify)(nil), "dkg.BodyBatchVerify")
+	proto.RegisterType((*BodyBatchResult)(nil), "dkg.BodyBatchResult")
 }
 
 func init() {
@@ -366,36 +1080,71 @@ func init() {
 }
 
 var fileDescriptor_727ac1befdf68301 = []byte{
-	// 494 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xcf, 0x6e, 0xd3, 0x4c,
-	0x14, 0xc5, 0x63, 0xc7, 0x9f, 0xbf, 0xf4, 0xa6, 0xb4, 0x66, 0x56, 0x56, 0x04, 0x52, 0x70, 0x37,
-	0x29, 0x8b, 0xb1, 0x14, 0x04, 0x2a, 0x9b, 0x4a, 0xa4, 0xa8, 0x64, 0xd1, 0x8a, 0xc8, 0x20, 0xf6,
-	0xfe, 0x33, 0xb1, 0x47, 0x8e, 0x3d, 0xd6, 0xcc, 0x14, 0xc9, 0x3c, 0x01, 0x2f, 0xc3, 0x9b, 0xf1,
-	0x10, 0xc8, 0x33, 0xb6, 0xe3, 0x04, 0xa4, 0xec, 0x3c, 0x77, 0x7e, 0xe7, 0xcc, 0xf1, 0xbd, 0x17,
-	0xde, 0xa6, 0x54, 0x66, 0x4f, 0x11, 0x8e, 0x59, 0xe1, 0xa7, 0x44, 0x86, 0x05, 0x15, 0x7e, 0xb8,
-	0xa3, 0x31, 0xf1, 0x63, 0x5e, 0x57, 0x92, 0xf9, 0x52, 0x08, 0x3f, 0xc9, 0x53, 0xbf, 0x20, 0x42,
-	0x84, 0x29, 0xc1, 0x15, 0x67, 0x92, 0xa1, 0x71, 0x92, 0xa7, 0xb3, 0xdb, 0x53, 0xda, 0x88, 0xf2,
-	0x3c, 0x63, 0xdb, 0x2d, 0x2d, 0x25, 0xe1, 0x15, 0xdb, 0x85, 0x92, 0xb2, 0xd2, 0x8f, 0x72, 0x6d,
-	0x32, 0xbb, 0x39, 0xa5, 0x8f, 0x59, 0x51, 0x50, 0x59, 0x90, 0x52, 0x1e, 0x3e, 0x3f, 0x3b, 0x99,
-	0xfa, 0x47, 0x5e, 0x71, 0xc6, 0xb6, 0x87, 0x32, 0xef, 0xb7, 0x01, 0xff, 0x3f, 0xea, 0x0a, 0x7a,
-	0x09, 0x96, 0xac, 0x2b, 0xe2, 0x1a, 0x73, 0x63, 0x71, 0xb1, 0x3c, 0xc3, 0x49, 0x9e, 0xe2, 0xaf,
-	0x75, 0x45, 0x02, 0x55, 0x46, 0x17, 0x60, 0xd2, 0xc4, 0x35, 0xe7, 0xc6, 0xe2, 0x2c, 0x30, 0x69,
-	0x82, 0xae, 0xc0, 0xaa, 0x08, 0xe1, 0xee, 0x78, 0x6e, 0x2c, 0xa6, 0xcb, 0x67, 0x0a, 0x5f, 0xb1,
-	0xa4, 0xde, 0x10, 0xc2, 0xd7, 0xa3, 0x40, 0x5d, 0x22, 0x1f, 0x26, 0x09, 0xd1, 0xa1, 0x5d, 0x4b,
-	0x81, 0xcf, 0x7b, 0xf0, 0x63, 0x7b, 0xb1, 0x1e, 0x05, 0x3d, 0x84, 0xae, 0xc1, 0xfe, 0x4e, 0x38,
-	0xdd, 0xd6, 0xee, 0x7f, 0x0a, 0xbf, 0xec, 0xf1, 0x6f, 0xaa, 0xbc, 0x1e, 0x05, 0x2d, 0xd0, 0xa0,
-	0x9c, 0x88, 0xa7, 0x9d, 0x74, 0xed, 0x23, 0x34, 0x50, 0xe5, 0x06, 0xd5, 0xc0, 0xca, 0x06, 0x2b,
-	0x62, 0x49, 0xed, 0xfd, 0x34, 0x60, 0xd2, 0x65, 0x44, 0xef, 0xc1, 0x8c, 0x72, 0xf5, 0xb7, 0xd3,
-	0xe5, 0x35, 0xfe, 0xe7, 0x64, 0xf0, 0x2a, 0xdf, 0x84, 0x3c, 0x2c, 0x88, 0x24, 0xbc, 0x6d, 0x53,
-	0x60, 0x46, 0x39, 0xfa, 0x00, 0xb0, 0x9f, 0x84, 0xea, 0xc9, 0x74, 0xf9, 0x0a, 0xef, 0x4b, 0x78,
-	0x1d, 0x8a, 0xec, 0xae, 0x3f, 0x76, 0xd2, 0x81, 0xc8, 0xfb, 0x65, 0xc0, 0xf9, 0xb0, 0x0b, 0xe8,
-	0x33, 0x38, 0x59, 0x28, 0xb2, 0xee, 0xac, 0x9c, 0x75, 0xb8, 0xab, 0x63, 0xe7, 0x21, 0xd3, 0x79,
-	0xff, 0x25, 0x46, 0x0f, 0x70, 0x59, 0x31, 0x5a, 0xca, 0xbb, 0xe3, 0xa4, 0xde, 0xd0, 0x6f, 0x73,
-	0x88, 0x74, 0x76, 0xc7, 0x52, 0xef, 0x1e, 0x60, 0x3f, 0x05, 0x74, 0xd3, 0x8f, 0x49, 0x47, 0x9c,
-	0x0f, 0x2d, 0xef, 0xc9, 0x2e, 0x29, 0xc2, 0x52, 0xa3, 0x9d, 0x61, 0xcb, 0x7b, 0x0f, 0xda, 0x47,
-	0x8f, 0x08, 0xdd, 0xc2, 0x54, 0xd0, 0x4f, 0x9b, 0x66, 0x33, 0x1f, 0x45, 0xda, 0x9a, 0xbd, 0xc0,
-	0xed, 0xb2, 0xe2, 0x2f, 0x71, 0x56, 0x32, 0xce, 0xf5, 0x7d, 0x6b, 0x34, 0x14, 0xbc, 0x7e, 0x07,
-	0x56, 0xb3, 0xa2, 0x68, 0x02, 0x56, 0x33, 0x53, 0x67, 0x84, 0xce, 0x61, 0xd2, 0x75, 0xc1, 0x31,
-	0x10, 0x80, 0xad, 0x63, 0x38, 0x66, 0xf3, 0xad, 0x5f, 0x75, 0xc6, 0x91, 0xad, 0xd6, 0xff, 0xcd,
-	0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x0f, 0x73, 0x32, 0x57, 0xed, 0x03, 0x00, 0x00,
+	// 1051 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdd, 0x6e, 0xe3, 0x44,
+	0x14, 0x8e, 0x9d, 0x6c, 0x9a, 0x1c, 0x27, 0xad, 0x7b, 0x28, 0xcb, 0x50, 0x2d, 0xab, 0xe0, 0xbd,
+	0xe9, 0xae, 0x90, 0x23, 0x15, 0x21, 0xb5, 0x12, 0xaa, 0xd8, 0x14, 0x76, 0xb3, 0x12, 0x4b, 0x8b,
+	0x0b, 0x48, 0x68, 0xaf, 0x1c, 0x7b, 0x92, 0x58, 0x49, 0x6c, 0xcb, 0xe3, 0x56, 0x84, 0x17, 0xe2,
+	0x0a, 0x09, 0x6e, 0x90, 0x78, 0x14, 0x5e, 0x80, 0xe7, 0x40, 0x9e, 0xb1, 0x27, 0x63, 0xc7, 0xd0,
+	0x2c, 0x7b, 0xe7, 0x9c, 0xf9, 0xbe, 0x6f, 0x7c, 0xfe, 0x3e, 0x07, 0x3e, 0x9b, 0x05, 0xe9, 0xfc,
+	0x76, 0x62, 0x7b, 0xd1, 0x6a, 0x38, 0xa3, 0xa9, 0xbb, 0x0a, 0xd8, 0xd0, 0x5d, 0x06, 0x1e, 0x1d,
+	0x7a, 0xc9, 0x3a, 0x4e, 0xa3, 0x61, 0xca, 0xd8, 0xd0, 0x5f, 0xcc, 0x86, 0x2b, 0xca, 0x98, 0x3b,
+	0xa3, 0x76, 0x9c, 0x44, 0x69, 0x84, 0x4d, 0x7f, 0x31, 0x3b, 0xbe, 0xb8, 0x8f, 0x3b, 0x09, 0x92,
+	0xc5, 0x3c, 0x9a, 0x4e, 0x83, 0x30, 0xa5, 0x49, 0x1c, 0x2d, 0xdd, 0x34, 0x88, 0xc2, 0xe1, 0x64,
+	0x21, 0x44, 0x8e, 0xcf, 0xee, 0xe3, 0x7b, 0xd1, 0x6a, 0x15, 0xa4, 0x2b, 0x1a, 0xa6, 0xe5, 0xeb,
+	0x8f, 0xef, 0x7d, 0xeb, 0x9f, 0x17, 0x71, 0x12, 0x45, 0xd3, 0x32, 0xcd, 0xfa, 0xb3, 0x0d, 0x7b,
+	0xaf, 0x45, 0x04, 0x3f, 0x82, 0x56, 0xba, 0x8e, 0x29, 0xd1, 0x06, 0xda, 0xc9, 0xfe, 0x69, 0xd7,
+	0xf6, 0x17, 0x33, 0xfb, 0xbb, 0x75, 0x4c, 0x1d, 0x1e, 0xc6, 0x7d, 0xd0, 0x03, 0x9f, 0xe8, 0x03,
+	0xed, 0xa4, 0xeb, 0xe8, 0x81, 0x8f, 0x8f, 0xa0, 0xcb, 0x28, 0x63, 0x41, 0x14, 0xbe, 0xf2, 0xc9,
+	0xde, 0x40, 0x3b, 0xe9, 0x39, 0x9b, 0x00, 0x3e, 0x81, 0x56, 0x4c, 0x69, 0x42, 0x9a, 0x03, 0xed,
+	0xc4, 0x38, 0xed, 0x73, 0xb1, 0x51, 0xe4, 0xaf, 0xaf, 0x29, 0x4d, 0xc6, 0x0d, 0x87, 0x1f, 0xe2,
+	0x10, 0x3a, 0x3e, 0x15, 0x29, 0x91, 0x16, 0x07, 0x1e, 0x4a, 0xe0, 0x97, 0xf9, 0xc1, 0xb8, 0xe1,
+	0x48, 0x10, 0x3e, 0x85, 0xf6, 0x1d, 0x4d, 0x82, 0xe9, 0x9a, 0x3c, 0xe0, 0xf0, 0x03, 0x09, 0xff,
+	0x81, 0x87, 0xc7, 0x0d, 0x27, 0x07, 0x64, 0xd0, 0x84, 0xb2, 0xdb, 0x65, 0x4a, 0xda, 0x15, 0xa8,
+	0xc3, 0xc3, 0x19, 0x54, 0x00, 0xf0, 0x39, 0xec, 0xc7, 0xd4, 0xa7, 0x09, 0xa3, 0xa1, 0x90, 0x21,
+	0x1d, 0x4e, 0xf9, 0x40, 0x79, 0x6b, 0xf5, 0x78, 0xdc, 0x70, 0x2a, 0x04, 0xfc, 0x04, 0xf6, 0xa6,
+	0x74, 0xe9, 0xaf, 0xdc, 0x90, 0x74, 0x39, 0xd7, 0x94, 0xdc, 0x17, 0x22, 0x3e, 0x6e, 0x38, 0x05,
+	0x04, 0x4f, 0xa1, 0xeb, 0x45, 0xab, 0x78, 0xe9, 0x06, 0x61, 0x4a, 0x80, 0xe3, 0x51, 0xe2, 0x2f,
+	0x8b, 0x93, 0x71, 0xc3, 0xd9, 0xc0, 0x44, 0x3e, 0x77, 0xd4, 0x5d, 0x12, 0x63, 0x2b, 0x9f, 0x2c,
+	0x2c, 0xf2, 0xc9, 0x9e, 0x32, 0xf9, 0x89, 0x9b, 0x7a, 0xf3, 0xac, 0xd6, 0xa4, 0x57, 0x91, 0x1f,
+	0x15, 0x27, 0x99, 0xbc, 0x84, 0xe1, 0x05, 0xf4, 0xf9, 0x8f, 0xa2, 0xec, 0xa4, 0xcf, 0x79, 0x0f,
+	0xcb, 0x3c, 0xa5, 0x29, 0x65, 0x38, 0x9e, 0x81, 0xc1, 0x03, 0x79, 0x01, 0xf7, 0x39, 0xfb, 0xa8,
+	0xcc, 0x96, 0xd5, 0x53, 0xa1, 0x92, 0x29, 0xda, 0x42, 0x0e, 0xea, 0x98, 0xb2, 0x65, 0x2a, 0x14,
+	0x5f, 0x82, 0x99, 0x57, 0x54, 0xd6, 0x8c, 0x98, 0x9c, 0xfe, 0x61, 0xb5, 0xfa, 0x6a, 0x51, 0xb7,
+	0x48, 0x59, 0xf7, 0xe8, 0x4f, 0x69, 0xe2, 0x7a, 0x29, 0x39, 0xac, 0x74, 0xef, 0x2b, 0x11, 0xcf,
+	0xba, 0x97, 0x43, 0x46, 0x6d, 0x68, 0x4d, 0x22, 0x7f, 0x6d, 0xfd, 0xae, 0x43, 0xa7, 0x18, 0x69,
+	0x3c, 0x07, 0x7d, 0xb2, 0xe0, 0xab, 0x63, 0x9c, 0x3e, 0xb5, 0x6b, 0xd7, 0xdc, 0x1e, 0x2d, 0xae,
+	0xdd, 0xc4, 0x5d, 0xd1, 0x94, 0x26, 0xf9, 0xce, 0x39, 0xfa, 0x64, 0x81, 0xcf, 0x01, 0x36, 0x6b,
+	0xcd, 0x17, 0xcc, 0x38, 0xfd, 0xd8, 0xde, 0x84, 0xec, 0xb1, 0xcb, 0xe6, 0x97, 0xf2, 0x67, 0x41,
+	0x55, 0x48, 0xe8, 0x00, 0x16, 0x03, 0xb9, 0x01, 0xe6, 0xbb, 0x67, 0xa9, 0x52, 0xd7, 0x51, 0x10,
+	0xa6, 0xdb, 0x5a, 0x35, 0x6c, 0xbc, 0x81, 0xf7, 0xbc, 0xb9, 0x1b, 0x84, 0x97, 0x91, 0x4f, 0x15,
+	0xd1, 0xd6, 0xae, 0xef, 0x57, 0xc7, 0xb6, 0x7e, 0xd5, 0xa0, 0xa7, 0x6e, 0x37, 0x5e, 0x81, 0x39,
+	0x77, 0x99, 0x9c, 0x23, 0x7e, 0x85, 0xa8, 0xe2, 0x93, 0xea, 0x15, 0x2a, 0xa6, 0xb8, 0x64, 0x8b,
+	0x8c, 0x5f, 0xc3, 0x41, 0x5c, 0x4e, 0x92, 0xe8, 0x3b, 0xd7, 0xa1, 0x4a, 0xb5, 0x5e, 0x00, 0x6c,
+	0xdc, 0x05, 0xcf, 0xa4, 0xfd, 0x88, 0x57, 0x1c, 0xa8, 0x92, 0xf9, 0xa4, 0x09, 0x68, 0x21, 0x98,
+	0xe3, 0xad, 0x5f, 0x34, 0x21, 0x94, 0x4f, 0xee, 0x05, 0x18, 0x2c, 0x78, 0x79, 0x9d, 0x19, 0xf2,
+	0x6b, 0x36, 0xcb, 0xd5, 0x1e, 0xd9, 0xb9, 0x47, 0xdb, 0x37, 0xde, 0x3c, 0x8c, 0x92, 0x44, 0x9c,
+	0xe7, 0x4a, 0x2a, 0x01, 0x7f, 0x84, 0xf7, 0x65, 0x75, 0x4b, 0xa5, 0xd3, 0x77, 0x2f, 0x5d, 0xbd,
+	0x82, 0x75, 0x05, 0xb8, 0xed, 0x78, 0x78, 0x5e, 0xc9, 0xbc, 0xd4, 0xff, 0x32, 0xb6, 0x9a, 0xfa,
+	0x1b, 0x30, 0x94, 0x45, 0xac, 0xeb, 0x8f, 0xf6, 0xff, 0xfb, 0x33, 0x84, 0x7e, 0xc9, 0x33, 0xf1,
+	0x31, 0x80, 0xeb, 0x79, 0xb7, 0x8c, 0xfa, 0xaf, 0x7c, 0x46, 0xb4, 0x41, 0xf3, 0xa4, 0xeb, 0x28,
+	0x11, 0xeb, 0xac, 0xe8, 0x03, 0x77, 0xca, 0x67, 0xd0, 0x66, 0x73, 0x37, 0xa1, 0x02, 0x59, 0xd8,
+	0xa4, 0x38, 0xa4, 0xfe, 0x4d, 0x76, 0xe4, 0xe4, 0x08, 0xeb, 0x06, 0xfa, 0xa5, 0x83, 0xfc, 0x83,
+	0xa8, 0xc9, 0x0f, 0xe2, 0x63, 0x00, 0x7a, 0xe7, 0x2e, 0x6f, 0xf9, 0xb2, 0xf3, 0x4e, 0xf4, 0x1c,
+	0x25, 0x82, 0x08, 0x2d, 0xe6, 0x2e, 0xc5, 0x5a, 0xf6, 0x1c, 0xfe, 0x6c, 0x8d, 0xe0, 0xa8, 0xce,
+	0xa5, 0xde, 0xea, 0xc5, 0xce, 0xc1, 0x50, 0x9c, 0xea, 0xad, 0xa8, 0x7f, 0x6b, 0xd0, 0x97, 0x26,
+	0xfb, 0xae, 0x3e, 0x76, 0x09, 0xc6, 0xa6, 0x83, 0x8c, 0xe8, 0x83, 0x66, 0x75, 0x50, 0xea, 0x8d,
+	0x42, 0x65, 0xe1, 0xf7, 0x70, 0x54, 0xe3, 0x1b, 0x8c, 0x34, 0x77, 0x55, 0xab, 0xa5, 0x5b, 0x7f,
+	0x68, 0x70, 0xb8, 0xf5, 0x15, 0xc3, 0x6f, 0xe1, 0xb0, 0xea, 0x1f, 0x45, 0xd5, 0x76, 0x5a, 0xa1,
+	0x6d, 0x36, 0x7e, 0x03, 0x66, 0x65, 0x46, 0x8b, 0x4a, 0xec, 0x32, 0xdf, 0x5b, 0x5c, 0xeb, 0x0a,
+	0x0e, 0x2a, 0xdf, 0x4f, 0xfc, 0x1c, 0x3a, 0x7c, 0xb5, 0x02, 0xd9, 0xe2, 0xfb, 0x7d, 0x48, 0x32,
+	0xac, 0xdf, 0x34, 0x45, 0x31, 0xb7, 0xa3, 0x2f, 0xa0, 0xa7, 0xb8, 0x4b, 0xa1, 0xfa, 0xdf, 0x7e,
+	0x54, 0x62, 0xe0, 0x1b, 0x78, 0x58, 0x6b, 0x27, 0x45, 0xf2, 0x3b, 0x95, 0xf3, 0x5f, 0x24, 0x9e,
+	0xfd, 0xa5, 0x41, 0x2b, 0xfb, 0x23, 0x8a, 0x1d, 0x68, 0x65, 0x43, 0x6a, 0x36, 0xb0, 0x07, 0x9d,
+	0x02, 0x63, 0x6a, 0x08, 0xd0, 0x16, 0xe9, 0x9a, 0x7a, 0xf6, 0x2c, 0xb2, 0x32, 0x9b, 0x88, 0xb0,
+	0x5f, 0xf6, 0x26, 0xb3, 0x85, 0x7d, 0xe8, 0xca, 0x35, 0x33, 0x1f, 0x08, 0x78, 0xb6, 0x1a, 0x66,
+	0x1b, 0x0d, 0xd8, 0xcb, 0x8b, 0x67, 0xee, 0x65, 0x38, 0xb9, 0x15, 0x66, 0x07, 0x0f, 0xa1, 0x5f,
+	0x9a, 0x1d, 0xb3, 0x8b, 0x07, 0x60, 0x28, 0x6d, 0x31, 0x41, 0x06, 0xf2, 0xfb, 0x0d, 0x3c, 0x02,
+	0xb3, 0xba, 0xd9, 0x66, 0x2f, 0xbb, 0x26, 0xdf, 0x55, 0xb3, 0x3f, 0x69, 0xf3, 0xff, 0xe1, 0x9f,
+	0xfe, 0x33, 0x00, 0x63, 0x6b, 0x72, 0x0b, 0x76, 0x0c, 0x00, 0x00,
 }
@@ -20,10 +20,18 @@ import (
 
 type peer struct {
 	*tss.Peer
-	peer     *peerData
-	decommit *decommitData
-	verify   *verifyData
-	result   *resultData
+	peer      *peerData
+	decommit  *decommitData
+	verify    *verifyData
+	complaint *complaintData
+	reveal    *revealData
+	result    *resultData
+
+	// Only used in the Pedersen mode
+	pedersenVerify   *pedersenVerifyData
+	feldman          *feldmanData
+	feldmanComplaint *feldmanComplaintData
+	extract          *extractData
 }
 
 func newPeer(id string) *peer {
@@ -31,3 +39,17 @@ func newPeer(id string) *peer {
 		Peer: tss.NewPeer(id),
 	}
 }
+
+type batchPeer struct {
+	*tss.Peer
+	peer     *batchPeerData
+	decommit *batchDecommitData
+	verify   *batchVerifyData
+	result   *batchResultData
+}
+
+func newBatchPeer(id string) *batchPeer {
+	return &batchPeer{
+		Peer: tss.NewPeer(id),
+	}
+}
@@ -0,0 +1,387 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frost
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrInvalidCommitment is returned if the nonce commitment is invalid
+	ErrInvalidCommitment = errors.New("invalid commitment")
+	// ErrPublicShareNotFound is returned if the public share of a signer is not found
+	ErrPublicShareNotFound = errors.New("public share not found")
+
+	// bindingFactorLabel is the domain separator of the binding factors
+	bindingFactorLabel = []byte("github.com/getamis/alice/crypto/tss/frost/rho")
+)
+
+type commitData struct {
+	d *pt.ECPoint
+	e *pt.ECPoint
+	// rho is the binding factor of the signer, which is computed after all the commitments are received
+	rho *big.Int
+}
+
+type commitHandler struct {
+	publicKey *pt.ECPoint
+	wi        *big.Int
+	msg       []byte
+	sessionID []byte
+
+	// d and e are the hiding nonce and the binding nonce, which are used for only one signature
+	d      *big.Int
+	e      *big.Int
+	commit *commitData
+
+	peerManager types.PeerManager
+	peerNum     uint32
+	peers       map[string]*peer
+}
+
+func newCommitHandler(publicKey *pt.ECPoint, peerManager types.PeerManager, sessionID []byte, secret *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, publicShares map[string]*pt.ECPoint, msg []byte) (*commitHandler, error) {
+	curve := publicKey.GetCurve()
+	if curve != pt.Edwards25519() {
+		log.Warn("Only Ed25519 is supported", "curve", curve.Params().Name)
+		return nil, pt.ErrInvalidCurve
+	}
+	numPeers := peerManager.NumPeers()
+	if len(bks) != int(numPeers+1) {
+		log.Warn("Inconsistent peer num", "bks", len(bks), "numPeers", numPeers)
+		return nil, tss.ErrInconsistentPeerNumAndBks
+	}
+	fieldOrder := curve.Params().N
+	wi, peers, err := buildWiAndPeers(publicKey, bks, publicShares, peerManager.SelfID(), secret)
+	if err != nil {
+		log.Warn("Failed to build wi and peers", "err", err)
+		return nil, err
+	}
+	d, err := utils.RandomPositiveInt(fieldOrder)
+	if err != nil {
+		log.Warn("Failed to generate the hiding nonce", "err", err)
+		return nil, err
+	}
+	e, err := utils.RandomPositiveInt(fieldOrder)
+	if err != nil {
+		log.Warn("Failed to generate the binding nonce", "err", err)
+		return nil, err
+	}
+	return &commitHandler{
+		publicKey: publicKey,
+		wi:        wi,
+		msg:       msg,
+		sessionID: sessionID,
+
+		d: d,
+		e: e,
+		commit: &commitData{
+			d: pt.ScalarBaseMult(curve, d),
+			e: pt.ScalarBaseMult(curve, e),
+		},
+
+		peerManager: peerManager,
+		peerNum:     numPeers,
+		peers:       peers,
+	}, nil
+}
+
+func (p *commitHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_Commit)
+}
+
+func (p *commitHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *commitHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.commit != nil
+}
+
+func (p *commitHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	body := msg.GetCommit()
+	d, err := p.toCommitment(logger, body.GetD())
+	if err != nil {
+		return err
+	}
+	e, err := p.toCommitment(logger, body.GetE())
+	if err != nil {
+		return err
+	}
+	peer.commit = &commitData{
+		d: d,
+		e: e,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *commitHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	r, rho, err := p.computeGroupCommitment(logger)
+	if err != nil {
+		return nil, err
+	}
+	c, err := computeChallenge(r, p.publicKey, p.msg)
+	if err != nil {
+		logger.Warn("Failed to compute challenge", "err", err)
+		return nil, err
+	}
+
+	// z_i = d_i + e_i * rho_i + w_i * c
+	fieldOrder := p.publicKey.GetCurve().Params().N
+	z := new(big.Int).Mul(p.e, rho)
+	z.Add(z, p.d)
+	z.Add(z, new(big.Int).Mul(p.wi, c))
+	z.Mod(z, fieldOrder)
+
+	msg := p.getSignShareMessage(z)
+	for id := range p.peers {
+		p.peerManager.MustSend(id, msg)
+	}
+	return newSignShareHandler(p, r, c, z), nil
+}
+
+func (p *commitHandler) GetCommitMessage() *Message {
+	// Ignore the errors because the points are on Ed25519
+	d, _ := p.commit.d.ToEcPointMessage()
+	e, _ := p.commit.e.ToEcPointMessage()
+	return &Message{
+		Type:      Type_Commit,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_Commit{
+			Commit: &BodyCommit{
+				D: d,
+				E: e,
+			},
+		},
+	}
+}
+
+func (p *commitHandler) getSignShareMessage(z *big.Int) *Message {
+	return &Message{
+		Type:      Type_SignShare,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_SignShare{
+			SignShare: &BodySignShare{
+				Z: z.Bytes(),
+			},
+		},
+	}
+}
+
+// toCommitment converts the message to a commitment, which must be a non-identity point in the prime-order
+// subgroup of Ed25519.
+func (p *commitHandler) toCommitment(logger log.Logger, msg *pt.EcPointMessage) (*pt.ECPoint, error) {
+	point, err := msg.ToPoint()
+	if err != nil {
+		logger.Warn("Failed to convert point", "err", err)
+		return nil, err
+	}
+	// Reject the points with small-order components, since the cofactor of Ed25519 is 8
+	if !point.IsSameCurve(p.publicKey) || point.IsIdentity() || !point.IsInPrimeOrderSubgroup() {
+		logger.Warn("Invalid commitment")
+		return nil, ErrInvalidCommitment
+	}
+	return point, nil
+}
+
+// computeGroupCommitment returns the group commitment R = sum(D_j + rho_j * E_j) and the binding factor of
+// self. The binding factor rho_j binds the commitments of all the signers and the message to each signer, and
+// it's kept in the commitment data to verify the signature share of the signer.
+func (p *commitHandler) computeGroupCommitment(logger log.Logger) (*pt.ECPoint, *big.Int, error) {
+	selfID := p.peerManager.SelfID()
+	commits := make(map[string]*commitData, len(p.peers)+1)
+	commits[selfID] = p.commit
+	for id, peer := range p.peers {
+		commits[id] = peer.commit
+	}
+	ids := make([]string, 0, len(commits))
+	for id := range commits {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	encodedCommits, err := encodeCommits(ids, commits)
+	if err != nil {
+		logger.Warn("Failed to encode commitments", "err", err)
+		return nil, nil, err
+	}
+	curve := p.publicKey.GetCurve()
+	r := pt.NewIdentity(curve)
+	var selfRho *big.Int
+	for _, id := range ids {
+		rho := computeBindingFactor(curve.Params().N, p.sessionID, id, p.msg, encodedCommits)
+		commits[id].rho = rho
+		ri, err := commits[id].d.Add(commits[id].e.ScalarMult(rho))
+		if err != nil {
+			logger.Warn("Failed to add points", "err", err)
+			return nil, nil, err
+		}
+		r, err = r.Add(ri)
+		if err != nil {
+			logger.Warn("Failed to add points", "err", err)
+			return nil, nil, err
+		}
+		if id == selfID {
+			selfRho = rho
+		}
+	}
+	return r, selfRho, nil
+}
+
+// encodeCommits encodes the ids and the commitments in the order of ids.
+func encodeCommits(ids []string, commits map[string]*commitData) ([]byte, error) {
+	var bs []byte
+	for _, id := range ids {
+		d, err := commits[id].d.ToEd25519()
+		if err != nil {
+			return nil, err
+		}
+		e, err := commits[id].e.ToEd25519()
+		if err != nil {
+			return nil, err
+		}
+		bs = appendWithLength(bs, []byte(id))
+		bs = append(bs, d...)
+		bs = append(bs, e...)
+	}
+	return bs, nil
+}
+
+// computeBindingFactor returns SHA512(label || session id || id || msg || commitments) mod n. All the variable
+// length inputs are prefixed with their lengths.
+func computeBindingFactor(fieldOrder *big.Int, sessionID []byte, id string, msg []byte, encodedCommits []byte) *big.Int {
+	var bs []byte
+	bs = append(bs, bindingFactorLabel...)
+	bs = appendWithLength(bs, sessionID)
+	bs = appendWithLength(bs, []byte(id))
+	bs = appendWithLength(bs, msg)
+	bs = append(bs, encodedCommits...)
+	h := sha512.Sum512(bs)
+	return new(big.Int).Mod(new(big.Int).SetBytes(h[:]), fieldOrder)
+}
+
+// computeChallenge returns SHA512(R || A || msg) mod n in RFC 8032, where the hash is a little-endian integer.
+func computeChallenge(r *pt.ECPoint, publicKey *pt.ECPoint, msg []byte) (*big.Int, error) {
+	encodedR, err := r.ToEd25519()
+	if err != nil {
+		return nil, err
+	}
+	encodedA, err := publicKey.ToEd25519()
+	if err != nil {
+		return nil, err
+	}
+	h := sha512.New()
+	_, _ = h.Write(encodedR)
+	_, _ = h.Write(encodedA)
+	_, _ = h.Write(msg)
+	c := new(big.Int).SetBytes(reverse(h.Sum(nil)))
+	return c.Mod(c, publicKey.GetCurve().Params().N), nil
+}
+
+func appendWithLength(bs []byte, v []byte) []byte {
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(v)))
+	bs = append(bs, length...)
+	return append(bs, v...)
+}
+
+// reverse returns the bytes in the reversed order to convert between big-endian and little-endian.
+func reverse(bs []byte) []byte {
+	r := make([]byte, len(bs))
+	for i := range bs {
+		r[len(bs)-1-i] = bs[i]
+	}
+	return r
+}
+
+// buildWiAndPeers returns w_i = share * birkhoff coefficient, so that the sum of w_i of the signers is the
+// private key. The peers are built with W_j = birkhoff coefficient * public share, and the sum of W_j must be
+// the public key.
+func buildWiAndPeers(publicKey *pt.ECPoint, bks map[string]*birkhoffinterpolation.BkParameter, publicShares map[string]*pt.ECPoint, selfID string, secret *big.Int) (*big.Int, map[string]*peer, error) {
+	selfBk, ok := bks[selfID]
+	if !ok {
+		return nil, nil, tss.ErrSelfBKNotFound
+	}
+	allBks := make(birkhoffinterpolation.BkParameters, 0, len(bks))
+	allBks = append(allBks, selfBk)
+	peerIDs := make([]string, 0, len(bks)-1)
+	for id, bk := range bks {
+		if id == selfID {
+			continue
+		}
+		allBks = append(allBks, bk)
+		peerIDs = append(peerIDs, id)
+	}
+
+	curve := publicKey.GetCurve()
+	fieldOrder := curve.Params().N
+	scalars, err := allBks.ComputeBkCoefficient(uint32(len(bks)), fieldOrder)
+	if err != nil {
+		log.Warn("Failed to compute bk coefficient", "allBks", allBks, "err", err)
+		return nil, nil, err
+	}
+	wi := new(big.Int).Mul(secret, scalars[0])
+	wi = wi.Mod(wi, fieldOrder)
+
+	sumW := pt.ScalarBaseMult(curve, wi)
+	peers := make(map[string]*peer, len(peerIDs))
+	for i, id := range peerIDs {
+		publicShare, ok := publicShares[id]
+		if !ok || !publicShare.IsSameCurve(publicKey) {
+			log.Warn("Public share not found", "id", id)
+			return nil, nil, ErrPublicShareNotFound
+		}
+		bigW := publicShare.ScalarMult(scalars[i+1])
+		sumW, err = sumW.Add(bigW)
+		if err != nil {
+			return nil, nil, err
+		}
+		peers[id] = newPeer(id, bigW)
+	}
+	if !sumW.Equal(publicKey) {
+		log.Warn("Inconsistent public key", "got", sumW, "expected", publicKey)
+		return nil, nil, tss.ErrInconsistentPubKey
+	}
+	return wi, peers, nil
+}
+
+func getMessage(messsage types.Message) *Message {
+	return messsage.(*Message)
+}
@@ -0,0 +1,125 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frost
+
+import (
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("commit handler, negative cases", func() {
+	var (
+		signers   map[string]*Signer
+		listeners map[string]*mocks.StateChangedListener
+	)
+	BeforeEach(func() {
+		signers, listeners, _ = newSigners(2, []*birkhoffinterpolation.BkParameter{
+			birkhoffinterpolation.NewBkParameter(big.NewInt(1), 0),
+			birkhoffinterpolation.NewBkParameter(big.NewInt(2), 0),
+		}, []byte{1, 2, 3})
+	})
+
+	AfterEach(func() {
+		stopSigners(signers, listeners)
+	})
+
+	Context("IsHandled", func() {
+		It("peer not found", func() {
+			for _, s := range signers {
+				Expect(s.ch.IsHandled(log.Discard(), "invalid peer")).Should(BeFalse())
+			}
+		})
+
+		It("message is not handled before", func() {
+			for id, s := range signers {
+				for peerID := range s.ch.peers {
+					Expect(s.ch.IsHandled(log.Discard(), peerID)).Should(BeFalse(), id)
+				}
+			}
+		})
+	})
+
+	Context("HandleMessage", func() {
+		It("peer not found", func() {
+			msg := &Message{
+				Id: "invalid peer",
+			}
+			for _, s := range signers {
+				Expect(s.ch.HandleMessage(log.Discard(), msg)).Should(Equal(tss.ErrPeerNotFound))
+			}
+		})
+
+		It("invalid point", func() {
+			for _, s := range signers {
+				for peerID := range s.ch.peers {
+					msg := signers[peerID].GetCommitMessage()
+					msg.GetCommit().D = nil
+					Expect(s.ch.HandleMessage(log.Discard(), msg)).Should(Equal(pt.ErrInvalidPoint))
+				}
+			}
+		})
+
+		It("identity commitment", func() {
+			identity, err := pt.NewIdentity(pt.Edwards25519()).ToEcPointMessage()
+			Expect(err).Should(BeNil())
+			for _, s := range signers {
+				for peerID := range s.ch.peers {
+					msg := signers[peerID].GetCommitMessage()
+					msg.GetCommit().E = identity
+					Expect(s.ch.HandleMessage(log.Discard(), msg)).Should(Equal(ErrInvalidCommitment))
+				}
+			}
+		})
+
+		It("commitment out of the prime-order subgroup", func() {
+			// Add the point of order 2 (i.e. (0, -1)) to the commitment
+			curve := pt.Edwards25519()
+			torsion, err := pt.NewECPoint(curve, big.NewInt(0), new(big.Int).Sub(curve.Params().P, big.NewInt(1)))
+			Expect(err).Should(BeNil())
+			for _, s := range signers {
+				for peerID := range s.ch.peers {
+					msg := signers[peerID].GetCommitMessage()
+					d, err := msg.GetCommit().GetD().ToPoint()
+					Expect(err).Should(BeNil())
+					d, err = d.Add(torsion)
+					Expect(err).Should(BeNil())
+					msg.GetCommit().D, err = d.ToEcPointMessage()
+					Expect(err).Should(BeNil())
+					Expect(s.ch.HandleMessage(log.Discard(), msg)).Should(Equal(ErrInvalidCommitment))
+				}
+			}
+		})
+
+		It("commitment on another curve", func() {
+			other, err := pt.NewBase(btcec.S256()).ToEcPointMessage()
+			Expect(err).Should(BeNil())
+			for _, s := range signers {
+				for peerID := range s.ch.peers {
+					msg := signers[peerID].GetCommitMessage()
+					msg.GetCommit().D = other
+					Expect(s.ch.HandleMessage(log.Discard(), msg)).Should(Equal(ErrInvalidCommitment))
+				}
+			}
+		})
+	})
+})
@@ -0,0 +1,147 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frost
+
+import (
+	"errors"
+	"math/big"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrInvalidSignShare is returned if the signature share is out of range
+	ErrInvalidSignShare = errors.New("invalid signature share")
+	// ErrInconsistentSignShare is returned if the signature share is inconsistent with the commitments and the
+	// public share of the signer
+	ErrInconsistentSignShare = errors.New("inconsistent signature share")
+	// ErrInvalidSignature is returned if the aggregated signature is invalid
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+type signShareData struct {
+	z *big.Int
+}
+
+type signShareHandler struct {
+	*commitHandler
+
+	// r is the group commitment, c is the challenge and z is the signature share of self
+	r *pt.ECPoint
+	c *big.Int
+	z *big.Int
+	s *big.Int
+}
+
+func newSignShareHandler(p *commitHandler, r *pt.ECPoint, c *big.Int, z *big.Int) *signShareHandler {
+	return &signShareHandler{
+		commitHandler: p,
+		r:             r,
+		c:             c,
+		z:             z,
+	}
+}
+
+func (p *signShareHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_SignShare)
+}
+
+func (p *signShareHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *signShareHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.signShare != nil
+}
+
+func (p *signShareHandler) HandleMessage(logger log.Logger, msg types.Message) error {
+	m := getMessage(msg)
+	id := m.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return tss.ErrPeerNotFound
+	}
+
+	curve := p.publicKey.GetCurve()
+	z := new(big.Int).SetBytes(m.GetSignShare().GetZ())
+	if z.Cmp(curve.Params().N) >= 0 {
+		logger.Warn("Signature share out of range")
+		return ErrInvalidSignShare
+	}
+	// Verify z_j * G = D_j + rho_j * E_j + c * W_j
+	exp, err := peer.commit.d.Add(peer.commit.e.ScalarMult(peer.commit.rho))
+	if err != nil {
+		logger.Warn("Failed to add points", "err", err)
+		return err
+	}
+	exp, err = exp.Add(peer.bigW.ScalarMult(p.c))
+	if err != nil {
+		logger.Warn("Failed to add points", "err", err)
+		return err
+	}
+	if !pt.ScalarBaseMult(curve, z).Equal(exp) {
+		logger.Warn("Inconsistent signature share")
+		return message.NewBlameError(ErrInconsistentSignShare, m)
+	}
+	peer.signShare = &signShareData{
+		z: z,
+	}
+	return peer.AddMessage(m)
+}
+
+func (p *signShareHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	curve := p.publicKey.GetCurve()
+	s := new(big.Int).Set(p.z)
+	for _, peer := range p.peers {
+		s.Add(s, peer.signShare.z)
+	}
+	s.Mod(s, curve.Params().N)
+
+	// Verify s * G = R + c * A
+	cA := p.publicKey.ScalarMult(p.c)
+	exp, err := p.r.Add(cA)
+	if err != nil {
+		logger.Warn("Failed to add points", "err", err)
+		return nil, err
+	}
+	if !pt.ScalarBaseMult(curve, s).Equal(exp) {
+		logger.Warn("Invalid signature")
+		return nil, ErrInvalidSignature
+	}
+	p.s = s
+	return nil, nil
+}
+
+// getSignature returns the signature in RFC 8032: the encoded R and the little-endian s.
+func (p *signShareHandler) getSignature() ([]byte, error) {
+	encodedR, err := p.r.ToEd25519()
+	if err != nil {
+		return nil, err
+	}
+	s := make([]byte, 32)
+	sBytes := p.s.Bytes()
+	copy(s[32-len(sBytes):], sBytes)
+	return append(encodedR, reverse(s)...), nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frost
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sign share handler, negative cases", func() {
+	var (
+		signers   map[string]*Signer
+		listeners map[string]*mocks.StateChangedListener
+	)
+	BeforeEach(func() {
+		signers, listeners, _ = newSigners(2, []*birkhoffinterpolation.BkParameter{
+			birkhoffinterpolation.NewBkParameter(big.NewInt(1), 0),
+			birkhoffinterpolation.NewBkParameter(big.NewInt(2), 1),
+		}, []byte{1, 2, 3})
+		// Handle the commit messages, but not the sign share messages
+		for _, s := range signers {
+			for peerID := range s.ch.peers {
+				Expect(s.ch.HandleMessage(log.Discard(), signers[peerID].GetCommitMessage())).Should(BeNil())
+			}
+		}
+	})
+
+	AfterEach(func() {
+		stopSigners(signers, listeners)
+	})
+
+	It("peer not found", func() {
+		for _, s := range signers {
+			h, err := s.ch.Finalize(log.Discard())
+			Expect(err).Should(BeNil())
+			sh := h.(*signShareHandler)
+			Expect(sh.MessageType()).Should(Equal(types.MessageType(Type_SignShare)))
+			Expect(sh.IsHandled(log.Discard(), "invalid peer")).Should(BeFalse())
+			Expect(sh.HandleMessage(log.Discard(), &Message{
+				Id: "invalid peer",
+			})).Should(Equal(tss.ErrPeerNotFound))
+		}
+	})
+
+	It("signature share out of range", func() {
+		for _, s := range signers {
+			h, err := s.ch.Finalize(log.Discard())
+			Expect(err).Should(BeNil())
+			sh := h.(*signShareHandler)
+			for peerID := range sh.peers {
+				msg := sh.getSignShareMessage(sh.publicKey.GetCurve().Params().N)
+				msg.Id = peerID
+				Expect(sh.HandleMessage(log.Discard(), msg)).Should(Equal(ErrInvalidSignShare))
+			}
+		}
+	})
+
+	It("inconsistent signature share", func() {
+		handlers := make(map[string]*signShareHandler, len(signers))
+		for id, s := range signers {
+			h, err := s.ch.Finalize(log.Discard())
+			Expect(err).Should(BeNil())
+			handlers[id] = h.(*signShareHandler)
+		}
+		for id, h := range handlers {
+			for peerID := range h.peers {
+				// Add one to the signature share of the peer
+				msg := h.getSignShareMessage(new(big.Int).Add(handlers[peerID].z, big.NewInt(1)))
+				msg.Id = peerID
+				err := h.HandleMessage(log.Discard(), msg)
+				var blameErr *message.BlameError
+				Expect(errors.As(err, &blameErr)).Should(BeTrue())
+				Expect(blameErr.Err).Should(Equal(ErrInconsistentSignShare))
+				Expect(blameErr.Culprits).Should(Equal([]string{peerID}))
+				Expect(blameErr.Evidence).Should(Equal([]types.Message{msg}))
+				Expect(h.IsHandled(log.Discard(), peerID)).Should(BeFalse(), id)
+			}
+		}
+	})
+})
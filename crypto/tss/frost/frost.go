@@ -0,0 +1,90 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frost
+
+import (
+	"math/big"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+type Result struct {
+	R *pt.ECPoint
+	S *big.Int
+	// Signature is the 64-byte signature in RFC 8032, which could be verified by crypto/ed25519
+	Signature []byte
+}
+
+// Signer signs the message by FROST in two rounds: the signers broadcast the commitments of two nonces, and then
+// the signature shares. The shares, the bks and the public shares could be the result of DKG over Ed25519. The
+// signature share of each peer is verified by its public share, so a peer sending an invalid one is reported as
+// a culprit in GetFailure().
+type Signer struct {
+	ch *commitHandler
+	*message.MsgMain
+}
+
+func NewSigner(peerManager types.PeerManager, sessionID []byte, publicKey *pt.ECPoint, secret *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, publicShares map[string]*pt.ECPoint, msg []byte, listener types.StateChangedListener) (*Signer, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	ch, err := newCommitHandler(publicKey, peerManager, sessionID, secret, bks, publicShares, msg)
+	if err != nil {
+		log.Warn("Failed to new a commit handler", "err", err)
+		return nil, err
+	}
+	return &Signer{
+		ch: ch,
+		MsgMain: message.NewMsgMain(peerManager,
+			sessionID,
+			listener,
+			ch,
+			types.MessageType(Type_Commit),
+			types.MessageType(Type_SignShare),
+		),
+	}, nil
+}
+
+func (s *Signer) GetCommitMessage() *Message {
+	return s.ch.GetCommitMessage()
+}
+
+// GetResult returns the final result: the signature of Ed25519
+func (s *Signer) GetResult() (*Result, error) {
+	if s.GetState() != types.StateDone {
+		return nil, tss.ErrNotReady
+	}
+
+	h := s.GetHandler()
+	rh, ok := h.(*signShareHandler)
+	if !ok {
+		log.Error("We cannot convert to sign share handler in done state")
+		return nil, tss.ErrNotReady
+	}
+	signature, err := rh.getSignature()
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		R:         rh.r.Copy(),
+		S:         new(big.Int).Set(rh.s),
+		Signature: signature,
+	}, nil
+}
@@ -0,0 +1,249 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frost
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/polynomial"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	proto "github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFrost(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Frost Suite")
+}
+
+var _ = Describe("Signer", func() {
+	curve := pt.Edwards25519()
+	msg := []byte("message to sign")
+
+	DescribeTable("NewSigner()", func(threshold uint32, bks []*birkhoffinterpolation.BkParameter) {
+		signers, listeners, publicKey := newSigners(threshold, bks, msg)
+		doneChs := make([]chan struct{}, 0, len(listeners))
+		for _, l := range listeners {
+			doneCh := make(chan struct{})
+			doneChs = append(doneChs, doneCh)
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+
+		// Send out commit message
+		for fromID, fromS := range signers {
+			msg := fromS.GetCommitMessage()
+			for toID, toS := range signers {
+				if fromID == toID {
+					continue
+				}
+				Expect(toS.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		encodedPublicKey, err := publicKey.ToEd25519()
+		Expect(err).Should(BeNil())
+		var exp *Result
+		for _, s := range signers {
+			s.Stop()
+			r, err := s.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(ed25519.Verify(ed25519.PublicKey(encodedPublicKey), msg, r.Signature)).Should(BeTrue())
+			// All the signatures should be the same
+			if exp != nil {
+				Expect(r).Should(Equal(exp))
+			}
+			exp = r
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	},
+		Entry("(x, rank): (1, 0), (2, 0), (3, 0)", uint32(3), []*birkhoffinterpolation.BkParameter{
+			birkhoffinterpolation.NewBkParameter(big.NewInt(1), 0),
+			birkhoffinterpolation.NewBkParameter(big.NewInt(2), 0),
+			birkhoffinterpolation.NewBkParameter(big.NewInt(3), 0),
+		}),
+		Entry("(x, rank): (1, 0), (2, 1), (3, 1)", uint32(3), []*birkhoffinterpolation.BkParameter{
+			birkhoffinterpolation.NewBkParameter(big.NewInt(1), 0),
+			birkhoffinterpolation.NewBkParameter(big.NewInt(2), 1),
+			birkhoffinterpolation.NewBkParameter(big.NewInt(3), 1),
+		}),
+		Entry("(x, rank): (5, 0), (17, 1)", uint32(2), []*birkhoffinterpolation.BkParameter{
+			birkhoffinterpolation.NewBkParameter(big.NewInt(5), 0),
+			birkhoffinterpolation.NewBkParameter(big.NewInt(17), 1),
+		}),
+	)
+
+	It("not Ed25519", func() {
+		publicKey := pt.ScalarBaseMult(btcec.S256(), big.NewInt(100))
+		s, err := NewSigner(newPeerManager(getID(0), 1), sessionID, publicKey, big.NewInt(1), nil, nil, msg, nil)
+		Expect(err).Should(Equal(pt.ErrInvalidCurve))
+		Expect(s).Should(BeNil())
+	})
+
+	It("inconsistent peer number and bks", func() {
+		publicKey := pt.ScalarBaseMult(curve, big.NewInt(100))
+		bks := map[string]*birkhoffinterpolation.BkParameter{
+			getID(0): birkhoffinterpolation.NewBkParameter(big.NewInt(1), 0),
+		}
+		s, err := NewSigner(newPeerManager(getID(0), 1), sessionID, publicKey, big.NewInt(1), bks, nil, msg, nil)
+		Expect(err).Should(Equal(tss.ErrInconsistentPeerNumAndBks))
+		Expect(s).Should(BeNil())
+	})
+
+	It("public share not found", func() {
+		publicKey := pt.ScalarBaseMult(curve, big.NewInt(100))
+		bks := map[string]*birkhoffinterpolation.BkParameter{
+			getID(0): birkhoffinterpolation.NewBkParameter(big.NewInt(1), 0),
+			getID(1): birkhoffinterpolation.NewBkParameter(big.NewInt(2), 0),
+		}
+		s, err := NewSigner(newPeerManager(getID(0), 1), sessionID, publicKey, big.NewInt(1), bks, nil, msg, nil)
+		Expect(err).Should(Equal(ErrPublicShareNotFound))
+		Expect(s).Should(BeNil())
+	})
+
+	It("inconsistent public shares", func() {
+		publicKey := pt.ScalarBaseMult(curve, big.NewInt(100))
+		bks := map[string]*birkhoffinterpolation.BkParameter{
+			getID(0): birkhoffinterpolation.NewBkParameter(big.NewInt(1), 0),
+			getID(1): birkhoffinterpolation.NewBkParameter(big.NewInt(2), 0),
+		}
+		publicShares := map[string]*pt.ECPoint{
+			getID(1): pt.ScalarBaseMult(curve, big.NewInt(2)),
+		}
+		s, err := NewSigner(newPeerManager(getID(0), 1), sessionID, publicKey, big.NewInt(1), bks, publicShares, msg, nil)
+		Expect(err).Should(Equal(tss.ErrInconsistentPubKey))
+		Expect(s).Should(BeNil())
+	})
+
+	It("empty session id", func() {
+		publicKey := pt.ScalarBaseMult(curve, big.NewInt(100))
+		s, err := NewSigner(newPeerManager(getID(0), 1), nil, publicKey, big.NewInt(1), nil, nil, msg, nil)
+		Expect(err).Should(Equal(tss.ErrEmptySessionID))
+		Expect(s).Should(BeNil())
+	})
+})
+
+var sessionID = []byte("session")
+
+func getID(id int) string {
+	return fmt.Sprintf("id-%d", id)
+}
+
+type peerManager struct {
+	id       string
+	numPeers uint32
+	signers  map[string]*Signer
+}
+
+func newPeerManager(id string, numPeers int) *peerManager {
+	return &peerManager{
+		id:       id,
+		numPeers: uint32(numPeers),
+	}
+}
+
+func (p *peerManager) setSigners(signers map[string]*Signer) {
+	p.signers = signers
+}
+
+func (p *peerManager) NumPeers() uint32 {
+	return p.numPeers
+}
+
+func (p *peerManager) SelfID() string {
+	return p.id
+}
+
+func (p *peerManager) PeerIDs() []string {
+	ids := make([]string, 0, len(p.signers))
+	for id := range p.signers {
+		if id != p.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (p *peerManager) MustSend(id string, message proto.Message) {
+	s := p.signers[id]
+	msg := message.(types.Message)
+	Expect(s.AddMessage(msg)).Should(BeNil())
+}
+
+// newSigners shares a random private key by the bks, and news the signers of all the bks.
+func newSigners(threshold uint32, bks []*birkhoffinterpolation.BkParameter, msg []byte) (map[string]*Signer, map[string]*mocks.StateChangedListener, *pt.ECPoint) {
+	curve := pt.Edwards25519()
+	poly, err := polynomial.RandomPolynomial(curve.Params().N, threshold-1)
+	Expect(err).Should(BeNil())
+	publicKey := pt.ScalarBaseMult(curve, poly.Get(0))
+
+	lens := len(bks)
+	bksMap := make(map[string]*birkhoffinterpolation.BkParameter, lens)
+	shares := make(map[string]*big.Int, lens)
+	publicShares := make(map[string]*pt.ECPoint, lens)
+	for i, bk := range bks {
+		id := getID(i)
+		bksMap[id] = bk
+		shares[id] = poly.Differentiate(bk.GetRank()).Evaluate(bk.GetX())
+		publicShares[id] = pt.ScalarBaseMult(curve, shares[id])
+	}
+	signers := make(map[string]*Signer, lens)
+	listeners := make(map[string]*mocks.StateChangedListener, lens)
+	for i := range bks {
+		id := getID(i)
+		pm := newPeerManager(id, lens-1)
+		pm.setSigners(signers)
+		listeners[id] = new(mocks.StateChangedListener)
+		signers[id], err = NewSigner(pm, sessionID, publicKey, shares[id], bksMap, publicShares, msg, listeners[id])
+		Expect(err).Should(BeNil())
+		r, err := signers[id].GetResult()
+		Expect(r).Should(BeNil())
+		Expect(err).Should(Equal(tss.ErrNotReady))
+		signers[id].Start()
+	}
+	return signers, listeners, publicKey
+}
+
+// stopSigners stops the signers which are expected to fail.
+func stopSigners(signers map[string]*Signer, listeners map[string]*mocks.StateChangedListener) {
+	for _, l := range listeners {
+		l.On("OnStateChanged", types.StateInit, types.StateFailed).Return().Once()
+	}
+	for _, s := range signers {
+		s.Stop()
+	}
+	time.Sleep(500 * time.Millisecond)
+	for _, l := range listeners {
+		l.AssertExpectations(GinkgoT())
+	}
+}
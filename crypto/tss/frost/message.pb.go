@@ -0,0 +1,273 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/getamis/alice/crypto/tss/frost/message.proto
+
+package frost
+
+import (
+	fmt "fmt"
+	ecpointgrouplaw "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Type int32
+
+const (
+	Type_Commit    Type = 0
+	Type_SignShare Type = 1
+)
+
+var Type_name = map[int32]string{
+	0: "Commit",
+	1: "SignShare",
+}
+
+var Type_value = map[string]int32{
+	"Commit":    0,
+	"SignShare": 1,
+}
+
+func (x Type) String() string {
+	return proto.EnumName(Type_name, int32(x))
+}
+
+func (Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e5ee471d71eb24c7, []int{0}
+}
+
+type Message struct {
+	Type      Type   `protobuf:"varint,1,opt,name=type,proto3,enum=frost.Type" json:"type,omitempty"`
+	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId []byte `protobuf:"bytes,3,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	// Types that are valid to be assigned to Body:
+	//	*Message_Commit
+	//	*Message_SignShare
+	Body                 isMessage_Body `protobuf_oneof:"body"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Message) Reset()         { *m = Message{} }
+func (m *Message) String() string { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()    {}
+func (*Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e5ee471d71eb24c7, []int{0}
+}
+
+func (m *Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Message.Unmarshal(m, b)
+}
+func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Message.Marshal(b, m, deterministic)
+}
+func (m *Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Message.Merge(m, src)
+}
+func (m *Message) XXX_Size() int {
+	return xxx_messageInfo_Message.Size(m)
+}
+func (m *Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Message proto.InternalMessageInfo
+
+func (m *Message) GetType() Type {
+	if m != nil {
+		return m.Type
+	}
+	return Type_Commit
+}
+
+func (m *Message) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Message) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+type isMessage_Body interface {
+	isMessage_Body()
+}
+
+type Message_Commit struct {
+	Commit *BodyCommit `protobuf:"bytes,4,opt,name=commit,proto3,oneof"`
+}
+
+type Message_SignShare struct {
+	SignShare *BodySignShare `protobuf:"bytes,5,opt,name=signShare,proto3,oneof"`
+}
+
+func (*Message_Commit) isMessage_Body() {}
+
+func (*Message_SignShare) isMessage_Body() {}
+
+func (m *Message) GetBody() isMessage_Body {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *Message) GetCommit() *BodyCommit {
+	if x, ok := m.GetBody().(*Message_Commit); ok {
+		return x.Commit
+	}
+	return nil
+}
+
+func (m *Message) GetSignShare() *BodySignShare {
+	if x, ok := m.GetBody().(*Message_SignShare); ok {
+		return x.SignShare
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Message) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Message_Commit)(nil),
+		(*Message_SignShare)(nil),
+	}
+}
+
+type BodyCommit struct {
+	// d and e are the commitments of the hiding nonce and the binding nonce
+	D                    *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,1,opt,name=d,proto3" json:"d,omitempty"`
+	E                    *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,2,opt,name=e,proto3" json:"e,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *BodyCommit) Reset()         { *m = BodyCommit{} }
+func (m *BodyCommit) String() string { return proto.CompactTextString(m) }
+func (*BodyCommit) ProtoMessage()    {}
+func (*BodyCommit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e5ee471d71eb24c7, []int{1}
+}
+
+func (m *BodyCommit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyCommit.Unmarshal(m, b)
+}
+func (m *BodyCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyCommit.Marshal(b, m, deterministic)
+}
+func (m *BodyCommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyCommit.Merge(m, src)
+}
+func (m *BodyCommit) XXX_Size() int {
+	return xxx_messageInfo_BodyCommit.Size(m)
+}
+func (m *BodyCommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyCommit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyCommit proto.InternalMessageInfo
+
+func (m *BodyCommit) GetD() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.D
+	}
+	return nil
+}
+
+func (m *BodyCommit) GetE() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.E
+	}
+	return nil
+}
+
+type BodySignShare struct {
+	Z                    []byte   `protobuf:"bytes,1,opt,name=z,proto3" json:"z,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BodySignShare) Reset()         { *m = BodySignShare{} }
+func (m *BodySignShare) String() string { return proto.CompactTextString(m) }
+func (*BodySignShare) ProtoMessage()    {}
+func (*BodySignShare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e5ee471d71eb24c7, []int{2}
+}
+
+func (m *BodySignShare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodySignShare.Unmarshal(m, b)
+}
+func (m *BodySignShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodySignShare.Marshal(b, m, deterministic)
+}
+func (m *BodySignShare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodySignShare.Merge(m, src)
+}
+func (m *BodySignShare) XXX_Size() int {
+	return xxx_messageInfo_BodySignShare.Size(m)
+}
+func (m *BodySignShare) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodySignShare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodySignShare proto.InternalMessageInfo
+
+func (m *BodySignShare) GetZ() []byte {
+	if m != nil {
+		return m.Z
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("frost.Type", Type_name, Type_value)
+	proto.RegisterType((*Message)(nil), "frost.Message")
+	proto.RegisterType((*BodyCommit)(nil), "frost.BodyCommit")
+	proto.RegisterType((*BodySignShare)(nil), "frost.BodySignShare")
+}
+
+func init() {
+	proto.RegisterFile("github.com/getamis/alice/crypto/tss/frost/message.proto", fileDescriptor_e5ee471d71eb24c7)
+}
+
+var fileDescriptor_e5ee471d71eb24c7 = []byte{
+	// 314 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0x5f, 0x4b, 0xf3, 0x30,
+	0x14, 0x87, 0x97, 0xbe, 0x5d, 0x5f, 0x7a, 0xf6, 0x87, 0x19, 0xbc, 0x28, 0xa2, 0xac, 0xee, 0xaa,
+	0x28, 0x26, 0x30, 0x05, 0x2f, 0xbc, 0x9b, 0x08, 0xf3, 0x42, 0x90, 0xcc, 0x2f, 0xd0, 0x25, 0xb1,
+	0x8b, 0xac, 0x4b, 0x68, 0x32, 0xa4, 0xfb, 0x7a, 0x7e, 0x31, 0x59, 0x36, 0xad, 0x7a, 0xa3, 0x97,
+	0xe7, 0x9c, 0x27, 0x4f, 0xce, 0xef, 0xc0, 0x75, 0xa1, 0xdc, 0x62, 0x3d, 0x27, 0x5c, 0x97, 0xb4,
+	0x90, 0x2e, 0x2f, 0x95, 0xa5, 0xf9, 0x52, 0x71, 0x49, 0x79, 0x55, 0x1b, 0xa7, 0xa9, 0xb3, 0x96,
+	0x3e, 0x57, 0xda, 0x3a, 0x5a, 0x4a, 0x6b, 0xf3, 0x42, 0x12, 0x53, 0x69, 0xa7, 0x71, 0xdb, 0x37,
+	0x8f, 0x6e, 0x7e, 0x7b, 0x2f, 0xb9, 0xd1, 0x6a, 0xe5, 0x8a, 0x4a, 0xaf, 0xcd, 0x32, 0x7f, 0xa5,
+	0xbe, 0xda, 0x39, 0x46, 0x6f, 0x08, 0xfe, 0x3f, 0xec, 0xac, 0x78, 0x08, 0xa1, 0xab, 0x8d, 0x4c,
+	0x50, 0x8a, 0xb2, 0xfe, 0xb8, 0x43, 0xbc, 0x9e, 0x3c, 0xd5, 0x46, 0x32, 0x3f, 0xc0, 0x7d, 0x08,
+	0x94, 0x48, 0x82, 0x14, 0x65, 0x31, 0x0b, 0x94, 0xc0, 0xc7, 0x10, 0x5b, 0x69, 0xad, 0xd2, 0xab,
+	0x7b, 0x91, 0xfc, 0x4b, 0x51, 0xd6, 0x65, 0x4d, 0x03, 0x9f, 0x43, 0xc4, 0x75, 0x59, 0x2a, 0x97,
+	0x84, 0x29, 0xca, 0x3a, 0xe3, 0x83, 0xbd, 0x70, 0xa2, 0x45, 0x7d, 0xeb, 0x07, 0xd3, 0x16, 0xdb,
+	0x23, 0xf8, 0x0a, 0x62, 0xab, 0x8a, 0xd5, 0x6c, 0x91, 0x57, 0x32, 0x69, 0x7b, 0xfe, 0xf0, 0x0b,
+	0x3f, 0xfb, 0x98, 0x4d, 0x5b, 0xac, 0x01, 0x27, 0x11, 0x84, 0x73, 0x2d, 0xea, 0xd1, 0x0b, 0x40,
+	0x63, 0xc5, 0x17, 0x80, 0x84, 0x0f, 0xd1, 0x19, 0x0f, 0xc9, 0x8f, 0xf0, 0xe4, 0x8e, 0x3f, 0x6e,
+	0xeb, 0x7d, 0x66, 0x86, 0xc4, 0x16, 0x97, 0x49, 0xf0, 0x47, 0x5c, 0x8e, 0x4e, 0xa0, 0xf7, 0x6d,
+	0x23, 0xdc, 0x05, 0xb4, 0xf1, 0xdf, 0x75, 0x19, 0xda, 0x9c, 0x9d, 0x42, 0xb8, 0xbd, 0x18, 0x06,
+	0x88, 0x76, 0xeb, 0x0c, 0x5a, 0xb8, 0x07, 0xf1, 0x27, 0x3e, 0x40, 0xf3, 0xc8, 0x9f, 0xfe, 0xf2,
+	0x7d, 0x00, 0x27, 0x7c, 0xe6, 0x85, 0xf9, 0x01, 0x00, 0x00,
+}
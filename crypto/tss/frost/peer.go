@@ -0,0 +1,35 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frost
+
+import (
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+)
+
+type peer struct {
+	*tss.Peer
+	// bigW is W_j = birkhoff coefficient * public share, which verifies the signature share of the peer
+	bigW      *pt.ECPoint
+	commit    *commitData
+	signShare *signShareData
+}
+
+func newPeer(id string, bigW *pt.ECPoint) *peer {
+	return &peer{
+		Peer: tss.NewPeer(id),
+		bigW: bigW,
+	}
+}
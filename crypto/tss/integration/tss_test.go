@@ -87,7 +87,7 @@ var _ = Describe("TSS", func() {
 			listener[i] = new(mocks.StateChangedListener)
 			listener[i].On("OnStateChanged", types.StateInit, types.StateDone).Once()
 			var err error
-			dkgs[id], err = dkg.NewDKG(c, dkgPeerManagers[i], threshold, ranks[i], listener[i])
+			dkgs[id], err = dkg.NewDKG(c, dkgPeerManagers[i], []byte("dkg"), threshold, ranks[i], listener[i])
 			Expect(err).Should(BeNil())
 			msgMain[id] = dkgs[id].MsgMain
 			dkgResult, err := dkgs[id].GetResult()
@@ -159,7 +159,7 @@ var _ = Describe("TSS", func() {
 				resharePeerManagers[i] = pm
 				listener[i].On("OnStateChanged", types.StateInit, types.StateDone).Once()
 				var err error
-				reshares[id], err = reshare.NewReshare(resharePeerManagers[i], threshold, r.publicKey, r.share[id], r.bks, listener[i])
+				reshares[id], err = reshare.NewReshare(resharePeerManagers[i], []byte("reshare"), threshold, r.publicKey, r.share[id], r.bks, listener[i])
 				Expect(err).Should(BeNil())
 				msgMain[id] = reshares[id].MsgMain
 				reshareResult, err := reshares[id].GetResult()
@@ -206,7 +206,8 @@ var _ = Describe("TSS", func() {
 			pmNew.setMsgMains(msgMain)
 			listenerNew := new(mocks.StateChangedListener)
 			listenerNew.On("OnStateChanged", types.StateInit, types.StateDone).Once()
-			addShareForNew = newpeer.NewAddShare(pmNew, r.publicKey, threshold, newPeerRank, listenerNew)
+			addShareForNew, err := newpeer.NewAddShare(pmNew, []byte("addshare"), r.publicKey, threshold, newPeerRank, listenerNew)
+			Expect(err).Should(BeNil())
 			msgMain[newPeerID] = addShareForNew.MsgMain
 			addShareNewResult, err := addShareForNew.GetResult()
 			Expect(addShareNewResult).Should(BeNil())
@@ -223,7 +224,7 @@ var _ = Describe("TSS", func() {
 				listenersOld[i] = new(mocks.StateChangedListener)
 				listenersOld[i].On("OnStateChanged", types.StateInit, types.StateDone).Once()
 				var err error
-				addSharesForOld[id], err = oldpeer.NewAddShare(pmOlds[i], r.publicKey, threshold, r.share[id], r.bks, newPeerID, listenersOld[i])
+				addSharesForOld[id], err = oldpeer.NewAddShare(pmOlds[i], []byte("addshare"), r.publicKey, threshold, r.share[id], r.bks, newPeerID, listenersOld[i])
 				Expect(err).Should(BeNil())
 				msgMain[id] = addSharesForOld[id].MsgMain
 				addShareOldResult, err := addSharesForOld[id].GetResult()
@@ -331,6 +332,10 @@ func sign(homoFunc func() (homo.Crypto, error), threshold, num int, dkgResult *r
 		signers := make(map[string]*signer.Signer, threshold)
 		doneChs := make(map[string]chan struct{}, threshold)
 		msgMain := make(map[string]*message.MsgMain, threshold)
+		// Add the ids first since the peer ids are checked in the constructor
+		for _, i := range c {
+			msgMain[getID(i)] = nil
+		}
 		for _, i := range c {
 			h, err := homoFunc()
 			Expect(err).Should(BeNil())
@@ -343,16 +348,11 @@ func sign(homoFunc func() (homo.Crypto, error), threshold, num int, dkgResult *r
 			listener[i].On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
 				close(doneCh)
 			}).Once()
-			bks := make(map[string]*birkhoffinterpolation.BkParameter)
-			bks[id] = dkgResult.bks[id]
-			for _, j := range c {
-				if i == j {
-					continue
-				}
-				pID := getID(j)
-				bks[pID] = dkgResult.bks[pID]
+			signerIDs := make([]string, len(c))
+			for k, j := range c {
+				signerIDs[k] = getID(j)
 			}
-			signers[id], err = signer.NewSigner(pm, dkgResult.publicKey, h, dkgResult.share[id], bks, msg, listener[i])
+			signers[id], err = signer.NewSigner(pm, []byte("signer"), dkgResult.publicKey, h, dkgResult.share[id], uint32(threshold), dkgResult.bks, signerIDs, msg, listener[i])
 			Expect(err).Should(BeNil())
 			msgMain[id] = signers[id].MsgMain
 			signerResult, err := signers[id].GetResult()
@@ -447,6 +447,16 @@ func (p *peerManager) SelfID() string {
 	return p.id
 }
 
+func (p *peerManager) PeerIDs() []string {
+	ids := make([]string, 0, len(p.msgMains))
+	for id := range p.msgMains {
+		if id != p.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func (p *peerManager) MustSend(id string, message proto.Message) {
 	msg := message.(types.Message)
 	Expect(p.msgMains[id].AddMessage(msg)).Should(BeNil())
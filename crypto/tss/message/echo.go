@@ -0,0 +1,211 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	// ErrInconsistentBroadcast is returned if a peer received a different broadcast message from the sender
+	ErrInconsistentBroadcast = errors.New("inconsistent broadcast message")
+	// ErrInvalidEcho is returned if the echo message is invalid
+	ErrInvalidEcho = errors.New("invalid echo message")
+	// ErrEchoDisabled is returned if an echo message is added but the echo broadcast is not enabled
+	ErrEchoDisabled = errors.New("echo broadcast disabled")
+)
+
+func (m *EchoMessage) GetMessageType() types.MessageType {
+	return types.MessageType(m.GetType())
+}
+
+func (m *EchoMessage) IsValid() bool {
+	return m != nil && m.GetId() != ""
+}
+
+// EnableEchoBroadcast makes the rounds of the message types reliable broadcasts. It should be called before Start.
+// After all the messages of such a round are handled, the digests of them are echoed to the senders, and the
+// round is finalized only if all the peers received the same messages. Otherwise, the process fails with
+// ErrInconsistentBroadcast, and both the sender and the echoing peer are blamed because we cannot tell which
+// one is lying. The peers must route the received *EchoMessage to AddMessage.
+func (t *MsgMain) EnableEchoBroadcast(msgTypes ...types.MessageType) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.echoChs = NewMsgChans(t.peerNum, msgTypes...)
+}
+
+// EchoBroadcastTypes returns the sorted message types of the rounds made reliable broadcasts by EnableEchoBroadcast.
+// They should be enabled again before a snapshot of the process is restored.
+func (t *MsgMain) EchoBroadcastTypes() []types.MessageType {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.echoChs == nil {
+		return nil
+	}
+	msgTypes := make([]types.MessageType, 0, len(t.echoChs.chs))
+	for msgType := range t.echoChs.chs {
+		msgTypes = append(msgTypes, msgType)
+	}
+	sort.Slice(msgTypes, func(i, j int) bool {
+		return msgTypes[i] < msgTypes[j]
+	})
+	return msgTypes
+}
+
+func (t *MsgMain) addEchoMessage(msg *EchoMessage) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.echoChs == nil {
+		return ErrEchoDisabled
+	}
+	// The network may deliver the same echo more than once. Ignore it silently. The different echoes from the
+	// same peer are still pushed, so that the peer would be blamed.
+	for _, echo := range t.echoes {
+		if proto.Equal(echo, msg) {
+			return nil
+		}
+	}
+	err := t.echoChs.Push(msg)
+	if err != nil {
+		return err
+	}
+	t.echoes = append(t.echoes, msg)
+	return nil
+}
+
+func (t *MsgMain) isEchoRound(msgType types.MessageType) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.echoChs == nil {
+		return false
+	}
+	_, ok := t.echoChs.chs[msgType]
+	return ok
+}
+
+// echo sends the digests of the handled messages to their senders, and checks the echoes of them.
+// It returns the ids of the peers whose echoes are missing if it fails to pop an echo.
+func (t *MsgMain) echo(ctx context.Context, msgType types.MessageType, msgs []types.Message) ([]string, error) {
+	received := make(map[string]types.Message, len(msgs))
+	digests := make(map[string][]byte, len(msgs))
+	ids := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		id := msg.GetId()
+		d, err := digest(msg)
+		if err != nil {
+			t.logger.Warn("Failed to get digest", "msgType", msgType, "fromId", id, "err", err)
+			return nil, err
+		}
+		received[id] = msg
+		digests[id] = d
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	echoMsg := &EchoMessage{
+		Id:        t.peerManager.SelfID(),
+		SessionId: t.sessionID,
+		Type:      int32(msgType),
+		Digests:   make([]*EchoDigest, len(ids)),
+	}
+	for i, id := range ids {
+		echoMsg.Digests[i] = &EchoDigest{
+			Id:   id,
+			Hash: digests[id],
+		}
+	}
+	for _, id := range ids {
+		t.peerManager.MustSend(id, echoMsg)
+	}
+
+	echoed := make(map[string]bool, len(ids))
+	for len(echoed) < len(ids) {
+		t.handlerLock.Unlock()
+		msg, err := t.echoChs.Pop(ctx, msgType)
+		t.handlerLock.Lock()
+		if err != nil {
+			var missing []string
+			for _, id := range ids {
+				if !echoed[id] {
+					missing = append(missing, id)
+				}
+			}
+			return missing, err
+		}
+		echo := msg.(*EchoMessage)
+		id := echo.GetId()
+		if _, ok := received[id]; !ok || echoed[id] {
+			t.logger.Warn("Unexpected echo message", "msgType", msgType, "fromId", id)
+			return nil, NewBlameError(ErrInvalidEcho, echo)
+		}
+		echoed[id] = true
+		err = verifyEcho(echo, received, digests)
+		if err != nil {
+			t.logger.Warn("Failed to verify echo message", "msgType", msgType, "fromId", id, "err", err)
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// verifyEcho checks the echo includes the same digests of the messages which are not sent by the echoing peer.
+func verifyEcho(echo *EchoMessage, received map[string]types.Message, digests map[string][]byte) error {
+	echoDigests := make(map[string][]byte, len(echo.GetDigests()))
+	for _, d := range echo.GetDigests() {
+		if _, ok := echoDigests[d.GetId()]; ok {
+			return NewBlameError(ErrInvalidEcho, echo)
+		}
+		echoDigests[d.GetId()] = d.GetHash()
+	}
+	for id, msg := range received {
+		if id == echo.GetId() {
+			continue
+		}
+		d, ok := echoDigests[id]
+		if !ok {
+			return NewBlameError(ErrInvalidEcho, echo)
+		}
+		if !bytes.Equal(d, digests[id]) {
+			return NewBlameError(ErrInconsistentBroadcast, msg, echo)
+		}
+	}
+	return nil
+}
+
+func digest(msg types.Message) ([]byte, error) {
+	pMsg, ok := msg.(proto.Message)
+	if !ok {
+		return nil, ErrInvalidMessage
+	}
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	err := buf.Marshal(pMsg)
+	if err != nil {
+		return nil, err
+	}
+	d := blake2b.Sum256(buf.Bytes())
+	return d[:], nil
+}
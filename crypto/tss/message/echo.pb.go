@@ -0,0 +1,160 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/getamis/alice/crypto/tss/message/echo.proto
+
+package message
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// EchoMessage echoes the digests of the messages received in a broadcast round
+type EchoMessage struct {
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId []byte `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// type is the message type of the broadcast round
+	Type                 int32         `protobuf:"varint,3,opt,name=type,proto3" json:"type,omitempty"`
+	Digests              []*EchoDigest `protobuf:"bytes,4,rep,name=digests,proto3" json:"digests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *EchoMessage) Reset()         { *m = EchoMessage{} }
+func (m *EchoMessage) String() string { return proto.CompactTextString(m) }
+func (*EchoMessage) ProtoMessage()    {}
+func (*EchoMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7936b48f48e3f385, []int{0}
+}
+
+func (m *EchoMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EchoMessage.Unmarshal(m, b)
+}
+func (m *EchoMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EchoMessage.Marshal(b, m, deterministic)
+}
+func (m *EchoMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EchoMessage.Merge(m, src)
+}
+func (m *EchoMessage) XXX_Size() int {
+	return xxx_messageInfo_EchoMessage.Size(m)
+}
+func (m *EchoMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_EchoMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EchoMessage proto.InternalMessageInfo
+
+func (m *EchoMessage) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *EchoMessage) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *EchoMessage) GetType() int32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *EchoMessage) GetDigests() []*EchoDigest {
+	if m != nil {
+		return m.Digests
+	}
+	return nil
+}
+
+// EchoDigest is the digest of the message sent by the peer
+type EchoDigest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EchoDigest) Reset()         { *m = EchoDigest{} }
+func (m *EchoDigest) String() string { return proto.CompactTextString(m) }
+func (*EchoDigest) ProtoMessage()    {}
+func (*EchoDigest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7936b48f48e3f385, []int{1}
+}
+
+func (m *EchoDigest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EchoDigest.Unmarshal(m, b)
+}
+func (m *EchoDigest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EchoDigest.Marshal(b, m, deterministic)
+}
+func (m *EchoDigest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EchoDigest.Merge(m, src)
+}
+func (m *EchoDigest) XXX_Size() int {
+	return xxx_messageInfo_EchoDigest.Size(m)
+}
+func (m *EchoDigest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EchoDigest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EchoDigest proto.InternalMessageInfo
+
+func (m *EchoDigest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *EchoDigest) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*EchoMessage)(nil), "message.EchoMessage")
+	proto.RegisterType((*EchoDigest)(nil), "message.EchoDigest")
+}
+
+func init() {
+	proto.RegisterFile("github.com/getamis/alice/crypto/tss/message/echo.proto", fileDescriptor_7936b48f48e3f385)
+}
+
+var fileDescriptor_7936b48f48e3f385 = []byte{
+	// 200 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x8f, 0xb1, 0x4a, 0x04, 0x31,
+	0x10, 0x40, 0xd9, 0xbd, 0xd5, 0xe3, 0xe6, 0xc4, 0x22, 0x36, 0x69, 0x84, 0x70, 0x55, 0x1a, 0x13,
+	0x51, 0xf0, 0x0b, 0xb4, 0xb0, 0xb0, 0xc9, 0x0f, 0x48, 0x2e, 0x19, 0x92, 0x01, 0xd7, 0x2c, 0x37,
+	0xb1, 0xd8, 0xca, 0x5f, 0x17, 0x63, 0xc4, 0xc2, 0xee, 0xcd, 0x9b, 0x81, 0xc7, 0xc0, 0x43, 0xa2,
+	0x9a, 0x3f, 0x8e, 0x26, 0x94, 0xd9, 0x26, 0xac, 0x7e, 0x26, 0xb6, 0xfe, 0x8d, 0x02, 0xda, 0x70,
+	0x5a, 0x97, 0x5a, 0x6c, 0x65, 0xb6, 0x33, 0x32, 0xfb, 0x84, 0x16, 0x43, 0x2e, 0x66, 0x39, 0x95,
+	0x5a, 0xc4, 0xb6, 0xbb, 0xc3, 0x27, 0xec, 0x9f, 0x42, 0x2e, 0x2f, 0x3f, 0xa3, 0xb8, 0x84, 0x91,
+	0xa2, 0x1c, 0xd4, 0xa0, 0x77, 0x6e, 0xa4, 0x28, 0xae, 0x01, 0x18, 0x99, 0xa9, 0xbc, 0xbf, 0x52,
+	0x94, 0xa3, 0x1a, 0xf4, 0x85, 0xdb, 0x75, 0xf3, 0x1c, 0x85, 0x80, 0xa9, 0xae, 0x0b, 0xca, 0x8d,
+	0x1a, 0xf4, 0x99, 0x6b, 0x2c, 0x6e, 0x60, 0x1b, 0x29, 0x21, 0x57, 0x96, 0x93, 0xda, 0xe8, 0xfd,
+	0xdd, 0x95, 0xe9, 0x31, 0xf3, 0x5d, 0x7a, 0x6c, 0x3b, 0xf7, 0x7b, 0x73, 0xb8, 0x05, 0xf8, 0xd3,
+	0xff, 0xfa, 0x02, 0xa6, 0xec, 0x39, 0xf7, 0x72, 0xe3, 0xe3, 0x79, 0x7b, 0xe1, 0xfe, 0x6b, 0x00,
+	0xa8, 0xe0, 0xf9, 0x4a, 0xfc, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,275 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/sirius/log"
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Echo broadcast", func() {
+	var (
+		sessionID = []byte("session")
+		msgType   = types.MessageType(1)
+		ids       = []string{"id-0", "id-1", "id-2"}
+
+		mains     map[string]*MsgMain
+		pms       map[string]*echoPeerManager
+		listeners map[string]*mocks.StateChangedListener
+		doneChs   map[string]chan struct{}
+	)
+
+	BeforeEach(func() {
+		mains = make(map[string]*MsgMain, len(ids))
+		pms = make(map[string]*echoPeerManager, len(ids))
+		listeners = make(map[string]*mocks.StateChangedListener, len(ids))
+		doneChs = make(map[string]chan struct{}, len(ids))
+		for _, id := range ids {
+			pm := &echoPeerManager{
+				id:    id,
+				ids:   ids,
+				mains: mains,
+			}
+			pms[id] = pm
+			listeners[id] = new(mocks.StateChangedListener)
+			doneChs[id] = make(chan struct{})
+			mains[id] = NewMsgMain(pm, sessionID, listeners[id], newBroadcastHandler(msgType, uint32(len(ids)-1)), msgType)
+			mains[id].EnableEchoBroadcast(msgType)
+		}
+	})
+
+	AfterEach(func() {
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	expectState := func(id string, state types.MainState) {
+		doneCh := doneChs[id]
+		listeners[id].On("OnStateChanged", types.StateInit, state).Run(func(args mock.Arguments) {
+			close(doneCh)
+		}).Once()
+	}
+
+	It("should be ok", func() {
+		for _, id := range ids {
+			expectState(id, types.StateDone)
+			mains[id].Start()
+		}
+		for _, from := range ids {
+			for _, to := range ids {
+				if from == to {
+					continue
+				}
+				Expect(mains[to].AddMessage(newTestMessage(from, msgType, sessionID, "data of "+from))).Should(BeNil())
+			}
+		}
+		for _, id := range ids {
+			<-doneChs[id]
+			Expect(mains[id].GetFailure()).Should(BeNil())
+		}
+	})
+
+	It("ignores duplicated echo messages", func() {
+		for _, id := range ids {
+			pms[id].duplicate = true
+			expectState(id, types.StateDone)
+			mains[id].Start()
+		}
+		for _, from := range ids {
+			for _, to := range ids {
+				if from == to {
+					continue
+				}
+				Expect(mains[to].AddMessage(newTestMessage(from, msgType, sessionID, "data of "+from))).Should(BeNil())
+			}
+		}
+		for _, id := range ids {
+			<-doneChs[id]
+			Expect(mains[id].GetFailure()).Should(BeNil())
+		}
+	})
+
+	It("detects the equivocating peer", func() {
+		expectState("id-0", types.StateFailed)
+		expectState("id-1", types.StateFailed)
+		expectState("id-2", types.StateDone)
+		for _, id := range ids {
+			mains[id].Start()
+		}
+		for _, from := range ids {
+			for _, to := range ids {
+				if from == to {
+					continue
+				}
+				data := "data of " + from
+				// id-2 sends different messages to id-0 and id-1
+				if from == "id-2" {
+					data = fmt.Sprintf("data of %s to %s", from, to)
+				}
+				Expect(mains[to].AddMessage(newTestMessage(from, msgType, sessionID, data))).Should(BeNil())
+			}
+		}
+		for _, id := range ids {
+			<-doneChs[id]
+		}
+
+		f := mains["id-0"].GetFailure()
+		Expect(f).ShouldNot(BeNil())
+		Expect(errors.Is(f.Err, ErrInconsistentBroadcast)).Should(BeTrue())
+		Expect(f.MessageType).Should(Equal(msgType))
+		Expect(f.Culprits).Should(Equal([]string{"id-2", "id-1"}))
+		Expect(f.Evidence).Should(HaveLen(2))
+		f = mains["id-1"].GetFailure()
+		Expect(f).ShouldNot(BeNil())
+		Expect(f.Culprits).Should(Equal([]string{"id-2", "id-0"}))
+		Expect(mains["id-2"].GetFailure()).Should(BeNil())
+	})
+
+	It("echo disabled", func() {
+		main := NewMsgMain(&echoPeerManager{id: "id-0", ids: ids}, sessionID, nil, newBroadcastHandler(msgType, 2), msgType)
+		err := main.AddMessage(&EchoMessage{
+			Id:        "id-1",
+			SessionId: sessionID,
+			Type:      int32(msgType),
+		})
+		Expect(err).Should(Equal(ErrEchoDisabled))
+		Expect(main.EchoBroadcastTypes()).Should(BeNil())
+	})
+
+	It("returns the echo broadcast types", func() {
+		main := NewMsgMain(&echoPeerManager{id: "id-0", ids: ids}, sessionID, nil, newBroadcastHandler(msgType, 2), msgType, 2, 3)
+		main.EnableEchoBroadcast(3, msgType)
+		Expect(main.EchoBroadcastTypes()).Should(Equal([]types.MessageType{msgType, 3}))
+	})
+
+	It("invalid echo", func() {
+		msgs := map[string]types.Message{
+			"id-1": newTestMessage("id-1", msgType, sessionID, "1"),
+			"id-2": newTestMessage("id-2", msgType, sessionID, "2"),
+		}
+		digests := make(map[string][]byte, len(msgs))
+		for id, msg := range msgs {
+			d, err := digest(msg)
+			Expect(err).Should(BeNil())
+			digests[id] = d
+		}
+
+		// Missing the digest of id-2
+		err := verifyEcho(&EchoMessage{Id: "id-1"}, msgs, digests)
+		Expect(err).Should(BeAssignableToTypeOf(&BlameError{}))
+		Expect(err.(*BlameError).Err).Should(Equal(ErrInvalidEcho))
+		Expect(err.(*BlameError).Culprits).Should(Equal([]string{"id-1"}))
+
+		// Duplicate digests
+		err = verifyEcho(&EchoMessage{
+			Id: "id-1",
+			Digests: []*EchoDigest{
+				{Id: "id-2", Hash: digests["id-2"]},
+				{Id: "id-2", Hash: digests["id-2"]},
+			},
+		}, msgs, digests)
+		Expect(err.(*BlameError).Err).Should(Equal(ErrInvalidEcho))
+
+		// The digest of the echoing peer itself is ignored
+		err = verifyEcho(&EchoMessage{
+			Id: "id-1",
+			Digests: []*EchoDigest{
+				{Id: "id-2", Hash: digests["id-2"]},
+			},
+		}, msgs, digests)
+		Expect(err).Should(BeNil())
+	})
+})
+
+type echoPeerManager struct {
+	id    string
+	ids   []string
+	mains map[string]*MsgMain
+	// duplicate sends every message twice
+	duplicate bool
+}
+
+func (p *echoPeerManager) NumPeers() uint32 {
+	return uint32(len(p.ids) - 1)
+}
+
+func (p *echoPeerManager) SelfID() string {
+	return p.id
+}
+
+func (p *echoPeerManager) PeerIDs() []string {
+	var ids []string
+	for _, id := range p.ids {
+		if id != p.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (p *echoPeerManager) MustSend(id string, msg proto.Message) {
+	_ = p.mains[id].AddMessage(msg.(types.Message))
+	if p.duplicate {
+		_ = p.mains[id].AddMessage(msg.(types.Message))
+	}
+}
+
+// broadcastHandler collects a message from every peer
+type broadcastHandler struct {
+	msgType types.MessageType
+	peerNum uint32
+	msgs    map[string]types.Message
+	// next is the handler of the next round. It's nil in the last round.
+	next types.Handler
+}
+
+func newBroadcastHandler(msgType types.MessageType, peerNum uint32) *broadcastHandler {
+	return &broadcastHandler{
+		msgType: msgType,
+		peerNum: peerNum,
+		msgs:    make(map[string]types.Message),
+	}
+}
+
+func (h *broadcastHandler) MessageType() types.MessageType {
+	return h.msgType
+}
+
+func (h *broadcastHandler) GetRequiredMessageCount() uint32 {
+	return h.peerNum
+}
+
+func (h *broadcastHandler) IsHandled(logger log.Logger, id string) bool {
+	_, ok := h.msgs[id]
+	return ok
+}
+
+func (h *broadcastHandler) HandleMessage(logger log.Logger, msg types.Message) error {
+	h.msgs[msg.GetId()] = msg
+	return nil
+}
+
+func (h *broadcastHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	return h.next, nil
+}
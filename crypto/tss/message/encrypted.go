@@ -0,0 +1,360 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// EncryptionKeySize is the size of the x25519 encryption keys
+	EncryptionKeySize = curve25519.ScalarSize
+
+	encryptionLabel = "alice-encryption"
+)
+
+var (
+	// ErrInvalidEncryptionKey is returned if the encryption key is invalid
+	ErrInvalidEncryptionKey = errors.New("invalid encryption key")
+	// ErrDecryptionFailed is returned if the message cannot be decrypted
+	ErrDecryptionFailed = errors.New("failed to decrypt message")
+	// ErrReplayedMessage is returned if the message has been received before
+	ErrReplayedMessage = errors.New("replayed message")
+)
+
+func (m *EncryptedMessage) GetMessageType() types.MessageType {
+	return types.MessageType(m.GetType())
+}
+
+func (m *EncryptedMessage) IsValid() bool {
+	return m != nil && m.GetId() != "" && len(m.GetNonce()) == chacha20poly1305.NonceSizeX && len(m.GetCiphertext()) > 0
+}
+
+// GenerateEncryptionKey generates a x25519 key pair by the randomness source.
+func GenerateEncryptionKey(rand io.Reader) (privateKey []byte, publicKey []byte, err error) {
+	privateKey = make([]byte, EncryptionKeySize)
+	if _, err = io.ReadFull(rand, privateKey); err != nil {
+		return nil, nil, err
+	}
+	publicKey, err = curve25519.X25519(privateKey, curve25519.Basepoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return privateKey, publicKey, nil
+}
+
+// EncryptedPeerManager wraps a peer manager. It encrypts the sent messages by the keys shared with the peers,
+// so the peers must receive *EncryptedMessage instead and decrypt them by an EncryptedReceiver.
+type EncryptedPeerManager struct {
+	types.PeerManager
+
+	sessionID []byte
+	peerIDs   []string
+	aeads     map[string]cipher.AEAD
+
+	lock   sync.Mutex
+	seqs   map[string]uint64
+	err    error
+	failed chan struct{}
+}
+
+// NewEncryptedPeerManager news an encrypted peer manager for the session. The private key is the x25519
+// encryption key of the self peer, and the public keys map the peer ids to their x25519 encryption keys.
+// The key shared with a peer is derived by ECDH and the session id, so a new one should be used for every session.
+// The peer manager must implement types.PeerIDsGetter.
+func NewEncryptedPeerManager(peerManager types.PeerManager, sessionID []byte, privateKey []byte, publicKeys map[string][]byte) (*EncryptedPeerManager, error) {
+	if len(sessionID) == 0 {
+		return nil, ErrInvalidSession
+	}
+	peerIDs, err := GetPeerIDs(peerManager)
+	if err != nil {
+		return nil, err
+	}
+	selfID := peerManager.SelfID()
+	aeads := make(map[string]cipher.AEAD, len(peerIDs))
+	for _, id := range peerIDs {
+		publicKey, ok := publicKeys[id]
+		if !ok {
+			log.Warn("Encryption key not found", "id", id)
+			return nil, ErrUnknownSender
+		}
+		aead, err := newChannelAEAD(sessionID, privateKey, publicKey, selfID, id)
+		if err != nil {
+			log.Warn("Failed to derive key", "id", id, "err", err)
+			return nil, err
+		}
+		aeads[id] = aead
+	}
+	return &EncryptedPeerManager{
+		PeerManager: peerManager,
+		sessionID:   sessionID,
+		peerIDs:     peerIDs,
+		aeads:       aeads,
+		seqs:        make(map[string]uint64, len(aeads)),
+		failed:      make(chan struct{}),
+	}, nil
+}
+
+// PeerIDs returns the ids of the peers.
+func (p *EncryptedPeerManager) PeerIDs() []string {
+	return p.peerIDs
+}
+
+// MustSend encrypts the message and sends it to the peer. If the message cannot be encrypted, it's dropped and
+// the peer manager fails. The peers would wait for the message forever, so the caller should watch Failed and
+// stop the process.
+func (p *EncryptedPeerManager) MustSend(id string, msg proto.Message) {
+	encMsg, err := p.Encrypt(id, msg)
+	if err != nil {
+		log.Error("Failed to encrypt message", "to", id, "err", err)
+		p.fail(err)
+		return
+	}
+	p.PeerManager.MustSend(id, encMsg)
+}
+
+// Failed returns a channel which is closed once the peer manager fails to send a message.
+func (p *EncryptedPeerManager) Failed() <-chan struct{} {
+	return p.failed
+}
+
+// Err returns the error of the first message which failed to be sent, or nil if the peer manager hasn't failed.
+func (p *EncryptedPeerManager) Err() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.err
+}
+
+func (p *EncryptedPeerManager) fail(err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.err != nil {
+		return
+	}
+	p.err = err
+	close(p.failed)
+}
+
+// Encrypt encrypts the message by the key shared with the peer.
+func (p *EncryptedPeerManager) Encrypt(id string, msg proto.Message) (*EncryptedMessage, error) {
+	aead, ok := p.aeads[id]
+	if !ok {
+		return nil, ErrUnknownSender
+	}
+	tMsg, ok := msg.(types.Message)
+	if !ok {
+		return nil, ErrInvalidMessage
+	}
+	anyMsg, err := ptypes.MarshalAny(msg)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := proto.Marshal(anyMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	seq := p.seqs[id]
+	p.seqs[id] = seq + 1
+	p.lock.Unlock()
+
+	// The nonces are random, so they never repeat even if the channel key is derived again and the sequence
+	// numbers start over (e.g. after a process is restored from a checkpoint)
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	encMsg := &EncryptedMessage{
+		Id:        p.SelfID(),
+		SessionId: p.sessionID,
+		Type:      int32(tMsg.GetMessageType()),
+		Seq:       seq,
+		Nonce:     nonce,
+	}
+	ad, err := encMsg.additionalData()
+	if err != nil {
+		return nil, err
+	}
+	encMsg.Ciphertext = aead.Seal(nil, nonce, plaintext, ad)
+	return encMsg, nil
+}
+
+// EncryptedReceiver decrypts the received messages by the keys shared with the peers, and adds the decrypted
+// messages to the process. It implements MessageAdder, so it could receive the messages opened by a SignedReceiver.
+type EncryptedReceiver struct {
+	adder     MessageAdder
+	sessionID []byte
+	aeads     map[string]cipher.AEAD
+
+	lock sync.Mutex
+	// seen records the nonces of the received messages. The sequence numbers can't detect replays, because
+	// they start over if the sender is restored from a checkpoint.
+	seen map[string]map[string]bool
+}
+
+// NewEncryptedReceiver news an encrypted receiver of the self peer for the session.
+func NewEncryptedReceiver(adder MessageAdder, selfID string, sessionID []byte, privateKey []byte, publicKeys map[string][]byte) (*EncryptedReceiver, error) {
+	if len(sessionID) == 0 {
+		return nil, ErrInvalidSession
+	}
+	aeads := make(map[string]cipher.AEAD, len(publicKeys))
+	seen := make(map[string]map[string]bool, len(publicKeys))
+	for id, publicKey := range publicKeys {
+		if id == selfID {
+			continue
+		}
+		aead, err := newChannelAEAD(sessionID, privateKey, publicKey, id, selfID)
+		if err != nil {
+			log.Warn("Failed to derive key", "id", id, "err", err)
+			return nil, err
+		}
+		aeads[id] = aead
+		seen[id] = make(map[string]bool)
+	}
+	return &EncryptedReceiver{
+		adder:     adder,
+		sessionID: sessionID,
+		aeads:     aeads,
+		seen:      seen,
+	}, nil
+}
+
+// AddMessage decrypts the *EncryptedMessage and adds the decrypted message to the process.
+func (r *EncryptedReceiver) AddMessage(msg types.Message) error {
+	encMsg, ok := msg.(*EncryptedMessage)
+	if !ok {
+		return ErrInvalidMessage
+	}
+	decMsg, err := r.Decrypt(encMsg)
+	if err != nil {
+		return err
+	}
+	return r.adder.AddMessage(decMsg)
+}
+
+// Decrypt decrypts the message. The message is rejected if it is sent by an unknown peer, belongs to another
+// session, has been tampered, or has been received before.
+func (r *EncryptedReceiver) Decrypt(encMsg *EncryptedMessage) (types.Message, error) {
+	if !encMsg.IsValid() {
+		return nil, ErrInvalidMessage
+	}
+	id := encMsg.GetId()
+	aead, ok := r.aeads[id]
+	if !ok {
+		log.Warn("Unknown sender", "fromId", id)
+		return nil, ErrUnknownSender
+	}
+	if !bytes.Equal(encMsg.GetSessionId(), r.sessionID) {
+		log.Warn("Invalid session", "fromId", id)
+		return nil, ErrInvalidSession
+	}
+	ad, err := encMsg.additionalData()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, encMsg.GetNonce(), encMsg.GetCiphertext(), ad)
+	if err != nil {
+		log.Warn("Failed to decrypt message", "fromId", id, "err", err)
+		return nil, ErrDecryptionFailed
+	}
+	anyMsg := &any.Any{}
+	err = proto.Unmarshal(plaintext, anyMsg)
+	if err != nil {
+		return nil, err
+	}
+	var dynMsg ptypes.DynamicAny
+	err = ptypes.UnmarshalAny(anyMsg, &dynMsg)
+	if err != nil {
+		log.Warn("Failed to unmarshal message", "fromId", id, "err", err)
+		return nil, err
+	}
+	msg, ok := dynMsg.Message.(types.Message)
+	if !ok {
+		return nil, ErrInvalidMessage
+	}
+	if msg.GetId() != id {
+		log.Warn("Inconsistent sender", "fromId", id, "msgId", msg.GetId())
+		return nil, ErrInconsistentSender
+	}
+	if msg.GetMessageType() != encMsg.GetMessageType() {
+		log.Warn("Inconsistent message type", "fromId", id, "msgType", msg.GetMessageType(), "type", encMsg.GetMessageType())
+		return nil, ErrInvalidMessage
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	nonce := string(encMsg.GetNonce())
+	if r.seen[id][nonce] {
+		log.Warn("Replayed message", "fromId", id, "seq", encMsg.GetSeq())
+		return nil, ErrReplayedMessage
+	}
+	r.seen[id][nonce] = true
+	return msg, nil
+}
+
+// newChannelAEAD derives the key of the messages sent from the sender to the receiver in the session.
+func newChannelAEAD(sessionID []byte, privateKey []byte, publicKey []byte, from string, to string) (cipher.AEAD, error) {
+	if len(privateKey) != EncryptionKeySize || len(publicKey) != EncryptionKeySize {
+		return nil, ErrInvalidEncryptionKey
+	}
+	// X25519 rejects the low order points
+	shared, err := curve25519.X25519(privateKey, publicKey)
+	if err != nil {
+		return nil, ErrInvalidEncryptionKey
+	}
+	info := proto.NewBuffer([]byte(encryptionLabel))
+	if err = info.EncodeStringBytes(from); err != nil {
+		return nil, err
+	}
+	if err = info.EncodeStringBytes(to); err != nil {
+		return nil, err
+	}
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err = io.ReadFull(hkdf.New(sha256.New, shared, sessionID, info.Bytes()), key); err != nil {
+		return nil, err
+	}
+	return chacha20poly1305.NewX(key)
+}
+
+func (m *EncryptedMessage) additionalData() ([]byte, error) {
+	buf := proto.NewBuffer([]byte(encryptionLabel))
+	buf.SetDeterministic(true)
+	err := buf.Marshal(&EncryptedMessage{
+		Id:        m.GetId(),
+		SessionId: m.GetSessionId(),
+		Type:      m.GetType(),
+		Seq:       m.GetSeq(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,221 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Encrypted message", func() {
+	var (
+		sessionID = []byte("session")
+		ids       = []string{"id-0", "id-1", "id-2"}
+		secret    = []byte("very secret share")
+
+		pms        map[string]*EncryptedPeerManager
+		receivers  map[string]*EncryptedReceiver
+		adders     map[string]*fakeAdder
+		privKeys   map[string][]byte
+		publicKeys map[string][]byte
+	)
+
+	newMsg := func(id string) *EchoMessage {
+		return &EchoMessage{
+			Id:        id,
+			SessionId: sessionID,
+			Type:      1,
+			Digests: []*EchoDigest{
+				{Id: id, Hash: secret},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		privKeys = make(map[string][]byte, len(ids))
+		publicKeys = make(map[string][]byte, len(ids))
+		for _, id := range ids {
+			var err error
+			privKeys[id], publicKeys[id], err = GenerateEncryptionKey(rand.Reader)
+			Expect(err).Should(BeNil())
+		}
+		pms = make(map[string]*EncryptedPeerManager, len(ids))
+		receivers = make(map[string]*EncryptedReceiver, len(ids))
+		adders = make(map[string]*fakeAdder, len(ids))
+		for _, id := range ids {
+			pm := new(mocks.PeerManager)
+			pm.On("SelfID").Return(id)
+			pm.On("NumPeers").Return(uint32(len(ids) - 1))
+			var peerIDs []string
+			for _, peerID := range ids {
+				if peerID != id {
+					peerIDs = append(peerIDs, peerID)
+				}
+			}
+			var err error
+			pms[id], err = NewEncryptedPeerManager(&peerIDsManager{PeerManager: pm, ids: peerIDs}, sessionID, privKeys[id], publicKeys)
+			Expect(err).Should(BeNil())
+			adders[id] = &fakeAdder{}
+			receivers[id], err = NewEncryptedReceiver(adders[id], id, sessionID, privKeys[id], publicKeys)
+			Expect(err).Should(BeNil())
+		}
+	})
+
+	It("should be ok", func() {
+		msg := newMsg("id-0")
+		pm := pms["id-0"].PeerManager.(*peerIDsManager).PeerManager.(*mocks.PeerManager)
+		pm.On("MustSend", "id-1", mock.AnythingOfType("*message.EncryptedMessage")).Run(func(args mock.Arguments) {
+			encMsg := args.Get(1).(*EncryptedMessage)
+			Expect(bytes.Contains(encMsg.Ciphertext, secret)).Should(BeFalse())
+			Expect(receivers["id-1"].AddMessage(encMsg)).Should(BeNil())
+		}).Twice()
+		pms["id-0"].MustSend("id-1", msg)
+		pms["id-0"].MustSend("id-1", msg)
+		pm.AssertExpectations(GinkgoT())
+
+		Expect(adders["id-1"].msgs).Should(HaveLen(2))
+		for _, got := range adders["id-1"].msgs {
+			Expect(proto.Equal(got.(proto.Message), msg)).Should(BeTrue())
+		}
+	})
+
+	It("works with signed envelopes", func() {
+		pub, priv, err := ed25519.GenerateKey(nil)
+		Expect(err).Should(BeNil())
+		signedPM, err := NewSignedPeerManager(pms["id-0"].PeerManager, sessionID, priv)
+		Expect(err).Should(BeNil())
+		encPM, err := NewEncryptedPeerManager(signedPM, sessionID, privKeys["id-0"], publicKeys)
+		Expect(err).Should(BeNil())
+		signedReceiver, err := NewSignedReceiver(receivers["id-1"], "id-1", sessionID, map[string]ed25519.PublicKey{"id-0": pub})
+		Expect(err).Should(BeNil())
+
+		msg := newMsg("id-0")
+		pm := pms["id-0"].PeerManager.(*peerIDsManager).PeerManager.(*mocks.PeerManager)
+		pm.On("MustSend", "id-1", mock.AnythingOfType("*message.Envelope")).Run(func(args mock.Arguments) {
+			Expect(signedReceiver.AddEnvelope(args.Get(1).(*Envelope))).Should(BeNil())
+		}).Once()
+		encPM.MustSend("id-1", msg)
+		pm.AssertExpectations(GinkgoT())
+		Expect(adders["id-1"].msgs).Should(HaveLen(1))
+		Expect(proto.Equal(adders["id-1"].msgs[0].(proto.Message), msg)).Should(BeTrue())
+	})
+
+	It("rejects replayed messages", func() {
+		encMsg, err := pms["id-0"].Encrypt("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		Expect(receivers["id-1"].AddMessage(encMsg)).Should(BeNil())
+		Expect(receivers["id-1"].AddMessage(encMsg)).Should(Equal(ErrReplayedMessage))
+	})
+
+	It("rejects tampered messages", func() {
+		encMsg, err := pms["id-0"].Encrypt("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		encMsg.Seq++
+		Expect(receivers["id-1"].AddMessage(encMsg)).Should(Equal(ErrDecryptionFailed))
+		encMsg.Seq--
+		encMsg.Nonce[0] ^= 1
+		Expect(receivers["id-1"].AddMessage(encMsg)).Should(Equal(ErrDecryptionFailed))
+		encMsg.Nonce[0] ^= 1
+		encMsg.Ciphertext[0] ^= 1
+		Expect(receivers["id-1"].AddMessage(encMsg)).Should(Equal(ErrDecryptionFailed))
+		Expect(adders["id-1"].msgs).Should(BeEmpty())
+	})
+
+	It("uses different nonces after the peer manager is created again", func() {
+		encMsg, err := pms["id-0"].Encrypt("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		pm, err := NewEncryptedPeerManager(pms["id-0"].PeerManager, sessionID, privKeys["id-0"], publicKeys)
+		Expect(err).Should(BeNil())
+		encMsg2, err := pm.Encrypt("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		Expect(encMsg2.Seq).Should(Equal(encMsg.Seq))
+		Expect(encMsg2.Nonce).ShouldNot(Equal(encMsg.Nonce))
+		// The messages of the restarted sender are not taken as replays
+		Expect(receivers["id-1"].AddMessage(encMsg)).Should(BeNil())
+		Expect(receivers["id-1"].AddMessage(encMsg2)).Should(BeNil())
+		Expect(adders["id-1"].msgs).Should(HaveLen(2))
+	})
+
+	It("fails if the message cannot be encrypted", func() {
+		Expect(pms["id-0"].Err()).Should(BeNil())
+		Consistently(pms["id-0"].Failed()).ShouldNot(BeClosed())
+		pms["id-0"].MustSend("unknown", newMsg("id-0"))
+		Eventually(pms["id-0"].Failed()).Should(BeClosed())
+		Expect(pms["id-0"].Err()).Should(Equal(ErrUnknownSender))
+
+		// The first error is kept
+		pms["id-0"].MustSend("id-1", &EchoDigest{})
+		Expect(pms["id-0"].Err()).Should(Equal(ErrUnknownSender))
+	})
+
+	It("rejects messages for other peers", func() {
+		encMsg, err := pms["id-0"].Encrypt("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		Expect(receivers["id-2"].AddMessage(encMsg)).Should(Equal(ErrDecryptionFailed))
+	})
+
+	It("rejects spoofed messages", func() {
+		// id-2 claims to be id-0
+		encMsg, err := pms["id-2"].Encrypt("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		Expect(receivers["id-1"].AddMessage(encMsg)).Should(Equal(ErrInconsistentSender))
+		encMsg.Id = "id-0"
+		Expect(receivers["id-1"].AddMessage(encMsg)).Should(Equal(ErrDecryptionFailed))
+	})
+
+	It("rejects messages of other sessions", func() {
+		r, err := NewEncryptedReceiver(adders["id-1"], "id-1", []byte("another session"), privKeys["id-1"], publicKeys)
+		Expect(err).Should(BeNil())
+		encMsg, err := pms["id-0"].Encrypt("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		Expect(r.AddMessage(encMsg)).Should(Equal(ErrInvalidSession))
+		encMsg.SessionId = []byte("another session")
+		Expect(r.AddMessage(encMsg)).Should(Equal(ErrDecryptionFailed))
+	})
+
+	It("rejects unencrypted messages", func() {
+		Expect(receivers["id-1"].AddMessage(newMsg("id-0"))).Should(Equal(ErrInvalidMessage))
+	})
+
+	It("invalid keys", func() {
+		pm := pms["id-0"].PeerManager
+		_, err := NewEncryptedPeerManager(pm, sessionID, []byte{1, 2, 3}, publicKeys)
+		Expect(err).Should(Equal(ErrInvalidEncryptionKey))
+		_, err = NewEncryptedPeerManager(pm, sessionID, privKeys["id-0"], map[string][]byte{
+			"id-1": publicKeys["id-1"],
+			"id-2": make([]byte, EncryptionKeySize),
+		})
+		Expect(err).Should(Equal(ErrInvalidEncryptionKey))
+		_, err = NewEncryptedPeerManager(pm, sessionID, privKeys["id-0"], map[string][]byte{
+			"id-1": publicKeys["id-1"],
+		})
+		Expect(err).Should(Equal(ErrUnknownSender))
+		_, err = NewEncryptedPeerManager(pm, nil, privKeys["id-0"], publicKeys)
+		Expect(err).Should(Equal(ErrInvalidSession))
+	})
+
+	It("unknown peers", func() {
+		pm := pms["id-0"].PeerManager.(*peerIDsManager).PeerManager
+		_, err := NewEncryptedPeerManager(pm, sessionID, privKeys["id-0"], publicKeys)
+		Expect(err).Should(Equal(ErrUnknownPeers))
+	})
+})
@@ -0,0 +1,239 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"sync"
+
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const envelopeLabel = "alice-envelope"
+
+var (
+	// ErrUnknownSender is returned if the identity key of the sender is not configured
+	ErrUnknownSender = errors.New("unknown sender")
+	// ErrInvalidSignature is returned if the signature of the envelope is invalid
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrInconsistentSender is returned if the id of the wrapped message is different from the signer of the envelope
+	ErrInconsistentSender = errors.New("inconsistent sender")
+	// ErrInvalidIdentityKey is returned if the identity key is invalid
+	ErrInvalidIdentityKey = errors.New("invalid identity key")
+	// ErrInvalidRecipient is returned if the envelope is sent to another peer
+	ErrInvalidRecipient = errors.New("invalid recipient")
+)
+
+// MessageAdder defines the processes receiving messages (e.g. DKG, signer and reshare)
+type MessageAdder interface {
+	AddMessage(msg types.Message) error
+}
+
+// SignedPeerManager wraps a peer manager. It wraps the sent messages in envelopes signed by the identity key,
+// so the peers must receive *Envelope instead and open them by a SignedReceiver.
+type SignedPeerManager struct {
+	types.PeerManager
+
+	sessionID  []byte
+	privateKey ed25519.PrivateKey
+
+	lock   sync.Mutex
+	err    error
+	failed chan struct{}
+}
+
+// NewSignedPeerManager news a signed peer manager of the session with the ed25519 identity key of the self peer.
+func NewSignedPeerManager(peerManager types.PeerManager, sessionID []byte, privateKey ed25519.PrivateKey) (*SignedPeerManager, error) {
+	if len(sessionID) == 0 {
+		return nil, ErrInvalidSession
+	}
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, ErrInvalidIdentityKey
+	}
+	return &SignedPeerManager{
+		PeerManager: peerManager,
+		sessionID:   sessionID,
+		privateKey:  privateKey,
+		failed:      make(chan struct{}),
+	}, nil
+}
+
+// PeerIDs returns the ids of the peers given by the wrapped peer manager. It returns nil if the wrapped one
+// doesn't implement types.PeerIDsGetter.
+func (p *SignedPeerManager) PeerIDs() []string {
+	g, ok := p.PeerManager.(types.PeerIDsGetter)
+	if !ok {
+		return nil
+	}
+	return g.PeerIDs()
+}
+
+// MustSend signs the message and sends the envelope to the peer. If the message cannot be sealed, it's dropped
+// and the peer manager fails. The peers would wait for the message forever, so the caller should watch Failed
+// and stop the process.
+func (p *SignedPeerManager) MustSend(id string, msg proto.Message) {
+	envelope, err := p.Seal(id, msg)
+	if err != nil {
+		log.Error("Failed to seal message", "to", id, "err", err)
+		p.fail(err)
+		return
+	}
+	p.PeerManager.MustSend(id, envelope)
+}
+
+// Failed returns a channel which is closed once the peer manager fails to send a message.
+func (p *SignedPeerManager) Failed() <-chan struct{} {
+	return p.failed
+}
+
+// Err returns the error of the first message which failed to be sent, or nil if the peer manager hasn't failed.
+func (p *SignedPeerManager) Err() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.err
+}
+
+func (p *SignedPeerManager) fail(err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.err != nil {
+		return
+	}
+	p.err = err
+	close(p.failed)
+}
+
+// Seal wraps the message to the peer in an envelope signed by the identity key.
+func (p *SignedPeerManager) Seal(id string, msg proto.Message) (*Envelope, error) {
+	anyMsg, err := ptypes.MarshalAny(msg)
+	if err != nil {
+		return nil, err
+	}
+	envelope := &Envelope{
+		Id:        p.SelfID(),
+		Message:   anyMsg,
+		To:        id,
+		SessionId: p.sessionID,
+	}
+	bs, err := envelope.signedBytes()
+	if err != nil {
+		return nil, err
+	}
+	envelope.Signature = ed25519.Sign(p.privateKey, bs)
+	return envelope, nil
+}
+
+// SignedReceiver verifies the received envelopes with the identity keys of the peers, and adds the wrapped
+// messages to the process.
+type SignedReceiver struct {
+	adder      MessageAdder
+	selfID     string
+	sessionID  []byte
+	publicKeys map[string]ed25519.PublicKey
+}
+
+// NewSignedReceiver news a signed receiver of the self peer for the session. The public keys map the peer ids to
+// their ed25519 identity keys.
+func NewSignedReceiver(adder MessageAdder, selfID string, sessionID []byte, publicKeys map[string]ed25519.PublicKey) (*SignedReceiver, error) {
+	if len(sessionID) == 0 {
+		return nil, ErrInvalidSession
+	}
+	for id, key := range publicKeys {
+		if len(key) != ed25519.PublicKeySize {
+			log.Warn("Invalid identity key", "id", id)
+			return nil, ErrInvalidIdentityKey
+		}
+	}
+	return &SignedReceiver{
+		adder:      adder,
+		selfID:     selfID,
+		sessionID:  sessionID,
+		publicKeys: publicKeys,
+	}, nil
+}
+
+// AddEnvelope opens the envelope and adds the wrapped message to the process.
+func (r *SignedReceiver) AddEnvelope(envelope *Envelope) error {
+	msg, err := r.Open(envelope)
+	if err != nil {
+		return err
+	}
+	return r.adder.AddMessage(msg)
+}
+
+// Open verifies the signature of the envelope and returns the wrapped message. The message is rejected if the
+// sender is unknown, the signature is invalid, the envelope is sent to another peer or belongs to another session,
+// or the message claims to be sent by another peer.
+func (r *SignedReceiver) Open(envelope *Envelope) (types.Message, error) {
+	id := envelope.GetId()
+	key, ok := r.publicKeys[id]
+	if !ok {
+		log.Warn("Unknown sender", "fromId", id)
+		return nil, ErrUnknownSender
+	}
+	if envelope.GetTo() != r.selfID {
+		log.Warn("Invalid recipient", "fromId", id, "to", envelope.GetTo())
+		return nil, ErrInvalidRecipient
+	}
+	if !bytes.Equal(envelope.GetSessionId(), r.sessionID) {
+		log.Warn("Invalid session", "fromId", id)
+		return nil, ErrInvalidSession
+	}
+	bs, err := envelope.signedBytes()
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(key, bs, envelope.GetSignature()) {
+		log.Warn("Invalid signature", "fromId", id)
+		return nil, ErrInvalidSignature
+	}
+	var dynMsg ptypes.DynamicAny
+	err = ptypes.UnmarshalAny(envelope.GetMessage(), &dynMsg)
+	if err != nil {
+		log.Warn("Failed to unmarshal message", "fromId", id, "err", err)
+		return nil, err
+	}
+	msg, ok := dynMsg.Message.(types.Message)
+	if !ok {
+		return nil, ErrInvalidMessage
+	}
+	if msg.GetId() != id {
+		log.Warn("Inconsistent sender", "fromId", id, "msgId", msg.GetId())
+		return nil, ErrInconsistentSender
+	}
+	return msg, nil
+}
+
+// signedBytes returns the bytes signed by the sender, which are the label, the sender id, the receiver id, the
+// session id and the wrapped message.
+func (m *Envelope) signedBytes() ([]byte, error) {
+	buf := proto.NewBuffer([]byte(envelopeLabel))
+	buf.SetDeterministic(true)
+	err := buf.Marshal(&Envelope{
+		Id:        m.GetId(),
+		Message:   m.GetMessage(),
+		To:        m.GetTo(),
+		SessionId: m.GetSessionId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
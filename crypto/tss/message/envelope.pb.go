@@ -0,0 +1,120 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/getamis/alice/crypto/tss/message/envelope.proto
+
+package message
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	any "github.com/golang/protobuf/ptypes/any"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Envelope wraps a message signed by the identity key of the sender
+type Envelope struct {
+	Id        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Message   *any.Any `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Signature []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	// to is the id of the receiver
+	To                   string   `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	SessionId            []byte   `protobuf:"bytes,5,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Envelope) Reset()         { *m = Envelope{} }
+func (m *Envelope) String() string { return proto.CompactTextString(m) }
+func (*Envelope) ProtoMessage()    {}
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_13aebfa116a0112d, []int{0}
+}
+
+func (m *Envelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Envelope.Unmarshal(m, b)
+}
+func (m *Envelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Envelope.Marshal(b, m, deterministic)
+}
+func (m *Envelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Envelope.Merge(m, src)
+}
+func (m *Envelope) XXX_Size() int {
+	return xxx_messageInfo_Envelope.Size(m)
+}
+func (m *Envelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_Envelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Envelope proto.InternalMessageInfo
+
+func (m *Envelope) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Envelope) GetMessage() *any.Any {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *Envelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Envelope) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *Envelope) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Envelope)(nil), "message.Envelope")
+}
+
+func init() {
+	proto.RegisterFile("github.com/getamis/alice/crypto/tss/message/envelope.proto", fileDescriptor_13aebfa116a0112d)
+}
+
+var fileDescriptor_13aebfa116a0112d = []byte{
+	// 205 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0xcd, 0x3f, 0x4b, 0xc6, 0x30,
+	0x10, 0xc7, 0x71, 0x52, 0xff, 0x3e, 0x51, 0x1c, 0x82, 0x43, 0x14, 0x85, 0xe2, 0xd4, 0x29, 0x01,
+	0xdd, 0xdc, 0x1c, 0x1c, 0x5c, 0xfb, 0x06, 0x24, 0x6d, 0xcf, 0x78, 0xd0, 0xe6, 0x4a, 0xef, 0x2a,
+	0xf4, 0x95, 0xf8, 0x76, 0xc5, 0xa6, 0xe5, 0x59, 0xbf, 0xfc, 0xee, 0x73, 0xfa, 0x35, 0xa2, 0x7c,
+	0xcf, 0x8d, 0x6b, 0x69, 0xf0, 0x11, 0x24, 0x0c, 0xc8, 0x3e, 0xf4, 0xd8, 0x82, 0x6f, 0xa7, 0x65,
+	0x14, 0xf2, 0xc2, 0xec, 0x07, 0x60, 0x0e, 0x11, 0x3c, 0xa4, 0x1f, 0xe8, 0x69, 0x04, 0x37, 0x4e,
+	0x24, 0x64, 0x2e, 0xb6, 0x7e, 0x7f, 0x17, 0x89, 0x62, 0x0f, 0x7e, 0xcd, 0xcd, 0xfc, 0xe5, 0x43,
+	0x5a, 0xf2, 0xe6, 0xe9, 0x57, 0xe9, 0xcb, 0xf7, 0xed, 0xcc, 0xdc, 0xe8, 0x02, 0x3b, 0xab, 0x4a,
+	0x55, 0x1d, 0xea, 0x02, 0x3b, 0xe3, 0xf4, 0x4e, 0xd8, 0xa2, 0x54, 0xd5, 0xd5, 0xf3, 0xad, 0xcb,
+	0x92, 0xdb, 0x25, 0xf7, 0x96, 0x96, 0x7a, 0x1f, 0x99, 0x07, 0x7d, 0x60, 0x8c, 0x29, 0xc8, 0x3c,
+	0x81, 0x3d, 0x29, 0x55, 0x75, 0x5d, 0x1f, 0xc3, 0xbf, 0x2e, 0x64, 0x4f, 0xb3, 0x2e, 0x64, 0x1e,
+	0xb5, 0x66, 0x60, 0x46, 0x4a, 0x9f, 0xd8, 0xd9, 0xb3, 0x6d, 0x9e, 0xcb, 0x47, 0xd7, 0x9c, 0xaf,
+	0x3f, 0x5e, 0xfe, 0x06, 0x00, 0xa3, 0x85, 0x4e, 0xf5, 0x02, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,176 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"crypto/ed25519"
+
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Envelope", func() {
+	var (
+		sessionID  = []byte("session")
+		ids        = []string{"id-0", "id-1"}
+		pms        map[string]*SignedPeerManager
+		publicKeys map[string]ed25519.PublicKey
+		adder      *fakeAdder
+		receiver   *SignedReceiver
+	)
+
+	BeforeEach(func() {
+		pms = make(map[string]*SignedPeerManager, len(ids))
+		publicKeys = make(map[string]ed25519.PublicKey, len(ids))
+		for _, id := range ids {
+			pub, priv, err := ed25519.GenerateKey(nil)
+			Expect(err).Should(BeNil())
+			pm := new(mocks.PeerManager)
+			pm.On("SelfID").Return(id)
+			pms[id], err = NewSignedPeerManager(pm, sessionID, priv)
+			Expect(err).Should(BeNil())
+			publicKeys[id] = pub
+		}
+		adder = &fakeAdder{}
+		var err error
+		receiver, err = NewSignedReceiver(adder, "id-1", sessionID, publicKeys)
+		Expect(err).Should(BeNil())
+	})
+
+	newMsg := func(id string) *EchoMessage {
+		return &EchoMessage{
+			Id:        id,
+			SessionId: sessionID,
+			Type:      1,
+		}
+	}
+
+	It("should be ok", func() {
+		msg := newMsg("id-0")
+		envelope, err := pms["id-0"].Seal("id-1", msg)
+		Expect(err).Should(BeNil())
+		Expect(receiver.AddEnvelope(envelope)).Should(BeNil())
+		Expect(adder.msgs).Should(HaveLen(1))
+		Expect(proto.Equal(adder.msgs[0].(proto.Message), msg)).Should(BeTrue())
+	})
+
+	It("sends envelopes", func() {
+		msg := newMsg("id-0")
+		pm := pms["id-0"].PeerManager.(*mocks.PeerManager)
+		pm.On("MustSend", "id-1", mock.AnythingOfType("*message.Envelope")).Run(func(args mock.Arguments) {
+			got, err := receiver.Open(args.Get(1).(*Envelope))
+			Expect(err).Should(BeNil())
+			Expect(proto.Equal(got.(proto.Message), msg)).Should(BeTrue())
+		}).Once()
+		pms["id-0"].MustSend("id-1", msg)
+		pm.AssertExpectations(GinkgoT())
+	})
+
+	It("fails if the message cannot be sealed", func() {
+		Expect(pms["id-0"].Err()).Should(BeNil())
+		Consistently(pms["id-0"].Failed()).ShouldNot(BeClosed())
+		pms["id-0"].MustSend("id-1", (*EchoMessage)(nil))
+		Eventually(pms["id-0"].Failed()).Should(BeClosed())
+		err := pms["id-0"].Err()
+		Expect(err).ShouldNot(BeNil())
+
+		// The first error is kept
+		pms["id-0"].MustSend("id-1", (*EchoDigest)(nil))
+		Expect(pms["id-0"].Err()).Should(Equal(err))
+	})
+
+	It("returns the peer ids of the wrapped peer manager", func() {
+		Expect(pms["id-0"].PeerIDs()).Should(BeNil())
+		_, priv, err := ed25519.GenerateKey(nil)
+		Expect(err).Should(BeNil())
+		pm, err := NewSignedPeerManager(&peerIDsManager{PeerManager: pms["id-0"].PeerManager, ids: []string{"id-1"}}, sessionID, priv)
+		Expect(err).Should(BeNil())
+		Expect(pm.PeerIDs()).Should(Equal([]string{"id-1"}))
+	})
+
+	It("rejects tampered messages", func() {
+		envelope, err := pms["id-0"].Seal("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		envelope.Message.Value = append(envelope.Message.Value, 0)
+		Expect(receiver.AddEnvelope(envelope)).Should(Equal(ErrInvalidSignature))
+		Expect(adder.msgs).Should(BeEmpty())
+	})
+
+	It("rejects spoofed envelopes", func() {
+		// id-1 claims to be id-0
+		envelope, err := pms["id-1"].Seal("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		envelope.Id = "id-0"
+		Expect(receiver.AddEnvelope(envelope)).Should(Equal(ErrInvalidSignature))
+		Expect(adder.msgs).Should(BeEmpty())
+	})
+
+	It("rejects spoofed messages", func() {
+		// id-1 signs a message of id-0
+		envelope, err := pms["id-1"].Seal("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		Expect(receiver.AddEnvelope(envelope)).Should(Equal(ErrInconsistentSender))
+		Expect(adder.msgs).Should(BeEmpty())
+	})
+
+	It("rejects envelopes to other peers", func() {
+		envelope, err := pms["id-0"].Seal("id-2", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		Expect(receiver.AddEnvelope(envelope)).Should(Equal(ErrInvalidRecipient))
+		envelope.To = "id-1"
+		Expect(receiver.AddEnvelope(envelope)).Should(Equal(ErrInvalidSignature))
+		Expect(adder.msgs).Should(BeEmpty())
+	})
+
+	It("rejects envelopes of other sessions", func() {
+		pm, err := NewSignedPeerManager(pms["id-0"].PeerManager, []byte("another session"), pms["id-0"].privateKey)
+		Expect(err).Should(BeNil())
+		envelope, err := pm.Seal("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		Expect(receiver.AddEnvelope(envelope)).Should(Equal(ErrInvalidSession))
+		envelope.SessionId = sessionID
+		Expect(receiver.AddEnvelope(envelope)).Should(Equal(ErrInvalidSignature))
+		Expect(adder.msgs).Should(BeEmpty())
+	})
+
+	It("unknown sender", func() {
+		envelope, err := pms["id-0"].Seal("id-1", newMsg("id-0"))
+		Expect(err).Should(BeNil())
+		envelope.Id = "id-2"
+		Expect(receiver.AddEnvelope(envelope)).Should(Equal(ErrUnknownSender))
+	})
+
+	It("invalid identity keys", func() {
+		pm, err := NewSignedPeerManager(new(mocks.PeerManager), sessionID, ed25519.PrivateKey{1, 2, 3})
+		Expect(err).Should(Equal(ErrInvalidIdentityKey))
+		Expect(pm).Should(BeNil())
+		r, err := NewSignedReceiver(adder, "id-1", sessionID, map[string]ed25519.PublicKey{"id-0": {1, 2, 3}})
+		Expect(err).Should(Equal(ErrInvalidIdentityKey))
+		Expect(r).Should(BeNil())
+	})
+})
+
+type fakeAdder struct {
+	msgs []types.Message
+}
+
+func (f *fakeAdder) AddMessage(msg types.Message) error {
+	f.msgs = append(f.msgs, msg)
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/getamis/alice/crypto/tss/message/types"
+)
+
+// Failure is the report of a failed process. It tells which peers made the process failed, so that
+// they could be excluded before the process is restarted.
+type Failure struct {
+	// MessageType is the message type of the round in which the process failed
+	MessageType types.MessageType
+	// Culprits are the ids of the peers who are responsible for the failure. It's empty if nobody could be blamed.
+	Culprits []string
+	// Evidence are the offending messages
+	Evidence []types.Message
+	// Err is the error which made the process failed
+	Err error
+}
+
+// BlameError could be returned by HandleMessage or Finalize of a handler if the handler identifies the
+// misbehaving peers by itself. Otherwise, the sender of the message is blamed if HandleMessage fails,
+// and nobody is blamed if Finalize fails.
+type BlameError struct {
+	// Culprits are the ids of the misbehaving peers
+	Culprits []string
+	// Evidence are the offending messages
+	Evidence []types.Message
+	// Err is the underlying error
+	Err error
+}
+
+// NewBlameError returns a blame error which blames the senders of the messages.
+func NewBlameError(err error, msgs ...types.Message) *BlameError {
+	culprits := make([]string, len(msgs))
+	for i, msg := range msgs {
+		culprits[i] = msg.GetId()
+	}
+	return &BlameError{
+		Culprits: culprits,
+		Evidence: msgs,
+		Err:      err,
+	}
+}
+
+func (e *BlameError) Error() string {
+	return fmt.Sprintf("%v (culprits: %v)", e.Err, e.Culprits)
+}
+
+func (e *BlameError) Unwrap() error {
+	return e.Err
+}
+
+// newFailure builds the failure report of the round. The sender of msg is blamed if the error doesn't
+// identify the culprits by itself. msg could be nil if the error isn't caused by a message.
+func newFailure(msgType types.MessageType, err error, msg types.Message) *Failure {
+	f := &Failure{
+		MessageType: msgType,
+		Err:         err,
+	}
+	var blameErr *BlameError
+	var timeoutErr *TimeoutError
+	switch {
+	case errors.As(err, &blameErr):
+		f.Culprits = blameErr.Culprits
+		f.Evidence = blameErr.Evidence
+	case errors.As(err, &timeoutErr):
+		f.Culprits = timeoutErr.MissingPeers
+	case msg != nil:
+		f.Culprits = []string{msg.GetId()}
+		f.Evidence = []types.Message{msg}
+	}
+	return f
+}
@@ -0,0 +1,65 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"errors"
+
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Failure", func() {
+	var (
+		unknownErr = errors.New("unknown error")
+		msgType    = types.MessageType(1)
+	)
+
+	It("NewBlameError()", func() {
+		msg1 := new(mocks.Message)
+		msg1.On("GetId").Return("id-1").Once()
+		msg2 := new(mocks.Message)
+		msg2.On("GetId").Return("id-2").Once()
+
+		err := NewBlameError(unknownErr, msg1, msg2)
+		Expect(err.Culprits).Should(Equal([]string{"id-1", "id-2"}))
+		Expect(err.Evidence).Should(Equal([]types.Message{msg1, msg2}))
+		Expect(errors.Is(err, unknownErr)).Should(BeTrue())
+		msg1.AssertExpectations(GinkgoT())
+		msg2.AssertExpectations(GinkgoT())
+	})
+
+	It("blames nobody without message", func() {
+		f := newFailure(msgType, unknownErr, nil)
+		Expect(f).Should(Equal(&Failure{
+			MessageType: msgType,
+			Err:         unknownErr,
+		}))
+	})
+
+	It("prefers the culprits of the blame error", func() {
+		msg := new(mocks.Message)
+		err := &BlameError{
+			Culprits: []string{"id-2"},
+			Err:      unknownErr,
+		}
+		f := newFailure(msgType, err, msg)
+		Expect(f.Culprits).Should(Equal([]string{"id-2"}))
+		Expect(f.Evidence).Should(BeNil())
+		msg.AssertExpectations(GinkgoT())
+	})
+})
@@ -15,43 +15,120 @@
 package message
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/sirius/log"
+	"github.com/golang/protobuf/proto"
 )
 
 var (
 	ErrOldMessage             = errors.New("old message")
 	ErrInvalidStateTransition = errors.New("invalid state transition")
 	ErrDupMsg                 = errors.New("duplicate message")
+	ErrInvalidSession         = errors.New("invalid session")
+	ErrUnknownPeers           = errors.New("unknown peers")
 )
 
+// TimeoutError is returned if the messages of a round are not collected before the deadline.
+type TimeoutError struct {
+	// MessageType is the message type of the round which is timed out
+	MessageType types.MessageType
+	// MissingPeers are the ids of the peers whose messages never arrived in this round
+	MissingPeers []string
+	// Session is true if the session deadline is reached, otherwise it's the round deadline
+	Session bool
+}
+
+func (e *TimeoutError) Error() string {
+	deadline := "round"
+	if e.Session {
+		deadline = "session"
+	}
+	return fmt.Sprintf("%s timeout: missing messages (type %d) from %v", deadline, e.MessageType, e.MissingPeers)
+}
+
 type MsgMain struct {
 	logger         log.Logger
+	peerManager    types.PeerManager
+	sessionID      []byte
 	peerNum        uint32
 	msgChs         *MsgChans
 	state          types.MainState
+	failure        *Failure
 	currentHandler types.Handler
 	listener       types.StateChangedListener
+	// messages are all the accepted messages. They are kept for checkpoints.
+	messages []types.Message
+	// echoChs collects the echo messages of the broadcast rounds. It's nil if the echo broadcast is disabled.
+	echoChs *MsgChans
+	// echoes are all the accepted echo messages. They are kept for checkpoints and used to ignore the duplicated ones.
+	echoes []*EchoMessage
+	// rounds maps the message types to the orders of their rounds
+	rounds map[types.MessageType]int
+
+	// handlerLock is held by the message loop while the current handler is handling messages
+	handlerLock sync.Mutex
+
+	// roundTimeout and sessionTimeout are disabled if they are zero
+	roundTimeout   time.Duration
+	sessionTimeout time.Duration
+
+	// metrics is nil if it's not set
+	metrics  types.Metrics
+	protocol string
 
 	lock   sync.RWMutex
 	cancel context.CancelFunc
 }
 
-func NewMsgMain(id string, peerNum uint32, listener types.StateChangedListener, initHandler types.Handler, msgTypes ...types.MessageType) *MsgMain {
+// NewMsgMain news a message main with the message types of the rounds, which must be in the order of the rounds.
+// The values of the message types need not be increasing, so new types could be appended to the enums.
+func NewMsgMain(peerManager types.PeerManager, sessionID []byte, listener types.StateChangedListener, initHandler types.Handler, msgTypes ...types.MessageType) *MsgMain {
+	peerNum := peerManager.NumPeers()
+	rounds := make(map[types.MessageType]int, len(msgTypes))
+	for i, msgType := range msgTypes {
+		rounds[msgType] = i
+	}
 	return &MsgMain{
-		logger:         log.New("self", id),
+		logger:         log.New("self", peerManager.SelfID()),
+		peerManager:    peerManager,
+		sessionID:      sessionID,
 		peerNum:        peerNum,
 		msgChs:         NewMsgChans(peerNum, msgTypes...),
+		rounds:         rounds,
 		state:          types.StateInit,
 		currentHandler: initHandler,
 		listener:       listener,
 	}
 }
 
+// SetTimeout sets the deadline of each round and the deadline of the whole session. It should be called before Start.
+// If a deadline is reached, the process goes to StateFailed and GetError returns a *TimeoutError. The error names
+// the missing peers only if the handler or the peer manager knows the peers of the round.
+func (t *MsgMain) SetTimeout(roundTimeout time.Duration, sessionTimeout time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.roundTimeout = roundTimeout
+	t.sessionTimeout = sessionTimeout
+}
+
+// SetMetrics sets the metrics of the process with the protocol label. It should be called before Start.
+func (t *MsgMain) SetMetrics(protocol string, metrics types.Metrics) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.protocol = protocol
+	t.metrics = metrics
+}
+
 func (t *MsgMain) Start() {
 	t.lock.Lock()
 	defer t.lock.Unlock()
@@ -77,16 +154,91 @@ func (t *MsgMain) Stop() {
 }
 
 func (t *MsgMain) AddMessage(msg types.Message) error {
-	currentMsgType := t.currentHandler.MessageType()
+	sessionID := msg.GetSessionId()
+	if !bytes.Equal(sessionID, t.sessionID) {
+		t.logger.Debug("Ignore message of other session", "sessionID", sessionID)
+		return ErrInvalidSession
+	}
+	currentMsgType := t.GetHandler().MessageType()
 	newMessageType := msg.GetMessageType()
-	if currentMsgType > newMessageType {
+	if t.isBefore(newMessageType, currentMsgType) {
 		t.logger.Debug("Ignore old message", "currentMsgType", currentMsgType, "newMessageType", newMessageType)
 		return ErrOldMessage
 	}
-	return t.msgChs.Push(msg)
+	if echo, ok := msg.(*EchoMessage); ok {
+		return t.addEchoMessage(echo)
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	// A restored peer may send the same messages again. Ignore them silently. The different messages
+	// are still pushed, so that the message loop would fail on them.
+	if t.isResent(msg) {
+		t.logger.Debug("Ignore resent message", "msgType", newMessageType, "fromId", msg.GetId())
+		return nil
+	}
+	err := t.msgChs.Push(msg)
+	if err != nil {
+		return err
+	}
+	t.messages = append(t.messages, msg)
+	return nil
+}
+
+// Snapshot calls fn with the current handler, all the accepted messages and the accepted echo messages. The message
+// loop is paused during the call, so that the state of the handler could be read consistently. The echoes must be
+// kept as well, because the peers never echo again if the snapshot is made in an echo broadcast round.
+func (t *MsgMain) Snapshot(fn func(handler types.Handler, msgs []types.Message, echoes []*EchoMessage) error) error {
+	t.handlerLock.Lock()
+	defer t.handlerLock.Unlock()
+
+	t.lock.RLock()
+	msgs := make([]types.Message, len(t.messages))
+	copy(msgs, t.messages)
+	echoes := make([]*EchoMessage, len(t.echoes))
+	copy(echoes, t.echoes)
+	t.lock.RUnlock()
+	return fn(t.currentHandler, msgs, echoes)
+}
+
+// Restore restores the messages and the echo messages of a snapshot. The messages of the previous rounds are
+// assumed to be handled already, and the others are pushed to the message loop again. It should be called
+// before Start.
+func (t *MsgMain) Restore(msgs []types.Message, echoes []*EchoMessage) error {
+	currentMsgType := t.currentHandler.MessageType()
+	for _, msg := range msgs {
+		if t.isBefore(msg.GetMessageType(), currentMsgType) {
+			t.lock.Lock()
+			t.messages = append(t.messages, msg)
+			t.lock.Unlock()
+			continue
+		}
+		err := t.AddMessage(msg)
+		if err != nil {
+			t.logger.Warn("Failed to restore message", "msgType", msg.GetMessageType(), "fromId", msg.GetId(), "err", err)
+			return err
+		}
+	}
+	for _, echo := range echoes {
+		if t.isBefore(echo.GetMessageType(), currentMsgType) {
+			t.lock.Lock()
+			t.echoes = append(t.echoes, echo)
+			t.lock.Unlock()
+			continue
+		}
+		err := t.AddMessage(echo)
+		if err != nil {
+			t.logger.Warn("Failed to restore echo message", "msgType", echo.GetMessageType(), "fromId", echo.GetId(), "err", err)
+			return err
+		}
+	}
+	return nil
 }
 
 func (t *MsgMain) GetHandler() types.Handler {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
 	return t.currentHandler
 }
 
@@ -94,66 +246,253 @@ func (t *MsgMain) GetState() types.MainState {
 	return t.state
 }
 
+// GetError returns the error which makes the process failed.
+func (t *MsgMain) GetError() error {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.failure == nil {
+		return nil
+	}
+	return t.failure.Err
+}
+
+// GetFailure returns the failure report if the process failed. Otherwise, it returns nil.
+func (t *MsgMain) GetFailure() *Failure {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.failure
+}
+
 func (t *MsgMain) messageLoop(ctx context.Context) (err error) {
+	var (
+		msgType   types.MessageType
+		failedMsg types.Message
+	)
+	t.lock.RLock()
+	roundTimeout := t.roundTimeout
+	sessionTimeout := t.sessionTimeout
+	metrics := t.metrics
+	protocol := t.protocol
+	t.lock.RUnlock()
+	roundListener, _ := t.listener.(types.RoundListener)
+
 	defer func() {
 		if err == nil {
 			_ = t.setState(types.StateDone)
 		} else {
+			t.setFailure(newFailure(msgType, err, failedMsg))
+			if metrics != nil {
+				metrics.ObserveFailure(protocol, msgType)
+			}
 			_ = t.setState(types.StateFailed)
 		}
 		t.Stop()
 	}()
 
+	sessionCtx := ctx
+	if sessionTimeout > 0 {
+		var cancel context.CancelFunc
+		sessionCtx, cancel = context.WithTimeout(ctx, sessionTimeout)
+		defer cancel()
+	}
+
 	handler := t.currentHandler
-	msgType := handler.MessageType()
-	msgCount := uint32(0)
+	msgType = handler.MessageType()
+	var roundMsgs []types.Message
+	roundStart := time.Now()
+	roundCtx, cancelRound := newRoundContext(sessionCtx, roundTimeout)
+	defer func() {
+		cancelRound()
+	}()
+	// Only release the handler lock while waiting for messages
+	t.handlerLock.Lock()
+	defer t.handlerLock.Unlock()
 	for {
 		// 1. Pop messages
 		// 2. Check if the message is handled before
 		// 3. Handle the message
 		// 4. Check if we collect enough messages
 		// 5. If yes, finalize the handler. Otherwise, wait for the next message
-		msg, err := t.msgChs.Pop(ctx, msgType)
+		t.handlerLock.Unlock()
+		msg, err := t.msgChs.Pop(roundCtx, msgType)
+		t.handlerLock.Lock()
+		if err == context.DeadlineExceeded {
+			timeoutErr := &TimeoutError{
+				MessageType:  msgType,
+				MissingPeers: t.getMissingPeers(handler),
+				Session:      sessionCtx.Err() != nil,
+			}
+			t.logger.Warn("Failed to collect messages before the deadline", "msgType", msgType, "missingPeers", timeoutErr.MissingPeers, "session", timeoutErr.Session)
+			return timeoutErr
+		}
 		if err != nil {
 			t.logger.Warn("Failed to pop message", "err", err)
 			return err
 		}
 		id := msg.GetId()
 		logger := t.logger.New("msgType", msgType, "fromId", id)
+		if !isRoundPeer(handler, id) {
+			logger.Debug("Ignore the message of the peer not in this round")
+			continue
+		}
 		if handler.IsHandled(logger, id) {
 			logger.Warn("The message is handled before")
+			failedMsg = msg
 			return ErrDupMsg
 		}
 
 		err = handler.HandleMessage(logger, msg)
 		if err != nil {
 			logger.Warn("Failed to save message", "err", err)
+			failedMsg = msg
 			return err
 		}
 
-		msgCount++
-		if msgCount < handler.GetRequiredMessageCount() {
+		if roundListener != nil {
+			roundListener.OnMessageReceived(msgType, id)
+		}
+		if metrics != nil {
+			if pMsg, ok := msg.(proto.Message); ok {
+				metrics.ObserveMessage(protocol, msgType, proto.Size(pMsg))
+			}
+		}
+
+		roundMsgs = append(roundMsgs, msg)
+		if uint32(len(roundMsgs)) < handler.GetRequiredMessageCount() {
 			continue
 		}
 
+		if t.isEchoRound(msgType) {
+			missingPeers, err := t.echo(roundCtx, msgType, roundMsgs)
+			if err == context.DeadlineExceeded {
+				t.logger.Warn("Failed to collect echo messages before the deadline", "msgType", msgType, "missingPeers", missingPeers)
+				return &TimeoutError{
+					MessageType:  msgType,
+					MissingPeers: missingPeers,
+					Session:      sessionCtx.Err() != nil,
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+
 		nextHandler, err := handler.Finalize(logger)
 		if err != nil {
 			logger.Warn("Failed to go to next handler", "err", err)
 			return err
 		}
+		elapsed := time.Since(roundStart)
+		if roundListener != nil {
+			roundListener.OnRoundFinished(msgType, elapsed)
+		}
+		if metrics != nil {
+			metrics.ObserveRound(protocol, msgType, elapsed)
+		}
 		// if nextHandler is nil, it means we got the final result
 		if nextHandler == nil {
 			return nil
 		}
+		t.lock.Lock()
 		t.currentHandler = nextHandler
-		handler = t.currentHandler
+		t.lock.Unlock()
+		handler = nextHandler
 		newType := handler.MessageType()
-		logger.Info("Change handler", "oldType", msgType, "newType", newType)
+		logger.Info("Change handler", "oldType", msgType, "newType", newType, "elapsed", elapsed)
+		if roundListener != nil {
+			roundListener.OnHandlerChanged(msgType, newType)
+		}
 		msgType = newType
-		msgCount = uint32(0)
+		roundStart = time.Now()
+		roundMsgs = nil
+		cancelRound()
+		roundCtx, cancelRound = newRoundContext(sessionCtx, roundTimeout)
 	}
 }
 
+// GetPeerIDs returns the ids of the peers if the peer manager implements types.PeerIDsGetter.
+func GetPeerIDs(peerManager types.PeerManager) ([]string, error) {
+	g, ok := peerManager.(types.PeerIDsGetter)
+	if !ok {
+		return nil, ErrUnknownPeers
+	}
+	ids := g.PeerIDs()
+	if len(ids) != int(peerManager.NumPeers()) {
+		return nil, ErrUnknownPeers
+	}
+	return ids, nil
+}
+
+// isRoundPeer checks if the message of the peer is expected by the handler.
+func isRoundPeer(handler types.Handler, id string) bool {
+	g, ok := handler.(types.RoundPeersGetter)
+	if !ok {
+		return true
+	}
+	for _, roundID := range g.GetRoundPeerIDs() {
+		if roundID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// getMissingPeers returns the sorted ids of the peers whose messages are not handled by the handler. It returns
+// nil if neither the handler nor the peer manager knows the peers of the round.
+func (t *MsgMain) getMissingPeers(handler types.Handler) []string {
+	var ids []string
+	if g, ok := handler.(types.RoundPeersGetter); ok {
+		ids = g.GetRoundPeerIDs()
+	} else if g, ok := t.peerManager.(types.PeerIDsGetter); ok {
+		ids = g.PeerIDs()
+	}
+	var missing []string
+	for _, id := range ids {
+		if !handler.IsHandled(log.Discard(), id) {
+			missing = append(missing, id)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// isBefore checks if the round of msgType is before the one of otherType. The undefined types are compared by
+// their values.
+func (t *MsgMain) isBefore(msgType types.MessageType, otherType types.MessageType) bool {
+	i, ok := t.rounds[msgType]
+	j, otherOk := t.rounds[otherType]
+	if !ok || !otherOk {
+		return msgType < otherType
+	}
+	return i < j
+}
+
+// isResent checks if the same message was accepted before.
+func (t *MsgMain) isResent(msg types.Message) bool {
+	pMsg, ok := msg.(proto.Message)
+	if !ok {
+		return false
+	}
+	for _, m := range t.messages {
+		if m.GetMessageType() != msg.GetMessageType() || m.GetId() != msg.GetId() {
+			continue
+		}
+		if pm, ok := m.(proto.Message); ok && proto.Equal(pm, pMsg) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *MsgMain) setFailure(failure *Failure) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.failure = failure
+}
+
 func (t *MsgMain) setState(newState types.MainState) error {
 	if t.isInFinalState() {
 		t.logger.Warn("Invalid state transition", "old", t.state, "new", newState)
@@ -170,3 +509,10 @@ func (t *MsgMain) setState(newState types.MainState) error {
 func (t *MsgMain) isInFinalState() bool {
 	return t.state == types.StateFailed || t.state == types.StateDone
 }
+
+func newRoundContext(ctx context.Context, roundTimeout time.Duration) (context.Context, context.CancelFunc) {
+	if roundTimeout > 0 {
+		return context.WithTimeout(ctx, roundTimeout)
+	}
+	return context.WithCancel(ctx)
+}
@@ -15,10 +15,13 @@ package message
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/golang/protobuf/ptypes/any"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
@@ -34,21 +37,27 @@ var _ = Describe("MsgMain", func() {
 		msgMain *MsgMain
 		buffLen = uint32(1)
 
-		mockListener *mocks.StateChangedListener
-		mockHandler  *mocks.Handler
-		mockMsg      *mocks.Message
+		mockPeerManager *mocks.PeerManager
+		mockListener    *mocks.StateChangedListener
+		mockHandler     *mocks.Handler
+		mockMsg         *mocks.Message
 
+		sessionID       = []byte("session")
 		msgType         = types.MessageType(10)
 		nextMessageType = msgType + 1
 	)
 	BeforeEach(func() {
+		mockPeerManager = new(mocks.PeerManager)
 		mockListener = new(mocks.StateChangedListener)
 		mockHandler = new(mocks.Handler)
 		mockMsg = new(mocks.Message)
-		msgMain = NewMsgMain("id", buffLen, mockListener, mockHandler, msgType, nextMessageType)
+		mockPeerManager.On("NumPeers").Return(buffLen).Once()
+		mockPeerManager.On("SelfID").Return("id").Once()
+		msgMain = NewMsgMain(mockPeerManager, sessionID, mockListener, mockHandler, msgType, nextMessageType)
 	})
 
 	AfterEach(func() {
+		mockPeerManager.AssertExpectations(GinkgoT())
 		mockListener.AssertExpectations(GinkgoT())
 		mockHandler.AssertExpectations(GinkgoT())
 		mockMsg.AssertExpectations(GinkgoT())
@@ -56,6 +65,7 @@ var _ = Describe("MsgMain", func() {
 
 	Context("AddMessage", func() {
 		It("should be ok", func() {
+			mockMsg.On("GetSessionId").Return(sessionID).Once()
 			mockHandler.On("MessageType").Return(msgType).Once()
 			mockMsg.On("GetMessageType").Return(msgType).Twice()
 			mockMsg.On("IsValid").Return(true).Once()
@@ -64,11 +74,32 @@ var _ = Describe("MsgMain", func() {
 		})
 
 		It("old message", func() {
+			mockMsg.On("GetSessionId").Return(sessionID).Once()
 			mockHandler.On("MessageType").Return(msgType).Once()
 			mockMsg.On("GetMessageType").Return(types.MessageType(9)).Once()
 			err := msgMain.AddMessage(mockMsg)
 			Expect(err).Should(Equal(ErrOldMessage))
 		})
+
+		It("orders the rounds by the message types", func() {
+			// The round of type 3 is after the one of type 10
+			laterType := types.MessageType(3)
+			mockPeerManager.On("NumPeers").Return(buffLen).Once()
+			mockPeerManager.On("SelfID").Return("id").Once()
+			msgMain = NewMsgMain(mockPeerManager, sessionID, mockListener, mockHandler, msgType, laterType)
+			mockMsg.On("GetSessionId").Return(sessionID).Once()
+			mockHandler.On("MessageType").Return(msgType).Once()
+			mockMsg.On("GetMessageType").Return(laterType).Twice()
+			mockMsg.On("IsValid").Return(true).Once()
+			err := msgMain.AddMessage(mockMsg)
+			Expect(err).Should(BeNil())
+		})
+
+		It("message of other session", func() {
+			mockMsg.On("GetSessionId").Return([]byte("other session")).Once()
+			err := msgMain.AddMessage(mockMsg)
+			Expect(err).Should(Equal(ErrInvalidSession))
+		})
 	})
 
 	Context("messageLoop()", func() {
@@ -79,6 +110,7 @@ var _ = Describe("MsgMain", func() {
 		)
 
 		BeforeEach(func() {
+			mockMsg.On("GetSessionId").Return(sessionID).Once()
 			mockHandler.On("MessageType").Return(msgType).Once()
 			mockMsg.On("GetMessageType").Return(msgType).Twice()
 			mockMsg.On("IsValid").Return(true).Once()
@@ -110,6 +142,26 @@ var _ = Describe("MsgMain", func() {
 			Expect(err).Should(Equal(context.Canceled))
 		})
 
+		It("ignores the message of the peer not in this round", func() {
+			handler := &roundPeersHandler{
+				Handler: mockHandler,
+				ids:     []string{"other-id"},
+			}
+			msgMain.currentHandler = handler
+			msgMain.SetTimeout(10*time.Millisecond, 0)
+			mockHandler.On("MessageType").Return(msgType).Once()
+			mockHandler.On("IsHandled", mock.Anything, "other-id").Return(false).Once()
+
+			// The message is not handled, so the round is timed out
+			mockListener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+			err := msgMain.messageLoop(context.Background())
+			Expect(err).Should(Equal(&TimeoutError{
+				MessageType:  msgType,
+				MissingPeers: []string{"other-id"},
+				Session:      false,
+			}))
+		})
+
 		It("should be ok for a ready handler and there's no next handler", func() {
 			ctx := context.Background()
 			mockHandler.On("MessageType").Return(msgType).Once()
@@ -136,6 +188,31 @@ var _ = Describe("MsgMain", func() {
 				mockListener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
 				err := msgMain.messageLoop(ctx)
 				Expect(err).Should(Equal(unknownErr))
+				Expect(msgMain.GetFailure()).Should(Equal(&Failure{
+					MessageType: msgType,
+					Err:         unknownErr,
+				}))
+			})
+
+			It("failed to finalize with culprits", func() {
+				mockHandler.On("MessageType").Return(msgType).Once()
+				mockHandler.On("IsHandled", mock.Anything, id).Return(false).Once()
+				mockHandler.On("HandleMessage", mock.Anything, mockMsg).Return(nil).Once()
+				mockHandler.On("GetRequiredMessageCount").Return(uint32(1)).Once()
+				blameErr := &BlameError{
+					Culprits: []string{"id-1", "id-2"},
+					Err:      unknownErr,
+				}
+				mockHandler.On("Finalize", mock.Anything).Return(nil, blameErr).Once()
+				mockListener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+				err := msgMain.messageLoop(ctx)
+				Expect(err).Should(Equal(blameErr))
+				Expect(errors.Is(err, unknownErr)).Should(BeTrue())
+				Expect(msgMain.GetFailure()).Should(Equal(&Failure{
+					MessageType: msgType,
+					Culprits:    []string{"id-1", "id-2"},
+					Err:         blameErr,
+				}))
 			})
 
 			It("failed to handle message", func() {
@@ -143,17 +220,371 @@ var _ = Describe("MsgMain", func() {
 				mockHandler.On("IsHandled", mock.Anything, id).Return(false).Once()
 				mockHandler.On("HandleMessage", mock.Anything, mockMsg).Return(unknownErr).Once()
 				mockListener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+				mockMsg.On("GetId").Return(id).Once()
 				err := msgMain.messageLoop(ctx)
 				Expect(err).Should(Equal(unknownErr))
+				Expect(msgMain.GetFailure()).Should(Equal(&Failure{
+					MessageType: msgType,
+					Culprits:    []string{id},
+					Evidence:    []types.Message{mockMsg},
+					Err:         unknownErr,
+				}))
 			})
 
-			It("failed to handle message", func() {
+			It("failed to handle duplicate message", func() {
 				mockHandler.On("MessageType").Return(msgType).Once()
 				mockHandler.On("IsHandled", mock.Anything, id).Return(true).Once()
 				mockListener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+				mockMsg.On("GetId").Return(id).Once()
 				err := msgMain.messageLoop(ctx)
 				Expect(err).Should(Equal(ErrDupMsg))
+				Expect(msgMain.GetFailure().Culprits).Should(Equal([]string{id}))
+			})
+		})
+	})
+
+	Context("Snapshot/Restore", func() {
+		It("should be ok", func() {
+			oldMsg := newTestMessage("id-1", msgType, sessionID, "old")
+			curMsg := newTestMessage("id-1", nextMessageType, sessionID, "current")
+			mockHandler.On("MessageType").Return(msgType).Once()
+			Expect(msgMain.AddMessage(oldMsg)).Should(BeNil())
+
+			var gotMsgs []types.Message
+			Expect(msgMain.Snapshot(func(handler types.Handler, msgs []types.Message, echoes []*EchoMessage) error {
+				Expect(handler).Should(Equal(mockHandler))
+				Expect(echoes).Should(BeEmpty())
+				gotMsgs = msgs
+				return nil
+			})).Should(BeNil())
+			Expect(gotMsgs).Should(Equal([]types.Message{oldMsg}))
+
+			// Restore to the next round
+			newMockHandler := new(mocks.Handler)
+			mockPeerManager.On("NumPeers").Return(buffLen).Once()
+			mockPeerManager.On("SelfID").Return("id").Once()
+			restored := NewMsgMain(mockPeerManager, sessionID, mockListener, newMockHandler, msgType, nextMessageType)
+			newMockHandler.On("MessageType").Return(nextMessageType)
+			Expect(restored.Restore([]types.Message{oldMsg, curMsg}, nil)).Should(BeNil())
+			Expect(restored.Snapshot(func(handler types.Handler, msgs []types.Message, echoes []*EchoMessage) error {
+				Expect(msgs).Should(Equal([]types.Message{oldMsg, curMsg}))
+				return nil
+			})).Should(BeNil())
+
+			// Only the message of the current round is pushed again
+			got, err := restored.msgChs.Pop(context.Background(), nextMessageType)
+			Expect(err).Should(BeNil())
+			Expect(got).Should(Equal(curMsg))
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			got, err = restored.msgChs.Pop(ctx, msgType)
+			Expect(err).Should(Equal(context.Canceled))
+			Expect(got).Should(BeNil())
+		})
+
+		It("restores the echo messages", func() {
+			msgMain.EnableEchoBroadcast(msgType, nextMessageType)
+			oldEcho := &EchoMessage{Id: "id-1", SessionId: sessionID, Type: int32(msgType)}
+			curEcho := &EchoMessage{Id: "id-1", SessionId: sessionID, Type: int32(nextMessageType)}
+			mockHandler.On("MessageType").Return(msgType).Once()
+			Expect(msgMain.AddMessage(oldEcho)).Should(BeNil())
+			Expect(msgMain.Snapshot(func(handler types.Handler, msgs []types.Message, echoes []*EchoMessage) error {
+				Expect(msgs).Should(BeEmpty())
+				Expect(echoes).Should(Equal([]*EchoMessage{oldEcho}))
+				return nil
+			})).Should(BeNil())
+
+			// Restore to the next round
+			newMockHandler := new(mocks.Handler)
+			mockPeerManager.On("NumPeers").Return(buffLen).Once()
+			mockPeerManager.On("SelfID").Return("id").Once()
+			restored := NewMsgMain(mockPeerManager, sessionID, mockListener, newMockHandler, msgType, nextMessageType)
+			restored.EnableEchoBroadcast(msgType, nextMessageType)
+			newMockHandler.On("MessageType").Return(nextMessageType)
+			Expect(restored.Restore(nil, []*EchoMessage{oldEcho, curEcho})).Should(BeNil())
+			Expect(restored.Snapshot(func(handler types.Handler, msgs []types.Message, echoes []*EchoMessage) error {
+				Expect(echoes).Should(Equal([]*EchoMessage{oldEcho, curEcho}))
+				return nil
+			})).Should(BeNil())
+
+			// Only the echo of the current round is pushed again
+			got, err := restored.echoChs.Pop(context.Background(), nextMessageType)
+			Expect(err).Should(BeNil())
+			Expect(got).Should(Equal(curEcho))
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			got, err = restored.echoChs.Pop(ctx, msgType)
+			Expect(err).Should(Equal(context.Canceled))
+			Expect(got).Should(BeNil())
+		})
+
+		It("ignores resent messages", func() {
+			mockHandler.On("MessageType").Return(msgType).Times(3)
+			Expect(msgMain.AddMessage(newTestMessage("id-1", msgType, sessionID, "data"))).Should(BeNil())
+			// Ignore the same message
+			Expect(msgMain.AddMessage(newTestMessage("id-1", msgType, sessionID, "data"))).Should(BeNil())
+			// Different messages from the same peer are still pushed
+			Expect(msgMain.AddMessage(newTestMessage("id-1", msgType, sessionID, "other data"))).Should(Equal(ErrFullChannel))
+		})
+	})
+
+	Context("timeout", func() {
+		var (
+			ctx = context.Background()
+		)
+
+		It("round timeout", func() {
+			msgMain.SetTimeout(10*time.Millisecond, 0)
+			mockHandler.On("MessageType").Return(msgType).Once()
+			msgMain.peerManager = &peerIDsManager{
+				PeerManager: mockPeerManager,
+				ids:         []string{"id-2", "id-1", "id-3"},
+			}
+			mockHandler.On("IsHandled", mock.Anything, "id-1").Return(false).Once()
+			mockHandler.On("IsHandled", mock.Anything, "id-2").Return(true).Once()
+			mockHandler.On("IsHandled", mock.Anything, "id-3").Return(false).Once()
+			mockListener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+			err := msgMain.messageLoop(ctx)
+			Expect(err).Should(Equal(&TimeoutError{
+				MessageType:  msgType,
+				MissingPeers: []string{"id-1", "id-3"},
+				Session:      false,
+			}))
+			Expect(msgMain.GetError()).Should(Equal(err))
+			Expect(msgMain.GetFailure()).Should(Equal(&Failure{
+				MessageType: msgType,
+				Culprits:    []string{"id-1", "id-3"},
+				Err:         err,
+			}))
+		})
+
+		It("session timeout", func() {
+			msgMain.SetTimeout(time.Minute, 10*time.Millisecond)
+			mockHandler.On("MessageType").Return(msgType).Once()
+			msgMain.peerManager = &peerIDsManager{
+				PeerManager: mockPeerManager,
+				ids:         []string{"id-1"},
+			}
+			mockHandler.On("IsHandled", mock.Anything, "id-1").Return(false).Once()
+			mockListener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+			err := msgMain.messageLoop(ctx)
+			Expect(err).Should(Equal(&TimeoutError{
+				MessageType:  msgType,
+				MissingPeers: []string{"id-1"},
+				Session:      true,
+			}))
+		})
+
+		It("round timeout with round peers", func() {
+			handler := &roundPeersHandler{
+				Handler: mockHandler,
+				ids:     []string{"new-peer"},
+			}
+			msgMain.currentHandler = handler
+			msgMain.SetTimeout(10*time.Millisecond, 0)
+			mockHandler.On("MessageType").Return(msgType).Once()
+			mockHandler.On("IsHandled", mock.Anything, "new-peer").Return(false).Once()
+			mockListener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+			err := msgMain.messageLoop(ctx)
+			Expect(err).Should(Equal(&TimeoutError{
+				MessageType:  msgType,
+				MissingPeers: []string{"new-peer"},
+				Session:      false,
+			}))
+		})
+
+		It("round timeout with unknown peers", func() {
+			msgMain.SetTimeout(10*time.Millisecond, 0)
+			mockHandler.On("MessageType").Return(msgType).Once()
+			mockListener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+			err := msgMain.messageLoop(ctx)
+			Expect(err).Should(Equal(&TimeoutError{
+				MessageType: msgType,
+				Session:     false,
+			}))
+			Expect(msgMain.GetFailure().Culprits).Should(BeNil())
+		})
+	})
+
+	Context("GetPeerIDs", func() {
+		It("returns the peer ids", func() {
+			mockPeerManager.On("NumPeers").Return(uint32(2)).Once()
+			got, err := GetPeerIDs(&peerIDsManager{
+				PeerManager: mockPeerManager,
+				ids:         []string{"id-1", "id-2"},
 			})
+			Expect(err).Should(BeNil())
+			Expect(got).Should(Equal([]string{"id-1", "id-2"}))
+		})
+
+		It("unknown peers", func() {
+			got, err := GetPeerIDs(mockPeerManager)
+			Expect(err).Should(Equal(ErrUnknownPeers))
+			Expect(got).Should(BeNil())
+		})
+
+		It("inconsistent peer number", func() {
+			mockPeerManager.On("NumPeers").Return(uint32(2)).Once()
+			got, err := GetPeerIDs(&peerIDsManager{
+				PeerManager: mockPeerManager,
+				ids:         []string{"id-1"},
+			})
+			Expect(err).Should(Equal(ErrUnknownPeers))
+			Expect(got).Should(BeNil())
 		})
 	})
 })
+
+var _ = Describe("Round events", func() {
+	var (
+		sessionID = []byte("session")
+		ids       = []string{"id-1", "id-2"}
+	)
+
+	It("notifies the round events and records the metrics", func() {
+		pm := new(mocks.PeerManager)
+		pm.On("NumPeers").Return(uint32(len(ids)))
+		pm.On("SelfID").Return("id-0")
+		second := newBroadcastHandler(types.MessageType(2), uint32(len(ids)))
+		first := newBroadcastHandler(types.MessageType(1), uint32(len(ids)))
+		first.next = second
+		listener := newRoundRecorder()
+		metrics := &metricsRecorder{}
+		main := NewMsgMain(pm, sessionID, listener, first, types.MessageType(1), types.MessageType(2))
+		main.SetMetrics("test", metrics)
+		main.Start()
+		for _, msgType := range []types.MessageType{1, 2} {
+			for _, id := range ids {
+				Expect(main.AddMessage(newTestMessage(id, msgType, sessionID, "data"))).Should(BeNil())
+			}
+		}
+		<-listener.doneCh
+
+		Expect(listener.events).Should(Equal([]string{
+			"received 1 id-1", "received 1 id-2", "finished 1", "changed 1 2",
+			"received 2 id-1", "received 2 id-2", "finished 2",
+		}))
+		Expect(metrics.messages).Should(Equal(map[types.MessageType]int{1: 2, 2: 2}))
+		Expect(metrics.bytes[1]).Should(BeNumerically(">", 0))
+		Expect(metrics.rounds).Should(Equal([]types.MessageType{1, 2}))
+		Expect(metrics.failures).Should(BeEmpty())
+	})
+
+	It("records the failures", func() {
+		pm := new(mocks.PeerManager)
+		pm.On("NumPeers").Return(uint32(len(ids)))
+		pm.On("SelfID").Return("id-0")
+		listener := newRoundRecorder()
+		metrics := &metricsRecorder{}
+		main := NewMsgMain(&peerIDsManager{PeerManager: pm, ids: ids}, sessionID, listener, newBroadcastHandler(types.MessageType(1), uint32(len(ids))), types.MessageType(1))
+		main.SetMetrics("test", metrics)
+		main.SetTimeout(10*time.Millisecond, 0)
+		main.Start()
+		<-listener.doneCh
+		Expect(metrics.failures).Should(Equal([]types.MessageType{1}))
+	})
+})
+
+type roundRecorder struct {
+	events []string
+	doneCh chan struct{}
+}
+
+func newRoundRecorder() *roundRecorder {
+	return &roundRecorder{
+		doneCh: make(chan struct{}),
+	}
+}
+
+func (r *roundRecorder) OnStateChanged(oldState types.MainState, newState types.MainState) {
+	close(r.doneCh)
+}
+
+func (r *roundRecorder) OnMessageReceived(msgType types.MessageType, id string) {
+	r.events = append(r.events, fmt.Sprintf("received %d %s", msgType, id))
+}
+
+func (r *roundRecorder) OnRoundFinished(msgType types.MessageType, elapsed time.Duration) {
+	r.events = append(r.events, fmt.Sprintf("finished %d", msgType))
+}
+
+func (r *roundRecorder) OnHandlerChanged(oldType types.MessageType, newType types.MessageType) {
+	r.events = append(r.events, fmt.Sprintf("changed %d %d", oldType, newType))
+}
+
+type metricsRecorder struct {
+	messages map[types.MessageType]int
+	bytes    map[types.MessageType]int
+	rounds   []types.MessageType
+	failures []types.MessageType
+}
+
+func (m *metricsRecorder) ObserveMessage(protocol string, msgType types.MessageType, size int) {
+	if m.messages == nil {
+		m.messages = make(map[types.MessageType]int)
+		m.bytes = make(map[types.MessageType]int)
+	}
+	m.messages[msgType]++
+	m.bytes[msgType] += size
+}
+
+func (m *metricsRecorder) ObserveRound(protocol string, msgType types.MessageType, elapsed time.Duration) {
+	m.rounds = append(m.rounds, msgType)
+}
+
+func (m *metricsRecorder) ObserveFailure(protocol string, msgType types.MessageType) {
+	m.failures = append(m.failures, msgType)
+}
+
+// peerIDsManager is a peer manager which knows the ids of the peers
+type peerIDsManager struct {
+	types.PeerManager
+	ids []string
+}
+
+func (p *peerIDsManager) PeerIDs() []string {
+	return p.ids
+}
+
+type roundPeersHandler struct {
+	types.Handler
+	ids []string
+}
+
+func (r *roundPeersHandler) GetRoundPeerIDs() []string {
+	return r.ids
+}
+
+type testMessage struct {
+	*any.Any
+
+	Type    types.MessageType
+	Session []byte
+}
+
+func newTestMessage(id string, msgType types.MessageType, sessionID []byte, data string) *testMessage {
+	return &testMessage{
+		Any: &any.Any{
+			TypeUrl: id,
+			Value:   []byte(data),
+		},
+		Type:    msgType,
+		Session: sessionID,
+	}
+}
+
+func (m *testMessage) GetId() string {
+	return m.TypeUrl
+}
+
+func (m *testMessage) GetMessageType() types.MessageType {
+	return m.Type
+}
+
+func (m *testMessage) GetSessionId() []byte {
+	return m.Session
+}
+
+func (m *testMessage) IsValid() bool {
+	return true
+}
@@ -0,0 +1,272 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+// maxClosedSessions is the number of the recently closed sessions kept by a router. The late messages of
+// them are rejected instead of being kept as pending messages.
+const maxClosedSessions = 1024
+
+// maxPendingSessions and maxPendingMessages are the max numbers of the unknown sessions and the messages kept by a
+// router for all the peers. The ids of the pending messages are not authenticated, so the per-peer limit alone
+// couldn't stop a sender claiming fresh ids.
+const (
+	maxPendingSessions = 1024
+	maxPendingMessages = 8192
+)
+
+var (
+	// ErrUnknownSession is returned if the session of the message is not registered
+	ErrUnknownSession = errors.New("unknown session")
+	// ErrDupSession is returned if the session is registered before
+	ErrDupSession = errors.New("duplicate session")
+	// ErrTooManySessions is returned if a peer joins too many sessions at the same time
+	ErrTooManySessions = errors.New("too many sessions")
+	// ErrClosedSession is returned if the session was unregistered
+	ErrClosedSession = errors.New("closed session")
+	// ErrTooManyPendingMessages is returned if a peer sends too many messages of unknown sessions, or the router
+	// keeps too many of them
+	ErrTooManyPendingMessages = errors.New("too many pending messages")
+)
+
+// Router dispatches the received messages to the processes by their session ids, so that a node could run
+// many processes with the same peers concurrently. The messages of a session which isn't registered yet are
+// kept until the session is registered, because the peers may start the session earlier.
+type Router struct {
+	// maxSessionsPerPeer is the max number of the concurrent sessions of a peer. Zero means no limit.
+	maxSessionsPerPeer int
+	// maxPendingPerPeer is the max number of the pending messages sent by a peer. Zero means no pending messages.
+	maxPendingPerPeer int
+
+	lock         sync.Mutex
+	sessions     map[string]*routedSession
+	peerSessions map[string]int
+	pending      map[string][]types.Message
+	peerPending  map[string]int
+	numPending   int
+	closed       map[string]bool
+	closedKeys   []string
+}
+
+type routedSession struct {
+	process MessageAdder
+	peerIDs []string
+}
+
+// NewRouter news a router.
+func NewRouter(maxSessionsPerPeer int, maxPendingPerPeer int) *Router {
+	return &Router{
+		maxSessionsPerPeer: maxSessionsPerPeer,
+		maxPendingPerPeer:  maxPendingPerPeer,
+		sessions:           make(map[string]*routedSession),
+		peerSessions:       make(map[string]int),
+		pending:            make(map[string][]types.Message),
+		peerPending:        make(map[string]int),
+		closed:             make(map[string]bool),
+	}
+}
+
+// Register registers the process of the session with the ids of the peers in the session. It fails if any peer
+// reaches the max number of sessions. The pending messages of the session are added to the process.
+func (r *Router) Register(sessionID []byte, peerIDs []string, process MessageAdder) error {
+	key := string(sessionID)
+	r.lock.Lock()
+	if _, ok := r.sessions[key]; ok || r.closed[key] {
+		r.lock.Unlock()
+		return ErrDupSession
+	}
+	if r.maxSessionsPerPeer > 0 {
+		for _, id := range peerIDs {
+			if r.peerSessions[id] >= r.maxSessionsPerPeer {
+				r.lock.Unlock()
+				log.Warn("Too many sessions", "id", id, "sessions", r.peerSessions[id])
+				return ErrTooManySessions
+			}
+		}
+	}
+	s := &routedSession{
+		process: process,
+		peerIDs: peerIDs,
+	}
+	r.sessions[key] = s
+	for _, id := range peerIDs {
+		r.peerSessions[id]++
+	}
+	pending := r.takePending(key)
+	r.lock.Unlock()
+
+	for _, msg := range pending {
+		if !s.hasPeer(msg.GetId()) {
+			log.Warn("Drop pending message from unknown peer", "fromId", msg.GetId())
+			continue
+		}
+		err := process.AddMessage(msg)
+		if err != nil {
+			log.Warn("Failed to add pending message", "fromId", msg.GetId(), "err", err)
+		}
+	}
+	return nil
+}
+
+// Unregister removes the session and its pending messages. The late messages of the session are rejected.
+func (r *Router) Unregister(sessionID []byte) {
+	key := string(sessionID)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.takePending(key)
+	s, ok := r.sessions[key]
+	if !ok {
+		return
+	}
+	delete(r.sessions, key)
+	r.closed[key] = true
+	r.closedKeys = append(r.closedKeys, key)
+	if len(r.closedKeys) > maxClosedSessions {
+		delete(r.closed, r.closedKeys[0])
+		r.closedKeys = r.closedKeys[1:]
+	}
+	for _, id := range s.peerIDs {
+		r.peerSessions[id]--
+		if r.peerSessions[id] <= 0 {
+			delete(r.peerSessions, id)
+		}
+	}
+}
+
+// NumSessions returns the number of the registered sessions.
+func (r *Router) NumSessions() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return len(r.sessions)
+}
+
+// NewListener returns a listener which unregisters the session after the process is done or failed, and then
+// notifies the listener. It should be passed to the process of the session. The listener could be nil. The round
+// events are passed to the listener if it implements types.RoundListener.
+func (r *Router) NewListener(sessionID []byte, listener types.StateChangedListener) types.StateChangedListener {
+	return &routerListener{
+		router:    r,
+		sessionID: sessionID,
+		listener:  listener,
+	}
+}
+
+// AddMessage adds the message to the process of its session. The message is rejected if the sender isn't
+// in the session.
+func (r *Router) AddMessage(msg types.Message) error {
+	key := string(msg.GetSessionId())
+	id := msg.GetId()
+	r.lock.Lock()
+	s, ok := r.sessions[key]
+	if !ok {
+		defer r.lock.Unlock()
+		return r.addPending(key, msg)
+	}
+	r.lock.Unlock()
+
+	if !s.hasPeer(id) {
+		log.Warn("Message from unknown peer", "fromId", id)
+		return ErrUnknownSender
+	}
+	return s.process.AddMessage(msg)
+}
+
+func (r *Router) addPending(key string, msg types.Message) error {
+	id := msg.GetId()
+	if r.closed[key] {
+		return ErrClosedSession
+	}
+	if r.maxPendingPerPeer == 0 {
+		return ErrUnknownSession
+	}
+	if r.peerPending[id] >= r.maxPendingPerPeer || r.numPending >= maxPendingMessages {
+		log.Warn("Too many pending messages", "fromId", id, "pending", r.numPending)
+		return ErrTooManyPendingMessages
+	}
+	if _, ok := r.pending[key]; !ok && len(r.pending) >= maxPendingSessions {
+		log.Warn("Too many pending sessions", "fromId", id, "sessions", len(r.pending))
+		return ErrTooManyPendingMessages
+	}
+	r.pending[key] = append(r.pending[key], msg)
+	r.peerPending[id]++
+	r.numPending++
+	return nil
+}
+
+func (r *Router) takePending(key string) []types.Message {
+	msgs := r.pending[key]
+	delete(r.pending, key)
+	r.numPending -= len(msgs)
+	for _, msg := range msgs {
+		id := msg.GetId()
+		r.peerPending[id]--
+		if r.peerPending[id] <= 0 {
+			delete(r.peerPending, id)
+		}
+	}
+	return msgs
+}
+
+func (s *routedSession) hasPeer(id string) bool {
+	for _, peerID := range s.peerIDs {
+		if peerID == id {
+			return true
+		}
+	}
+	return false
+}
+
+type routerListener struct {
+	router    *Router
+	sessionID []byte
+	listener  types.StateChangedListener
+}
+
+func (l *routerListener) OnStateChanged(oldState types.MainState, newState types.MainState) {
+	if newState == types.StateDone || newState == types.StateFailed {
+		l.router.Unregister(l.sessionID)
+	}
+	if l.listener != nil {
+		l.listener.OnStateChanged(oldState, newState)
+	}
+}
+
+func (l *routerListener) OnMessageReceived(msgType types.MessageType, id string) {
+	if rl, ok := l.listener.(types.RoundListener); ok {
+		rl.OnMessageReceived(msgType, id)
+	}
+}
+
+func (l *routerListener) OnRoundFinished(msgType types.MessageType, elapsed time.Duration) {
+	if rl, ok := l.listener.(types.RoundListener); ok {
+		rl.OnRoundFinished(msgType, elapsed)
+	}
+}
+
+func (l *routerListener) OnHandlerChanged(oldType types.MessageType, newType types.MessageType) {
+	if rl, ok := l.listener.(types.RoundListener); ok {
+		rl.OnHandlerChanged(oldType, newType)
+	}
+}
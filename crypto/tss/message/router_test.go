@@ -0,0 +1,145 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"fmt"
+
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Router", func() {
+	var (
+		msgType  = types.MessageType(1)
+		session1 = []byte("session-1")
+		session2 = []byte("session-2")
+		peerIDs  = []string{"id-1", "id-2"}
+
+		router *Router
+	)
+
+	BeforeEach(func() {
+		router = NewRouter(2, 1)
+	})
+
+	It("dispatches messages by sessions", func() {
+		adder1 := &fakeAdder{}
+		adder2 := &fakeAdder{}
+		Expect(router.Register(session1, peerIDs, adder1)).Should(BeNil())
+		Expect(router.Register(session2, peerIDs, adder2)).Should(BeNil())
+		Expect(router.NumSessions()).Should(Equal(2))
+
+		msg1 := newTestMessage("id-1", msgType, session1, "1")
+		msg2 := newTestMessage("id-2", msgType, session2, "2")
+		Expect(router.AddMessage(msg1)).Should(BeNil())
+		Expect(router.AddMessage(msg2)).Should(BeNil())
+		Expect(adder1.msgs).Should(Equal([]types.Message{msg1}))
+		Expect(adder2.msgs).Should(Equal([]types.Message{msg2}))
+
+		// Messages from the peers not in the session are rejected
+		Expect(router.AddMessage(newTestMessage("id-3", msgType, session1, "3"))).Should(Equal(ErrUnknownSender))
+		Expect(router.Register(session1, peerIDs, adder1)).Should(Equal(ErrDupSession))
+	})
+
+	It("keeps pending messages until the session is registered", func() {
+		msg := newTestMessage("id-1", msgType, session1, "1")
+		Expect(router.AddMessage(msg)).Should(BeNil())
+		// id-1 has too many pending messages
+		Expect(router.AddMessage(newTestMessage("id-1", msgType, session2, "2"))).Should(Equal(ErrTooManyPendingMessages))
+
+		adder := &fakeAdder{}
+		Expect(router.Register(session1, peerIDs, adder)).Should(BeNil())
+		Expect(adder.msgs).Should(Equal([]types.Message{msg}))
+		Expect(router.AddMessage(newTestMessage("id-1", msgType, session2, "2"))).Should(BeNil())
+
+		// Pending messages are disabled
+		router = NewRouter(0, 0)
+		Expect(router.AddMessage(msg)).Should(Equal(ErrUnknownSession))
+	})
+
+	It("limits the pending messages of all the peers", func() {
+		// The senders claiming fresh ids could not bypass the limit of the pending sessions
+		for i := 0; i < maxPendingSessions; i++ {
+			id := fmt.Sprintf("fake-%d", i)
+			Expect(router.AddMessage(newTestMessage(id, msgType, []byte(id), "1"))).Should(BeNil())
+		}
+		Expect(router.AddMessage(newTestMessage("id-1", msgType, session1, "1"))).Should(Equal(ErrTooManyPendingMessages))
+		// Registering a session releases its pending messages
+		Expect(router.Register([]byte("fake-0"), []string{"fake-0"}, &fakeAdder{})).Should(BeNil())
+		Expect(router.AddMessage(newTestMessage("id-1", msgType, session1, "1"))).Should(BeNil())
+
+		// The senders claiming fresh ids could not bypass the limit of the pending messages
+		router = NewRouter(0, maxPendingMessages)
+		for i := 0; i < maxPendingMessages; i++ {
+			Expect(router.AddMessage(newTestMessage(fmt.Sprintf("fake-%d", i), msgType, session1, "1"))).Should(BeNil())
+		}
+		Expect(router.AddMessage(newTestMessage("id-1", msgType, session1, "1"))).Should(Equal(ErrTooManyPendingMessages))
+		router.Unregister(session1)
+		Expect(router.AddMessage(newTestMessage("id-1", msgType, session2, "1"))).Should(BeNil())
+	})
+
+	It("limits the sessions of a peer", func() {
+		Expect(router.Register(session1, peerIDs, &fakeAdder{})).Should(BeNil())
+		Expect(router.Register(session2, []string{"id-1"}, &fakeAdder{})).Should(BeNil())
+		Expect(router.Register([]byte("session-3"), []string{"id-2"}, &fakeAdder{})).Should(BeNil())
+		Expect(router.Register([]byte("session-4"), []string{"id-1"}, &fakeAdder{})).Should(Equal(ErrTooManySessions))
+
+		router.Unregister(session2)
+		Expect(router.NumSessions()).Should(Equal(2))
+		Expect(router.Register([]byte("session-4"), []string{"id-1"}, &fakeAdder{})).Should(BeNil())
+	})
+
+	It("cleans up finished sessions", func() {
+		doneCh := make(chan struct{})
+		listener := new(mocks.StateChangedListener)
+		listener.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+			close(doneCh)
+		}).Once()
+		pm := new(mocks.PeerManager)
+		pm.On("NumPeers").Return(uint32(len(peerIDs)))
+		pm.On("SelfID").Return("id-0")
+		main := NewMsgMain(pm, session1, router.NewListener(session1, listener), newBroadcastHandler(msgType, uint32(len(peerIDs))), msgType)
+		Expect(router.Register(session1, peerIDs, main)).Should(BeNil())
+		main.Start()
+		for _, id := range peerIDs {
+			Expect(router.AddMessage(newTestMessage(id, msgType, session1, id))).Should(BeNil())
+		}
+		<-doneCh
+		Expect(router.NumSessions()).Should(Equal(0))
+		Expect(router.AddMessage(newTestMessage("id-1", msgType, session1, "1"))).Should(Equal(ErrClosedSession))
+		Expect(router.Register(session1, peerIDs, main)).Should(Equal(ErrDupSession))
+		listener.AssertExpectations(GinkgoT())
+	})
+
+	It("passes the round events to the listener", func() {
+		listener := newRoundRecorder()
+		pm := new(mocks.PeerManager)
+		pm.On("NumPeers").Return(uint32(len(peerIDs)))
+		pm.On("SelfID").Return("id-0")
+		main := NewMsgMain(pm, session1, router.NewListener(session1, listener), newBroadcastHandler(msgType, uint32(len(peerIDs))), msgType)
+		Expect(router.Register(session1, peerIDs, main)).Should(BeNil())
+		main.Start()
+		for _, id := range peerIDs {
+			Expect(router.AddMessage(newTestMessage(id, msgType, session1, id))).Should(BeNil())
+		}
+		<-listener.doneCh
+		Expect(listener.events).Should(Equal([]string{"received 1 id-1", "received 1 id-2", "finished 1"}))
+		Expect(router.NumSessions()).Should(Equal(0))
+	})
+})
@@ -40,6 +40,22 @@ func (_m *Message) GetMessageType() types.MessageType {
 	return r0
 }
 
+// GetSessionId provides a mock function with given fields:
+func (_m *Message) GetSessionId() []byte {
+	ret := _m.Called()
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func() []byte); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	return r0
+}
+
 // IsValid provides a mock function with given fields:
 func (_m *Message) IsValid() bool {
 	ret := _m.Called()
@@ -15,6 +15,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/getamis/sirius/log"
 	"github.com/golang/protobuf/proto"
 )
@@ -27,6 +29,14 @@ type PeerManager interface {
 	MustSend(id string, msg proto.Message)
 }
 
+// PeerIDsGetter is an optional interface of PeerManager. The peer managers which know the ids of the peers
+// should implement it. The timeouts of the rounds could name the missing peers only if the peer manager
+// implements it, and the processes which need the peers beforehand require it.
+type PeerIDsGetter interface {
+	// PeerIDs returns the ids of the peers except the self one
+	PeerIDs() []string
+}
+
 // Handler defines the message handler
 //go:generate mockery -name=Handler
 type Handler interface {
@@ -43,6 +53,14 @@ type Handler interface {
 	Finalize(logger log.Logger) (Handler, error)
 }
 
+// RoundPeersGetter is an optional interface of Handler. The handlers which only expect messages from
+// a part of the peers (or from a peer unknown to the peer manager) in their round should implement it.
+// The messages of the other peers are ignored in the round.
+type RoundPeersGetter interface {
+	// GetRoundPeerIDs returns the ids of the peers whose messages are expected in this round
+	GetRoundPeerIDs() []string
+}
+
 // MessageType defines the message state
 type MessageType int32
 
@@ -53,6 +71,8 @@ type Message interface {
 	GetId() string
 	// GetMessageType returns the message type
 	GetMessageType() MessageType
+	// GetSessionId returns the id of the session which the message belongs to
+	GetSessionId() []byte
 	// IsValid checks if message is valid or not
 	IsValid() bool
 }
@@ -85,3 +105,24 @@ func (m MainState) String() string {
 type StateChangedListener interface {
 	OnStateChanged(oldState MainState, newState MainState)
 }
+
+// RoundListener is an optional interface of StateChangedListener. The listeners which implement it are notified
+// about the progress of the rounds.
+type RoundListener interface {
+	// OnMessageReceived is called after the message of the peer is handled
+	OnMessageReceived(msgType MessageType, id string)
+	// OnRoundFinished is called after the round is finalized. The elapsed time starts when the round begins.
+	OnRoundFinished(msgType MessageType, elapsed time.Duration)
+	// OnHandlerChanged is called after the handler of the next round is set
+	OnHandlerChanged(oldType MessageType, newType MessageType)
+}
+
+// Metrics records the metrics of the processes. The protocol is the label set by the process (e.g. "dkg" or "signer").
+type Metrics interface {
+	// ObserveMessage records the size of a handled message
+	ObserveMessage(protocol string, msgType MessageType, size int)
+	// ObserveRound records the time spent in a finalized round
+	ObserveRound(protocol string, msgType MessageType, elapsed time.Duration)
+	// ObserveFailure records a failed process and the round in which it failed
+	ObserveFailure(protocol string, msgType MessageType)
+}
@@ -0,0 +1,231 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides a Prometheus-style implementation of types.Metrics. The metrics are exported in the
+// Prometheus text format, so they could be scraped without any Prometheus client library.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/getamis/alice/crypto/tss/message/types"
+)
+
+const (
+	messagesName      = "tss_messages_total"
+	messageBytesName  = "tss_message_bytes_total"
+	roundDurationName = "tss_round_duration_seconds"
+	failuresName      = "tss_failures_total"
+)
+
+// DefaultRoundBuckets are the default upper bounds (in seconds) of the round duration histogram
+var DefaultRoundBuckets = []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60}
+
+// Prometheus implements types.Metrics. It counts the messages and bytes per message type, the round latencies
+// and the failures per protocol.
+type Prometheus struct {
+	buckets []float64
+
+	lock         sync.Mutex
+	messages     map[label]uint64
+	messageBytes map[label]uint64
+	failures     map[label]uint64
+	rounds       map[label]*histogram
+}
+
+type label struct {
+	protocol string
+	msgType  types.MessageType
+}
+
+type histogram struct {
+	// counts are the non-cumulative counts of the buckets
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewPrometheus news the metrics with the buckets of the round duration histogram. DefaultRoundBuckets are used
+// if buckets is empty.
+func NewPrometheus(buckets []float64) *Prometheus {
+	if len(buckets) == 0 {
+		buckets = DefaultRoundBuckets
+	}
+	bs := make([]float64, len(buckets))
+	copy(bs, buckets)
+	sort.Float64s(bs)
+	return &Prometheus{
+		buckets:      bs,
+		messages:     make(map[label]uint64),
+		messageBytes: make(map[label]uint64),
+		failures:     make(map[label]uint64),
+		rounds:       make(map[label]*histogram),
+	}
+}
+
+func (p *Prometheus) ObserveMessage(protocol string, msgType types.MessageType, size int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	l := label{protocol: protocol, msgType: msgType}
+	p.messages[l]++
+	p.messageBytes[l] += uint64(size)
+}
+
+func (p *Prometheus) ObserveRound(protocol string, msgType types.MessageType, elapsed time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	l := label{protocol: protocol, msgType: msgType}
+	h, ok := p.rounds[l]
+	if !ok {
+		h = &histogram{
+			counts: make([]uint64, len(p.buckets)),
+		}
+		p.rounds[l] = h
+	}
+	seconds := elapsed.Seconds()
+	i := sort.SearchFloat64s(p.buckets, seconds)
+	if i < len(p.buckets) {
+		h.counts[i]++
+	}
+	h.sum += seconds
+	h.count++
+}
+
+func (p *Prometheus) ObserveFailure(protocol string, msgType types.MessageType) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.failures[label{protocol: protocol, msgType: msgType}]++
+}
+
+// GetMessages returns the number of the handled messages of the type.
+func (p *Prometheus) GetMessages(protocol string, msgType types.MessageType) uint64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.messages[label{protocol: protocol, msgType: msgType}]
+}
+
+// GetMessageBytes returns the total size of the handled messages of the type.
+func (p *Prometheus) GetMessageBytes(protocol string, msgType types.MessageType) uint64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.messageBytes[label{protocol: protocol, msgType: msgType}]
+}
+
+// GetRounds returns the number and the total seconds of the finalized rounds of the type.
+func (p *Prometheus) GetRounds(protocol string, msgType types.MessageType) (uint64, float64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	h, ok := p.rounds[label{protocol: protocol, msgType: msgType}]
+	if !ok {
+		return 0, 0
+	}
+	return h.count, h.sum
+}
+
+// GetFailures returns the number of the processes of the protocol which failed in the round of the type.
+func (p *Prometheus) GetFailures(protocol string, msgType types.MessageType) uint64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.failures[label{protocol: protocol, msgType: msgType}]
+}
+
+// WriteTo writes the metrics in the Prometheus text format.
+func (p *Prometheus) WriteTo(w io.Writer) (int64, error) {
+	p.lock.Lock()
+	buf := &bytes.Buffer{}
+	writeCounters(buf, messagesName, "Number of the handled messages.", p.messages)
+	writeCounters(buf, messageBytesName, "Total bytes of the handled messages.", p.messageBytes)
+	p.writeRounds(buf)
+	writeCounters(buf, failuresName, "Number of the failed processes by the round in which they failed.", p.failures)
+	p.lock.Unlock()
+	return buf.WriteTo(w)
+}
+
+// ServeHTTP serves the metrics, so it could be registered as the scraping endpoint (e.g. /metrics).
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	_, _ = p.WriteTo(w)
+}
+
+func (p *Prometheus) writeRounds(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "# HELP %s Time spent in the finalized rounds.\n", roundDurationName)
+	fmt.Fprintf(buf, "# TYPE %s histogram\n", roundDurationName)
+	for _, l := range sortedLabels(p.rounds) {
+		h := p.rounds[l]
+		cumulative := uint64(0)
+		for i, b := range p.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(buf, "%s_bucket{%s,le=\"%s\"} %d\n", roundDurationName, l, formatFloat(b), cumulative)
+		}
+		fmt.Fprintf(buf, "%s_bucket{%s,le=\"+Inf\"} %d\n", roundDurationName, l, h.count)
+		fmt.Fprintf(buf, "%s_sum{%s} %s\n", roundDurationName, l, formatFloat(h.sum))
+		fmt.Fprintf(buf, "%s_count{%s} %d\n", roundDurationName, l, h.count)
+	}
+}
+
+func writeCounters(buf *bytes.Buffer, name string, help string, counters map[label]uint64) {
+	fmt.Fprintf(buf, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(buf, "# TYPE %s counter\n", name)
+	for _, l := range sortedLabels(counters) {
+		fmt.Fprintf(buf, "%s{%s} %d\n", name, l, counters[l])
+	}
+}
+
+// sortedLabels returns the sorted keys of a map[label]uint64 or a map[label]*histogram.
+func sortedLabels(m interface{}) []label {
+	var ls []label
+	switch v := m.(type) {
+	case map[label]uint64:
+		for l := range v {
+			ls = append(ls, l)
+		}
+	case map[label]*histogram:
+		for l := range v {
+			ls = append(ls, l)
+		}
+	}
+	sort.Slice(ls, func(i, j int) bool {
+		if ls[i].protocol != ls[j].protocol {
+			return ls[i].protocol < ls[j].protocol
+		}
+		return ls[i].msgType < ls[j].msgType
+	})
+	return ls
+}
+
+func (l label) String() string {
+	return fmt.Sprintf("protocol=%s,type=\"%d\"", strconv.Quote(l.protocol), l.msgType)
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
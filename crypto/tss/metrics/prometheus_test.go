@@ -0,0 +1,90 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/getamis/alice/crypto/tss/message/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestMetrics(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Metrics Suite")
+}
+
+var _ = Describe("Prometheus", func() {
+	var (
+		p *Prometheus
+	)
+
+	BeforeEach(func() {
+		p = NewPrometheus([]float64{1, 0.1})
+		p.ObserveMessage("dkg", types.MessageType(1), 10)
+		p.ObserveMessage("dkg", types.MessageType(1), 20)
+		p.ObserveMessage("signer", types.MessageType(0), 5)
+		p.ObserveRound("dkg", types.MessageType(1), 50*time.Millisecond)
+		p.ObserveRound("dkg", types.MessageType(1), 500*time.Millisecond)
+		p.ObserveRound("dkg", types.MessageType(1), 2*time.Second)
+		p.ObserveFailure("signer", types.MessageType(3))
+	})
+
+	It("counts the metrics", func() {
+		Expect(p.GetMessages("dkg", types.MessageType(1))).Should(Equal(uint64(2)))
+		Expect(p.GetMessageBytes("dkg", types.MessageType(1))).Should(Equal(uint64(30)))
+		Expect(p.GetMessages("dkg", types.MessageType(2))).Should(BeZero())
+		count, sum := p.GetRounds("dkg", types.MessageType(1))
+		Expect(count).Should(Equal(uint64(3)))
+		Expect(sum).Should(BeNumerically("~", 2.55))
+		Expect(p.GetFailures("signer", types.MessageType(3))).Should(Equal(uint64(1)))
+	})
+
+	It("writes the text format", func() {
+		buf := &bytes.Buffer{}
+		_, err := p.WriteTo(buf)
+		Expect(err).Should(BeNil())
+		Expect(buf.String()).Should(Equal(`# HELP tss_messages_total Number of the handled messages.
+# TYPE tss_messages_total counter
+tss_messages_total{protocol="dkg",type="1"} 2
+tss_messages_total{protocol="signer",type="0"} 1
+# HELP tss_message_bytes_total Total bytes of the handled messages.
+# TYPE tss_message_bytes_total counter
+tss_message_bytes_total{protocol="dkg",type="1"} 30
+tss_message_bytes_total{protocol="signer",type="0"} 5
+# HELP tss_round_duration_seconds Time spent in the finalized rounds.
+# TYPE tss_round_duration_seconds histogram
+tss_round_duration_seconds_bucket{protocol="dkg",type="1",le="0.1"} 1
+tss_round_duration_seconds_bucket{protocol="dkg",type="1",le="1"} 2
+tss_round_duration_seconds_bucket{protocol="dkg",type="1",le="+Inf"} 3
+tss_round_duration_seconds_sum{protocol="dkg",type="1"} 2.55
+tss_round_duration_seconds_count{protocol="dkg",type="1"} 3
+# HELP tss_failures_total Number of the failed processes by the round in which they failed.
+# TYPE tss_failures_total counter
+tss_failures_total{protocol="signer",type="3"} 1
+`))
+	})
+
+	It("serves the metrics", func() {
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+		Expect(w.Code).Should(Equal(200))
+		Expect(w.Body.String()).Should(ContainSubstring(`tss_failures_total{protocol="signer",type="3"} 1`))
+	})
+})
@@ -42,13 +42,14 @@ type commitHandler struct {
 	poly                *polynomial.Polynomial
 	threshold           uint32
 	feldmanCommitmenter *commitment.FeldmanCommitmenter
+	sessionID           []byte
 
 	peerManager types.PeerManager
 	peerNum     uint32
 	peers       map[string]*peer
 }
 
-func newCommitHandler(publicKey *ecpointgrouplaw.ECPoint, peerManager types.PeerManager, threshold uint32, oldShare *big.Int, bks map[string]*birkhoffinterpolation.BkParameter) (*commitHandler, error) {
+func newCommitHandler(publicKey *ecpointgrouplaw.ECPoint, peerManager types.PeerManager, sessionID []byte, threshold uint32, oldShare *big.Int, bks map[string]*birkhoffinterpolation.BkParameter) (*commitHandler, error) {
 	numPeers := peerManager.NumPeers()
 	lenBks := len(bks)
 	if lenBks != int(numPeers+1) {
@@ -59,21 +60,25 @@ func newCommitHandler(publicKey *ecpointgrouplaw.ECPoint, peerManager types.Peer
 		return nil, err
 	}
 
-	curve := publicKey.GetCurve()
-	fieldOrder := curve.Params().N
+	fieldOrder := publicKey.GetCurve().Params().N
 	poly, err := polynomial.RandomPolynomial(fieldOrder, threshold-1)
 	if err != nil {
 		return nil, err
 	}
 	poly.SetConstant(big.NewInt(0))
+	return newCommitHandlerWithPolynomial(publicKey, peerManager, sessionID, threshold, oldShare, bks, poly)
+}
 
+func newCommitHandlerWithPolynomial(publicKey *ecpointgrouplaw.ECPoint, peerManager types.PeerManager, sessionID []byte, threshold uint32, oldShare *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, poly *polynomial.Polynomial) (*commitHandler, error) {
+	curve := publicKey.GetCurve()
+	fieldOrder := curve.Params().N
 	// Build Feldman commitmenter
 	feldmanCommitmenter, err := commitment.NewFeldmanCommitmenter(curve, poly)
 	if err != nil {
 		return nil, err
 	}
 
-	selfBK, peers, err := buildPeers(fieldOrder, peerManager.SelfID(), threshold, bks, feldmanCommitmenter)
+	selfBK, peers, err := buildPeers(fieldOrder, peerManager.SelfID(), sessionID, threshold, bks, feldmanCommitmenter)
 	if err != nil {
 		log.Warn("Failed to build peers", "err", err)
 		return nil, err
@@ -86,9 +91,10 @@ func newCommitHandler(publicKey *ecpointgrouplaw.ECPoint, peerManager types.Peer
 		poly:                poly,
 		threshold:           threshold,
 		feldmanCommitmenter: feldmanCommitmenter,
+		sessionID:           sessionID,
 
 		peerManager: peerManager,
-		peerNum:     numPeers,
+		peerNum:     peerManager.NumPeers(),
 		peers:       peers,
 	}, nil
 }
@@ -131,8 +137,9 @@ func (p *commitHandler) Finalize(logger log.Logger) (types.Handler, error) {
 
 func (p *commitHandler) GetCommitMessage() *Message {
 	return &Message{
-		Type: Type_Commit,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_Commit,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_Commit{
 			Commit: &BodyCommit{
 				PointCommitment: p.feldmanCommitmenter.GetCommitmentMessage(),
@@ -149,7 +156,7 @@ func getMessageByType(peer *peer, t Type) *Message {
 	return getMessage(peer.GetMessage(types.MessageType(t)))
 }
 
-func buildPeers(fieldOrder *big.Int, selfID string, threshold uint32, bks map[string]*birkhoffinterpolation.BkParameter, commitmenter *commitment.FeldmanCommitmenter) (*birkhoffinterpolation.BkParameter, map[string]*peer, error) {
+func buildPeers(fieldOrder *big.Int, selfID string, sessionID []byte, threshold uint32, bks map[string]*birkhoffinterpolation.BkParameter, commitmenter *commitment.FeldmanCommitmenter) (*birkhoffinterpolation.BkParameter, map[string]*peer, error) {
 	lenBks := len(bks)
 	allBKs := make(birkhoffinterpolation.BkParameters, lenBks)
 	peers := make(map[string]*peer, lenBks-1)
@@ -169,8 +176,9 @@ func buildPeers(fieldOrder *big.Int, selfID string, threshold uint32, bks map[st
 		peer.peer = &peerData{
 			bk: bk,
 			verifyMessage: &Message{
-				Type: Type_Verify,
-				Id:   selfID,
+				Type:      Type_Verify,
+				Id:        selfID,
+				SessionId: sessionID,
 				Body: &Message_Verify{
 					Verify: &BodyVerify{
 						Verify: commitmenter.GetVerifyMessage(bk),
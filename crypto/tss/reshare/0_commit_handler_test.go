@@ -70,14 +70,14 @@ var _ = Describe("commit handler, negative cases", func() {
 
 		It("inconsistent peer number and bks", func() {
 			mockPeerManager.On("NumPeers").Return(uint32(3)).Once()
-			got, err := newCommitHandler(expPublic, mockPeerManager, threshold, nil, bks)
+			got, err := newCommitHandler(expPublic, mockPeerManager, sessionID, threshold, nil, bks)
 			Expect(got).Should(BeNil())
 			Expect(err).Should(Equal(tss.ErrInconsistentPeerNumAndBks))
 		})
 
 		It("invalid threshold", func() {
 			mockPeerManager.On("NumPeers").Return(uint32(4)).Once()
-			got, err := newCommitHandler(expPublic, mockPeerManager, 6, nil, bks)
+			got, err := newCommitHandler(expPublic, mockPeerManager, sessionID, 6, nil, bks)
 			Expect(got).Should(BeNil())
 			Expect(err).Should(Equal(utils.ErrLargeThreshold))
 		})
@@ -85,7 +85,7 @@ var _ = Describe("commit handler, negative cases", func() {
 		It("self id not found", func() {
 			mockPeerManager.On("NumPeers").Return(uint32(4)).Once()
 			mockPeerManager.On("SelfID").Return("not found").Once()
-			got, err := newCommitHandler(expPublic, mockPeerManager, 5, nil, bks)
+			got, err := newCommitHandler(expPublic, mockPeerManager, sessionID, 5, nil, bks)
 			Expect(got).Should(BeNil())
 			Expect(err).Should(Equal(tss.ErrSelfBKNotFound))
 		})
@@ -100,7 +100,7 @@ var _ = Describe("commit handler, negative cases", func() {
 			}
 			mockPeerManager.On("NumPeers").Return(uint32(4)).Once()
 			mockPeerManager.On("SelfID").Return("1").Once()
-			got, err := newCommitHandler(expPublic, mockPeerManager, 5, nil, dupBks)
+			got, err := newCommitHandler(expPublic, mockPeerManager, sessionID, 5, nil, dupBks)
 			Expect(got).Should(BeNil())
 			Expect(err).Should(Equal(matrix.ErrNotInvertableMatrix))
 		})
@@ -89,7 +89,7 @@ func (p *verifyHandler) Finalize(logger log.Logger) (types.Handler, error) {
 	p.newShare = new(big.Int).Mod(p.newShare, p.publicKey.GetCurve().Params().N)
 
 	// Build and send out the result message
-	p.siGProofMsg, err = zkproof.NewBaseSchorrMessage(p.publicKey.GetCurve(), p.newShare)
+	p.siGProofMsg, err = zkproof.NewBaseSchorrMessageWithSession(p.sessionID, p.publicKey.GetCurve(), p.newShare)
 	if err != nil {
 		log.Warn("Failed to new si schorr proof", "err", err)
 		return nil, err
@@ -102,8 +102,9 @@ func (p *verifyHandler) Finalize(logger log.Logger) (types.Handler, error) {
 
 func (p *verifyHandler) getResultMessage() *Message {
 	return &Message{
-		Type: Type_Result,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_Result,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_Result{
 			Result: &BodyResult{
 				SiGProofMsg: p.siGProofMsg,
@@ -69,7 +69,7 @@ func (p *resultHandler) HandleMessage(logger log.Logger, message types.Message)
 		return err
 	}
 
-	err = siGProofMsg.Verify(ecpointgrouplaw.NewBase(p.publicKey.GetCurve()))
+	err = siGProofMsg.VerifyWithSession(p.sessionID, ecpointgrouplaw.NewBase(p.publicKey.GetCurve()))
 	if err != nil {
 		logger.Warn("Failed to verify Schorr proof", "err", err)
 		return err
@@ -98,3 +98,17 @@ func (p *resultHandler) Finalize(logger log.Logger) (types.Handler, error) {
 	}
 	return nil, tss.ValidatePublicKey(logger, bks, sgs, p.threshold, p.publicKey)
 }
+
+// getPublicShares returns the public shares of self and the peers
+func (p *resultHandler) getPublicShares() (map[string]*ecpointgrouplaw.ECPoint, error) {
+	siG, err := p.siGProofMsg.V.ToPoint()
+	if err != nil {
+		return nil, err
+	}
+	publicShares := make(map[string]*ecpointgrouplaw.ECPoint, p.peerNum+1)
+	publicShares[p.peerManager.SelfID()] = siG
+	for id, peer := range p.peers {
+		publicShares[id] = peer.result.result
+	}
+	return publicShares, nil
+}
@@ -0,0 +1,171 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reshare
+
+import (
+	"math/big"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/polynomial"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+const checkpointLabel = "reshare"
+
+// Checkpoint returns the encrypted state of the process, including the private polynomial, the old share
+// and the collected messages. It could be restored by RestoreReshare after the process crashes.
+func (d *Reshare) Checkpoint(key []byte) ([]byte, error) {
+	var state *State
+	err := d.Snapshot(func(handler types.Handler, msgs []types.Message, echoes []*message.EchoMessage) error {
+		var err error
+		state, err = d.ch.getState(handler, msgs, echoes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, msgType := range d.EchoBroadcastTypes() {
+		state.EchoTypes = append(state.EchoTypes, int32(msgType))
+	}
+	return tss.EncryptCheckpoint(key, checkpointLabel, state)
+}
+
+// RestoreReshare restores the process from the checkpoint. The process continues from the round in which
+// the checkpoint was made. The peer manager must manage the same peers as before.
+func RestoreReshare(peerManager types.PeerManager, key []byte, checkpoint []byte, listener types.StateChangedListener) (*Reshare, error) {
+	state := &State{}
+	err := tss.DecryptCheckpoint(key, checkpointLabel, checkpoint, state)
+	if err != nil {
+		return nil, err
+	}
+	ch, handler, err := restoreHandler(peerManager, state)
+	if err != nil {
+		return nil, err
+	}
+	d := newReshareWithCurrentHandler(peerManager, state.SessionId, listener, ch, handler)
+	msgs := make([]types.Message, len(state.Messages))
+	for i, msg := range state.Messages {
+		msgs[i] = msg
+	}
+	// The echo broadcast is enabled by the caller, so it's enabled again before the echoes are restored
+	if len(state.EchoTypes) > 0 {
+		echoTypes := make([]types.MessageType, len(state.EchoTypes))
+		for i, msgType := range state.EchoTypes {
+			echoTypes[i] = types.MessageType(msgType)
+		}
+		d.EnableEchoBroadcast(echoTypes...)
+	}
+	err = d.Restore(msgs, state.Echoes)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (p *commitHandler) getState(handler types.Handler, msgs []types.Message, echoes []*message.EchoMessage) (*State, error) {
+	publicKey, err := p.publicKey.ToEcPointMessage()
+	if err != nil {
+		return nil, err
+	}
+	bks := make(map[string]*birkhoffinterpolation.BkParameterMessage, len(p.peers)+1)
+	bks[p.peerManager.SelfID()] = p.bk.ToMessage()
+	for id, peer := range p.peers {
+		bks[id] = peer.peer.bk.ToMessage()
+	}
+	coefficients := make([][]byte, p.poly.Len())
+	for i := range coefficients {
+		coefficients[i] = p.poly.Get(i).Bytes()
+	}
+	state := &State{
+		Round:        Type(handler.MessageType()),
+		SessionId:    p.sessionID,
+		Threshold:    p.threshold,
+		PublicKey:    publicKey,
+		OldShare:     p.oldShare.Bytes(),
+		Bks:          bks,
+		Coefficients: coefficients,
+		Messages:     make([]*Message, len(msgs)),
+		Echoes:       echoes,
+	}
+	for i, msg := range msgs {
+		state.Messages[i] = getMessage(msg)
+	}
+	if rh, ok := handler.(*resultHandler); ok {
+		state.NewShare = rh.newShare.Bytes()
+		state.SiGProofMsg = rh.siGProofMsg
+	}
+	return state, nil
+}
+
+// restoreHandler rebuilds the handler of the round in the state. The messages of the previous rounds are
+// handled again. No message is sent while handling messages in this protocol.
+func restoreHandler(peerManager types.PeerManager, state *State) (*commitHandler, types.Handler, error) {
+	if err := tss.EnsureSessionID(state.SessionId); err != nil {
+		return nil, nil, err
+	}
+	publicKey, err := state.GetPublicKey().ToPoint()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(state.Bks) != int(peerManager.NumPeers()+1) {
+		return nil, nil, tss.ErrInconsistentPeerNumAndBks
+	}
+	bks := make(map[string]*birkhoffinterpolation.BkParameter, len(state.Bks))
+	for id, bk := range state.Bks {
+		bks[id] = bk.ToBk()
+	}
+	coefficients := make([]*big.Int, len(state.Coefficients))
+	for i, c := range state.Coefficients {
+		coefficients[i] = new(big.Int).SetBytes(c)
+	}
+	poly, err := polynomial.NewPolynomial(publicKey.GetCurve().Params().N, coefficients)
+	if err != nil {
+		return nil, nil, err
+	}
+	ch, err := newCommitHandlerWithPolynomial(publicKey, peerManager, state.SessionId, state.Threshold, new(big.Int).SetBytes(state.OldShare), bks, poly)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var handler types.Handler = ch
+	for handler.MessageType() < types.MessageType(state.Round) {
+		for _, msg := range state.Messages {
+			if msg.GetMessageType() != handler.MessageType() {
+				continue
+			}
+			err = handler.HandleMessage(log.Discard(), msg)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		switch h := handler.(type) {
+		case *commitHandler:
+			handler = newVerifyHandler(h)
+		case *verifyHandler:
+			h.newShare = new(big.Int).SetBytes(state.NewShare)
+			h.siGProofMsg = state.SiGProofMsg
+			handler = newResultHandler(h)
+		default:
+			return nil, nil, tss.ErrInvalidCheckpoint
+		}
+	}
+	if handler.MessageType() != types.MessageType(state.Round) {
+		return nil, nil, tss.ErrInvalidCheckpoint
+	}
+	return ch, handler, nil
+}
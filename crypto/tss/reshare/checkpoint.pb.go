@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/getamis/alice/crypto/tss/reshare/checkpoint.proto
+
+package reshare
+
+import (
+	fmt "fmt"
+	birkhoffinterpolation "github.com/getamis/alice/crypto/birkhoffinterpolation"
+	ecpointgrouplaw "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	message "github.com/getamis/alice/crypto/tss/message"
+	zkproof "github.com/getamis/alice/crypto/zkproof"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// State is the checkpoint of a reshare process
+type State struct {
+	Round        Type                                                 `protobuf:"varint,1,opt,name=round,proto3,enum=reshare.Type" json:"round,omitempty"`
+	SessionId    []byte                                               `protobuf:"bytes,2,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	Threshold    uint32                                               `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	PublicKey    *ecpointgrouplaw.EcPointMessage                      `protobuf:"bytes,4,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	OldShare     []byte                                               `protobuf:"bytes,5,opt,name=oldShare,proto3" json:"oldShare,omitempty"`
+	Bks          map[string]*birkhoffinterpolation.BkParameterMessage `protobuf:"bytes,6,rep,name=bks,proto3" json:"bks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Coefficients [][]byte                                             `protobuf:"bytes,7,rep,name=coefficients,proto3" json:"coefficients,omitempty"`
+	// The fields below are set after the verify round
+	NewShare    []byte                       `protobuf:"bytes,8,opt,name=newShare,proto3" json:"newShare,omitempty"`
+	SiGProofMsg *zkproof.SchnorrProofMessage `protobuf:"bytes,9,opt,name=siGProofMsg,proto3" json:"siGProofMsg,omitempty"`
+	// messages are all the accepted messages
+	Messages []*Message `protobuf:"bytes,10,rep,name=messages,proto3" json:"messages,omitempty"`
+	// echoes are all the accepted echo messages of the rounds sent by echo broadcast
+	Echoes []*message.EchoMessage `protobuf:"bytes,11,rep,name=echoes,proto3" json:"echoes,omitempty"`
+	// echoTypes are the message types of the rounds sent by echo broadcast, which are enabled again after restoring
+	EchoTypes            []int32  `protobuf:"varint,12,rep,packed,name=echoTypes,proto3" json:"echoTypes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *State) Reset()         { *m = State{} }
+func (m *State) String() string { return proto.CompactTextString(m) }
+func (*State) ProtoMessage()    {}
+func (*State) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a516ed71a1db69b2, []int{0}
+}
+
+func (m *State) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_State.Unmarshal(m, b)
+}
+func (m *State) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_State.Marshal(b, m, deterministic)
+}
+func (m *State) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_State.Merge(m, src)
+}
+func (m *State) XXX_Size() int {
+	return xxx_messageInfo_State.Size(m)
+}
+func (m *State) XXX_DiscardUnknown() {
+	xxx_messageInfo_State.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_State proto.InternalMessageInfo
+
+func (m *State) GetRound() Type {
+	if m != nil {
+		return m.Round
+	}
+	return Type_Commit
+}
+
+func (m *State) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *State) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *State) GetPublicKey() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *State) GetOldShare() []byte {
+	if m != nil {
+		return m.OldShare
+	}
+	return nil
+}
+
+func (m *State) GetBks() map[string]*birkhoffinterpolation.BkParameterMessage {
+	if m != nil {
+		return m.Bks
+	}
+	return nil
+}
+
+func (m *State) GetCoefficients() [][]byte {
+	if m != nil {
+		return m.Coefficients
+	}
+	return nil
+}
+
+func (m *State) GetNewShare() []byte {
+	if m != nil {
+		return m.NewShare
+	}
+	return nil
+}
+
+func (m *State) GetSiGProofMsg() *zkproof.SchnorrProofMessage {
+	if m != nil {
+		return m.SiGProofMsg
+	}
+	return nil
+}
+
+func (m *State) GetMessages() []*Message {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func (m *State) GetEchoes() []*message.EchoMessage {
+	if m != nil {
+		return m.Echoes
+	}
+	return nil
+}
+
+func (m *State) GetEchoTypes() []int32 {
+	if m != nil {
+		return m.EchoTypes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*State)(nil), "reshare.State")
+	proto.RegisterMapType((map[string]*birkhoffinterpolation.BkParameterMessage)(nil), "reshare.State.BksEntry")
+}
+
+func init() {
+	proto.RegisterFile("github.com/getamis/alice/crypto/tss/reshare/checkpoint.proto", fileDescriptor_a516ed71a1db69b2)
+}
+
+var fileDescriptor_a516ed71a1db69b2 = []byte{
+	// 485 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x6f, 0x6b, 0xdb, 0x3e,
+	0x10, 0xc7, 0x71, 0xdd, 0xa4, 0x89, 0x92, 0xfe, 0x28, 0xe2, 0x07, 0x13, 0xa1, 0x30, 0xd3, 0x3d,
+	0x71, 0xa1, 0x48, 0x90, 0xb1, 0xb1, 0xbf, 0x1d, 0x14, 0xc2, 0x18, 0xa3, 0x10, 0x9c, 0xbd, 0x01,
+	0x59, 0x39, 0xdb, 0xc2, 0x8e, 0x65, 0x24, 0x79, 0xc5, 0x7b, 0x8b, 0x7b, 0x53, 0x43, 0xfe, 0x93,
+	0xb4, 0x63, 0x90, 0xed, 0x99, 0x74, 0x77, 0x1f, 0x7d, 0xef, 0xee, 0x6b, 0xa3, 0x0f, 0xa9, 0xb4,
+	0x59, 0x1d, 0x53, 0xa1, 0x76, 0x2c, 0x05, 0xcb, 0x77, 0xd2, 0x30, 0x5e, 0x48, 0x01, 0x4c, 0xe8,
+	0xa6, 0xb2, 0x8a, 0x59, 0x63, 0x98, 0x06, 0x93, 0x71, 0x0d, 0x4c, 0x64, 0x20, 0xf2, 0x4a, 0xc9,
+	0xd2, 0xd2, 0x4a, 0x2b, 0xab, 0xf0, 0x59, 0x9f, 0x59, 0xdc, 0x1e, 0x7b, 0x26, 0x96, 0x3a, 0xcf,
+	0x54, 0x92, 0xc8, 0xd2, 0x82, 0xae, 0x54, 0xc1, 0xad, 0x54, 0x25, 0x8b, 0xf3, 0xee, 0xa1, 0xc5,
+	0xfb, 0x63, 0x3c, 0x88, 0x56, 0x37, 0xd5, 0xaa, 0xae, 0x0a, 0xfe, 0xc0, 0x1e, 0x75, 0xb1, 0x78,
+	0x75, 0x0c, 0xfe, 0x91, 0x57, 0x5a, 0xa9, 0x84, 0xed, 0xc0, 0x18, 0x9e, 0x42, 0x8f, 0xbd, 0xfd,
+	0x97, 0xd1, 0x9f, 0xa2, 0xaf, 0xff, 0x06, 0xed, 0x11, 0x06, 0x22, 0x53, 0x1d, 0x77, 0xf5, 0xf3,
+	0x14, 0x8d, 0x36, 0x96, 0x5b, 0xc0, 0x2f, 0xd0, 0x48, 0xab, 0xba, 0xdc, 0x12, 0x2f, 0xf0, 0xc2,
+	0xff, 0x96, 0xe7, 0xb4, 0x17, 0xa2, 0xdf, 0x9a, 0x0a, 0xa2, 0x2e, 0x87, 0x2f, 0xd1, 0xd4, 0x80,
+	0x31, 0x52, 0x95, 0x5f, 0xb6, 0xe4, 0x24, 0xf0, 0xc2, 0x79, 0x74, 0x08, 0xb8, 0xac, 0xcd, 0x1c,
+	0xa6, 0x8a, 0x2d, 0xf1, 0x03, 0x2f, 0x3c, 0x8f, 0x0e, 0x01, 0xfc, 0x11, 0x4d, 0xab, 0x3a, 0x2e,
+	0xa4, 0xf8, 0x0a, 0x0d, 0x39, 0x0d, 0xbc, 0x70, 0xb6, 0x7c, 0x4e, 0x7f, 0xdb, 0x22, 0x5d, 0x89,
+	0xb5, 0xbb, 0xdf, 0x77, 0x9d, 0x46, 0x07, 0x02, 0x2f, 0xd0, 0x44, 0x15, 0xdb, 0x8d, 0x6b, 0x89,
+	0x8c, 0x5a, 0xe5, 0xfd, 0x1d, 0x5f, 0x23, 0x3f, 0xce, 0x0d, 0x19, 0x07, 0x7e, 0x38, 0x5b, 0x3e,
+	0xdb, 0x77, 0xde, 0x0e, 0x46, 0xef, 0x72, 0xb3, 0x2a, 0xad, 0x6e, 0x22, 0x57, 0x83, 0xaf, 0xd0,
+	0x5c, 0x28, 0x48, 0x12, 0x29, 0x24, 0x94, 0xd6, 0x90, 0xb3, 0xc0, 0x0f, 0xe7, 0xd1, 0x93, 0x98,
+	0x93, 0x2a, 0xe1, 0xa1, 0x93, 0x9a, 0x74, 0x52, 0xc3, 0x1d, 0xdf, 0xa2, 0x99, 0x91, 0x9f, 0xd7,
+	0xce, 0xbd, 0x7b, 0x93, 0x92, 0x69, 0x3b, 0xc7, 0x25, 0xed, 0x0d, 0xa5, 0x1b, 0x91, 0x95, 0x4a,
+	0xeb, 0x2e, 0xdf, 0x0f, 0xf1, 0x18, 0xc0, 0x37, 0x68, 0xd2, 0xdb, 0x60, 0x08, 0x6a, 0xfb, 0xbd,
+	0xd8, 0xf7, 0x3b, 0x00, 0xfb, 0x0a, 0x7c, 0x83, 0xc6, 0xce, 0x2c, 0x30, 0x64, 0xd6, 0xd6, 0xfe,
+	0x4f, 0x07, 0xdb, 0x57, 0x22, 0x53, 0x43, 0x7d, 0x5f, 0xe3, 0xf6, 0xef, 0x4e, 0xce, 0x30, 0x43,
+	0xe6, 0x81, 0x1f, 0x8e, 0xa2, 0x43, 0x60, 0xc1, 0xd1, 0x64, 0x58, 0x05, 0xbe, 0x40, 0x7e, 0x0e,
+	0x4d, 0x6b, 0xf5, 0x34, 0x72, 0x47, 0xfc, 0x09, 0x8d, 0xbe, 0xf3, 0xa2, 0x86, 0xd6, 0xd5, 0xd9,
+	0xf2, 0x9a, 0xfe, 0xf1, 0xff, 0xa0, 0x77, 0xf9, 0x9a, 0x6b, 0xbe, 0x03, 0x0b, 0x7a, 0x50, 0xef,
+	0xb8, 0x77, 0x27, 0x6f, 0xbc, 0x78, 0xdc, 0x7e, 0x54, 0x2f, 0x7f, 0x0d, 0x00, 0x3a, 0xff, 0x82,
+	0xb8, 0xc4, 0x03, 0x00, 0x00,
+}
@@ -0,0 +1,231 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reshare
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/polynomial"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	proto "github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Checkpoint", func() {
+	var (
+		curve = btcec.S256()
+		key   = bytes.Repeat([]byte{1}, tss.CheckpointKeySize)
+		bks   = []*birkhoffinterpolation.BkParameter{
+			birkhoffinterpolation.NewBkParameter(big.NewInt(1), uint32(0)),
+			birkhoffinterpolation.NewBkParameter(big.NewInt(2), uint32(0)),
+			birkhoffinterpolation.NewBkParameter(big.NewInt(3), uint32(0)),
+		}
+	)
+
+	It("restores a crashed peer and finishes the process", func() {
+		reshares, listeners := newReshares(curve, 2, bks)
+		for _, l := range listeners {
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Once()
+		}
+		crashedID := getID(0)
+		crashed := reshares[crashedID]
+		listeners[crashedID].On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+		crashed.Stop()
+
+		// The crashed peer receives the commit messages, but never handles them
+		for fromID, fromD := range reshares {
+			msg := fromD.GetCommitMessage()
+			for toID, toD := range reshares {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		checkpoint, err := crashed.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		restored, err := RestoreReshare(crashed.ch.peerManager, key, checkpoint, listeners[crashedID])
+		Expect(err).Should(BeNil())
+		Expect(restored.GetCommitMessage()).Should(Equal(crashed.GetCommitMessage()))
+		reshares[crashedID] = restored
+		restored.Start()
+		time.Sleep(1 * time.Second)
+
+		for _, d := range reshares {
+			d.Stop()
+			_, err := d.GetResult()
+			Expect(err).Should(BeNil())
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("restores a crashed peer in an echo broadcast round", func() {
+		crashedID, slowID := getID(0), getID(2)
+		reshares := make(map[string]*Reshare, len(bks))
+		listeners := make(map[string]*mocks.StateChangedListener, len(bks))
+		doneChs := make(map[string]chan struct{}, len(bks))
+		pms := make(map[string]*holdPeerManager, len(bks))
+		bksMap := make(map[string]*birkhoffinterpolation.BkParameter, len(bks))
+		for i, bk := range bks {
+			bksMap[getID(i)] = bk
+		}
+		poly, err := polynomial.RandomPolynomial(curve.Params().N, 1)
+		Expect(err).Should(BeNil())
+		pubkey := ecpointgrouplaw.ScalarBaseMult(curve, poly.Get(0))
+		for i, bk := range bks {
+			id := getID(i)
+			pm := newPeerManager(id, len(bks)-1)
+			pm.setReshares(reshares)
+			pms[id] = &holdPeerManager{peerManager: pm}
+			listeners[id] = new(mocks.StateChangedListener)
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			listeners[id].On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+			reshares[id], err = NewReshare(pms[id], sessionID, 2, pubkey, poly.Evaluate(bk.GetX()), bksMap, listeners[id])
+			Expect(err).Should(BeNil())
+			reshares[id].EnableEchoBroadcast(types.MessageType(Type_Commit))
+		}
+		// The echo of the commit messages from the slow peer arrives after the crashed peer is restored
+		pms[slowID].hold = func(id string, msg types.Message) bool {
+			echo, ok := msg.(*message.EchoMessage)
+			return ok && id == crashedID && Type(echo.GetType()) == Type_Commit
+		}
+		for _, r := range reshares {
+			r.Start()
+		}
+		for fromID, fromD := range reshares {
+			msg := fromD.GetCommitMessage()
+			for toID, toD := range reshares {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+
+		// The crashed peer has collected the echo of the other peer, and the verify messages of the next round
+		crashed := reshares[crashedID]
+		Eventually(func() bool {
+			var ok bool
+			_ = crashed.Snapshot(func(handler types.Handler, msgs []types.Message, echoes []*message.EchoMessage) error {
+				var verifies int
+				for _, msg := range msgs {
+					if msg.GetMessageType() == types.MessageType(Type_Verify) {
+						verifies++
+					}
+				}
+				ok = handler.MessageType() == types.MessageType(Type_Commit) && len(echoes) == 1 && verifies == 2
+				return nil
+			})
+			return ok
+		}).Should(BeTrue())
+		failedCh := make(chan struct{})
+		listeners[crashedID].On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+			close(failedCh)
+		}).Once()
+		crashed.Stop()
+		<-failedCh
+		checkpoint, err := crashed.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		restored, err := RestoreReshare(pms[crashedID], key, checkpoint, listeners[crashedID])
+		Expect(err).Should(BeNil())
+		Expect(restored.GetHandler().MessageType()).Should(Equal(types.MessageType(Type_Commit)))
+		Expect(restored.EchoBroadcastTypes()).Should(Equal([]types.MessageType{types.MessageType(Type_Commit)}))
+		reshares[crashedID] = restored
+		restored.Start()
+		pms[slowID].release()
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		for _, d := range reshares {
+			d.Stop()
+			_, err := d.GetResult()
+			Expect(err).Should(BeNil())
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("wrong key", func() {
+		reshares, listeners := newReshares(curve, 2, bks)
+		d := reshares[getID(0)]
+		checkpoint, err := d.Checkpoint(key)
+		Expect(err).Should(BeNil())
+		for id, d := range reshares {
+			listeners[id].On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+			d.Stop()
+		}
+
+		restored, err := RestoreReshare(d.ch.peerManager, bytes.Repeat([]byte{2}, tss.CheckpointKeySize), checkpoint, nil)
+		Expect(err).Should(Equal(tss.ErrInvalidCheckpoint))
+		Expect(restored).Should(BeNil())
+	})
+})
+
+// holdPeerManager holds the messages chosen by hold until they are released. The errors of the delivered messages
+// are ignored, because the restored peers send the messages of the restored round again.
+type holdPeerManager struct {
+	*peerManager
+
+	lock sync.Mutex
+	hold func(id string, msg types.Message) bool
+	held map[string][]types.Message
+}
+
+func (p *holdPeerManager) MustSend(id string, message proto.Message) {
+	msg := message.(types.Message)
+	p.lock.Lock()
+	if p.hold != nil && p.hold(id, msg) {
+		if p.held == nil {
+			p.held = make(map[string][]types.Message)
+		}
+		p.held[id] = append(p.held[id], msg)
+		p.lock.Unlock()
+		return
+	}
+	p.lock.Unlock()
+	_ = p.reshares[id].AddMessage(msg)
+}
+
+func (p *holdPeerManager) release() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.hold = nil
+	for id, msgs := range p.held {
+		for _, msg := range msgs {
+			Expect(p.reshares[id].AddMessage(msg)).Should(BeNil())
+		}
+	}
+	p.held = nil
+}
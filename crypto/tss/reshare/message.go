@@ -19,6 +19,9 @@ import (
 )
 
 func (m *Message) IsValid() bool {
+	if len(m.GetSessionId()) == 0 {
+		return false
+	}
 	switch m.Type {
 	case Type_Commit:
 		return m.GetCommit() != nil
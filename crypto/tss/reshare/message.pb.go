@@ -51,8 +51,9 @@ func (Type) EnumDescriptor() ([]byte, []int) {
 }
 
 type Message struct {
-	Type Type   `protobuf:"varint,1,opt,name=type,proto3,enum=reshare.Type" json:"type,omitempty"`
-	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Type      Type   `protobuf:"varint,1,opt,name=type,proto3,enum=reshare.Type" json:"type,omitempty"`
+	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId []byte `protobuf:"bytes,6,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
 	// Types that are valid to be assigned to Body:
 	//	*Message_Commit
 	//	*Message_Verify
@@ -102,6 +103,13 @@ func (m *Message) GetId() string {
 	return ""
 }
 
+func (m *Message) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
 type isMessage_Body interface {
 	isMessage_Body()
 }
@@ -291,28 +299,29 @@ func init() {
 }
 
 var fileDescriptor_b20ef4ba92a5944f = []byte{
-	// 358 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0x4d, 0x4b, 0xc3, 0x30,
-	0x1c, 0xc6, 0xd7, 0x5a, 0x3b, 0xcc, 0x70, 0x8e, 0x78, 0x29, 0xc3, 0x43, 0xed, 0x69, 0x0c, 0x4c,
-	0x61, 0x22, 0xcc, 0x8b, 0x87, 0x0d, 0xa6, 0x87, 0x0d, 0x46, 0x15, 0x0f, 0xde, 0xba, 0x36, 0xeb,
-	0x82, 0x6b, 0x53, 0x92, 0x4c, 0xa8, 0xdf, 0xd2, 0x6f, 0x24, 0xe9, 0x3f, 0xee, 0x45, 0x06, 0xbb,
-	0x85, 0x3c, 0xbf, 0xe7, 0xf9, 0xbf, 0xa1, 0xc7, 0x8c, 0xa9, 0xd5, 0x66, 0x41, 0x12, 0x9e, 0x87,
-	0x19, 0x55, 0x71, 0xce, 0x64, 0x18, 0xaf, 0x59, 0x42, 0xc3, 0x44, 0x54, 0xa5, 0xe2, 0xa1, 0x92,
-	0x32, 0x14, 0x54, 0xae, 0x62, 0x41, 0xc3, 0x9c, 0x4a, 0x19, 0x67, 0x94, 0x94, 0x82, 0x2b, 0x8e,
-	0x9b, 0xe6, 0xbb, 0x3b, 0x3c, 0x95, 0x91, 0xf0, 0x3c, 0x67, 0x2a, 0xa7, 0x85, 0x3a, 0x8c, 0xe8,
-	0x3e, 0x9c, 0x72, 0x7e, 0x7f, 0x96, 0x82, 0xf3, 0xe5, 0xa1, 0x2d, 0xf8, 0xb1, 0x50, 0x73, 0x06,
-	0x3f, 0xf8, 0x16, 0x39, 0xaa, 0x2a, 0xa9, 0x67, 0xf9, 0x56, 0xaf, 0x3d, 0xb8, 0x24, 0xa6, 0x29,
-	0xf2, 0x56, 0x95, 0x34, 0xaa, 0x25, 0xdc, 0x46, 0x36, 0x4b, 0x3d, 0xdb, 0xb7, 0x7a, 0x17, 0x91,
-	0xcd, 0x52, 0x7c, 0x87, 0x5c, 0xe8, 0xc8, 0x3b, 0xf3, 0xad, 0x5e, 0x6b, 0x70, 0xbd, 0x35, 0x8d,
-	0x78, 0x5a, 0x8d, 0x6b, 0xe9, 0xa5, 0x11, 0x19, 0x48, 0xe3, 0x5f, 0x54, 0xb0, 0x65, 0xe5, 0x39,
-	0x47, 0xf0, 0xf7, 0x5a, 0xd2, 0x38, 0x40, 0x1a, 0x17, 0x54, 0x6e, 0xd6, 0xca, 0x3b, 0x3f, 0x82,
-	0x47, 0xb5, 0xa4, 0x71, 0x80, 0x46, 0x2e, 0x72, 0x16, 0x3c, 0xad, 0x82, 0x0f, 0x84, 0x76, 0xd5,
-	0xf1, 0x14, 0x5d, 0x95, 0x9c, 0x15, 0x6a, 0xbc, 0xdd, 0x5c, 0x3d, 0x60, 0x6b, 0x10, 0x90, 0xdd,
-	0x32, 0xc9, 0xfc, 0x10, 0x31, 0x2b, 0x89, 0xfe, 0x5b, 0x83, 0x09, 0x64, 0x43, 0xab, 0x78, 0xb8,
-	0x9d, 0x07, 0x22, 0xfd, 0xfd, 0xc8, 0x09, 0x5d, 0xa7, 0x79, 0x5c, 0x00, 0xfa, 0x17, 0x68, 0xf8,
-	0x60, 0x0a, 0x39, 0x30, 0x03, 0x7e, 0x42, 0x2d, 0xc9, 0x9e, 0xe7, 0xfa, 0x3e, 0x33, 0x99, 0x99,
-	0xb0, 0x1b, 0x62, 0x4e, 0x46, 0x5e, 0x93, 0x55, 0xc1, 0x85, 0x00, 0xdd, 0x04, 0xed, 0x1b, 0xfa,
-	0x7d, 0xe4, 0xe8, 0x23, 0x61, 0x84, 0x5c, 0xe8, 0xb5, 0xd3, 0xd0, 0x6f, 0x28, 0xdd, 0xb1, 0xf4,
-	0x1b, 0x2a, 0x75, 0xec, 0x85, 0x5b, 0x1f, 0xfe, 0xfe, 0x37, 0x00, 0x00, 0xff, 0xff, 0xff, 0xc8,
-	0x2f, 0x07, 0xaf, 0x02, 0x00, 0x00,
+	// 376 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xcf, 0x8b, 0xda, 0x40,
+	0x14, 0xc7, 0x4d, 0x9a, 0x46, 0x7c, 0xb6, 0x56, 0xa6, 0x97, 0x20, 0x1e, 0xd2, 0x9c, 0x82, 0xd0,
+	0x09, 0x58, 0x0a, 0xf6, 0xd2, 0x83, 0x82, 0x6d, 0x41, 0x41, 0xd2, 0x65, 0x0f, 0x7b, 0x8b, 0xc9,
+	0x18, 0x87, 0x35, 0x99, 0x30, 0x33, 0x2e, 0x64, 0xff, 0xe9, 0xfd, 0x17, 0x96, 0xc9, 0xcc, 0x1a,
+	0x5d, 0x04, 0x6f, 0xc3, 0xfb, 0x7e, 0xde, 0xf7, 0xfd, 0x1a, 0xf8, 0x95, 0x53, 0xb9, 0x3f, 0x6e,
+	0x71, 0xca, 0x8a, 0x28, 0x27, 0x32, 0x29, 0xa8, 0x88, 0x92, 0x03, 0x4d, 0x49, 0x94, 0xf2, 0xba,
+	0x92, 0x2c, 0x92, 0x42, 0x44, 0x9c, 0x88, 0x7d, 0xc2, 0x49, 0x54, 0x10, 0x21, 0x92, 0x9c, 0xe0,
+	0x8a, 0x33, 0xc9, 0x50, 0xd7, 0x84, 0x47, 0xb3, 0x5b, 0x1e, 0x29, 0x2b, 0x0a, 0x2a, 0x0b, 0x52,
+	0xca, 0x4b, 0x8b, 0xd1, 0xcf, 0x5b, 0x99, 0xcf, 0x8f, 0x15, 0x67, 0x6c, 0x77, 0x99, 0x16, 0xbc,
+	0x58, 0xd0, 0x5d, 0xeb, 0x08, 0xfa, 0x06, 0x8e, 0xac, 0x2b, 0xe2, 0x59, 0xbe, 0x15, 0x0e, 0xa6,
+	0x9f, 0xb1, 0x69, 0x0a, 0xdf, 0xd5, 0x15, 0x89, 0x1b, 0x09, 0x0d, 0xc0, 0xa6, 0x99, 0x67, 0xfb,
+	0x56, 0xd8, 0x8b, 0x6d, 0x9a, 0xa1, 0x31, 0xf4, 0x04, 0x11, 0x82, 0xb2, 0xf2, 0x5f, 0xe6, 0xb9,
+	0xbe, 0x15, 0x7e, 0x8a, 0xdb, 0x00, 0xfa, 0x0e, 0xae, 0xee, 0xd7, 0xfb, 0xe0, 0x5b, 0x61, 0x7f,
+	0xfa, 0xf5, 0x64, 0x39, 0x67, 0x59, 0xbd, 0x68, 0xa4, 0xbf, 0x9d, 0xd8, 0x40, 0x0a, 0x7f, 0x22,
+	0x9c, 0xee, 0x6a, 0xcf, 0xb9, 0x82, 0xdf, 0x37, 0x92, 0xc2, 0x35, 0xa4, 0x70, 0x4e, 0xc4, 0xf1,
+	0x20, 0xbd, 0x8f, 0x57, 0xf0, 0xb8, 0x91, 0x14, 0xae, 0xa1, 0xb9, 0x0b, 0xce, 0x96, 0x65, 0x75,
+	0xf0, 0x00, 0xd0, 0x56, 0x47, 0x2b, 0xf8, 0x52, 0x31, 0x5a, 0xca, 0xc5, 0x69, 0xaf, 0xcd, 0xf8,
+	0xfd, 0x69, 0x80, 0xdb, 0x55, 0xe3, 0xcd, 0x25, 0x62, 0x16, 0x16, 0xbf, 0x4f, 0x0d, 0x96, 0x00,
+	0x6d, 0xab, 0x68, 0x76, 0x9a, 0x47, 0x5b, 0xfa, 0xe7, 0x96, 0x4b, 0x72, 0xc8, 0x8a, 0xa4, 0xd4,
+	0xe8, 0x9b, 0xa1, 0xe1, 0x83, 0x15, 0x40, 0x3b, 0x03, 0xfa, 0x0d, 0x7d, 0x41, 0xff, 0x6c, 0xd4,
+	0xf5, 0xd6, 0x22, 0x37, 0x66, 0x63, 0x6c, 0x0e, 0x8a, 0xff, 0xa7, 0xfb, 0x92, 0x71, 0xae, 0x75,
+	0x63, 0x74, 0x9e, 0x30, 0x99, 0x80, 0xa3, 0x4e, 0x88, 0x00, 0x5c, 0xdd, 0xeb, 0xb0, 0xa3, 0xde,
+	0xba, 0xf4, 0xd0, 0x52, 0x6f, 0x5d, 0x69, 0x68, 0x6f, 0xdd, 0xe6, 0x5b, 0xfc, 0x78, 0x1d, 0x00,
+	0x7f, 0x6e, 0x75, 0xcf, 0xcd, 0x02, 0x00, 0x00,
 }
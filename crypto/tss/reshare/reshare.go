@@ -32,24 +32,33 @@ type Reshare struct {
 
 type Result struct {
 	Share *big.Int
+	// PublicShares are the verified public shares (i.e. s_i*G) of the participants, including self
+	PublicShares map[string]*ecpointgrouplaw.ECPoint
 }
 
-func NewReshare(peerManager types.PeerManager, threshold uint32, publicKey *ecpointgrouplaw.ECPoint, oldShare *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, listener types.StateChangedListener) (*Reshare, error) {
+func NewReshare(peerManager types.PeerManager, sessionID []byte, threshold uint32, publicKey *ecpointgrouplaw.ECPoint, oldShare *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, listener types.StateChangedListener) (*Reshare, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
 	peerNum := peerManager.NumPeers()
 	if len(bks) != int(peerNum+1) {
 		return nil, tss.ErrNotEnoughBKs
 	}
-	ch, err := newCommitHandler(publicKey, peerManager, threshold, oldShare, bks)
+	ch, err := newCommitHandler(publicKey, peerManager, sessionID, threshold, oldShare, bks)
 	if err != nil {
 		return nil, err
 	}
+	return newReshareWithCurrentHandler(peerManager, sessionID, listener, ch, ch), nil
+}
+
+func newReshareWithCurrentHandler(peerManager types.PeerManager, sessionID []byte, listener types.StateChangedListener, ch *commitHandler, handler types.Handler) *Reshare {
 	return &Reshare{
 		ch:      ch,
-		MsgMain: message.NewMsgMain(peerManager.SelfID(), peerNum, listener, ch, types.MessageType(Type_Commit), types.MessageType(Type_Verify), types.MessageType(Type_Result)),
-	}, nil
+		MsgMain: message.NewMsgMain(peerManager, sessionID, listener, handler, types.MessageType(Type_Commit), types.MessageType(Type_Verify), types.MessageType(Type_Result)),
+	}
 }
 
-// GetResult returns the final result: new share
+// GetResult returns the final result: new share and public shares
 func (d *Reshare) GetResult() (*Result, error) {
 	if d.GetState() != types.StateDone {
 		return nil, tss.ErrNotReady
@@ -62,8 +71,14 @@ func (d *Reshare) GetResult() (*Result, error) {
 		return nil, tss.ErrNotReady
 	}
 
+	publicShares, err := rh.getPublicShares()
+	if err != nil {
+		log.Error("Failed to get public shares", "err", err)
+		return nil, err
+	}
 	return &Result{
-		Share: rh.newShare,
+		Share:        rh.newShare,
+		PublicShares: publicShares,
 	}, nil
 }
 
@@ -28,6 +28,7 @@ import (
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/message/types/mocks"
 	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/sirius/log"
 	proto "github.com/golang/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -59,10 +60,18 @@ var _ = Describe("Reshare", func() {
 		}
 		time.Sleep(1 * time.Second)
 
-		for _, d := range reshares {
+		bksMap := make(map[string]*birkhoffinterpolation.BkParameter, len(bks))
+		for i, bk := range bks {
+			bksMap[getID(i)] = bk
+		}
+		for id, d := range reshares {
 			d.Stop()
-			_, err := d.GetResult()
+			r, err := d.GetResult()
 			Expect(err).Should(BeNil())
+			// The public shares are consistent with the new shares and the public key
+			Expect(r.PublicShares).Should(HaveLen(len(bks)))
+			Expect(r.PublicShares[id].Equal(ecpointgrouplaw.ScalarBaseMult(c, r.Share))).Should(BeTrue())
+			Expect(tss.ValidatePublicShares(log.Discard(), bksMap, r.PublicShares, threshold, d.ch.publicKey)).Should(BeNil())
 		}
 
 		for _, l := range listeners {
@@ -161,7 +170,7 @@ var _ = Describe("Reshare", func() {
 			listener[i].On("OnStateChanged", types.StateInit, types.StateFailed).Once()
 			tempPoly := poly.Differentiate(ranks[i])
 			oldShare := tempPoly.Evaluate(xs[i])
-			reshares[id], err = NewReshare(peerManagers[i], threshold, pubkey, oldShare, bks, listener[i])
+			reshares[id], err = NewReshare(peerManagers[i], sessionID, threshold, pubkey, oldShare, bks, listener[i])
 			Expect(err).Should(Equal(tss.ErrNotEnoughBKs))
 		}
 	})
@@ -193,7 +202,7 @@ var _ = Describe("Reshare", func() {
 		pm.setReshares(reshares)
 		listener.On("OnStateChanged", types.StateInit, types.StateFailed).Once()
 		var err error
-		_, err = NewReshare(pm, threshold, pubkey, oldShare, bks, listener)
+		_, err = NewReshare(pm, sessionID, threshold, pubkey, oldShare, bks, listener)
 		Expect(err).Should(Equal(tss.ErrSelfBKNotFound))
 	})
 
@@ -233,12 +242,20 @@ var _ = Describe("Reshare", func() {
 			tempPoly := poly.Differentiate(ranks[i])
 			oldShare := tempPoly.Evaluate(xs[i])
 			var err error
-			reshares[id], err = NewReshare(peerManagers[i], threshold, pubkey, oldShare, bks, listener[i])
+			reshares[id], err = NewReshare(peerManagers[i], sessionID, threshold, pubkey, oldShare, bks, listener[i])
 			Expect(err).Should(Equal(utils.ErrLargeThreshold))
 		}
 	})
+
+	It("empty session id", func() {
+		r, err := NewReshare(newPeerManager(getID(0), 2), nil, 2, ecpointgrouplaw.ScalarBaseMult(curve, big.NewInt(100)), big.NewInt(50), nil, nil)
+		Expect(err).Should(Equal(tss.ErrEmptySessionID))
+		Expect(r).Should(BeNil())
+	})
 })
 
+var sessionID = []byte("session")
+
 func getID(id int) string {
 	return fmt.Sprintf("id-%d", id)
 }
@@ -268,6 +285,16 @@ func (p *peerManager) SelfID() string {
 	return p.id
 }
 
+func (p *peerManager) PeerIDs() []string {
+	ids := make([]string, 0, len(p.reshares))
+	for id := range p.reshares {
+		if id != p.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func (p *peerManager) MustSend(id string, message proto.Message) {
 	d := p.reshares[id]
 	msg := message.(types.Message)
@@ -301,7 +328,7 @@ func newReshares(c elliptic.Curve, threshold uint32, bks []*birkhoffinterpolatio
 		listeners[id] = new(mocks.StateChangedListener)
 		tempPoly := poly.Differentiate(bks[i].GetRank())
 		oldShare := tempPoly.Evaluate(bks[i].GetX())
-		reshares[id], err = NewReshare(peerManagers[i], threshold, pubkey, oldShare, bksMap, listeners[id])
+		reshares[id], err = NewReshare(peerManagers[i], sessionID, threshold, pubkey, oldShare, bksMap, listeners[id])
 		Expect(err).Should(BeNil())
 		r, err := reshares[id].GetResult()
 		Expect(r).Should(BeNil())
@@ -17,30 +17,41 @@ package signer
 import (
 	"crypto/elliptic"
 	"errors"
-	fmt "fmt"
+	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/getamis/alice/crypto/birkhoffinterpolation"
 	"github.com/getamis/alice/crypto/commitment"
 	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/homo/paillier"
 	"github.com/getamis/alice/crypto/mta"
 	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
 	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/zkproof"
 	"github.com/getamis/sirius/log"
 	proto "github.com/golang/protobuf/proto"
 )
 
 var (
 	ErrPeerNotFound = errors.New("peer message not found")
+	// ErrPubkeyNotFound is returned if the verified homomorphic public key of a peer is not given
+	ErrPubkeyNotFound = errors.New("homomorphic public key not found")
+	// ErrSignerBkNotFound is returned if the Birkhoff parameter of a signer is not in the key group
+	ErrSignerBkNotFound = errors.New("signer Birkhoff parameter not found")
 )
 
 type pubkeyData struct {
 	publicKey homo.Pubkey
 	aigCommit *commitment.HashCommitmentMessage
+	pedersen  *paillier.PedersenOpenParameter
 }
 
 type pubkeyHandler struct {
+	secret    *big.Int
+	bks       map[string]*birkhoffinterpolation.BkParameter
 	wi        *big.Int
 	msg       []byte
 	publicKey *pt.ECPoint
@@ -49,32 +60,46 @@ type pubkeyHandler struct {
 	aiMta          mta.Mta
 	homo           homo.Crypto
 	agCommitmenter *commitment.HashCommitmenter
+	sessionID      []byte
+	// homoPubkey is our homomorphic public key with the proofs bound to the session. It is generated when the
+	// public key message is built first, and kept in the checkpoint to resend the same one after restoring.
+	homoPubkey []byte
+	// peerPubkeys are the homomorphic public keys of the peers verified before (e.g. by auxinfo). If they
+	// are given, the public keys are neither sent out nor verified again in this round.
+	peerPubkeys map[string]homo.Pubkey
+	// presign is true if the process stops after R is built, which is independent of the message
+	presign bool
+	// pedersen is our ring-Pedersen parameter if the homo crypto is Paillier. The peers prove the ranges of
+	// their ciphertexts in the EncK and Mta rounds under it.
+	pedersen *paillier.PedersenParameter
 
 	peerManager types.PeerManager
 	peerNum     uint32
 	peers       map[string]*peer
 }
 
-func newPubkeyHandler(publicKey *pt.ECPoint, peerManager types.PeerManager, homo homo.Crypto, secret *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, msg []byte) (*pubkeyHandler, error) {
-	numPeers := peerManager.NumPeers()
-	lenBks := len(bks)
-	if lenBks != int(numPeers+1) {
-		log.Warn("Inconsistent peer num", "bks", len(bks), "numPeers", numPeers)
-		return nil, tss.ErrInconsistentPeerNumAndBks
+func newPubkeyHandler(publicKey *pt.ECPoint, peerManager types.PeerManager, sessionID []byte, homo homo.Crypto, secret *big.Int, threshold uint32, keyGroupBks map[string]*birkhoffinterpolation.BkParameter, signerIDs []string, msg []byte) (*pubkeyHandler, error) {
+	bks, err := selectSignerBks(publicKey.GetCurve().Params().N, peerManager, threshold, keyGroupBks, signerIDs)
+	if err != nil {
+		log.Warn("Failed to select signer bks", "err", err)
+		return nil, err
 	}
 
 	// Build mta for ai, g
-	curve := publicKey.GetCurve()
-	aiMta, err := mta.NewMta(curve.Params().N, homo)
+	aiMta, err := mta.NewMta(publicKey.GetCurve().Params().N, homo)
 	if err != nil {
 		log.Warn("Failed to new ai mta", "err", err)
 		return nil, err
 	}
+	return newPubkeyHandlerWithMta(publicKey, peerManager, sessionID, homo, secret, bks, msg, aiMta)
+}
 
+func newPubkeyHandlerWithMta(publicKey *pt.ECPoint, peerManager types.PeerManager, sessionID []byte, homo homo.Crypto, secret *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, msg []byte, aiMta mta.Mta) (*pubkeyHandler, error) {
 	// Build committer for ag
+	curve := publicKey.GetCurve()
 	// bit length / 8(to bytes) * 2(x and y point)
 	p := aiMta.GetAG(curve)
-	agCommitmenter, err := tss.NewCommitterByPoint(p)
+	agCommitmenter, err := tss.NewCommitterByPoint(sessionID, p)
 	if err != nil {
 		log.Warn("Failed to new an ag hash commiter", "err", err)
 		return nil, err
@@ -85,7 +110,9 @@ func newPubkeyHandler(publicKey *pt.ECPoint, peerManager types.PeerManager, homo
 		log.Warn("Failed to build wi and peers", "err", err)
 		return nil, err
 	}
-	return &pubkeyHandler{
+	ph := &pubkeyHandler{
+		secret:    secret,
+		bks:       bks,
 		wi:        wi,
 		msg:       msg,
 		publicKey: publicKey,
@@ -94,11 +121,31 @@ func newPubkeyHandler(publicKey *pt.ECPoint, peerManager types.PeerManager, homo
 		aiMta:          aiMta,
 		agCommitmenter: agCommitmenter,
 		homo:           homo,
+		sessionID:      sessionID,
 
 		peerManager: peerManager,
-		peerNum:     numPeers,
+		peerNum:     peerManager.NumPeers(),
 		peers:       peers,
-	}, nil
+	}
+	if paillierHomo, ok := homo.(*paillier.Paillier); ok {
+		ph.pedersen = paillierHomo.GetPedersenParameter()
+	}
+	return ph, nil
+}
+
+// setPeerPubkeys sets the verified homomorphic public keys of all the peers.
+func (p *pubkeyHandler) setPeerPubkeys(pubkeys map[string]homo.Pubkey) error {
+	peerPubkeys := make(map[string]homo.Pubkey, len(p.peers))
+	for id := range p.peers {
+		pubkey, ok := pubkeys[id]
+		if !ok || pubkey == nil {
+			log.Warn("Homomorphic public key not found", "id", id)
+			return ErrPubkeyNotFound
+		}
+		peerPubkeys[id] = pubkey
+	}
+	p.peerPubkeys = peerPubkeys
+	return nil
 }
 
 func (p *pubkeyHandler) MessageType() types.MessageType {
@@ -128,49 +175,113 @@ func (p *pubkeyHandler) HandleMessage(logger log.Logger, message types.Message)
 	}
 
 	body := msg.GetPubkey()
-	// Verify public key
-	publicKey, err := p.homo.NewPubKeyFromBytes(body.Pubkey)
-	if err != nil {
-		logger.Warn("Failed to get public key", "err", err)
-		return err
+	publicKey, ok := p.peerPubkeys[id]
+	if !ok {
+		// Verify public key
+		var err error
+		publicKey, err = p.homo.NewPubKeyFromBytesWithSession(p.sessionID, id, body.Pubkey)
+		if err != nil {
+			logger.Warn("Failed to get public key", "err", err)
+			return blame(err, msg)
+		}
+	}
+
+	var pedersen *paillier.PedersenOpenParameter
+	if p.pedersen != nil {
+		var err error
+		pedersen, err = paillier.GetPedersenOpenParameter(publicKey)
+		if err != nil {
+			logger.Warn("Failed to get pedersen parameter", "err", err)
+			return blame(err, msg)
+		}
 	}
 
 	peer.pubkey = &pubkeyData{
 		publicKey: publicKey,
 		aigCommit: body.AgCommitment,
+		pedersen:  pedersen,
 	}
 	return peer.AddMessage(msg)
 }
 
 func (p *pubkeyHandler) Finalize(logger log.Logger) (types.Handler, error) {
-	msg := p.getEnckMessage()
-	p.broadcast(msg)
+	for id, peer := range p.peers {
+		msg, err := p.getEnckMessage(peer)
+		if err != nil {
+			logger.Warn("Failed to get enck message", "id", id, "err", err)
+			return nil, err
+		}
+		p.peerManager.MustSend(id, msg)
+	}
 	return newEncKHandler(p)
 }
 
 func (p *pubkeyHandler) GetPubkeyMessage() *Message {
+	// The peers have verified our public key before
+	var pubkey []byte
+	if p.peerPubkeys == nil {
+		pubkey = p.getHomoPubkey()
+	}
 	return &Message{
-		Type: Type_Pubkey,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_Pubkey,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_Pubkey{
 			Pubkey: &BodyPublicKey{
-				Pubkey:       p.homo.GetPubKey().ToPubKeyBytes(),
+				Pubkey:       pubkey,
 				AgCommitment: p.agCommitmenter.GetCommitmentMessage(),
 			},
 		},
 	}
 }
 
-func (p *pubkeyHandler) getEnckMessage() *Message {
+// getHomoPubkey returns our homomorphic public key with the proofs bound to the session. The proofs are generated
+// once and reused in the later public key messages.
+func (p *pubkeyHandler) getHomoPubkey() []byte {
+	if p.homoPubkey == nil {
+		bs, err := p.homo.ToPubKeyBytesWithSession(p.sessionID, p.peerManager.SelfID())
+		if err != nil {
+			// The peers fail to verify the empty public key
+			log.Warn("Failed to get homomorphic public key", "err", err)
+			return nil
+		}
+		p.homoPubkey = bs
+	}
+	return p.homoPubkey
+}
+
+// getEnckMessage returns the EncK message to the peer. With Paillier, it contains the range proof of k and the proof
+// that our modulus has no small factor under the ring-Pedersen parameter of the peer.
+func (p *pubkeyHandler) getEnckMessage(peer *peer) (*Message, error) {
+	encK := p.aiMta.GetEncK()
+	var (
+		rangeProof         *paillier.RangeProofMessage
+		noSmallFactorProof *zkproof.NoSmallFactorMessage
+	)
+	if p.pedersen != nil {
+		paillierHomo := p.homo.(*paillier.Paillier)
+		var err error
+		rangeProof, err = paillierHomo.NewRangeProofMessage(p.sessionID, p.getN(), encK, peer.pubkey.pedersen)
+		if err != nil {
+			return nil, err
+		}
+		noSmallFactorProof, err = paillierHomo.NewNoSmallFactorMessage(p.sessionID, peer.pubkey.pedersen)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &Message{
-		Type: Type_EncK,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_EncK,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_EncK{
 			EncK: &BodyEncK{
-				Enck: p.aiMta.GetEncK(),
+				Enck:               encK,
+				RangeProof:         rangeProof,
+				NoSmallFactorProof: noSmallFactorProof,
 			},
 		},
-	}
+	}, nil
 }
 
 func (p *pubkeyHandler) getCurve() elliptic.Curve {
@@ -191,6 +302,11 @@ func getMessage(messsage types.Message) *Message {
 	return messsage.(*Message)
 }
 
+// blame attaches the invalid message of the sender to the error as the evidence.
+func blame(err error, msg *Message) error {
+	return message.NewBlameError(err, msg)
+}
+
 func buildWiAndPeers(curveN *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, selfId string, secret *big.Int) (*big.Int, map[string]*peer, error) {
 	lenBks := len(bks)
 	// Find self bk
@@ -224,6 +340,79 @@ func buildWiAndPeers(curveN *big.Int, bks map[string]*birkhoffinterpolation.BkPa
 	wi = new(big.Int).Mod(wi, curveN)
 	// calculate wi from share & brikhoff coefficient( wi = share * brikhoff coefficient)
 	// sigma wi for all i is private key
-	fmt.Printf("wi: %d, share: %d\n", wi, secret)
 	return wi, peers, nil
 }
+
+// selectSignerBks selects the bks of the signers from the ones of the key group, and ensures the signers are
+// authorized to sign by the threshold and their ranks. If the peer manager knows its peers, they must be the
+// signers except self.
+func selectSignerBks(curveN *big.Int, peerManager types.PeerManager, threshold uint32, keyGroupBks map[string]*birkhoffinterpolation.BkParameter, signerIDs []string) (map[string]*birkhoffinterpolation.BkParameter, error) {
+	numPeers := peerManager.NumPeers()
+	if len(signerIDs) != int(numPeers+1) {
+		log.Warn("Inconsistent peer num", "signers", len(signerIDs), "numPeers", numPeers)
+		return nil, tss.ErrInconsistentPeerNumAndBks
+	}
+	bks := make(map[string]*birkhoffinterpolation.BkParameter, len(signerIDs))
+	signerBks := make(birkhoffinterpolation.BkParameters, 0, len(signerIDs))
+	for _, id := range signerIDs {
+		if _, ok := bks[id]; ok {
+			log.Warn("Duplicate signer", "id", id)
+			return nil, ErrInconsistentSigners
+		}
+		bk, ok := keyGroupBks[id]
+		if !ok {
+			log.Warn("Signer bk not found", "id", id)
+			return nil, ErrSignerBkNotFound
+		}
+		bks[id] = bk
+		signerBks = append(signerBks, bk)
+	}
+	selfID := peerManager.SelfID()
+	if _, ok := bks[selfID]; !ok {
+		return nil, tss.ErrSelfBKNotFound
+	}
+	// The messages are only sent to and expected from the peers of the peer manager
+	if g, ok := peerManager.(types.PeerIDsGetter); ok && !isSignerPeers(selfID, bks, g.PeerIDs()) {
+		log.Warn("Inconsistent signers and peers", "signers", signerIDs, "peers", g.PeerIDs())
+		return nil, ErrInconsistentSigners
+	}
+	err := signerBks.CheckValid(threshold, curveN)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, explainSignerBks(threshold, signerBks))
+	}
+	return bks, nil
+}
+
+// isSignerPeers checks if the peers are exactly the signers except self.
+func isSignerPeers(selfID string, bks map[string]*birkhoffinterpolation.BkParameter, peerIDs []string) bool {
+	if len(peerIDs) != len(bks)-1 {
+		return false
+	}
+	seen := make(map[string]bool, len(peerIDs))
+	for _, id := range peerIDs {
+		if _, ok := bks[id]; !ok || id == selfID || seen[id] {
+			return false
+		}
+		seen[id] = true
+	}
+	return true
+}
+
+// explainSignerBks returns which requirement of the threshold the signers fail to meet. Any threshold shares
+// could recover the secret only if at least i+1 of them are of rank i or lower for every i < threshold.
+func explainSignerBks(threshold uint32, bks birkhoffinterpolation.BkParameters) string {
+	if uint32(len(bks)) < threshold {
+		return fmt.Sprintf("%d signers are required by the threshold, but %d are given", threshold, len(bks))
+	}
+	ranks := make([]int, len(bks))
+	for i, bk := range bks {
+		ranks[i] = int(bk.GetRank())
+	}
+	sort.Ints(ranks)
+	for i := 0; i < int(threshold); i++ {
+		if ranks[i] > i {
+			return fmt.Sprintf("at least %d of the signers must be of rank %d or lower, but %d are (ranks: %v)", i+1, i, i, ranks)
+		}
+	}
+	return fmt.Sprintf("the Birkhoff matrix of the signers is not invertible (ranks: %v)", ranks)
+}
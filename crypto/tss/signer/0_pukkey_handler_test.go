@@ -14,6 +14,7 @@
 package signer
 
 import (
+	"crypto/elliptic"
 	"errors"
 	"math/big"
 	"time"
@@ -21,13 +22,15 @@ import (
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/getamis/alice/crypto/birkhoffinterpolation"
 	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo"
 	"github.com/getamis/alice/crypto/homo/cl"
 	homoMocks "github.com/getamis/alice/crypto/homo/mocks"
-	"github.com/getamis/alice/crypto/matrix"
+	"github.com/getamis/alice/crypto/homo/paillier"
 	"github.com/getamis/alice/crypto/tss"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/message/types/mocks"
 	"github.com/getamis/sirius/log"
+	proto "github.com/golang/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
@@ -57,6 +60,7 @@ var _ = Describe("pubkey handler, negative cases", func() {
 				"2": birkhoffinterpolation.NewBkParameter(big.NewInt(10), 0),
 				"3": birkhoffinterpolation.NewBkParameter(big.NewInt(20), 0),
 			}
+			signerIDs  = []string{"1", "2", "3"}
 			gScale     = big.NewInt(5987)
 			expPublic  = ecpointgrouplaw.ScalarBaseMult(curve, gScale)
 			unknownErr = errors.New("unknown error")
@@ -70,30 +74,66 @@ var _ = Describe("pubkey handler, negative cases", func() {
 			mockHomo.AssertExpectations(GinkgoT())
 		})
 
-		It("inconsistent peer number and bks", func() {
+		It("inconsistent peer number and signers", func() {
 			mockPeerManager.On("NumPeers").Return(uint32(3)).Once()
-			got, err := newPubkeyHandler(expPublic, mockPeerManager, mockHomo, nil, bks, nil)
+			got, err := newPubkeyHandler(expPublic, mockPeerManager, sessionID, mockHomo, nil, 2, bks, signerIDs, nil)
 			Expect(got).Should(BeNil())
 			Expect(err).Should(Equal(tss.ErrInconsistentPeerNumAndBks))
 		})
 
-		It("failed to do homo encryption", func() {
+		It("duplicate signers", func() {
 			mockPeerManager.On("NumPeers").Return(uint32(2)).Once()
-			mockHomo.On("Encrypt", mock.Anything).Return(nil, unknownErr).Once()
-			got, err := newPubkeyHandler(expPublic, mockPeerManager, mockHomo, nil, bks, nil)
+			got, err := newPubkeyHandler(expPublic, mockPeerManager, sessionID, mockHomo, nil, 2, bks, []string{"1", "2", "1"}, nil)
 			Expect(got).Should(BeNil())
-			Expect(err).Should(Equal(unknownErr))
+			Expect(err).Should(Equal(ErrInconsistentSigners))
+		})
+
+		It("signer bk not found", func() {
+			mockPeerManager.On("NumPeers").Return(uint32(2)).Once()
+			got, err := newPubkeyHandler(expPublic, mockPeerManager, sessionID, mockHomo, nil, 2, bks, []string{"1", "2", "4"}, nil)
+			Expect(got).Should(BeNil())
+			Expect(err).Should(Equal(ErrSignerBkNotFound))
 		})
 
 		It("self id not found", func() {
 			mockPeerManager.On("NumPeers").Return(uint32(2)).Once()
-			mockHomo.On("Encrypt", mock.Anything).Return([]byte("enc k"), nil).Once()
 			mockPeerManager.On("SelfID").Return("not found").Once()
-			got, err := newPubkeyHandler(expPublic, mockPeerManager, mockHomo, nil, bks, nil)
+			got, err := newPubkeyHandler(expPublic, mockPeerManager, sessionID, mockHomo, nil, 2, bks, signerIDs, nil)
 			Expect(got).Should(BeNil())
 			Expect(err).Should(Equal(tss.ErrSelfBKNotFound))
 		})
 
+		It("inconsistent signers and peers", func() {
+			pm := newPeerManager("1", 2)
+			pm.setSigners(map[string]*Signer{"1": nil, "2": nil, "4": nil})
+			got, err := newPubkeyHandler(expPublic, pm, sessionID, mockHomo, nil, 2, bks, signerIDs, nil)
+			Expect(got).Should(BeNil())
+			Expect(err).Should(Equal(ErrInconsistentSigners))
+		})
+
+		It("not enough signers", func() {
+			mockPeerManager.On("NumPeers").Return(uint32(1)).Once()
+			mockPeerManager.On("SelfID").Return("1").Once()
+			got, err := newPubkeyHandler(expPublic, mockPeerManager, sessionID, mockHomo, nil, 3, bks, []string{"1", "2"}, nil)
+			Expect(got).Should(BeNil())
+			Expect(errors.Is(err, birkhoffinterpolation.ErrEqualOrLargerThreshold)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("3 signers are required by the threshold, but 2 are given"))
+		})
+
+		It("unauthorized ranks", func() {
+			rankBks := map[string]*birkhoffinterpolation.BkParameter{
+				"1": birkhoffinterpolation.NewBkParameter(big.NewInt(1), 0),
+				"2": birkhoffinterpolation.NewBkParameter(big.NewInt(10), 1),
+				"3": birkhoffinterpolation.NewBkParameter(big.NewInt(20), 1),
+			}
+			mockPeerManager.On("NumPeers").Return(uint32(1)).Once()
+			mockPeerManager.On("SelfID").Return("2").Once()
+			got, err := newPubkeyHandler(expPublic, mockPeerManager, sessionID, mockHomo, nil, 2, rankBks, []string{"2", "3"}, nil)
+			Expect(got).Should(BeNil())
+			Expect(errors.Is(err, birkhoffinterpolation.ErrNoValidBks)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("at least 1 of the signers must be of rank 0 or lower, but 0 are"))
+		})
+
 		It("duplicate bks", func() {
 			dupBks := map[string]*birkhoffinterpolation.BkParameter{
 				"1": birkhoffinterpolation.NewBkParameter(big.NewInt(10), 0),
@@ -101,11 +141,34 @@ var _ = Describe("pubkey handler, negative cases", func() {
 				"3": birkhoffinterpolation.NewBkParameter(big.NewInt(20), 0),
 			}
 			mockPeerManager.On("NumPeers").Return(uint32(2)).Once()
-			mockHomo.On("Encrypt", mock.Anything).Return([]byte("enc k"), nil).Once()
 			mockPeerManager.On("SelfID").Return("1").Once()
-			got, err := newPubkeyHandler(expPublic, mockPeerManager, mockHomo, nil, dupBks, nil)
+			got, err := newPubkeyHandler(expPublic, mockPeerManager, sessionID, mockHomo, nil, 2, dupBks, signerIDs, nil)
+			Expect(got).Should(BeNil())
+			Expect(errors.Is(err, birkhoffinterpolation.ErrInvalidBks)).Should(BeTrue())
+		})
+
+		It("failed to do homo encryption", func() {
+			mockPeerManager.On("NumPeers").Return(uint32(2)).Once()
+			mockPeerManager.On("SelfID").Return("1").Once()
+			mockHomo.On("Encrypt", mock.Anything).Return(nil, unknownErr).Once()
+			got, err := newPubkeyHandler(expPublic, mockPeerManager, sessionID, mockHomo, nil, 2, bks, signerIDs, nil)
 			Expect(got).Should(BeNil())
-			Expect(err).Should(Equal(matrix.ErrNotInvertableMatrix))
+			Expect(err).Should(Equal(unknownErr))
+		})
+
+		It("selects the signers from the key group", func() {
+			mockPeerManager.On("NumPeers").Return(uint32(1)).Twice()
+			mockPeerManager.On("SelfID").Return("1").Twice()
+			mockHomo.On("Encrypt", mock.Anything).Return([]byte("enc k"), nil).Once()
+			got, err := newPubkeyHandler(expPublic, mockPeerManager, sessionID, mockHomo, big.NewInt(1), 2, bks, []string{"1", "3"}, nil)
+			Expect(err).Should(BeNil())
+			Expect(got.bks).Should(Equal(map[string]*birkhoffinterpolation.BkParameter{
+				"1": bks["1"],
+				"3": bks["3"],
+			}))
+			Expect(got.peers).Should(HaveLen(1))
+			Expect(got.peers).Should(HaveKey("3"))
+			Expect(got.peerNum).Should(Equal(uint32(1)))
 		})
 	})
 
@@ -126,6 +189,37 @@ var _ = Describe("pubkey handler, negative cases", func() {
 		})
 	})
 
+	Context("setPeerPubkeys", func() {
+		BeforeEach(func() {
+			ph.peers[peerId] = newPeer(peerId)
+		})
+
+		It("public key not found", func() {
+			Expect(ph.setPeerPubkeys(map[string]homo.Pubkey{
+				"other-id": new(homoMocks.Pubkey),
+			})).Should(Equal(ErrPubkeyNotFound))
+			Expect(ph.peerPubkeys).Should(BeNil())
+		})
+
+		It("nil public key", func() {
+			Expect(ph.setPeerPubkeys(map[string]homo.Pubkey{
+				peerId: nil,
+			})).Should(Equal(ErrPubkeyNotFound))
+			Expect(ph.peerPubkeys).Should(BeNil())
+		})
+
+		It("ignores the public keys of the other peers", func() {
+			pubkey := new(homoMocks.Pubkey)
+			Expect(ph.setPeerPubkeys(map[string]homo.Pubkey{
+				peerId:     pubkey,
+				"other-id": new(homoMocks.Pubkey),
+			})).Should(BeNil())
+			Expect(ph.peerPubkeys).Should(Equal(map[string]homo.Pubkey{
+				peerId: pubkey,
+			}))
+		})
+	})
+
 	Context("HandleMessage/Finalize", func() {
 		var (
 			signers   map[string]*Signer
@@ -173,10 +267,41 @@ var _ = Describe("pubkey handler, negative cases", func() {
 				Expect(s.ph.HandleMessage(log.Discard(), invalidMsg)).ShouldNot(BeNil())
 			}
 		})
+
+		It("pubkey of another session", func() {
+			fromID := getID(0)
+			toID := getID(1)
+			pubkey, err := signers[fromID].ph.homo.ToPubKeyBytesWithSession([]byte("another session"), fromID)
+			Expect(err).Should(BeNil())
+			msg := proto.Clone(signers[fromID].GetPubkeyMessage()).(*Message)
+			msg.GetPubkey().Pubkey = pubkey
+			Expect(signers[toID].ph.HandleMessage(log.Discard(), msg)).Should(MatchError(paillier.ErrInvalidMessage))
+		})
+
+		It("blames the sender of an invalid pubkey message", func() {
+			fromID := getID(0)
+			toID := getID(1)
+			msg := proto.Clone(signers[fromID].GetPubkeyMessage()).(*Message)
+			msg.GetPubkey().Pubkey = []byte("invalid pubkey")
+			err := signers[toID].ph.HandleMessage(log.Discard(), msg)
+			expectBlame(err, msg)
+		})
 	})
 })
 
 func newTestSigners() (map[string]*Signer, map[string]*mocks.StateChangedListener) {
+	curve, expPublic, ss := getTestSignerParameters()
+	return newSigners(curve, expPublic, ss, []byte{1, 2, 3})
+}
+
+// newTestSignersWithPubkeys news the signers with the verified public keys of the peers. The tests of the handlers
+// after the Pubkey round use them to skip verifying the proofs of the Paillier keys in every test.
+func newTestSignersWithPubkeys() (map[string]*Signer, map[string]*mocks.StateChangedListener) {
+	curve, expPublic, ss := getTestSignerParameters()
+	return newSignersWithPubkeys(curve, expPublic, ss, []byte{1, 2, 3})
+}
+
+func getTestSignerParameters() (elliptic.Curve, *ecpointgrouplaw.ECPoint, [][]*big.Int) {
 	curve := btcec.S256()
 	ss := [][]*big.Int{
 		{big.NewInt(1094), big.NewInt(591493497), big.NewInt(0)},
@@ -185,5 +310,5 @@ func newTestSigners() (map[string]*Signer, map[string]*mocks.StateChangedListene
 	}
 	gScale := big.NewInt(5987)
 	expPublic := ecpointgrouplaw.ScalarBaseMult(curve, gScale)
-	return newSigners(curve, expPublic, ss, []byte{1, 2, 3})
+	return curve, expPublic, ss
 }
@@ -18,6 +18,7 @@ import (
 	"math/big"
 
 	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo/paillier"
 	"github.com/getamis/alice/crypto/mta"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/sirius/log"
@@ -82,33 +83,64 @@ func (p *encKHandler) HandleMessage(logger log.Logger, message types.Message) er
 
 	// Compute alpha and beta
 	body := msg.GetEncK()
-	encAiAlpha, aiBeta, err := p.aiMta.Compute(peer.pubkey.publicKey, body.Enck)
-	if err != nil {
-		logger.Warn("Failed to compute for ai mta", "err", err)
-		return err
-	}
-	encWiAlpha, wiBeta, err := p.wiMta.Compute(peer.pubkey.publicKey, body.Enck)
-	if err != nil {
-		logger.Warn("Failed to compute for wi mta", "err", err)
-		return err
+	var (
+		encAiAlpha, aiBeta, encWiAlpha, wiBeta *big.Int
+		aiRangeProof, wiRangeProof             *paillier.RespondentProofMessage
+		err                                    error
+	)
+	if p.pedersen == nil {
+		encAiAlpha, aiBeta, err = p.aiMta.Compute(peer.pubkey.publicKey, body.Enck)
+		if err != nil {
+			logger.Warn("Failed to compute for ai mta", "err", err)
+			return blame(err, msg)
+		}
+		encWiAlpha, wiBeta, err = p.wiMta.Compute(peer.pubkey.publicKey, body.Enck)
+		if err != nil {
+			logger.Warn("Failed to compute for wi mta", "err", err)
+			return blame(err, msg)
+		}
+	} else {
+		err = p.pedersen.VerifyNoSmallFactor(p.sessionID, peer.pubkey.publicKey, body.GetNoSmallFactorProof())
+		if err != nil {
+			logger.Warn("Failed to verify no small factor proof", "err", err)
+			return blame(err, msg)
+		}
+		err = body.GetRangeProof().Verify(p.sessionID, p.getN(), peer.pubkey.publicKey, body.Enck, p.pedersen.PedersenOpenParameter)
+		if err != nil {
+			logger.Warn("Failed to verify range proof of enck", "err", err)
+			return blame(err, msg)
+		}
+		encAiAlpha, aiBeta, aiRangeProof, err = p.aiMta.ComputeWithRangeProof(p.sessionID, p.getCurve(), peer.pubkey.publicKey, peer.pubkey.pedersen, body.Enck, false)
+		if err != nil {
+			logger.Warn("Failed to compute for ai mta", "err", err)
+			return blame(err, msg)
+		}
+		encWiAlpha, wiBeta, wiRangeProof, err = p.wiMta.ComputeWithRangeProof(p.sessionID, p.getCurve(), peer.pubkey.publicKey, peer.pubkey.pedersen, body.Enck, true)
+		if err != nil {
+			logger.Warn("Failed to compute for wi mta", "err", err)
+			return blame(err, msg)
+		}
 	}
 	wiProof, err := p.wiMta.GetProofWithCheck(p.getCurve(), wiBeta)
 	if err != nil {
 		logger.Warn("Failed to compute beta proof", "err", err)
-		return err
+		return blame(err, msg)
 	}
 
 	peer.enck = &encKData{
 		aiBeta: aiBeta,
 		wiBeta: wiBeta,
 		mtaMsg: &Message{
-			Type: Type_Mta,
-			Id:   p.peerManager.SelfID(),
+			Type:      Type_Mta,
+			Id:        p.peerManager.SelfID(),
+			SessionId: p.sessionID,
 			Body: &Message_Mta{
 				Mta: &BodyMta{
-					EncAiAlpha: encAiAlpha.Bytes(),
-					EncWiAlpha: encWiAlpha.Bytes(),
-					WiProof:    wiProof,
+					EncAiAlpha:   encAiAlpha.Bytes(),
+					EncWiAlpha:   encWiAlpha.Bytes(),
+					WiProof:      wiProof,
+					AiRangeProof: aiRangeProof,
+					WiRangeProof: wiRangeProof,
 				},
 			},
 		},
@@ -18,10 +18,13 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/getamis/alice/crypto/homo/paillier"
 	mtaMocks "github.com/getamis/alice/crypto/mta/mocks"
 	"github.com/getamis/alice/crypto/tss"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/alice/crypto/zkproof"
 	"github.com/getamis/sirius/log"
 	proto "github.com/golang/protobuf/proto"
 	. "github.com/onsi/ginkgo"
@@ -37,7 +40,7 @@ var _ = Describe("enck handler, negative cases", func() {
 		listeners map[string]*mocks.StateChangedListener
 	)
 	BeforeEach(func() {
-		signers, listeners = newTestSigners()
+		signers, listeners = newTestSignersWithPubkeys()
 		// Override peer manager
 		for _, s := range signers {
 			p := newStopPeerManager(Type_EncK, s.ph.peerManager)
@@ -56,10 +59,10 @@ var _ = Describe("enck handler, negative cases", func() {
 		}
 		// Wait dkgs to handle decommit messages
 		for _, s := range signers {
-			_, ok := s.GetHandler().(*encKHandler)
-			if !ok {
-				time.Sleep(500 * time.Millisecond)
-			}
+			Eventually(func() bool {
+				_, ok := s.GetHandler().(*encKHandler)
+				return ok
+			}, 10*time.Second).Should(BeTrue())
 		}
 	})
 
@@ -136,12 +139,15 @@ var _ = Describe("enck handler, negative cases", func() {
 			fromS := signers[fromId]
 			fromH, ok = fromS.GetHandler().(*encKHandler)
 			Expect(ok).Should(BeTrue())
-			msg = fromH.getEnckMessage()
 
 			toId := getID(0)
 			toS := signers[toId]
 			toH, ok = toS.GetHandler().(*encKHandler)
 			Expect(ok).Should(BeTrue())
+
+			var err error
+			msg, err = fromH.getEnckMessage(fromH.peers[toId])
+			Expect(err).Should(BeNil())
 		})
 
 		AfterEach(func() {
@@ -158,25 +164,68 @@ var _ = Describe("enck handler, negative cases", func() {
 
 		It("failed to compute ai mta", func() {
 			toH.aiMta = mockMta
-			mockMta.On("Compute", toH.peers[fromId].pubkey.publicKey, msg.GetEncK().GetEnck()).Return(nil, nil, unknownErr).Once()
+			fromPeer := toH.peers[fromId]
+			mockMta.On("ComputeWithRangeProof", toH.sessionID, toH.getCurve(), fromPeer.pubkey.publicKey, fromPeer.pubkey.pedersen, msg.GetEncK().GetEnck(), false).Return(nil, nil, nil, unknownErr).Once()
 			err := toH.HandleMessage(log.Discard(), msg)
-			Expect(err).Should(Equal(unknownErr))
+			Expect(err).Should(MatchError(unknownErr))
 		})
 
 		It("failed to compute wi mta", func() {
 			toH.wiMta = mockMta
-			mockMta.On("Compute", toH.peers[fromId].pubkey.publicKey, msg.GetEncK().GetEnck()).Return(nil, nil, unknownErr).Once()
+			fromPeer := toH.peers[fromId]
+			mockMta.On("ComputeWithRangeProof", toH.sessionID, toH.getCurve(), fromPeer.pubkey.publicKey, fromPeer.pubkey.pedersen, msg.GetEncK().GetEnck(), true).Return(nil, nil, nil, unknownErr).Once()
 			err := toH.HandleMessage(log.Discard(), msg)
-			Expect(err).Should(Equal(unknownErr))
+			Expect(err).Should(MatchError(unknownErr))
 		})
 
 		It("failed to compute wi GetProofWithCheck", func() {
 			toH.wiMta = mockMta
 			wiBeta := big.NewInt(101)
-			mockMta.On("Compute", toH.peers[fromId].pubkey.publicKey, msg.GetEncK().GetEnck()).Return(big.NewInt(100), wiBeta, nil).Once()
+			fromPeer := toH.peers[fromId]
+			mockMta.On("ComputeWithRangeProof", toH.sessionID, toH.getCurve(), fromPeer.pubkey.publicKey, fromPeer.pubkey.pedersen, msg.GetEncK().GetEnck(), true).Return(big.NewInt(100), wiBeta, nil, nil).Once()
 			mockMta.On("GetProofWithCheck", toH.getCurve(), wiBeta).Return(nil, unknownErr).Once()
 			err := toH.HandleMessage(log.Discard(), msg)
-			Expect(err).Should(Equal(unknownErr))
+			Expect(err).Should(MatchError(unknownErr))
+		})
+
+		It("missing no small factor proof", func() {
+			msg.GetEncK().NoSmallFactorProof = nil
+			err := toH.HandleMessage(log.Discard(), msg)
+			Expect(err).Should(MatchError(utils.ErrNotInRange))
+		})
+
+		It("no small factor proof of another session", func() {
+			proof, err := fromH.homo.(*paillier.Paillier).NewNoSmallFactorMessage([]byte("another session id"), fromH.peers[getID(0)].pubkey.pedersen)
+			Expect(err).Should(BeNil())
+			msg.GetEncK().NoSmallFactorProof = proof
+			err = toH.HandleMessage(log.Discard(), msg)
+			Expect(err).Should(MatchError(zkproof.ErrVerifyFailure))
+		})
+
+		It("missing range proof", func() {
+			msg.GetEncK().RangeProof = nil
+			err := toH.HandleMessage(log.Discard(), msg)
+			Expect(err).Should(MatchError(utils.ErrNotInRange))
+		})
+
+		It("out-of-range k", func() {
+			// The range proof of an out-of-range k cannot be verified
+			fromHomo := fromH.homo.(*paillier.Paillier)
+			k := new(big.Int).Exp(fromH.getN(), big.NewInt(4), nil)
+			encK, err := fromHomo.Encrypt(k.Bytes())
+			Expect(err).Should(BeNil())
+			rangeProof, err := fromHomo.NewRangeProofMessage(fromH.sessionID, fromH.getN(), encK, fromH.peers[getID(0)].pubkey.pedersen)
+			Expect(err).Should(BeNil())
+			msg.GetEncK().Enck = encK
+			msg.GetEncK().RangeProof = rangeProof
+			err = toH.HandleMessage(log.Discard(), msg)
+			Expect(err).Should(MatchError(utils.ErrNotInRange))
+		})
+
+		It("blames the sender of an invalid range proof", func() {
+			msg.GetEncK().RangeProof = nil
+			err := toH.HandleMessage(log.Discard(), msg)
+			expectBlame(err, msg)
 		})
 	})
 })
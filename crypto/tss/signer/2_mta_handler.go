@@ -18,9 +18,11 @@ import (
 	"errors"
 	"math/big"
 
+	"github.com/getamis/alice/crypto/commitment"
 	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/mta"
 	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/utils"
 	"github.com/getamis/sirius/log"
 )
 
@@ -29,6 +31,11 @@ var (
 	ErrUnexpectedPublickey = errors.New("unexpected public key")
 )
 
+const (
+	// hiddingPointSeed is the seed to derive the hidding point of the Pedersen commitment of sigma_i
+	hiddingPointSeed = "github.com/getamis/alice/crypto/tss/signer"
+)
+
 type mtaData struct {
 	aiAlpha *big.Int
 	wiAlpha *big.Int
@@ -40,6 +47,11 @@ type mtaHandler struct {
 
 	deltaI *big.Int
 	tmpSi  *big.Int
+	// The presigner commits sigma_i by sigmaICommitment = sigma_i*G + sigmaIBlinding*H, where H is the hidding
+	// point. It proves sigma_i*R with the commitment later.
+	hiddingPoint     *pt.ECPoint
+	sigmaIBlinding   *big.Int
+	sigmaICommitment *pt.EcPointMessage
 }
 
 func newMtaHandler(p *encKHandler) (*mtaHandler, error) {
@@ -78,17 +90,31 @@ func (p *mtaHandler) HandleMessage(logger log.Logger, message types.Message) err
 	aiAlpha, err := p.aiMta.Decrypt(new(big.Int).SetBytes(body.EncAiAlpha))
 	if err != nil {
 		logger.Warn("Failed to decrypt EncAiAlpha", "err", err)
-		return err
+		return blame(err, msg)
 	}
 	wiAlpha, err := p.wiMta.Decrypt(new(big.Int).SetBytes(body.EncWiAlpha))
 	if err != nil {
 		logger.Warn("Failed to decrypt EncWiAlpha", "err", err)
-		return err
+		return blame(err, msg)
 	}
 	wiG, err := p.wiMta.VerifyProofWithCheck(body.WiProof, p.getCurve(), wiAlpha)
 	if err != nil {
 		logger.Warn("Failed to verify wi beta proof", "err", err)
-		return err
+		return blame(err, msg)
+	}
+	if p.pedersen != nil {
+		pubkey := p.homo.GetPubKey()
+		encK := p.aiMta.GetEncK()
+		err = body.GetAiRangeProof().Verify(p.sessionID, p.getCurve(), pubkey, p.pedersen.PedersenOpenParameter, encK, body.EncAiAlpha, nil)
+		if err != nil {
+			logger.Warn("Failed to verify ai range proof", "err", err)
+			return blame(err, msg)
+		}
+		err = body.GetWiRangeProof().Verify(p.sessionID, p.getCurve(), pubkey, p.pedersen.PedersenOpenParameter, encK, body.EncWiAlpha, wiG)
+		if err != nil {
+			logger.Warn("Failed to verify wi range proof", "err", err)
+			return blame(err, msg)
+		}
 	}
 	peer.mta = &mtaData{
 		aiAlpha: aiAlpha,
@@ -107,6 +133,13 @@ func (p *mtaHandler) Finalize(logger log.Logger) (types.Handler, error) {
 	if err != nil {
 		return nil, err
 	}
+	if p.presign {
+		err = p.buildSigmaICommitment()
+		if err != nil {
+			logger.Warn("Failed to build sigma i commitment", "err", err)
+			return nil, err
+		}
+	}
 	// Send out delta message
 	msg := p.getDeltaMessage()
 	p.broadcast(msg)
@@ -115,16 +148,37 @@ func (p *mtaHandler) Finalize(logger log.Logger) (types.Handler, error) {
 
 func (p *mtaHandler) getDeltaMessage() *Message {
 	return &Message{
-		Type: Type_Delta,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_Delta,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_Delta{
 			Delta: &BodyDelta{
-				Delta: p.deltaI.Bytes(),
+				Delta:            p.deltaI.Bytes(),
+				SigmaICommitment: p.sigmaICommitment,
 			},
 		},
 	}
 }
 
+func (p *mtaHandler) buildSigmaICommitment() error {
+	curve := p.getCurve()
+	var err error
+	p.hiddingPoint, err = commitment.NewHiddingPoint(curve, []byte(hiddingPointSeed))
+	if err != nil {
+		return err
+	}
+	p.sigmaIBlinding, err = utils.RandomInt(p.getN())
+	if err != nil {
+		return err
+	}
+	sigmaICommitment, err := pt.ScalarBaseMult(curve, p.tmpSi).Add(p.hiddingPoint.ScalarMult(p.sigmaIBlinding))
+	if err != nil {
+		return err
+	}
+	p.sigmaICommitment, err = sigmaICommitment.ToEcPointMessage()
+	return err
+}
+
 // Expect the sum of wg is the expected public key
 // calculated in same way as in dkg
 func (p *mtaHandler) ensurePublickey(logger log.Logger) error {
@@ -25,7 +25,10 @@ import (
 	"github.com/getamis/alice/crypto/tss"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/getamis/alice/crypto/zkproof"
 	"github.com/getamis/sirius/log"
+	"github.com/golang/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
@@ -41,7 +44,7 @@ var _ = Describe("mta handler, negative cases", func() {
 		listeners map[string]*mocks.StateChangedListener
 	)
 	BeforeEach(func() {
-		signers, listeners = newTestSigners()
+		signers, listeners = newTestSignersWithPubkeys()
 		// Override peer manager
 		for _, s := range signers {
 			p := newStopPeerManager(Type_Mta, s.ph.peerManager)
@@ -60,10 +63,10 @@ var _ = Describe("mta handler, negative cases", func() {
 		}
 		// Wait dkgs to handle decommit messages
 		for _, s := range signers {
-			_, ok := s.GetHandler().(*mtaHandler)
-			if !ok {
-				time.Sleep(500 * time.Millisecond)
-			}
+			Eventually(func() bool {
+				_, ok := s.GetHandler().(*mtaHandler)
+				return ok
+			}, 10*time.Second).Should(BeTrue())
 		}
 
 		mockMta = new(mtaMocks.Mta)
@@ -144,7 +147,7 @@ var _ = Describe("mta handler, negative cases", func() {
 			msg := fromH.peers[toId].enck.mtaMsg
 			mockMta.On("Decrypt", new(big.Int).SetBytes(msg.GetMta().EncAiAlpha)).Return(nil, unknownErr).Once()
 			err := toH.HandleMessage(log.Discard(), msg)
-			Expect(err).Should(Equal(unknownErr))
+			Expect(err).Should(MatchError(unknownErr))
 		})
 
 		It("failed to decrypt wi mta", func() {
@@ -152,7 +155,7 @@ var _ = Describe("mta handler, negative cases", func() {
 			msg := fromH.peers[toId].enck.mtaMsg
 			mockMta.On("Decrypt", new(big.Int).SetBytes(msg.GetMta().EncWiAlpha)).Return(nil, unknownErr).Once()
 			err := toH.HandleMessage(log.Discard(), msg)
-			Expect(err).Should(Equal(unknownErr))
+			Expect(err).Should(MatchError(unknownErr))
 		})
 
 		It("failed to decrypt wi verify check", func() {
@@ -162,7 +165,28 @@ var _ = Describe("mta handler, negative cases", func() {
 			mockMta.On("Decrypt", new(big.Int).SetBytes(msg.GetMta().EncWiAlpha)).Return(wiAlpha, nil).Once()
 			mockMta.On("VerifyProofWithCheck", msg.GetMta().WiProof, toH.getCurve(), wiAlpha).Return(nil, unknownErr).Once()
 			err := toH.HandleMessage(log.Discard(), msg)
-			Expect(err).Should(Equal(unknownErr))
+			Expect(err).Should(MatchError(unknownErr))
+		})
+
+		It("invalid ai range proof", func() {
+			msg := proto.Clone(fromH.peers[toId].enck.mtaMsg).(*Message)
+			msg.GetMta().AiRangeProof = msg.GetMta().WiRangeProof
+			err := toH.HandleMessage(log.Discard(), msg)
+			Expect(err).Should(MatchError(zkproof.ErrVerifyFailure))
+		})
+
+		It("missing wi range proof", func() {
+			msg := proto.Clone(fromH.peers[toId].enck.mtaMsg).(*Message)
+			msg.GetMta().WiRangeProof = nil
+			err := toH.HandleMessage(log.Discard(), msg)
+			Expect(err).Should(MatchError(utils.ErrNotInRange))
+		})
+
+		It("blames the sender of an invalid range proof", func() {
+			msg := proto.Clone(fromH.peers[toId].enck.mtaMsg).(*Message)
+			msg.GetMta().AiRangeProof = msg.GetMta().WiRangeProof
+			err := toH.HandleMessage(log.Discard(), msg)
+			expectBlame(err, msg)
 		})
 	})
 
@@ -189,14 +213,14 @@ var _ = Describe("mta handler, negative cases", func() {
 			toH.wiG = pt.NewBase(elliptic.P256())
 			got, err := toH.Finalize(log.Discard())
 			Expect(got).Should(BeNil())
-			Expect(err).Should(Equal(pt.ErrDifferentCurve))
+			Expect(err).Should(MatchError(pt.ErrDifferentCurve))
 		})
 
 		It("unexpected public key", func() {
 			toH.wiG = pt.NewBase(btcec.S256())
 			got, err := toH.Finalize(log.Discard())
 			Expect(got).Should(BeNil())
-			Expect(err).Should(Equal(ErrUnexpectedPublickey))
+			Expect(err).Should(MatchError(ErrUnexpectedPublickey))
 		})
 
 		It("failed to get ai mta GetResult", func() {
@@ -204,7 +228,7 @@ var _ = Describe("mta handler, negative cases", func() {
 			mockMta.On("GetResult", mock.Anything, mock.Anything).Return(nil, unknownErr).Once()
 			got, err := toH.Finalize(log.Discard())
 			Expect(got).Should(BeNil())
-			Expect(err).Should(Equal(unknownErr))
+			Expect(err).Should(MatchError(unknownErr))
 		})
 
 		It("failed to get wi mta GetResult", func() {
@@ -212,7 +236,7 @@ var _ = Describe("mta handler, negative cases", func() {
 			mockMta.On("GetResult", mock.Anything, mock.Anything).Return(nil, unknownErr).Once()
 			got, err := toH.Finalize(log.Discard())
 			Expect(got).Should(BeNil())
-			Expect(err).Should(Equal(unknownErr))
+			Expect(err).Should(MatchError(unknownErr))
 		})
 	})
 })
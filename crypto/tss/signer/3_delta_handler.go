@@ -17,12 +17,15 @@ package signer
 import (
 	"math/big"
 
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/sirius/log"
 )
 
 type deltaData struct {
 	delta *big.Int
+	// sigmaICommitment is the Pedersen commitment of sigma_i, which is given only in presigning
+	sigmaICommitment *pt.ECPoint
 }
 
 type deltaHandler struct {
@@ -64,8 +67,22 @@ func (p *deltaHandler) HandleMessage(logger log.Logger, message types.Message) e
 		return ErrPeerNotFound
 	}
 
+	var sigmaICommitment *pt.ECPoint
+	if p.presign {
+		var err error
+		sigmaICommitment, err = body.GetSigmaICommitment().ToPoint()
+		if err != nil {
+			logger.Warn("Failed to get sigma i commitment", "err", err)
+			return blame(err, msg)
+		}
+		if !sigmaICommitment.IsSameCurve(p.publicKey) {
+			logger.Warn("Different curve of sigma i commitment")
+			return blame(pt.ErrDifferentCurve, msg)
+		}
+	}
 	peer.delta = &deltaData{
-		delta: new(big.Int).SetBytes(body.GetDelta()),
+		delta:            new(big.Int).SetBytes(body.GetDelta()),
+		sigmaICommitment: sigmaICommitment,
 	}
 	return peer.AddMessage(msg)
 }
@@ -88,13 +105,14 @@ func (p *deltaHandler) Finalize(logger log.Logger) (types.Handler, error) {
 }
 
 func (p *mtaHandler) getProofAiMessage() (*Message, error) {
-	aProofMsg, err := p.aiMta.GetAProof(p.getCurve())
+	aProofMsg, err := p.aiMta.GetAProof(p.sessionID, p.getCurve())
 	if err != nil {
 		return nil, err
 	}
 	return &Message{
-		Type: Type_ProofAi,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_ProofAi,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_ProofAi{
 			ProofAi: &BodyProofAi{
 				AgDecommitment: p.agCommitmenter.GetDecommitmentMessage(),
@@ -17,6 +17,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
 	mtaMocks "github.com/getamis/alice/crypto/mta/mocks"
 	"github.com/getamis/alice/crypto/tss"
 	"github.com/getamis/alice/crypto/tss/message/types"
@@ -36,7 +37,7 @@ var _ = Describe("delta handler, negative cases", func() {
 		listeners map[string]*mocks.StateChangedListener
 	)
 	BeforeEach(func() {
-		signers, listeners = newTestSigners()
+		signers, listeners = newTestSignersWithPubkeys()
 		// Override peer manager
 		for _, s := range signers {
 			p := newStopPeerManager(Type_Delta, s.ph.peerManager)
@@ -55,10 +56,10 @@ var _ = Describe("delta handler, negative cases", func() {
 		}
 		// Wait dkgs to handle decommit messages
 		for _, s := range signers {
-			_, ok := s.GetHandler().(*deltaHandler)
-			if !ok {
-				time.Sleep(500 * time.Millisecond)
-			}
+			Eventually(func() bool {
+				_, ok := s.GetHandler().(*deltaHandler)
+				return ok
+			}, 10*time.Second).Should(BeTrue())
 		}
 
 		mockMta = new(mtaMocks.Mta)
@@ -118,6 +119,20 @@ var _ = Describe("delta handler, negative cases", func() {
 				Expect(s.GetHandler().HandleMessage(log.Discard(), msg)).Should(Equal(tss.ErrPeerNotFound))
 			}
 		})
+		It("blames the sender of an invalid sigma i commitment", func() {
+			toH, ok := signers[getID(0)].GetHandler().(*deltaHandler)
+			Expect(ok).Should(BeTrue())
+			fromH, ok := signers[getID(1)].GetHandler().(*deltaHandler)
+			Expect(ok).Should(BeTrue())
+
+			// The presigning peers must send the commitments of sigma i
+			toH.presign = true
+			msg := fromH.getDeltaMessage()
+			msg.GetDelta().SigmaICommitment = nil
+			err := toH.HandleMessage(log.Discard(), msg)
+			Expect(err).Should(MatchError(ecpointgrouplaw.ErrInvalidPoint))
+			expectBlame(err, msg)
+		})
 	})
 
 	Context("Finalize", func() {
@@ -137,7 +152,7 @@ var _ = Describe("delta handler, negative cases", func() {
 			}
 
 			toH.aiMta = mockMta
-			mockMta.On("GetAProof", toH.getCurve()).Return(nil, unknownErr).Once()
+			mockMta.On("GetAProof", toH.sessionID, toH.getCurve()).Return(nil, unknownErr).Once()
 			got, err := toH.Finalize(log.Discard())
 			Expect(got).Should(BeNil())
 			Expect(err).Should(Equal(unknownErr))
@@ -17,7 +17,6 @@ package signer
 import (
 	"crypto/elliptic"
 	"errors"
-	fmt "fmt"
 	"math/big"
 
 	"github.com/getamis/alice/crypto/commitment"
@@ -52,6 +51,11 @@ type proofAiHandler struct {
 	ai             *pt.ECPoint
 	aiCommitmenter *commitment.HashCommitmenter
 	si             *big.Int
+	// kiR and sigmaIR are committed with Vi and Ai, so the peers could check si if the signature is invalid
+	kiR                 *pt.ECPoint
+	kiRCommitmenter     *commitment.HashCommitmenter
+	sigmaIR             *pt.ECPoint
+	sigmaIRCommitmenter *commitment.HashCommitmenter
 }
 
 func newproofAiHandler(p *deltaHandler) (*proofAiHandler, error) {
@@ -89,16 +93,16 @@ func (p *proofAiHandler) HandleMessage(logger log.Logger, message types.Message)
 	}
 
 	// Verify ag decommit message
-	agPoint, err := tss.GetPointFromHashCommitment(logger, peer.pubkey.aigCommit, body.GetAgDecommitment())
+	agPoint, err := tss.GetPointFromHashCommitment(logger, p.sessionID, peer.pubkey.aigCommit, body.GetAgDecommitment())
 	if err != nil {
-		return err
+		return blame(err, msg)
 	}
 
 	// Verify ag schnorr proof
-	err = body.GetAiProof().Verify(p.g)
+	err = body.GetAiProof().VerifyWithSession(p.sessionID, p.g)
 	if err != nil {
 		logger.Warn("Failed to verify aig schnorr proof", "err", err)
-		return err
+		return blame(err, msg)
 	}
 
 	peer.proofAi = &proofAiData{
@@ -122,17 +126,35 @@ func (p *proofAiHandler) Finalize(logger log.Logger) (types.Handler, error) {
 		logger.Warn("R is an identity element")
 		return nil, ErrIndentityR
 	}
-	fmt.Printf("Rx: %d\n", p.r.GetX())
+	// The presigner proves k_i*R and sigma_i*R. The rest depends on the message.
+	if p.presign {
+		err = p.sendKiRSigmaIR(logger)
+		if err != nil {
+			return nil, err
+		}
+		return newKiRSigmaIRHandler(p)
+	}
 
 	p.si = buildSi(p.aiMta, p.getN(), p.r.GetX(), p.tmpSi, new(big.Int).SetBytes(p.msg))
 
-	fmt.Printf("Si in 4: %d\n", p.si)
-	p.li, p.vi, p.liProof, p.viCommitmenter, err = buildViCommitter(logger, p.si, p.r)
+	p.li, p.vi, p.liProof, p.viCommitmenter, err = buildViCommitter(logger, p.sessionID, p.si, p.r)
+	if err != nil {
+		return nil, err
+	}
+	p.kiR = p.r.ScalarMult(p.aiMta.GetProductWithK(big.NewInt(1)))
+	p.kiRCommitmenter, err = tss.NewCommitterByPoint(p.sessionID, p.kiR)
+	if err != nil {
+		logger.Warn("Failed to new kiRCommitmenter", "err", err)
+		return nil, err
+	}
+	p.sigmaIR = p.r.ScalarMult(p.tmpSi)
+	p.sigmaIRCommitmenter, err = tss.NewCommitterByPoint(p.sessionID, p.sigmaIR)
 	if err != nil {
+		logger.Warn("Failed to new sigmaIRCommitmenter", "err", err)
 		return nil, err
 	}
 
-	p.rhoI, p.ai, p.rhoIProof, p.aiCommitmenter, err = buildAiCommitter(logger, p.getCurve())
+	p.rhoI, p.ai, p.rhoIProof, p.aiCommitmenter, err = buildAiCommitter(logger, p.sessionID, p.getCurve())
 	if err != nil {
 		return nil, err
 	}
@@ -145,12 +167,15 @@ func (p *proofAiHandler) Finalize(logger log.Logger) (types.Handler, error) {
 
 func (p *proofAiHandler) getCommitAiViMessage() *Message {
 	return &Message{
-		Type: Type_CommitViAi,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_CommitViAi,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_CommitViAi{
 			CommitViAi: &BodyCommitViAi{
-				ViCommitment: p.viCommitmenter.GetCommitmentMessage(),
-				AiCommitment: p.aiCommitmenter.GetCommitmentMessage(),
+				ViCommitment:      p.viCommitmenter.GetCommitmentMessage(),
+				AiCommitment:      p.aiCommitmenter.GetCommitmentMessage(),
+				KiRCommitment:     p.kiRCommitmenter.GetCommitmentMessage(),
+				SigmaIRCommitment: p.sigmaIRCommitmenter.GetCommitmentMessage(),
 			},
 		},
 	}
@@ -158,14 +183,17 @@ func (p *proofAiHandler) getCommitAiViMessage() *Message {
 
 func (p *proofAiHandler) getDecommitAiViMessage() *Message {
 	return &Message{
-		Type: Type_DecommitViAi,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_DecommitViAi,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_DecommitViAi{
 			DecommitViAi: &BodyDecommitViAi{
-				ViDecommitment: p.viCommitmenter.GetDecommitmentMessage(),
-				AiDecommitment: p.aiCommitmenter.GetDecommitmentMessage(),
-				RhoIProof:      p.rhoIProof,
-				LiProof:        p.liProof,
+				ViDecommitment:      p.viCommitmenter.GetDecommitmentMessage(),
+				AiDecommitment:      p.aiCommitmenter.GetDecommitmentMessage(),
+				RhoIProof:           p.rhoIProof,
+				LiProof:             p.liProof,
+				KiRDecommitment:     p.kiRCommitmenter.GetDecommitmentMessage(),
+				SigmaIRDecommitment: p.sigmaIRCommitmenter.GetDecommitmentMessage(),
 			},
 		},
 	}
@@ -179,7 +207,7 @@ func buildSi(aiMta mta.Mta, n *big.Int, rx *big.Int, tmpSi *big.Int, msg *big.In
 	return new(big.Int).Mod(r, n)
 }
 
-func buildViCommitter(logger log.Logger, si *big.Int, r *pt.ECPoint) (*big.Int, *pt.ECPoint, *zkproof.SchnorrProofMessage, *commitment.HashCommitmenter, error) {
+func buildViCommitter(logger log.Logger, sessionID []byte, si *big.Int, r *pt.ECPoint) (*big.Int, *pt.ECPoint, *zkproof.SchnorrProofMessage, *commitment.HashCommitmenter, error) {
 	curve := r.GetCurve()
 	n := curve.Params().N
 	li, err := utils.RandomInt(n)
@@ -187,7 +215,7 @@ func buildViCommitter(logger log.Logger, si *big.Int, r *pt.ECPoint) (*big.Int,
 		logger.Warn("Failed to random li", "err", err)
 		return nil, nil, nil, nil, err
 	}
-	proofLi, err := zkproof.NewSchorrMessage(si, li, r)
+	proofLi, err := zkproof.NewSchorrMessageWithSession(sessionID, si, li, r)
 	if err != nil {
 		logger.Warn("Failed to proof li", "err", err)
 		return nil, nil, nil, nil, err
@@ -201,7 +229,7 @@ func buildViCommitter(logger log.Logger, si *big.Int, r *pt.ECPoint) (*big.Int,
 		logger.Warn("Failed to add siR and liG", "err", err)
 		return nil, nil, nil, nil, err
 	}
-	viCommitmenter, err := tss.NewCommitterByPoint(Vi)
+	viCommitmenter, err := tss.NewCommitterByPoint(sessionID, Vi)
 	if err != nil {
 		logger.Warn("Failed to new viCommitmenter", "err", err)
 		return nil, nil, nil, nil, err
@@ -209,21 +237,21 @@ func buildViCommitter(logger log.Logger, si *big.Int, r *pt.ECPoint) (*big.Int,
 	return li, Vi, proofLi, viCommitmenter, nil
 }
 
-func buildAiCommitter(logger log.Logger, curve elliptic.Curve) (*big.Int, *pt.ECPoint, *zkproof.SchnorrProofMessage, *commitment.HashCommitmenter, error) {
+func buildAiCommitter(logger log.Logger, sessionID []byte, curve elliptic.Curve) (*big.Int, *pt.ECPoint, *zkproof.SchnorrProofMessage, *commitment.HashCommitmenter, error) {
 	n := curve.Params().N
 	rhoI, err := utils.RandomInt(n)
 	if err != nil {
 		logger.Warn("Failed to random rho i", "err", err)
 		return nil, nil, nil, nil, err
 	}
-	proofRhoI, err := zkproof.NewBaseSchorrMessage(curve, rhoI)
+	proofRhoI, err := zkproof.NewBaseSchorrMessageWithSession(sessionID, curve, rhoI)
 	if err != nil {
 		logger.Warn("Failed to proof rho i", "err", err)
 		return nil, nil, nil, nil, err
 	}
 
 	Ai := pt.ScalarBaseMult(curve, rhoI)
-	aiCommitmenter, err := tss.NewCommitterByPoint(Ai)
+	aiCommitmenter, err := tss.NewCommitterByPoint(sessionID, Ai)
 	if err != nil {
 		logger.Warn("Failed to new aiCommitmenter", "err", err)
 		return nil, nil, nil, nil, err
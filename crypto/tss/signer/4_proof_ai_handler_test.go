@@ -39,7 +39,7 @@ var _ = Describe("proof ai handler, negative cases", func() {
 		listeners map[string]*mocks.StateChangedListener
 	)
 	BeforeEach(func() {
-		signers, listeners = newTestSigners()
+		signers, listeners = newTestSignersWithPubkeys()
 		// Override peer manager
 		for _, s := range signers {
 			p := newStopPeerManager(Type_ProofAi, s.ph.peerManager)
@@ -58,10 +58,10 @@ var _ = Describe("proof ai handler, negative cases", func() {
 		}
 		// Wait dkgs to handle decommit messages
 		for _, s := range signers {
-			_, ok := s.GetHandler().(*proofAiHandler)
-			if !ok {
-				time.Sleep(500 * time.Millisecond)
-			}
+			Eventually(func() bool {
+				_, ok := s.GetHandler().(*proofAiHandler)
+				return ok
+			}, 10*time.Second).Should(BeTrue())
 		}
 	})
 
@@ -148,7 +148,7 @@ var _ = Describe("proof ai handler, negative cases", func() {
 					},
 				},
 			}
-			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(Equal(commitment.ErrDifferentDigest))
+			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(MatchError(commitment.ErrDifferentDigest))
 		})
 
 		It("failed to get ag point (different digest)", func() {
@@ -162,7 +162,13 @@ var _ = Describe("proof ai handler, negative cases", func() {
 					},
 				},
 			}
-			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(Equal(pt.ErrInvalidPoint))
+			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(MatchError(pt.ErrInvalidPoint))
+		})
+
+		It("blames the sender of an invalid ai proof", func() {
+			msg.GetProofAi().AiProof = &zkproof.SchnorrProofMessage{}
+			err := toH.HandleMessage(log.Discard(), msg)
+			expectBlame(err, msg)
 		})
 	})
 
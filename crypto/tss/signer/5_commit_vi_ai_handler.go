@@ -21,8 +21,10 @@ import (
 )
 
 type commitViAiData struct {
-	viCommitment *commitment.HashCommitmentMessage
-	aiCommitment *commitment.HashCommitmentMessage
+	viCommitment      *commitment.HashCommitmentMessage
+	aiCommitment      *commitment.HashCommitmentMessage
+	kiRCommitment     *commitment.HashCommitmentMessage
+	sigmaIRCommitment *commitment.HashCommitmentMessage
 }
 
 type commitViAiHandler struct {
@@ -63,8 +65,10 @@ func (p *commitViAiHandler) HandleMessage(logger log.Logger, message types.Messa
 
 	body := msg.GetCommitViAi()
 	peer.commitViAi = &commitViAiData{
-		viCommitment: body.ViCommitment,
-		aiCommitment: body.AiCommitment,
+		viCommitment:      body.ViCommitment,
+		aiCommitment:      body.AiCommitment,
+		kiRCommitment:     body.KiRCommitment,
+		sigmaIRCommitment: body.SigmaIRCommitment,
 	}
 	return peer.AddMessage(msg)
 }
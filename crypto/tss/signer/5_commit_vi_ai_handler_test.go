@@ -32,7 +32,7 @@ var _ = Describe("commit vi ai handler, negative cases", func() {
 		listeners map[string]*mocks.StateChangedListener
 	)
 	BeforeEach(func() {
-		signers, listeners = newTestSigners()
+		signers, listeners = newTestSignersWithPubkeys()
 		// Override peer manager
 		for _, s := range signers {
 			p := newStopPeerManager(Type_CommitViAi, s.ph.peerManager)
@@ -51,10 +51,10 @@ var _ = Describe("commit vi ai handler, negative cases", func() {
 		}
 		// Wait dkgs to handle decommit messages
 		for _, s := range signers {
-			_, ok := s.GetHandler().(*commitViAiHandler)
-			if !ok {
-				time.Sleep(500 * time.Millisecond)
-			}
+			Eventually(func() bool {
+				_, ok := s.GetHandler().(*commitViAiHandler)
+				return ok
+			}, 10*time.Second).Should(BeTrue())
 		}
 	})
 
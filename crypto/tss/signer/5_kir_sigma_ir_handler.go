@@ -0,0 +1,197 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"errors"
+	"math/big"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo/paillier"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/zkproof"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrInconsistentKiR is returned if the sum of k_i*R is not the base point
+	ErrInconsistentKiR = errors.New("inconsistent kiR")
+	// ErrInconsistentSigmaIR is returned if the sum of sigma_i*R is not the public key
+	ErrInconsistentSigmaIR = errors.New("inconsistent sigmaIR")
+)
+
+type kiRSigmaIRData struct {
+	kiR     *pt.ECPoint
+	sigmaIR *pt.ECPoint
+}
+
+// kiRSigmaIRHandler collects k_i*R and sigma_i*R of the presigners. Once the sum of k_i*R is G and the sum of
+// sigma_i*R is Q, s*R = m*G + r*Q holds if s_i*R = m*k_i*R + r*sigma_i*R for all the s_i. So the online signer
+// could check the s_i of each peer, and the signature is valid if all the s_i are valid.
+type kiRSigmaIRHandler struct {
+	*proofAiHandler
+}
+
+func newKiRSigmaIRHandler(p *proofAiHandler) (*kiRSigmaIRHandler, error) {
+	return &kiRSigmaIRHandler{
+		proofAiHandler: p,
+	}, nil
+}
+
+func (p *kiRSigmaIRHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_KiRSigmaIR)
+}
+
+func (p *kiRSigmaIRHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *kiRSigmaIRHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.kiRSigmaIR != nil
+}
+
+func (p *kiRSigmaIRHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return ErrPeerNotFound
+	}
+
+	body := msg.GetKiRSigmaIR()
+	kiR, err := body.GetKiR().ToPoint()
+	if err != nil {
+		logger.Warn("Failed to get kiR", "err", err)
+		return blame(err, msg)
+	}
+	sigmaIR, err := body.GetSigmaIR().ToPoint()
+	if err != nil {
+		logger.Warn("Failed to get sigmaIR", "err", err)
+		return blame(err, msg)
+	}
+	if !kiR.IsSameCurve(p.publicKey) || !sigmaIR.IsSameCurve(p.publicKey) {
+		logger.Warn("Different curves of kiR or sigmaIR")
+		return blame(pt.ErrDifferentCurve, msg)
+	}
+
+	// Verify sigmaIR with the sigma i commitment in the delta round
+	err = body.GetSigmaIRProof().Verify(p.sessionID, p.hiddingPoint, peer.delta.sigmaICommitment, p.r, sigmaIR)
+	if err != nil {
+		logger.Warn("Failed to verify sigmaIR proof", "err", err)
+		return blame(err, msg)
+	}
+	// Verify kiR with the enck in the enck round
+	if p.pedersen != nil {
+		encK := getMessage(peer.GetMessage(types.MessageType(Type_EncK))).GetEncK().GetEnck()
+		err = body.GetKiRProof().VerifyWithCheck(p.sessionID, peer.pubkey.publicKey, encK, p.pedersen.PedersenOpenParameter, p.r, kiR)
+		if err != nil {
+			logger.Warn("Failed to verify kiR proof", "err", err)
+			return blame(err, msg)
+		}
+	}
+
+	peer.kiRSigmaIR = &kiRSigmaIRData{
+		kiR:     kiR,
+		sigmaIR: sigmaIR,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *kiRSigmaIRHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	var err error
+	sumKiR := p.kiR
+	sumSigmaIR := p.sigmaIR
+	for id, peer := range p.peers {
+		sumKiR, err = sumKiR.Add(peer.kiRSigmaIR.kiR)
+		if err != nil {
+			logger.Warn("Failed to add kiR", "id", id, "err", err)
+			return nil, err
+		}
+		sumSigmaIR, err = sumSigmaIR.Add(peer.kiRSigmaIR.sigmaIR)
+		if err != nil {
+			logger.Warn("Failed to add sigmaIR", "id", id, "err", err)
+			return nil, err
+		}
+	}
+	if !sumKiR.Equal(p.g) {
+		logger.Warn("Inconsistent kiR")
+		return nil, ErrInconsistentKiR
+	}
+	if !sumSigmaIR.Equal(p.publicKey) {
+		logger.Warn("Inconsistent sigmaIR")
+		return nil, ErrInconsistentSigmaIR
+	}
+	// The presignature is ready
+	return nil, nil
+}
+
+// sendKiRSigmaIR sends k_i*R and sigma_i*R to the peers with the proofs
+func (p *proofAiHandler) sendKiRSigmaIR(logger log.Logger) error {
+	p.kiR = p.r.ScalarMult(p.aiMta.GetProductWithK(big.NewInt(1)))
+	p.sigmaIR = p.r.ScalarMult(p.tmpSi)
+	for id, peer := range p.peers {
+		msg, err := p.getKiRSigmaIRMessage(peer)
+		if err != nil {
+			logger.Warn("Failed to get kiR sigmaIR message", "id", id, "err", err)
+			return err
+		}
+		p.peerManager.MustSend(id, msg)
+	}
+	return nil
+}
+
+// getKiRSigmaIRMessage returns the KiRSigmaIR message to the peer. With Paillier, it contains the proof of kiR
+// under the ring-Pedersen parameter of the peer.
+func (p *proofAiHandler) getKiRSigmaIRMessage(peer *peer) (*Message, error) {
+	kiR, err := p.kiR.ToEcPointMessage()
+	if err != nil {
+		return nil, err
+	}
+	sigmaIR, err := p.sigmaIR.ToEcPointMessage()
+	if err != nil {
+		return nil, err
+	}
+	sigmaIRProof, err := zkproof.NewPedersenDLEQMessage(p.sessionID, p.tmpSi, p.sigmaIBlinding, p.hiddingPoint, p.r)
+	if err != nil {
+		return nil, err
+	}
+	var kiRProof *paillier.RangeProofMessage
+	if p.pedersen != nil {
+		paillierHomo := p.homo.(*paillier.Paillier)
+		kiRProof, err = paillierHomo.NewRangeProofMessageWithCheck(p.sessionID, p.aiMta.GetEncK(), peer.pubkey.pedersen, p.r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Message{
+		Type:      Type_KiRSigmaIR,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_KiRSigmaIR{
+			KiRSigmaIR: &BodyKiRSigmaIR{
+				KiR:          kiR,
+				SigmaIR:      sigmaIR,
+				KiRProof:     kiRProof,
+				SigmaIRProof: sigmaIRProof,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,194 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/alice/crypto/zkproof"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("kiR sigmaIR handler, negative cases", func() {
+	var (
+		peerId = "peer-id"
+
+		presigners map[string]*Presigner
+		listeners  map[string]*mocks.StateChangedListener
+	)
+	BeforeEach(func() {
+		expPublic := ecpointgrouplaw.ScalarBaseMult(btcec.S256(), privateKey)
+		presigners, listeners = newPresignersWithPubkeys(expPublic, [][]*big.Int{
+			{shareX, shareY, big.NewInt(0)},
+			{shareX2, shareY2, big.NewInt(0)},
+			{shareX3, shareY3, big.NewInt(0)},
+		})
+		// Override peer manager
+		for _, p := range presigners {
+			p.ph.peerManager = newStopPeerManager(Type_KiRSigmaIR, p.ph.peerManager)
+		}
+		for _, p := range presigners {
+			p.Start()
+		}
+
+		// Send out peer message
+		for fromID, fromD := range presigners {
+			msg := fromD.GetPubkeyMessage()
+			for toID, toD := range presigners {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		// Wait presigners to handle proof ai messages
+		for _, p := range presigners {
+			Eventually(func() bool {
+				_, ok := p.GetHandler().(*kiRSigmaIRHandler)
+				return ok
+			}, time.Minute).Should(BeTrue())
+		}
+	})
+
+	AfterEach(func() {
+		for _, l := range listeners {
+			l.On("OnStateChanged", types.StateInit, types.StateFailed).Return().Once()
+		}
+		for _, p := range presigners {
+			p.Stop()
+		}
+		time.Sleep(500 * time.Millisecond)
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	Context("IsHandled", func() {
+		It("peer not found", func() {
+			for _, p := range presigners {
+				rh, ok := p.GetHandler().(*kiRSigmaIRHandler)
+				Expect(ok).Should(BeTrue())
+				Expect(rh.IsHandled(log.Discard(), peerId)).Should(BeFalse())
+			}
+		})
+	})
+
+	Context("HandleMessage/Finalize", func() {
+		var (
+			toID, fromID, otherID string
+			toH, fromH            *kiRSigmaIRHandler
+			msg                   *Message
+		)
+		BeforeEach(func() {
+			toID, fromID, otherID = getID(0), getID(1), getID(2)
+			toH = presigners[toID].GetHandler().(*kiRSigmaIRHandler)
+			fromH = presigners[fromID].GetHandler().(*kiRSigmaIRHandler)
+			var err error
+			msg, err = fromH.getKiRSigmaIRMessage(fromH.peers[toID])
+			Expect(err).Should(BeNil())
+		})
+
+		It("peer not found", func() {
+			msg := &Message{
+				Id: "invalid peer",
+			}
+			Expect(toH.HandleMessage(log.Discard(), msg)).Should(Equal(tss.ErrPeerNotFound))
+		})
+
+		It("invalid kiR", func() {
+			msg.GetKiRSigmaIR().KiR = nil
+			Expect(toH.HandleMessage(log.Discard(), msg)).Should(MatchError(ecpointgrouplaw.ErrInvalidPoint))
+		})
+
+		It("sigmaIR on a different curve", func() {
+			var err error
+			msg.GetKiRSigmaIR().SigmaIR, err = ecpointgrouplaw.NewBase(elliptic.P256()).ToEcPointMessage()
+			Expect(err).Should(BeNil())
+			Expect(toH.HandleMessage(log.Discard(), msg)).Should(MatchError(ecpointgrouplaw.ErrDifferentCurve))
+		})
+
+		It("sigmaIR is inconsistent with the sigma i commitment", func() {
+			otherH := presigners[otherID].GetHandler().(*kiRSigmaIRHandler)
+			var err error
+			msg.GetKiRSigmaIR().SigmaIR, err = otherH.sigmaIR.ToEcPointMessage()
+			Expect(err).Should(BeNil())
+			Expect(toH.HandleMessage(log.Discard(), msg)).Should(MatchError(zkproof.ErrVerifyFailure))
+		})
+
+		It("blames the sender of an invalid sigmaIR", func() {
+			otherH := presigners[otherID].GetHandler().(*kiRSigmaIRHandler)
+			var err error
+			msg.GetKiRSigmaIR().SigmaIR, err = otherH.sigmaIR.ToEcPointMessage()
+			Expect(err).Should(BeNil())
+			err = toH.HandleMessage(log.Discard(), msg)
+			expectBlame(err, msg)
+		})
+
+		It("kiR proof under the ring-Pedersen parameter of another peer", func() {
+			other, err := fromH.getKiRSigmaIRMessage(fromH.peers[otherID])
+			Expect(err).Should(BeNil())
+			msg.GetKiRSigmaIR().KiRProof = other.GetKiRSigmaIR().GetKiRProof()
+			Expect(toH.HandleMessage(log.Discard(), msg)).ShouldNot(BeNil())
+			Expect(toH.IsHandled(log.Discard(), fromID)).Should(BeFalse())
+		})
+
+		It("inconsistent kiR", func() {
+			handleKiRSigmaIRMessages(presigners, toID)
+			toH.kiR = toH.g
+			got, err := toH.Finalize(log.Discard())
+			Expect(err).Should(Equal(ErrInconsistentKiR))
+			Expect(got).Should(BeNil())
+		})
+
+		It("inconsistent sigmaIR", func() {
+			handleKiRSigmaIRMessages(presigners, toID)
+			toH.sigmaIR = toH.g
+			got, err := toH.Finalize(log.Discard())
+			Expect(err).Should(Equal(ErrInconsistentSigmaIR))
+			Expect(got).Should(BeNil())
+		})
+
+		It("valid kiR and sigmaIR", func() {
+			handleKiRSigmaIRMessages(presigners, toID)
+			got, err := toH.Finalize(log.Discard())
+			Expect(err).Should(BeNil())
+			Expect(got).Should(BeNil())
+		})
+	})
+})
+
+// handleKiRSigmaIRMessages lets the handler of the id handle the KiRSigmaIR messages of the peers
+func handleKiRSigmaIRMessages(presigners map[string]*Presigner, toID string) {
+	toH := presigners[toID].GetHandler().(*kiRSigmaIRHandler)
+	for id, p := range presigners {
+		if id == toID {
+			continue
+		}
+		fromH := p.GetHandler().(*kiRSigmaIRHandler)
+		msg, err := fromH.getKiRSigmaIRMessage(fromH.peers[toID])
+		Expect(err).Should(BeNil())
+		Expect(toH.HandleMessage(log.Discard(), msg)).Should(BeNil())
+		Expect(toH.IsHandled(log.Discard(), id)).Should(BeTrue())
+	}
+}
@@ -21,21 +21,27 @@ import (
 	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/tss"
 	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/zkproof"
 	"github.com/getamis/sirius/log"
 )
 
 type decommitViAiData struct {
-	vi *pt.ECPoint
-	ai *pt.ECPoint
+	vi      *pt.ECPoint
+	ai      *pt.ECPoint
+	kiR     *pt.ECPoint
+	sigmaIR *pt.ECPoint
 }
 
 type decommitViAiHandler struct {
 	*commitViAiHandler
 
+	a           *pt.ECPoint
 	ui          *pt.ECPoint
 	uiCommitter *commitment.HashCommitmenter
 	ti          *pt.ECPoint
 	tiCommitter *commitment.HashCommitmenter
+	// tiProof proves that Ti and l_i*G share the same l_i, which binds Vi to kiR and sigmaIR
+	tiProof *zkproof.DLEQMessage
 }
 
 func newDecommitViAiHandler(p *commitViAiHandler) (*decommitViAiHandler, error) {
@@ -72,32 +78,48 @@ func (p *decommitViAiHandler) HandleMessage(logger log.Logger, message types.Mes
 
 	// Verify li and rhoI
 	body := msg.GetDecommitViAi()
-	err := body.LiProof.Verify(p.r)
+	err := body.LiProof.VerifyWithSession(p.sessionID, p.r)
 	if err != nil {
 		logger.Warn("Failed to verify li proof message", "err", err)
-		return err
+		return blame(err, msg)
 	}
-	err = body.RhoIProof.Verify(p.g)
+	err = body.RhoIProof.VerifyWithSession(p.sessionID, p.g)
 	if err != nil {
 		logger.Warn("Failed to verify rho i proof message", "err", err)
-		return err
+		return blame(err, msg)
 	}
 
 	// Decommit Vi and Ai
-	vi, err := tss.GetPointFromHashCommitment(logger, peer.commitViAi.viCommitment, body.ViDecommitment)
+	vi, err := tss.GetPointFromHashCommitment(logger, p.sessionID, peer.commitViAi.viCommitment, body.ViDecommitment)
 	if err != nil {
 		logger.Warn("Failed to decommit vi message", "err", err)
-		return err
+		return blame(err, msg)
 	}
-	ai, err := tss.GetPointFromHashCommitment(logger, peer.commitViAi.aiCommitment, body.AiDecommitment)
+	ai, err := tss.GetPointFromHashCommitment(logger, p.sessionID, peer.commitViAi.aiCommitment, body.AiDecommitment)
 	if err != nil {
 		logger.Warn("Failed to decommit ai message", "err", err)
-		return err
+		return blame(err, msg)
+	}
+	kiR, err := tss.GetPointFromHashCommitment(logger, p.sessionID, peer.commitViAi.kiRCommitment, body.KiRDecommitment)
+	if err != nil {
+		logger.Warn("Failed to decommit kiR message", "err", err)
+		return blame(err, msg)
+	}
+	sigmaIR, err := tss.GetPointFromHashCommitment(logger, p.sessionID, peer.commitViAi.sigmaIRCommitment, body.SigmaIRDecommitment)
+	if err != nil {
+		logger.Warn("Failed to decommit sigmaIR message", "err", err)
+		return blame(err, msg)
+	}
+	if !kiR.IsSameCurve(p.publicKey) || !sigmaIR.IsSameCurve(p.publicKey) {
+		logger.Warn("Different curves of kiR or sigmaIR")
+		return blame(pt.ErrDifferentCurve, msg)
 	}
 
 	peer.decommitViAi = &decommitViAiData{
-		vi: vi,
-		ai: ai,
+		vi:      vi,
+		ai:      ai,
+		kiR:     kiR,
+		sigmaIR: sigmaIR,
 	}
 	return peer.AddMessage(msg)
 }
@@ -109,19 +131,25 @@ func (p *decommitViAiHandler) Finalize(logger log.Logger) (types.Handler, error)
 		return nil, err
 	}
 	p.ui = v.ScalarMult(p.rhoI)
-	p.uiCommitter, err = tss.NewCommitterByPoint(p.ui)
+	p.uiCommitter, err = tss.NewCommitterByPoint(p.sessionID, p.ui)
 	if err != nil {
 		return nil, err
 	}
 
 	// Build A and its committer
-	a, err := buildA(logger, p.ai, p.peers)
+	p.a, err = buildA(logger, p.ai, p.peers)
+	if err != nil {
+		return nil, err
+	}
+	p.ti = p.a.ScalarMult(p.li)
+	p.tiCommitter, err = tss.NewCommitterByPoint(p.sessionID, p.ti)
 	if err != nil {
 		return nil, err
 	}
-	p.ti = a.ScalarMult(p.li)
-	p.tiCommitter, err = tss.NewCommitterByPoint(p.ti)
+
+	p.tiProof, err = zkproof.NewDLEQMessage(p.sessionID, p.li, p.g, p.a)
 	if err != nil {
+		logger.Warn("Failed to prove ti", "err", err)
 		return nil, err
 	}
 
@@ -133,12 +161,14 @@ func (p *decommitViAiHandler) Finalize(logger log.Logger) (types.Handler, error)
 
 func (p *decommitViAiHandler) getDecommitUiTiMessage() *Message {
 	return &Message{
-		Type: Type_DecommitUiTi,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_DecommitUiTi,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_DecommitUiTi{
 			DecommitUiTi: &BodyDecommitUiTi{
 				UiDecommitment: p.uiCommitter.GetDecommitmentMessage(),
 				TiDecommitment: p.tiCommitter.GetDecommitmentMessage(),
+				LiProof:        p.tiProof,
 			},
 		},
 	}
@@ -146,8 +176,9 @@ func (p *decommitViAiHandler) getDecommitUiTiMessage() *Message {
 
 func (p *decommitViAiHandler) getCommitUiTiMessage() *Message {
 	return &Message{
-		Type: Type_CommitUiTi,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_CommitUiTi,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_CommitUiTi{
 			CommitUiTi: &BodyCommitUiTi{
 				UiCommitment: p.uiCommitter.GetCommitmentMessage(),
@@ -197,3 +228,12 @@ func buildA(logger log.Logger, selfAi *pt.ECPoint, peers map[string]*peer) (*pt.
 	}
 	return A, nil
 }
+
+// buildLiG returns Vi - m*k_i*R - r*sigma_i*R, which is l_i*G if kiR and sigmaIR are consistent with Vi
+func buildLiG(vi *pt.ECPoint, kiR *pt.ECPoint, sigmaIR *pt.ECPoint, m *big.Int, rx *big.Int) (*pt.ECPoint, error) {
+	liG, err := vi.Add(kiR.ScalarMult(new(big.Int).Neg(m)))
+	if err != nil {
+		return nil, err
+	}
+	return liG.Add(sigmaIR.ScalarMult(new(big.Int).Neg(rx)))
+}
@@ -36,7 +36,7 @@ var _ = Describe("decommit vi ai handler, negative cases", func() {
 		listeners map[string]*mocks.StateChangedListener
 	)
 	BeforeEach(func() {
-		signers, listeners = newTestSigners()
+		signers, listeners = newTestSignersWithPubkeys()
 		// Override peer manager
 		for _, s := range signers {
 			p := newStopPeerManager(Type_DecommitViAi, s.ph.peerManager)
@@ -55,10 +55,10 @@ var _ = Describe("decommit vi ai handler, negative cases", func() {
 		}
 		// Wait dkgs to handle decommit messages
 		for _, s := range signers {
-			_, ok := s.GetHandler().(*decommitViAiHandler)
-			if !ok {
-				time.Sleep(500 * time.Millisecond)
-			}
+			Eventually(func() bool {
+				_, ok := s.GetHandler().(*decommitViAiHandler)
+				return ok
+			}, 10*time.Second).Should(BeTrue())
 		}
 	})
 
@@ -146,7 +146,7 @@ var _ = Describe("decommit vi ai handler, negative cases", func() {
 					},
 				},
 			}
-			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(Equal(pt.ErrInvalidPoint))
+			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(MatchError(pt.ErrInvalidPoint))
 		})
 
 		It("failed to verify RhoIProof", func() {
@@ -162,7 +162,7 @@ var _ = Describe("decommit vi ai handler, negative cases", func() {
 					},
 				},
 			}
-			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(Equal(pt.ErrInvalidPoint))
+			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(MatchError(pt.ErrInvalidPoint))
 		})
 
 		It("failed to verify RhoIProof", func() {
@@ -179,7 +179,7 @@ var _ = Describe("decommit vi ai handler, negative cases", func() {
 					},
 				},
 			}
-			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(Equal(pt.ErrInvalidPoint))
+			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(MatchError(pt.ErrInvalidPoint))
 		})
 
 		It("failed to decommit vi", func() {
@@ -196,7 +196,7 @@ var _ = Describe("decommit vi ai handler, negative cases", func() {
 					},
 				},
 			}
-			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(Equal(commitment.ErrDifferentDigest))
+			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(MatchError(commitment.ErrDifferentDigest))
 		})
 
 		It("failed to decommit ai", func() {
@@ -213,7 +213,31 @@ var _ = Describe("decommit vi ai handler, negative cases", func() {
 					},
 				},
 			}
-			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(Equal(commitment.ErrDifferentDigest))
+			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(MatchError(commitment.ErrDifferentDigest))
+		})
+
+		It("failed to decommit kiR", func() {
+			msg.GetDecommitViAi().KiRDecommitment = &commitment.HashDecommitmentMessage{}
+			Expect(toH.HandleMessage(log.Discard(), msg)).Should(MatchError(commitment.ErrDifferentDigest))
+		})
+
+		It("failed to decommit sigmaIR", func() {
+			msg.GetDecommitViAi().SigmaIRDecommitment = &commitment.HashDecommitmentMessage{}
+			Expect(toH.HandleMessage(log.Discard(), msg)).Should(MatchError(commitment.ErrDifferentDigest))
+		})
+
+		It("sigmaIR on a different curve", func() {
+			committer, err := tss.NewCommitterByPoint(toH.sessionID, pt.NewBase(elliptic.P256()))
+			Expect(err).Should(BeNil())
+			toH.peers[msg.GetId()].commitViAi.sigmaIRCommitment = committer.GetCommitmentMessage()
+			msg.GetDecommitViAi().SigmaIRDecommitment = committer.GetDecommitmentMessage()
+			Expect(toH.HandleMessage(log.Discard(), msg)).Should(MatchError(pt.ErrDifferentCurve))
+		})
+
+		It("blames the sender of an invalid vi decommitment", func() {
+			msg.GetDecommitViAi().ViDecommitment = &commitment.HashDecommitmentMessage{}
+			err := toH.HandleMessage(log.Discard(), msg)
+			expectBlame(err, msg)
 		})
 	})
 
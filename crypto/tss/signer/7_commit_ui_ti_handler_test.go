@@ -32,7 +32,7 @@ var _ = Describe("commit ui ti handler, negative cases", func() {
 		listeners map[string]*mocks.StateChangedListener
 	)
 	BeforeEach(func() {
-		signers, listeners = newTestSigners()
+		signers, listeners = newTestSignersWithPubkeys()
 		// Override peer manager
 		for _, s := range signers {
 			p := newStopPeerManager(Type_CommitUiTi, s.ph.peerManager)
@@ -51,10 +51,10 @@ var _ = Describe("commit ui ti handler, negative cases", func() {
 		}
 		// Wait dkgs to handle decommit messages
 		for _, s := range signers {
-			_, ok := s.GetHandler().(*commitUiTiHandler)
-			if !ok {
-				time.Sleep(500 * time.Millisecond)
-			}
+			Eventually(func() bool {
+				_, ok := s.GetHandler().(*commitUiTiHandler)
+				return ok
+			}, 10*time.Second).Should(BeTrue())
 		}
 	})
 
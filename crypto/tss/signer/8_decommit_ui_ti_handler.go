@@ -16,6 +16,7 @@ package signer
 
 import (
 	"errors"
+	"math/big"
 
 	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/tss"
@@ -69,15 +70,27 @@ func (p *decommitUiTiHandler) HandleMessage(logger log.Logger, message types.Mes
 	}
 
 	body := msg.GetDecommitUiTi()
-	ui, err := tss.GetPointFromHashCommitment(logger, peer.commitUiTi.uiCommitment, body.UiDecommitment)
+	ui, err := tss.GetPointFromHashCommitment(logger, p.sessionID, peer.commitUiTi.uiCommitment, body.UiDecommitment)
 	if err != nil {
 		logger.Warn("Failed to decommit ui message", "err", err)
-		return err
+		return blame(err, msg)
 	}
-	ti, err := tss.GetPointFromHashCommitment(logger, peer.commitUiTi.tiCommitment, body.TiDecommitment)
+	ti, err := tss.GetPointFromHashCommitment(logger, p.sessionID, peer.commitUiTi.tiCommitment, body.TiDecommitment)
 	if err != nil {
 		logger.Warn("Failed to decommit ti message", "err", err)
-		return err
+		return blame(err, msg)
+	}
+	// Ensure Vi - m*k_i*R - r*sigma_i*R = l_i*G and Ti = l_i*A. Then s_i*R = m*k_i*R + r*sigma_i*R for the s_i
+	// committed in Vi.
+	liG, err := buildLiG(peer.decommitViAi.vi, peer.decommitViAi.kiR, peer.decommitViAi.sigmaIR, new(big.Int).SetBytes(p.msg), p.r.GetX())
+	if err != nil {
+		logger.Warn("Failed to build liG", "err", err)
+		return blame(err, msg)
+	}
+	err = body.GetLiProof().Verify(p.sessionID, p.g, liG, p.a, ti)
+	if err != nil {
+		logger.Warn("Failed to verify li proof", "err", err)
+		return blame(err, msg)
 	}
 
 	peer.decommitUiTi = &decommitUiTiData{
@@ -109,8 +122,9 @@ func (p *decommitUiTiHandler) Finalize(logger log.Logger) (types.Handler, error)
 
 func (p *decommitUiTiHandler) getSiMessage() *Message {
 	return &Message{
-		Type: Type_Si,
-		Id:   p.peerManager.SelfID(),
+		Type:      Type_Si,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
 		Body: &Message_Si{
 			Si: &BodySi{
 				Si: p.si.Bytes(),
@@ -22,6 +22,7 @@ import (
 	"github.com/getamis/alice/crypto/tss"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/alice/crypto/zkproof"
 	"github.com/getamis/sirius/log"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -35,7 +36,7 @@ var _ = Describe("decommit ui ti handler, negative cases", func() {
 		listeners map[string]*mocks.StateChangedListener
 	)
 	BeforeEach(func() {
-		signers, listeners = newTestSigners()
+		signers, listeners = newTestSignersWithPubkeys()
 		// Override peer manager
 		for _, s := range signers {
 			p := newStopPeerManager(Type_DecommitUiTi, s.ph.peerManager)
@@ -54,10 +55,10 @@ var _ = Describe("decommit ui ti handler, negative cases", func() {
 		}
 		// Wait dkgs to handle decommit messages
 		for _, s := range signers {
-			_, ok := s.GetHandler().(*decommitUiTiHandler)
-			if !ok {
-				time.Sleep(500 * time.Millisecond)
-			}
+			Eventually(func() bool {
+				_, ok := s.GetHandler().(*decommitUiTiHandler)
+				return ok
+			}, 10*time.Second).Should(BeTrue())
 		}
 	})
 
@@ -142,7 +143,7 @@ var _ = Describe("decommit ui ti handler, negative cases", func() {
 					},
 				},
 			}
-			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(Equal(commitment.ErrDifferentDigest))
+			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(MatchError(commitment.ErrDifferentDigest))
 		})
 
 		It("failed to decommit ti", func() {
@@ -156,7 +157,23 @@ var _ = Describe("decommit ui ti handler, negative cases", func() {
 					},
 				},
 			}
-			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(Equal(commitment.ErrDifferentDigest))
+			Expect(toH.HandleMessage(log.Discard(), newMsg)).Should(MatchError(commitment.ErrDifferentDigest))
+		})
+
+		It("failed to verify li proof", func() {
+			msg.GetDecommitUiTi().LiProof = toH.tiProof
+			Expect(toH.HandleMessage(log.Discard(), msg)).Should(MatchError(zkproof.ErrVerifyFailure))
+		})
+
+		It("kiR is inconsistent with vi", func() {
+			toH.peers[msg.GetId()].decommitViAi.kiR = toH.g
+			Expect(toH.HandleMessage(log.Discard(), msg)).Should(MatchError(zkproof.ErrVerifyFailure))
+		})
+
+		It("blames the sender of an invalid li proof", func() {
+			msg.GetDecommitUiTi().LiProof = toH.tiProof
+			err := toH.HandleMessage(log.Discard(), msg)
+			expectBlame(err, msg)
 		})
 	})
 
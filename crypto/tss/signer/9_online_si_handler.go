@@ -0,0 +1,159 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"math/big"
+	"sort"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+// onlineSiHandler collects s_i of the signers with a presignature. The signature is verified before it's output.
+// If it's invalid, the s_i of each peer is checked by s_i*R = m*k_i*R + r*sigma_i*R with k_i*R and sigma_i*R in
+// the presignature, so all the peers sending invalid s_i are blamed.
+type onlineSiHandler struct {
+	publicKey *pt.ECPoint
+	r         *pt.ECPoint
+	msg       []byte
+	si        *big.Int
+	sessionID []byte
+	s         *big.Int
+
+	peerManager types.PeerManager
+	peerNum     uint32
+	peers       map[string]*peer
+}
+
+func newOnlineSiHandler(peerManager types.PeerManager, sessionID []byte, presignature *Presignature, msg []byte) (*onlineSiHandler, error) {
+	// Ensure the signers are the same as the ones generating the presignature
+	peerIDs, err := message.GetPeerIDs(peerManager)
+	if err != nil {
+		log.Warn("Failed to get peer ids", "err", err)
+		return nil, err
+	}
+	sort.Strings(peerIDs)
+	if peerManager.SelfID() != presignature.selfID || int(peerManager.NumPeers()) != len(presignature.peerIDs) || !isSameIDs(peerIDs, presignature.peerIDs) {
+		log.Warn("Inconsistent signers", "self", peerManager.SelfID(), "peers", peerIDs, "expected", presignature.peerIDs)
+		return nil, ErrInconsistentSigners
+	}
+	aiMta, tmpSi, err := presignature.consume()
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make(map[string]*peer, len(peerIDs))
+	for _, id := range peerIDs {
+		peers[id] = newPeer(id)
+		peers[id].kiRSigmaIR = presignature.peers[id]
+	}
+	n := presignature.publicKey.GetCurve().Params().N
+	return &onlineSiHandler{
+		publicKey: presignature.publicKey,
+		r:         presignature.r,
+		msg:       msg,
+		si:        buildSi(aiMta, n, presignature.r.GetX(), tmpSi, new(big.Int).SetBytes(msg)),
+		sessionID: sessionID,
+
+		peerManager: peerManager,
+		peerNum:     peerManager.NumPeers(),
+		peers:       peers,
+	}, nil
+}
+
+func (p *onlineSiHandler) MessageType() types.MessageType {
+	return types.MessageType(Type_Si)
+}
+
+func (p *onlineSiHandler) GetRequiredMessageCount() uint32 {
+	return p.peerNum
+}
+
+func (p *onlineSiHandler) IsHandled(logger log.Logger, id string) bool {
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return false
+	}
+	return peer.si != nil
+}
+
+func (p *onlineSiHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	peer, ok := p.peers[id]
+	if !ok {
+		logger.Warn("Peer not found")
+		return ErrPeerNotFound
+	}
+
+	// The s_i is checked in Finalize only if the signature is invalid
+	peer.si = &siData{
+		si: new(big.Int).SetBytes(msg.GetSi().GetSi()),
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *onlineSiHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	n := p.publicKey.GetCurve().Params().N
+	s := new(big.Int).Set(p.si)
+	for _, peer := range p.peers {
+		s = new(big.Int).Add(s, peer.si.si)
+	}
+	s.Mod(s, n)
+	m := new(big.Int).SetBytes(p.msg)
+	if s.Cmp(big0) == 0 {
+		return nil, blameInvalidSi(logger, p.r, m, p.peers, presignedKiRSigmaIR, ErrZeroS)
+	}
+	if !VerifySignature(p.publicKey, p.r.GetX(), s, m) {
+		logger.Warn("Failed to verify the signature")
+		return nil, blameInvalidSi(logger, p.r, m, p.peers, presignedKiRSigmaIR, ErrInvalidSignature)
+	}
+	p.s = s
+	return nil, nil
+}
+
+// presignedKiRSigmaIR returns k_i*R and sigma_i*R of the peer, which are revealed in presigning.
+func presignedKiRSigmaIR(peer *peer) (*pt.ECPoint, *pt.ECPoint) {
+	return peer.kiRSigmaIR.kiR, peer.kiRSigmaIR.sigmaIR
+}
+
+func (p *onlineSiHandler) getSiMessage() *Message {
+	return &Message{
+		Type:      Type_Si,
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_Si{
+			Si: &BodySi{
+				Si: p.si.Bytes(),
+			},
+		},
+	}
+}
+
+func isSameIDs(ids1 []string, ids2 []string) bool {
+	if len(ids1) != len(ids2) {
+		return false
+	}
+	for i := range ids1 {
+		if ids1[i] != ids2[i] {
+			return false
+		}
+	}
+	return true
+}
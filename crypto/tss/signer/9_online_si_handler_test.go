@@ -0,0 +1,131 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("online si handler, negative cases", func() {
+	var (
+		peerId = "peer-id"
+		curve  = btcec.S256()
+		msg    = []byte("9ca9bf7e9c8b1e1c2b6f4e0e1c2f2e6a")
+
+		handlers map[string]*onlineSiHandler
+	)
+	BeforeEach(func() {
+		expPublic := ecpointgrouplaw.ScalarBaseMult(curve, privateKey)
+		presignatures := runPresigners(newPresignersWithPubkeys(expPublic, [][]*big.Int{
+			{shareX, shareY, big.NewInt(0)},
+			{shareX2, shareY2, big.NewInt(0)},
+			{shareX3, shareY3, big.NewInt(0)},
+		}))
+		processes := map[string]message.MessageAdder{
+			getID(0): nil,
+			getID(1): nil,
+			getID(2): nil,
+		}
+		handlers = make(map[string]*onlineSiHandler, len(presignatures))
+		for id, p := range presignatures {
+			pm := newPresignPeerManager(id, len(presignatures)-1)
+			pm.setProcesses(processes)
+			var err error
+			handlers[id], err = newOnlineSiHandler(tss.NewSilentPeerManager(pm), sessionID, p, msg)
+			Expect(err).Should(BeNil())
+		}
+	})
+
+	Context("IsHandled", func() {
+		It("peer not found", func() {
+			for _, h := range handlers {
+				Expect(h.IsHandled(log.Discard(), peerId)).Should(BeFalse())
+			}
+		})
+	})
+
+	Context("HandleMessage/Finalize", func() {
+		It("peer not found", func() {
+			msg := &Message{
+				Id: peerId,
+			}
+			for _, h := range handlers {
+				Expect(h.HandleMessage(log.Discard(), msg)).Should(Equal(ErrPeerNotFound))
+			}
+		})
+
+		It("blames the sender of an invalid si", func() {
+			h0 := handlers[getID(0)]
+			h1 := handlers[getID(1)]
+			msg := h1.getSiMessage()
+			msg.GetSi().Si = new(big.Int).Add(h1.si, big.NewInt(1)).Bytes()
+			Expect(h0.HandleMessage(log.Discard(), msg)).Should(BeNil())
+			Expect(h0.HandleMessage(log.Discard(), handlers[getID(2)].getSiMessage())).Should(BeNil())
+			got, err := h0.Finalize(log.Discard())
+			Expect(got).Should(BeNil())
+			Expect(err).Should(MatchError(ErrInvalidSi))
+			expectBlame(err, msg)
+		})
+
+		It("blames all the senders of invalid si", func() {
+			h0 := handlers[getID(0)]
+			var msgs []types.Message
+			for _, id := range []string{getID(1), getID(2)} {
+				msg := handlers[id].getSiMessage()
+				msg.GetSi().Si = new(big.Int).Add(handlers[id].si, big.NewInt(1)).Bytes()
+				Expect(h0.HandleMessage(log.Discard(), msg)).Should(BeNil())
+				msgs = append(msgs, msg)
+			}
+			got, err := h0.Finalize(log.Discard())
+			Expect(got).Should(BeNil())
+			var blameErr *message.BlameError
+			Expect(errors.As(err, &blameErr)).Should(BeTrue())
+			Expect(blameErr.Culprits).Should(Equal([]string{getID(1), getID(2)}))
+			Expect(blameErr.Evidence).Should(Equal(msgs))
+		})
+
+		It("invalid signature", func() {
+			h0 := handlers[getID(0)]
+			for _, id := range []string{getID(1), getID(2)} {
+				Expect(h0.HandleMessage(log.Discard(), handlers[id].getSiMessage())).Should(BeNil())
+			}
+			h0.si = new(big.Int).Add(h0.si, big.NewInt(1))
+			got, err := h0.Finalize(log.Discard())
+			Expect(err).Should(Equal(ErrInvalidSignature))
+			Expect(got).Should(BeNil())
+		})
+
+		It("valid si", func() {
+			h0 := handlers[getID(0)]
+			for _, id := range []string{getID(1), getID(2)} {
+				Expect(h0.HandleMessage(log.Discard(), handlers[id].getSiMessage())).Should(BeNil())
+			}
+			got, err := h0.Finalize(log.Discard())
+			Expect(err).Should(BeNil())
+			Expect(got).Should(BeNil())
+			Expect(VerifySignature(h0.publicKey, h0.r.GetX(), h0.s, new(big.Int).SetBytes(h0.msg))).Should(BeTrue())
+		})
+	})
+})
@@ -17,7 +17,10 @@ package signer
 import (
 	"errors"
 	"math/big"
+	"sort"
 
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss/message"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/sirius/log"
 )
@@ -27,6 +30,10 @@ var (
 
 	// ErrZeroS is returned if the s is zero
 	ErrZeroS = errors.New("zero s")
+	// ErrInvalidSignature is returned if the signature is invalid
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrInvalidSi is returned if the s_i of a peer is inconsistent with its k_i*R and sigma_i*R
+	ErrInvalidSi = errors.New("invalid si")
 )
 
 type siData struct {
@@ -70,9 +77,9 @@ func (p *siHandler) HandleMessage(logger log.Logger, message types.Message) erro
 		return ErrPeerNotFound
 	}
 
-	body := msg.GetSi()
+	// The s_i is checked in Finalize only if the signature is invalid
 	peer.si = &siData{
-		si: new(big.Int).SetBytes(body.GetSi()),
+		si: new(big.Int).SetBytes(msg.GetSi().GetSi()),
 	}
 	return peer.AddMessage(msg)
 }
@@ -83,8 +90,74 @@ func (p *siHandler) Finalize(logger log.Logger) (types.Handler, error) {
 		p.s = new(big.Int).Add(p.s, peer.si.si)
 	}
 	p.s.Mod(p.s, p.getCurve().Params().N)
+	m := new(big.Int).SetBytes(p.msg)
 	if p.s.Cmp(big0) == 0 {
-		return nil, ErrZeroS
+		return nil, blameInvalidSi(logger, p.r, m, p.peers, decommittedKiRSigmaIR, ErrZeroS)
+	}
+	if !VerifySignature(p.publicKey, p.r.GetX(), p.s, m) {
+		logger.Warn("Failed to verify the signature")
+		return nil, blameInvalidSi(logger, p.r, m, p.peers, decommittedKiRSigmaIR, ErrInvalidSignature)
 	}
 	return nil, nil
 }
+
+// blameInvalidSi checks the s_i of each peer against its k_i*R and sigma_i*R given by getKiRSigmaIR, and blames
+// all the peers whose s_i are invalid. It returns err if all the s_i are valid.
+func blameInvalidSi(logger log.Logger, r *pt.ECPoint, m *big.Int, peers map[string]*peer, getKiRSigmaIR func(peer *peer) (*pt.ECPoint, *pt.ECPoint), err error) error {
+	ids := make([]string, 0, len(peers))
+	for id := range peers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var evidence []types.Message
+	for _, id := range ids {
+		peer := peers[id]
+		kiR, sigmaIR := getKiRSigmaIR(peer)
+		siErr := verifySi(r, peer.si.si, kiR, sigmaIR, m)
+		if siErr != nil {
+			logger.Warn("Invalid si", "fromId", id, "err", siErr)
+			evidence = append(evidence, peer.GetMessage(types.MessageType(Type_Si)))
+		}
+	}
+	if len(evidence) == 0 {
+		return err
+	}
+	return message.NewBlameError(ErrInvalidSi, evidence...)
+}
+
+// decommittedKiRSigmaIR returns k_i*R and sigma_i*R of the peer, which are bound to Vi in the decommit ui ti round.
+func decommittedKiRSigmaIR(peer *peer) (*pt.ECPoint, *pt.ECPoint) {
+	return peer.decommitViAi.kiR, peer.decommitViAi.sigmaIR
+}
+
+// verifySi returns ErrInvalidSi if s_i*R != m*k_i*R + r*sigma_i*R
+func verifySi(r *pt.ECPoint, si *big.Int, kiR *pt.ECPoint, sigmaIR *pt.ECPoint, m *big.Int) error {
+	expected, err := kiR.ScalarMult(m).Add(sigmaIR.ScalarMult(r.GetX()))
+	if err != nil {
+		return err
+	}
+	if !r.ScalarMult(si).Equal(expected) {
+		return ErrInvalidSi
+	}
+	return nil
+}
+
+// VerifySignature verifies the ECDSA signature (r, s) of the message hash m under the public key
+func VerifySignature(publicKey *pt.ECPoint, r *big.Int, s *big.Int, m *big.Int) bool {
+	curve := publicKey.GetCurve()
+	n := curve.Params().N
+	if r.Sign() <= 0 || r.Cmp(n) >= 0 || s.Sign() <= 0 || s.Cmp(n) >= 0 {
+		return false
+	}
+	// R' = (m*s^-1)*G + (r*s^-1)*Q
+	w := new(big.Int).ModInverse(s, n)
+	u1 := new(big.Int).Mul(m, w)
+	u1.Mod(u1, n)
+	u2 := new(big.Int).Mul(r, w)
+	u2.Mod(u2, n)
+	rPrime, err := pt.ScalarBaseMult(curve, u1).Add(publicKey.ScalarMult(u2))
+	if err != nil || rPrime.IsIdentity() {
+		return false
+	}
+	return new(big.Int).Mod(rPrime.GetX(), n).Cmp(r) == 0
+}
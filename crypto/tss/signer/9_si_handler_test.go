@@ -14,9 +14,12 @@
 package signer
 
 import (
+	"errors"
+	"math/big"
 	"time"
 
 	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/message/types/mocks"
 	"github.com/getamis/sirius/log"
@@ -32,7 +35,7 @@ var _ = Describe("si handler, negative cases", func() {
 		listeners map[string]*mocks.StateChangedListener
 	)
 	BeforeEach(func() {
-		signers, listeners = newTestSigners()
+		signers, listeners = newTestSignersWithPubkeys()
 		// Override peer manager
 		for _, s := range signers {
 			p := newStopPeerManager(Type_Si, s.ph.peerManager)
@@ -51,10 +54,10 @@ var _ = Describe("si handler, negative cases", func() {
 		}
 		// Wait dkgs to handle decommit messages
 		for _, s := range signers {
-			_, ok := s.GetHandler().(*siHandler)
-			if !ok {
-				time.Sleep(500 * time.Millisecond)
-			}
+			Eventually(func() bool {
+				_, ok := s.GetHandler().(*siHandler)
+				return ok
+			}, 10*time.Second).Should(BeTrue())
 		}
 	})
 
@@ -111,4 +114,63 @@ var _ = Describe("si handler, negative cases", func() {
 			}
 		})
 	})
+
+	Context("Finalize", func() {
+		var (
+			selfID = getID(0)
+		)
+
+		// handleSiMessages lets the handler of self id handle the si messages of the peers. The si of the bad
+		// peers are increased by one. It returns the tampered messages.
+		handleSiMessages := func(badIDs ...string) (*siHandler, []types.Message) {
+			rh := signers[selfID].GetHandler().(*siHandler)
+			var tampered []types.Message
+			for _, id := range []string{getID(1), getID(2)} {
+				h := signers[id].GetHandler().(*siHandler)
+				msg := h.getSiMessage()
+				for _, badID := range badIDs {
+					if id == badID {
+						msg.GetSi().Si = new(big.Int).Add(h.si, big.NewInt(1)).Bytes()
+						tampered = append(tampered, msg)
+					}
+				}
+				Expect(rh.HandleMessage(log.Discard(), msg)).Should(BeNil())
+			}
+			return rh, tampered
+		}
+
+		It("valid signature", func() {
+			rh, _ := handleSiMessages()
+			got, err := rh.Finalize(log.Discard())
+			Expect(err).Should(BeNil())
+			Expect(got).Should(BeNil())
+		})
+
+		It("blames the sender of an invalid si", func() {
+			rh, tampered := handleSiMessages(getID(1))
+			got, err := rh.Finalize(log.Discard())
+			Expect(got).Should(BeNil())
+			Expect(err).Should(MatchError(ErrInvalidSi))
+			expectBlame(err, tampered[0].(*Message))
+		})
+
+		It("blames all the senders of invalid si", func() {
+			rh, tampered := handleSiMessages(getID(1), getID(2))
+			got, err := rh.Finalize(log.Discard())
+			Expect(got).Should(BeNil())
+			var blameErr *message.BlameError
+			Expect(errors.As(err, &blameErr)).Should(BeTrue())
+			Expect(blameErr.Err).Should(Equal(ErrInvalidSi))
+			Expect(blameErr.Culprits).Should(Equal([]string{getID(1), getID(2)}))
+			Expect(blameErr.Evidence).Should(Equal(tampered))
+		})
+
+		It("invalid signature", func() {
+			rh, _ := handleSiMessages()
+			rh.si = new(big.Int).Add(rh.si, big.NewInt(1))
+			got, err := rh.Finalize(log.Discard())
+			Expect(got).Should(BeNil())
+			Expect(err).Should(Equal(ErrInvalidSignature))
+		})
+	})
 })
@@ -0,0 +1,198 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+	proto "github.com/golang/protobuf/proto"
+)
+
+var (
+	// ErrInvalidBatchSize is returned if no message is given to the batch signer
+	ErrInvalidBatchSize = errors.New("invalid batch size")
+	// ErrInconsistentBatchSize is returned if the number of the messages in a batch message is not the batch size
+	ErrInconsistentBatchSize = errors.New("inconsistent batch size")
+)
+
+// BatchSigner signs a batch of messages in one session. Each signature has its own k_i, gamma_i, MtAs and
+// commitments as NewSigner, but the messages of all the signatures are sent together in each round, so the
+// number of rounds does not grow with the batch size. The homomorphic public keys of the peers are sent and
+// verified once for the batch.
+//
+// A failure in any signature aborts the whole batch. The messages of a round are sent only after all the
+// signatures finish the previous round, so the other signatures reveal nothing beyond the rounds all of them
+// have passed.
+type BatchSigner struct {
+	bh *batchHandler
+	*message.MsgMain
+
+	lowS bool
+}
+
+// NewBatchSigner creates a batch signer of the messages. The other arguments are the same as NewSigner.
+func NewBatchSigner(peerManager types.PeerManager, sessionID []byte, expectedPubkey *pt.ECPoint, homo homo.Crypto, secret *big.Int, threshold uint32, bks map[string]*birkhoffinterpolation.BkParameter, signerIDs []string, msgs [][]byte, listener types.StateChangedListener) (*BatchSigner, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 {
+		return nil, ErrInvalidBatchSize
+	}
+	slots := make([]*batchSlot, len(msgs))
+	handlers := make([]types.Handler, len(msgs))
+	for i, msg := range msgs {
+		slotSessionID := getSlotSessionID(sessionID, i)
+		pm := newSlotPeerManager(peerManager)
+		var slotPM types.PeerManager = pm
+		if g, ok := peerManager.(types.PeerIDsGetter); ok {
+			slotPM = &slotPeerIDsManager{slotPeerManager: pm, PeerIDsGetter: g}
+		}
+		ph, err := newPubkeyHandler(expectedPubkey, slotPM, slotSessionID, homo, secret, threshold, bks, signerIDs, msg)
+		if err != nil {
+			log.Warn("Failed to new a public key handler", "slot", i, "err", err)
+			return nil, err
+		}
+		// Only the first signature sends and verifies the homomorphic public keys
+		slots[i] = newBatchSlot(ph, pm, slotSessionID, i > 0)
+		handlers[i] = ph
+	}
+	bh := newBatchHandler(peerManager, sessionID, slots, handlers)
+	return &BatchSigner{
+		bh:   bh,
+		lowS: true,
+		MsgMain: message.NewMsgMain(peerManager,
+			sessionID,
+			listener,
+			bh,
+			types.MessageType(Type_BatchPubkey),
+			types.MessageType(Type_BatchEncK),
+			types.MessageType(Type_BatchMta),
+			types.MessageType(Type_BatchDelta),
+			types.MessageType(Type_BatchProofAi),
+			types.MessageType(Type_BatchCommitViAi),
+			types.MessageType(Type_BatchDecommitViAi),
+			types.MessageType(Type_BatchCommitUiTi),
+			types.MessageType(Type_BatchDecommitUiTi),
+			types.MessageType(Type_BatchSi),
+		),
+	}, nil
+}
+
+func (s *BatchSigner) GetPubkeyMessage() *Message {
+	msgs := make([]*Message, len(s.bh.slots))
+	for i, slot := range s.bh.slots {
+		msgs[i] = slot.ph.GetPubkeyMessage()
+	}
+	return s.bh.newBatchMessage(msgs)
+}
+
+// SetLowS sets whether s of the results is normalized to the lower one. It's enabled by default.
+func (s *BatchSigner) SetLowS(lowS bool) {
+	s.lowS = lowS
+}
+
+// GetResults returns the signatures in the order of the messages
+func (s *BatchSigner) GetResults() ([]*Result, error) {
+	if s.GetState() != types.StateDone {
+		return nil, tss.ErrNotReady
+	}
+
+	h := s.GetHandler()
+	bh, ok := h.(*batchHandler)
+	if !ok {
+		log.Error("We cannot convert to batch handler in done state")
+		return nil, tss.ErrNotReady
+	}
+	results := make([]*Result, len(bh.handlers))
+	for i, handler := range bh.handlers {
+		rh, ok := handler.(*siHandler)
+		if !ok {
+			log.Error("We cannot convert to result handler in done state", "slot", i)
+			return nil, tss.ErrNotReady
+		}
+		results[i] = NewResult(rh.r, rh.s, s.lowS)
+	}
+	return results, nil
+}
+
+// batchSlot is a signature in the batch
+type batchSlot struct {
+	ph        *pubkeyHandler
+	pm        *slotPeerManager
+	sessionID []byte
+}
+
+// newBatchSlot creates a signature in the batch. If reusePubkeys is true, the signature neither sends nor verifies
+// the homomorphic public keys, and the batch handler gives it the ones verified by the first signature.
+func newBatchSlot(ph *pubkeyHandler, pm *slotPeerManager, sessionID []byte, reusePubkeys bool) *batchSlot {
+	if reusePubkeys {
+		ph.peerPubkeys = make(map[string]homo.Pubkey, len(ph.peers))
+	}
+	return &batchSlot{
+		ph:        ph,
+		pm:        pm,
+		sessionID: sessionID,
+	}
+}
+
+// getSlotSessionID returns the session id of the i-th signature, so the commitments and the proofs of a signature
+// could not be replayed in another one of the batch.
+func getSlotSessionID(sessionID []byte, i int) []byte {
+	slotSessionID := make([]byte, len(sessionID)+4)
+	copy(slotSessionID, sessionID)
+	binary.BigEndian.PutUint32(slotSessionID[len(sessionID):], uint32(i))
+	return slotSessionID
+}
+
+// slotPeerManager keeps the messages of a signature in the batch instead of sending them. The batch handler sends
+// the kept messages of all the signatures together.
+type slotPeerManager struct {
+	types.PeerManager
+	msgs map[string]*Message
+}
+
+func newSlotPeerManager(peerManager types.PeerManager) *slotPeerManager {
+	return &slotPeerManager{
+		PeerManager: peerManager,
+		msgs:        make(map[string]*Message),
+	}
+}
+
+func (p *slotPeerManager) MustSend(id string, message proto.Message) {
+	p.msgs[id] = message.(*Message)
+}
+
+// slotPeerIDsManager is the slot peer manager of a peer manager implementing types.PeerIDsGetter. It forwards
+// PeerIDs, so the peers are checked to be the signers except self as NewSigner does.
+type slotPeerIDsManager struct {
+	*slotPeerManager
+	types.PeerIDsGetter
+}
+
+// popMessages returns the kept messages and clears them
+func (p *slotPeerManager) popMessages() map[string]*Message {
+	msgs := p.msgs
+	p.msgs = make(map[string]*Message)
+	return msgs
+}
@@ -0,0 +1,141 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"bytes"
+
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+// batchHandler runs a round of all the signatures in the batch. It passes the messages in a batch message to the
+// handlers of the signatures, and sends the messages of the next round only if all of them are finalized.
+type batchHandler struct {
+	peerManager types.PeerManager
+	sessionID   []byte
+	slots       []*batchSlot
+	handlers    []types.Handler
+}
+
+func newBatchHandler(peerManager types.PeerManager, sessionID []byte, slots []*batchSlot, handlers []types.Handler) *batchHandler {
+	return &batchHandler{
+		peerManager: peerManager,
+		sessionID:   sessionID,
+		slots:       slots,
+		handlers:    handlers,
+	}
+}
+
+func (p *batchHandler) MessageType() types.MessageType {
+	return types.MessageType(toBatchType(Type(p.handlers[0].MessageType())))
+}
+
+func (p *batchHandler) GetRequiredMessageCount() uint32 {
+	return p.handlers[0].GetRequiredMessageCount()
+}
+
+// IsHandled checks if the messages of all the signatures are handled. A batch message might be handled partially
+// if it fails in a signature.
+func (p *batchHandler) IsHandled(logger log.Logger, id string) bool {
+	for _, handler := range p.handlers {
+		if !handler.IsHandled(logger, id) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *batchHandler) HandleMessage(logger log.Logger, message types.Message) error {
+	msg := getMessage(message)
+	id := msg.GetId()
+	msgs := msg.GetBatch().GetMessages()
+	if len(msgs) != len(p.handlers) {
+		logger.Warn("Inconsistent batch size", "got", len(msgs), "expected", len(p.handlers))
+		return blame(ErrInconsistentBatchSize, msg)
+	}
+	for i, m := range msgs {
+		if m.GetId() != id || !bytes.Equal(m.GetSessionId(), p.slots[i].sessionID) {
+			logger.Warn("Inconsistent id or session id", "slot", i)
+			return blame(tss.ErrInvalidMsg, msg)
+		}
+	}
+
+	for i, handler := range p.handlers {
+		// Skip the signatures which have handled the message of the peer before
+		if handler.IsHandled(logger, id) {
+			continue
+		}
+		// The signatures except the first one reuse the homomorphic public key verified by the first one
+		if i > 0 && msgs[i].GetType() == Type_Pubkey {
+			p.slots[i].ph.peerPubkeys[id] = p.slots[0].ph.peers[id].pubkey.publicKey
+		}
+		err := handler.HandleMessage(logger, msgs[i])
+		if err != nil {
+			logger.Warn("Failed to handle message", "slot", i, "err", err)
+			return blame(err, msg)
+		}
+	}
+	return nil
+}
+
+func (p *batchHandler) Finalize(logger log.Logger) (types.Handler, error) {
+	handlers := make([]types.Handler, len(p.handlers))
+	for i, handler := range p.handlers {
+		var err error
+		handlers[i], err = handler.Finalize(logger)
+		if err != nil {
+			logger.Warn("Failed to finalize", "slot", i, "err", err)
+			return nil, err
+		}
+	}
+	// All the signatures are done in the same round
+	if handlers[0] == nil {
+		return nil, nil
+	}
+
+	// Send the messages of the next round of all the signatures together
+	slotMsgs := make([]map[string]*Message, len(p.slots))
+	for i, slot := range p.slots {
+		slotMsgs[i] = slot.pm.popMessages()
+	}
+	for id := range slotMsgs[0] {
+		msgs := make([]*Message, len(p.slots))
+		for i := range p.slots {
+			msgs[i] = slotMsgs[i][id]
+		}
+		p.peerManager.MustSend(id, p.newBatchMessage(msgs))
+	}
+	return newBatchHandler(p.peerManager, p.sessionID, p.slots, handlers), nil
+}
+
+func (p *batchHandler) newBatchMessage(msgs []*Message) *Message {
+	return &Message{
+		Type:      toBatchType(msgs[0].GetType()),
+		Id:        p.peerManager.SelfID(),
+		SessionId: p.sessionID,
+		Body: &Message_Batch{
+			Batch: &BodyBatch{
+				Messages: msgs,
+			},
+		},
+	}
+}
+
+// toBatchType returns the batch type of the message type of a signature
+func toBatchType(t Type) Type {
+	return t + Type_BatchPubkey
+}
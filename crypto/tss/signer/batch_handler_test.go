@@ -0,0 +1,194 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"errors"
+
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	"github.com/getamis/sirius/log"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("batch handler", func() {
+	var (
+		selfID     = "self-id"
+		peerID     = "peer-id"
+		unknownErr = errors.New("unknown error")
+
+		mockPeerManager *mocks.PeerManager
+		mockHandlers    []*mocks.Handler
+		bh              *batchHandler
+	)
+
+	BeforeEach(func() {
+		mockPeerManager = new(mocks.PeerManager)
+		mockHandlers = []*mocks.Handler{new(mocks.Handler), new(mocks.Handler)}
+		slots := make([]*batchSlot, len(mockHandlers))
+		handlers := make([]types.Handler, len(mockHandlers))
+		for i, h := range mockHandlers {
+			slots[i] = &batchSlot{
+				pm:        newSlotPeerManager(mockPeerManager),
+				sessionID: getSlotSessionID(sessionID, i),
+			}
+			handlers[i] = h
+		}
+		bh = newBatchHandler(mockPeerManager, sessionID, slots, handlers)
+	})
+
+	AfterEach(func() {
+		mockPeerManager.AssertExpectations(GinkgoT())
+		for _, h := range mockHandlers {
+			h.AssertExpectations(GinkgoT())
+		}
+	})
+
+	newSlotMessage := func(i int, t Type) *Message {
+		return &Message{
+			Type:      t,
+			Id:        peerID,
+			SessionId: getSlotSessionID(sessionID, i),
+			Body: &Message_Delta{
+				Delta: &BodyDelta{},
+			},
+		}
+	}
+
+	It("MessageType()", func() {
+		mockHandlers[0].On("MessageType").Return(types.MessageType(Type_Delta)).Once()
+		Expect(bh.MessageType()).Should(Equal(types.MessageType(Type_BatchDelta)))
+	})
+
+	Context("IsHandled()", func() {
+		It("handled by all the signatures", func() {
+			for _, h := range mockHandlers {
+				h.On("IsHandled", mock.Anything, peerID).Return(true).Once()
+			}
+			Expect(bh.IsHandled(log.Discard(), peerID)).Should(BeTrue())
+		})
+
+		It("handled by a part of the signatures", func() {
+			mockHandlers[0].On("IsHandled", mock.Anything, peerID).Return(true).Once()
+			mockHandlers[1].On("IsHandled", mock.Anything, peerID).Return(false).Once()
+			Expect(bh.IsHandled(log.Discard(), peerID)).Should(BeFalse())
+		})
+	})
+
+	Context("HandleMessage()", func() {
+		It("passes the messages to the signatures", func() {
+			msgs := []*Message{newSlotMessage(0, Type_Delta), newSlotMessage(1, Type_Delta)}
+			for i, h := range mockHandlers {
+				h.On("IsHandled", mock.Anything, peerID).Return(false).Once()
+				h.On("HandleMessage", mock.Anything, msgs[i]).Return(nil).Once()
+			}
+			mockPeerManager.On("SelfID").Return(peerID).Once()
+			Expect(bh.HandleMessage(log.Discard(), bh.newBatchMessage(msgs))).Should(BeNil())
+		})
+
+		It("skips the signatures which have handled the messages", func() {
+			msgs := []*Message{newSlotMessage(0, Type_Delta), newSlotMessage(1, Type_Delta)}
+			mockHandlers[0].On("IsHandled", mock.Anything, peerID).Return(true).Once()
+			mockHandlers[1].On("IsHandled", mock.Anything, peerID).Return(false).Once()
+			mockHandlers[1].On("HandleMessage", mock.Anything, msgs[1]).Return(nil).Once()
+			mockPeerManager.On("SelfID").Return(peerID).Once()
+			Expect(bh.HandleMessage(log.Discard(), bh.newBatchMessage(msgs))).Should(BeNil())
+		})
+
+		It("inconsistent batch size", func() {
+			mockPeerManager.On("SelfID").Return(peerID).Once()
+			msg := bh.newBatchMessage([]*Message{newSlotMessage(0, Type_Delta)})
+			Expect(bh.HandleMessage(log.Discard(), msg)).Should(MatchError(ErrInconsistentBatchSize))
+		})
+
+		It("replayed message of another signature", func() {
+			mockPeerManager.On("SelfID").Return(peerID).Once()
+			msg := bh.newBatchMessage([]*Message{newSlotMessage(0, Type_Delta), newSlotMessage(0, Type_Delta)})
+			Expect(bh.HandleMessage(log.Discard(), msg)).Should(MatchError(tss.ErrInvalidMsg))
+		})
+
+		It("inconsistent id", func() {
+			mockPeerManager.On("SelfID").Return("other-id").Once()
+			msg := bh.newBatchMessage([]*Message{newSlotMessage(0, Type_Delta), newSlotMessage(1, Type_Delta)})
+			Expect(bh.HandleMessage(log.Discard(), msg)).Should(MatchError(tss.ErrInvalidMsg))
+		})
+
+		It("failed to handle the message of a signature", func() {
+			msgs := []*Message{newSlotMessage(0, Type_Delta), newSlotMessage(1, Type_Delta)}
+			mockHandlers[0].On("IsHandled", mock.Anything, peerID).Return(false).Once()
+			mockHandlers[0].On("HandleMessage", mock.Anything, msgs[0]).Return(unknownErr).Once()
+			mockPeerManager.On("SelfID").Return(peerID).Once()
+			Expect(bh.HandleMessage(log.Discard(), bh.newBatchMessage(msgs))).Should(MatchError(unknownErr))
+		})
+
+		It("blames the sender of the batch message", func() {
+			msgs := []*Message{newSlotMessage(0, Type_Delta), newSlotMessage(1, Type_Delta)}
+			mockHandlers[0].On("IsHandled", mock.Anything, peerID).Return(false).Once()
+			mockHandlers[0].On("HandleMessage", mock.Anything, msgs[0]).Return(blame(unknownErr, msgs[0])).Once()
+			mockPeerManager.On("SelfID").Return(peerID).Once()
+			msg := bh.newBatchMessage(msgs)
+			err := bh.HandleMessage(log.Discard(), msg)
+			Expect(err).Should(MatchError(unknownErr))
+			expectBlame(err, msg)
+		})
+	})
+
+	Context("Finalize()", func() {
+		It("sends the messages of all the signatures together", func() {
+			msgs := make([]*Message, len(mockHandlers))
+			for i, h := range mockHandlers {
+				slot := bh.slots[i]
+				msgs[i] = newSlotMessage(i, Type_ProofAi)
+				msg := msgs[i]
+				h.On("Finalize", mock.Anything).Run(func(args mock.Arguments) {
+					slot.pm.MustSend(peerID, msg)
+				}).Return(new(mocks.Handler), nil).Once()
+			}
+			mockPeerManager.On("SelfID").Return(selfID).Once()
+			mockPeerManager.On("MustSend", peerID, mock.Anything).Run(func(args mock.Arguments) {
+				msg := args.Get(1).(*Message)
+				Expect(msg.GetType()).Should(Equal(Type_BatchProofAi))
+				Expect(msg.GetId()).Should(Equal(selfID))
+				Expect(msg.GetBatch().GetMessages()).Should(Equal(msgs))
+			}).Once()
+			got, err := bh.Finalize(log.Discard())
+			Expect(err).Should(BeNil())
+			Expect(got.(*batchHandler).handlers).Should(HaveLen(len(mockHandlers)))
+		})
+
+		It("sends nothing if a signature fails", func() {
+			slot := bh.slots[0]
+			mockHandlers[0].On("Finalize", mock.Anything).Run(func(args mock.Arguments) {
+				slot.pm.MustSend(peerID, newSlotMessage(0, Type_ProofAi))
+			}).Return(new(mocks.Handler), nil).Once()
+			mockHandlers[1].On("Finalize", mock.Anything).Return(nil, unknownErr).Once()
+			got, err := bh.Finalize(log.Discard())
+			Expect(err).Should(Equal(unknownErr))
+			Expect(got).Should(BeNil())
+		})
+
+		It("done", func() {
+			for _, h := range mockHandlers {
+				h.On("Finalize", mock.Anything).Return(nil, nil).Once()
+			}
+			got, err := bh.Finalize(log.Discard())
+			Expect(err).Should(BeNil())
+			Expect(got).Should(BeNil())
+		})
+	})
+})
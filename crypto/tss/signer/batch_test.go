@@ -0,0 +1,297 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	proto "github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("BatchSigner", func() {
+	var (
+		curve     = btcec.S256()
+		expPublic = ecpointgrouplaw.ScalarBaseMult(curve, privateKey)
+		ss        = [][]*big.Int{
+			{shareX, shareY, big.NewInt(0)},
+			{shareX2, shareY2, big.NewInt(0)},
+			{shareX3, shareY3, big.NewInt(0)},
+		}
+		msgs = [][]byte{
+			[]byte("9ca9bf7e9c8b1e1c2b6f4e0e1c2f2e6a"),
+			[]byte("another message to be signed...."),
+			[]byte("the last message of the batch..."),
+		}
+	)
+
+	It("signs all the messages in one session", func() {
+		signers, listeners, _ := newBatchSigners(expPublic, ss, msgs)
+		doneChs := make([]chan struct{}, 0, len(listeners))
+		for _, l := range listeners {
+			doneCh := make(chan struct{})
+			doneChs = append(doneChs, doneCh)
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+		for _, s := range signers {
+			r, err := s.GetResults()
+			Expect(err).Should(Equal(tss.ErrNotReady))
+			Expect(r).Should(BeNil())
+			s.Start()
+		}
+
+		// Send out the pubkey messages
+		for fromID, fromS := range signers {
+			msg := fromS.GetPubkeyMessage()
+			Expect(msg.GetType()).Should(Equal(Type_BatchPubkey))
+			pubkeyMsgs := msg.GetBatch().GetMessages()
+			Expect(pubkeyMsgs).Should(HaveLen(len(msgs)))
+			// Only the first signature carries the homomorphic public key
+			for i, m := range pubkeyMsgs {
+				if i == 0 {
+					Expect(m.GetPubkey().GetPubkey()).ShouldNot(BeEmpty())
+				} else {
+					Expect(m.GetPubkey().GetPubkey()).Should(BeEmpty())
+				}
+			}
+			for toID, toS := range signers {
+				if fromID == toID {
+					continue
+				}
+				Expect(toS.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		ecdsaPublicKey := &ecdsa.PublicKey{
+			Curve: expPublic.GetCurve(),
+			X:     expPublic.GetX(),
+			Y:     expPublic.GetY(),
+		}
+		var expResults []*Result
+		for _, s := range signers {
+			s.Stop()
+			results, err := s.GetResults()
+			Expect(err).Should(BeNil())
+			Expect(results).Should(HaveLen(len(msgs)))
+			for i, r := range results {
+				Expect(ecdsa.Verify(ecdsaPublicKey, msgs[i], r.R, r.S)).Should(BeTrue())
+			}
+			// All the signers get the same signatures
+			if expResults != nil {
+				Expect(results).Should(Equal(expResults))
+			}
+			expResults = results
+		}
+		// The nonces of the signatures are different
+		Expect(expResults[0].R).ShouldNot(Equal(expResults[1].R))
+		Expect(expResults[1].R).ShouldNot(Equal(expResults[2].R))
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("aborts the batch if a signature fails", func() {
+		badID, victimID := getID(0), getID(1)
+		signers, listeners, pms := newBatchSigners(expPublic, ss, msgs)
+		// The bad peer sends a wrong encrypted k of the second signature to the victim
+		pms[badID].tamper = func(id string, msg *Message) {
+			if msg.Type == Type_BatchEncK && id == victimID {
+				msg.GetBatch().GetMessages()[1].GetEncK().Enck = []byte("invalid enck")
+			}
+		}
+		failedChs := make(map[string]chan struct{}, len(listeners))
+		for id, l := range listeners {
+			failedCh := make(chan struct{})
+			failedChs[id] = failedCh
+			l.On("OnStateChanged", types.StateInit, types.StateFailed).Run(func(args mock.Arguments) {
+				close(failedCh)
+			}).Once()
+		}
+		for _, s := range signers {
+			s.Start()
+		}
+		for fromID, fromS := range signers {
+			msg := fromS.GetPubkeyMessage()
+			for toID, toS := range signers {
+				if fromID == toID {
+					continue
+				}
+				Expect(toS.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		<-failedChs[victimID]
+		failure := signers[victimID].GetFailure()
+		Expect(failure).ShouldNot(BeNil())
+		Expect(failure.Culprits).Should(Equal([]string{badID}))
+
+		// The others are waiting for the mta messages of the victim until they are stopped
+		for _, s := range signers {
+			s.Stop()
+		}
+		for _, failedCh := range failedChs {
+			<-failedCh
+		}
+		for _, s := range signers {
+			rs, err := s.GetResults()
+			Expect(err).Should(Equal(tss.ErrNotReady))
+			Expect(rs).Should(BeNil())
+		}
+		// The victim sends nothing of the other signatures after the failure
+		Expect(pms[victimID].getSentTypes()).Should(Equal(map[Type]bool{
+			Type_BatchEncK: true,
+		}))
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	Context("NewBatchSigner(), negative cases", func() {
+		It("invalid batch size", func() {
+			s, err := NewBatchSigner(newBatchPeerManager(getID(0), 2), sessionID, expPublic, nil, shareY, 3, nil, nil, nil, nil)
+			Expect(err).Should(Equal(ErrInvalidBatchSize))
+			Expect(s).Should(BeNil())
+		})
+
+		It("empty session id", func() {
+			s, err := NewBatchSigner(newBatchPeerManager(getID(0), 2), nil, expPublic, nil, shareY, 3, nil, nil, msgs, nil)
+			Expect(err).Should(Equal(tss.ErrEmptySessionID))
+			Expect(s).Should(BeNil())
+		})
+
+		It("unauthorized signers", func() {
+			bks := map[string]*birkhoffinterpolation.BkParameter{
+				getID(0): birkhoffinterpolation.NewBkParameter(shareX, 0),
+				getID(1): birkhoffinterpolation.NewBkParameter(shareX2, 0),
+				getID(2): birkhoffinterpolation.NewBkParameter(shareX3, 0),
+			}
+			pm := newBatchPeerManager(getID(0), 1)
+			pm.signers = map[string]*BatchSigner{getID(0): nil, getID(1): nil}
+			s, err := NewBatchSigner(pm, sessionID, expPublic, nil, shareY, 3, bks, []string{getID(0), getID(1)}, msgs, nil)
+			Expect(errors.Is(err, birkhoffinterpolation.ErrEqualOrLargerThreshold)).Should(BeTrue())
+			Expect(s).Should(BeNil())
+		})
+
+		It("inconsistent signers and peers", func() {
+			bks := map[string]*birkhoffinterpolation.BkParameter{
+				getID(0): birkhoffinterpolation.NewBkParameter(shareX, 0),
+				getID(1): birkhoffinterpolation.NewBkParameter(shareX2, 0),
+			}
+			pm := newBatchPeerManager(getID(0), 1)
+			pm.signers = map[string]*BatchSigner{getID(0): nil, getID(2): nil}
+			s, err := NewBatchSigner(pm, sessionID, expPublic, nil, shareY, 2, bks, []string{getID(0), getID(1)}, msgs, nil)
+			Expect(err).Should(Equal(ErrInconsistentSigners))
+			Expect(s).Should(BeNil())
+		})
+	})
+})
+
+// batchPeerManager delivers the messages of the batch signers. The messages could be tampered before sent, and
+// the types of the sent messages are recorded.
+type batchPeerManager struct {
+	id       string
+	numPeers uint32
+	signers  map[string]*BatchSigner
+	tamper   func(id string, msg *Message)
+
+	mu        sync.Mutex
+	sentTypes map[Type]bool
+}
+
+func newBatchPeerManager(id string, numPeers int) *batchPeerManager {
+	return &batchPeerManager{
+		id:        id,
+		numPeers:  uint32(numPeers),
+		sentTypes: make(map[Type]bool),
+	}
+}
+
+func (p *batchPeerManager) NumPeers() uint32 {
+	return p.numPeers
+}
+
+func (p *batchPeerManager) SelfID() string {
+	return p.id
+}
+
+func (p *batchPeerManager) PeerIDs() []string {
+	ids := make([]string, 0, len(p.signers))
+	for id := range p.signers {
+		if id != p.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (p *batchPeerManager) MustSend(id string, message proto.Message) {
+	// The messages in a batch are shared by the peers, so tamper a copy
+	msg := proto.Clone(message).(*Message)
+	if p.tamper != nil {
+		p.tamper(id, msg)
+	}
+	p.mu.Lock()
+	p.sentTypes[msg.Type] = true
+	p.mu.Unlock()
+	Expect(p.signers[id].AddMessage(msg)).Should(BeNil())
+}
+
+func (p *batchPeerManager) getSentTypes() map[Type]bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sentTypes
+}
+
+func newBatchSigners(expPublic *ecpointgrouplaw.ECPoint, ss [][]*big.Int, msgs [][]byte) (map[string]*BatchSigner, map[string]*mocks.StateChangedListener, map[string]*batchPeerManager) {
+	threshold := len(ss)
+	signers := make(map[string]*BatchSigner, threshold)
+	listeners := make(map[string]*mocks.StateChangedListener, threshold)
+	pms := make(map[string]*batchPeerManager, threshold)
+
+	bks := make(map[string]*birkhoffinterpolation.BkParameter, threshold)
+	signerIDs := make([]string, threshold)
+	for i := 0; i < threshold; i++ {
+		signerIDs[i] = getID(i)
+		bks[signerIDs[i]] = birkhoffinterpolation.NewBkParameter(ss[i][0], uint32(ss[i][2].Uint64()))
+		// Add the ids first since the peer ids are checked in the constructor
+		signers[signerIDs[i]] = nil
+	}
+
+	for i := 0; i < threshold; i++ {
+		id := getID(i)
+		pms[id] = newBatchPeerManager(id, threshold-1)
+		pms[id].signers = signers
+		listeners[id] = new(mocks.StateChangedListener)
+		var err error
+		signers[id], err = NewBatchSigner(pms[id], sessionID, expPublic, getTestHomo(id), ss[i][1], uint32(threshold), bks, signerIDs, msgs, listeners[id])
+		Expect(err).Should(BeNil())
+	}
+	return signers, listeners, pms
+}
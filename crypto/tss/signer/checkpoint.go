@@ -0,0 +1,331 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/mta"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+const checkpointLabel = "signer"
+
+// Checkpoint returns the encrypted state of the process, including the share, the nonce k, the message to sign
+// and the collected messages. It could be restored by RestoreSigner after the process crashes.
+func (s *Signer) Checkpoint(key []byte) ([]byte, error) {
+	var state *State
+	err := s.Snapshot(func(handler types.Handler, msgs []types.Message, echoes []*message.EchoMessage) error {
+		var err error
+		state, err = s.ph.getState(handler, msgs, echoes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, msgType := range s.EchoBroadcastTypes() {
+		state.EchoTypes = append(state.EchoTypes, int32(msgType))
+	}
+	return tss.EncryptCheckpoint(key, checkpointLabel, state)
+}
+
+// RestoreSigner restores the process from the checkpoint. The process continues from the round in which
+// the checkpoint was made. The peer manager must manage the same peers as before, and the homo crypto
+// must be the same one which was used to new the signer. The message to sign is restored from the checkpoint,
+// so the nonce is never reused to sign another message.
+func RestoreSigner(peerManager types.PeerManager, key []byte, checkpoint []byte, homo homo.Crypto, listener types.StateChangedListener) (*Signer, error) {
+	state := &State{}
+	err := tss.DecryptCheckpoint(key, checkpointLabel, checkpoint, state)
+	if err != nil {
+		return nil, err
+	}
+	ph, handler, err := restoreHandler(peerManager, homo, state)
+	if err != nil {
+		return nil, err
+	}
+	s := newSignerWithCurrentHandler(peerManager, state.SessionId, listener, ph, handler)
+	msgs := make([]types.Message, len(state.Messages))
+	for i, msg := range state.Messages {
+		msgs[i] = msg
+	}
+	// The echo broadcast is enabled by the caller, so it's enabled again before the echoes are restored
+	if len(state.EchoTypes) > 0 {
+		echoTypes := make([]types.MessageType, len(state.EchoTypes))
+		for i, msgType := range state.EchoTypes {
+			echoTypes[i] = types.MessageType(msgType)
+		}
+		s.EnableEchoBroadcast(echoTypes...)
+	}
+	err = s.Restore(msgs, state.Echoes)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (p *pubkeyHandler) getState(handler types.Handler, msgs []types.Message, echoes []*message.EchoMessage) (*State, error) {
+	publicKey, err := p.publicKey.ToEcPointMessage()
+	if err != nil {
+		return nil, err
+	}
+	bks := make(map[string]*birkhoffinterpolation.BkParameterMessage, len(p.bks))
+	for id, bk := range p.bks {
+		bks[id] = bk.ToMessage()
+	}
+	mtaState := p.aiMta.GetState()
+	state := &State{
+		Round:             Type(handler.MessageType()),
+		SessionId:         p.sessionID,
+		PublicKey:         publicKey,
+		Share:             p.secret.Bytes(),
+		Bks:               bks,
+		Msg:               p.msg,
+		HomoPubkey:        p.homo.GetPubKey().ToPubKeyBytes(),
+		SessionHomoPubkey: p.homoPubkey,
+		K:                 mtaState.K.Bytes(),
+		A:                 mtaState.A.Bytes(),
+		EncK:              mtaState.EncK,
+		AgDecommitment:    p.agCommitmenter.GetDecommitmentMessage(),
+		EncKs:             make(map[string]*EncKState),
+		Messages:          make([]*Message, len(msgs)),
+		Echoes:            echoes,
+	}
+	for id, peer := range p.peers {
+		if peer.enck == nil {
+			continue
+		}
+		state.EncKs[id] = &EncKState{
+			AiBeta: new(big.Int).Neg(peer.enck.aiBeta).Bytes(),
+			WiBeta: new(big.Int).Neg(peer.enck.wiBeta).Bytes(),
+			MtaMsg: peer.enck.mtaMsg,
+		}
+	}
+	for i, msg := range msgs {
+		state.Messages[i] = getMessage(msg)
+	}
+	if p.peerPubkeys != nil {
+		state.PeerHomoPubkeys = make(map[string][]byte, len(p.peerPubkeys))
+		for id, pubkey := range p.peerPubkeys {
+			state.PeerHomoPubkeys[id] = pubkey.ToPubKeyBytes()
+		}
+	}
+
+	var (
+		ph *proofAiHandler
+		dh *decommitViAiHandler
+	)
+	switch h := handler.(type) {
+	case *commitViAiHandler:
+		ph = h.proofAiHandler
+	case *decommitViAiHandler:
+		ph = h.proofAiHandler
+	case *commitUiTiHandler:
+		ph, dh = h.proofAiHandler, h.decommitViAiHandler
+	case *decommitUiTiHandler:
+		ph, dh = h.proofAiHandler, h.decommitViAiHandler
+	case *siHandler:
+		ph, dh = h.proofAiHandler, h.decommitViAiHandler
+	}
+	if ph != nil {
+		state.Li = ph.li.Bytes()
+		state.LiProof = ph.liProof
+		state.ViDecommitment = ph.viCommitmenter.GetDecommitmentMessage()
+		state.RhoI = ph.rhoI.Bytes()
+		state.RhoIProof = ph.rhoIProof
+		state.AiDecommitment = ph.aiCommitmenter.GetDecommitmentMessage()
+		state.KiRDecommitment = ph.kiRCommitmenter.GetDecommitmentMessage()
+		state.SigmaIRDecommitment = ph.sigmaIRCommitmenter.GetDecommitmentMessage()
+	}
+	if dh != nil {
+		state.UiDecommitment = dh.uiCommitter.GetDecommitmentMessage()
+		state.TiDecommitment = dh.tiCommitter.GetDecommitmentMessage()
+		state.TiProof = dh.tiProof
+	}
+	return state, nil
+}
+
+// restoreHandler rebuilds the handler of the round in the state. The messages of the previous rounds are
+// handled and finalized again without sending out any messages. The random values which were sent out before
+// are restored from the state.
+func restoreHandler(peerManager types.PeerManager, homo homo.Crypto, state *State) (*pubkeyHandler, types.Handler, error) {
+	if err := tss.EnsureSessionID(state.SessionId); err != nil {
+		return nil, nil, err
+	}
+	if !bytes.Equal(homo.GetPubKey().ToPubKeyBytes(), state.HomoPubkey) {
+		log.Warn("Inconsistent homo public key")
+		return nil, nil, tss.ErrInvalidCheckpoint
+	}
+	publicKey, err := state.GetPublicKey().ToPoint()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(state.Bks) != int(peerManager.NumPeers()+1) {
+		return nil, nil, tss.ErrInconsistentPeerNumAndBks
+	}
+	curve := publicKey.GetCurve()
+	bks := make(map[string]*birkhoffinterpolation.BkParameter, len(state.Bks))
+	for id, bk := range state.Bks {
+		bks[id] = bk.ToBk()
+	}
+	aiMta, err := mta.NewMtaWithState(curve.Params().N, homo, &mta.State{
+		K:    new(big.Int).SetBytes(state.K),
+		A:    new(big.Int).SetBytes(state.A),
+		EncK: state.EncK,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	ph, err := newPubkeyHandlerWithMta(publicKey, peerManager, state.SessionId, homo, new(big.Int).SetBytes(state.Share), bks, state.Msg, aiMta)
+	if err != nil {
+		return nil, nil, err
+	}
+	agCommitmenter, ag, err := tss.NewCommitterByDecommitment(log.Discard(), state.SessionId, state.AgDecommitment)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ag.Equal(aiMta.GetAG(curve)) {
+		return nil, nil, tss.ErrInvalidCheckpoint
+	}
+	ph.agCommitmenter = agCommitmenter
+	// Resend the same public key as before
+	if len(state.SessionHomoPubkey) > 0 {
+		ph.homoPubkey = state.SessionHomoPubkey
+	}
+	if len(state.PeerHomoPubkeys) > 0 {
+		err = ph.restorePeerPubkeys(state.PeerHomoPubkeys)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Replay the messages of the previous rounds silently
+	ph.peerManager = tss.NewSilentPeerManager(peerManager)
+	defer func() {
+		ph.peerManager = peerManager
+	}()
+	var handler types.Handler = ph
+	for handler.MessageType() < types.MessageType(state.Round) {
+		for _, msg := range state.Messages {
+			if msg.GetMessageType() != handler.MessageType() {
+				continue
+			}
+			err = replayMessage(handler, state, msg)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		next, err := handler.Finalize(log.Discard())
+		if err != nil {
+			return nil, nil, err
+		}
+		switch h := next.(type) {
+		case nil:
+			return nil, nil, tss.ErrInvalidCheckpoint
+		case *commitViAiHandler:
+			err = h.restoreViAi(state)
+		case *commitUiTiHandler:
+			err = h.restoreUiTi(state)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		handler = next
+	}
+	if handler.MessageType() != types.MessageType(state.Round) {
+		return nil, nil, tss.ErrInvalidCheckpoint
+	}
+	return ph, handler, nil
+}
+
+// restorePeerPubkeys restores the homomorphic public keys of the peers verified before the signer started.
+func (p *pubkeyHandler) restorePeerPubkeys(pubkeys map[string][]byte) error {
+	peerPubkeys := make(map[string]homo.Pubkey, len(pubkeys))
+	for id, bs := range pubkeys {
+		pubkey, err := p.homo.NewPubKeyFromBytes(bs)
+		if err != nil {
+			return err
+		}
+		peerPubkeys[id] = pubkey
+	}
+	return p.setPeerPubkeys(peerPubkeys)
+}
+
+// replayMessage handles the message again. The mta results of EncK messages are random and have been sent out,
+// so they are restored from the state instead of being computed again.
+func replayMessage(handler types.Handler, state *State, msg *Message) error {
+	h, ok := handler.(*encKHandler)
+	if !ok {
+		return handler.HandleMessage(log.Discard(), msg)
+	}
+	peer, ok := h.peers[msg.GetId()]
+	if !ok {
+		return ErrPeerNotFound
+	}
+	enck, ok := state.EncKs[msg.GetId()]
+	if !ok {
+		return tss.ErrInvalidCheckpoint
+	}
+	peer.enck = &encKData{
+		aiBeta: new(big.Int).Neg(new(big.Int).SetBytes(enck.AiBeta)),
+		wiBeta: new(big.Int).Neg(new(big.Int).SetBytes(enck.WiBeta)),
+		mtaMsg: enck.MtaMsg,
+	}
+	return peer.AddMessage(msg)
+}
+
+func (p *commitViAiHandler) restoreViAi(state *State) error {
+	var err error
+	p.viCommitmenter, p.vi, err = tss.NewCommitterByDecommitment(log.Discard(), p.sessionID, state.ViDecommitment)
+	if err != nil {
+		return err
+	}
+	p.aiCommitmenter, p.ai, err = tss.NewCommitterByDecommitment(log.Discard(), p.sessionID, state.AiDecommitment)
+	if err != nil {
+		return err
+	}
+	p.kiRCommitmenter, p.kiR, err = tss.NewCommitterByDecommitment(log.Discard(), p.sessionID, state.KiRDecommitment)
+	if err != nil {
+		return err
+	}
+	p.sigmaIRCommitmenter, p.sigmaIR, err = tss.NewCommitterByDecommitment(log.Discard(), p.sessionID, state.SigmaIRDecommitment)
+	if err != nil {
+		return err
+	}
+	p.li = new(big.Int).SetBytes(state.Li)
+	p.liProof = state.LiProof
+	p.rhoI = new(big.Int).SetBytes(state.RhoI)
+	p.rhoIProof = state.RhoIProof
+	return nil
+}
+
+func (p *commitUiTiHandler) restoreUiTi(state *State) error {
+	var err error
+	p.uiCommitter, p.ui, err = tss.NewCommitterByDecommitment(log.Discard(), p.sessionID, state.UiDecommitment)
+	if err != nil {
+		return err
+	}
+	p.tiCommitter, p.ti, err = tss.NewCommitterByDecommitment(log.Discard(), p.sessionID, state.TiDecommitment)
+	if err != nil {
+		return err
+	}
+	p.tiProof = state.TiProof
+	return nil
+}
@@ -0,0 +1,414 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/getamis/alice/crypto/tss/signer/checkpoint.proto
+
+package signer
+
+import (
+	fmt "fmt"
+	birkhoffinterpolation "github.com/getamis/alice/crypto/birkhoffinterpolation"
+	commitment "github.com/getamis/alice/crypto/commitment"
+	ecpointgrouplaw "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	message "github.com/getamis/alice/crypto/tss/message"
+	zkproof "github.com/getamis/alice/crypto/zkproof"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// State is the checkpoint of a signer process
+type State struct {
+	Round     Type                                                 `protobuf:"varint,1,opt,name=round,proto3,enum=signer.Type" json:"round,omitempty"`
+	SessionId []byte                                               `protobuf:"bytes,2,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	PublicKey *ecpointgrouplaw.EcPointMessage                      `protobuf:"bytes,3,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Share     []byte                                               `protobuf:"bytes,4,opt,name=share,proto3" json:"share,omitempty"`
+	Bks       map[string]*birkhoffinterpolation.BkParameterMessage `protobuf:"bytes,5,rep,name=bks,proto3" json:"bks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// msg is the message to sign. It's bound to the nonce k, so it cannot be changed after restoring.
+	Msg            []byte                              `protobuf:"bytes,6,opt,name=msg,proto3" json:"msg,omitempty"`
+	HomoPubkey     []byte                              `protobuf:"bytes,7,opt,name=homoPubkey,proto3" json:"homoPubkey,omitempty"`
+	K              []byte                              `protobuf:"bytes,8,opt,name=k,proto3" json:"k,omitempty"`
+	A              []byte                              `protobuf:"bytes,9,opt,name=a,proto3" json:"a,omitempty"`
+	EncK           []byte                              `protobuf:"bytes,10,opt,name=encK,proto3" json:"encK,omitempty"`
+	AgDecommitment *commitment.HashDecommitmentMessage `protobuf:"bytes,11,opt,name=agDecommitment,proto3" json:"agDecommitment,omitempty"`
+	// encKs are the mta results of the handled EncK messages
+	EncKs map[string]*EncKState `protobuf:"bytes,12,rep,name=encKs,proto3" json:"encKs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The fields below are set after the proof ai round
+	Li             []byte                              `protobuf:"bytes,13,opt,name=li,proto3" json:"li,omitempty"`
+	LiProof        *zkproof.SchnorrProofMessage        `protobuf:"bytes,14,opt,name=liProof,proto3" json:"liProof,omitempty"`
+	ViDecommitment *commitment.HashDecommitmentMessage `protobuf:"bytes,15,opt,name=viDecommitment,proto3" json:"viDecommitment,omitempty"`
+	RhoI           []byte                              `protobuf:"bytes,16,opt,name=rhoI,proto3" json:"rhoI,omitempty"`
+	RhoIProof      *zkproof.SchnorrProofMessage        `protobuf:"bytes,17,opt,name=rhoIProof,proto3" json:"rhoIProof,omitempty"`
+	AiDecommitment *commitment.HashDecommitmentMessage `protobuf:"bytes,18,opt,name=aiDecommitment,proto3" json:"aiDecommitment,omitempty"`
+	// The fields below are set after the decommit vi ai round
+	UiDecommitment *commitment.HashDecommitmentMessage `protobuf:"bytes,19,opt,name=uiDecommitment,proto3" json:"uiDecommitment,omitempty"`
+	TiDecommitment *commitment.HashDecommitmentMessage `protobuf:"bytes,20,opt,name=tiDecommitment,proto3" json:"tiDecommitment,omitempty"`
+	// messages are all the accepted messages
+	Messages []*Message `protobuf:"bytes,21,rep,name=messages,proto3" json:"messages,omitempty"`
+	// peerHomoPubkeys are the homomorphic public keys of the peers verified before the signer started
+	PeerHomoPubkeys map[string][]byte `protobuf:"bytes,22,rep,name=peerHomoPubkeys,proto3" json:"peerHomoPubkeys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The fields below are set after the proof ai round
+	KiRDecommitment     *commitment.HashDecommitmentMessage `protobuf:"bytes,23,opt,name=kiRDecommitment,proto3" json:"kiRDecommitment,omitempty"`
+	SigmaIRDecommitment *commitment.HashDecommitmentMessage `protobuf:"bytes,24,opt,name=sigmaIRDecommitment,proto3" json:"sigmaIRDecommitment,omitempty"`
+	// The field below is set after the decommit vi ai round
+	TiProof *zkproof.DLEQMessage `protobuf:"bytes,25,opt,name=tiProof,proto3" json:"tiProof,omitempty"`
+	// sessionHomoPubkey is our homomorphic public key with the proofs bound to the session if it has been sent out
+	SessionHomoPubkey []byte `protobuf:"bytes,26,opt,name=sessionHomoPubkey,proto3" json:"sessionHomoPubkey,omitempty"`
+	// echoes are all the accepted echo messages of the rounds sent by echo broadcast
+	Echoes []*message.EchoMessage `protobuf:"bytes,27,rep,name=echoes,proto3" json:"echoes,omitempty"`
+	// echoTypes are the message types of the rounds sent by echo broadcast, which are enabled again after restoring
+	EchoTypes            []int32  `protobuf:"varint,28,rep,packed,name=echoTypes,proto3" json:"echoTypes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *State) Reset()         { *m = State{} }
+func (m *State) String() string { return proto.CompactTextString(m) }
+func (*State) ProtoMessage()    {}
+func (*State) Descriptor() ([]byte, []int) {
+	return fileDescriptor_27e00e41eafc720e, []int{0}
+}
+
+func (m *State) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_State.Unmarshal(m, b)
+}
+func (m *State) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_State.Marshal(b, m, deterministic)
+}
+func (m *State) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_State.Merge(m, src)
+}
+func (m *State) XXX_Size() int {
+	return xxx_messageInfo_State.Size(m)
+}
+func (m *State) XXX_DiscardUnknown() {
+	xxx_messageInfo_State.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_State proto.InternalMessageInfo
+
+func (m *State) GetRound() Type {
+	if m != nil {
+		return m.Round
+	}
+	return Type_Pubkey
+}
+
+func (m *State) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *State) GetPublicKey() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *State) GetShare() []byte {
+	if m != nil {
+		return m.Share
+	}
+	return nil
+}
+
+func (m *State) GetBks() map[string]*birkhoffinterpolation.BkParameterMessage {
+	if m != nil {
+		return m.Bks
+	}
+	return nil
+}
+
+func (m *State) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *State) GetHomoPubkey() []byte {
+	if m != nil {
+		return m.HomoPubkey
+	}
+	return nil
+}
+
+func (m *State) GetK() []byte {
+	if m != nil {
+		return m.K
+	}
+	return nil
+}
+
+func (m *State) GetA() []byte {
+	if m != nil {
+		return m.A
+	}
+	return nil
+}
+
+func (m *State) GetEncK() []byte {
+	if m != nil {
+		return m.EncK
+	}
+	return nil
+}
+
+func (m *State) GetAgDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.AgDecommitment
+	}
+	return nil
+}
+
+func (m *State) GetEncKs() map[string]*EncKState {
+	if m != nil {
+		return m.EncKs
+	}
+	return nil
+}
+
+func (m *State) GetLi() []byte {
+	if m != nil {
+		return m.Li
+	}
+	return nil
+}
+
+func (m *State) GetLiProof() *zkproof.SchnorrProofMessage {
+	if m != nil {
+		return m.LiProof
+	}
+	return nil
+}
+
+func (m *State) GetViDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.ViDecommitment
+	}
+	return nil
+}
+
+func (m *State) GetRhoI() []byte {
+	if m != nil {
+		return m.RhoI
+	}
+	return nil
+}
+
+func (m *State) GetRhoIProof() *zkproof.SchnorrProofMessage {
+	if m != nil {
+		return m.RhoIProof
+	}
+	return nil
+}
+
+func (m *State) GetAiDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.AiDecommitment
+	}
+	return nil
+}
+
+func (m *State) GetUiDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.UiDecommitment
+	}
+	return nil
+}
+
+func (m *State) GetTiDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.TiDecommitment
+	}
+	return nil
+}
+
+func (m *State) GetMessages() []*Message {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func (m *State) GetPeerHomoPubkeys() map[string][]byte {
+	if m != nil {
+		return m.PeerHomoPubkeys
+	}
+	return nil
+}
+
+func (m *State) GetKiRDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.KiRDecommitment
+	}
+	return nil
+}
+
+func (m *State) GetSigmaIRDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.SigmaIRDecommitment
+	}
+	return nil
+}
+
+func (m *State) GetTiProof() *zkproof.DLEQMessage {
+	if m != nil {
+		return m.TiProof
+	}
+	return nil
+}
+
+func (m *State) GetSessionHomoPubkey() []byte {
+	if m != nil {
+		return m.SessionHomoPubkey
+	}
+	return nil
+}
+
+func (m *State) GetEchoes() []*message.EchoMessage {
+	if m != nil {
+		return m.Echoes
+	}
+	return nil
+}
+
+func (m *State) GetEchoTypes() []int32 {
+	if m != nil {
+		return m.EchoTypes
+	}
+	return nil
+}
+
+type EncKState struct {
+	// aiBeta and wiBeta are the negative betas
+	AiBeta               []byte   `protobuf:"bytes,1,opt,name=aiBeta,proto3" json:"aiBeta,omitempty"`
+	WiBeta               []byte   `protobuf:"bytes,2,opt,name=wiBeta,proto3" json:"wiBeta,omitempty"`
+	MtaMsg               *Message `protobuf:"bytes,3,opt,name=mtaMsg,proto3" json:"mtaMsg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EncKState) Reset()         { *m = EncKState{} }
+func (m *EncKState) String() string { return proto.CompactTextString(m) }
+func (*EncKState) ProtoMessage()    {}
+func (*EncKState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_27e00e41eafc720e, []int{1}
+}
+
+func (m *EncKState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncKState.Unmarshal(m, b)
+}
+func (m *EncKState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncKState.Marshal(b, m, deterministic)
+}
+func (m *EncKState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncKState.Merge(m, src)
+}
+func (m *EncKState) XXX_Size() int {
+	return xxx_messageInfo_EncKState.Size(m)
+}
+func (m *EncKState) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncKState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncKState proto.InternalMessageInfo
+
+func (m *EncKState) GetAiBeta() []byte {
+	if m != nil {
+		return m.AiBeta
+	}
+	return nil
+}
+
+func (m *EncKState) GetWiBeta() []byte {
+	if m != nil {
+		return m.WiBeta
+	}
+	return nil
+}
+
+func (m *EncKState) GetMtaMsg() *Message {
+	if m != nil {
+		return m.MtaMsg
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*State)(nil), "signer.State")
+	proto.RegisterMapType((map[string]*birkhoffinterpolation.BkParameterMessage)(nil), "signer.State.BksEntry")
+	proto.RegisterMapType((map[string]*EncKState)(nil), "signer.State.EncKsEntry")
+	proto.RegisterMapType((map[string][]byte)(nil), "signer.State.PeerHomoPubkeysEntry")
+	proto.RegisterType((*EncKState)(nil), "signer.EncKState")
+}
+
+func init() {
+	proto.RegisterFile("github.com/getamis/alice/crypto/tss/signer/checkpoint.proto", fileDescriptor_27e00e41eafc720e)
+}
+
+var fileDescriptor_27e00e41eafc720e = []byte{
+	// 780 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x6d, 0x6f, 0x22, 0x37,
+	0x10, 0x16, 0xe1, 0x20, 0x61, 0x42, 0xc9, 0xc5, 0x47, 0x53, 0x97, 0x46, 0x2d, 0x4a, 0x3f, 0x1c,
+	0x55, 0x4f, 0x5e, 0x29, 0x55, 0x4f, 0xd1, 0x55, 0x6d, 0x25, 0x74, 0x48, 0x17, 0x71, 0x91, 0xe8,
+	0x5e, 0xfb, 0x03, 0xcc, 0xc6, 0xd9, 0xb5, 0xf6, 0xc5, 0x2b, 0xdb, 0x7b, 0x27, 0xfa, 0xb3, 0xfa,
+	0x0b, 0x2b, 0xdb, 0xbb, 0x2c, 0x4b, 0x90, 0x80, 0x4f, 0xd8, 0x33, 0xf3, 0x3c, 0xf3, 0xb2, 0x0f,
+	0x63, 0xf8, 0x2d, 0xe4, 0x3a, 0x2a, 0x96, 0x24, 0x10, 0xa9, 0x17, 0x32, 0x4d, 0x53, 0xae, 0x3c,
+	0x9a, 0xf0, 0x80, 0x79, 0x81, 0x5c, 0xe5, 0x5a, 0x78, 0x5a, 0x29, 0x4f, 0xf1, 0x30, 0x63, 0xd2,
+	0x0b, 0x22, 0x16, 0xc4, 0xb9, 0xe0, 0x99, 0x26, 0xb9, 0x14, 0x5a, 0xa0, 0xae, 0x73, 0x8c, 0xfe,
+	0xd8, 0x47, 0xb2, 0xe4, 0x32, 0x8e, 0xc4, 0xd3, 0x13, 0xcf, 0x34, 0x93, 0xb9, 0x48, 0xa8, 0xe6,
+	0x22, 0xf3, 0x96, 0xb1, 0xe3, 0x19, 0xdd, 0xed, 0xc3, 0x07, 0x22, 0x4d, 0xb9, 0x4e, 0x59, 0xa6,
+	0xbd, 0x94, 0x29, 0x45, 0x43, 0x56, 0x22, 0xf7, 0x96, 0xcf, 0x02, 0x5b, 0x70, 0x28, 0x45, 0x91,
+	0x27, 0xf4, 0x8b, 0xb7, 0x51, 0xfe, 0xe8, 0xd7, 0x7d, 0xe0, 0x7f, 0xe3, 0x5c, 0x0a, 0xf1, 0xb4,
+	0x95, 0xf3, 0xee, 0x88, 0x91, 0x35, 0x91, 0x6f, 0x0f, 0x41, 0x96, 0x10, 0x8f, 0x05, 0x91, 0x70,
+	0xb8, 0x9b, 0xff, 0xfa, 0xd0, 0xf9, 0xa4, 0xa9, 0x66, 0xe8, 0x06, 0x3a, 0x52, 0x14, 0xd9, 0x23,
+	0x6e, 0x8d, 0x5b, 0x93, 0xc1, 0x6d, 0x9f, 0xb8, 0x3c, 0xe4, 0xef, 0x55, 0xce, 0x7c, 0xe7, 0x42,
+	0xd7, 0xd0, 0x53, 0x4c, 0x29, 0x2e, 0xb2, 0xfb, 0x47, 0x7c, 0x32, 0x6e, 0x4d, 0xfa, 0x7e, 0x6d,
+	0x40, 0xbf, 0x43, 0x2f, 0x2f, 0x96, 0x09, 0x0f, 0xe6, 0x6c, 0x85, 0xdb, 0xe3, 0xd6, 0xe4, 0xfc,
+	0xf6, 0x07, 0xb2, 0x35, 0x25, 0x32, 0x0b, 0x16, 0xe6, 0xfe, 0xe0, 0x4a, 0xf1, 0x6b, 0x04, 0x1a,
+	0x42, 0x47, 0x45, 0x54, 0x32, 0xfc, 0xc2, 0x12, 0xbb, 0x0b, 0x9a, 0x40, 0x7b, 0x19, 0x2b, 0xdc,
+	0x19, 0xb7, 0x27, 0xe7, 0xb7, 0x57, 0x55, 0x51, 0xb6, 0x64, 0x32, 0x8d, 0xd5, 0x2c, 0xd3, 0x72,
+	0xe5, 0x9b, 0x10, 0xf4, 0x12, 0xda, 0xa9, 0x0a, 0x71, 0xd7, 0xa2, 0xcd, 0x11, 0x7d, 0x0f, 0x10,
+	0x89, 0x54, 0x2c, 0x8a, 0x65, 0xcc, 0x56, 0xf8, 0xd4, 0x3a, 0x36, 0x2c, 0xa8, 0x0f, 0xad, 0x18,
+	0x9f, 0x59, 0x73, 0x2b, 0x36, 0x37, 0x8a, 0x7b, 0xee, 0x46, 0x11, 0x82, 0x17, 0x2c, 0x0b, 0xe6,
+	0x18, 0xac, 0xc1, 0x9e, 0xd1, 0x1c, 0x06, 0x34, 0x7c, 0xcf, 0x6a, 0xc9, 0xe0, 0x73, 0xdb, 0xe5,
+	0x8f, 0xa4, 0x36, 0x91, 0x0f, 0x54, 0x45, 0x9b, 0x31, 0x55, 0xa7, 0x5b, 0x50, 0x44, 0xa0, 0x63,
+	0x48, 0x15, 0xee, 0xdb, 0xd6, 0x70, 0xb3, 0xb5, 0x99, 0x71, 0xb9, 0xe6, 0x5c, 0x18, 0x1a, 0xc0,
+	0x49, 0xc2, 0xf1, 0x57, 0xb6, 0x9c, 0x93, 0x84, 0xa3, 0xb7, 0x70, 0x9a, 0xf0, 0x85, 0x11, 0x11,
+	0x1e, 0xd8, 0x2a, 0xae, 0x49, 0x29, 0x2a, 0xf2, 0x29, 0x88, 0x32, 0x21, 0xa5, 0x75, 0x56, 0xe9,
+	0xab, 0x60, 0xd3, 0xc4, 0x67, 0xde, 0x68, 0xe2, 0xe2, 0x88, 0x26, 0x9a, 0x50, 0x33, 0x25, 0x19,
+	0x89, 0x7b, 0xfc, 0xd2, 0x4d, 0xc9, 0x9c, 0xd1, 0x3b, 0xe8, 0x99, 0x5f, 0x57, 0xda, 0xe5, 0x01,
+	0xa5, 0xd5, 0xe1, 0x76, 0xc2, 0xcd, 0xe2, 0xd0, 0x31, 0x13, 0x6e, 0x16, 0x37, 0x87, 0x41, 0xd1,
+	0x24, 0x7b, 0x75, 0x04, 0x59, 0xf1, 0x8c, 0x4c, 0x37, 0xc9, 0x86, 0x47, 0x90, 0x35, 0xa1, 0xe8,
+	0x67, 0x38, 0x2b, 0xff, 0x8b, 0x0a, 0x7f, 0x6d, 0x3f, 0xff, 0x45, 0xf5, 0xf9, 0x2b, 0xc8, 0x3a,
+	0x00, 0x7d, 0x84, 0x8b, 0x9c, 0x31, 0xf9, 0x61, 0xad, 0x5b, 0x85, 0xaf, 0x2c, 0xe6, 0xa6, 0x29,
+	0x99, 0x45, 0x33, 0xc8, 0x89, 0x67, 0x1b, 0x8a, 0x1e, 0xe0, 0x22, 0xe6, 0x7e, 0xa3, 0x91, 0x6f,
+	0x0e, 0x6f, 0x64, 0x1b, 0x8b, 0xfe, 0x81, 0x57, 0x8a, 0x87, 0x29, 0xbd, 0x6f, 0x52, 0xe2, 0xc3,
+	0x29, 0x77, 0xe1, 0x11, 0x81, 0x53, 0x5d, 0x8a, 0xfb, 0x5b, 0x4b, 0x35, 0x5c, 0x2b, 0xe8, 0xfd,
+	0xc7, 0xd9, 0x5f, 0x6b, 0x51, 0x97, 0x41, 0xe8, 0x0d, 0x5c, 0x96, 0x7b, 0xa8, 0xee, 0x15, 0x8f,
+	0xac, 0x28, 0x9f, 0x3b, 0xd0, 0x1b, 0xe8, 0x9a, 0x15, 0xc8, 0x14, 0xfe, 0xce, 0x0e, 0x72, 0x48,
+	0xaa, 0x65, 0x3a, 0x0b, 0x22, 0x51, 0x91, 0x97, 0x31, 0x66, 0xe9, 0x99, 0x93, 0xd9, 0x83, 0x0a,
+	0x5f, 0x8f, 0xdb, 0x93, 0x8e, 0x5f, 0x1b, 0x46, 0x14, 0xce, 0xaa, 0x35, 0x64, 0x36, 0x90, 0xc9,
+	0x6b, 0x16, 0x68, 0xcf, 0x37, 0x47, 0xf4, 0x27, 0x74, 0x3e, 0xd3, 0xa4, 0x60, 0x76, 0x59, 0x9e,
+	0xdf, 0xfe, 0x44, 0x76, 0x3e, 0x57, 0x64, 0x1a, 0x2f, 0xa8, 0xa4, 0x29, 0xd3, 0x4c, 0x56, 0xd9,
+	0x1d, 0xee, 0xdd, 0xc9, 0x5d, 0x6b, 0x34, 0x07, 0xa8, 0xd7, 0xc1, 0x8e, 0x24, 0xaf, 0x9b, 0x49,
+	0x2e, 0x2b, 0x59, 0x18, 0x90, 0x95, 0xc6, 0x26, 0xd9, 0x14, 0x86, 0xbb, 0x84, 0xb2, 0x83, 0x76,
+	0xb8, 0x49, 0xdb, 0xdf, 0xe0, 0xb8, 0x79, 0x84, 0xde, 0x9a, 0x1b, 0x5d, 0x41, 0x97, 0xf2, 0x29,
+	0xd3, 0xd4, 0x62, 0xfb, 0x7e, 0x79, 0x33, 0xf6, 0x2f, 0xce, 0xee, 0xf0, 0xe5, 0x0d, 0xbd, 0x86,
+	0x6e, 0xaa, 0xe9, 0x83, 0x0a, 0xcb, 0x27, 0xe2, 0x99, 0xf2, 0x4b, 0xf7, 0xb2, 0x6b, 0x5f, 0xa8,
+	0x5f, 0xfe, 0x1f, 0x00, 0x32, 0xad, 0xfd, 0x6b, 0x48, 0x08, 0x00, 0x00,
+}
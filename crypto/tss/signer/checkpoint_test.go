@@ -0,0 +1,196 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"bytes"
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	proto "github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Checkpoint", func() {
+	var (
+		curve     = btcec.S256()
+		key       = bytes.Repeat([]byte{1}, tss.CheckpointKeySize)
+		msg       = []byte{1, 2, 3}
+		expPublic = ecpointgrouplaw.ScalarBaseMult(curve, privateKey)
+		ss        = [][]*big.Int{
+			{shareX, shareY, big.NewInt(0)},
+			{shareX2, shareY2, big.NewInt(0)},
+			{shareX3, shareY3, big.NewInt(0)},
+		}
+	)
+
+	It("restores a crashed peer and finishes the process", func() {
+		signers, listeners := newSigners(curve, expPublic, ss, msg)
+		doneChs := make(map[string]chan struct{}, len(listeners))
+		for id, l := range listeners {
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+		crashedID := getID(0)
+		crashed := signers[crashedID]
+		listeners[crashedID].On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+		crashed.Stop()
+
+		// The crashed peer receives the pubkey messages, but never handles them
+		for fromID, fromD := range signers {
+			msg := fromD.GetPubkeyMessage()
+			for toID, toD := range signers {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		checkpoint, err := crashed.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		restored, err := RestoreSigner(crashed.ph.peerManager, key, checkpoint, crashed.ph.homo, listeners[crashedID])
+		Expect(err).Should(BeNil())
+		Expect(restored.GetPubkeyMessage()).Should(Equal(crashed.GetPubkeyMessage()))
+		signers[crashedID] = restored
+		restored.Start()
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		var r, s *big.Int
+		for _, signer := range signers {
+			signer.Stop()
+			result, err := signer.GetResult()
+			Expect(err).Should(BeNil())
+			if r != nil {
+				Expect(r).Should(Equal(result.R))
+				Expect(s).Should(Equal(result.S))
+			} else {
+				r = result.R
+				s = result.S
+			}
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("restores the result of a done process", func() {
+		signers, listeners := newSigners(curve, expPublic, ss, msg)
+		doneChs := make(map[string]chan struct{}, len(listeners))
+		for id, l := range listeners {
+			doneCh := make(chan struct{})
+			doneChs[id] = doneCh
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+		for fromID, fromD := range signers {
+			msg := fromD.GetPubkeyMessage()
+			for toID, toD := range signers {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		s := signers[getID(1)]
+		s.Stop()
+		exp, err := s.GetResult()
+		Expect(err).Should(BeNil())
+		checkpoint, err := s.Checkpoint(key)
+		Expect(err).Should(BeNil())
+
+		// All the previous rounds are replayed and the last round is handled again
+		doneCh := make(chan struct{})
+		listener := new(mocks.StateChangedListener)
+		listener.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+			close(doneCh)
+		}).Once()
+		restored, err := RestoreSigner(s.ph.peerManager, key, checkpoint, s.ph.homo, listener)
+		Expect(err).Should(BeNil())
+		Expect(restored.GetHandler().MessageType()).Should(Equal(types.MessageType(Type_Si)))
+		restored.Start()
+		<-doneCh
+		got, err := restored.GetResult()
+		Expect(err).Should(BeNil())
+		Expect(got).Should(Equal(exp))
+
+		// The sent commitments are restored
+		expHandler := s.GetHandler().(*siHandler)
+		gotHandler := restored.GetHandler().(*siHandler)
+		Expect(proto.Equal(gotHandler.getDecommitAiViMessage(), expHandler.getDecommitAiViMessage())).Should(BeTrue())
+		Expect(proto.Equal(gotHandler.getDecommitUiTiMessage(), expHandler.getDecommitUiTiMessage())).Should(BeTrue())
+		restored.Stop()
+		listener.AssertExpectations(GinkgoT())
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("restores the verified public keys of the peers", func() {
+		signers, listeners := newSignersWithPubkeys(curve, expPublic, ss, msg)
+		s := signers[getID(0)]
+		checkpoint, err := s.Checkpoint(key)
+		Expect(err).Should(BeNil())
+		for id, s := range signers {
+			listeners[id].On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+			s.Stop()
+		}
+
+		restored, err := RestoreSigner(s.ph.peerManager, key, checkpoint, s.ph.homo, nil)
+		Expect(err).Should(BeNil())
+		Expect(restored.GetPubkeyMessage()).Should(Equal(s.GetPubkeyMessage()))
+		Expect(restored.ph.peerPubkeys).Should(HaveLen(len(s.ph.peerPubkeys)))
+		for id, pubkey := range s.ph.peerPubkeys {
+			Expect(restored.ph.peerPubkeys[id].ToPubKeyBytes()).Should(Equal(pubkey.ToPubKeyBytes()))
+		}
+		time.Sleep(500 * time.Millisecond)
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("inconsistent homo", func() {
+		signers, listeners := newSigners(curve, expPublic, ss, msg)
+		s := signers[getID(0)]
+		checkpoint, err := s.Checkpoint(key)
+		Expect(err).Should(BeNil())
+		for id, s := range signers {
+			listeners[id].On("OnStateChanged", types.StateInit, types.StateFailed).Once()
+			s.Stop()
+		}
+
+		// The homo of another signer
+		restored, err := RestoreSigner(s.ph.peerManager, key, checkpoint, getTestHomo(getID(1)), nil)
+		Expect(err).Should(Equal(tss.ErrInvalidCheckpoint))
+		Expect(restored).Should(BeNil())
+	})
+})
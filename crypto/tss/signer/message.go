@@ -19,6 +19,9 @@ import (
 )
 
 func (m *Message) IsValid() bool {
+	if len(m.GetSessionId()) == 0 {
+		return false
+	}
 	switch m.Type {
 	case Type_Pubkey:
 		return m.GetPubkey() != nil
@@ -40,10 +43,29 @@ func (m *Message) IsValid() bool {
 		return m.GetDecommitUiTi() != nil
 	case Type_Si:
 		return m.GetSi() != nil
+	case Type_KiRSigmaIR:
+		return m.GetKiRSigmaIR() != nil
+	case Type_BatchPubkey, Type_BatchEncK, Type_BatchMta, Type_BatchDelta, Type_BatchProofAi, Type_BatchCommitViAi,
+		Type_BatchDecommitViAi, Type_BatchCommitUiTi, Type_BatchDecommitUiTi, Type_BatchSi:
+		return m.isValidBatch()
 	}
 	return false
 }
 
+// isValidBatch checks if all the messages in the batch are valid messages of the round
+func (m *Message) isValidBatch() bool {
+	msgs := m.GetBatch().GetMessages()
+	if len(msgs) == 0 {
+		return false
+	}
+	for _, msg := range msgs {
+		if toBatchType(msg.GetType()) != m.Type || !msg.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *Message) GetMessageType() types.MessageType {
 	return types.MessageType(m.Type)
 }
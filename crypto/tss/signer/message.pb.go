@@ -6,6 +6,8 @@ package signer
 import (
 	fmt "fmt"
 	commitment "github.com/getamis/alice/crypto/commitment"
+	ecpointgrouplaw "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	paillier "github.com/getamis/alice/crypto/homo/paillier"
 	zkproof "github.com/getamis/alice/crypto/zkproof"
 	proto "github.com/golang/protobuf/proto"
 	math "math"
@@ -35,32 +37,68 @@ const (
 	Type_CommitUiTi   Type = 7
 	Type_DecommitUiTi Type = 8
 	Type_Si           Type = 9
+	// The batch mode: the batch message of a round carries the messages of all the signatures in the batch. The
+	// batch types are in the same order as the ones above.
+	Type_BatchPubkey       Type = 10
+	Type_BatchEncK         Type = 11
+	Type_BatchMta          Type = 12
+	Type_BatchDelta        Type = 13
+	Type_BatchProofAi      Type = 14
+	Type_BatchCommitViAi   Type = 15
+	Type_BatchDecommitViAi Type = 16
+	Type_BatchCommitUiTi   Type = 17
+	Type_BatchDecommitUiTi Type = 18
+	Type_BatchSi           Type = 19
+	// KiRSigmaIR is the last round of the presigner after ProofAi
+	Type_KiRSigmaIR Type = 20
 )
 
 var Type_name = map[int32]string{
-	0: "Pubkey",
-	1: "EncK",
-	2: "Mta",
-	3: "Delta",
-	4: "ProofAi",
-	5: "CommitViAi",
-	6: "DecommitViAi",
-	7: "CommitUiTi",
-	8: "DecommitUiTi",
-	9: "Si",
+	0:  "Pubkey",
+	1:  "EncK",
+	2:  "Mta",
+	3:  "Delta",
+	4:  "ProofAi",
+	5:  "CommitViAi",
+	6:  "DecommitViAi",
+	7:  "CommitUiTi",
+	8:  "DecommitUiTi",
+	9:  "Si",
+	10: "BatchPubkey",
+	11: "BatchEncK",
+	12: "BatchMta",
+	13: "BatchDelta",
+	14: "BatchProofAi",
+	15: "BatchCommitViAi",
+	16: "BatchDecommitViAi",
+	17: "BatchCommitUiTi",
+	18: "BatchDecommitUiTi",
+	19: "BatchSi",
+	20: "KiRSigmaIR",
 }
 
 var Type_value = map[string]int32{
-	"Pubkey":       0,
-	"EncK":         1,
-	"Mta":          2,
-	"Delta":        3,
-	"ProofAi":      4,
-	"CommitViAi":   5,
-	"DecommitViAi": 6,
-	"CommitUiTi":   7,
-	"DecommitUiTi": 8,
-	"Si":           9,
+	"Pubkey":            0,
+	"EncK":              1,
+	"Mta":               2,
+	"Delta":             3,
+	"ProofAi":           4,
+	"CommitViAi":        5,
+	"DecommitViAi":      6,
+	"CommitUiTi":        7,
+	"DecommitUiTi":      8,
+	"Si":                9,
+	"BatchPubkey":       10,
+	"BatchEncK":         11,
+	"BatchMta":          12,
+	"BatchDelta":        13,
+	"BatchProofAi":      14,
+	"BatchCommitViAi":   15,
+	"BatchDecommitViAi": 16,
+	"BatchCommitUiTi":   17,
+	"BatchDecommitUiTi": 18,
+	"BatchSi":           19,
+	"KiRSigmaIR":        20,
 }
 
 func (x Type) String() string {
@@ -72,8 +110,9 @@ func (Type) EnumDescriptor() ([]byte, []int) {
 }
 
 type Message struct {
-	Type Type   `protobuf:"varint,1,opt,name=type,proto3,enum=signer.Type" json:"type,omitempty"`
-	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Type      Type   `protobuf:"varint,1,opt,name=type,proto3,enum=signer.Type" json:"type,omitempty"`
+	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId []byte `protobuf:"bytes,13,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
 	// Types that are valid to be assigned to Body:
 	//	*Message_Pubkey
 	//	*Message_EncK
@@ -85,6 +124,8 @@ type Message struct {
 	//	*Message_CommitUiTi
 	//	*Message_DecommitUiTi
 	//	*Message_Si
+	//	*Message_Batch
+	//	*Message_KiRSigmaIR
 	Body                 isMessage_Body `protobuf_oneof:"body"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
@@ -130,6 +171,13 @@ func (m *Message) GetId() string {
 	return ""
 }
 
+func (m *Message) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
 type isMessage_Body interface {
 	isMessage_Body()
 }
@@ -174,6 +222,14 @@ type Message_Si struct {
 	Si *BodySi `protobuf:"bytes,12,opt,name=si,proto3,oneof"`
 }
 
+type Message_Batch struct {
+	Batch *BodyBatch `protobuf:"bytes,14,opt,name=batch,proto3,oneof"`
+}
+
+type Message_KiRSigmaIR struct {
+	KiRSigmaIR *BodyKiRSigmaIR `protobuf:"bytes,15,opt,name=kiRSigmaIR,proto3,oneof"`
+}
+
 func (*Message_Pubkey) isMessage_Body() {}
 
 func (*Message_EncK) isMessage_Body() {}
@@ -194,6 +250,10 @@ func (*Message_DecommitUiTi) isMessage_Body() {}
 
 func (*Message_Si) isMessage_Body() {}
 
+func (*Message_Batch) isMessage_Body() {}
+
+func (*Message_KiRSigmaIR) isMessage_Body() {}
+
 func (m *Message) GetBody() isMessage_Body {
 	if m != nil {
 		return m.Body
@@ -271,6 +331,20 @@ func (m *Message) GetSi() *BodySi {
 	return nil
 }
 
+func (m *Message) GetBatch() *BodyBatch {
+	if x, ok := m.GetBody().(*Message_Batch); ok {
+		return x.Batch
+	}
+	return nil
+}
+
+func (m *Message) GetKiRSigmaIR() *BodyKiRSigmaIR {
+	if x, ok := m.GetBody().(*Message_KiRSigmaIR); ok {
+		return x.KiRSigmaIR
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -284,6 +358,8 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_CommitUiTi)(nil),
 		(*Message_DecommitUiTi)(nil),
 		(*Message_Si)(nil),
+		(*Message_Batch)(nil),
+		(*Message_KiRSigmaIR)(nil),
 	}
 }
 
@@ -335,10 +411,14 @@ func (m *BodyPublicKey) GetAgCommitment() *commitment.HashCommitmentMessage {
 }
 
 type BodyEncK struct {
-	Enck                 []byte   `protobuf:"bytes,2,opt,name=enck,proto3" json:"enck,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Enck []byte `protobuf:"bytes,2,opt,name=enck,proto3" json:"enck,omitempty"`
+	// rangeProof is the range proof of enck under the ring-Pedersen parameter of the receiver
+	RangeProof *paillier.RangeProofMessage `protobuf:"bytes,3,opt,name=rangeProof,proto3" json:"rangeProof,omitempty"`
+	// noSmallFactorProof is the proof that the Paillier modulus of the sender has no small factor
+	NoSmallFactorProof   *zkproof.NoSmallFactorMessage `protobuf:"bytes,4,opt,name=noSmallFactorProof,proto3" json:"noSmallFactorProof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
 func (m *BodyEncK) Reset()         { *m = BodyEncK{} }
@@ -373,13 +453,30 @@ func (m *BodyEncK) GetEnck() []byte {
 	return nil
 }
 
+func (m *BodyEncK) GetRangeProof() *paillier.RangeProofMessage {
+	if m != nil {
+		return m.RangeProof
+	}
+	return nil
+}
+
+func (m *BodyEncK) GetNoSmallFactorProof() *zkproof.NoSmallFactorMessage {
+	if m != nil {
+		return m.NoSmallFactorProof
+	}
+	return nil
+}
+
 type BodyMta struct {
-	EncAiAlpha           []byte   `protobuf:"bytes,1,opt,name=encAiAlpha,proto3" json:"encAiAlpha,omitempty"`
-	EncWiAlpha           []byte   `protobuf:"bytes,2,opt,name=encWiAlpha,proto3" json:"encWiAlpha,omitempty"`
-	WiProof              []byte   `protobuf:"bytes,3,opt,name=wiProof,proto3" json:"wiProof,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	EncAiAlpha []byte `protobuf:"bytes,1,opt,name=encAiAlpha,proto3" json:"encAiAlpha,omitempty"`
+	EncWiAlpha []byte `protobuf:"bytes,2,opt,name=encWiAlpha,proto3" json:"encWiAlpha,omitempty"`
+	WiProof    []byte `protobuf:"bytes,3,opt,name=wiProof,proto3" json:"wiProof,omitempty"`
+	// aiRangeProof and wiRangeProof are the range proofs under the ring-Pedersen parameter of the receiver
+	AiRangeProof         *paillier.RespondentProofMessage `protobuf:"bytes,4,opt,name=aiRangeProof,proto3" json:"aiRangeProof,omitempty"`
+	WiRangeProof         *paillier.RespondentProofMessage `protobuf:"bytes,5,opt,name=wiRangeProof,proto3" json:"wiRangeProof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
 }
 
 func (m *BodyMta) Reset()         { *m = BodyMta{} }
@@ -428,11 +525,27 @@ func (m *BodyMta) GetWiProof() []byte {
 	return nil
 }
 
+func (m *BodyMta) GetAiRangeProof() *paillier.RespondentProofMessage {
+	if m != nil {
+		return m.AiRangeProof
+	}
+	return nil
+}
+
+func (m *BodyMta) GetWiRangeProof() *paillier.RespondentProofMessage {
+	if m != nil {
+		return m.WiRangeProof
+	}
+	return nil
+}
+
 type BodyDelta struct {
-	Delta                []byte   `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Delta []byte `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	// sigmaICommitment is the Pedersen commitment of sigma_i, which is given only by the presigner
+	SigmaICommitment     *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,2,opt,name=sigmaICommitment,proto3" json:"sigmaICommitment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
 }
 
 func (m *BodyDelta) Reset()         { *m = BodyDelta{} }
@@ -467,6 +580,13 @@ func (m *BodyDelta) GetDelta() []byte {
 	return nil
 }
 
+func (m *BodyDelta) GetSigmaICommitment() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.SigmaICommitment
+	}
+	return nil
+}
+
 type BodyProofAi struct {
 	AgDecommitment       *commitment.HashDecommitmentMessage `protobuf:"bytes,1,opt,name=agDecommitment,proto3" json:"agDecommitment,omitempty"`
 	AiProof              *zkproof.SchnorrProofMessage        `protobuf:"bytes,2,opt,name=aiProof,proto3" json:"aiProof,omitempty"`
@@ -515,8 +635,12 @@ func (m *BodyProofAi) GetAiProof() *zkproof.SchnorrProofMessage {
 }
 
 type BodyCommitViAi struct {
-	ViCommitment         *commitment.HashCommitmentMessage `protobuf:"bytes,1,opt,name=viCommitment,proto3" json:"viCommitment,omitempty"`
-	AiCommitment         *commitment.HashCommitmentMessage `protobuf:"bytes,2,opt,name=aiCommitment,proto3" json:"aiCommitment,omitempty"`
+	ViCommitment *commitment.HashCommitmentMessage `protobuf:"bytes,1,opt,name=viCommitment,proto3" json:"viCommitment,omitempty"`
+	AiCommitment *commitment.HashCommitmentMessage `protobuf:"bytes,2,opt,name=aiCommitment,proto3" json:"aiCommitment,omitempty"`
+	// kiRCommitment and sigmaIRCommitment are the commitments of k_i*R and sigma_i*R, which are used to identify
+	// the peers sending invalid s_i
+	KiRCommitment        *commitment.HashCommitmentMessage `protobuf:"bytes,3,opt,name=kiRCommitment,proto3" json:"kiRCommitment,omitempty"`
+	SigmaIRCommitment    *commitment.HashCommitmentMessage `protobuf:"bytes,4,opt,name=sigmaIRCommitment,proto3" json:"sigmaIRCommitment,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
 	XXX_unrecognized     []byte                            `json:"-"`
 	XXX_sizecache        int32                             `json:"-"`
@@ -561,11 +685,27 @@ func (m *BodyCommitViAi) GetAiCommitment() *commitment.HashCommitmentMessage {
 	return nil
 }
 
+func (m *BodyCommitViAi) GetKiRCommitment() *commitment.HashCommitmentMessage {
+	if m != nil {
+		return m.KiRCommitment
+	}
+	return nil
+}
+
+func (m *BodyCommitViAi) GetSigmaIRCommitment() *commitment.HashCommitmentMessage {
+	if m != nil {
+		return m.SigmaIRCommitment
+	}
+	return nil
+}
+
 type BodyDecommitViAi struct {
 	ViDecommitment       *commitment.HashDecommitmentMessage `protobuf:"bytes,1,opt,name=viDecommitment,proto3" json:"viDecommitment,omitempty"`
 	AiDecommitment       *commitment.HashDecommitmentMessage `protobuf:"bytes,2,opt,name=aiDecommitment,proto3" json:"aiDecommitment,omitempty"`
 	RhoIProof            *zkproof.SchnorrProofMessage        `protobuf:"bytes,3,opt,name=rhoIProof,proto3" json:"rhoIProof,omitempty"`
 	LiProof              *zkproof.SchnorrProofMessage        `protobuf:"bytes,4,opt,name=liProof,proto3" json:"liProof,omitempty"`
+	KiRDecommitment      *commitment.HashDecommitmentMessage `protobuf:"bytes,5,opt,name=kiRDecommitment,proto3" json:"kiRDecommitment,omitempty"`
+	SigmaIRDecommitment  *commitment.HashDecommitmentMessage `protobuf:"bytes,6,opt,name=sigmaIRDecommitment,proto3" json:"sigmaIRDecommitment,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
 	XXX_unrecognized     []byte                              `json:"-"`
 	XXX_sizecache        int32                               `json:"-"`
@@ -624,6 +764,20 @@ func (m *BodyDecommitViAi) GetLiProof() *zkproof.SchnorrProofMessage {
 	return nil
 }
 
+func (m *BodyDecommitViAi) GetKiRDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.KiRDecommitment
+	}
+	return nil
+}
+
+func (m *BodyDecommitViAi) GetSigmaIRDecommitment() *commitment.HashDecommitmentMessage {
+	if m != nil {
+		return m.SigmaIRDecommitment
+	}
+	return nil
+}
+
 type BodyCommitUiTi struct {
 	UiCommitment         *commitment.HashCommitmentMessage `protobuf:"bytes,1,opt,name=uiCommitment,proto3" json:"uiCommitment,omitempty"`
 	TiCommitment         *commitment.HashCommitmentMessage `protobuf:"bytes,2,opt,name=tiCommitment,proto3" json:"tiCommitment,omitempty"`
@@ -672,11 +826,13 @@ func (m *BodyCommitUiTi) GetTiCommitment() *commitment.HashCommitmentMessage {
 }
 
 type BodyDecommitUiTi struct {
-	UiDecommitment       *commitment.HashDecommitmentMessage `protobuf:"bytes,1,opt,name=uiDecommitment,proto3" json:"uiDecommitment,omitempty"`
-	TiDecommitment       *commitment.HashDecommitmentMessage `protobuf:"bytes,2,opt,name=tiDecommitment,proto3" json:"tiDecommitment,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
-	XXX_unrecognized     []byte                              `json:"-"`
-	XXX_sizecache        int32                               `json:"-"`
+	UiDecommitment *commitment.HashDecommitmentMessage `protobuf:"bytes,1,opt,name=uiDecommitment,proto3" json:"uiDecommitment,omitempty"`
+	TiDecommitment *commitment.HashDecommitmentMessage `protobuf:"bytes,2,opt,name=tiDecommitment,proto3" json:"tiDecommitment,omitempty"`
+	// liProof proves that Vi - m*k_i*R - r*sigma_i*R = l_i*G and Ti = l_i*A with the same l_i
+	LiProof              *zkproof.DLEQMessage `protobuf:"bytes,3,opt,name=liProof,proto3" json:"liProof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *BodyDecommitUiTi) Reset()         { *m = BodyDecommitUiTi{} }
@@ -718,6 +874,13 @@ func (m *BodyDecommitUiTi) GetTiDecommitment() *commitment.HashDecommitmentMessa
 	return nil
 }
 
+func (m *BodyDecommitUiTi) GetLiProof() *zkproof.DLEQMessage {
+	if m != nil {
+		return m.LiProof
+	}
+	return nil
+}
+
 type BodySi struct {
 	Si                   []byte   `protobuf:"bytes,1,opt,name=si,proto3" json:"si,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -757,6 +920,112 @@ func (m *BodySi) GetSi() []byte {
 	return nil
 }
 
+type BodyKiRSigmaIR struct {
+	KiR     *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,1,opt,name=kiR,proto3" json:"kiR,omitempty"`
+	SigmaIR *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,2,opt,name=sigmaIR,proto3" json:"sigmaIR,omitempty"`
+	// kiRProof proves that kiR = k_i*R for the k_i in enck under the ring-Pedersen parameter of the receiver. It's
+	// given only if the homo crypto is Paillier.
+	KiRProof *paillier.RangeProofMessage `protobuf:"bytes,3,opt,name=kiRProof,proto3" json:"kiRProof,omitempty"`
+	// sigmaIRProof proves that sigmaIR = sigma_i*R for the sigma_i in sigmaICommitment
+	SigmaIRProof         *zkproof.PedersenDLEQMessage `protobuf:"bytes,4,opt,name=sigmaIRProof,proto3" json:"sigmaIRProof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *BodyKiRSigmaIR) Reset()         { *m = BodyKiRSigmaIR{} }
+func (m *BodyKiRSigmaIR) String() string { return proto.CompactTextString(m) }
+func (*BodyKiRSigmaIR) ProtoMessage()    {}
+func (*BodyKiRSigmaIR) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ad801314df39a0f8, []int{11}
+}
+
+func (m *BodyKiRSigmaIR) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyKiRSigmaIR.Unmarshal(m, b)
+}
+func (m *BodyKiRSigmaIR) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyKiRSigmaIR.Marshal(b, m, deterministic)
+}
+func (m *BodyKiRSigmaIR) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyKiRSigmaIR.Merge(m, src)
+}
+func (m *BodyKiRSigmaIR) XXX_Size() int {
+	return xxx_messageInfo_BodyKiRSigmaIR.Size(m)
+}
+func (m *BodyKiRSigmaIR) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyKiRSigmaIR.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyKiRSigmaIR proto.InternalMessageInfo
+
+func (m *BodyKiRSigmaIR) GetKiR() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.KiR
+	}
+	return nil
+}
+
+func (m *BodyKiRSigmaIR) GetSigmaIR() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.SigmaIR
+	}
+	return nil
+}
+
+func (m *BodyKiRSigmaIR) GetKiRProof() *paillier.RangeProofMessage {
+	if m != nil {
+		return m.KiRProof
+	}
+	return nil
+}
+
+func (m *BodyKiRSigmaIR) GetSigmaIRProof() *zkproof.PedersenDLEQMessage {
+	if m != nil {
+		return m.SigmaIRProof
+	}
+	return nil
+}
+
+type BodyBatch struct {
+	// messages are the messages of the signatures in the order of the batch
+	Messages             []*Message `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BodyBatch) Reset()         { *m = BodyBatch{} }
+func (m *BodyBatch) String() string { return proto.CompactTextString(m) }
+func (*BodyBatch) ProtoMessage()    {}
+func (*BodyBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ad801314df39a0f8, []int{12}
+}
+
+func (m *BodyBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BodyBatch.Unmarshal(m, b)
+}
+func (m *BodyBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BodyBatch.Marshal(b, m, deterministic)
+}
+func (m *BodyBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BodyBatch.Merge(m, src)
+}
+func (m *BodyBatch) XXX_Size() int {
+	return xxx_messageInfo_BodyBatch.Size(m)
+}
+func (m *BodyBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_BodyBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BodyBatch proto.InternalMessageInfo
+
+func (m *BodyBatch) GetMessages() []*Message {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("signer.Type", Type_name, Type_value)
 	proto.RegisterType((*Message)(nil), "signer.Message")
@@ -770,6 +1039,8 @@ func init() {
 	proto.RegisterType((*BodyCommitUiTi)(nil), "signer.BodyCommitUiTi")
 	proto.RegisterType((*BodyDecommitUiTi)(nil), "signer.BodyDecommitUiTi")
 	proto.RegisterType((*BodySi)(nil), "signer.BodySi")
+	proto.RegisterType((*BodyKiRSigmaIR)(nil), "signer.BodyKiRSigmaIR")
+	proto.RegisterType((*BodyBatch)(nil), "signer.BodyBatch")
 }
 
 func init() {
@@ -777,52 +1048,78 @@ func init() {
 }
 
 var fileDescriptor_ad801314df39a0f8 = []byte{
-	// 747 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdd, 0x6a, 0xdb, 0x4a,
-	0x10, 0xb6, 0x64, 0x59, 0xb2, 0xc7, 0x3a, 0x3e, 0x3a, 0x7b, 0xce, 0x09, 0x22, 0x94, 0xe0, 0x28,
-	0x50, 0xd2, 0x5e, 0x48, 0x90, 0xd2, 0x12, 0x7a, 0x51, 0x70, 0xd2, 0x80, 0x8b, 0x09, 0x04, 0x25,
-	0x6d, 0xaf, 0x65, 0x79, 0x6b, 0x2f, 0xfe, 0x91, 0xb1, 0xd6, 0x29, 0xea, 0x1b, 0xf4, 0xb6, 0x0f,
-	0x50, 0xfa, 0x00, 0xbd, 0xeb, 0x0b, 0x16, 0x8d, 0x56, 0xd6, 0xae, 0xdb, 0x90, 0xd4, 0x97, 0xda,
-	0xf9, 0xbe, 0x99, 0xd1, 0x7c, 0xdf, 0xec, 0xc2, 0xe9, 0x98, 0xf1, 0xc9, 0x7a, 0xe8, 0xc7, 0xc9,
-	0x3c, 0x18, 0x53, 0x1e, 0xcd, 0x59, 0x1a, 0x44, 0x33, 0x16, 0xd3, 0x20, 0x5e, 0x65, 0x4b, 0x9e,
-	0x04, 0x3c, 0x4d, 0x83, 0x94, 0x8d, 0x17, 0x74, 0x15, 0xcc, 0x69, 0x9a, 0x46, 0x63, 0xea, 0x2f,
-	0x57, 0x09, 0x4f, 0x88, 0x59, 0x9c, 0xee, 0xdf, 0x9b, 0x21, 0x4e, 0xe6, 0x73, 0xc6, 0xe7, 0x74,
-	0xc1, 0xd5, 0x0c, 0xfb, 0xcf, 0xef, 0x63, 0x7e, 0x9a, 0x2e, 0x57, 0x49, 0xf2, 0x41, 0xa5, 0x79,
-	0x3f, 0x0c, 0xb0, 0x2e, 0x8b, 0x13, 0xd2, 0x05, 0x83, 0x67, 0x4b, 0xea, 0x6a, 0x5d, 0xed, 0xb8,
-	0x73, 0x62, 0xfb, 0x45, 0x4f, 0xfe, 0x4d, 0xb6, 0xa4, 0x21, 0x46, 0x48, 0x07, 0x74, 0x36, 0x72,
-	0xf5, 0xae, 0x76, 0xdc, 0x0a, 0x75, 0x36, 0x22, 0x01, 0x98, 0xcb, 0xf5, 0x70, 0x4a, 0x33, 0xb7,
-	0xde, 0xd5, 0x8e, 0xdb, 0x27, 0xff, 0x97, 0x9c, 0xb3, 0x64, 0x94, 0x5d, 0xad, 0x87, 0x33, 0x16,
-	0x0f, 0x68, 0xd6, 0xaf, 0x85, 0x02, 0x46, 0x1e, 0x83, 0x41, 0x17, 0xf1, 0xc0, 0x35, 0x10, 0xee,
-	0xc8, 0xf0, 0x8b, 0x45, 0x3c, 0xe8, 0xd7, 0x42, 0x8c, 0x93, 0x23, 0xa8, 0xcf, 0x79, 0xe4, 0x36,
-	0x10, 0xf6, 0xb7, 0x0c, 0xbb, 0xe4, 0x51, 0xbf, 0x16, 0xe6, 0x51, 0xf2, 0x04, 0x1a, 0x23, 0x3a,
-	0xe3, 0x91, 0x6b, 0x22, 0xec, 0x1f, 0x19, 0xf6, 0x3a, 0x0f, 0xf4, 0x6b, 0x61, 0x81, 0x20, 0x01,
-	0x58, 0xf8, 0xf7, 0x3d, 0xe6, 0x5a, 0x08, 0xfe, 0x57, 0xe9, 0xb4, 0x08, 0xf5, 0x6b, 0x61, 0x89,
-	0x22, 0xa7, 0x00, 0xc5, 0xa8, 0xdf, 0xb1, 0x1e, 0x73, 0x9b, 0xc8, 0xd9, 0x93, 0x39, 0xe7, 0x9b,
-	0x68, 0xbf, 0x16, 0x4a, 0x58, 0xf2, 0x0a, 0xec, 0x11, 0x95, 0xb8, 0x2d, 0xe4, 0xba, 0x6a, 0x73,
-	0xb1, 0xcc, 0x56, 0xf0, 0x55, 0xe5, 0xb7, 0xec, 0x86, 0xb9, 0x70, 0x57, 0xe5, 0x3c, 0x5a, 0x55,
-	0xce, 0xbf, 0xe4, 0xca, 0xc8, 0x6d, 0xdf, 0x5d, 0x59, 0xb0, 0x15, 0x3c, 0xe9, 0x82, 0x9e, 0x32,
-	0xd7, 0x46, 0x56, 0x47, 0x66, 0x5d, 0xe7, 0x58, 0x3d, 0x65, 0x67, 0x26, 0x18, 0xc3, 0x64, 0x94,
-	0x79, 0x0b, 0xf8, 0x4b, 0x51, 0x98, 0xec, 0x6d, 0x8c, 0x90, 0x9b, 0xc7, 0xde, 0xe8, 0x7d, 0x01,
-	0x76, 0x34, 0x3e, 0xdf, 0x78, 0x56, 0xd8, 0xe4, 0xd0, 0xaf, 0x6c, 0xec, 0xf7, 0xa3, 0x74, 0x52,
-	0x21, 0x84, 0x17, 0x43, 0x85, 0xe6, 0x1d, 0x40, 0xb3, 0xb4, 0x08, 0x21, 0x68, 0xa1, 0x29, 0xba,
-	0xd0, 0x46, 0xbb, 0x4c, 0xbd, 0x18, 0x2c, 0xe1, 0x0d, 0x72, 0x00, 0x40, 0x17, 0x71, 0x8f, 0xf5,
-	0x66, 0xcb, 0x49, 0x24, 0xba, 0x91, 0x4e, 0x44, 0xfc, 0xbd, 0x88, 0xeb, 0x9b, 0xb8, 0x38, 0x21,
-	0x2e, 0x58, 0x1f, 0x19, 0x1a, 0x02, 0x9b, 0xb5, 0xc3, 0xf2, 0xd3, 0x3b, 0x84, 0xd6, 0xc6, 0x59,
-	0xe4, 0xbf, 0xd2, 0x7b, 0x45, 0x85, 0xe2, 0xc3, 0xfb, 0xa2, 0x41, 0x5b, 0x32, 0x14, 0x19, 0x40,
-	0x27, 0x1a, 0x97, 0x33, 0xc7, 0x01, 0x68, 0x38, 0x80, 0xa3, 0xed, 0x01, 0xc8, 0x98, 0x72, 0x04,
-	0x5b, 0x54, 0xf2, 0x02, 0xac, 0x48, 0x74, 0xa6, 0x63, 0x96, 0x47, 0xbe, 0xd8, 0x69, 0xff, 0x3a,
-	0x9e, 0x2c, 0x92, 0xd5, 0x0a, 0x83, 0x25, 0xbd, 0x04, 0x7b, 0x5f, 0x35, 0xe8, 0xa8, 0x8e, 0xcd,
-	0x65, 0xb9, 0x65, 0xe7, 0xdb, 0x5d, 0x3d, 0x44, 0x16, 0x99, 0x86, 0xea, 0xca, 0x69, 0xf4, 0x87,
-	0xab, 0x2b, 0xd1, 0xbc, 0x6f, 0x3a, 0x38, 0xdb, 0x6b, 0x91, 0x8f, 0xee, 0x96, 0xed, 0x3c, 0x3a,
-	0x95, 0x8a, 0x3a, 0xa8, 0xc9, 0xf4, 0x3f, 0xd1, 0x41, 0x4d, 0xf6, 0x12, 0x5a, 0xab, 0x49, 0xf2,
-	0xa6, 0xf2, 0xc8, 0x7d, 0x4a, 0x54, 0xf0, 0x5c, 0xc3, 0x99, 0xd0, 0xd0, 0x78, 0x88, 0x86, 0xb3,
-	0xdf, 0x6a, 0x88, 0xdb, 0x7a, 0x01, 0xf6, 0x7a, 0x37, 0x0d, 0xd7, 0x5b, 0x1a, 0xf2, 0xdd, 0x34,
-	0x94, 0x69, 0xde, 0x77, 0x4d, 0xd5, 0x10, 0x5b, 0x1c, 0x40, 0x67, 0xbd, 0xbb, 0x86, 0xeb, 0x5f,
-	0x34, 0xe4, 0xbb, 0x6b, 0xa8, 0x52, 0x3d, 0x17, 0xcc, 0xe2, 0x62, 0xcb, 0x9f, 0xb4, 0x94, 0x89,
-	0x2d, 0xd6, 0x53, 0xf6, 0xf4, 0xb3, 0x06, 0x46, 0xfe, 0xe2, 0x11, 0x00, 0xf3, 0x0a, 0x2f, 0x31,
-	0xa7, 0x46, 0x9a, 0x60, 0xe4, 0x77, 0x8f, 0xa3, 0x11, 0x0b, 0xea, 0x97, 0x3c, 0x72, 0x74, 0xd2,
-	0x82, 0x06, 0xde, 0x04, 0x4e, 0x9d, 0xb4, 0xc1, 0x12, 0x0b, 0xef, 0x18, 0xa4, 0x03, 0x50, 0x2d,
-	0x9a, 0xd3, 0x20, 0x0e, 0xd8, 0xb2, 0xaf, 0x1d, 0xb3, 0x42, 0xe4, 0x33, 0x72, 0x2c, 0x19, 0x81,
-	0x27, 0x4d, 0x62, 0x82, 0x7e, 0xcd, 0x9c, 0xd6, 0xd0, 0xc4, 0x37, 0xfa, 0xd9, 0xcf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x65, 0x39, 0x06, 0x58, 0x58, 0x08, 0x00, 0x00,
+	// 1163 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x5d, 0x8e, 0xe3, 0x44,
+	0x17, 0x8d, 0x9d, 0xff, 0x1b, 0x77, 0xe2, 0xbe, 0xdd, 0x33, 0xb2, 0xfa, 0x9b, 0x0f, 0x82, 0x47,
+	0x42, 0x0d, 0x48, 0x8e, 0x18, 0x04, 0x34, 0x8c, 0x84, 0xe8, 0x3f, 0x48, 0x2b, 0x04, 0x1a, 0x67,
+	0x06, 0x9e, 0x1d, 0xa7, 0x48, 0x4a, 0x49, 0x6c, 0xcb, 0x76, 0xa6, 0x15, 0x96, 0xc1, 0x02, 0x58,
+	0x00, 0x0b, 0x40, 0xac, 0x80, 0x47, 0x56, 0xc0, 0x1e, 0x78, 0xe6, 0x0d, 0x55, 0xb9, 0x6c, 0x97,
+	0x93, 0x1e, 0x3a, 0xe4, 0xb1, 0xea, 0x9e, 0x73, 0xea, 0xd6, 0xf5, 0xb9, 0x55, 0x65, 0x38, 0x9b,
+	0xd2, 0x78, 0xb6, 0x1a, 0x5b, 0xae, 0xbf, 0xec, 0x4d, 0x49, 0xec, 0x2c, 0x69, 0xd4, 0x73, 0x16,
+	0xd4, 0x25, 0x3d, 0x37, 0x5c, 0x07, 0xb1, 0xdf, 0x8b, 0xa3, 0xa8, 0x17, 0xd1, 0xa9, 0x47, 0xc2,
+	0xde, 0x92, 0x44, 0x91, 0x33, 0x25, 0x56, 0x10, 0xfa, 0xb1, 0x8f, 0xb5, 0x64, 0xf6, 0xe4, 0x41,
+	0x05, 0xd7, 0x5f, 0x2e, 0x69, 0xbc, 0x24, 0x5e, 0x5c, 0x54, 0x38, 0x79, 0xfe, 0x10, 0x93, 0xb8,
+	0x81, 0x4f, 0xbd, 0x78, 0x1a, 0xfa, 0xab, 0x60, 0xe1, 0xdc, 0xf5, 0xf8, 0x68, 0x57, 0xf2, 0xcc,
+	0x5f, 0xfa, 0xbd, 0xc0, 0xa1, 0x8b, 0x05, 0xdd, 0xcc, 0xfd, 0xe4, 0xc3, 0x87, 0xc8, 0x3f, 0xce,
+	0x83, 0xd0, 0xf7, 0x7f, 0x28, 0xd2, 0xcc, 0x5f, 0xab, 0x50, 0x1f, 0x26, 0x33, 0xd8, 0x85, 0x4a,
+	0xbc, 0x0e, 0x88, 0xa1, 0x74, 0x95, 0xd3, 0xf6, 0x33, 0xcd, 0x4a, 0xaa, 0x61, 0xbd, 0x58, 0x07,
+	0xc4, 0xe6, 0x11, 0x6c, 0x83, 0x4a, 0x27, 0x86, 0xda, 0x55, 0x4e, 0x9b, 0xb6, 0x4a, 0x27, 0xf8,
+	0x04, 0x9a, 0x11, 0x89, 0x22, 0xea, 0x7b, 0x37, 0x13, 0xe3, 0xa0, 0xab, 0x9c, 0x6a, 0x76, 0x3e,
+	0x81, 0x3d, 0xa8, 0x05, 0xab, 0xf1, 0x9c, 0xac, 0x8d, 0x72, 0x57, 0x39, 0x6d, 0x3d, 0x7b, 0x94,
+	0x2a, 0x5e, 0xf8, 0x93, 0xf5, 0xed, 0x6a, 0xbc, 0xa0, 0xee, 0x80, 0xac, 0xfb, 0x25, 0x5b, 0xc0,
+	0xf0, 0x6d, 0xa8, 0x10, 0xcf, 0x1d, 0x18, 0x15, 0x0e, 0xd7, 0x65, 0xf8, 0xb5, 0xe7, 0x0e, 0xfa,
+	0x25, 0x9b, 0xc7, 0xf1, 0x29, 0x94, 0x97, 0xb1, 0x63, 0x54, 0x39, 0xac, 0x23, 0xc3, 0x86, 0xb1,
+	0xd3, 0x2f, 0xd9, 0x2c, 0x8a, 0xef, 0x40, 0x75, 0x42, 0x16, 0xb1, 0x63, 0xd4, 0x38, 0xec, 0x50,
+	0x86, 0x5d, 0xb1, 0x40, 0xbf, 0x64, 0x27, 0x08, 0xec, 0x41, 0x9d, 0xd7, 0xe6, 0x9c, 0x1a, 0x75,
+	0x0e, 0x3e, 0x2a, 0x64, 0x9a, 0x84, 0xfa, 0x25, 0x3b, 0x45, 0xe1, 0x19, 0x40, 0x62, 0x81, 0xef,
+	0xe8, 0x39, 0x35, 0x1a, 0x9c, 0xf3, 0x58, 0xe6, 0x5c, 0x66, 0xd1, 0x7e, 0xc9, 0x96, 0xb0, 0xf8,
+	0x19, 0x68, 0x13, 0x22, 0x71, 0x9b, 0x9c, 0x6b, 0x14, 0x93, 0x73, 0x65, 0x76, 0x01, 0x9f, 0xaf,
+	0xfc, 0x92, 0xbe, 0xa0, 0x06, 0xbc, 0x6e, 0x65, 0x16, 0xcd, 0x57, 0x66, 0x23, 0x79, 0x65, 0xce,
+	0x6d, 0xbd, 0x7e, 0x65, 0xc1, 0x2e, 0xe0, 0xb1, 0x0b, 0x6a, 0x44, 0x0d, 0x8d, 0xb3, 0xda, 0x32,
+	0x6b, 0xc4, 0xb0, 0x6a, 0x44, 0x59, 0xc5, 0xc7, 0x4e, 0xec, 0xce, 0x8c, 0xf6, 0x76, 0xc5, 0x2f,
+	0x58, 0x80, 0x55, 0x9c, 0x23, 0xd8, 0x36, 0xe6, 0xd4, 0x1e, 0xd1, 0xe9, 0xd2, 0xb9, 0xb1, 0x8d,
+	0xce, 0xf6, 0x36, 0x06, 0x59, 0x94, 0x6d, 0x23, 0xc7, 0x5e, 0xd4, 0xa0, 0x32, 0xf6, 0x27, 0x6b,
+	0xd3, 0x83, 0x83, 0x82, 0x8d, 0xf0, 0x71, 0xe6, 0x36, 0x85, 0x1b, 0x51, 0x8c, 0xf0, 0x1a, 0x34,
+	0x67, 0x7a, 0x99, 0x35, 0xac, 0xf0, 0xe2, 0x5b, 0x56, 0xde, 0xc3, 0x56, 0xdf, 0x89, 0x66, 0x39,
+	0x42, 0xb4, 0x83, 0x5d, 0xa0, 0x99, 0xbf, 0x28, 0xd0, 0x48, 0x8d, 0x88, 0xc8, 0x8d, 0x3a, 0xe7,
+	0x9d, 0xa0, 0x71, 0x53, 0xce, 0xf1, 0x39, 0x40, 0xe8, 0x78, 0x53, 0xc2, 0xed, 0x22, 0x56, 0xf9,
+	0x9f, 0x95, 0x76, 0xab, 0x65, 0x67, 0xb1, 0x54, 0x5f, 0x82, 0xe3, 0x10, 0xd0, 0xf3, 0x47, 0x4b,
+	0x67, 0xb1, 0xf8, 0xc2, 0x71, 0x63, 0x3f, 0x4c, 0x44, 0x92, 0x3e, 0xf8, 0xbf, 0x25, 0x5a, 0xd7,
+	0xfa, 0x5a, 0x86, 0xa4, 0x32, 0xf7, 0x10, 0xcd, 0xbf, 0x14, 0xa8, 0x8b, 0x76, 0xc0, 0x37, 0x00,
+	0x88, 0xe7, 0x9e, 0xd3, 0xf3, 0x45, 0x30, 0x73, 0x44, 0x6d, 0xa4, 0x19, 0x11, 0xff, 0x5e, 0xc4,
+	0xd5, 0x2c, 0x2e, 0x66, 0xd0, 0x80, 0xfa, 0x1d, 0xcd, 0x37, 0xa5, 0xd9, 0xe9, 0x10, 0xaf, 0x40,
+	0x73, 0x68, 0xbe, 0x2f, 0x91, 0x6e, 0x57, 0xda, 0x33, 0x89, 0x02, 0xdf, 0x9b, 0x10, 0x2f, 0x2e,
+	0x6c, 0xbc, 0xc0, 0x62, 0x2a, 0x77, 0xb2, 0x4a, 0x75, 0x57, 0x15, 0x99, 0x65, 0x7a, 0xd0, 0xcc,
+	0x1a, 0x1b, 0x8f, 0xd3, 0xd6, 0x4f, 0x76, 0x9b, 0x0c, 0x70, 0x00, 0x7a, 0xc4, 0x4d, 0x24, 0x99,
+	0x41, 0xe5, 0x8b, 0xbd, 0x69, 0x6d, 0x1c, 0xcb, 0xd6, 0xb5, 0x7b, 0xcb, 0xc6, 0xe9, 0x5a, 0x5b,
+	0x44, 0xf3, 0x27, 0x05, 0x5a, 0xd2, 0xe1, 0x80, 0x03, 0x68, 0x3b, 0xd3, 0xb4, 0x7f, 0xb8, 0xb4,
+	0xc2, 0xa5, 0x9f, 0x6e, 0xfa, 0x4c, 0xc6, 0xa4, 0xf2, 0x1b, 0x54, 0xfc, 0x08, 0xea, 0x8e, 0x28,
+	0x79, 0x92, 0xe0, 0x93, 0xcc, 0x02, 0x23, 0x77, 0xe6, 0xf9, 0x61, 0x58, 0xa8, 0x44, 0x0a, 0x36,
+	0x7f, 0x53, 0xa1, 0x5d, 0x3c, 0x7d, 0x98, 0xfb, 0x5f, 0xd1, 0xcb, 0xcd, 0xac, 0x76, 0x71, 0xbf,
+	0x4c, 0xe3, 0x4d, 0x44, 0xb7, 0xea, 0xb6, 0x53, 0x13, 0xc9, 0x32, 0x5f, 0xc2, 0xc1, 0x9c, 0xda,
+	0xfb, 0x34, 0x63, 0x91, 0x87, 0xdf, 0xc0, 0x61, 0xf2, 0x49, 0x64, 0xb1, 0xca, 0xae, 0x62, 0xdb,
+	0x5c, 0xf3, 0xf7, 0x32, 0xe8, 0x9b, 0x87, 0x2f, 0xfb, 0xa8, 0xaf, 0xe8, 0xde, 0x1f, 0xb5, 0x48,
+	0xe5, 0x0e, 0x29, 0x8a, 0xa9, 0xff, 0xc5, 0x21, 0x45, 0xb1, 0x4f, 0xa1, 0x19, 0xce, 0xfc, 0x1b,
+	0xf9, 0xac, 0xf9, 0x77, 0x8f, 0xe4, 0x70, 0xe6, 0xae, 0x05, 0x95, 0x3b, 0xf6, 0x01, 0x77, 0x09,
+	0x30, 0x0e, 0xa1, 0x33, 0xa7, 0x76, 0x61, 0x07, 0xd5, 0xdd, 0x77, 0xb0, 0xc9, 0xc5, 0x97, 0x70,
+	0x24, 0x3e, 0x43, 0x41, 0xb2, 0xb6, 0xbb, 0xe4, 0x7d, 0x7c, 0xf3, 0x67, 0x45, 0xee, 0x01, 0x7e,
+	0x73, 0x5d, 0x83, 0xb6, 0xda, 0xaf, 0x07, 0x56, 0x1b, 0x3d, 0x10, 0xef, 0xd7, 0x03, 0x32, 0xcd,
+	0xfc, 0x53, 0x29, 0x3a, 0x8d, 0xa7, 0x38, 0x80, 0xf6, 0x6a, 0x7f, 0xa7, 0xad, 0xb6, 0x9c, 0x16,
+	0xef, 0xef, 0xb4, 0x22, 0x15, 0xad, 0xdc, 0x2d, 0x89, 0xcf, 0x8e, 0x33, 0xb7, 0x5c, 0x7d, 0x75,
+	0xfd, 0xed, 0xa6, 0x4b, 0x4c, 0x03, 0x6a, 0xc9, 0xa3, 0x80, 0x3d, 0x16, 0x23, 0x2a, 0x8e, 0x60,
+	0x35, 0xa2, 0xe6, 0xdf, 0xe2, 0xcb, 0xe4, 0x57, 0x3b, 0xbe, 0x0f, 0xe5, 0x39, 0xb5, 0x0d, 0x65,
+	0xb7, 0x53, 0x98, 0x61, 0xf1, 0x13, 0xa8, 0x8b, 0xcf, 0xbe, 0xeb, 0xe1, 0x9d, 0xe2, 0xf1, 0x63,
+	0x68, 0xcc, 0xa9, 0xbd, 0xf3, 0xfd, 0x9c, 0x81, 0xf1, 0x73, 0xd0, 0x84, 0xc6, 0xfd, 0x6d, 0x73,
+	0x4b, 0x26, 0x24, 0x8c, 0x88, 0x27, 0x17, 0xa4, 0xc0, 0x30, 0xcf, 0xa0, 0x99, 0xbd, 0x82, 0xf0,
+	0x3d, 0x68, 0x88, 0x47, 0x78, 0x64, 0x28, 0xdd, 0xb2, 0xfc, 0x86, 0xcd, 0xd6, 0x4e, 0x01, 0xef,
+	0xfe, 0xa1, 0x42, 0x85, 0xbd, 0xc0, 0x11, 0xa0, 0x76, 0xcb, 0x5f, 0x34, 0x7a, 0x09, 0x1b, 0x50,
+	0x61, 0xef, 0x10, 0x5d, 0xc1, 0x3a, 0x94, 0x87, 0xb1, 0xa3, 0xab, 0xd8, 0x84, 0x2a, 0xbf, 0xfc,
+	0xf4, 0x32, 0xb6, 0xa0, 0x2e, 0xae, 0x25, 0xbd, 0x82, 0x6d, 0x80, 0xfc, 0x3a, 0xd0, 0xab, 0xa8,
+	0x83, 0x26, 0x9f, 0x71, 0x7a, 0x2d, 0x47, 0x30, 0x27, 0xea, 0x75, 0x19, 0xc1, 0x67, 0x1a, 0x58,
+	0x03, 0x75, 0x44, 0xf5, 0x26, 0x76, 0xa0, 0xc5, 0x77, 0x20, 0xf2, 0x00, 0x3c, 0x80, 0x26, 0x9f,
+	0xe0, 0xc9, 0xb4, 0x50, 0x83, 0x06, 0x1f, 0xb2, 0x8c, 0x34, 0xa6, 0xcb, 0x47, 0x49, 0x5a, 0x07,
+	0x4c, 0x37, 0x61, 0x8b, 0xdc, 0xda, 0x78, 0x04, 0x1d, 0x3e, 0x23, 0x25, 0xd8, 0xc1, 0x47, 0x70,
+	0x28, 0x68, 0x52, 0x96, 0xfa, 0x06, 0x96, 0x27, 0x76, 0xb8, 0x85, 0xe5, 0xd3, 0xc8, 0x0a, 0xc0,
+	0xa7, 0x47, 0x54, 0x3f, 0x62, 0x69, 0xe4, 0x8e, 0xd3, 0x8f, 0xc7, 0x35, 0xfe, 0xe3, 0xf3, 0xc1,
+	0x3f, 0x03, 0x00, 0x2f, 0x22, 0xd1, 0xd9, 0x27, 0x0e, 0x00, 0x00,
 }
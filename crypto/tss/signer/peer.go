@@ -25,6 +25,7 @@ type peer struct {
 	mta          *mtaData
 	delta        *deltaData
 	proofAi      *proofAiData
+	kiRSigmaIR   *kiRSigmaIRData
 	commitViAi   *commitViAiData
 	decommitViAi *decommitViAiData
 	commitUiTi   *commitUiTiData
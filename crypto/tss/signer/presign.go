@@ -0,0 +1,212 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/mta"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+)
+
+var (
+	// ErrPresignatureUsed is returned if the presignature has been used to sign
+	ErrPresignatureUsed = errors.New("presignature has been used")
+	// ErrInconsistentSigners is returned if the signers are not the ones generating the presignature
+	ErrInconsistentSigners = errors.New("inconsistent signers")
+)
+
+// Presignature is the message-independent part of a signature. It contains the secret share of the nonce, so
+// it must be kept private and used to sign only one message. Otherwise, the private key could be extracted
+// from the signatures. NewOnlineSigner consumes it, and it cannot be used again.
+type Presignature struct {
+	mu   sync.Mutex
+	used bool
+
+	publicKey *pt.ECPoint
+	r         *pt.ECPoint
+	aiMta     mta.Mta
+	tmpSi     *big.Int
+	selfID    string
+	// peerIDs are the sorted ids of the peers generating the presignature together
+	peerIDs []string
+	// peers are the verified k_i*R and sigma_i*R of the peers, which are used to check their s_i
+	peers map[string]*kiRSigmaIRData
+}
+
+// GetPublicKey returns the public key which the presignature signs under
+func (p *Presignature) GetPublicKey() *pt.ECPoint {
+	return p.publicKey.Copy()
+}
+
+// GetR returns the R of the presignature, whose x-coordinate is the r of the signature
+func (p *Presignature) GetR() *pt.ECPoint {
+	return p.r.Copy()
+}
+
+// IsUsed returns true if the presignature has been used to sign
+func (p *Presignature) IsUsed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.used
+}
+
+// consume marks the presignature as used and returns the secrets. The secrets are dropped from the
+// presignature, so they could be returned only once.
+func (p *Presignature) consume() (mta.Mta, *big.Int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.used {
+		return nil, nil, ErrPresignatureUsed
+	}
+	aiMta, tmpSi := p.aiMta, p.tmpSi
+	p.used = true
+	p.aiMta = nil
+	p.tmpSi = nil
+	return aiMta, tmpSi, nil
+}
+
+// Presigner runs the rounds of GG18 which are independent of the message (i.e. Pubkey, EncK, Mta, Delta and
+// ProofAi), and outputs a presignature for the same signers to sign a message later in one round. Instead of the
+// consistency checks of GG18 on s_i, the presigners reveal k_i*R and sigma_i*R with proofs in an extra KiRSigmaIR
+// round as GG20 does. The presignature is output only if the sum of k_i*R is G and the sum of sigma_i*R is Q, so
+// the online signer could check the s_i of each peer and blame the ones sending invalid s_i.
+type Presigner struct {
+	ph *pubkeyHandler
+	*message.MsgMain
+
+	mu           sync.Mutex
+	presignature *Presignature
+}
+
+// NewPresigner creates a presigner. The arguments are the same as NewSigner except the message.
+func NewPresigner(peerManager types.PeerManager, sessionID []byte, expectedPubkey *pt.ECPoint, homo homo.Crypto, secret *big.Int, threshold uint32, bks map[string]*birkhoffinterpolation.BkParameter, signerIDs []string, listener types.StateChangedListener) (*Presigner, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	ph, err := newPubkeyHandler(expectedPubkey, peerManager, sessionID, homo, secret, threshold, bks, signerIDs, nil)
+	if err != nil {
+		log.Warn("Failed to new a public key handler", "err", err)
+		return nil, err
+	}
+	ph.presign = true
+	return &Presigner{
+		ph: ph,
+		MsgMain: message.NewMsgMain(peerManager,
+			sessionID,
+			listener,
+			ph,
+			types.MessageType(Type_Pubkey),
+			types.MessageType(Type_EncK),
+			types.MessageType(Type_Mta),
+			types.MessageType(Type_Delta),
+			types.MessageType(Type_ProofAi),
+			types.MessageType(Type_KiRSigmaIR),
+		),
+	}, nil
+}
+
+func (p *Presigner) GetPubkeyMessage() *Message {
+	return p.ph.GetPubkeyMessage()
+}
+
+// GetResult returns the presignature. The same presignature is returned in every call, so it could be used
+// only once.
+func (p *Presigner) GetResult() (*Presignature, error) {
+	if p.GetState() != types.StateDone {
+		return nil, tss.ErrNotReady
+	}
+
+	h := p.GetHandler()
+	rh, ok := h.(*kiRSigmaIRHandler)
+	if !ok {
+		log.Error("We cannot convert to kiR sigmaIR handler in done state")
+		return nil, tss.ErrNotReady
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.presignature == nil {
+		peerIDs := make([]string, 0, len(rh.peers))
+		peers := make(map[string]*kiRSigmaIRData, len(rh.peers))
+		for id, peer := range rh.peers {
+			peerIDs = append(peerIDs, id)
+			peers[id] = peer.kiRSigmaIR
+		}
+		sort.Strings(peerIDs)
+		p.presignature = &Presignature{
+			publicKey: rh.publicKey,
+			r:         rh.r,
+			aiMta:     rh.aiMta,
+			tmpSi:     rh.tmpSi,
+			selfID:    rh.peerManager.SelfID(),
+			peerIDs:   peerIDs,
+			peers:     peers,
+		}
+	}
+	return p.presignature, nil
+}
+
+// OnlineSigner signs the message with a presignature in one round
+type OnlineSigner struct {
+	oh *onlineSiHandler
+	*message.MsgMain
+
+	lowS bool
+}
+
+// NewOnlineSigner consumes the presignature to sign the message. The peer manager must manage the same peers as
+// the one generating the presignature. The presignature could not be used again even if the signing fails.
+func NewOnlineSigner(peerManager types.PeerManager, sessionID []byte, presignature *Presignature, msg []byte, listener types.StateChangedListener) (*OnlineSigner, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	oh, err := newOnlineSiHandler(peerManager, sessionID, presignature, msg)
+	if err != nil {
+		log.Warn("Failed to new an online si handler", "err", err)
+		return nil, err
+	}
+	return &OnlineSigner{
+		oh:      oh,
+		lowS:    true,
+		MsgMain: message.NewMsgMain(peerManager, sessionID, listener, oh, types.MessageType(Type_Si)),
+	}, nil
+}
+
+func (s *OnlineSigner) GetSiMessage() *Message {
+	return s.oh.getSiMessage()
+}
+
+// SetLowS sets whether s of the result is normalized to the lower one. It's enabled by default.
+func (s *OnlineSigner) SetLowS(lowS bool) {
+	s.lowS = lowS
+}
+
+// GetResult returns the signature with the recovery id
+func (s *OnlineSigner) GetResult() (*Result, error) {
+	if s.GetState() != types.StateDone {
+		return nil, tss.ErrNotReady
+	}
+	return NewResult(s.oh.r, s.oh.s, s.lowS), nil
+}
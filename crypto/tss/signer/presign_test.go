@@ -0,0 +1,334 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/message/types/mocks"
+	proto "github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Presigner", func() {
+	var (
+		curve     = btcec.S256()
+		expPublic = ecpointgrouplaw.ScalarBaseMult(curve, privateKey)
+		ss        = [][]*big.Int{
+			{shareX, shareY, big.NewInt(0)},
+			{shareX2, shareY2, big.NewInt(0)},
+			{shareX3, shareY3, big.NewInt(0)},
+		}
+		msgs = [][]byte{
+			[]byte("9ca9bf7e9c8b1e1c2b6f4e0e1c2f2e6a"),
+			[]byte("another message to be signed...."),
+		}
+	)
+
+	It("signs messages with presignatures in one round", func() {
+		// Presign twice for two messages. The second presigners use the public keys verified in the first one.
+		presignatures := []map[string]*Presignature{
+			runPresigners(newPresigners(expPublic, ss)),
+			runPresigners(newPresignersWithPubkeys(expPublic, ss)),
+		}
+		for _, ps := range presignatures {
+			for _, p := range ps {
+				Expect(p.GetPublicKey().Equal(expPublic)).Should(BeTrue())
+				Expect(p.IsUsed()).Should(BeFalse())
+			}
+		}
+		// The nonces of the presignatures are different
+		Expect(presignatures[0][getID(0)].GetR().Equal(presignatures[1][getID(0)].GetR())).Should(BeFalse())
+
+		ecdsaPublicKey := &ecdsa.PublicKey{
+			Curve: expPublic.GetCurve(),
+			X:     expPublic.GetX(),
+			Y:     expPublic.GetY(),
+		}
+		for i, msg := range msgs {
+			results := runOnlineSigners(newOnlineSigners(presignatures[i], msg))
+			for _, result := range results {
+				Expect(result.R).Should(Equal(presignatures[i][getID(0)].GetR().GetX()))
+				Expect(result).Should(Equal(results[getID(0)]))
+				Expect(ecdsa.Verify(ecdsaPublicKey, msg, result.R, result.S)).Should(BeTrue())
+			}
+			for _, p := range presignatures[i] {
+				Expect(p.IsUsed()).Should(BeTrue())
+			}
+		}
+
+		// The presignatures could not be used again
+		for id, p := range presignatures[0] {
+			pm := newPresignPeerManager(id, len(ss)-1)
+			pm.setProcesses(map[string]message.MessageAdder{
+				getID(0): nil,
+				getID(1): nil,
+				getID(2): nil,
+			})
+			s, err := NewOnlineSigner(pm, sessionID, p, msgs[1], nil)
+			Expect(err).Should(Equal(ErrPresignatureUsed))
+			Expect(s).Should(BeNil())
+		}
+	})
+
+	It("the same presignature in every call of GetResult", func() {
+		presigners, listeners := newPresignersWithPubkeys(expPublic, ss)
+		for _, p := range presigners {
+			r, err := p.GetResult()
+			Expect(err).Should(Equal(tss.ErrNotReady))
+			Expect(r).Should(BeNil())
+		}
+		presignatures := runPresigners(presigners, listeners)
+		for id, p := range presigners {
+			r, err := p.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(r).Should(BeIdenticalTo(presignatures[id]))
+		}
+	})
+
+	It("inconsistent signers", func() {
+		presignatures := runPresigners(newPresignersWithPubkeys(expPublic, ss))
+		p := presignatures[getID(0)]
+
+		// Wrong self id
+		pm := newPresignPeerManager(getID(1), len(ss)-1)
+		pm.setProcesses(map[string]message.MessageAdder{
+			getID(0): nil,
+			getID(1): nil,
+			getID(2): nil,
+		})
+		s, err := NewOnlineSigner(pm, sessionID, p, msgs[0], nil)
+		Expect(err).Should(Equal(ErrInconsistentSigners))
+		Expect(s).Should(BeNil())
+
+		// Wrong peers
+		pm = newPresignPeerManager(getID(0), len(ss)-1)
+		pm.setProcesses(map[string]message.MessageAdder{
+			getID(0): nil,
+			getID(1): nil,
+			getID(3): nil,
+		})
+		s, err = NewOnlineSigner(pm, sessionID, p, msgs[0], nil)
+		Expect(err).Should(Equal(ErrInconsistentSigners))
+		Expect(s).Should(BeNil())
+
+		// The presignature is not consumed
+		Expect(p.IsUsed()).Should(BeFalse())
+	})
+
+	It("empty session id", func() {
+		s, err := NewPresigner(newPresignPeerManager(getID(0), 2), nil, expPublic, nil, shareY, 2, nil, nil, nil)
+		Expect(err).Should(Equal(tss.ErrEmptySessionID))
+		Expect(s).Should(BeNil())
+
+		o, err := NewOnlineSigner(newPresignPeerManager(getID(0), 2), nil, &Presignature{}, msgs[0], nil)
+		Expect(err).Should(Equal(tss.ErrEmptySessionID))
+		Expect(o).Should(BeNil())
+	})
+})
+
+type presignPeerManager struct {
+	id        string
+	numPeers  uint32
+	processes map[string]message.MessageAdder
+}
+
+func newPresignPeerManager(id string, numPeers int) *presignPeerManager {
+	return &presignPeerManager{
+		id:       id,
+		numPeers: uint32(numPeers),
+	}
+}
+
+func (p *presignPeerManager) setProcesses(processes map[string]message.MessageAdder) {
+	p.processes = processes
+}
+
+func (p *presignPeerManager) NumPeers() uint32 {
+	return p.numPeers
+}
+
+func (p *presignPeerManager) SelfID() string {
+	return p.id
+}
+
+func (p *presignPeerManager) PeerIDs() []string {
+	ids := make([]string, 0, len(p.processes))
+	for id := range p.processes {
+		if id != p.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (p *presignPeerManager) MustSend(id string, message proto.Message) {
+	d := p.processes[id]
+	msg := message.(types.Message)
+	Expect(d.AddMessage(msg)).Should(BeNil())
+}
+
+func newPresigners(expPublic *ecpointgrouplaw.ECPoint, ss [][]*big.Int) (map[string]*Presigner, map[string]*mocks.StateChangedListener) {
+	threshold := len(ss)
+	presigners := make(map[string]*Presigner, threshold)
+	processes := make(map[string]message.MessageAdder, threshold)
+	listeners := make(map[string]*mocks.StateChangedListener, threshold)
+
+	bks := make(map[string]*birkhoffinterpolation.BkParameter, threshold)
+	signerIDs := make([]string, threshold)
+	for i := 0; i < threshold; i++ {
+		signerIDs[i] = getID(i)
+		bks[signerIDs[i]] = birkhoffinterpolation.NewBkParameter(ss[i][0], uint32(ss[i][2].Uint64()))
+		// Add the ids first since the peer ids are checked in the constructor
+		processes[signerIDs[i]] = nil
+	}
+
+	for i := 0; i < threshold; i++ {
+		id := getID(i)
+		pm := newPresignPeerManager(id, threshold-1)
+		pm.setProcesses(processes)
+		listeners[id] = new(mocks.StateChangedListener)
+		var err error
+		presigners[id], err = NewPresigner(pm, sessionID, expPublic, getTestHomo(id), ss[i][1], uint32(threshold), bks, signerIDs, listeners[id])
+		Expect(err).Should(BeNil())
+		processes[id] = presigners[id]
+	}
+	return presigners, listeners
+}
+
+// newPresignersWithPubkeys news the presigners with the verified public keys of the peers. The tests after the
+// Pubkey round use them to skip verifying the proofs of the Paillier keys in every test.
+func newPresignersWithPubkeys(expPublic *ecpointgrouplaw.ECPoint, ss [][]*big.Int) (map[string]*Presigner, map[string]*mocks.StateChangedListener) {
+	presigners, listeners := newPresigners(expPublic, ss)
+	pubkeys := make(map[string]homo.Pubkey, len(presigners))
+	for id := range presigners {
+		pubkeys[id] = getTestHomo(id).GetPubKey()
+	}
+	for _, p := range presigners {
+		Expect(p.ph.setPeerPubkeys(pubkeys)).Should(BeNil())
+	}
+	return presigners, listeners
+}
+
+// runPresigners runs the presigners until done, and returns the presignatures.
+func runPresigners(presigners map[string]*Presigner, listeners map[string]*mocks.StateChangedListener) map[string]*Presignature {
+	doneChs := make([]chan struct{}, 0, len(listeners))
+	for _, l := range listeners {
+		doneCh := make(chan struct{})
+		doneChs = append(doneChs, doneCh)
+		l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+			close(doneCh)
+		}).Once()
+	}
+	for _, p := range presigners {
+		p.Start()
+	}
+
+	for fromID, fromD := range presigners {
+		msg := fromD.GetPubkeyMessage()
+		for toID, toD := range presigners {
+			if fromID == toID {
+				continue
+			}
+			Expect(toD.AddMessage(msg)).Should(BeNil())
+		}
+	}
+	for _, doneCh := range doneChs {
+		<-doneCh
+	}
+
+	presignatures := make(map[string]*Presignature, len(presigners))
+	for id, p := range presigners {
+		p.Stop()
+		var err error
+		presignatures[id], err = p.GetResult()
+		Expect(err).Should(BeNil())
+	}
+	for _, l := range listeners {
+		l.AssertExpectations(GinkgoT())
+	}
+	return presignatures
+}
+
+func newOnlineSigners(presignatures map[string]*Presignature, msg []byte) (map[string]*OnlineSigner, map[string]*mocks.StateChangedListener) {
+	signers := make(map[string]*OnlineSigner, len(presignatures))
+	processes := make(map[string]message.MessageAdder, len(presignatures))
+	listeners := make(map[string]*mocks.StateChangedListener, len(presignatures))
+	// Add the ids first since the handlers get the peer ids in the constructor
+	for id := range presignatures {
+		processes[id] = nil
+	}
+	for id, p := range presignatures {
+		pm := newPresignPeerManager(id, len(presignatures)-1)
+		pm.setProcesses(processes)
+		listeners[id] = new(mocks.StateChangedListener)
+		var err error
+		signers[id], err = NewOnlineSigner(pm, sessionID, p, msg, listeners[id])
+		Expect(err).Should(BeNil())
+	}
+	for id, s := range signers {
+		processes[id] = s
+	}
+	return signers, listeners
+}
+
+func runOnlineSigners(signers map[string]*OnlineSigner, listeners map[string]*mocks.StateChangedListener) map[string]*Result {
+	doneChs := make([]chan struct{}, 0, len(listeners))
+	for _, l := range listeners {
+		doneCh := make(chan struct{})
+		doneChs = append(doneChs, doneCh)
+		l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+			close(doneCh)
+		}).Once()
+	}
+	for _, s := range signers {
+		s.Start()
+	}
+
+	for fromID, fromD := range signers {
+		msg := fromD.GetSiMessage()
+		for toID, toD := range signers {
+			if fromID == toID {
+				continue
+			}
+			Expect(toD.AddMessage(msg)).Should(BeNil())
+		}
+	}
+	for _, doneCh := range doneChs {
+		<-doneCh
+	}
+
+	results := make(map[string]*Result, len(signers))
+	for id, s := range signers {
+		s.Stop()
+		var err error
+		results[id], err = s.GetResult()
+		Expect(err).Should(BeNil())
+	}
+	for _, l := range listeners {
+		l.AssertExpectations(GinkgoT())
+	}
+	return results
+}
@@ -0,0 +1,120 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+)
+
+const (
+	// scalarSize is the byte length of r and s in the fixed-size encodings
+	scalarSize = 32
+	// EthereumSignatureSize is the byte length of [R || S || V]
+	EthereumSignatureSize = 2*scalarSize + 1
+	// CompactSignatureSize is the byte length of [R || S]
+	CompactSignatureSize = 2 * scalarSize
+)
+
+var (
+	// ErrInvalidRecoveryID is returned if the recovery id could not be encoded
+	ErrInvalidRecoveryID = errors.New("invalid recovery id")
+	// ErrOversizedSignature is returned if r or s could not be encoded in 32 bytes
+	ErrOversizedSignature = errors.New("oversized signature")
+
+	big2  = big.NewInt(2)
+	big35 = big.NewInt(35)
+)
+
+type Result struct {
+	R *big.Int
+	S *big.Int
+	// RecoveryID is the y-parity of R plus 2 if the x-coordinate of R is not less than the curve order. It's
+	// adjusted if S is normalized to the lower one, so the public key could be recovered from (R, S).
+	RecoveryID byte
+}
+
+// NewResult builds the result from R and s. If lowS is true, s is normalized to the lower one (i.e. s <= N/2),
+// which is required by Bitcoin and Ethereum.
+func NewResult(r *pt.ECPoint, s *big.Int, lowS bool) *Result {
+	n := r.GetCurve().Params().N
+	x := r.GetX()
+	recoveryID := byte(r.GetY().Bit(0))
+	if x.Cmp(n) >= 0 {
+		recoveryID |= 2
+	}
+	s = new(big.Int).Set(s)
+	// This is copied from:
+	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
+	// This is needed because of tendermint checks here:
+	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
+	if lowS && s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		// (R, -s) is the signature of -R
+		s.Sub(n, s)
+		recoveryID ^= 1
+	}
+	return &Result{
+		R:          new(big.Int).Mod(x, n),
+		S:          s,
+		RecoveryID: recoveryID,
+	}
+}
+
+// EthereumBytes returns the 65-byte signature [R || S || V] used by Ethereum, where V is the recovery id (i.e.
+// 0 or 1). Add 27 to V for eth_sign, or use EIP155V for transactions.
+func (r *Result) EthereumBytes() ([]byte, error) {
+	if r.RecoveryID > 1 {
+		return nil, ErrInvalidRecoveryID
+	}
+	bs, err := r.CompactBytes()
+	if err != nil {
+		return nil, err
+	}
+	return append(bs, r.RecoveryID), nil
+}
+
+// EIP155V returns the v of a transaction signed for the chain id (i.e. chainID*2 + 35 + recovery id)
+func (r *Result) EIP155V(chainID *big.Int) (*big.Int, error) {
+	if r.RecoveryID > 1 {
+		return nil, ErrInvalidRecoveryID
+	}
+	v := new(big.Int).Mul(chainID, big2)
+	v.Add(v, big35)
+	return v.Add(v, big.NewInt(int64(r.RecoveryID))), nil
+}
+
+// DERBytes returns the DER encoding of the signature used by Bitcoin
+func (r *Result) DERBytes() ([]byte, error) {
+	return asn1.Marshal(struct {
+		R *big.Int
+		S *big.Int
+	}{r.R, r.S})
+}
+
+// CompactBytes returns the 64-byte signature [R || S]
+func (r *Result) CompactBytes() ([]byte, error) {
+	if r.R.BitLen() > 8*scalarSize || r.S.BitLen() > 8*scalarSize {
+		return nil, ErrOversizedSignature
+	}
+	bs := make([]byte, CompactSignatureSize)
+	rBytes := r.R.Bytes()
+	sBytes := r.S.Bytes()
+	copy(bs[scalarSize-len(rBytes):scalarSize], rBytes)
+	copy(bs[CompactSignatureSize-len(sBytes):], sBytes)
+	return bs, nil
+}
@@ -0,0 +1,142 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Result", func() {
+	var (
+		curve = btcec.S256()
+		n     = curve.Params().N
+		halfN = new(big.Int).Rsh(n, 1)
+		hash  = []byte("9ca9bf7e9c8b1e1c2b6f4e0e1c2f2e6a")
+	)
+
+	// sign signs the hash by the private key, and returns R and s
+	sign := func() (*pt.ECPoint, *big.Int) {
+		k, err := utils.RandomPositiveInt(n)
+		Expect(err).Should(BeNil())
+		r := pt.ScalarBaseMult(curve, k)
+		s := new(big.Int).Mul(r.GetX(), privateKey)
+		s.Add(s, new(big.Int).SetBytes(hash))
+		s.Mul(s, new(big.Int).ModInverse(k, n))
+		return r, s.Mod(s, n)
+	}
+
+	// recover recovers the public key from the compact signature of btcec
+	recoverPubkey := func(result *Result) *btcec.PublicKey {
+		compact, err := result.CompactBytes()
+		Expect(err).Should(BeNil())
+		sig := append([]byte{27 + result.RecoveryID}, compact...)
+		pubkey, _, err := btcec.RecoverCompact(curve, sig, hash)
+		Expect(err).Should(BeNil())
+		return pubkey
+	}
+
+	It("recovers the public key with or without low s", func() {
+		expected := pt.ScalarBaseMult(curve, privateKey)
+		// Sign many times to get both high and low s
+		for i := 0; i < 20; i++ {
+			r, s := sign()
+			for _, lowS := range []bool{true, false} {
+				result := NewResult(r, s, lowS)
+				Expect(result.R).Should(Equal(new(big.Int).Mod(r.GetX(), n)))
+				if lowS {
+					Expect(result.S.Cmp(halfN)).ShouldNot(BeNumerically(">", 0))
+				} else {
+					Expect(result.S).Should(Equal(s))
+				}
+				pubkey := recoverPubkey(result)
+				Expect(pubkey.X).Should(Equal(expected.GetX()))
+				Expect(pubkey.Y).Should(Equal(expected.GetY()))
+			}
+		}
+	})
+
+	Context("Encodings", func() {
+		var result *Result
+		BeforeEach(func() {
+			r, s := sign()
+			result = NewResult(r, s, true)
+		})
+
+		It("EthereumBytes()", func() {
+			got, err := result.EthereumBytes()
+			Expect(err).Should(BeNil())
+			Expect(got).Should(HaveLen(EthereumSignatureSize))
+			Expect(new(big.Int).SetBytes(got[:32])).Should(Equal(result.R))
+			Expect(new(big.Int).SetBytes(got[32:64])).Should(Equal(result.S))
+			Expect(got[64]).Should(Equal(result.RecoveryID))
+		})
+
+		It("EIP155V()", func() {
+			result.RecoveryID = 0
+			got, err := result.EIP155V(big.NewInt(1))
+			Expect(err).Should(BeNil())
+			Expect(got).Should(Equal(big.NewInt(37)))
+			result.RecoveryID = 1
+			got, err = result.EIP155V(big.NewInt(3))
+			Expect(err).Should(BeNil())
+			Expect(got).Should(Equal(big.NewInt(42)))
+		})
+
+		It("DERBytes()", func() {
+			got, err := result.DERBytes()
+			Expect(err).Should(BeNil())
+			sig, err := btcec.ParseDERSignature(got, curve)
+			Expect(err).Should(BeNil())
+			Expect(sig.R).Should(Equal(result.R))
+			Expect(sig.S).Should(Equal(result.S))
+		})
+
+		It("CompactBytes()", func() {
+			result.R = big.NewInt(1)
+			got, err := result.CompactBytes()
+			Expect(err).Should(BeNil())
+			Expect(got).Should(HaveLen(CompactSignatureSize))
+			Expect(got[31]).Should(Equal(byte(1)))
+			Expect(new(big.Int).SetBytes(got[:32])).Should(Equal(big.NewInt(1)))
+			Expect(new(big.Int).SetBytes(got[32:])).Should(Equal(result.S))
+		})
+
+		It("invalid recovery id", func() {
+			result.RecoveryID = 2
+			got, err := result.EthereumBytes()
+			Expect(err).Should(Equal(ErrInvalidRecoveryID))
+			Expect(got).Should(BeNil())
+			v, err := result.EIP155V(big.NewInt(1))
+			Expect(err).Should(Equal(ErrInvalidRecoveryID))
+			Expect(v).Should(BeNil())
+		})
+
+		It("oversized signature", func() {
+			result.S = new(big.Int).Lsh(big.NewInt(1), 256)
+			got, err := result.CompactBytes()
+			Expect(err).Should(Equal(ErrOversizedSignature))
+			Expect(got).Should(BeNil())
+			got, err = result.EthereumBytes()
+			Expect(err).Should(Equal(ErrOversizedSignature))
+			Expect(got).Should(BeNil())
+		})
+	})
+})
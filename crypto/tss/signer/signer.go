@@ -15,7 +15,6 @@
 package signer
 
 import (
-	fmt "fmt"
 	"math/big"
 
 	"github.com/getamis/alice/crypto/birkhoffinterpolation"
@@ -27,32 +26,55 @@ import (
 	"github.com/getamis/sirius/log"
 )
 
-type Result struct {
-	R *big.Int
-	S *big.Int
-}
-
 type Signer struct {
 	ph *pubkeyHandler
 	*message.MsgMain
+
+	lowS bool
 }
 
-func NewSigner(peerManager types.PeerManager, expectedPubkey *pt.ECPoint, homo homo.Crypto, secret *big.Int, bks map[string]*birkhoffinterpolation.BkParameter, msg []byte, listener types.StateChangedListener) (*Signer, error) {
-	numPeers := peerManager.NumPeers()
-	//u0 := big.NewInt(0)
-	//fmt.Printf("secret: %d\n", secret)
+// NewSigner creates a signer. The bks are the Birkhoff parameters of the whole key group (e.g. the ones in the
+// DKG result), and signerIDs are the ids of the participants including self, who must be exactly the ones in the
+// peer manager. The signers are ensured to be authorized by the threshold and their ranks.
+func NewSigner(peerManager types.PeerManager, sessionID []byte, expectedPubkey *pt.ECPoint, homo homo.Crypto, secret *big.Int, threshold uint32, bks map[string]*birkhoffinterpolation.BkParameter, signerIDs []string, msg []byte, listener types.StateChangedListener) (*Signer, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	ph, err := newPubkeyHandler(expectedPubkey, peerManager, sessionID, homo, secret, threshold, bks, signerIDs, msg)
+	if err != nil {
+		log.Warn("Failed to new a public key handler", "err", err)
+		return nil, err
+	}
+	return newSignerWithCurrentHandler(peerManager, sessionID, listener, ph, ph), nil
+}
 
-	ph, err := newPubkeyHandler(expectedPubkey, peerManager, homo, secret, bks, msg)
+// NewSignerWithPubkeys creates a signer with the homomorphic public keys of the peers verified before (e.g. the
+// result of auxinfo). The homo crypto must be the one whose public key was verified by the peers. The public
+// keys are neither sent out nor verified again, so the per-signature setup is skipped.
+func NewSignerWithPubkeys(peerManager types.PeerManager, sessionID []byte, expectedPubkey *pt.ECPoint, homo homo.Crypto, peerPubkeys map[string]homo.Pubkey, secret *big.Int, threshold uint32, bks map[string]*birkhoffinterpolation.BkParameter, signerIDs []string, msg []byte, listener types.StateChangedListener) (*Signer, error) {
+	if err := tss.EnsureSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	ph, err := newPubkeyHandler(expectedPubkey, peerManager, sessionID, homo, secret, threshold, bks, signerIDs, msg)
 	if err != nil {
 		log.Warn("Failed to new a public key handler", "err", err)
 		return nil, err
 	}
+	err = ph.setPeerPubkeys(peerPubkeys)
+	if err != nil {
+		return nil, err
+	}
+	return newSignerWithCurrentHandler(peerManager, sessionID, listener, ph, ph), nil
+}
+
+func newSignerWithCurrentHandler(peerManager types.PeerManager, sessionID []byte, listener types.StateChangedListener, ph *pubkeyHandler, handler types.Handler) *Signer {
 	return &Signer{
-		ph: ph,
-		MsgMain: message.NewMsgMain(peerManager.SelfID(),
-			numPeers,
+		ph:   ph,
+		lowS: true,
+		MsgMain: message.NewMsgMain(peerManager,
+			sessionID,
 			listener,
-			ph,
+			handler,
 			types.MessageType(Type_Pubkey),
 			types.MessageType(Type_EncK),
 			types.MessageType(Type_Mta),
@@ -64,14 +86,19 @@ func NewSigner(peerManager types.PeerManager, expectedPubkey *pt.ECPoint, homo h
 			types.MessageType(Type_DecommitUiTi),
 			types.MessageType(Type_Si),
 		),
-	}, nil
+	}
 }
 
 func (s *Signer) GetPubkeyMessage() *Message {
 	return s.ph.GetPubkeyMessage()
 }
 
-// GetResult returns the final result: public key, share, bks (including self bk)
+// SetLowS sets whether s of the result is normalized to the lower one. It's enabled by default.
+func (s *Signer) SetLowS(lowS bool) {
+	s.lowS = lowS
+}
+
+// GetResult returns the signature with the recovery id
 func (s *Signer) GetResult() (*Result, error) {
 	if s.GetState() != types.StateDone {
 		return nil, tss.ErrNotReady
@@ -83,22 +110,5 @@ func (s *Signer) GetResult() (*Result, error) {
 		log.Error("We cannot convert to result handler in done state")
 		return nil, tss.ErrNotReady
 	}
-
-	// This is copied from:
-	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
-	// This is needed because of tendermint checks here:
-	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
-	sumS := new(big.Int).Set(rh.s)
-
-	curve := s.ph.publicKey.GetCurve()
-	secp256k1halfN := new(big.Int).Rsh(curve.Params().N, 1)
-	if sumS.Cmp(secp256k1halfN) > 0 {
-		sumS.Sub(curve.Params().N, sumS)
-	}
-	// sumS should be used for bitcoin or ethereum
-	fmt.Printf("r: %d s: %d sumS: %d\n", rh.r.GetX(), rh.s, sumS)
-	return &Result{
-		R: new(big.Int).Set(rh.r.GetX()),
-		S: new(big.Int).Set(sumS),
-	}, nil
+	return NewResult(rh.r, rh.s, s.lowS), nil
 }
@@ -16,6 +16,7 @@ package signer
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
@@ -24,8 +25,10 @@ import (
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/getamis/alice/crypto/birkhoffinterpolation"
 	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo"
 	"github.com/getamis/alice/crypto/homo/paillier"
 	"github.com/getamis/alice/crypto/tss"
+	"github.com/getamis/alice/crypto/tss/message"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/message/types/mocks"
 	proto "github.com/golang/protobuf/proto"
@@ -52,6 +55,14 @@ func TestSigner(t *testing.T) {
 	RunSpecs(t, "Signer Suite")
 }
 
+var _ = BeforeSuite(func() {
+	for i := 0; i < numTestHomos; i++ {
+		homo, err := paillier.NewPaillier(2048)
+		Expect(err).Should(BeNil())
+		testHomos[getID(i)] = homo
+	}
+})
+
 var _ = Describe("Signer", func() {
 	// m is transaction( message to sign ). could be calculated by transaction, not here
 	m := new(big.Int)
@@ -116,6 +127,24 @@ var _ = Describe("Signer", func() {
 				r = result.R
 				s = result.S
 			}
+
+			// The public key could be recovered by the recovery id
+			compact, err := result.CompactBytes()
+			Expect(err).Should(BeNil())
+			recovered, _, err := btcec.RecoverCompact(btcec.S256(), append([]byte{27 + result.RecoveryID}, compact...), msg)
+			Expect(err).Should(BeNil())
+			Expect(recovered.X).Should(Equal(expPublic.GetX()))
+			Expect(recovered.Y).Should(Equal(expPublic.GetY()))
+
+			// The higher s is returned if low s is disabled
+			signer.SetLowS(false)
+			highResult, err := signer.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(highResult.R).Should(Equal(result.R))
+			if highResult.S.Cmp(result.S) != 0 {
+				Expect(new(big.Int).Add(highResult.S, result.S)).Should(Equal(curve.Params().N))
+				Expect(highResult.RecoveryID).Should(Equal(result.RecoveryID ^ 1))
+			}
 		}
 
 		ecdsaPublicKey := &ecdsa.PublicKey{
@@ -169,12 +198,105 @@ var _ = Describe("Signer", func() {
 				{big.NewInt(64444), big.NewInt(15554), big.NewInt(2)},
 			}, big.NewInt(8274194)),*/
 	)
+
+	It("signs with the verified public keys of the peers", func() {
+		expPublic := ecpointgrouplaw.ScalarBaseMult(curve, privateKey)
+		signers, listeners := newSignersWithPubkeys(curve, expPublic, [][]*big.Int{
+			{shareX, shareY, big.NewInt(0)},
+			{shareX2, shareY2, big.NewInt(0)},
+			{shareX3, shareY3, big.NewInt(0)},
+		}, msg)
+		doneChs := make([]chan struct{}, 0, len(listeners))
+		for _, l := range listeners {
+			doneCh := make(chan struct{})
+			doneChs = append(doneChs, doneCh)
+			l.On("OnStateChanged", types.StateInit, types.StateDone).Run(func(args mock.Arguments) {
+				close(doneCh)
+			}).Once()
+		}
+
+		// The public keys are not sent out again
+		for fromID, fromD := range signers {
+			msg := fromD.GetPubkeyMessage()
+			Expect(msg.GetPubkey().GetPubkey()).Should(BeEmpty())
+			for toID, toD := range signers {
+				if fromID == toID {
+					continue
+				}
+				Expect(toD.AddMessage(msg)).Should(BeNil())
+			}
+		}
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+
+		ecdsaPublicKey := &ecdsa.PublicKey{
+			Curve: expPublic.GetCurve(),
+			X:     expPublic.GetX(),
+			Y:     expPublic.GetY(),
+		}
+		for _, signer := range signers {
+			signer.Stop()
+			result, err := signer.GetResult()
+			Expect(err).Should(BeNil())
+			Expect(ecdsa.Verify(ecdsaPublicKey, msg, result.R, result.S)).Should(BeTrue())
+		}
+		for _, l := range listeners {
+			l.AssertExpectations(GinkgoT())
+		}
+	})
+
+	It("public keys of the peers not found", func() {
+		expPublic := ecpointgrouplaw.ScalarBaseMult(curve, privateKey)
+		bks := map[string]*birkhoffinterpolation.BkParameter{
+			getID(0): birkhoffinterpolation.NewBkParameter(shareX, 0),
+			getID(1): birkhoffinterpolation.NewBkParameter(shareX2, 0),
+		}
+		pm := newPeerManager(getID(0), 1)
+		pm.setSigners(map[string]*Signer{
+			getID(0): nil,
+			getID(1): nil,
+		})
+		s, err := NewSignerWithPubkeys(pm, sessionID, expPublic, getTestHomo(getID(0)), nil, shareY, 2, bks, []string{getID(0), getID(1)}, msg, nil)
+		Expect(err).Should(Equal(ErrPubkeyNotFound))
+		Expect(s).Should(BeNil())
+	})
+
+	It("empty session id", func() {
+		expPublic := ecpointgrouplaw.ScalarBaseMult(curve, privateKey)
+		s, err := NewSigner(newPeerManager(getID(0), 2), nil, expPublic, nil, shareY, 2, nil, nil, msg, nil)
+		Expect(err).Should(Equal(tss.ErrEmptySessionID))
+		Expect(s).Should(BeNil())
+	})
 })
 
+var (
+	sessionID = []byte("session")
+
+	// testHomos are the homomorphic keys of the signers shared by the tests. They are generated once in
+	// BeforeSuite, since generating them is slow.
+	testHomos    = make(map[string]*paillier.Paillier, numTestHomos)
+	numTestHomos = 3
+)
+
+func getTestHomo(id string) *paillier.Paillier {
+	homo, ok := testHomos[id]
+	ExpectWithOffset(1, ok).Should(BeTrue())
+	return homo
+}
+
 func getID(id int) string {
 	return fmt.Sprintf("id-%d", id)
 }
 
+// expectBlame expects that the error blames the sender of the message with the message as the evidence.
+func expectBlame(err error, msg *Message) {
+	var blameErr *message.BlameError
+	ExpectWithOffset(1, errors.As(err, &blameErr)).Should(BeTrue())
+	ExpectWithOffset(1, blameErr.Culprits).Should(Equal([]string{msg.GetId()}))
+	ExpectWithOffset(1, blameErr.Evidence).Should(Equal([]types.Message{msg}))
+}
+
 type peerManager struct {
 	id       string
 	numPeers uint32
@@ -200,6 +322,16 @@ func (p *peerManager) SelfID() string {
 	return p.id
 }
 
+func (p *peerManager) PeerIDs() []string {
+	ids := make([]string, 0, len(p.signers))
+	for id := range p.signers {
+		if id != p.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func (p *peerManager) MustSend(id string, message proto.Message) {
 	d := p.signers[id]
 	msg := message.(types.Message)
@@ -213,8 +345,12 @@ func newSigners(curve elliptic.Curve, expPublic *ecpointgrouplaw.ECPoint, ss [][
 	listeners := make(map[string]*mocks.StateChangedListener, threshold)
 
 	bks := make(map[string]*birkhoffinterpolation.BkParameter, threshold)
+	signerIDs := make([]string, threshold)
 	for i := 0; i < threshold; i++ {
-		bks[getID(i)] = birkhoffinterpolation.NewBkParameter(ss[i][0], uint32(ss[i][2].Uint64()))
+		signerIDs[i] = getID(i)
+		bks[signerIDs[i]] = birkhoffinterpolation.NewBkParameter(ss[i][0], uint32(ss[i][2].Uint64()))
+		// Add the ids first since the peer ids are checked in the constructor
+		signers[signerIDs[i]] = nil
 	}
 
 	for i := 0; i < threshold; i++ {
@@ -223,9 +359,8 @@ func newSigners(curve elliptic.Curve, expPublic *ecpointgrouplaw.ECPoint, ss [][
 		pm.setSigners(signers)
 		peerManagers[i] = pm
 		listeners[id] = new(mocks.StateChangedListener)
-		homo, err := paillier.NewPaillier(2048)
-		Expect(err).Should(BeNil())
-		signers[id], err = NewSigner(peerManagers[i], expPublic, homo, ss[i][1], bks, msg, listeners[id])
+		var err error
+		signers[id], err = NewSigner(peerManagers[i], sessionID, expPublic, getTestHomo(id), ss[i][1], uint32(threshold), bks, signerIDs, msg, listeners[id])
 		Expect(err).Should(BeNil())
 		r, err := signers[id].GetResult()
 		Expect(r).Should(BeNil())
@@ -234,3 +369,40 @@ func newSigners(curve elliptic.Curve, expPublic *ecpointgrouplaw.ECPoint, ss [][
 	}
 	return signers, listeners
 }
+
+// newSignersWithPubkeys news the signers with the homomorphic public keys of the peers, which are verified before.
+func newSignersWithPubkeys(curve elliptic.Curve, expPublic *ecpointgrouplaw.ECPoint, ss [][]*big.Int, msg []byte) (map[string]*Signer, map[string]*mocks.StateChangedListener) {
+	threshold := len(ss)
+	signers := make(map[string]*Signer, threshold)
+	listeners := make(map[string]*mocks.StateChangedListener, threshold)
+
+	bks := make(map[string]*birkhoffinterpolation.BkParameter, threshold)
+	signerIDs := make([]string, threshold)
+	homos := make(map[string]homo.Crypto, threshold)
+	for i := 0; i < threshold; i++ {
+		id := getID(i)
+		signerIDs[i] = id
+		bks[id] = birkhoffinterpolation.NewBkParameter(ss[i][0], uint32(ss[i][2].Uint64()))
+		homos[id] = getTestHomo(id)
+		// Add the ids first since the handlers get the peer ids in the constructor
+		signers[id] = nil
+	}
+
+	for i := 0; i < threshold; i++ {
+		id := getID(i)
+		pm := newPeerManager(id, threshold-1)
+		pm.setSigners(signers)
+		listeners[id] = new(mocks.StateChangedListener)
+		peerPubkeys := make(map[string]homo.Pubkey, threshold-1)
+		for peerID, h := range homos {
+			if peerID != id {
+				peerPubkeys[peerID] = h.GetPubKey()
+			}
+		}
+		var err error
+		signers[id], err = NewSignerWithPubkeys(pm, sessionID, expPublic, homos[id], peerPubkeys, ss[i][1], uint32(threshold), bks, signerIDs, msg, listeners[id])
+		Expect(err).Should(BeNil())
+		signers[id].Start()
+	}
+	return signers, listeners
+}
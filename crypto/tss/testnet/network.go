@@ -0,0 +1,249 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testnet provides an in-process network to run the tss processes end-to-end. The network could inject
+// latency, reordering, duplication, drops and crash-stop peers.
+package testnet
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/sirius/log"
+	"github.com/golang/protobuf/proto"
+)
+
+// Config defines the faults injected by the network
+type Config struct {
+	// MinLatency and MaxLatency are the bounds of the random latency of a message. The messages are reordered
+	// if MaxLatency is larger than MinLatency.
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// DuplicateRate is the probability that a message is delivered twice
+	DuplicateRate float64
+	// DropRate is the probability that a message is dropped
+	DropRate float64
+	// RoundTimeout and SessionTimeout are set to the processes run by the network, so that they fail instead of
+	// waiting forever if messages are dropped or peers crash. Zero means no timeout.
+	RoundTimeout   time.Duration
+	SessionTimeout time.Duration
+	// Seed is the seed of the random faults
+	Seed int64
+}
+
+// Network is an in-process network. Each peer joins the network by a peer manager, and the messages sent by
+// MustSend are delivered to AddMessage of the receivers after the faults are injected.
+type Network struct {
+	config *Config
+
+	lock       sync.Mutex
+	rand       *rand.Rand
+	receivers  map[string]message.MessageAdder
+	crashed    map[string]chan struct{}
+	sent       map[string]int
+	crashAfter map[string]int
+}
+
+// NewNetwork news a network with the config. A nil config means a reliable network without any faults.
+func NewNetwork(config *Config) *Network {
+	if config == nil {
+		config = &Config{}
+	}
+	return &Network{
+		config:     config,
+		rand:       rand.New(rand.NewSource(config.Seed)),
+		receivers:  make(map[string]message.MessageAdder),
+		crashed:    make(map[string]chan struct{}),
+		sent:       make(map[string]int),
+		crashAfter: make(map[string]int),
+	}
+}
+
+// NewPeerManager news a peer manager of the peer. The peer ids are the other peers in the process.
+func (n *Network) NewPeerManager(id string, peerIDs []string) *PeerManager {
+	return &PeerManager{
+		network: n,
+		id:      id,
+		peerIDs: peerIDs,
+	}
+}
+
+// Join sets the receiver of the peer. The messages to the peer are dropped before it joins.
+func (n *Network) Join(id string, receiver message.MessageAdder) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.receivers[id] = receiver
+}
+
+// Leave removes the receiver of the peer.
+func (n *Network) Leave(id string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	delete(n.receivers, id)
+}
+
+// Crash crash-stops the peer. All the messages from and to the peer are dropped afterwards.
+func (n *Network) Crash(id string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.crash(id)
+}
+
+// CrashAfter crash-stops the peer after it sends count messages.
+func (n *Network) CrashAfter(id string, count int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.sent[id] >= count {
+		n.crash(id)
+		return
+	}
+	n.crashAfter[id] = count
+}
+
+// IsCrashed checks if the peer crashed.
+func (n *Network) IsCrashed(id string) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.isCrashed(id)
+}
+
+func (n *Network) crashedCh(id string) <-chan struct{} {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	ch, ok := n.crashed[id]
+	if !ok {
+		ch = make(chan struct{})
+		n.crashed[id] = ch
+	}
+	return ch
+}
+
+func (n *Network) crash(id string) {
+	ch, ok := n.crashed[id]
+	if !ok {
+		ch = make(chan struct{})
+		n.crashed[id] = ch
+	}
+	select {
+	case <-ch:
+	default:
+		close(ch)
+	}
+	delete(n.crashAfter, id)
+}
+
+func (n *Network) isCrashed(id string) bool {
+	ch, ok := n.crashed[id]
+	if !ok {
+		return false
+	}
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func (n *Network) send(from string, to string, msg proto.Message) {
+	n.lock.Lock()
+	if n.isCrashed(from) {
+		n.lock.Unlock()
+		return
+	}
+	if count, ok := n.crashAfter[from]; ok && n.sent[from] >= count {
+		n.crash(from)
+		n.lock.Unlock()
+		return
+	}
+	n.sent[from]++
+	if n.rand.Float64() < n.config.DropRate {
+		n.lock.Unlock()
+		log.Debug("Drop message", "from", from, "to", to)
+		return
+	}
+	delays := []time.Duration{n.latency()}
+	if n.rand.Float64() < n.config.DuplicateRate {
+		delays = append(delays, n.latency())
+	}
+	n.lock.Unlock()
+
+	for _, delay := range delays {
+		// Clone the message as if it's serialized, so that the receivers never share it with the sender.
+		m := proto.Clone(msg)
+		time.AfterFunc(delay, func() {
+			n.deliver(from, to, m)
+		})
+	}
+}
+
+func (n *Network) latency() time.Duration {
+	d := n.config.MinLatency
+	if jitter := n.config.MaxLatency - n.config.MinLatency; jitter > 0 {
+		d += time.Duration(n.rand.Int63n(int64(jitter)))
+	}
+	return d
+}
+
+func (n *Network) deliver(from string, to string, msg proto.Message) {
+	n.lock.Lock()
+	receiver, ok := n.receivers[to]
+	crashed := n.isCrashed(to)
+	n.lock.Unlock()
+	if !ok || crashed {
+		log.Debug("Drop message to unknown or crashed peer", "from", from, "to", to)
+		return
+	}
+	tMsg, ok := msg.(types.Message)
+	if !ok {
+		log.Warn("Drop invalid message", "from", from, "to", to)
+		return
+	}
+	err := receiver.AddMessage(tMsg)
+	if err != nil {
+		log.Debug("Failed to add message", "from", from, "to", to, "err", err)
+	}
+}
+
+// PeerManager implements types.PeerManager over the network
+type PeerManager struct {
+	network *Network
+	id      string
+	peerIDs []string
+}
+
+func (p *PeerManager) NumPeers() uint32 {
+	return uint32(len(p.peerIDs))
+}
+
+func (p *PeerManager) SelfID() string {
+	return p.id
+}
+
+func (p *PeerManager) PeerIDs() []string {
+	return p.peerIDs
+}
+
+func (p *PeerManager) MustSend(id string, msg proto.Message) {
+	p.network.send(p.id, id, msg)
+}
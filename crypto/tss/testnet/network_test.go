@@ -0,0 +1,142 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testnet
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestTestnet(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Testnet Suite")
+}
+
+var _ = Describe("Network", func() {
+	var (
+		receiver *recorder
+	)
+
+	BeforeEach(func() {
+		receiver = &recorder{}
+	})
+
+	newMsg := func(id string) *message.EchoMessage {
+		return &message.EchoMessage{
+			Id:        id,
+			SessionId: []byte("session"),
+		}
+	}
+
+	It("delivers messages", func() {
+		n := NewNetwork(nil)
+		n.Join("id-1", receiver)
+		pm := n.NewPeerManager("id-0", []string{"id-1"})
+		Expect(pm.SelfID()).Should(Equal("id-0"))
+		Expect(pm.NumPeers()).Should(Equal(uint32(1)))
+		Expect(pm.PeerIDs()).Should(Equal([]string{"id-1"}))
+
+		msg := newMsg("id-0")
+		pm.MustSend("id-1", msg)
+		Eventually(receiver.get).Should(HaveLen(1))
+		got := receiver.get()[0]
+		Expect(got).Should(Equal(msg))
+		// The message is cloned
+		Expect(got).ShouldNot(BeIdenticalTo(msg))
+	})
+
+	It("reorders messages", func() {
+		n := NewNetwork(&Config{
+			MaxLatency: 50 * time.Millisecond,
+			Seed:       1,
+		})
+		n.Join("id-1", receiver)
+		pm := n.NewPeerManager("id-0", []string{"id-1"})
+		var sent []string
+		for i := 0; i < 20; i++ {
+			msg := newMsg("id-0")
+			msg.Id = string(rune('a' + i))
+			sent = append(sent, msg.Id)
+			pm.MustSend("id-1", msg)
+		}
+		Eventually(receiver.get).Should(HaveLen(20))
+		var got []string
+		for _, msg := range receiver.get() {
+			got = append(got, msg.GetId())
+		}
+		Expect(got).Should(ConsistOf(sent))
+		Expect(got).ShouldNot(Equal(sent))
+	})
+
+	It("duplicates messages", func() {
+		n := NewNetwork(&Config{
+			DuplicateRate: 1,
+		})
+		n.Join("id-1", receiver)
+		n.NewPeerManager("id-0", []string{"id-1"}).MustSend("id-1", newMsg("id-0"))
+		Eventually(receiver.get).Should(HaveLen(2))
+	})
+
+	It("drops messages", func() {
+		n := NewNetwork(&Config{
+			DropRate: 1,
+		})
+		n.Join("id-1", receiver)
+		n.NewPeerManager("id-0", []string{"id-1"}).MustSend("id-1", newMsg("id-0"))
+		Consistently(receiver.get, 50*time.Millisecond).Should(BeEmpty())
+	})
+
+	It("crash-stops peers", func() {
+		n := NewNetwork(nil)
+		n.Join("id-1", receiver)
+		pm := n.NewPeerManager("id-0", []string{"id-1"})
+		n.CrashAfter("id-0", 2)
+		for i := 0; i < 3; i++ {
+			pm.MustSend("id-1", newMsg("id-0"))
+		}
+		Expect(n.IsCrashed("id-0")).Should(BeTrue())
+		Eventually(receiver.get).Should(HaveLen(2))
+		Consistently(receiver.get, 50*time.Millisecond).Should(HaveLen(2))
+
+		// The messages to a crashed peer are dropped
+		n.Crash("id-1")
+		n.NewPeerManager("id-2", []string{"id-1"}).MustSend("id-1", newMsg("id-2"))
+		Consistently(receiver.get, 50*time.Millisecond).Should(HaveLen(2))
+	})
+})
+
+type recorder struct {
+	lock sync.Mutex
+	msgs []types.Message
+}
+
+func (r *recorder) AddMessage(msg types.Message) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.msgs = append(r.msgs, msg)
+	return nil
+}
+
+func (r *recorder) get() []types.Message {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]types.Message(nil), r.msgs...)
+}
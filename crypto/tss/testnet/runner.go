@@ -0,0 +1,658 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testnet
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/getamis/alice/crypto/birkhoffinterpolation"
+	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/tss/addshare/newpeer"
+	"github.com/getamis/alice/crypto/tss/addshare/oldpeer"
+	"github.com/getamis/alice/crypto/tss/auxinfo"
+	"github.com/getamis/alice/crypto/tss/cggmp"
+	"github.com/getamis/alice/crypto/tss/dkg"
+	"github.com/getamis/alice/crypto/tss/frost"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/crypto/tss/reshare"
+	"github.com/getamis/alice/crypto/tss/signer"
+	"github.com/golang/protobuf/proto"
+)
+
+// ErrNoPeers is returned if there are no peers to run the process
+var ErrNoPeers = errors.New("no peers")
+
+// RunError is returned if any process of the peers which didn't crash failed
+type RunError struct {
+	// Failures are the failure reports of the failed processes
+	Failures map[string]*message.Failure
+}
+
+func (e *RunError) Error() string {
+	ids := make([]string, 0, len(e.Failures))
+	for id := range e.Failures {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return fmt.Sprintf("processes failed: %v", ids)
+}
+
+// process defines the common methods of the tss processes
+type process interface {
+	message.MessageAdder
+	Start()
+	Stop()
+	SetTimeout(roundTimeout time.Duration, sessionTimeout time.Duration)
+	GetFailure() *message.Failure
+}
+
+type node struct {
+	id       string
+	proc     process
+	listener *listener
+	// kickoff sends the messages of the first round
+	kickoff func()
+}
+
+// listener is notified when the process is done or failed
+type listener struct {
+	once   sync.Once
+	doneCh chan struct{}
+}
+
+func newListener() *listener {
+	return &listener{
+		doneCh: make(chan struct{}),
+	}
+}
+
+func (l *listener) OnStateChanged(oldState types.MainState, newState types.MainState) {
+	if newState == types.StateDone || newState == types.StateFailed {
+		l.once.Do(func() {
+			close(l.doneCh)
+		})
+	}
+}
+
+// RunDKG runs DKG among the peers in ranks, which maps the peer ids to their ranks. It returns the results of
+// the peers which are done. A *RunError is returned if any peer which didn't crash failed.
+func (n *Network) RunDKG(sessionID []byte, curve elliptic.Curve, threshold uint32, ranks map[string]uint32) (map[string]*dkg.Result, error) {
+	ids := rankIDs(ranks)
+	dkgs := make(map[string]*dkg.DKG, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		d, err := dkg.NewDKG(curve, pm, sessionID, threshold, ranks[id], l)
+		if err != nil {
+			return nil, err
+		}
+		dkgs[id] = d
+		nodes[i] = &node{
+			id:       id,
+			proc:     d,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, d.GetPeerMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	results := make(map[string]*dkg.Result, len(ids))
+	for id, d := range dkgs {
+		if r, e := d.GetResult(); e == nil {
+			results[id] = r
+		}
+	}
+	return results, err
+}
+
+// RunBatchDKG runs the batch mode of DKG among the peers in ranks, which generates batchSize keys in one
+// session. It returns the results of the peers which are done, in which the i-th result belongs to the i-th key.
+func (n *Network) RunBatchDKG(sessionID []byte, curve elliptic.Curve, threshold uint32, ranks map[string]uint32, batchSize uint32) (map[string][]*dkg.Result, error) {
+	ids := rankIDs(ranks)
+	dkgs := make(map[string]*dkg.BatchDKG, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		d, err := dkg.NewBatchDKG(curve, pm, sessionID, threshold, ranks[id], batchSize, l)
+		if err != nil {
+			return nil, err
+		}
+		dkgs[id] = d
+		nodes[i] = &node{
+			id:       id,
+			proc:     d,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, d.GetPeerMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	results := make(map[string][]*dkg.Result, len(ids))
+	for id, d := range dkgs {
+		if rs, e := d.GetResults(); e == nil {
+			results[id] = rs
+		}
+	}
+	return results, err
+}
+
+// RunSigner signs the message by the peers in results, which maps the peer ids to their DKG results. The peers
+// must be authorized to sign by the threshold of the key. The homo function news the homomorphic encryption of
+// each peer.
+func (n *Network) RunSigner(sessionID []byte, threshold uint32, homoFunc func() (homo.Crypto, error), results map[string]*dkg.Result, msg []byte) (map[string]*signer.Result, error) {
+	ids := resultIDs(results)
+	signers := make(map[string]*signer.Signer, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		h, err := homoFunc()
+		if err != nil {
+			return nil, err
+		}
+		r := results[id]
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		s, err := signer.NewSigner(pm, sessionID, r.PublicKey, h, r.Share, threshold, r.Bks, ids, msg, l)
+		if err != nil {
+			return nil, err
+		}
+		signers[id] = s
+		nodes[i] = &node{
+			id:       id,
+			proc:     s,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, s.GetPubkeyMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	signerResults := make(map[string]*signer.Result, len(ids))
+	for id, s := range signers {
+		if r, e := s.GetResult(); e == nil {
+			signerResults[id] = r
+		}
+	}
+	return signerResults, err
+}
+
+// RunBatchSigner signs the messages in one session by the peers in results like RunSigner. It returns the results
+// of the peers which are done, in which the i-th result is the signature of the i-th message.
+func (n *Network) RunBatchSigner(sessionID []byte, threshold uint32, homoFunc func() (homo.Crypto, error), results map[string]*dkg.Result, msgs [][]byte) (map[string][]*signer.Result, error) {
+	ids := resultIDs(results)
+	signers := make(map[string]*signer.BatchSigner, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		h, err := homoFunc()
+		if err != nil {
+			return nil, err
+		}
+		r := results[id]
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		s, err := signer.NewBatchSigner(pm, sessionID, r.PublicKey, h, r.Share, threshold, r.Bks, ids, msgs, l)
+		if err != nil {
+			return nil, err
+		}
+		signers[id] = s
+		nodes[i] = &node{
+			id:       id,
+			proc:     s,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, s.GetPubkeyMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	signerResults := make(map[string][]*signer.Result, len(ids))
+	for id, s := range signers {
+		if rs, e := s.GetResults(); e == nil {
+			signerResults[id] = rs
+		}
+	}
+	return signerResults, err
+}
+
+// RunPresigner runs the message-independent rounds of the signer by the peers in results, which maps the peer ids
+// to their DKG results. It returns the presignatures of the peers which are done.
+func (n *Network) RunPresigner(sessionID []byte, threshold uint32, homoFunc func() (homo.Crypto, error), results map[string]*dkg.Result) (map[string]*signer.Presignature, error) {
+	ids := resultIDs(results)
+	presigners := make(map[string]*signer.Presigner, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		h, err := homoFunc()
+		if err != nil {
+			return nil, err
+		}
+		r := results[id]
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		p, err := signer.NewPresigner(pm, sessionID, r.PublicKey, h, r.Share, threshold, r.Bks, ids, l)
+		if err != nil {
+			return nil, err
+		}
+		presigners[id] = p
+		nodes[i] = &node{
+			id:       id,
+			proc:     p,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, p.GetPubkeyMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	presignatures := make(map[string]*signer.Presignature, len(ids))
+	for id, p := range presigners {
+		if r, e := p.GetResult(); e == nil {
+			presignatures[id] = r
+		}
+	}
+	return presignatures, err
+}
+
+// RunOnlineSigner signs the message in one round by the peers in presignatures, which maps the peer ids to their
+// presignatures. The presignatures are consumed even if the signing fails.
+func (n *Network) RunOnlineSigner(sessionID []byte, presignatures map[string]*signer.Presignature, msg []byte) (map[string]*signer.Result, error) {
+	ids := make([]string, 0, len(presignatures))
+	for id := range presignatures {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	signers := make(map[string]*signer.OnlineSigner, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		s, err := signer.NewOnlineSigner(pm, sessionID, presignatures[id], msg, l)
+		if err != nil {
+			return nil, err
+		}
+		signers[id] = s
+		nodes[i] = &node{
+			id:       id,
+			proc:     s,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, s.GetSiMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	signerResults := make(map[string]*signer.Result, len(ids))
+	for id, s := range signers {
+		if r, e := s.GetResult(); e == nil {
+			signerResults[id] = r
+		}
+	}
+	return signerResults, err
+}
+
+// RunFrost signs the message by FROST with the peers in results, which maps the peer ids to their DKG results
+// over Ed25519.
+func (n *Network) RunFrost(sessionID []byte, results map[string]*dkg.Result, msg []byte) (map[string]*frost.Result, error) {
+	ids := resultIDs(results)
+	signers := make(map[string]*frost.Signer, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		r := results[id]
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		s, err := frost.NewSigner(pm, sessionID, r.PublicKey, r.Share, selectBks(r.Bks, ids), r.PublicShares, msg, l)
+		if err != nil {
+			return nil, err
+		}
+		signers[id] = s
+		nodes[i] = &node{
+			id:       id,
+			proc:     s,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, s.GetCommitMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	signerResults := make(map[string]*frost.Result, len(ids))
+	for id, s := range signers {
+		if r, e := s.GetResult(); e == nil {
+			signerResults[id] = r
+		}
+	}
+	return signerResults, err
+}
+
+// RunAuxInfo broadcasts the homomorphic public keys among the peers in ids once. The homo function news the
+// homomorphic encryption of each peer.
+func (n *Network) RunAuxInfo(sessionID []byte, homoFunc func() (homo.Crypto, error), ids []string) (map[string]*auxinfo.Result, error) {
+	auxInfos := make(map[string]*auxinfo.AuxInfo, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		h, err := homoFunc()
+		if err != nil {
+			return nil, err
+		}
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		a, err := auxinfo.NewAuxInfo(pm, sessionID, h, l)
+		if err != nil {
+			return nil, err
+		}
+		auxInfos[id] = a
+		nodes[i] = &node{
+			id:       id,
+			proc:     a,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, a.GetPubkeyMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	auxResults := make(map[string]*auxinfo.Result, len(ids))
+	for id, a := range auxInfos {
+		if r, e := a.GetResult(); e == nil {
+			auxResults[id] = r
+		}
+	}
+	return auxResults, err
+}
+
+// RunSignerWithAuxInfo signs the message by the peers in results like RunSigner, but with the homomorphic keys
+// in auxResults, which are verified before by RunAuxInfo.
+func (n *Network) RunSignerWithAuxInfo(sessionID []byte, threshold uint32, auxResults map[string]*auxinfo.Result, results map[string]*dkg.Result, msg []byte) (map[string]*signer.Result, error) {
+	ids := resultIDs(results)
+	signers := make(map[string]*signer.Signer, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		r := results[id]
+		a, ok := auxResults[id]
+		if !ok {
+			return nil, signer.ErrPubkeyNotFound
+		}
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		s, err := signer.NewSignerWithPubkeys(pm, sessionID, r.PublicKey, a.Homo, a.Pubkeys, r.Share, threshold, r.Bks, ids, msg, l)
+		if err != nil {
+			return nil, err
+		}
+		signers[id] = s
+		nodes[i] = &node{
+			id:       id,
+			proc:     s,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, s.GetPubkeyMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	signerResults := make(map[string]*signer.Result, len(ids))
+	for id, s := range signers {
+		if r, e := s.GetResult(); e == nil {
+			signerResults[id] = r
+		}
+	}
+	return signerResults, err
+}
+
+// RunCGGMPPresigner presigns by CGGMP with the peers in results, which maps the peer ids to their DKG results. The
+// Paillier keys in auxResults are verified before by RunAuxInfo. It returns the presignatures of the peers which are
+// done.
+func (n *Network) RunCGGMPPresigner(sessionID []byte, auxResults map[string]*auxinfo.Result, results map[string]*dkg.Result) (map[string]*cggmp.Presignature, error) {
+	ids := resultIDs(results)
+	presigners := make(map[string]*cggmp.Presigner, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		a, ok := auxResults[id]
+		if !ok {
+			return nil, cggmp.ErrPubkeyNotFound
+		}
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		p, err := cggmp.NewPresigner(pm, sessionID, results[id], a, l)
+		if err != nil {
+			return nil, err
+		}
+		presigners[id] = p
+		nodes[i] = &node{
+			id:       id,
+			proc:     p,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, p.GetEncKMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	presignatures := make(map[string]*cggmp.Presignature, len(ids))
+	for id, p := range presigners {
+		if r, e := p.GetResult(); e == nil {
+			presignatures[id] = r
+		}
+	}
+	return presignatures, err
+}
+
+// RunCGGMPOnlineSigner signs the message in one round by the peers in presignatures of CGGMP. The presignatures
+// are consumed even if the signing fails.
+func (n *Network) RunCGGMPOnlineSigner(sessionID []byte, presignatures map[string]*cggmp.Presignature, msg []byte) (map[string]*signer.Result, error) {
+	ids := make([]string, 0, len(presignatures))
+	for id := range presignatures {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	signers := make(map[string]*cggmp.OnlineSigner, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		s, err := cggmp.NewOnlineSigner(pm, sessionID, presignatures[id], msg, l)
+		if err != nil {
+			return nil, err
+		}
+		signers[id] = s
+		nodes[i] = &node{
+			id:       id,
+			proc:     s,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, s.GetSigmaMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	signerResults := make(map[string]*signer.Result, len(ids))
+	for id, s := range signers {
+		if r, e := s.GetResult(); e == nil {
+			signerResults[id] = r
+		}
+	}
+	return signerResults, err
+}
+
+// RunReshare refreshes the shares of the peers in results, which maps the peer ids to their DKG results.
+func (n *Network) RunReshare(sessionID []byte, threshold uint32, results map[string]*dkg.Result) (map[string]*reshare.Result, error) {
+	ids := resultIDs(results)
+	reshares := make(map[string]*reshare.Reshare, len(ids))
+	nodes := make([]*node, len(ids))
+	for i, id := range ids {
+		r := results[id]
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		rs, err := reshare.NewReshare(pm, sessionID, threshold, r.PublicKey, r.Share, selectBks(r.Bks, ids), l)
+		if err != nil {
+			return nil, err
+		}
+		reshares[id] = rs
+		nodes[i] = &node{
+			id:       id,
+			proc:     rs,
+			listener: l,
+			kickoff: func() {
+				broadcast(pm, rs.GetCommitMessage())
+			},
+		}
+	}
+	err := n.run(nodes)
+	reshareResults := make(map[string]*reshare.Result, len(ids))
+	for id, rs := range reshares {
+		if r, e := rs.GetResult(); e == nil {
+			reshareResults[id] = r
+		}
+	}
+	return reshareResults, err
+}
+
+// RunAddShare adds a share of the new peer with the peers in results, which maps the peer ids to their DKG
+// results. It returns the results of the old peers and the new peer.
+func (n *Network) RunAddShare(sessionID []byte, threshold uint32, results map[string]*dkg.Result, newPeerID string, newPeerRank uint32) (map[string]*oldpeer.Result, *newpeer.Result, error) {
+	ids := resultIDs(results)
+	olds := make(map[string]*oldpeer.AddShare, len(ids))
+	nodes := make([]*node, 0, len(ids)+1)
+	for _, id := range ids {
+		r := results[id]
+		pm := n.NewPeerManager(id, otherIDs(ids, id))
+		l := newListener()
+		a, err := oldpeer.NewAddShare(pm, sessionID, r.PublicKey, threshold, r.Share, selectBks(r.Bks, ids), newPeerID, l)
+		if err != nil {
+			return nil, nil, err
+		}
+		olds[id] = a
+		nodes = append(nodes, &node{
+			id:       id,
+			proc:     a,
+			listener: l,
+			kickoff: func() {
+				pm.MustSend(newPeerID, a.GetPeerMessage())
+			},
+		})
+	}
+	if len(ids) == 0 {
+		return nil, nil, ErrNoPeers
+	}
+	l := newListener()
+	newAddShare, err := newpeer.NewAddShare(n.NewPeerManager(newPeerID, ids), sessionID, results[ids[0]].PublicKey, threshold, newPeerRank, l)
+	if err != nil {
+		return nil, nil, err
+	}
+	nodes = append(nodes, &node{
+		id:       newPeerID,
+		proc:     newAddShare,
+		listener: l,
+		kickoff:  func() {},
+	})
+
+	err = n.run(nodes)
+	oldResults := make(map[string]*oldpeer.Result, len(ids))
+	for id, a := range olds {
+		if r, e := a.GetResult(); e == nil {
+			oldResults[id] = r
+		}
+	}
+	newResult, _ := newAddShare.GetResult()
+	return oldResults, newResult, err
+}
+
+// run starts the processes and waits until all the processes are done, failed or crashed.
+func (n *Network) run(nodes []*node) error {
+	for _, nd := range nodes {
+		nd.proc.SetTimeout(n.config.RoundTimeout, n.config.SessionTimeout)
+		n.Join(nd.id, nd.proc)
+	}
+	for _, nd := range nodes {
+		nd.proc.Start()
+	}
+	for _, nd := range nodes {
+		nd.kickoff()
+	}
+	for _, nd := range nodes {
+		select {
+		case <-nd.listener.doneCh:
+		case <-n.crashedCh(nd.id):
+		}
+	}
+
+	failures := make(map[string]*message.Failure)
+	for _, nd := range nodes {
+		nd.proc.Stop()
+		n.Leave(nd.id)
+		if n.IsCrashed(nd.id) {
+			continue
+		}
+		if f := nd.proc.GetFailure(); f != nil {
+			failures[nd.id] = f
+		}
+	}
+	if len(failures) > 0 {
+		return &RunError{
+			Failures: failures,
+		}
+	}
+	return nil
+}
+
+func broadcast(pm *PeerManager, msg proto.Message) {
+	for _, id := range pm.PeerIDs() {
+		pm.MustSend(id, msg)
+	}
+}
+
+func otherIDs(ids []string, selfID string) []string {
+	others := make([]string, 0, len(ids)-1)
+	for _, id := range ids {
+		if id != selfID {
+			others = append(others, id)
+		}
+	}
+	return others
+}
+
+func rankIDs(ranks map[string]uint32) []string {
+	ids := make([]string, 0, len(ranks))
+	for id := range ranks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func resultIDs(results map[string]*dkg.Result) []string {
+	ids := make([]string, 0, len(results))
+	for id := range results {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// selectBks returns the bks of the peers
+func selectBks(bks map[string]*birkhoffinterpolation.BkParameter, ids []string) map[string]*birkhoffinterpolation.BkParameter {
+	selected := make(map[string]*birkhoffinterpolation.BkParameter, len(ids))
+	for _, id := range ids {
+		selected[id] = bks[id]
+	}
+	return selected
+}
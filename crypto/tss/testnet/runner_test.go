@@ -0,0 +1,362 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testnet
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/homo"
+	"github.com/getamis/alice/crypto/homo/paillier"
+	"github.com/getamis/alice/crypto/tss/cggmp"
+	"github.com/getamis/alice/crypto/tss/dkg"
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/signer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Runner", func() {
+	var (
+		curve     = btcec.S256()
+		threshold = uint32(2)
+		ranks     = map[string]uint32{
+			"id-0": 0,
+			"id-1": 0,
+			"id-2": 0,
+		}
+		msg      = []byte{1, 2, 3}
+		homoFunc = func() (homo.Crypto, error) {
+			return paillier.NewPaillier(2048)
+		}
+	)
+
+	verify := func(results map[string]*dkg.Result, n *Network, threshold uint32, sessionID []byte) {
+		signerResults, err := n.RunSigner(sessionID, threshold, homoFunc, results, msg)
+		Expect(err).Should(BeNil())
+		Expect(signerResults).Should(HaveLen(len(results)))
+		var publicKey *ecdsa.PublicKey
+		for _, r := range results {
+			publicKey = &ecdsa.PublicKey{
+				Curve: curve,
+				X:     r.PublicKey.GetX(),
+				Y:     r.PublicKey.GetY(),
+			}
+		}
+		for _, r := range signerResults {
+			Expect(ecdsa.Verify(publicKey, msg, r.R, r.S)).Should(BeTrue())
+		}
+	}
+
+	It("runs all the processes on an unreliable network", func() {
+		n := NewNetwork(&Config{
+			MaxLatency:    10 * time.Millisecond,
+			DuplicateRate: 0.2,
+			Seed:          1,
+		})
+		results, err := n.RunDKG([]byte("dkg"), curve, threshold, ranks)
+		Expect(err).Should(BeNil())
+		Expect(results).Should(HaveLen(len(ranks)))
+		for _, r := range results {
+			Expect(r.PublicKey).Should(Equal(results["id-0"].PublicKey))
+		}
+
+		By("Signing by two of them")
+		verify(map[string]*dkg.Result{
+			"id-0": results["id-0"],
+			"id-2": results["id-2"],
+		}, n, threshold, []byte("signer"))
+
+		By("Resharing")
+		reshareResults, err := n.RunReshare([]byte("reshare"), threshold, results)
+		Expect(err).Should(BeNil())
+		for id, r := range reshareResults {
+			Expect(r.Share).ShouldNot(Equal(results[id].Share))
+			results[id] = &dkg.Result{
+				PublicKey: results[id].PublicKey,
+				Share:     r.Share,
+				Bks:       results[id].Bks,
+			}
+		}
+		verify(map[string]*dkg.Result{
+			"id-1": results["id-1"],
+			"id-2": results["id-2"],
+		}, n, threshold, []byte("signer-2"))
+
+		By("Adding a share")
+		oldResults, newResult, err := n.RunAddShare([]byte("addshare"), threshold, results, "id-3", 0)
+		Expect(err).Should(BeNil())
+		Expect(oldResults).Should(HaveLen(len(results)))
+		Expect(newResult.PublicKey).Should(Equal(results["id-0"].PublicKey))
+		verify(map[string]*dkg.Result{
+			"id-0": {
+				PublicKey: oldResults["id-0"].PublicKey,
+				Share:     oldResults["id-0"].Share,
+				Bks:       oldResults["id-0"].Bks,
+			},
+			"id-3": {
+				PublicKey: newResult.PublicKey,
+				Share:     newResult.Share,
+				Bks:       newResult.Bks,
+			},
+		}, n, threshold, []byte("signer-3"))
+	})
+
+	It("signs with the homomorphic keys of auxinfo", func() {
+		n := NewNetwork(&Config{
+			MaxLatency: 10 * time.Millisecond,
+			Seed:       2,
+		})
+		results, err := n.RunDKG([]byte("dkg"), curve, threshold, ranks)
+		Expect(err).Should(BeNil())
+		auxResults, err := n.RunAuxInfo([]byte("auxinfo"), homoFunc, []string{"id-0", "id-1", "id-2"})
+		Expect(err).Should(BeNil())
+		Expect(auxResults).Should(HaveLen(len(ranks)))
+		for id, r := range auxResults {
+			Expect(r.Pubkeys).Should(HaveLen(len(ranks) - 1))
+			for peerID, pubkey := range r.Pubkeys {
+				Expect(pubkey.GetMessageRange(curve.Params().N)).Should(Equal(auxResults[peerID].Homo.GetMessageRange(curve.Params().N)), id)
+			}
+		}
+
+		publicKey := &ecdsa.PublicKey{
+			Curve: curve,
+			X:     results["id-0"].PublicKey.GetX(),
+			Y:     results["id-0"].PublicKey.GetY(),
+		}
+		// The keys are reused by the signers of different subsets
+		for i, signers := range [][]string{{"id-0", "id-1"}, {"id-1", "id-2"}} {
+			selected := make(map[string]*dkg.Result, len(signers))
+			for _, id := range signers {
+				selected[id] = results[id]
+			}
+			signerResults, err := n.RunSignerWithAuxInfo([]byte(fmt.Sprintf("signer-%d", i)), threshold, auxResults, selected, msg)
+			Expect(err).Should(BeNil())
+			Expect(signerResults).Should(HaveLen(len(signers)))
+			for _, r := range signerResults {
+				Expect(ecdsa.Verify(publicKey, msg, r.R, r.S)).Should(BeTrue())
+			}
+		}
+	})
+
+	It("signs under a derived child key without running DKG again", func() {
+		n := NewNetwork(&Config{
+			MaxLatency: 10 * time.Millisecond,
+			Seed:       4,
+		})
+		results, err := n.RunDKG([]byte("dkg"), curve, 3, map[string]uint32{
+			"id-0": 0,
+			"id-1": 0,
+			"id-2": 1,
+			"id-3": 1,
+		})
+		Expect(err).Should(BeNil())
+
+		children := make(map[string]*dkg.Result, len(results))
+		for id, r := range results {
+			children[id], err = r.DeriveChild(id, "m/44/60/0/0/7")
+			Expect(err).Should(BeNil())
+		}
+		for _, c := range children {
+			Expect(c.PublicKey.Equal(children["id-0"].PublicKey)).Should(BeTrue())
+			Expect(c.PublicKey.Equal(results["id-0"].PublicKey)).Should(BeFalse())
+		}
+		verify(map[string]*dkg.Result{
+			"id-0": children["id-0"],
+			"id-2": children["id-2"],
+			"id-3": children["id-3"],
+		}, n, 3, []byte("signer"))
+	})
+
+	It("runs batch DKG and signs with one of the keys", func() {
+		n := NewNetwork(&Config{
+			MaxLatency:    10 * time.Millisecond,
+			DuplicateRate: 0.2,
+			Seed:          5,
+		})
+		batchResults, err := n.RunBatchDKG([]byte("batch-dkg"), curve, threshold, ranks, 4)
+		Expect(err).Should(BeNil())
+		Expect(batchResults).Should(HaveLen(len(ranks)))
+		for _, rs := range batchResults {
+			Expect(rs).Should(HaveLen(4))
+			for i, r := range rs {
+				Expect(r.PublicKey.Equal(batchResults["id-0"][i].PublicKey)).Should(BeTrue())
+			}
+		}
+
+		verify(map[string]*dkg.Result{
+			"id-1": batchResults["id-1"][2],
+			"id-2": batchResults["id-2"][2],
+		}, n, threshold, []byte("signer"))
+	})
+
+	It("signs a batch of messages in one session", func() {
+		n := NewNetwork(&Config{
+			MaxLatency:    10 * time.Millisecond,
+			DuplicateRate: 0.2,
+			Seed:          9,
+		})
+		results, err := n.RunDKG([]byte("dkg"), curve, threshold, ranks)
+		Expect(err).Should(BeNil())
+		signers := map[string]*dkg.Result{
+			"id-1": results["id-1"],
+			"id-2": results["id-2"],
+		}
+		msgs := [][]byte{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+		signerResults, err := n.RunBatchSigner([]byte("batch-signer"), threshold, homoFunc, signers, msgs)
+		Expect(err).Should(BeNil())
+		Expect(signerResults).Should(HaveLen(len(signers)))
+		publicKey := &ecdsa.PublicKey{
+			Curve: curve,
+			X:     results["id-0"].PublicKey.GetX(),
+			Y:     results["id-0"].PublicKey.GetY(),
+		}
+		for _, rs := range signerResults {
+			Expect(rs).Should(HaveLen(len(msgs)))
+			for i, r := range rs {
+				Expect(ecdsa.Verify(publicKey, msgs[i], r.R, r.S)).Should(BeTrue())
+			}
+		}
+	})
+
+	It("presigns and signs the message in one round", func() {
+		n := NewNetwork(&Config{
+			MaxLatency:    10 * time.Millisecond,
+			DuplicateRate: 0.2,
+			Seed:          6,
+		})
+		results, err := n.RunDKG([]byte("dkg"), curve, threshold, ranks)
+		Expect(err).Should(BeNil())
+		signers := map[string]*dkg.Result{
+			"id-0": results["id-0"],
+			"id-2": results["id-2"],
+		}
+		presignatures, err := n.RunPresigner([]byte("presigner"), threshold, homoFunc, signers)
+		Expect(err).Should(BeNil())
+		Expect(presignatures).Should(HaveLen(len(signers)))
+
+		signerResults, err := n.RunOnlineSigner([]byte("online-signer"), presignatures, msg)
+		Expect(err).Should(BeNil())
+		Expect(signerResults).Should(HaveLen(len(signers)))
+		publicKey := &ecdsa.PublicKey{
+			Curve: curve,
+			X:     results["id-0"].PublicKey.GetX(),
+			Y:     results["id-0"].PublicKey.GetY(),
+		}
+		for _, r := range signerResults {
+			Expect(ecdsa.Verify(publicKey, msg, r.R, r.S)).Should(BeTrue())
+		}
+
+		// The presignatures could not be used again
+		signerResults, err = n.RunOnlineSigner([]byte("online-signer-2"), presignatures, []byte("another message"))
+		Expect(err).Should(Equal(signer.ErrPresignatureUsed))
+		Expect(signerResults).Should(BeNil())
+	})
+
+	It("presigns by CGGMP with the DKG result and the keys of auxinfo", func() {
+		n := NewNetwork(&Config{
+			MaxLatency:    10 * time.Millisecond,
+			DuplicateRate: 0.2,
+			Seed:          8,
+		})
+		results, err := n.RunDKG([]byte("dkg"), curve, threshold, ranks)
+		Expect(err).Should(BeNil())
+		auxResults, err := n.RunAuxInfo([]byte("auxinfo"), homoFunc, []string{"id-0", "id-1", "id-2"})
+		Expect(err).Should(BeNil())
+		signers := map[string]*dkg.Result{
+			"id-1": results["id-1"],
+			"id-2": results["id-2"],
+		}
+		presignatures, err := n.RunCGGMPPresigner([]byte("presigner"), auxResults, signers)
+		Expect(err).Should(BeNil())
+		Expect(presignatures).Should(HaveLen(len(signers)))
+
+		signerResults, err := n.RunCGGMPOnlineSigner([]byte("online-signer"), presignatures, msg)
+		Expect(err).Should(BeNil())
+		Expect(signerResults).Should(HaveLen(len(signers)))
+		publicKey := &ecdsa.PublicKey{
+			Curve: curve,
+			X:     results["id-0"].PublicKey.GetX(),
+			Y:     results["id-0"].PublicKey.GetY(),
+		}
+		for _, r := range signerResults {
+			Expect(ecdsa.Verify(publicKey, msg, r.R, r.S)).Should(BeTrue())
+		}
+
+		// The presignatures could not be used again
+		signerResults, err = n.RunCGGMPOnlineSigner([]byte("online-signer-2"), presignatures, []byte("another message"))
+		Expect(err).Should(Equal(cggmp.ErrPresignatureUsed))
+		Expect(signerResults).Should(BeNil())
+	})
+
+	It("runs DKG and FROST over Ed25519", func() {
+		n := NewNetwork(&Config{
+			MaxLatency:    10 * time.Millisecond,
+			DuplicateRate: 0.2,
+			Seed:          3,
+		})
+		results, err := n.RunDKG([]byte("dkg"), ecpointgrouplaw.Edwards25519(), 3, map[string]uint32{
+			"id-0": 0,
+			"id-1": 0,
+			"id-2": 1,
+			"id-3": 1,
+		})
+		Expect(err).Should(BeNil())
+		publicKey, err := results["id-0"].PublicKey.ToEd25519()
+		Expect(err).Should(BeNil())
+
+		signerResults, err := n.RunFrost([]byte("frost"), map[string]*dkg.Result{
+			"id-0": results["id-0"],
+			"id-2": results["id-2"],
+			"id-3": results["id-3"],
+		}, msg)
+		Expect(err).Should(BeNil())
+		Expect(signerResults).Should(HaveLen(3))
+		for _, r := range signerResults {
+			Expect(ed25519.Verify(ed25519.PublicKey(publicKey), msg, r.Signature)).Should(BeTrue())
+		}
+	})
+
+	It("reports the crashed peer", func() {
+		n := NewNetwork(&Config{
+			RoundTimeout: 200 * time.Millisecond,
+		})
+		// id-2 crashes after sending the peer messages
+		n.CrashAfter("id-2", 2)
+		results, err := n.RunDKG([]byte("dkg"), curve, threshold, ranks)
+		Expect(results).Should(BeEmpty())
+		var runErr *RunError
+		Expect(errors.As(err, &runErr)).Should(BeTrue())
+		Expect(runErr.Failures).Should(HaveLen(2))
+		for _, f := range runErr.Failures {
+			Expect(f.Culprits).Should(Equal([]string{"id-2"}))
+			var timeoutErr *message.TimeoutError
+			Expect(errors.As(f.Err, &timeoutErr)).Should(BeTrue())
+		}
+	})
+
+	It("no peers", func() {
+		oldResults, newResult, err := NewNetwork(nil).RunAddShare([]byte("addshare"), threshold, nil, "id-3", 0)
+		Expect(err).Should(Equal(ErrNoPeers))
+		Expect(oldResults).Should(BeNil())
+		Expect(newResult).Should(BeNil())
+	})
+})
+
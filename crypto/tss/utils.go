@@ -16,6 +16,7 @@ package tss
 
 import (
 	"errors"
+	"sort"
 
 	"github.com/getamis/alice/crypto/birkhoffinterpolation"
 	"github.com/getamis/alice/crypto/commitment"
@@ -33,21 +34,22 @@ var (
 	ErrInconsistentThreshold     = errors.New("inconsistent threshold")
 	ErrInconsistentPeerNumAndBks = errors.New("inconsistent peer num and bks")
 	ErrInconsistentPubKey        = errors.New("inconsistent public key")
+	ErrEmptySessionID            = errors.New("empty session id")
 )
 
-func NewCommitterByPoint(p *pt.ECPoint) (*commitment.HashCommitmenter, error) {
+func NewCommitterByPoint(sessionID []byte, p *pt.ECPoint) (*commitment.HashCommitmenter, error) {
 	msg, err := p.ToEcPointMessage()
 	if err != nil {
 		log.Warn("Failed to convert to an ec point message", "err", err)
 		return nil, err
 	}
 
-	return commitment.NewProtoHashCommitmenter(msg)
+	return commitment.NewProtoHashCommitmenterWithSession(sessionID, msg)
 }
 
-func GetPointFromHashCommitment(logger log.Logger, commit *commitment.HashCommitmentMessage, decommit *commitment.HashDecommitmentMessage) (*pt.ECPoint, error) {
+func GetPointFromHashCommitment(logger log.Logger, sessionID []byte, commit *commitment.HashCommitmentMessage, decommit *commitment.HashDecommitmentMessage) (*pt.ECPoint, error) {
 	msg := &pt.EcPointMessage{}
-	err := commit.DecommitToProto(decommit, msg)
+	err := commit.DecommitToProtoWithSession(sessionID, decommit, msg)
 	if err != nil {
 		logger.Warn("Failed to decommit message", "err", err)
 		return nil, err
@@ -60,6 +62,14 @@ func GetPointFromHashCommitment(logger log.Logger, commit *commitment.HashCommit
 	return point, nil
 }
 
+// EnsureSessionID checks if the session id is valid
+func EnsureSessionID(sessionID []byte) error {
+	if len(sessionID) == 0 {
+		return ErrEmptySessionID
+	}
+	return nil
+}
+
 func ValidatePublicKey(logger log.Logger, bks birkhoffinterpolation.BkParameters, sgs []*pt.ECPoint, threshold uint32, pubkey *pt.ECPoint) error {
 	fieldOrder := pubkey.GetCurve().Params().N
 	scalars, err := bks.ComputeBkCoefficient(threshold, fieldOrder)
@@ -78,3 +88,29 @@ func ValidatePublicKey(logger log.Logger, bks birkhoffinterpolation.BkParameters
 	}
 	return nil
 }
+
+// ValidatePublicShares checks if the public shares (i.e. s_i*G) of the participants reconstruct the public key.
+// The keys of publicShares must be the same as the ones of bks.
+func ValidatePublicShares(logger log.Logger, bks map[string]*birkhoffinterpolation.BkParameter, publicShares map[string]*pt.ECPoint, threshold uint32, pubkey *pt.ECPoint) error {
+	if len(bks) != len(publicShares) {
+		logger.Warn("Inconsistent bks and public shares", "bks", len(bks), "publicShares", len(publicShares))
+		return ErrInconsistentPeerNumAndBks
+	}
+	ids := make([]string, 0, len(bks))
+	for id := range bks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	sortedBks := make(birkhoffinterpolation.BkParameters, len(ids))
+	sgs := make([]*pt.ECPoint, len(ids))
+	for i, id := range ids {
+		sg, ok := publicShares[id]
+		if !ok {
+			logger.Warn("Public share not found", "id", id)
+			return ErrInconsistentPeerNumAndBks
+		}
+		sortedBks[i] = bks[id]
+		sgs[i] = sg
+	}
+	return ValidatePublicKey(logger, sortedBks, sgs, threshold, pubkey)
+}
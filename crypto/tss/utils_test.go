@@ -36,32 +36,49 @@ func TestTSSUtils(t *testing.T) {
 
 var _ = Describe("Utils", func() {
 	Context("NewCommitterByPoint/GetPointFromHashCommitment", func() {
+		sessionID := []byte("session")
+
 		It("should be ok", func() {
 			p := pt.NewIdentity(btcec.S256())
-			c, err := NewCommitterByPoint(p)
+			c, err := NewCommitterByPoint(sessionID, p)
 			Expect(err).Should(BeNil())
 			Expect(c).ShouldNot(BeNil())
 
-			got, err := GetPointFromHashCommitment(log.Discard(), c.GetCommitmentMessage(), c.GetDecommitmentMessage())
+			got, err := GetPointFromHashCommitment(log.Discard(), sessionID, c.GetCommitmentMessage(), c.GetDecommitmentMessage())
 			Expect(err).Should(BeNil())
 			Expect(got.Equal(p)).Should(BeTrue())
 		})
 
+		It("different session", func() {
+			p := pt.NewIdentity(btcec.S256())
+			c, err := NewCommitterByPoint(sessionID, p)
+			Expect(err).Should(BeNil())
+
+			got, err := GetPointFromHashCommitment(log.Discard(), []byte("other session"), c.GetCommitmentMessage(), c.GetDecommitmentMessage())
+			Expect(err).Should(Equal(commitment.ErrDifferentDigest))
+			Expect(got).Should(BeNil())
+		})
+
 		It("failed to new by empty point", func() {
-			c, err := NewCommitterByPoint(&pt.ECPoint{})
+			c, err := NewCommitterByPoint(sessionID, &pt.ECPoint{})
 			Expect(err).ShouldNot(BeNil())
 			Expect(c).Should(BeNil())
 		})
 
 		It("not an ec point", func() {
-			cm, err := commitment.NewHashCommitmenter([]byte{1, 2, 3})
+			cm, err := commitment.NewHashCommitmenterWithSession(sessionID, []byte{1, 2, 3})
 			Expect(err).Should(BeNil())
-			got, err := GetPointFromHashCommitment(log.Discard(), cm.GetCommitmentMessage(), cm.GetDecommitmentMessage())
+			got, err := GetPointFromHashCommitment(log.Discard(), sessionID, cm.GetCommitmentMessage(), cm.GetDecommitmentMessage())
 			Expect(err).ShouldNot(BeNil())
 			Expect(got).Should(BeNil())
 		})
 	})
 
+	It("EnsureSessionID", func() {
+		Expect(EnsureSessionID([]byte("session"))).Should(BeNil())
+		Expect(EnsureSessionID(nil)).Should(Equal(ErrEmptySessionID))
+	})
+
 	Context("ValidatePublicKey", func() {
 		var (
 			err       error
@@ -143,4 +160,51 @@ var _ = Describe("Utils", func() {
 			Expect(err).Should(Equal(ErrInconsistentPubKey))
 		})
 	})
+
+	Context("ValidatePublicShares", func() {
+		var (
+			curve     = btcec.S256()
+			threshold = uint32(3)
+
+			expPubkey    *ecpointgrouplaw.ECPoint
+			bks          map[string]*birkhoffinterpolation.BkParameter
+			publicShares map[string]*ecpointgrouplaw.ECPoint
+		)
+
+		BeforeEach(func() {
+			poly, err := polynomial.RandomPolynomial(curve.Params().N, threshold-1)
+			Expect(err).Should(BeNil())
+			expPubkey = ecpointgrouplaw.ScalarBaseMult(curve, poly.Get(0))
+			xs := []*big.Int{big.NewInt(4), big.NewInt(7), big.NewInt(8), big.NewInt(11)}
+			ranks := []uint32{0, 0, 1, 1}
+			ids := []string{"a", "b", "c", "d"}
+			bks = make(map[string]*birkhoffinterpolation.BkParameter, len(ids))
+			publicShares = make(map[string]*ecpointgrouplaw.ECPoint, len(ids))
+			for i, id := range ids {
+				bks[id] = birkhoffinterpolation.NewBkParameter(xs[i], ranks[i])
+				si := poly.Differentiate(ranks[i]).Evaluate(xs[i])
+				publicShares[id] = ecpointgrouplaw.ScalarBaseMult(curve, si)
+			}
+		})
+
+		It("should be ok", func() {
+			Expect(ValidatePublicShares(log.Discard(), bks, publicShares, threshold, expPubkey)).Should(BeNil())
+		})
+
+		It("missing public share", func() {
+			delete(publicShares, "a")
+			Expect(ValidatePublicShares(log.Discard(), bks, publicShares, threshold, expPubkey)).Should(Equal(ErrInconsistentPeerNumAndBks))
+		})
+
+		It("public share of unknown id", func() {
+			publicShares["e"] = publicShares["a"]
+			delete(publicShares, "a")
+			Expect(ValidatePublicShares(log.Discard(), bks, publicShares, threshold, expPubkey)).Should(Equal(ErrInconsistentPeerNumAndBks))
+		})
+
+		It("swapped public shares", func() {
+			publicShares["a"], publicShares["b"] = publicShares["b"], publicShares["a"]
+			Expect(ValidatePublicShares(log.Discard(), bks, publicShares, threshold, expPubkey)).Should(Equal(ErrInconsistentPubKey))
+		})
+	})
 })
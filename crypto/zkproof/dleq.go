@@ -0,0 +1,285 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zkproof
+
+import (
+	"math/big"
+
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+/*
+	DLEQ (Chaum-Pedersen) proof: the prover convinces the verifier that P1 = x*G1 and P2 = x*G2 with the same x.
+	The verifier knows G1, P1, G2 and P2.
+
+	Step 1:
+	- The prover randomly chooses m in [1, p-1] and computes alpha1 := m*G1 and alpha2 := m*G2.
+	- The prover computes c := H(G1, P1, G2, P2, alpha1, alpha2, sid), where sid is the session id.
+	- The prover computes u := m + c*x mod p. The resulting proof is (alpha1, alpha2, u).
+	Step 2: The verifier verifies u*G1 = alpha1 + c*P1 and u*G2 = alpha2 + c*P2.
+
+	Pedersen DLEQ proof: the prover convinces the verifier that T = a*G + b*H and S = a*R with the same a, where G
+	is the base point. The verifier knows H, T, R and S.
+
+	Step 1:
+	- The prover randomly chooses m, n in [1, p-1] and computes alpha := m*G + n*H and beta := m*R.
+	- The prover computes c := H(G, H, T, R, S, alpha, beta, sid).
+	- The prover computes u := m + c*a mod p and t := n + c*b mod p. The resulting proof is (alpha, beta, u, t).
+	Step 2: The verifier verifies u*G + t*H = alpha + c*T and u*R = beta + c*S.
+*/
+
+// NewDLEQMessage returns the proof that x*g1 and x*g2 have the same discrete logarithm x, which is bound to the
+// session id.
+func NewDLEQMessage(sessionID []byte, x *big.Int, g1 *pt.ECPoint, g2 *pt.ECPoint) (*DLEQMessage, error) {
+	if !g1.IsSameCurve(g2) {
+		return nil, ErrDifferentCurves
+	}
+	fieldOrder := g1.GetCurve().Params().N
+	err := utils.InRange(x, big0, fieldOrder)
+	if err != nil {
+		return nil, err
+	}
+	m, err := utils.RandomInt(fieldOrder)
+	if err != nil {
+		return nil, err
+	}
+	p1 := g1.ScalarMult(x)
+	p2 := g2.ScalarMult(x)
+	alpha1 := g1.ScalarMult(m)
+	alpha2 := g2.ScalarMult(m)
+	msgs, err := toEcPointMessages(g1, p1, g2, p2, alpha1, alpha2)
+	if err != nil {
+		return nil, err
+	}
+	c, salt, err := utils.HashProtosRejectSampling(fieldOrder, msgs[0], msgs[1], msgs[2], msgs[3], msgs[4], msgs[5], &any.Any{
+		Value: sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// u := m + c*x mod p
+	u := new(big.Int).Mul(c, x)
+	u.Add(u, m)
+	u.Mod(u, fieldOrder)
+
+	msg := &DLEQMessage{
+		Salt:   salt,
+		Alpha1: msgs[4],
+		Alpha2: msgs[5],
+		U:      u.Bytes(),
+	}
+	err = msg.Verify(sessionID, g1, p1, g2, p2)
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Verify verifies that p1 = x*g1 and p2 = x*g2 with the same x in the session.
+func (msg *DLEQMessage) Verify(sessionID []byte, g1 *pt.ECPoint, p1 *pt.ECPoint, g2 *pt.ECPoint, p2 *pt.ECPoint) error {
+	if !g1.IsSameCurve(p1) || !g1.IsSameCurve(g2) || !g1.IsSameCurve(p2) {
+		return ErrDifferentCurves
+	}
+	fieldOrder := g1.GetCurve().Params().N
+	u := new(big.Int).SetBytes(msg.GetU())
+	err := utils.InRange(u, big0, fieldOrder)
+	if err != nil {
+		return err
+	}
+	alpha1, err := toPointOnCurve(msg.GetAlpha1(), g1)
+	if err != nil {
+		return err
+	}
+	alpha2, err := toPointOnCurve(msg.GetAlpha2(), g1)
+	if err != nil {
+		return err
+	}
+	msgs, err := toEcPointMessages(g1, p1, g2, p2)
+	if err != nil {
+		return err
+	}
+	c, err := utils.HashProtosToInt(msg.GetSalt(), msgs[0], msgs[1], msgs[2], msgs[3], msg.GetAlpha1(), msg.GetAlpha2(), &any.Any{
+		Value: sessionID,
+	})
+	if err != nil {
+		return err
+	}
+	err = utils.InRange(c, big0, fieldOrder)
+	if err != nil {
+		return err
+	}
+
+	// Expect u*G1 = alpha1 + c*P1 and u*G2 = alpha2 + c*P2
+	err = verifyLinearRelation(g1.ScalarMult(u), alpha1, p1.ScalarMult(c))
+	if err != nil {
+		return err
+	}
+	return verifyLinearRelation(g2.ScalarMult(u), alpha2, p2.ScalarMult(c))
+}
+
+// NewPedersenDLEQMessage returns the proof that T = a*G + b*h and S = a*r with the same a, which is bound to the
+// session id.
+func NewPedersenDLEQMessage(sessionID []byte, a *big.Int, b *big.Int, h *pt.ECPoint, r *pt.ECPoint) (*PedersenDLEQMessage, error) {
+	if !h.IsSameCurve(r) {
+		return nil, ErrDifferentCurves
+	}
+	curve := h.GetCurve()
+	fieldOrder := curve.Params().N
+	err := utils.InRange(a, big0, fieldOrder)
+	if err != nil {
+		return nil, err
+	}
+	err = utils.InRange(b, big0, fieldOrder)
+	if err != nil {
+		return nil, err
+	}
+	m, err := utils.RandomInt(fieldOrder)
+	if err != nil {
+		return nil, err
+	}
+	n, err := utils.RandomInt(fieldOrder)
+	if err != nil {
+		return nil, err
+	}
+	T, err := pt.ScalarBaseMult(curve, a).Add(h.ScalarMult(b))
+	if err != nil {
+		return nil, err
+	}
+	S := r.ScalarMult(a)
+	alpha, err := pt.ScalarBaseMult(curve, m).Add(h.ScalarMult(n))
+	if err != nil {
+		return nil, err
+	}
+	beta := r.ScalarMult(m)
+	msgs, err := toEcPointMessages(pt.NewBase(curve), h, T, r, S, alpha, beta)
+	if err != nil {
+		return nil, err
+	}
+	c, salt, err := utils.HashProtosRejectSampling(fieldOrder, msgs[0], msgs[1], msgs[2], msgs[3], msgs[4], msgs[5], msgs[6], &any.Any{
+		Value: sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// u := m + c*a mod p and t := n + c*b mod p
+	u := new(big.Int).Mul(c, a)
+	u.Add(u, m)
+	u.Mod(u, fieldOrder)
+	t := new(big.Int).Mul(c, b)
+	t.Add(t, n)
+	t.Mod(t, fieldOrder)
+
+	msg := &PedersenDLEQMessage{
+		Salt:  salt,
+		Alpha: msgs[5],
+		Beta:  msgs[6],
+		U:     u.Bytes(),
+		T:     t.Bytes(),
+	}
+	err = msg.Verify(sessionID, h, T, r, S)
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Verify verifies that T = a*G + b*h and S = a*r with the same a in the session.
+func (msg *PedersenDLEQMessage) Verify(sessionID []byte, h *pt.ECPoint, T *pt.ECPoint, r *pt.ECPoint, S *pt.ECPoint) error {
+	if !h.IsSameCurve(T) || !h.IsSameCurve(r) || !h.IsSameCurve(S) {
+		return ErrDifferentCurves
+	}
+	curve := h.GetCurve()
+	fieldOrder := curve.Params().N
+	u := new(big.Int).SetBytes(msg.GetU())
+	err := utils.InRange(u, big0, fieldOrder)
+	if err != nil {
+		return err
+	}
+	t := new(big.Int).SetBytes(msg.GetT())
+	err = utils.InRange(t, big0, fieldOrder)
+	if err != nil {
+		return err
+	}
+	alpha, err := toPointOnCurve(msg.GetAlpha(), h)
+	if err != nil {
+		return err
+	}
+	beta, err := toPointOnCurve(msg.GetBeta(), h)
+	if err != nil {
+		return err
+	}
+	msgs, err := toEcPointMessages(pt.NewBase(curve), h, T, r, S)
+	if err != nil {
+		return err
+	}
+	c, err := utils.HashProtosToInt(msg.GetSalt(), msgs[0], msgs[1], msgs[2], msgs[3], msgs[4], msg.GetAlpha(), msg.GetBeta(), &any.Any{
+		Value: sessionID,
+	})
+	if err != nil {
+		return err
+	}
+	err = utils.InRange(c, big0, fieldOrder)
+	if err != nil {
+		return err
+	}
+
+	// Expect u*G + t*H = alpha + c*T and u*R = beta + c*S
+	uGtH, err := pt.ScalarBaseMult(curve, u).Add(h.ScalarMult(t))
+	if err != nil {
+		return err
+	}
+	err = verifyLinearRelation(uGtH, alpha, T.ScalarMult(c))
+	if err != nil {
+		return err
+	}
+	return verifyLinearRelation(r.ScalarMult(u), beta, S.ScalarMult(c))
+}
+
+// verifyLinearRelation returns nil if expected = p1 + p2
+func verifyLinearRelation(expected *pt.ECPoint, p1 *pt.ECPoint, p2 *pt.ECPoint) error {
+	sum, err := p1.Add(p2)
+	if err != nil {
+		return err
+	}
+	if !expected.Equal(sum) {
+		return ErrVerifyFailure
+	}
+	return nil
+}
+
+func toPointOnCurve(msg *pt.EcPointMessage, base *pt.ECPoint) (*pt.ECPoint, error) {
+	p, err := msg.ToPoint()
+	if err != nil {
+		return nil, err
+	}
+	if !p.IsSameCurve(base) {
+		return nil, ErrDifferentCurves
+	}
+	return p, nil
+}
+
+func toEcPointMessages(points ...*pt.ECPoint) ([]*pt.EcPointMessage, error) {
+	msgs := make([]*pt.EcPointMessage, len(points))
+	for i, p := range points {
+		var err error
+		msgs[i], err = p.ToEcPointMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return msgs, nil
+}
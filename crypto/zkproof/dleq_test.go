@@ -0,0 +1,101 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zkproof
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DLEQ", func() {
+	var (
+		sessionID = []byte("session-1")
+		x         = big.NewInt(9527)
+	)
+
+	DescribeTable("should be ok", func(curve elliptic.Curve) {
+		g1 := pt.NewBase(curve)
+		g2 := pt.ScalarBaseMult(curve, big.NewInt(123))
+		msg, err := NewDLEQMessage(sessionID, x, g1, g2)
+		Expect(err).Should(BeNil())
+		p1 := g1.ScalarMult(x)
+		p2 := g2.ScalarMult(x)
+		Expect(msg.Verify(sessionID, g1, p1, g2, p2)).Should(BeNil())
+
+		// Different session
+		Expect(msg.Verify([]byte("session-2"), g1, p1, g2, p2)).Should(Equal(ErrVerifyFailure))
+		// Different discrete logarithms
+		Expect(msg.Verify(sessionID, g1, p1, g2, p1)).Should(Equal(ErrVerifyFailure))
+		// Different curves
+		Expect(msg.Verify(sessionID, g1, p1, g2, pt.NewBase(elliptic.P384()))).Should(Equal(ErrDifferentCurves))
+	},
+		Entry("Curve: P256", elliptic.P256()),
+		Entry("Curve: S256", btcec.S256()),
+	)
+
+	It("different curves", func() {
+		msg, err := NewDLEQMessage(sessionID, x, pt.NewBase(btcec.S256()), pt.NewBase(elliptic.P256()))
+		Expect(err).Should(Equal(ErrDifferentCurves))
+		Expect(msg).Should(BeNil())
+	})
+
+	It("out of range", func() {
+		curve := btcec.S256()
+		msg, err := NewDLEQMessage(sessionID, curve.Params().N, pt.NewBase(curve), pt.NewBase(curve))
+		Expect(err).ShouldNot(BeNil())
+		Expect(msg).Should(BeNil())
+	})
+})
+
+var _ = Describe("Pedersen DLEQ", func() {
+	var (
+		sessionID = []byte("session-1")
+		a         = big.NewInt(9527)
+		b         = big.NewInt(5566)
+	)
+
+	DescribeTable("should be ok", func(curve elliptic.Curve) {
+		h := pt.ScalarBaseMult(curve, big.NewInt(123))
+		r := pt.ScalarBaseMult(curve, big.NewInt(456))
+		msg, err := NewPedersenDLEQMessage(sessionID, a, b, h, r)
+		Expect(err).Should(BeNil())
+		T, err := pt.ScalarBaseMult(curve, a).Add(h.ScalarMult(b))
+		Expect(err).Should(BeNil())
+		S := r.ScalarMult(a)
+		Expect(msg.Verify(sessionID, h, T, r, S)).Should(BeNil())
+
+		// Different session
+		Expect(msg.Verify([]byte("session-2"), h, T, r, S)).Should(Equal(ErrVerifyFailure))
+		// S is not built with a
+		Expect(msg.Verify(sessionID, h, T, r, r.ScalarMult(b))).Should(Equal(ErrVerifyFailure))
+		// T is not built with a
+		Expect(msg.Verify(sessionID, h, h.ScalarMult(b), r, S)).Should(Equal(ErrVerifyFailure))
+	},
+		Entry("Curve: P256", elliptic.P256()),
+		Entry("Curve: S256", btcec.S256()),
+	)
+
+	It("different curves", func() {
+		msg, err := NewPedersenDLEQMessage(sessionID, a, b, pt.NewBase(btcec.S256()), pt.NewBase(elliptic.P256()))
+		Expect(err).Should(Equal(ErrDifferentCurves))
+		Expect(msg).Should(BeNil())
+	})
+})
@@ -163,9 +163,443 @@ func (m *SchnorrProofMessage) GetT() []byte {
 	return nil
 }
 
+type RingPedersenParameterMessage struct {
+	Salt                 []byte   `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	N                    []byte   `protobuf:"bytes,2,opt,name=n,proto3" json:"n,omitempty"`
+	S                    []byte   `protobuf:"bytes,3,opt,name=s,proto3" json:"s,omitempty"`
+	T                    []byte   `protobuf:"bytes,4,opt,name=t,proto3" json:"t,omitempty"`
+	A                    [][]byte `protobuf:"bytes,5,rep,name=a,proto3" json:"a,omitempty"`
+	Z                    [][]byte `protobuf:"bytes,6,rep,name=z,proto3" json:"z,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RingPedersenParameterMessage) Reset()         { *m = RingPedersenParameterMessage{} }
+func (m *RingPedersenParameterMessage) String() string { return proto.CompactTextString(m) }
+func (*RingPedersenParameterMessage) ProtoMessage()    {}
+func (*RingPedersenParameterMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7463df78901cfd5c, []int{2}
+}
+
+func (m *RingPedersenParameterMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RingPedersenParameterMessage.Unmarshal(m, b)
+}
+func (m *RingPedersenParameterMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RingPedersenParameterMessage.Marshal(b, m, deterministic)
+}
+func (m *RingPedersenParameterMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RingPedersenParameterMessage.Merge(m, src)
+}
+func (m *RingPedersenParameterMessage) XXX_Size() int {
+	return xxx_messageInfo_RingPedersenParameterMessage.Size(m)
+}
+func (m *RingPedersenParameterMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_RingPedersenParameterMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RingPedersenParameterMessage proto.InternalMessageInfo
+
+func (m *RingPedersenParameterMessage) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+func (m *RingPedersenParameterMessage) GetN() []byte {
+	if m != nil {
+		return m.N
+	}
+	return nil
+}
+
+func (m *RingPedersenParameterMessage) GetS() []byte {
+	if m != nil {
+		return m.S
+	}
+	return nil
+}
+
+func (m *RingPedersenParameterMessage) GetT() []byte {
+	if m != nil {
+		return m.T
+	}
+	return nil
+}
+
+func (m *RingPedersenParameterMessage) GetA() [][]byte {
+	if m != nil {
+		return m.A
+	}
+	return nil
+}
+
+func (m *RingPedersenParameterMessage) GetZ() [][]byte {
+	if m != nil {
+		return m.Z
+	}
+	return nil
+}
+
+type PaillierBlumMessage struct {
+	Salt                 []byte   `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	N                    []byte   `protobuf:"bytes,2,opt,name=n,proto3" json:"n,omitempty"`
+	W                    []byte   `protobuf:"bytes,3,opt,name=w,proto3" json:"w,omitempty"`
+	X                    [][]byte `protobuf:"bytes,4,rep,name=x,proto3" json:"x,omitempty"`
+	A                    []bool   `protobuf:"varint,5,rep,packed,name=a,proto3" json:"a,omitempty"`
+	B                    []bool   `protobuf:"varint,6,rep,packed,name=b,proto3" json:"b,omitempty"`
+	Z                    [][]byte `protobuf:"bytes,7,rep,name=z,proto3" json:"z,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PaillierBlumMessage) Reset()         { *m = PaillierBlumMessage{} }
+func (m *PaillierBlumMessage) String() string { return proto.CompactTextString(m) }
+func (*PaillierBlumMessage) ProtoMessage()    {}
+func (*PaillierBlumMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7463df78901cfd5c, []int{3}
+}
+
+func (m *PaillierBlumMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaillierBlumMessage.Unmarshal(m, b)
+}
+func (m *PaillierBlumMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaillierBlumMessage.Marshal(b, m, deterministic)
+}
+func (m *PaillierBlumMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaillierBlumMessage.Merge(m, src)
+}
+func (m *PaillierBlumMessage) XXX_Size() int {
+	return xxx_messageInfo_PaillierBlumMessage.Size(m)
+}
+func (m *PaillierBlumMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaillierBlumMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PaillierBlumMessage proto.InternalMessageInfo
+
+func (m *PaillierBlumMessage) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+func (m *PaillierBlumMessage) GetN() []byte {
+	if m != nil {
+		return m.N
+	}
+	return nil
+}
+
+func (m *PaillierBlumMessage) GetW() []byte {
+	if m != nil {
+		return m.W
+	}
+	return nil
+}
+
+func (m *PaillierBlumMessage) GetX() [][]byte {
+	if m != nil {
+		return m.X
+	}
+	return nil
+}
+
+func (m *PaillierBlumMessage) GetA() []bool {
+	if m != nil {
+		return m.A
+	}
+	return nil
+}
+
+func (m *PaillierBlumMessage) GetB() []bool {
+	if m != nil {
+		return m.B
+	}
+	return nil
+}
+
+func (m *PaillierBlumMessage) GetZ() [][]byte {
+	if m != nil {
+		return m.Z
+	}
+	return nil
+}
+
+type NoSmallFactorMessage struct {
+	Salt                 []byte   `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	P                    []byte   `protobuf:"bytes,2,opt,name=p,proto3" json:"p,omitempty"`
+	Q                    []byte   `protobuf:"bytes,3,opt,name=q,proto3" json:"q,omitempty"`
+	A                    []byte   `protobuf:"bytes,4,opt,name=a,proto3" json:"a,omitempty"`
+	B                    []byte   `protobuf:"bytes,5,opt,name=b,proto3" json:"b,omitempty"`
+	T                    []byte   `protobuf:"bytes,6,opt,name=t,proto3" json:"t,omitempty"`
+	Sigma                []byte   `protobuf:"bytes,7,opt,name=sigma,proto3" json:"sigma,omitempty"`
+	Z1                   []byte   `protobuf:"bytes,8,opt,name=z1,proto3" json:"z1,omitempty"`
+	Z2                   []byte   `protobuf:"bytes,9,opt,name=z2,proto3" json:"z2,omitempty"`
+	W1                   []byte   `protobuf:"bytes,10,opt,name=w1,proto3" json:"w1,omitempty"`
+	W2                   []byte   `protobuf:"bytes,11,opt,name=w2,proto3" json:"w2,omitempty"`
+	V                    []byte   `protobuf:"bytes,12,opt,name=v,proto3" json:"v,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NoSmallFactorMessage) Reset()         { *m = NoSmallFactorMessage{} }
+func (m *NoSmallFactorMessage) String() string { return proto.CompactTextString(m) }
+func (*NoSmallFactorMessage) ProtoMessage()    {}
+func (*NoSmallFactorMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7463df78901cfd5c, []int{4}
+}
+
+func (m *NoSmallFactorMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoSmallFactorMessage.Unmarshal(m, b)
+}
+func (m *NoSmallFactorMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoSmallFactorMessage.Marshal(b, m, deterministic)
+}
+func (m *NoSmallFactorMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoSmallFactorMessage.Merge(m, src)
+}
+func (m *NoSmallFactorMessage) XXX_Size() int {
+	return xxx_messageInfo_NoSmallFactorMessage.Size(m)
+}
+func (m *NoSmallFactorMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoSmallFactorMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoSmallFactorMessage proto.InternalMessageInfo
+
+func (m *NoSmallFactorMessage) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+func (m *NoSmallFactorMessage) GetP() []byte {
+	if m != nil {
+		return m.P
+	}
+	return nil
+}
+
+func (m *NoSmallFactorMessage) GetQ() []byte {
+	if m != nil {
+		return m.Q
+	}
+	return nil
+}
+
+func (m *NoSmallFactorMessage) GetA() []byte {
+	if m != nil {
+		return m.A
+	}
+	return nil
+}
+
+func (m *NoSmallFactorMessage) GetB() []byte {
+	if m != nil {
+		return m.B
+	}
+	return nil
+}
+
+func (m *NoSmallFactorMessage) GetT() []byte {
+	if m != nil {
+		return m.T
+	}
+	return nil
+}
+
+func (m *NoSmallFactorMessage) GetSigma() []byte {
+	if m != nil {
+		return m.Sigma
+	}
+	return nil
+}
+
+func (m *NoSmallFactorMessage) GetZ1() []byte {
+	if m != nil {
+		return m.Z1
+	}
+	return nil
+}
+
+func (m *NoSmallFactorMessage) GetZ2() []byte {
+	if m != nil {
+		return m.Z2
+	}
+	return nil
+}
+
+func (m *NoSmallFactorMessage) GetW1() []byte {
+	if m != nil {
+		return m.W1
+	}
+	return nil
+}
+
+func (m *NoSmallFactorMessage) GetW2() []byte {
+	if m != nil {
+		return m.W2
+	}
+	return nil
+}
+
+func (m *NoSmallFactorMessage) GetV() []byte {
+	if m != nil {
+		return m.V
+	}
+	return nil
+}
+
+// DLEQMessage is the proof that two points have the same discrete logarithm to their bases
+type DLEQMessage struct {
+	Salt                 []byte                          `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	Alpha1               *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,2,opt,name=alpha1,proto3" json:"alpha1,omitempty"`
+	Alpha2               *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,3,opt,name=alpha2,proto3" json:"alpha2,omitempty"`
+	U                    []byte                          `protobuf:"bytes,4,opt,name=u,proto3" json:"u,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *DLEQMessage) Reset()         { *m = DLEQMessage{} }
+func (m *DLEQMessage) String() string { return proto.CompactTextString(m) }
+func (*DLEQMessage) ProtoMessage()    {}
+func (*DLEQMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7463df78901cfd5c, []int{5}
+}
+
+func (m *DLEQMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DLEQMessage.Unmarshal(m, b)
+}
+func (m *DLEQMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DLEQMessage.Marshal(b, m, deterministic)
+}
+func (m *DLEQMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DLEQMessage.Merge(m, src)
+}
+func (m *DLEQMessage) XXX_Size() int {
+	return xxx_messageInfo_DLEQMessage.Size(m)
+}
+func (m *DLEQMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_DLEQMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DLEQMessage proto.InternalMessageInfo
+
+func (m *DLEQMessage) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+func (m *DLEQMessage) GetAlpha1() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.Alpha1
+	}
+	return nil
+}
+
+func (m *DLEQMessage) GetAlpha2() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.Alpha2
+	}
+	return nil
+}
+
+func (m *DLEQMessage) GetU() []byte {
+	if m != nil {
+		return m.U
+	}
+	return nil
+}
+
+// PedersenDLEQMessage is the proof that a Pedersen commitment and a point share the same discrete logarithm
+type PedersenDLEQMessage struct {
+	Salt                 []byte                          `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	Alpha                *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,2,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta                 *ecpointgrouplaw.EcPointMessage `protobuf:"bytes,3,opt,name=beta,proto3" json:"beta,omitempty"`
+	U                    []byte                          `protobuf:"bytes,4,opt,name=u,proto3" json:"u,omitempty"`
+	T                    []byte                          `protobuf:"bytes,5,opt,name=t,proto3" json:"t,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *PedersenDLEQMessage) Reset()         { *m = PedersenDLEQMessage{} }
+func (m *PedersenDLEQMessage) String() string { return proto.CompactTextString(m) }
+func (*PedersenDLEQMessage) ProtoMessage()    {}
+func (*PedersenDLEQMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7463df78901cfd5c, []int{6}
+}
+
+func (m *PedersenDLEQMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PedersenDLEQMessage.Unmarshal(m, b)
+}
+func (m *PedersenDLEQMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PedersenDLEQMessage.Marshal(b, m, deterministic)
+}
+func (m *PedersenDLEQMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PedersenDLEQMessage.Merge(m, src)
+}
+func (m *PedersenDLEQMessage) XXX_Size() int {
+	return xxx_messageInfo_PedersenDLEQMessage.Size(m)
+}
+func (m *PedersenDLEQMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PedersenDLEQMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PedersenDLEQMessage proto.InternalMessageInfo
+
+func (m *PedersenDLEQMessage) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+func (m *PedersenDLEQMessage) GetAlpha() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.Alpha
+	}
+	return nil
+}
+
+func (m *PedersenDLEQMessage) GetBeta() *ecpointgrouplaw.EcPointMessage {
+	if m != nil {
+		return m.Beta
+	}
+	return nil
+}
+
+func (m *PedersenDLEQMessage) GetU() []byte {
+	if m != nil {
+		return m.U
+	}
+	return nil
+}
+
+func (m *PedersenDLEQMessage) GetT() []byte {
+	if m != nil {
+		return m.T
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*IntegerFactorizationProofMessage)(nil), "zkproof.IntegerFactorizationProofMessage")
 	proto.RegisterType((*SchnorrProofMessage)(nil), "zkproof.SchnorrProofMessage")
+	proto.RegisterType((*RingPedersenParameterMessage)(nil), "zkproof.RingPedersenParameterMessage")
+	proto.RegisterType((*PaillierBlumMessage)(nil), "zkproof.PaillierBlumMessage")
+	proto.RegisterType((*NoSmallFactorMessage)(nil), "zkproof.NoSmallFactorMessage")
+	proto.RegisterType((*DLEQMessage)(nil), "zkproof.DLEQMessage")
+	proto.RegisterType((*PedersenDLEQMessage)(nil), "zkproof.PedersenDLEQMessage")
 }
 
 func init() {
@@ -173,22 +607,37 @@ func init() {
 }
 
 var fileDescriptor_7463df78901cfd5c = []byte{
-	// 259 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0x41, 0x4b, 0x03, 0x31,
-	0x10, 0x85, 0x89, 0xb6, 0x8a, 0xb1, 0xa7, 0x78, 0x09, 0x22, 0x58, 0x7a, 0xf2, 0xe2, 0x06, 0x94,
-	0x9e, 0x3c, 0x2b, 0x88, 0x08, 0xa5, 0x42, 0xef, 0xd9, 0x10, 0xb3, 0xc1, 0xec, 0x4e, 0xc8, 0x4e,
-	0xb0, 0xbb, 0x07, 0xff, 0x90, 0x7f, 0x52, 0x92, 0xb4, 0x08, 0x5e, 0xea, 0x2d, 0xdf, 0x9b, 0xbc,
-	0x79, 0x2f, 0xa1, 0x4b, 0x63, 0xb1, 0x89, 0x75, 0xa5, 0xa0, 0x15, 0x46, 0xa3, 0x6c, 0x6d, 0x2f,
-	0xa4, 0xb3, 0x4a, 0x0b, 0x15, 0x06, 0x8f, 0x20, 0xc6, 0x0f, 0x1f, 0x00, 0xde, 0x45, 0xab, 0xfb,
-	0x5e, 0x1a, 0x5d, 0xf9, 0x00, 0x08, 0xec, 0x74, 0x27, 0x5f, 0x3e, 0x1c, 0xf2, 0x6b, 0xe5, 0xc1,
-	0x76, 0x68, 0x02, 0x44, 0xef, 0xe4, 0xa7, 0xc8, 0x54, 0xb6, 0x2c, 0xbe, 0xe8, 0xfc, 0xb9, 0x43,
-	0x6d, 0x74, 0x78, 0x92, 0x0a, 0x21, 0xd8, 0x51, 0xa2, 0x85, 0x6e, 0x95, 0x36, 0xbf, 0x96, 0x3c,
-	0xc6, 0xe8, 0xa4, 0x97, 0x0e, 0x39, 0x99, 0x93, 0x9b, 0xd9, 0x3a, 0x9f, 0xd9, 0x15, 0x3d, 0xf3,
-	0xb1, 0x76, 0x56, 0xbd, 0xe8, 0x81, 0x1f, 0xe5, 0xc1, 0xaf, 0xc0, 0x66, 0x94, 0x6c, 0xf9, 0x71,
-	0x56, 0xc9, 0x36, 0xd1, 0xc0, 0x27, 0x85, 0xf2, 0x6c, 0xe4, 0xd3, 0x42, 0xe3, 0xe2, 0x9b, 0xd0,
-	0x8b, 0x37, 0xd5, 0x74, 0x10, 0xc2, 0xc1, 0xcc, 0x5b, 0x4a, 0x36, 0x39, 0xeb, 0xfc, 0xee, 0xba,
-	0xfa, 0xf3, 0xa8, 0xea, 0x51, 0xad, 0x12, 0xef, 0xfc, 0x6b, 0xb2, 0x61, 0x4b, 0x3a, 0x95, 0xce,
-	0x37, 0x32, 0x17, 0xf9, 0x87, 0xa5, 0xdc, 0x4e, 0xfd, 0xe2, 0xbe, 0x6d, 0x4c, 0x84, 0xfb, 0xb6,
-	0x58, 0x9f, 0xe4, 0x4f, 0xbb, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0x19, 0xc2, 0x4e, 0x24, 0xb3,
-	0x01, 0x00, 0x00,
+	// 497 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x94, 0xcb, 0x8e, 0xd3, 0x30,
+	0x14, 0x86, 0xe5, 0xde, 0xe7, 0xb4, 0x62, 0x91, 0xce, 0xc2, 0x42, 0x23, 0x51, 0x65, 0xc5, 0x86,
+	0x46, 0xcd, 0x68, 0xc4, 0x82, 0x1d, 0x62, 0x90, 0x10, 0x17, 0x85, 0x8e, 0x34, 0x7b, 0x27, 0x98,
+	0xd4, 0xc2, 0x89, 0x33, 0x8e, 0x33, 0x69, 0x23, 0xc1, 0xbb, 0xb0, 0x66, 0xc5, 0xbb, 0xf0, 0x40,
+	0xc8, 0xb7, 0x22, 0x66, 0xd1, 0x76, 0x76, 0xfd, 0x8e, 0x8f, 0xfd, 0xff, 0x3e, 0xf9, 0x5d, 0xb8,
+	0xca, 0x99, 0xda, 0x34, 0xe9, 0x32, 0x13, 0x45, 0x94, 0x53, 0x45, 0x0a, 0x56, 0x47, 0x84, 0xb3,
+	0x8c, 0x46, 0x99, 0xdc, 0x55, 0x4a, 0x44, 0xdd, 0xb7, 0x4a, 0x0a, 0xf1, 0x35, 0x2a, 0x68, 0x5d,
+	0x93, 0x9c, 0x2e, 0x2b, 0x29, 0x94, 0x08, 0xc6, 0xae, 0xfc, 0xf4, 0xd5, 0xb1, 0xfd, 0x34, 0xab,
+	0x04, 0x2b, 0x55, 0x2e, 0x45, 0x53, 0x71, 0xd2, 0x46, 0x86, 0xec, 0x29, 0xe1, 0x0f, 0x58, 0xbc,
+	0x2b, 0x15, 0xcd, 0xa9, 0x7c, 0x4b, 0x32, 0x25, 0x24, 0xeb, 0x88, 0x62, 0xa2, 0x4c, 0xf4, 0xc9,
+	0x1f, 0xad, 0x5e, 0x10, 0xc0, 0xa0, 0x26, 0x5c, 0x61, 0xb4, 0x40, 0xcf, 0x67, 0x6b, 0xf3, 0x3b,
+	0xb8, 0x80, 0xb3, 0xaa, 0x49, 0x39, 0xcb, 0xde, 0xd3, 0x1d, 0xee, 0x99, 0x85, 0x7f, 0x85, 0x60,
+	0x06, 0x68, 0x8b, 0xfb, 0xa6, 0x8a, 0xb6, 0x9a, 0x76, 0x78, 0x60, 0xc9, 0xac, 0x75, 0x78, 0x68,
+	0xa9, 0x0b, 0x7f, 0x21, 0x98, 0xdf, 0x64, 0x9b, 0x52, 0x48, 0x79, 0x54, 0xf3, 0x05, 0xa0, 0x5b,
+	0xa3, 0x35, 0x8d, 0x9f, 0x2d, 0x1f, 0x5c, 0x6a, 0x79, 0x9d, 0x25, 0x9a, 0xdd, 0xfe, 0x35, 0xba,
+	0x0d, 0xae, 0x60, 0x48, 0x78, 0xb5, 0x21, 0xb8, 0x7f, 0xda, 0x16, 0xdb, 0xad, 0xfd, 0x35, 0xde,
+	0x6d, 0xa3, 0x49, 0x79, 0xb7, 0x2a, 0xdc, 0xc2, 0xc5, 0x9a, 0x95, 0x79, 0x42, 0xbf, 0x50, 0x59,
+	0xd3, 0x32, 0x21, 0x92, 0x14, 0x54, 0x51, 0x79, 0xc8, 0xf5, 0x0c, 0x50, 0xe9, 0x26, 0x84, 0x4a,
+	0x4d, 0xb5, 0x9f, 0x4c, 0x6d, 0x4f, 0x77, 0x5a, 0xa6, 0x93, 0xe0, 0xe1, 0xa2, 0xaf, 0x89, 0xd8,
+	0x39, 0x8d, 0x2c, 0x75, 0xe1, 0x77, 0x98, 0x27, 0x84, 0x71, 0xce, 0xa8, 0x7c, 0xcd, 0x9b, 0xe2,
+	0x51, 0x82, 0xad, 0x17, 0x6c, 0xed, 0x87, 0x19, 0x2c, 0xfa, 0xfb, 0x0f, 0x63, 0x05, 0x27, 0x4e,
+	0x30, 0x35, 0x82, 0x93, 0x35, 0x4a, 0xad, 0xfc, 0xd8, 0xcb, 0xff, 0x41, 0x70, 0xfe, 0x49, 0xdc,
+	0x14, 0x84, 0x73, 0x9b, 0x93, 0x23, 0x06, 0x2a, 0x6f, 0xa0, 0xd2, 0x74, 0xe7, 0x0d, 0xdc, 0x59,
+	0x49, 0x77, 0x63, 0x27, 0xe9, 0xa6, 0x9b, 0xda, 0x69, 0x8c, 0xfc, 0x34, 0xce, 0x61, 0x58, 0xb3,
+	0xbc, 0x20, 0x78, 0x6c, 0x2a, 0x16, 0x82, 0x27, 0xd0, 0xeb, 0x56, 0x78, 0x62, 0x4a, 0xbd, 0x6e,
+	0x65, 0x38, 0xc6, 0x67, 0x8e, 0x63, 0xcd, 0xed, 0x0a, 0x83, 0xe5, 0xd6, 0xac, 0xb7, 0x31, 0x9e,
+	0x3a, 0x8e, 0xb5, 0xc6, 0x3d, 0x9e, 0x59, 0x8d, 0xfb, 0xf0, 0x27, 0x82, 0xe9, 0x9b, 0x0f, 0xd7,
+	0x9f, 0x0f, 0xdd, 0xe6, 0x25, 0x8c, 0x4c, 0x30, 0x56, 0xa7, 0x46, 0xcf, 0xb5, 0xef, 0x37, 0xc6,
+	0xa7, 0x06, 0xd0, 0xb5, 0xff, 0x9f, 0xc0, 0xf0, 0x37, 0x82, 0xb9, 0x0f, 0xdc, 0x31, 0xaf, 0xfb,
+	0xc8, 0xf7, 0x1e, 0x15, 0xf9, 0x4b, 0x18, 0xa4, 0x54, 0x9d, 0xfc, 0x50, 0x4c, 0xf3, 0xa1, 0x77,
+	0x92, 0x8e, 0xcc, 0x9f, 0xcb, 0xe5, 0xdf, 0x01, 0x00, 0xdd, 0x5d, 0x92, 0x7c, 0xdb, 0x04, 0x00,
+	0x00,
 }
@@ -0,0 +1,213 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zkproof
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+const (
+	// noSmallFactorL is the bit length of the challenge, which is the size of the curve order
+	noSmallFactorL = 256
+	// noSmallFactorEpsilon is the slackness parameter of the ranges
+	noSmallFactorEpsilon = 2 * noSmallFactorL
+)
+
+var (
+	//ErrInvalidFactors is returned if the product of the factors is not the modulus
+	ErrInvalidFactors = errors.New("invalid factors")
+)
+
+/*
+	Notations:
+	- modulus: n0 = pq
+	- ring-Pedersen parameter of the verifier: (nHat, s, t)
+	- secret: p, q
+	- l = 256 and epsilon = 2l
+
+	The prover convinces the verifier that p and q are larger than 2^-(l+epsilon+1) * sqrt(n0), i.e. n0 has no small
+	factor. The protocol comes from the Π^fac in the paper "UC Non-Interactive, Proactive, Threshold ECDSA with
+	Identifiable Aborts". Since the integers are sent in bytes, the random values are chosen in [0, B) instead of
+	[-B, B], which changes the distributions of the responses negligibly.
+
+	Step 1: The prover randomly chooses alpha, beta in [0, 2^(l+epsilon) * sqrt(n0)), mu, nu in [0, 2^l * nHat),
+	        sigma in [0, 2^l * n0 * nHat), r in [0, 2^(l+epsilon) * n0 * nHat) and x, y in [0, 2^(l+epsilon) * nHat).
+	Step 2: The prover computes P = s^p t^mu, Q = s^q t^nu, A = s^alpha t^x, B = s^beta t^y and T = Q^alpha t^r mod nHat.
+	Step 3: The prover computes e := H(n0, nHat, s, t, P, Q, A, B, T, sigma, sid) in [0, 2^l).
+	Step 4: The prover computes z1 = alpha + e*p, z2 = beta + e*q, w1 = x + e*mu, w2 = y + e*nu and
+	        v = r + e*(sigma - nu*p). The resulting proof is (P, Q, A, B, T, sigma, z1, z2, w1, w2, v).
+	Step 5: The verifier checks z1, z2 in [0, 2^(l+epsilon) * sqrt(n0)), s^z1 t^w1 = A P^e, s^z2 t^w2 = B Q^e and
+	        Q^z1 t^v = T R^e mod nHat, where R = s^n0 t^sigma mod nHat.
+*/
+
+// NewNoSmallFactorMessage returns the proof that n0 = pq has no small factor under the ring-Pedersen parameter
+// (nHat, s, t) of the verifier. The proof is bound to the session id.
+func NewNoSmallFactorMessage(sessionID []byte, p *big.Int, q *big.Int, n0 *big.Int, nHat *big.Int, s *big.Int, t *big.Int) (*NoSmallFactorMessage, error) {
+	if new(big.Int).Mul(p, q).Cmp(n0) != 0 {
+		return nil, ErrInvalidFactors
+	}
+	zBound := noSmallFactorZBound(n0)
+	lNHat := new(big.Int).Lsh(nHat, noSmallFactorL)
+	lEpsilonNHat := new(big.Int).Lsh(nHat, noSmallFactorL+noSmallFactorEpsilon)
+	lN0NHat := new(big.Int).Mul(lNHat, n0)
+	lEpsilonN0NHat := new(big.Int).Mul(lEpsilonNHat, n0)
+
+	for i := 0; i < maxRetry; i++ {
+		randoms, err := randomInts(zBound, zBound, lNHat, lNHat, lN0NHat, lEpsilonN0NHat, lEpsilonNHat, lEpsilonNHat)
+		if err != nil {
+			return nil, err
+		}
+		alpha, beta, mu, nu, sigma, r, x, y := randoms[0], randoms[1], randoms[2], randoms[3], randoms[4], randoms[5], randoms[6], randoms[7]
+
+		P := pedersenCommit(nHat, s, t, p, mu)
+		Q := pedersenCommit(nHat, s, t, q, nu)
+		A := pedersenCommit(nHat, s, t, alpha, x)
+		B := pedersenCommit(nHat, s, t, beta, y)
+		T := pedersenCommit(nHat, Q, t, alpha, r)
+		e, salt, err := utils.HashProtosRejectSampling(big256bit, hashNoSmallFactorInputs(sessionID, n0, nHat, s, t, P, Q, A, B, T, sigma)...)
+		if err != nil {
+			return nil, err
+		}
+
+		z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, p))
+		z2 := new(big.Int).Add(beta, new(big.Int).Mul(e, q))
+		w1 := new(big.Int).Add(x, new(big.Int).Mul(e, mu))
+		w2 := new(big.Int).Add(y, new(big.Int).Mul(e, nu))
+		// v = r + e*(sigma - nu*p)
+		v := new(big.Int).Sub(sigma, new(big.Int).Mul(nu, p))
+		v = v.Mul(v, e)
+		v = v.Add(v, r)
+		// Try again if the responses are out of range, which happens with negligible probability
+		if z1.Cmp(zBound) >= 0 || z2.Cmp(zBound) >= 0 || v.Sign() < 0 {
+			continue
+		}
+		return &NoSmallFactorMessage{
+			Salt:  salt,
+			P:     P.Bytes(),
+			Q:     Q.Bytes(),
+			A:     A.Bytes(),
+			B:     B.Bytes(),
+			T:     T.Bytes(),
+			Sigma: sigma.Bytes(),
+			Z1:    z1.Bytes(),
+			Z2:    z2.Bytes(),
+			W1:    w1.Bytes(),
+			W2:    w2.Bytes(),
+			V:     v.Bytes(),
+		}, nil
+	}
+	return nil, ErrExceedMaxRetry
+}
+
+// Verify verifies the proof that n0 has no small factor under the ring-Pedersen parameter (nHat, s, t) of ours.
+func (msg *NoSmallFactorMessage) Verify(sessionID []byte, n0 *big.Int, nHat *big.Int, s *big.Int, t *big.Int) error {
+	if n0.BitLen() < safePubKeySize {
+		return ErrSmallPublicKeySize
+	}
+	P := new(big.Int).SetBytes(msg.GetP())
+	Q := new(big.Int).SetBytes(msg.GetQ())
+	A := new(big.Int).SetBytes(msg.GetA())
+	B := new(big.Int).SetBytes(msg.GetB())
+	T := new(big.Int).SetBytes(msg.GetT())
+	for _, v := range []*big.Int{P, Q, A, B, T} {
+		err := utils.InRange(v, big1, nHat)
+		if err != nil {
+			return err
+		}
+		if !utils.IsRelativePrime(v, nHat) {
+			return ErrNotCoprime
+		}
+	}
+	zBound := noSmallFactorZBound(n0)
+	z1 := new(big.Int).SetBytes(msg.GetZ1())
+	err := utils.InRange(z1, big0, zBound)
+	if err != nil {
+		return err
+	}
+	z2 := new(big.Int).SetBytes(msg.GetZ2())
+	err = utils.InRange(z2, big0, zBound)
+	if err != nil {
+		return err
+	}
+	sigma := new(big.Int).SetBytes(msg.GetSigma())
+	w1 := new(big.Int).SetBytes(msg.GetW1())
+	w2 := new(big.Int).SetBytes(msg.GetW2())
+	v := new(big.Int).SetBytes(msg.GetV())
+
+	e, err := utils.HashProtosToInt(msg.GetSalt(), hashNoSmallFactorInputs(sessionID, n0, nHat, s, t, P, Q, A, B, T, sigma)...)
+	if err != nil {
+		return err
+	}
+	// Check s^z1 t^w1 = A P^e mod nHat
+	if pedersenCommit(nHat, s, t, z1, w1).Cmp(mulExp(nHat, A, P, e)) != 0 {
+		return ErrVerifyFailure
+	}
+	// Check s^z2 t^w2 = B Q^e mod nHat
+	if pedersenCommit(nHat, s, t, z2, w2).Cmp(mulExp(nHat, B, Q, e)) != 0 {
+		return ErrVerifyFailure
+	}
+	// Check Q^z1 t^v = T R^e mod nHat, where R = s^n0 t^sigma mod nHat
+	R := pedersenCommit(nHat, s, t, n0, sigma)
+	if pedersenCommit(nHat, Q, t, z1, v).Cmp(mulExp(nHat, T, R, e)) != 0 {
+		return ErrVerifyFailure
+	}
+	return nil
+}
+
+// noSmallFactorZBound returns 2^(l+epsilon) * sqrt(n0).
+func noSmallFactorZBound(n0 *big.Int) *big.Int {
+	sqrtN0 := new(big.Int).Sqrt(n0)
+	return sqrtN0.Lsh(sqrtN0, noSmallFactorL+noSmallFactorEpsilon)
+}
+
+// randomInts returns random integers in [0, bound) for each bound.
+func randomInts(bounds ...*big.Int) ([]*big.Int, error) {
+	results := make([]*big.Int, len(bounds))
+	for i, bound := range bounds {
+		var err error
+		results[i], err = utils.RandomInt(bound)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// pedersenCommit computes s^x t^y mod n.
+func pedersenCommit(n *big.Int, s *big.Int, t *big.Int, x *big.Int, y *big.Int) *big.Int {
+	result := new(big.Int).Exp(s, x, n)
+	result = result.Mul(result, new(big.Int).Exp(t, y, n))
+	return result.Mod(result, n)
+}
+
+// mulExp computes a * b^e mod n.
+func mulExp(n *big.Int, a *big.Int, b *big.Int, e *big.Int) *big.Int {
+	result := new(big.Int).Exp(b, e, n)
+	result = result.Mul(result, a)
+	return result.Mod(result, n)
+}
+
+func hashNoSmallFactorInputs(sessionID []byte, values ...*big.Int) []proto.Message {
+	msgs := make([]proto.Message, 0, len(values)+1)
+	for _, v := range values {
+		msgs = append(msgs, &any.Any{Value: v.Bytes()})
+	}
+	return append(msgs, &any.Any{Value: sessionID})
+}
@@ -0,0 +1,97 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zkproof
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("No small factor", func() {
+	var (
+		sessionID = []byte("session id")
+
+		p, q, n0, nHat, s, t *big.Int
+		msg                  *NoSmallFactorMessage
+	)
+
+	BeforeEach(func() {
+		if n0 == nil {
+			var err error
+			p, err = rand.Prime(rand.Reader, 1024)
+			Expect(err).To(BeNil())
+			q, err = rand.Prime(rand.Reader, 1024)
+			Expect(err).To(BeNil())
+			n0 = new(big.Int).Mul(p, q)
+
+			pHat, err := rand.Prime(rand.Reader, 1024)
+			Expect(err).To(BeNil())
+			qHat, err := rand.Prime(rand.Reader, 1024)
+			Expect(err).To(BeNil())
+			nHat = new(big.Int).Mul(pHat, qHat)
+			tau, err := utils.RandomCoprimeInt(nHat)
+			Expect(err).To(BeNil())
+			t = new(big.Int).Exp(tau, big2, nHat)
+			lambda, err := utils.RandomInt(nHat)
+			Expect(err).To(BeNil())
+			s = new(big.Int).Exp(t, lambda, nHat)
+		}
+		var err error
+		msg, err = NewNoSmallFactorMessage(sessionID, p, q, n0, nHat, s, t)
+		Expect(err).To(BeNil())
+	})
+
+	It("should be ok", func() {
+		Expect(msg.Verify(sessionID, n0, nHat, s, t)).To(BeNil())
+	})
+
+	It("different session id", func() {
+		Expect(msg.Verify([]byte("another session id"), n0, nHat, s, t)).To(Equal(ErrVerifyFailure))
+	})
+
+	It("different modulus", func() {
+		Expect(msg.Verify(sessionID, new(big.Int).Add(n0, big2), nHat, s, t)).To(Equal(ErrVerifyFailure))
+	})
+
+	It("altered sigma", func() {
+		sigma := new(big.Int).SetBytes(msg.Sigma)
+		msg.Sigma = sigma.Add(sigma, big1).Bytes()
+		Expect(msg.Verify(sessionID, n0, nHat, s, t)).To(Equal(ErrVerifyFailure))
+	})
+
+	It("z1 is out of range", func() {
+		msg.Z1 = noSmallFactorZBound(n0).Bytes()
+		Expect(msg.Verify(sessionID, n0, nHat, s, t)).To(Equal(utils.ErrNotInRange))
+	})
+
+	It("P is out of range", func() {
+		msg.P = nHat.Bytes()
+		Expect(msg.Verify(sessionID, n0, nHat, s, t)).To(Equal(utils.ErrNotInRange))
+	})
+
+	It("inconsistent factors", func() {
+		got, err := NewNoSmallFactorMessage(sessionID, p, p, n0, nHat, s, t)
+		Expect(err).To(Equal(ErrInvalidFactors))
+		Expect(got).To(BeNil())
+	})
+
+	It("small n0", func() {
+		Expect(msg.Verify(sessionID, new(big.Int).Rsh(n0, 8), nHat, s, t)).To(Equal(ErrSmallPublicKeySize))
+	})
+})
@@ -0,0 +1,256 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zkproof
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/golang/protobuf/ptypes/any"
+	"golang.org/x/crypto/blake2b"
+)
+
+// paillierBlumRepetitions is the number of the repetitions. The soundness error is 2^-80.
+const paillierBlumRepetitions = 80
+
+var (
+	//ErrNotPaillierBlumModulus is returned if the modulus is not a Paillier-Blum modulus
+	ErrNotPaillierBlumModulus = errors.New("not a Paillier-Blum modulus")
+
+	big3 = big.NewInt(3)
+	big4 = big.NewInt(4)
+)
+
+/*
+	Notations:
+	- modulus: n = pq, where p = q = 3 mod 4 and gcd(n, phi(n)) = 1
+	- secret: p, q
+
+	The prover convinces the verifier that n is a Paillier-Blum modulus. The protocol comes from the Π^mod in the
+	paper "UC Non-Interactive, Proactive, Threshold ECDSA with Identifiable Aborts" and is repeated m times.
+
+	Step 1: The prover randomly chooses w in Z_n^\ast with the Jacobi symbol (w|n) = -1.
+	Step 2: The prover derives y_1, ..., y_m in Z_n from H(n, w, sid, pid), where sid is the session id and pid is the
+	        id of the prover.
+	Step 3: For each i, the prover finds a_i, b_i in {0, 1} such that y'_i = (-1)^a_i * w^b_i * y_i mod n is a quadratic
+	        residue, and computes a fourth root x_i of y'_i and z_i = y_i^(n^-1 mod phi(n)) mod n.
+	        The resulting proof is (w, x_i, a_i, b_i, z_i).
+	Step 4: The verifier checks n is an odd composite, (w|n) = -1, z_i^n = y_i mod n and
+	        x_i^4 = (-1)^a_i * w^b_i * y_i mod n for all i.
+*/
+
+// NewPaillierBlumMessage returns the proof that n = pq is a Paillier-Blum modulus bound to the session id and the
+// prover id.
+func NewPaillierBlumMessage(sessionID []byte, proverID string, p *big.Int, q *big.Int, n *big.Int) (*PaillierBlumMessage, error) {
+	if n.BitLen() < safePubKeySize {
+		return nil, ErrSmallPublicKeySize
+	}
+	if new(big.Int).Mul(p, q).Cmp(n) != 0 || !isBlumPrime(p) || !isBlumPrime(q) {
+		return nil, ErrNotPaillierBlumModulus
+	}
+	// n^-1 mod p-1 and n^-1 mod q-1 exist if gcd(n, phi(n)) = 1
+	pMinus1 := new(big.Int).Sub(p, big1)
+	qMinus1 := new(big.Int).Sub(q, big1)
+	nInvP := new(big.Int).ModInverse(n, pMinus1)
+	nInvQ := new(big.Int).ModInverse(n, qMinus1)
+	if nInvP == nil || nInvQ == nil {
+		return nil, ErrNotPaillierBlumModulus
+	}
+	// The square root of a quadratic residue v mod p is v^((p+1)/4), which is also a quadratic residue if p = 3 mod 4.
+	fourthRootP := new(big.Int).Rsh(new(big.Int).Add(p, big1), 2)
+	fourthRootP = fourthRootP.Mul(fourthRootP, fourthRootP)
+	fourthRootQ := new(big.Int).Rsh(new(big.Int).Add(q, big1), 2)
+	fourthRootQ = fourthRootQ.Mul(fourthRootQ, fourthRootQ)
+	pInvQ := new(big.Int).ModInverse(p, q)
+
+	w, err := randomJacobiMinusOne(n)
+	if err != nil {
+		return nil, err
+	}
+	salt, err := utils.GenRandomBytes(utils.SaltSize)
+	if err != nil {
+		return nil, err
+	}
+	ys, err := paillierBlumChallenges(salt, sessionID, proverID, n, w)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &PaillierBlumMessage{
+		Salt: salt,
+		N:    n.Bytes(),
+		W:    w.Bytes(),
+		X:    make([][]byte, paillierBlumRepetitions),
+		A:    make([]bool, paillierBlumRepetitions),
+		B:    make([]bool, paillierBlumRepetitions),
+		Z:    make([][]byte, paillierBlumRepetitions),
+	}
+	for i, y := range ys {
+		if !utils.IsRelativePrime(y, n) {
+			return nil, ErrNotCoprime
+		}
+		a, b, yPrime := findQuadraticResidue(p, q, n, w, y)
+		x := crt(new(big.Int).Exp(yPrime, fourthRootP, p), new(big.Int).Exp(yPrime, fourthRootQ, q), p, q, pInvQ)
+		z := crt(new(big.Int).Exp(y, nInvP, p), new(big.Int).Exp(y, nInvQ, q), p, q, pInvQ)
+		msg.X[i] = x.Bytes()
+		msg.A[i] = a
+		msg.B[i] = b
+		msg.Z[i] = z.Bytes()
+	}
+	return msg, nil
+}
+
+// Verify verifies the proof of the Paillier-Blum modulus which is made by the prover in the session.
+func (msg *PaillierBlumMessage) Verify(sessionID []byte, proverID string) error {
+	n := new(big.Int).SetBytes(msg.GetN())
+	if n.BitLen() < safePubKeySize {
+		return ErrSmallPublicKeySize
+	}
+	// ProbablyPrime is always true for primes, so a prime n is never accepted.
+	if n.Bit(0) == 0 || n.ProbablyPrime(0) {
+		return ErrNotPaillierBlumModulus
+	}
+	w := new(big.Int).SetBytes(msg.GetW())
+	err := utils.InRange(w, big1, n)
+	if err != nil {
+		return err
+	}
+	if big.Jacobi(w, n) != -1 {
+		return ErrVerifyFailure
+	}
+	if len(msg.GetX()) != paillierBlumRepetitions || len(msg.GetA()) != paillierBlumRepetitions ||
+		len(msg.GetB()) != paillierBlumRepetitions || len(msg.GetZ()) != paillierBlumRepetitions {
+		return ErrVerifyFailure
+	}
+
+	ys, err := paillierBlumChallenges(msg.GetSalt(), sessionID, proverID, n, w)
+	if err != nil {
+		return err
+	}
+	for i, y := range ys {
+		x := new(big.Int).SetBytes(msg.X[i])
+		err = utils.InRange(x, big1, n)
+		if err != nil {
+			return err
+		}
+		z := new(big.Int).SetBytes(msg.Z[i])
+		err = utils.InRange(z, big1, n)
+		if err != nil {
+			return err
+		}
+
+		// Check z_i^n = y_i mod n
+		if new(big.Int).Exp(z, n, n).Cmp(y) != 0 {
+			return ErrVerifyFailure
+		}
+		// Check x_i^4 = (-1)^a_i * w^b_i * y_i mod n
+		if new(big.Int).Exp(x, big4, n).Cmp(adjustChallenge(n, w, y, msg.A[i], msg.B[i])) != 0 {
+			return ErrVerifyFailure
+		}
+	}
+	return nil
+}
+
+// paillierBlumChallenges derives the challenges y_1, ..., y_m in Z_n from H(n, w, sid, pid). Each y_i consists of the
+// hashes of (H(n, w, sid, pid), i, j) for j = 0, 1, ..., which are longer than n by 256 bits to make y_i almost uniform.
+func paillierBlumChallenges(salt []byte, sessionID []byte, proverID string, n *big.Int, w *big.Int) ([]*big.Int, error) {
+	seed, err := utils.HashProtos(salt, &any.Any{
+		Value: n.Bytes(),
+	}, &any.Any{
+		Value: w.Bytes(),
+	}, &any.Any{
+		Value: sessionID,
+	}, &any.Any{
+		Value: []byte(proverID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	blocks := (n.BitLen()+255)/256 + 1
+	ys := make([]*big.Int, paillierBlumRepetitions)
+	input := make([]byte, len(seed)+8)
+	copy(input, seed)
+	for i := range ys {
+		bs := make([]byte, 0, blocks*blake2b.Size256)
+		for j := 0; j < blocks; j++ {
+			binary.BigEndian.PutUint32(input[len(seed):], uint32(i))
+			binary.BigEndian.PutUint32(input[len(seed)+4:], uint32(j))
+			h := blake2b.Sum256(input)
+			bs = append(bs, h[:]...)
+		}
+		ys[i] = new(big.Int).SetBytes(bs)
+		ys[i] = ys[i].Mod(ys[i], n)
+	}
+	return ys, nil
+}
+
+// findQuadraticResidue returns a, b and y' = (-1)^a * w^b * y mod n such that y' is a quadratic residue. Exactly
+// one of them is if n is a Paillier-Blum modulus and (w|n) = -1.
+func findQuadraticResidue(p *big.Int, q *big.Int, n *big.Int, w *big.Int, y *big.Int) (bool, bool, *big.Int) {
+	for _, a := range []bool{false, true} {
+		for _, b := range []bool{false, true} {
+			yPrime := adjustChallenge(n, w, y, a, b)
+			if big.Jacobi(yPrime, p) == 1 && big.Jacobi(yPrime, q) == 1 {
+				return a, b, yPrime
+			}
+		}
+	}
+	// It never happens because the inputs are checked before.
+	return false, false, y
+}
+
+// adjustChallenge computes (-1)^a * w^b * y mod n.
+func adjustChallenge(n *big.Int, w *big.Int, y *big.Int, a bool, b bool) *big.Int {
+	result := new(big.Int).Set(y)
+	if b {
+		result = result.Mul(result, w)
+		result = result.Mod(result, n)
+	}
+	if a {
+		result = result.Sub(n, result)
+		result = result.Mod(result, n)
+	}
+	return result
+}
+
+// randomJacobiMinusOne returns a random w in Z_n^\ast with (w|n) = -1.
+func randomJacobiMinusOne(n *big.Int) (*big.Int, error) {
+	for i := 0; i < maxRetry; i++ {
+		w, err := utils.RandomCoprimeInt(n)
+		if err != nil {
+			return nil, err
+		}
+		if big.Jacobi(w, n) == -1 {
+			return w, nil
+		}
+	}
+	return nil, ErrExceedMaxRetry
+}
+
+// crt returns x in [0, pq) such that x = xp mod p and x = xq mod q.
+func crt(xp *big.Int, xq *big.Int, p *big.Int, q *big.Int, pInvQ *big.Int) *big.Int {
+	h := new(big.Int).Sub(xq, xp)
+	h = h.Mul(h, pInvQ)
+	h = h.Mod(h, q)
+	h = h.Mul(h, p)
+	return h.Add(h, xp)
+}
+
+// isBlumPrime returns if p = 3 mod 4. The primality of p is not checked.
+func isBlumPrime(p *big.Int) bool {
+	return new(big.Int).Mod(p, big4).Cmp(big3) == 0
+}
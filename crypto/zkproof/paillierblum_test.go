@@ -0,0 +1,116 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zkproof
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Paillier-Blum modulus", func() {
+	var (
+		sessionID = []byte("session id")
+		proverID  = "prover id"
+
+		p, q, n *big.Int
+		msg     *PaillierBlumMessage
+	)
+
+	BeforeEach(func() {
+		if n == nil {
+			p = randomBlumPrime(1024)
+			q = randomBlumPrime(1024)
+			n = new(big.Int).Mul(p, q)
+		}
+		var err error
+		msg, err = NewPaillierBlumMessage(sessionID, proverID, p, q, n)
+		Expect(err).To(BeNil())
+	})
+
+	It("should be ok", func() {
+		Expect(msg.Verify(sessionID, proverID)).To(BeNil())
+	})
+
+	It("different session id", func() {
+		Expect(msg.Verify([]byte("another session id"), proverID)).To(Equal(ErrVerifyFailure))
+	})
+
+	It("different prover id", func() {
+		Expect(msg.Verify(sessionID, "another prover id")).To(Equal(ErrVerifyFailure))
+	})
+
+	It("not Blum primes", func() {
+		var p1 *big.Int
+		for {
+			var err error
+			p1, err = rand.Prime(rand.Reader, 1024)
+			Expect(err).To(BeNil())
+			if !isBlumPrime(p1) {
+				break
+			}
+		}
+		got, err := NewPaillierBlumMessage(sessionID, proverID, p1, q, new(big.Int).Mul(p1, q))
+		Expect(err).To(Equal(ErrNotPaillierBlumModulus))
+		Expect(got).To(BeNil())
+	})
+
+	It("prime n", func() {
+		prime, err := rand.Prime(rand.Reader, 2048)
+		Expect(err).To(BeNil())
+		msg.N = prime.Bytes()
+		Expect(msg.Verify(sessionID, proverID)).To(Equal(ErrNotPaillierBlumModulus))
+	})
+
+	It("even n", func() {
+		msg.N = new(big.Int).Lsh(n, 1).Bytes()
+		Expect(msg.Verify(sessionID, proverID)).To(Equal(ErrNotPaillierBlumModulus))
+	})
+
+	It("w is a quadratic residue", func() {
+		w := new(big.Int).SetBytes(msg.W)
+		msg.W = w.Exp(w, big2, n).Bytes()
+		Expect(msg.Verify(sessionID, proverID)).To(Equal(ErrVerifyFailure))
+	})
+
+	It("altered fourth root", func() {
+		x := new(big.Int).SetBytes(msg.X[0])
+		msg.X[0] = x.Sub(n, x).Bytes()
+		msg.A[0] = !msg.A[0]
+		Expect(msg.Verify(sessionID, proverID)).To(Equal(ErrVerifyFailure))
+	})
+
+	It("missing repetitions", func() {
+		msg.Z = msg.Z[1:]
+		Expect(msg.Verify(sessionID, proverID)).To(Equal(ErrVerifyFailure))
+	})
+
+	It("small n", func() {
+		msg.N = msg.N[1:]
+		Expect(msg.Verify(sessionID, proverID)).To(Equal(ErrSmallPublicKeySize))
+	})
+})
+
+func randomBlumPrime(bits int) *big.Int {
+	for {
+		p, err := rand.Prime(rand.Reader, bits)
+		Expect(err).To(BeNil())
+		if isBlumPrime(p) {
+			return p
+		}
+	}
+}
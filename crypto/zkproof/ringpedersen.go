@@ -0,0 +1,146 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zkproof
+
+import (
+	"math/big"
+
+	"github.com/getamis/alice/crypto/utils"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+// ringPedersenRepetitions is the number of the repetitions with binary challenges. The soundness error is 2^-80.
+const ringPedersenRepetitions = 80
+
+/*
+	Notations:
+	- modulus: n
+	- ring-Pedersen parameter: (n, s, t), where s = t^lambda mod n
+	- secret: lambda
+
+	The prover convinces the verifier that s is in the subgroup generated by t, so the commitment s^x t^y mod n
+	does not leak x to the prover who does not know lambda. The protocol comes from the Π^prm in the paper
+	"UC Non-Interactive, Proactive, Threshold ECDSA with Identifiable Aborts" and is repeated m times.
+
+	Step 1: The prover randomly chooses a_i in [0, order) and computes A_i = t^a_i mod n for i = 1, ..., m.
+	Step 2: The prover computes e := H(n, s, t, A_1, ..., A_m, sid, pid), where sid is the session id and pid
+	        is the id of the prover, and takes the first m bits e_1, ..., e_m.
+	Step 3: The prover computes z_i = a_i + e_i*lambda mod order. The resulting proof is (A_i, z_i).
+	Step 4: The verifier checks t^z_i = A_i * s^e_i mod n for all i.
+
+	Remark: order could be any multiple of the order of t, e.g. the Euler phi or Carmichael function of n.
+*/
+
+// NewRingPedersenParameterMessage returns the proof of s = t^lambda mod n bound to the session id and the prover id.
+func NewRingPedersenParameterMessage(sessionID []byte, proverID string, order *big.Int, n *big.Int, s *big.Int, t *big.Int, lambda *big.Int) (*RingPedersenParameterMessage, error) {
+	if n.BitLen() < safePubKeySize {
+		return nil, ErrSmallPublicKeySize
+	}
+	a := make([]*big.Int, ringPedersenRepetitions)
+	A := make([][]byte, ringPedersenRepetitions)
+	for i := range a {
+		var err error
+		a[i], err = utils.RandomInt(order)
+		if err != nil {
+			return nil, err
+		}
+		A[i] = new(big.Int).Exp(t, a[i], n).Bytes()
+	}
+
+	e, salt, err := utils.HashProtosRejectSampling(big256bit, hashRingPedersenInputs(sessionID, proverID, n, s, t, A)...)
+	if err != nil {
+		return nil, err
+	}
+	z := make([][]byte, ringPedersenRepetitions)
+	for i := range z {
+		// z_i = a_i + e_i*lambda mod order
+		zi := a[i]
+		if e.Bit(i) == 1 {
+			zi = new(big.Int).Add(zi, lambda)
+		}
+		z[i] = zi.Mod(zi, order).Bytes()
+	}
+	return &RingPedersenParameterMessage{
+		Salt: salt,
+		N:    n.Bytes(),
+		S:    s.Bytes(),
+		T:    t.Bytes(),
+		A:    A,
+		Z:    z,
+	}, nil
+}
+
+// Verify verifies the proof which is made by the prover in the session.
+func (msg *RingPedersenParameterMessage) Verify(sessionID []byte, proverID string) error {
+	n := new(big.Int).SetBytes(msg.GetN())
+	if n.BitLen() < safePubKeySize {
+		return ErrSmallPublicKeySize
+	}
+	s := new(big.Int).SetBytes(msg.GetS())
+	t := new(big.Int).SetBytes(msg.GetT())
+	for _, v := range []*big.Int{s, t} {
+		err := utils.InRange(v, big2, n)
+		if err != nil {
+			return err
+		}
+		if !utils.IsRelativePrime(v, n) {
+			return ErrNotCoprime
+		}
+	}
+	if len(msg.GetA()) != ringPedersenRepetitions || len(msg.GetZ()) != ringPedersenRepetitions {
+		return ErrVerifyFailure
+	}
+
+	e, err := utils.HashProtosToInt(msg.GetSalt(), hashRingPedersenInputs(sessionID, proverID, n, s, t, msg.GetA())...)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < ringPedersenRepetitions; i++ {
+		A := new(big.Int).SetBytes(msg.A[i])
+		err = utils.InRange(A, big1, n)
+		if err != nil {
+			return err
+		}
+		z := new(big.Int).SetBytes(msg.Z[i])
+		err = utils.InRange(z, big0, n)
+		if err != nil {
+			return err
+		}
+
+		// Check t^z_i = A_i * s^e_i mod n
+		expected := A
+		if e.Bit(i) == 1 {
+			expected = new(big.Int).Mul(expected, s)
+			expected = expected.Mod(expected, n)
+		}
+		if new(big.Int).Exp(t, z, n).Cmp(expected) != 0 {
+			return ErrVerifyFailure
+		}
+	}
+	return nil
+}
+
+func hashRingPedersenInputs(sessionID []byte, proverID string, n *big.Int, s *big.Int, t *big.Int, A [][]byte) []proto.Message {
+	msgs := []proto.Message{
+		&any.Any{Value: n.Bytes()},
+		&any.Any{Value: s.Bytes()},
+		&any.Any{Value: t.Bytes()},
+	}
+	for _, a := range A {
+		msgs = append(msgs, &any.Any{Value: a})
+	}
+	return append(msgs, &any.Any{Value: sessionID}, &any.Any{Value: []byte(proverID)})
+}
@@ -0,0 +1,87 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zkproof
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ring-Pedersen parameter", func() {
+	var (
+		sessionID = []byte("session id")
+		proverID  = "prover id"
+
+		p   *big.Int
+		msg *RingPedersenParameterMessage
+	)
+
+	BeforeEach(func() {
+		var err error
+		p, err = rand.Prime(rand.Reader, 1024)
+		Expect(err).To(BeNil())
+		q, err := rand.Prime(rand.Reader, 1024)
+		Expect(err).To(BeNil())
+		n := new(big.Int).Mul(p, q)
+		eulerValue, err := utils.EulerFunction([]*big.Int{p, q})
+		Expect(err).To(BeNil())
+		tau, err := utils.RandomCoprimeInt(n)
+		Expect(err).To(BeNil())
+		t := new(big.Int).Exp(tau, big2, n)
+		lambda, err := utils.RandomInt(eulerValue)
+		Expect(err).To(BeNil())
+		s := new(big.Int).Exp(t, lambda, n)
+		msg, err = NewRingPedersenParameterMessage(sessionID, proverID, eulerValue, n, s, t, lambda)
+		Expect(err).To(BeNil())
+	})
+
+	It("should be ok", func() {
+		Expect(msg.Verify(sessionID, proverID)).To(BeNil())
+	})
+
+	It("different session id", func() {
+		Expect(msg.Verify([]byte("another session id"), proverID)).To(Equal(ErrVerifyFailure))
+	})
+
+	It("different prover id", func() {
+		Expect(msg.Verify(sessionID, "another prover id")).To(Equal(ErrVerifyFailure))
+	})
+
+	It("s is not generated by t", func() {
+		n := new(big.Int).SetBytes(msg.N)
+		s := new(big.Int).SetBytes(msg.S)
+		msg.S = s.Add(s, big1).Mod(s, n).Bytes()
+		Expect(msg.Verify(sessionID, proverID)).To(Equal(ErrVerifyFailure))
+	})
+
+	It("missing repetitions", func() {
+		msg.A = msg.A[1:]
+		Expect(msg.Verify(sessionID, proverID)).To(Equal(ErrVerifyFailure))
+	})
+
+	It("t is not coprime to n", func() {
+		msg.T = p.Bytes()
+		Expect(msg.Verify(sessionID, proverID)).To(Equal(ErrNotCoprime))
+	})
+
+	It("small n", func() {
+		msg.N = msg.N[1:]
+		Expect(msg.Verify(sessionID, proverID)).To(Equal(ErrSmallPublicKeySize))
+	})
+})
@@ -21,6 +21,7 @@ import (
 
 	pt "github.com/getamis/alice/crypto/ecpointgrouplaw"
 	"github.com/getamis/alice/crypto/utils"
+	"github.com/golang/protobuf/ptypes/any"
 )
 
 var (
@@ -41,18 +42,29 @@ var (
 
 	Step 1:
 	- The prover randomly chooses two numbers m, n in [1, p-1] and sends alpha := m*G + n*R to the verifier.
-	- The prover computes c:=H(G,V,R,alpha).
+	- The prover computes c:=H(G,V,R,alpha,sid), where sid is the session id (empty if the proof is not bound to a session).
 	- The prover computes  u := m + c*a1 mod p and t := n + c*a2 mod p. The resulting proof is the (u,t, alpha)
 	Step 2: The verifier verifies t*R + u*G = alpha +c*V, and u, t in [0, p-1]. If the result true accept, otherwise reject.
 	Remark: If R is the identity element(i.e. R = (nil,nil)) and t = 0, then the above protocol reduces to the standard Schnorr protocol.
 */
 
 func NewBaseSchorrMessage(curve elliptic.Curve, a1 *big.Int) (*SchnorrProofMessage, error) {
+	return NewBaseSchorrMessageWithSession(nil, curve, a1)
+}
+
+// NewBaseSchorrMessageWithSession returns the Schnorr proof of a1 with the base point, which is bound to the session id.
+func NewBaseSchorrMessageWithSession(sessionID []byte, curve elliptic.Curve, a1 *big.Int) (*SchnorrProofMessage, error) {
 	base := pt.NewBase(curve)
-	return NewSchorrMessage(a1, big0, base)
+	return NewSchorrMessageWithSession(sessionID, a1, big0, base)
 }
 
 func NewSchorrMessage(a1 *big.Int, a2 *big.Int, R *pt.ECPoint) (*SchnorrProofMessage, error) {
+	return NewSchorrMessageWithSession(nil, a1, a2, R)
+}
+
+// NewSchorrMessageWithSession returns the Schnorr proof of a1 and a2, which is bound to the session id.
+// The proof can only be verified with the same session id.
+func NewSchorrMessageWithSession(sessionID []byte, a1 *big.Int, a2 *big.Int, R *pt.ECPoint) (*SchnorrProofMessage, error) {
 	msgR, err := R.ToEcPointMessage()
 	if err != nil {
 		return nil, err
@@ -108,7 +120,9 @@ func NewSchorrMessage(a1 *big.Int, a2 *big.Int, R *pt.ECPoint) (*SchnorrProofMes
 	}
 
 	// Compute c
-	c, salt, err := utils.HashProtosRejectSampling(fieldOrder, msgG, msgV, msgR, msgAlpha)
+	c, salt, err := utils.HashProtosRejectSampling(fieldOrder, msgG, msgV, msgR, msgAlpha, &any.Any{
+		Value: sessionID,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +144,7 @@ func NewSchorrMessage(a1 *big.Int, a2 *big.Int, R *pt.ECPoint) (*SchnorrProofMes
 		U:     u.Bytes(),
 		T:     t.Bytes(),
 	}
-	err = msg.Verify(R)
+	err = msg.VerifyWithSession(sessionID, R)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +152,11 @@ func NewSchorrMessage(a1 *big.Int, a2 *big.Int, R *pt.ECPoint) (*SchnorrProofMes
 }
 
 func (s *SchnorrProofMessage) Verify(R *pt.ECPoint) error {
+	return s.VerifyWithSession(nil, R)
+}
+
+// VerifyWithSession verifies the proof which is made in the session.
+func (s *SchnorrProofMessage) VerifyWithSession(sessionID []byte, R *pt.ECPoint) error {
 	curve := R.GetCurve()
 	fieldOrder := curve.Params().N
 
@@ -188,7 +207,9 @@ func (s *SchnorrProofMessage) Verify(R *pt.ECPoint) error {
 	}
 
 	// Calculate alpha + c*V
-	c, err := utils.HashProtosToInt(s.Salt, msgG, s.V, msgR, s.Alpha)
+	c, err := utils.HashProtosToInt(s.Salt, msgG, s.V, msgR, s.Alpha, &any.Any{
+		Value: sessionID,
+	})
 	if err != nil {
 		return err
 	}
@@ -52,6 +52,17 @@ var _ = Describe("Schnorr (Sigma protocol)", func() {
 		Entry("Curve: S256", btcec.S256()),
 	)
 
+	DescribeTable("NewBaseSchorrMessageWithSession", func(curve elliptic.Curve) {
+		p, err := NewBaseSchorrMessageWithSession([]byte("session-1"), curve, a1)
+		Expect(err).Should(BeNil())
+		Expect(p.VerifyWithSession([]byte("session-1"), pt.NewBase(curve))).Should(BeNil())
+		Expect(p.VerifyWithSession([]byte("session-2"), pt.NewBase(curve))).ShouldNot(BeNil())
+		Expect(p.Verify(pt.NewBase(curve))).ShouldNot(BeNil())
+	},
+		Entry("Curve: P256", elliptic.P256()),
+		Entry("Curve: S256", btcec.S256()),
+	)
+
 	Context("NewSchorrMessage", func() {
 		It("invalid point message", func() {
 			p, err := NewSchorrMessage(a1, a2, &pt.ECPoint{})
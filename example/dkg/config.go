@@ -25,6 +25,7 @@ import (
 
 type DKGConfig struct {
 	Port      int64   `yaml:"port"`
+	Session   string  `yaml:"session"`
 	Rank      uint32  `yaml:"rank"`
 	Threshold uint32  `yaml:"threshold"`
 	Peers     []int64 `yaml:"peers"`
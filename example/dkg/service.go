@@ -17,7 +17,9 @@ import (
 	"io/ioutil"
 
 	"github.com/getamis/alice/crypto/tss/dkg"
+	"github.com/getamis/alice/crypto/tss/message"
 	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/example/peer"
 	"github.com/getamis/alice/example/utils"
 	"github.com/getamis/sirius/log"
 	"github.com/gogo/protobuf/proto"
@@ -28,8 +30,9 @@ type service struct {
 	config *DKGConfig
 	pm     types.PeerManager
 
-	dkg  *dkg.DKG
-	done chan struct{}
+	dkg      *dkg.DKG
+	receiver *message.EncryptedReceiver
+	done     chan struct{}
 }
 
 func NewService(config *DKGConfig, pm types.PeerManager) (*service, error) {
@@ -40,17 +43,24 @@ func NewService(config *DKGConfig, pm types.PeerManager) (*service, error) {
 	}
 
 	// Create dkg
-	d, err := dkg.NewDKG(utils.GetCurve(), pm, config.Threshold, config.Rank, s)
+	d, err := dkg.NewDKG(utils.GetCurve(), pm, []byte(config.Session), config.Threshold, config.Rank, s)
 	if err != nil {
 		log.Warn("Cannot create a new DKG", "config", config, "err", err)
 		return nil, err
 	}
 	s.dkg = d
+
+	// Decrypt the messages from peers
+	s.receiver, err = peer.NewEncryptedReceiver(d, config.Port, config.Peers, []byte(config.Session))
+	if err != nil {
+		log.Warn("Cannot create a new receiver", "err", err)
+		return nil, err
+	}
 	return s, nil
 }
 
 func (p *service) Handle(s network.Stream) {
-	data := &dkg.Message{}
+	data := &message.EncryptedMessage{}
 	buf, err := ioutil.ReadAll(s)
 	if err != nil {
 		log.Warn("Cannot read data from stream", "err", err)
@@ -66,7 +76,7 @@ func (p *service) Handle(s network.Stream) {
 	}
 
 	log.Info("Received request", "from", s.Conn().RemotePeer())
-	err = p.dkg.AddMessage(data)
+	err = p.receiver.AddMessage(data)
 	if err != nil {
 		log.Warn("Cannot add message to DKG", "err", err)
 		return
@@ -0,0 +1,65 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package peer
+
+import (
+	"math/rand"
+
+	"github.com/getamis/alice/crypto/tss/message"
+	"github.com/getamis/alice/crypto/tss/message/types"
+	"github.com/getamis/alice/example/utils"
+)
+
+// NewEncryptedPeerManager wraps the peer manager to encrypt all the messages end-to-end.
+func NewEncryptedPeerManager(pm types.PeerManager, port int64, peerPorts []int64, session []byte) (*message.EncryptedPeerManager, error) {
+	privateKey, publicKeys, err := getEncryptionKeys(port, peerPorts)
+	if err != nil {
+		return nil, err
+	}
+	return message.NewEncryptedPeerManager(pm, session, privateKey, publicKeys)
+}
+
+// NewEncryptedReceiver news a receiver which decrypts the messages from the peers and adds them to the process.
+func NewEncryptedReceiver(adder message.MessageAdder, port int64, peerPorts []int64, session []byte) (*message.EncryptedReceiver, error) {
+	privateKey, publicKeys, err := getEncryptionKeys(port, peerPorts)
+	if err != nil {
+		return nil, err
+	}
+	return message.NewEncryptedReceiver(adder, utils.GetPeerIDFromPort(port), session, privateKey, publicKeys)
+}
+
+// getEncryptionKeys gets the encryption key of the port and the public encryption keys of the peers.
+func getEncryptionKeys(port int64, peerPorts []int64) ([]byte, map[string][]byte, error) {
+	privateKey, _, err := generateEncryptionKey(port)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKeys := make(map[string][]byte, len(peerPorts))
+	for _, peerPort := range peerPorts {
+		_, publicKey, err := generateEncryptionKey(peerPort)
+		if err != nil {
+			return nil, nil, err
+		}
+		publicKeys[utils.GetPeerIDFromPort(peerPort)] = publicKey
+	}
+	return privateKey, publicKeys, nil
+}
+
+// generateEncryptionKey generates a fixed encryption key pair by using port as random source.
+func generateEncryptionKey(port int64) ([]byte, []byte, error) {
+	// Use the negative port as the randomness source in this example, so it's different from the identity.
+	// In practice, the keys should be generated by crypto/rand and the public keys are exchanged beforehand.
+	r := rand.New(rand.NewSource(-port))
+	return message.GenerateEncryptionKey(r)
+}
@@ -49,6 +49,14 @@ func (p *peerManager) SelfID() string {
 	return p.id
 }
 
+func (p *peerManager) PeerIDs() []string {
+	ids := make([]string, 0, len(p.peers))
+	for id := range p.peers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 func (p *peerManager) MustSend(peerID string, message proto.Message) {
 	send(context.Background(), p.host, p.peers[peerID], message, p.protocol)
 }
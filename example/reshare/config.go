@@ -25,6 +25,7 @@ import (
 
 type ReshareConfig struct {
 	Port      int64                `yaml:"port"`
+	Session   string               `yaml:"session"`
 	Threshold uint32               `yaml:"threshold"`
 	Share     string               `yaml:"share"`
 	Pubkey    config.Pubkey        `yaml:"pubkey"`
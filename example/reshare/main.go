@@ -54,8 +54,14 @@ var Cmd = &cobra.Command{
 			log.Crit("Failed to add peers", "err", err)
 		}
 
+		// Encrypt the messages to peers.
+		epm, err := peer.NewEncryptedPeerManager(pm, c.Port, c.Peers, []byte(c.Session))
+		if err != nil {
+			log.Crit("Failed to new encrypted peer manager", "err", err)
+		}
+
 		// Create a new service.
-		service, err := NewService(c, pm)
+		service, err := NewService(c, epm)
 		if err != nil {
 			log.Crit("Failed to new service", "err", err)
 		}
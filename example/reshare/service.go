@@ -16,8 +16,10 @@ package reshare
 import (
 	"io/ioutil"
 
+	"github.com/getamis/alice/crypto/tss/message"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/reshare"
+	"github.com/getamis/alice/example/peer"
 	"github.com/getamis/alice/example/utils"
 	"github.com/getamis/sirius/log"
 	"github.com/gogo/protobuf/proto"
@@ -28,8 +30,9 @@ type service struct {
 	config *ReshareConfig
 	pm     types.PeerManager
 
-	reshare *reshare.Reshare
-	done    chan struct{}
+	reshare  *reshare.Reshare
+	receiver *message.EncryptedReceiver
+	done     chan struct{}
 }
 
 func NewService(config *ReshareConfig, pm types.PeerManager) (*service, error) {
@@ -47,17 +50,24 @@ func NewService(config *ReshareConfig, pm types.PeerManager) (*service, error) {
 	}
 
 	// Create reshare
-	reshare, err := reshare.NewReshare(pm, config.Threshold, dkgResult.PublicKey, dkgResult.Share, dkgResult.Bks, s)
+	reshare, err := reshare.NewReshare(pm, []byte(config.Session), config.Threshold, dkgResult.PublicKey, dkgResult.Share, dkgResult.Bks, s)
 	if err != nil {
 		log.Warn("Cannot create a new reshare", "err", err)
 		return nil, err
 	}
 	s.reshare = reshare
+
+	// Decrypt the messages from peers
+	s.receiver, err = peer.NewEncryptedReceiver(reshare, config.Port, config.Peers, []byte(config.Session))
+	if err != nil {
+		log.Warn("Cannot create a new receiver", "err", err)
+		return nil, err
+	}
 	return s, nil
 }
 
 func (p *service) Handle(s network.Stream) {
-	data := &reshare.Message{}
+	data := &message.EncryptedMessage{}
 	buf, err := ioutil.ReadAll(s)
 	if err != nil {
 		log.Warn("Cannot read data from stream", "err", err)
@@ -73,7 +83,7 @@ func (p *service) Handle(s network.Stream) {
 	}
 
 	log.Info("Received request", "from", s.Conn().RemotePeer())
-	err = p.reshare.AddMessage(data)
+	err = p.receiver.AddMessage(data)
 	if err != nil {
 		log.Warn("Cannot add message to reshare", "err", err)
 		return
@@ -24,17 +24,20 @@ import (
 )
 
 type SignerConfig struct {
-	Port    int64                `yaml:"port"`
-	Share   string               `yaml:"share"`
-	Pubkey  config.Pubkey        `yaml:"pubkey"`
-	BKs     map[string]config.BK `yaml:"bks"`
-	Message string               `yaml:"msg"`
-	Peers   []int64              `yaml:"peers"`
+	Port      int64                `yaml:"port"`
+	Session   string               `yaml:"session"`
+	Threshold uint32               `yaml:"threshold"`
+	Share     string               `yaml:"share"`
+	Pubkey    config.Pubkey        `yaml:"pubkey"`
+	BKs       map[string]config.BK `yaml:"bks"`
+	Message   string               `yaml:"msg"`
+	Peers     []int64              `yaml:"peers"`
 }
 
 type SignerResult struct {
 	R string `yaml:"r"`
 	S string `yaml:"s"`
+	V uint8  `yaml:"v"`
 }
 
 func readSignerConfigFile(filaPath string) (*SignerConfig, error) {
@@ -55,6 +58,7 @@ func writeSignerResult(id string, result *signer.Result) error {
 	signerResult := &SignerResult{
 		R: result.R.String(),
 		S: result.S.String(),
+		V: result.RecoveryID,
 	}
 	err := config.WriteYamlFile(signerResult, getFilePath(id))
 	if err != nil {
@@ -19,8 +19,10 @@ import (
 	//"github.com/getamis/alice/crypto/homo/paillier"
 
 	"github.com/getamis/alice/crypto/homo/paillier"
+	"github.com/getamis/alice/crypto/tss/message"
 	"github.com/getamis/alice/crypto/tss/message/types"
 	"github.com/getamis/alice/crypto/tss/signer"
+	"github.com/getamis/alice/example/peer"
 	"github.com/getamis/alice/example/utils"
 	"github.com/getamis/sirius/log"
 	"github.com/gogo/protobuf/proto"
@@ -31,8 +33,9 @@ type service struct {
 	config *SignerConfig
 	pm     types.PeerManager
 
-	signer *signer.Signer
-	done   chan struct{}
+	signer   *signer.Signer
+	receiver *message.EncryptedReceiver
+	done     chan struct{}
 }
 
 func NewService(config *SignerConfig, pm types.PeerManager) (*service, error) {
@@ -65,17 +68,28 @@ func NewService(config *SignerConfig, pm types.PeerManager) (*service, error) {
 		}
 	*/
 	// Create signer
-	signer, err := signer.NewSigner(pm, dkgResult.PublicKey, paillier, dkgResult.Share, dkgResult.Bks, []byte(config.Message), s)
+	signerIDs := []string{utils.GetPeerIDFromPort(config.Port)}
+	for _, peerPort := range config.Peers {
+		signerIDs = append(signerIDs, utils.GetPeerIDFromPort(peerPort))
+	}
+	signer, err := signer.NewSigner(pm, []byte(config.Session), dkgResult.PublicKey, paillier, dkgResult.Share, config.Threshold, dkgResult.Bks, signerIDs, []byte(config.Message), s)
 	if err != nil {
 		log.Warn("Cannot create a new signer", "err", err)
 		return nil, err
 	}
 	s.signer = signer
+
+	// Decrypt the messages from peers
+	s.receiver, err = peer.NewEncryptedReceiver(signer, config.Port, config.Peers, []byte(config.Session))
+	if err != nil {
+		log.Warn("Cannot create a new receiver", "err", err)
+		return nil, err
+	}
 	return s, nil
 }
 
 func (p *service) Handle(s network.Stream) {
-	data := &signer.Message{}
+	data := &message.EncryptedMessage{}
 	buf, err := ioutil.ReadAll(s)
 	if err != nil {
 		log.Warn("Cannot read data from stream", "err", err)
@@ -91,7 +105,7 @@ func (p *service) Handle(s network.Stream) {
 	}
 
 	log.Info("Received request", "from", s.Conn().RemotePeer())
-	err = p.signer.AddMessage(data)
+	err = p.receiver.AddMessage(data)
 	if err != nil {
 		log.Warn("Cannot add message to signer", "err", err)
 		return